@@ -0,0 +1,183 @@
+// Package cloudslash is the supported library API for embedding CloudSlash.
+//
+// Analyze runs a full scan and returns findings as plain values. Everything
+// under pkg/ is internal to the CLI and may change between minor releases;
+// the types in this package follow semantic versioning.
+//
+//	res, err := cloudslash.Analyze(ctx, cloudslash.Options{Region: "us-east-1"})
+//	if err != nil {
+//		return err
+//	}
+//	for _, f := range res.Findings {
+//		fmt.Println(f.ResourceID, f.MonthlyCost)
+//	}
+//
+// Analyze does not replace the global slog logger or OpenTelemetry providers.
+package cloudslash
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/report"
+)
+
+// Options configures a library scan.
+type Options struct {
+	// Region to scan. Defaults to the AWS SDK's resolved region.
+	Region string
+
+	// AllProfiles scans every profile in the shared AWS config.
+	AllProfiles bool
+
+	// AssumeRolesFile lists role ARNs or account IDs to scan by assuming
+	// each role. Org scans every active account in the organization instead.
+	// OrgRole is the role assumed for account IDs and Org; empty means
+	// OrganizationAccountAccessRole.
+	AssumeRolesFile string
+	Org             bool
+	OrgRole         string
+
+	// RequiredTags flags resources missing any of these tag keys.
+	RequiredTags []string
+
+	// RulesFile is an optional policy rules file.
+	RulesFile string
+
+	// TFStatePath enables Terraform-aware reporting.
+	TFStatePath string
+
+	// DiscountRate applies an EDP/RI rate to list prices (e.g. 0.82).
+	DiscountRate float64
+
+	// Concurrency caps parallel API calls. Zero uses the engine default.
+	Concurrency int
+
+	// OutputDir keeps the generated report artifacts. When empty they are
+	// written to a temporary directory and removed after the scan.
+	OutputDir string
+
+	// Logger receives engine logs. Nil discards them.
+	Logger *slog.Logger
+
+	// Mock runs against synthetic data without AWS credentials.
+	Mock bool
+}
+
+// Finding is a single waste item.
+type Finding struct {
+	ResourceID  string  `json:"resource_id"`
+	Type        string  `json:"type"`
+	Region      string  `json:"region"`
+	AccountID   string  `json:"account_id,omitempty"`
+	Name        string  `json:"name,omitempty"`
+	MonthlyCost float64 `json:"monthly_cost"`
+	RiskScore   int     `json:"risk_score"`
+	Reason      string  `json:"reason"`
+	Owner       string  `json:"owner,omitempty"`
+	Action      string  `json:"action"` // DELETE, REVIEW, REVIEW_IAC or JUSTIFIED
+}
+
+// Summary aggregates a scan.
+type Summary struct {
+	Region           string   `json:"region"`
+	ResourcesScanned int      `json:"resources_scanned"`
+	WasteCount       int      `json:"waste_count"`
+	MonthlySavings   float64  `json:"monthly_savings"`
+	Partial          bool     `json:"partial"`
+	FailedScopes     []string `json:"failed_scopes,omitempty"`
+}
+
+// Result is the outcome of Analyze.
+type Result struct {
+	Findings []Finding `json:"findings"` // Most expensive first.
+	Summary  Summary   `json:"summary"`
+}
+
+// Analyze scans the account and returns its findings.
+// A scan that skipped some scopes still succeeds; check Summary.Partial.
+func Analyze(ctx context.Context, opts Options) (*Result, error) {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+
+	outputDir := opts.OutputDir
+	if outputDir == "" {
+		dir, err := os.MkdirTemp("", "cloudslash-*")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create output directory: %w", err)
+		}
+		defer os.RemoveAll(dir)
+		outputDir = dir
+	}
+
+	cfg := engine.Config{
+		Region:          opts.Region,
+		MockMode:        opts.Mock,
+		AllProfiles:     opts.AllProfiles,
+		AssumeRolesFile: opts.AssumeRolesFile,
+		Org:             opts.Org,
+		OrgRole:         opts.OrgRole,
+		RequiredTags:    strings.Join(opts.RequiredTags, ","),
+		RulesFile:       opts.RulesFile,
+		TFStatePath:     opts.TFStatePath,
+		DiscountRate:    opts.DiscountRate,
+		MaxConcurrency:  opts.Concurrency,
+		OutputDir:       outputDir,
+		Headless:        true,
+		SkipTelemetry:   true,
+		Logger:          logger,
+	}
+
+	eng, err := engine.New(ctx,
+		engine.WithEmbedded(),
+		engine.WithLogger(logger),
+		engine.WithConfig(cfg),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	_, g, _, err := eng.Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Result{}
+	for _, item := range report.Findings(g) {
+		res.Findings = append(res.Findings, Finding{
+			ResourceID:  item.ResourceID,
+			Type:        item.Type,
+			Region:      item.Region,
+			AccountID:   item.AccountID,
+			Name:        item.NameTag,
+			MonthlyCost: item.MonthlyCost,
+			RiskScore:   item.RiskScore,
+			Reason:      item.AuditDetail,
+			Owner:       item.OwnerARN,
+			Action:      item.Action,
+		})
+	}
+
+	s := report.Summarize(g, opts.Region)
+	res.Summary = Summary{
+		Region:           s.Region,
+		ResourcesScanned: s.TotalScanned,
+		WasteCount:       s.TotalWaste,
+		MonthlySavings:   s.TotalSavings,
+	}
+
+	g.Mu.RLock()
+	res.Summary.Partial = g.Metadata.Partial
+	for _, f := range g.Metadata.FailedScopes {
+		res.Summary.FailedScopes = append(res.Summary.FailedScopes, f.Scope)
+	}
+	g.Mu.RUnlock()
+
+	return res, nil
+}
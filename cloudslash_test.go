@@ -0,0 +1,32 @@
+package cloudslash
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+)
+
+func TestAnalyzeMock(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	before := slog.Default()
+	res, err := Analyze(context.Background(), Options{Region: "us-east-1", Mock: true})
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	if slog.Default() != before {
+		t.Error("Analyze must not replace the default logger")
+	}
+	if res.Summary.ResourcesScanned == 0 {
+		t.Error("Expected mock resources to be scanned")
+	}
+	if len(res.Findings) != res.Summary.WasteCount {
+		t.Errorf("Findings (%d) disagree with summary (%d)", len(res.Findings), res.Summary.WasteCount)
+	}
+	for i := 1; i < len(res.Findings); i++ {
+		if res.Findings[i].MonthlyCost > res.Findings[i-1].MonthlyCost {
+			t.Fatal("Findings should be sorted by cost, descending")
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/remediation"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/report"
+	"github.com/spf13/cobra"
+)
+
+var (
+	applyPlanPath string
+	applyOutDir   string
+)
+
+var applyCmd = &cobra.Command{
+	Use:   "apply --plan approved_report.json",
+	Short: "Generate remediation scripts for an approved set of findings",
+	Long: `Second half of an approve-then-apply workflow.
+
+Run a scan, commit waste_report.json for review, and delete the entries
+reviewers reject. 'apply' then rebuilds the approved findings and generates
+remediation artifacts for exactly those resources, without rescanning:
+
+  remediation_plan.json / remediation_plan.sh   Safe cleanup steps
+  restoration_plan.json                         Rollback steps
+
+JUSTIFIED findings are skipped. Nothing is executed; review the generated
+script before running it.
+
+Example:
+  cloudslash apply --plan approved_report.json
+  cloudslash apply --plan approved_report.json --out cloudslash-out/approved`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		items, err := report.LoadFindings(applyPlanPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		outDir := applyOutDir
+		if outDir == "" {
+			outDir = filepath.Join(config.OutputDir, "approved")
+		}
+		if err := os.MkdirAll(outDir, 0755); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to create %s: %v\n", outDir, err)
+			os.Exit(1)
+		}
+
+		g, err := report.GraphFromFindings(items)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		approved := len(report.Findings(g))
+		if approved == 0 {
+			fmt.Println("No approved findings. Nothing to generate.")
+			return
+		}
+
+		gen := remediation.NewGenerator(g, config.Logger)
+		planPath := filepath.Join(outDir, "remediation_plan.json")
+		if err := gen.GenerateRemediationPlan(planPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to generate remediation plan: %v\n", err)
+			os.Exit(1)
+		}
+		if err := gen.GenerateRestorationPlan(filepath.Join(outDir, "restoration_plan.json")); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to generate restoration plan: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Generated remediation for %d approved findings (%d in report).\n", approved, len(items))
+		fmt.Printf("   Plan:     %s\n", planPath)
+		fmt.Printf("   Script:   %s\n", filepath.Join(outDir, "remediation_plan.sh"))
+		fmt.Printf("   Rollback: %s\n", filepath.Join(outDir, "restoration_plan.json"))
+	},
+}
+
+func init() {
+	applyCmd.Flags().StringVar(&applyPlanPath, "plan", "", "Approved report (waste_report.json schema)")
+	applyCmd.Flags().StringVar(&applyOutDir, "out", "", "Output directory (default <output-dir>/approved)")
+	applyCmd.MarkFlagRequired("plan")
+	rootCmd.AddCommand(applyCmd)
+}
@@ -0,0 +1,202 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/remediation"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/spf13/cobra"
+)
+
+var (
+	applyIgnoreDryRun  bool
+	applyIgnoreConfirm bool
+)
+
+// tagResult is the outcome of tagging one ignore plan resource.
+type tagResult struct {
+	ID     string            `json:"id"`
+	Type   string            `json:"type"`
+	ARN    string            `json:"arn"`
+	Tags   map[string]string `json:"tags"`
+	Status string            `json:"status"` // "planned", "tagged", "skipped" or "failed"
+	Error  string            `json:"error,omitempty"`
+}
+
+var applyIgnoreCmd = &cobra.Command{
+	Use:   "apply-ignore [ignore_plan.json]",
+	Short: "Apply cloudslash:ignore tags from an ignore plan",
+	Long: `Tags every resource in an ignore plan through the Resource Groups Tagging API,
+without the generated shell script or the AWS CLI.
+
+Runs as a dry run by default, listing the tags that would be applied.
+Pass --confirm to tag the resources. Exits with code 1 if any resource
+could not be tagged.
+
+Example:
+  cloudslash apply-ignore
+  cloudslash apply-ignore cloudslash-out/ignore_plan.json --confirm`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		planPath := filepath.Join(config.OutputDir, "ignore_plan.json")
+		if len(args) == 1 {
+			planPath = args[0]
+		}
+		// --confirm executes unless a dry run was asked for explicitly.
+		dryRun := applyIgnoreDryRun
+		if applyIgnoreConfirm && !cmd.Flags().Changed("dry-run") {
+			dryRun = false
+		}
+
+		plan, err := remediation.ValidatePlan(planPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		targets := remediation.IgnoreTargets(plan)
+		if len(targets) == 0 {
+			fmt.Println("Ignore plan is empty. Nothing to tag.")
+			return
+		}
+
+		ctx := context.Background()
+		var results, skipped []tagResult
+		var arns []string
+		for _, t := range targets {
+			if t.Skipped != "" {
+				skipped = append(skipped, tagResult{ID: t.ID, Type: t.Type, ARN: t.ARN, Tags: t.Tags, Status: "skipped", Error: t.Skipped})
+				continue
+			}
+			results = append(results, tagResult{ID: t.ID, Type: t.Type, ARN: t.ARN, Tags: t.Tags, Status: "planned"})
+			arns = append(arns, t.ARN)
+		}
+		if len(arns) > 0 {
+			client, resolved, _, err := resolvePlanARNs(ctx, arns)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			for i := range results {
+				results[i].ARN = resolved[i]
+			}
+			if !dryRun {
+				applyIgnoreTags(ctx, client, results)
+			}
+		}
+		results = append(results, skipped...)
+		sort.Slice(results, func(i, j int) bool { return results[i].ARN < results[j].ARN })
+
+		failed, skips := 0, 0
+		for _, r := range results {
+			switch r.Status {
+			case "failed":
+				failed++
+			case "skipped":
+				skips++
+			}
+		}
+		if config.JsonLogs {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(results); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		} else {
+			printTagResults(results)
+			if dryRun {
+				fmt.Printf("\nDry run: %d resources would be tagged, %d skipped. Re-run with --confirm to apply.\n", len(results)-skips, skips)
+			} else {
+				fmt.Printf("\nTagged %d of %d resources, %d failed, %d skipped.\n", len(results)-failed-skips, len(results), failed, skips)
+				fmt.Println("Run 'cloudslash verify-ignore' to confirm the tags stuck.")
+			}
+		}
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// applyIgnoreTags tags each resource, one TagResources batch per region and
+// tag set, and records the outcome in results.
+func applyIgnoreTags(ctx context.Context, client *aws.Client, results []tagResult) {
+	type group struct {
+		region string
+		tags   map[string]string
+		index  []int
+	}
+	groups := make(map[string]*group)
+	var order []string
+	for i, r := range results {
+		region := client.Config.Region
+		if parsed, err := arn.Parse(r.ARN); err == nil && parsed.Region != "" {
+			region = parsed.Region
+		}
+		key := region + "|" + tagSetKey(r.Tags)
+		g, ok := groups[key]
+		if !ok {
+			g = &group{region: region, tags: r.Tags}
+			groups[key] = g
+			order = append(order, key)
+		}
+		g.index = append(g.index, i)
+	}
+
+	for _, key := range order {
+		g := groups[key]
+		arns := make([]string, len(g.index))
+		for j, i := range g.index {
+			arns[j] = results[i].ARN
+		}
+		failed, err := aws.NewTaggingClient(client.GetConfigForRegion(g.region)).TagResources(ctx, arns, g.tags)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: tagging in %s: %v\n", g.region, err)
+		}
+		for _, i := range g.index {
+			if msg, ok := failed[results[i].ARN]; ok {
+				results[i].Status, results[i].Error = "failed", msg
+				continue
+			}
+			results[i].Status = "tagged"
+		}
+	}
+}
+
+// tagSetKey identifies a tag set independently of map order.
+func tagSetKey(tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for k, v := range tags {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func printTagResults(results []tagResult) {
+	for _, r := range results {
+		tags := tagSetKey(r.Tags)
+		switch r.Status {
+		case "tagged":
+			fmt.Printf("%s %s (%s): tagged %s\n", glyph("✅", "[OK]"), r.ID, r.Type, tags)
+		case "failed":
+			fmt.Printf("%s %s (%s): %s\n", glyph("❌", "[FAIL]"), r.ID, r.Type, r.Error)
+		case "skipped":
+			fmt.Printf("%s %s (%s): skipped, %s\n", glyph("⚠️ ", "[SKIP]"), r.ID, r.Type, r.Error)
+		default:
+			fmt.Printf("%s %s (%s): would tag %s\n", glyph("•", "[PLAN]"), r.ID, r.Type, tags)
+		}
+	}
+}
+
+func init() {
+	applyIgnoreCmd.Flags().BoolVar(&applyIgnoreDryRun, "dry-run", true, "List the tags that would be applied without calling AWS")
+	applyIgnoreCmd.Flags().BoolVar(&applyIgnoreConfirm, "confirm", false, "Apply the tags")
+	rootCmd.AddCommand(applyIgnoreCmd)
+}
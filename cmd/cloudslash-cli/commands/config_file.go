@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// configKeys lists the keys accepted in the config file and the flag that
+// overrides each one ("" when there is no flag). Keys inside a section are
+// dotted (risk.weights.spot).
+// Precedence: CLI flags > CLOUDSLASH_* env vars > config file > defaults.
+var configKeys = map[string]string{
+	"region":              "region",
+	"tfstate":             "tfstate",
+	"iac":                 "iac",
+	"pulumi_state":        "pulumi-state",
+	"all_profiles":        "all-profiles",
+	"required_tags":       "required-tags",
+	"slack_webhook":       "slack-webhook",
+	"teams_webhook":       "teams-webhook",
+	"discord_webhook":     "discord-webhook",
+	"datadog_api_key":     "datadog-api-key",
+	"datadog_site":        "datadog-site",
+	"verbose":             "verbose",
+	"json_logs":           "json",
+	"no_metrics":          "no-metrics",
+	"rules_file":          "rules",
+	"budget":              "budget",
+	"history_url":         "history-url",
+	"output_dir":          "output-dir",
+	"otel_endpoint":       "otel-endpoint",
+	"no_color":            "no-color",
+	"ci":                  "ci",
+	"max_workers":         "max-workers",
+	"discount_rate":       "",
+	"disabled_heuristics": "disable",
+	"metric_window":       "metric-window",
+	"critical_threshold":  "",
+	"review_threshold":    "",
+
+	// Risk engine (pkg/config.RiskConfig), read by loadRiskConfig.
+	"risk.baseline_risk":        "",
+	"risk.decay_factor":         "",
+	"risk.interruption_penalty": "",
+	"risk.weights.statefulness": "",
+	"risk.weights.cross_az":     "",
+	"risk.weights.spot":         "",
+	"risk.weights.arch_change":  "",
+	"risk.weights.production":   "",
+}
+
+// configFileCandidates are searched in order when --config is not given.
+// cloudslash.yaml is the original name and is still accepted.
+func configFileCandidates() []string {
+	candidates := []string{".cloudslash.yaml", "cloudslash.yaml"}
+	if home, err := os.UserHomeDir(); err == nil {
+		dir := filepath.Join(home, ".cloudslash")
+		candidates = append(candidates, filepath.Join(dir, ".cloudslash.yaml"), filepath.Join(dir, "cloudslash.yaml"))
+	}
+	return candidates
+}
+
+// findConfigFile returns the first existing candidate, or "".
+func findConfigFile() string {
+	for _, path := range configFileCandidates() {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
+// validateConfigFile rejects keys CloudSlash does not know, so a typo is not silently ignored.
+func validateConfigFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %v", err)
+	}
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %v", path, err)
+	}
+
+	unknown := unknownConfigKeys(raw, "")
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+
+	known := make([]string, 0, len(configKeys))
+	for key := range configKeys {
+		known = append(known, key)
+	}
+	sort.Strings(known)
+	return fmt.Errorf("unknown key(s) in %s: %s (valid keys: %s)", path, strings.Join(unknown, ", "), strings.Join(known, ", "))
+}
+
+// unknownConfigKeys returns the keys of raw missing from configKeys. A map is
+// a section, and its keys are checked as prefix.key.
+func unknownConfigKeys(raw map[string]interface{}, prefix string) []string {
+	var unknown []string
+	for key, value := range raw {
+		key = prefix + key
+		if _, ok := configKeys[key]; ok {
+			continue
+		}
+		if section, ok := value.(map[string]interface{}); ok && isConfigSection(key) {
+			unknown = append(unknown, unknownConfigKeys(section, key+".")...)
+			continue
+		}
+		unknown = append(unknown, key)
+	}
+	return unknown
+}
+
+// isConfigSection reports whether key holds nested config keys.
+func isConfigSection(key string) bool {
+	for known := range configKeys {
+		if strings.HasPrefix(known, key+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// bindConfigFlags binds each config key to its flag in flags. It is called again
+// with the running command's flags because subcommands may redefine a root flag
+// locally (scan --rules), and only that copy records whether the user set it.
+func bindConfigFlags(flags *pflag.FlagSet) {
+	for key, flag := range configKeys {
+		if flag == "" {
+			continue
+		}
+		if f := flags.Lookup(flag); f != nil {
+			viper.BindPFlag(key, f)
+		}
+	}
+}
+
+// listValue reads a key that may be a YAML list or a comma-separated string.
+func listValue(key string) string {
+	switch v := viper.Get(key).(type) {
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			parts = append(parts, strings.TrimSpace(fmt.Sprint(item)))
+		}
+		return strings.Join(parts, ",")
+	case []string:
+		return strings.Join(v, ",")
+	}
+	return viper.GetString(key)
+}
+
+// splitList splits a comma-separated list, dropping empty entries.
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func writeConfigFile(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".cloudslash.yaml")
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestValidateConfigFileAcceptsRiskSection(t *testing.T) {
+	path := writeConfigFile(t, "region: us-west-2\nrisk:\n  baseline_risk: 0.1\n  weights:\n    spot: 0.4\n")
+	if err := validateConfigFile(path); err != nil {
+		t.Fatalf("validateConfigFile rejected the risk section: %v", err)
+	}
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.SetConfigFile(path)
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatalf("failed to read config file: %v", err)
+	}
+	cfg := loadRiskConfig()
+	if cfg.Weights.Spot != 0.4 || cfg.BaselineRisk != 0.1 {
+		t.Errorf("risk config = %+v, want spot 0.4 and baseline 0.1", cfg)
+	}
+	// Keys the file leaves out keep their defaults.
+	if cfg.Weights.Statefulness != 0.25 {
+		t.Errorf("Statefulness = %.2f, want the 0.25 default", cfg.Weights.Statefulness)
+	}
+}
+
+func TestValidateConfigFileRejectsUnknownKeys(t *testing.T) {
+	path := writeConfigFile(t, "regoin: us-west-2\nrisk:\n  weights:\n    spott: 0.4\n")
+	err := validateConfigFile(path)
+	if err == nil {
+		t.Fatal("Expected unknown keys to be rejected")
+	}
+	if msg := err.Error(); !strings.Contains(msg, "unknown key(s) in "+path+": regoin, risk.weights.spott") {
+		t.Errorf("error = %q", msg)
+	}
+}
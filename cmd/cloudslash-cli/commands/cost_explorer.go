@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/report"
+	"github.com/spf13/cobra"
+)
+
+var costExplorerCmd = &cobra.Command{
+	Use:   "cost-explorer",
+	Short: "Compare estimated waste with the cost actually billed",
+	Long: `Run a scan, then reconcile every finding with Cost Explorer.
+
+Findings take the cost Cost Explorer billed over the last 14 days, scaled to
+a month, instead of the list-price estimate. Findings without resource-level
+billing data keep the estimate. Resource-level data must be enabled in the
+Cost Explorer preferences; run from the management account to cover every
+linked account.
+
+The reports gain EstimatedCost and BilledCost columns.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println("Initializing Cost Reconciliation...")
+		config.Headless = true
+		config.CostExplorer = true
+		// Keep warnings visible: a missing opt-in silently leaves every estimate in place.
+		level := slog.LevelWarn
+		if config.Verbose {
+			level = slog.LevelDebug
+		}
+		if config.Logger == nil {
+			config.Logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+		}
+		eng, err := engine.New(cmd.Context(),
+			engine.WithLogger(config.Logger),
+			engine.WithConfig(config),
+			engine.WithConcurrency(config.MaxConcurrency),
+		)
+		if err != nil {
+			fmt.Printf("\n[ERROR] Reconciliation Failed (Init): %v\n", err)
+			return
+		}
+		_, g, _, err := eng.Run(cmd.Context())
+		if err != nil {
+			fmt.Printf("\n[ERROR] Reconciliation Failed: %v\n", err)
+			return
+		}
+		printCostReconciliation(report.Findings(g))
+	},
+}
+
+func printCostReconciliation(items []report.ExportItem) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RESOURCE\tTYPE\tESTIMATED\tBILLED\tDELTA")
+	var estimated, billed float64
+	reconciled := 0
+	for _, item := range items {
+		if item.BilledCost == nil {
+			fmt.Fprintf(w, "%s\t%s\t$%.2f\t-\t-\n", item.ResourceID, item.Type, item.EstimatedCost)
+			continue
+		}
+		reconciled++
+		estimated += item.EstimatedCost
+		billed += *item.BilledCost
+		fmt.Fprintf(w, "%s\t%s\t$%.2f\t$%.2f\t%+.2f\n", item.ResourceID, item.Type, item.EstimatedCost, *item.BilledCost, *item.BilledCost-item.EstimatedCost)
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d of %d findings reconciled with billed cost.\n", reconciled, len(items))
+	if reconciled > 0 {
+		fmt.Printf("Estimated $%.2f/mo, billed $%.2f/mo (%+.2f).\n", estimated, billed, billed-estimated)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(costExplorerCmd)
+}
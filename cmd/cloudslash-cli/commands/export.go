@@ -2,8 +2,12 @@ package commands
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
 	"github.com/spf13/cobra"
 )
 
@@ -12,8 +16,11 @@ var exportPath string
 
 var ExportCmd = &cobra.Command{
 	Use:   "export",
-	Short: "Export forensic data (CSV, JSON)",
+	Short: "Export forensic data (CSV, JSON, DOT)",
 	Long: `Run a scan and export the results to a specified format.
+
+--format dot also writes the dependency graph as GraphViz DOT (graph.dot):
+    dot -Tsvg cloudslash-out/graph.dot -o graph.svg
     
 Default output directory: ./cloudslash-out/`,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -29,12 +36,23 @@ Default output directory: ./cloudslash-out/`,
 			fmt.Printf("\n[ERROR] Export Failed (Init): %v\n", err)
 			return
 		}
-		_, _, _, err = eng.Run(cmd.Context())
+		_, g, _, err := eng.Run(cmd.Context())
 		if err != nil {
 			fmt.Printf("\n[ERROR] Export Failed: %v\n", err)
 			return
 		}
 
+		if strings.EqualFold(exportFormat, "dot") {
+			path, err := writeDOT(g)
+			if err != nil {
+				fmt.Printf("\n[ERROR] DOT Export Failed: %v\n", err)
+				return
+			}
+			fmt.Println("\n[SUCCESS] Export Complete.")
+			fmt.Printf("   DOT:  %s\n", path)
+			return
+		}
+
 		fmt.Println("\n[SUCCESS] Export Complete.")
 		fmt.Println("   CSV:  ./cloudslash-out/waste_report.csv")
 		fmt.Println("   JSON: ./cloudslash-out/waste_report.json")
@@ -42,5 +60,29 @@ Default output directory: ./cloudslash-out/`,
 	},
 }
 
+// writeDOT writes the dependency graph to graph.dot in the output directory.
+func writeDOT(g *graph.Graph) (string, error) {
+	dir := config.OutputDir
+	if dir == "" || strings.HasPrefix(dir, "s3://") {
+		dir = "cloudslash-out"
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %v", err)
+	}
+
+	path := filepath.Join(dir, "graph.dot")
+	f, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s: %v", path, err)
+	}
+	defer f.Close()
+
+	if err := g.ExportDOT(f); err != nil {
+		return "", fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	return path, nil
+}
+
 func init() {
+	ExportCmd.Flags().StringVar(&exportFormat, "format", "", "Additional export format: dot (GraphViz dependency graph)")
 }
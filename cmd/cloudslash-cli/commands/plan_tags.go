@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+)
+
+// fetchPlanTags reads the current tags of plan resources.
+// Plan ARNs may carry "region"/"account" placeholders; they are resolved against
+// the caller's identity and returned in the same order as arns.
+func fetchPlanTags(ctx context.Context, arns []string) ([]string, map[string]map[string]string, error) {
+	client, resolved, byRegion, err := resolvePlanARNs(ctx, arns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tags := make(map[string]map[string]string)
+	for region, regionARNs := range byRegion {
+		found, err := aws.NewTaggingClient(client.GetConfigForRegion(region)).GetTags(ctx, regionARNs)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %v", region, err)
+		}
+		for a, t := range found {
+			tags[a] = t
+		}
+	}
+	return resolved, tags, nil
+}
+
+// resolvePlanARNs resolves plan ARN placeholders against the caller's identity
+// and groups the results by region, since the tagging API is regional.
+func resolvePlanARNs(ctx context.Context, arns []string) (*aws.Client, []string, map[string][]string, error) {
+	client, err := aws.NewClient(ctx, config.Region, "", config.Verbose)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	account := ""
+	if caller, err := client.CallerARN(ctx); err == nil {
+		if parsed, err := arn.Parse(caller); err == nil {
+			account = parsed.AccountID
+		}
+	}
+
+	resolved := make([]string, len(arns))
+	byRegion := make(map[string][]string)
+	for i, a := range arns {
+		resolved[i] = aws.ResolveARN(a, client.Config.Region, account)
+		region := client.Config.Region
+		if parsed, err := arn.Parse(resolved[i]); err == nil && parsed.Region != "" {
+			region = parsed.Region
+		}
+		byRegion[region] = append(byRegion[region], resolved[i])
+	}
+	return client, resolved, byRegion, nil
+}
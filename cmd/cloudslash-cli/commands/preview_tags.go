@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/remediation"
+	"github.com/spf13/cobra"
+)
+
+var previewTagsCmd = &cobra.Command{
+	Use:   "preview-tags [plan.json...]",
+	Short: "Show the tag changes a plan would make, before applying it",
+	Long: `Reads the current tags of every resource in the ignore and remediation plans
+and shows each resource's tags before and after the plan's tags are applied
+(cloudslash:ignore, CloudSlash:Status=Purgatory, CloudSlash:ExpiryDate).
+
+Warns when a planned tag overwrites an existing value or would push a resource
+past the 50-tag limit. Use --json for machine-readable output.
+
+Example:
+  cloudslash preview-tags
+  cloudslash preview-tags cloudslash-out/ignore_plan.json --json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		paths := args
+		if len(paths) == 0 {
+			for _, name := range []string{"ignore_plan.json", "remediation_plan.json"} {
+				p := filepath.Join(config.OutputDir, name)
+				if _, err := os.Stat(p); err == nil {
+					paths = append(paths, p)
+				}
+			}
+			if len(paths) == 0 {
+				fmt.Fprintf(os.Stderr, "Error: no plans found in %s. Run 'cloudslash scan' first.\n", config.OutputDir)
+				os.Exit(1)
+			}
+		}
+
+		var targets []remediation.TagPreview
+		for _, p := range paths {
+			plan, err := remediation.ValidatePlan(p)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			targets = append(targets, remediation.TagPreviewTargets(plan)...)
+		}
+		if len(targets) == 0 {
+			fmt.Println("No planned tag changes.")
+			return
+		}
+
+		// Bare resource IDs are rejected by the tagging API; PreviewTags reports them.
+		var arns []string
+		var index []int
+		for i, t := range targets {
+			if t.Skipped == "" {
+				arns = append(arns, t.ARN)
+				index = append(index, i)
+			}
+		}
+		var tags map[string]map[string]string
+		if len(arns) > 0 {
+			resolved, current, err := fetchPlanTags(context.Background(), arns)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			for j, i := range index {
+				targets[i].ARN = resolved[j]
+			}
+			tags = current
+		}
+
+		previews := remediation.PreviewTags(targets, tags)
+		sort.Slice(previews, func(i, j int) bool { return previews[i].ARN < previews[j].ARN })
+
+		if config.JsonLogs {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(previews); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+		printTagPreviews(previews)
+	},
+}
+
+func printTagPreviews(previews []remediation.TagPreview) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RESOURCE\tOPERATION\tTAG\tBEFORE\tAFTER\tCHANGE")
+	for _, p := range previews {
+		for _, c := range p.Changes {
+			before := c.Before
+			if before == "" {
+				before = "-"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", p.ID, p.Operation, c.Key, before, c.After, c.Kind)
+		}
+	}
+	w.Flush()
+
+	warned := 0
+	for _, p := range previews {
+		if len(p.Warnings) == 0 {
+			continue
+		}
+		if warned == 0 {
+			fmt.Println()
+		}
+		warned++
+		for _, msg := range p.Warnings {
+			fmt.Printf("%s %s (%s): %s\n", glyph("⚠️ ", "[WARN]"), p.ID, p.Type, msg)
+		}
+	}
+
+	fmt.Printf("\n%d resources, %d with warnings.\n", len(previews), warned)
+}
+
+func init() {
+	rootCmd.AddCommand(previewTagsCmd)
+}
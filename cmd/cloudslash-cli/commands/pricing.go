@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/pricing"
+	"github.com/spf13/cobra"
+)
+
+var pricingWarmRegions []string
+
+var PricingCmd = &cobra.Command{
+	Use:   "pricing",
+	Short: "Manage the local AWS price cache",
+}
+
+var pricingWarmCmd = &cobra.Command{
+	Use:   "warm",
+	Short: "Fetch prices ahead of time for offline scans",
+	Long: `Fetch on-demand prices for every right-sizing candidate instance type, EBS
+volume type and NAT Gateway in each region, and save them to the price cache
+(~/.cloudslash/pricing.json).
+
+Scans in an environment without Pricing API access (air-gapped accounts, or
+credentials lacking pricing:GetProducts) then price resources from the cache.
+Cached prices are refreshed after 15 days; copy pricing.json into the offline
+environment within that window. Elastic IP prices are fixed and need no cache.
+
+Example:
+  cloudslash pricing warm --regions us-east-1,eu-west-1`,
+	Run: func(cmd *cobra.Command, args []string) {
+		regions := pricingWarmRegions
+		if len(regions) == 0 {
+			regions = strings.Split(config.Region, ",")
+		}
+		for i := range regions {
+			regions[i] = strings.TrimSpace(regions[i])
+		}
+
+		if config.Logger == nil {
+			level := slog.LevelWarn
+			if config.Verbose {
+				level = slog.LevelDebug
+			}
+			config.Logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+		}
+		if home, err := os.UserHomeDir(); err == nil {
+			config.CacheDir = filepath.Join(home, ".cloudslash")
+		} else {
+			config.CacheDir = ".cloudslash"
+		}
+		client, err := pricing.NewClient(cmd.Context(), config.Logger, config.CacheDir, config.DiscountRate, os.Getenv("AWS_PROFILE"))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Warming price cache for %s...\n", strings.Join(regions, ", "))
+		result := client.Warm(cmd.Context(), regions, aws.CandidateTypes, 0)
+
+		fmt.Printf("\n%d fetched, %d already cached, %d failed.\n", result.Fetched, result.Cached, result.Failed)
+		fmt.Printf("Price cache: %s\n", filepath.Join(config.CacheDir, "pricing.json"))
+		if result.Failed > 0 {
+			fmt.Printf("%s Some prices could not be fetched; scans estimate them instead. Run with --verbose for details.\n", glyph("⚠️ ", "[WARN]"))
+		}
+		if result.Fetched+result.Cached == 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	pricingWarmCmd.Flags().StringSliceVar(&pricingWarmRegions, "regions", nil, "Regions to fetch prices for (default: --region)")
+
+	PricingCmd.AddCommand(pricingWarmCmd)
+	rootCmd.AddCommand(PricingCmd)
+}
@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/policy"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/spf13/cobra"
+)
+
+var queryFrom string
+
+var queryCmd = &cobra.Command{
+	Use:   "query <expression>",
+	Short: "Filter a saved scan's resources with a CEL expression",
+	Long: `Loads the graph from a waste_report.json written by an earlier scan and
+prints the resources matching a CEL expression, most expensive first. Nothing
+is marked or changed, and AWS is not called.
+
+The expression sees the variables policy rules see (id, kind, cost, tags,
+resource) and also:
+
+  type    Resource type; alias of kind
+  region  Region, empty when unknown
+  waste   Whether the resource was flagged
+  risk    Risk score of the finding
+  reason  Reason for the finding
+  props   Every recorded property
+
+Example:
+  cloudslash query 'type == "AWS::EC2::Volume" && cost > 50 && waste'
+  cloudslash query 'region == "us-east-1" && risk >= 80'
+  cloudslash query '"State" in props && props.State == "available"' --from old/waste_report.json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		q, err := policy.CompileQuery(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		f, err := os.Open(queryFrom)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to open report: %v\n", err)
+			os.Exit(1)
+		}
+		g, err := graph.LoadFromJSON(f)
+		f.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", queryFrom, err)
+			os.Exit(1)
+		}
+
+		var matches []policy.QueryContext
+		var firstErr error
+		failed := 0
+		g.Mu.RLock()
+		nodes := g.Store.GetAllNodes()
+		for _, node := range nodes {
+			qc := policy.NewQueryContext(node)
+			ok, err := q.Match(qc)
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("%s: %v", qc.ID, err)
+				}
+				failed++
+				continue
+			}
+			if ok {
+				matches = append(matches, qc)
+			}
+		}
+		g.Mu.RUnlock()
+
+		printQueryMatches(matches)
+		fmt.Printf("\n%d of %d resources matched.\n", len(matches), len(nodes))
+		if failed > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: query failed on %d resources (first: %v)\n", failed, firstErr)
+		}
+	},
+}
+
+// printQueryMatches prints matches as a table, most expensive first.
+func printQueryMatches(matches []policy.QueryContext) {
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Cost != matches[j].Cost {
+			return matches[i].Cost > matches[j].Cost
+		}
+		return matches[i].ID < matches[j].ID
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTYPE\tREGION\tCOST\tREASON")
+	for _, m := range matches {
+		region := m.Region
+		if region == "" {
+			region = "-"
+		}
+		reason := strings.Join(strings.Fields(m.Reason), " ")
+		if reason == "" {
+			reason = "-"
+		} else if len(reason) > 100 {
+			reason = reason[:97] + "..."
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t$%.2f\t%s\n", m.ID, m.Kind, region, m.Cost, reason)
+	}
+	w.Flush()
+}
+
+func init() {
+	queryCmd.Flags().StringVar(&queryFrom, "from", "cloudslash-out/waste_report.json", "waste_report.json to query")
+	rootCmd.AddCommand(queryCmd)
+}
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/report"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportFrom   string
+	reportOutDir string
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report --from waste_report.json",
+	Short: "Regenerate reports from a saved waste_report.json",
+	Long: `Rebuilds the graph from a waste_report.json written by an earlier scan and
+regenerates the reports from it, without calling AWS:
+
+  dashboard.html         Interactive dashboard
+  executive_summary.md   Executive summary
+  waste_report.csv       Findings as CSV
+
+Only findings and the resources linked to them are in the report, so
+summary sections about resources that are not waste (unmanaged resources,
+deprecations on healthy resources) cover the findings only.
+
+Example:
+  cloudslash report --from cloudslash-out/waste_report.json
+  cloudslash report --from waste_report.json --out reports/ --summary-template technical`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		f, err := os.Open(reportFrom)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to open report: %v\n", err)
+			os.Exit(1)
+		}
+		g, err := graph.LoadFromJSON(f)
+		f.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", reportFrom, err)
+			os.Exit(1)
+		}
+
+		outDir := reportOutDir
+		if outDir == "" {
+			outDir = config.OutputDir
+		}
+		if outDir == "" || strings.HasPrefix(outDir, "s3://") {
+			outDir = "cloudslash-out"
+		}
+		if err := os.MkdirAll(outDir, 0755); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to create %s: %v\n", outDir, err)
+			os.Exit(1)
+		}
+
+		dashboard := filepath.Join(outDir, "dashboard.html")
+		summary := filepath.Join(outDir, "executive_summary.md")
+		csvPath := filepath.Join(outDir, "waste_report.csv")
+		if err := report.GenerateDashboard(g, dashboard, config.Report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to generate dashboard: %v\n", err)
+			os.Exit(1)
+		}
+		if err := report.WriteSummary(g, summary, fmt.Sprintf("cs-report-%d", time.Now().Unix()), "AWS-ACCOUNT", config.SummaryTemplate); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to generate executive summary: %v\n", err)
+			os.Exit(1)
+		}
+		if err := report.GenerateCSV(g, csvPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to generate CSV: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Regenerated reports for %d findings from %s.\n", len(report.Findings(g)), reportFrom)
+		fmt.Printf("   Dashboard: %s\n", dashboard)
+		fmt.Printf("   Summary:   %s\n", summary)
+		fmt.Printf("   CSV:       %s\n", csvPath)
+	},
+}
+
+func init() {
+	reportCmd.Flags().StringVar(&reportFrom, "from", "", "waste_report.json from an earlier scan")
+	reportCmd.Flags().StringVar(&reportOutDir, "out", "", "Output directory (default <output-dir>)")
+	reportCmd.Flags().StringVar(&config.SummaryTemplate, "summary-template", "", "Executive summary template: 'executive', 'technical', or a Go template file")
+	reportCmd.MarkFlagRequired("from")
+	rootCmd.AddCommand(reportCmd)
+}
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	internalconfig "github.com/DrSkyle/cloudslash/v2/pkg/config"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/oracle"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	riskCurrentType string
+	riskTargetType  string
+	riskZone        string
+	riskSpot        bool
+	riskCrossAZ     bool
+	riskProduction  bool
+)
+
+var RiskCmd = &cobra.Command{
+	Use:   "risk",
+	Short: "Inspect optimizer risk scoring",
+}
+
+var riskExplainCmd = &cobra.Command{
+	Use:   "explain <arn>",
+	Short: "Print the risk breakdown for a workload",
+	Long: `Explain how the optimizer scores a proposed change to a workload.
+
+Weights are read from the 'risk' section of cloudslash.yaml:
+
+  risk:
+    weights:
+      statefulness: 0.25
+      cross_az: 0.10
+      spot: 0.20
+      arch_change: 0.15
+      production: 0.20`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		target := args[0]
+
+		profile := oracle.WorkloadProfile{
+			Zone:         riskZone,
+			InstanceType: riskTargetType,
+			Stateful:     isStatefulARN(target),
+			CrossAZ:      riskCrossAZ,
+			Spot:         riskSpot,
+			Production:   riskProduction,
+		}
+		if riskCurrentType != "" && riskTargetType != "" {
+			profile.ArchChange = isGravitonType(riskCurrentType) != isGravitonType(riskTargetType)
+		}
+
+		engine := oracle.NewRiskEngine(loadRiskConfig())
+		assessment := engine.Assess(profile)
+
+		fmt.Printf("Risk Breakdown: %s\n\n", target)
+		fmt.Printf("  %-22s %6.2f\n", "Base (pool history)", assessment.Base)
+		for _, f := range assessment.Factors {
+			mark := " "
+			contrib := 0.0
+			if f.Applied {
+				mark = "+"
+				contrib = f.Weight
+			}
+			fmt.Printf("%s %-22s %6.2f  (weight %.2f)\n", mark, f.Name, contrib, f.Weight)
+		}
+		fmt.Printf("\n  %-22s %6.2f  (threshold %.2f)\n", "Total", assessment.Total, oracle.MaxAcceptableRisk)
+
+		if assessment.Accepted {
+			fmt.Println("\n[SUCCESS] Recommendation would be ACCEPTED.")
+		} else {
+			fmt.Println("\n[WARN] Recommendation would be REJECTED (risk above threshold).")
+		}
+	},
+}
+
+func init() {
+	riskExplainCmd.Flags().StringVar(&riskCurrentType, "current-type", "", "Current instance type (e.g. m5.large)")
+	riskExplainCmd.Flags().StringVar(&riskTargetType, "target-type", "", "Proposed instance type (e.g. m6g.large)")
+	riskExplainCmd.Flags().StringVar(&riskZone, "zone", internalconfig.DefaultRegion+"a", "Target availability zone")
+	riskExplainCmd.Flags().BoolVar(&riskSpot, "spot", false, "Target capacity is spot")
+	riskExplainCmd.Flags().BoolVar(&riskCrossAZ, "cross-az", false, "Workload moves across availability zones")
+	riskExplainCmd.Flags().BoolVar(&riskProduction, "prod", false, "Workload is tagged as production")
+
+	RiskCmd.AddCommand(riskExplainCmd)
+	rootCmd.AddCommand(RiskCmd)
+}
+
+// loadRiskConfig overlays the 'risk' section of the config file on the defaults.
+func loadRiskConfig() internalconfig.RiskConfig {
+	cfg := internalconfig.DefaultRiskConfig()
+	if err := viper.UnmarshalKey("risk", &cfg); err != nil {
+		fmt.Printf("[WARN] Invalid risk config, using defaults: %v\n", err)
+		return internalconfig.DefaultRiskConfig()
+	}
+	return cfg
+}
+
+// isStatefulARN reports whether the resource holds data.
+func isStatefulARN(resourceARN string) bool {
+	parsed, err := arn.Parse(resourceARN)
+	if err != nil {
+		return false
+	}
+	switch parsed.Service {
+	case "rds", "dynamodb", "elasticache", "redshift", "es", "elasticfilesystem", "s3":
+		return true
+	case "ec2":
+		return strings.HasPrefix(parsed.Resource, "volume/")
+	}
+	return false
+}
+
+// isGravitonType detects ARM instance families (m6g, c7gn, r6gd, ...).
+func isGravitonType(instanceType string) bool {
+	family := strings.SplitN(instanceType, ".", 2)[0]
+	idx := strings.IndexAny(family, "0123456789")
+	if idx == -1 {
+		return false
+	}
+	suffix := strings.TrimLeft(family[idx:], "0123456789")
+	return strings.Contains(suffix, "g")
+}
@@ -1,10 +1,13 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
 
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine"
 	"github.com/DrSkyle/cloudslash/v2/pkg/version"
@@ -17,6 +20,8 @@ import (
 var (
 	cfgFile string
 	config  engine.Config
+	noColor bool
+	ciMode  bool
 )
 
 var rootCmd = &cobra.Command{
@@ -30,7 +35,15 @@ Identify. Audit. Optimize.`,
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	// Ctrl-C or SIGTERM cancels cmd.Context(); a second signal kills the process.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
@@ -39,31 +52,26 @@ func Execute() {
 func init() {
 	cobra.OnInitialize(initConfig)
 
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "Config file (default: ./.cloudslash.yaml, then ~/.cloudslash/.cloudslash.yaml)")
 	rootCmd.PersistentFlags().StringVar(&config.Region, "region", "us-east-1", "AWS Region")
 	rootCmd.PersistentFlags().StringVar(&config.TFStatePath, "tfstate", "terraform.tfstate", "Path to web.tfstate")
+	rootCmd.PersistentFlags().StringVar(&config.IaC, "iac", "auto", "IaC tool to reconcile against: auto, terraform, pulumi")
+	rootCmd.PersistentFlags().StringVar(&config.PulumiStatePath, "pulumi-state", "", "Path to pulumi stack export JSON (default: run pulumi stack export)")
 	rootCmd.PersistentFlags().BoolVar(&config.AllProfiles, "all-profiles", false, "Scan all AWS profiles")
 	rootCmd.PersistentFlags().StringVar(&config.RequiredTags, "required-tags", "", "Required tags (comma-separated)")
 	rootCmd.PersistentFlags().StringVar(&config.SlackWebhook, "slack-webhook", "", "Slack Webhook URL")
 	rootCmd.PersistentFlags().BoolVarP(&config.Verbose, "verbose", "v", false, "Enable Matrix Mode (Visual API Logging)")
 	rootCmd.PersistentFlags().BoolVar(&config.JsonLogs, "json", false, "Enable JSON Logging (Machine Mode)")
 	rootCmd.PersistentFlags().BoolVar(&config.DisableCWMetrics, "no-metrics", false, "Skip CloudWatch API calls (faster, but less accurate)")
-	rootCmd.PersistentFlags().StringVar(&config.RulesFile, "rules", "", "Path to YAML Policy Rules")
+	rootCmd.PersistentFlags().StringVar(&config.RulesFile, "rules", "", "Path or s3://bucket/key URL of YAML Policy Rules")
+	rootCmd.PersistentFlags().Float64Var(&config.Budget, "budget", 0, "Monthly budget in USD; alerts when month-end spend is projected over it")
 	rootCmd.PersistentFlags().StringVar(&config.HistoryURL, "history-url", "", "S3 URL for Shared History (e.g. s3://bucket/key)")
 	rootCmd.PersistentFlags().StringVar(&config.OutputDir, "output-dir", "cloudslash-out", "Directory for artifacts")
 	rootCmd.PersistentFlags().StringVar(&config.OtelEndpoint, "otel-endpoint", "", "OpenTelemetry Exporter Endpoint (HTTP)")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable ANSI colors, spinners and Unicode glyphs")
+	rootCmd.PersistentFlags().BoolVar(&ciMode, "ci", false, "CI Mode: plain ASCII output, implies --headless")
 
-	viper.BindPFlag("region", rootCmd.PersistentFlags().Lookup("region"))
-	viper.BindPFlag("tfstate", rootCmd.PersistentFlags().Lookup("tfstate"))
-	viper.BindPFlag("all_profiles", rootCmd.PersistentFlags().Lookup("all-profiles"))
-	viper.BindPFlag("required_tags", rootCmd.PersistentFlags().Lookup("required-tags"))
-	viper.BindPFlag("slack_webhook", rootCmd.PersistentFlags().Lookup("slack-webhook"))
-	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
-	viper.BindPFlag("json_logs", rootCmd.PersistentFlags().Lookup("json"))
-	viper.BindPFlag("no_metrics", rootCmd.PersistentFlags().Lookup("no-metrics"))
-	viper.BindPFlag("rules_file", rootCmd.PersistentFlags().Lookup("rules"))
-	viper.BindPFlag("history_url", rootCmd.PersistentFlags().Lookup("history-url"))
-	viper.BindPFlag("output_dir", rootCmd.PersistentFlags().Lookup("output-dir"))
-	viper.BindPFlag("otel_endpoint", rootCmd.PersistentFlags().Lookup("otel-endpoint"))
+	bindConfigFlags(rootCmd.PersistentFlags())
 
 	rootCmd.PersistentFlags().BoolVar(&config.MockMode, "mock", false, "Run in Mock Mode")
 	rootCmd.PersistentFlags().MarkHidden("mock")
@@ -73,23 +81,40 @@ func init() {
 	})
 
 	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		bindConfigFlags(cmd.Flags())
+		noColor = viper.GetBool("no_color")
+		ciMode = viper.GetBool("ci")
+		configureOutput(noColor || ciMode)
+
 		// Check for updates on major commands.
 		if cmd.Name() == "help" || cmd.Name() == "scan" || cmd.Name() == "update" {
 			checkUpdate()
 		}
 
-		config.Region = viper.GetString("region")
+		config.Region = listValue("region")
 		config.TFStatePath = viper.GetString("tfstate")
+		config.IaC = viper.GetString("iac")
+		config.PulumiStatePath = viper.GetString("pulumi_state")
 		config.AllProfiles = viper.GetBool("all_profiles")
-		config.RequiredTags = viper.GetString("required_tags")
+		config.RequiredTags = listValue("required_tags")
 		config.SlackWebhook = viper.GetString("slack_webhook")
+		config.TeamsWebhook = viper.GetString("teams_webhook")
+		config.DiscordWebhook = viper.GetString("discord_webhook")
+		config.DatadogAPIKey = viper.GetString("datadog_api_key")
+		config.DatadogSite = viper.GetString("datadog_site")
 		config.Verbose = viper.GetBool("verbose")
 		config.JsonLogs = viper.GetBool("json_logs")
 		config.DisableCWMetrics = viper.GetBool("no_metrics")
 		config.RulesFile = viper.GetString("rules_file")
+		config.Budget = viper.GetFloat64("budget")
 		config.HistoryURL = viper.GetString("history_url")
 		config.OutputDir = viper.GetString("output_dir")
 		config.OtelEndpoint = viper.GetString("otel_endpoint")
+		config.MaxConcurrency = viper.GetInt("max_workers")
+		config.DiscountRate = viper.GetFloat64("discount_rate")
+		config.DisabledHeuristics = splitList(listValue("disabled_heuristics"))
+		config.Report.CriticalThreshold = viper.GetInt("critical_threshold")
+		config.Report.ReviewThreshold = viper.GetInt("review_threshold")
 	}
 
 	rootCmd.AddCommand(CleanupCmd)
@@ -112,20 +137,36 @@ func checkUpdate() {
 }
 
 func initConfig() {
-	viper.SetConfigName("cloudslash")
-	viper.SetConfigType("yaml")
-	viper.AddConfigPath(".")
-	viper.AddConfigPath("$HOME/.cloudslash")
-
 	viper.SetEnvPrefix("CLOUDSLASH")
 	viper.AutomaticEnv()
 
-	if err := viper.ReadInConfig(); err == nil {
-		// Configuration loaded.
+	path := cfgFile
+	if path == "" {
+		path = findConfigFile()
+	}
+	if path == "" {
+		return
+	}
+
+	// An explicit or discovered file that cannot be used is an error, not a silent fallback.
+	if err := validateConfigFile(path); err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] %v\n", err)
+		os.Exit(1)
+	}
+	viper.SetConfigFile(path)
+	viper.SetConfigType("yaml")
+	if err := viper.ReadInConfig(); err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] Failed to load config file %s: %v\n", path, err)
+		os.Exit(1)
 	}
 }
 
 func renderFutureGlassHelp(cmd *cobra.Command) {
+	// The help func bypasses PersistentPreRun; read the flags directly.
+	nc, _ := cmd.Flags().GetBool("no-color")
+	ci, _ := cmd.Flags().GetBool("ci")
+	configureOutput(nc || ci)
+
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(lipgloss.Color("#00FF99")).
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -15,10 +16,13 @@ import (
 	internalconfig "github.com/DrSkyle/cloudslash/v2/pkg/config"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/heuristics"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/notifier"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/oracle"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/policy"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/pricing"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/provenance"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/report"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/solver"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/tetris"
 	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
@@ -28,8 +32,19 @@ import (
 	"github.com/DrSkyle/cloudslash/v2/pkg/version"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
+// summaryFormat selects how the scan reports its result: "text" or "json".
+var summaryFormat string
+
+// wasteGate holds the --fail-on-waste and --fail-on-count thresholds.
+var wasteGate report.WasteGate
+
+// exitWasteThreshold is the exit code of a headless scan whose waste exceeds
+// a --fail-on threshold. Partial failures under --strict exit 2.
+const exitWasteThreshold = 3
+
 var scanCmd = &cobra.Command{
 	Use:   "scan",
 	Short: "Launch interactive infrastructure audit (TUI)",
@@ -54,9 +69,43 @@ Example:
 		if fast, _ := cmd.Flags().GetBool("fast"); fast {
 			config.DisableCWMetrics = true
 		}
+		if config.SlackToken == "" {
+			// Keep bot tokens out of shell history.
+			config.SlackToken = os.Getenv("CLOUDSLASH_SLACK_TOKEN")
+		}
 
 
-		if headless, _ := cmd.Flags().GetBool("headless"); headless {
+		window, err := internalconfig.ParseMetricWindow(viper.GetString("metric_window"))
+		if err == nil && window > 0 {
+			err = aws.ValidateMetricWindow(window)
+		}
+		if err != nil {
+			fmt.Printf("[FATAL] --metric-window: %v\n", err)
+			os.Exit(1)
+		}
+		config.Heuristics.MetricWindow = window
+
+		if err := engine.ValidateResourceScopes(config.OnlyResources, config.SkipResources); err != nil {
+			fmt.Printf("[FATAL] %v\n", err)
+			os.Exit(1)
+		}
+
+		if summaryFormat != "text" && summaryFormat != "json" {
+			fmt.Printf("[FATAL] --summary-format must be text or json, got %q\n", summaryFormat)
+			os.Exit(1)
+		}
+
+		if wasteGate.MaxMonthlyCost < 0 || wasteGate.MaxCount < 0 {
+			fmt.Println("[FATAL] --fail-on-waste and --fail-on-count must not be negative")
+			os.Exit(1)
+		}
+
+		if config.AssumeRolesFile != "" && config.Org {
+			fmt.Println("[FATAL] --assume-roles and --org cannot be combined")
+			os.Exit(1)
+		}
+
+		if headless, _ := cmd.Flags().GetBool("headless"); headless || ciMode {
 			config.Headless = true
 		}
 
@@ -81,7 +130,7 @@ Example:
 			accountId, err := verifClient.VerifyIdentity(cmd.Context())
 			if err != nil {
 				// Graceful exit!
-				fmt.Printf("\n\n❌ AWS Authentication Failed.\n")
+				fmt.Printf("\n\n%s AWS Authentication Failed.\n", glyph("❌", "[ERROR]"))
 				fmt.Printf("   Error: %v\n\n", err)
 				fmt.Println("   Typical causes:")
 				fmt.Println("   1. AWS CLI not configured (run 'aws configure')")
@@ -123,10 +172,16 @@ Example:
 		config.CacheDir = cacheDir
 
 		// Initialize pricing client.
-		profile := os.Getenv("AWS_PROFILE")
-		pricingClient, err := pricing.NewClient(cmd.Context(), config.Logger, config.CacheDir, config.DiscountRate, profile)
-		if err != nil {
-			config.Logger.Debug("Pre-init pricing client failed", "error", err)
+		// One client serves the engine and the solver, so the price cache is
+		// loaded and the discount calibrated once per run.
+		var pricingClient *pricing.Client
+		if !config.MockMode {
+			profile := os.Getenv("AWS_PROFILE")
+			pricingClient, err = pricing.NewClient(cmd.Context(), config.Logger, config.CacheDir, config.DiscountRate, profile)
+			if err != nil {
+				config.Logger.Debug("Pre-init pricing client failed", "error", err)
+				pricingClient = nil
+			}
 		}
 
 		// Initialize engine.
@@ -142,6 +197,10 @@ Example:
 		}
 
 		success, g, swarmEngine, err := eng.Run(cmd.Context())
+		if errors.Is(err, engine.ErrInterrupted) {
+			fmt.Fprintln(os.Stderr, "Scan interrupted; no reports were written.")
+			os.Exit(130)
+		}
 		if err != nil {
 			config.Logger.Error("Pipeline failed", "error", err)
 			os.Exit(1)
@@ -150,6 +209,23 @@ Example:
 			os.Exit(1)
 		}
 
+		// With --summary-format json, the last line on stdout is the summary.
+		printSummary := func() {
+			if summaryFormat != "json" {
+				return
+			}
+			summary, ok := eng.Summary()
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(report.NewScanSummary(summary, eng.Artifacts()))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "[WARN] Failed to encode summary: %v\n", err)
+				return
+			}
+			fmt.Println(string(data))
+		}
+
 		if !config.Headless {
 			model := ui.NewModel(swarmEngine, g, config.MockMode, config.Region)
 			startTime := time.Now()
@@ -171,19 +247,21 @@ Example:
 			ui.PrintExitSummary(startTime, totalNodes)
 		}
 
-		runSolver(g)
+		runSolver(g, pricingClient)
 
-		// Generate remediation artifacts.
-		fmt.Printf("\n[INFO] Safe Remediation Plan generated at: %s/remediation_plan.json\n", config.OutputDir)
-		fmt.Printf("       (Use the JSON plan with the CloudSlash Executor for safe removal)\n")
+		if !config.PlanOnly {
+			// Generate remediation artifacts.
+			fmt.Printf("\n[INFO] Safe Remediation Plan generated at: %s/remediation_plan.json\n", config.OutputDir)
+			fmt.Printf("       (Use the JSON plan with the CloudSlash Executor for safe removal)\n")
 
-		// Generate restoration plan.
-		restorePath := filepath.Join(config.OutputDir, "restore.tf")
-		gen := script.NewGenerator(g, nil)
-		if err := gen.GenerateRestorationPlan(restorePath); err != nil {
-			fmt.Printf("[WARN] Failed to generate restoration plan: %v\n", err)
-		} else {
-			fmt.Printf("[SUCCESS] Lazarus Protocol Active: Restoration plan generated: %s\n", restorePath)
+			// Generate restoration plan.
+			restorePath := filepath.Join(config.OutputDir, "restore.tf")
+			gen := script.NewGenerator(g, nil)
+			if err := gen.GenerateRestorationPlan(restorePath); err != nil {
+				fmt.Printf("[WARN] Failed to generate restoration plan: %v\n", err)
+			} else {
+				fmt.Printf("[SUCCESS] Lazarus Protocol Active: Restoration plan generated: %s\n", restorePath)
+			}
 		}
 
 		// Initialize Terraform analysis.
@@ -225,12 +303,15 @@ Example:
 					report := tf.Analyze(state, unused)
 
 					printTerraformReport(report, provMap)
-					generateFixScript(report)
+					if !config.PlanOnly {
+						generateFixScript(report)
+					}
 				}
 			}
 			// Check for Partial Failures to signal CI/CD
 			if err != nil && errors.Is(err, engine.ErrPartialResult) {
 				fmt.Println("\n[WARN] Scan completed with partial failures (Strict Mode).")
+				printSummary()
 				os.Exit(2)
 			} else if config.StrictMode {
 				// If strict mode is on but engine returned nil, check manual state just in case
@@ -243,6 +324,7 @@ Example:
 				g.Mu.RUnlock()
 				if isPartial {
 					fmt.Println("\n[WARN] Scan completed with partial failures. Check logs for details.")
+					printSummary()
 					os.Exit(2)
 				}
 			} else {
@@ -255,6 +337,21 @@ Example:
 				}
 			}
 		}
+
+		// CI gating on the amount of waste found.
+		if config.Headless {
+			if summary, ok := eng.Summary(); ok {
+				if breaches := wasteGate.Breaches(summary); len(breaches) > 0 {
+					for _, b := range breaches {
+						fmt.Printf("\n[FAIL] Waste threshold exceeded: %s.\n", b)
+					}
+					printSummary()
+					os.Exit(exitWasteThreshold)
+				}
+			}
+		}
+
+		printSummary()
 	},
 }
 
@@ -264,10 +361,55 @@ func init() {
 	scanCmd.Flags().Bool("fast", false, "Alias for --no-metrics (Fast scan)")
 	scanCmd.Flags().Bool("headless", false, "Run without TUI (for CI/CD)")
 	scanCmd.Flags().StringVar(&config.SlackWebhook, "slack-webhook", "", "Slack Webhook URL for Reporting")
+	scanCmd.Flags().StringVar(&config.TeamsWebhook, "teams-webhook", "", "Microsoft Teams webhook URL for Reporting (Adaptive Card)")
+	scanCmd.Flags().StringVar(&config.DiscordWebhook, "discord-webhook", "", "Discord webhook URL for Reporting")
+	scanCmd.Flags().StringVar(&config.DatadogAPIKey, "datadog-api-key", "", "Datadog API key; posts the summary as an event and submits waste metrics")
+	scanCmd.Flags().StringVar(&config.DatadogSite, "datadog-site", notifier.DefaultDatadogSite, "Datadog site, e.g. datadoghq.eu or us5.datadoghq.com")
 	scanCmd.Flags().StringVar(&config.SlackChannel, "slack-channel", "", "Override Slack Channel")
+	scanCmd.Flags().StringVar(&config.SlackToken, "slack-token", "", "Slack bot token; posts the full finding list as thread replies (requires --slack-channel)")
 	scanCmd.Flags().IntVar(&config.MaxConcurrency, "max-workers", 0, "Limit concurrency (default: auto)")
-	scanCmd.Flags().StringVar(&config.RulesFile, "rules", "", "Path to YAML Policy Rules (e.g. dynamic_rules.yaml)")
+	scanCmd.Flags().StringSliceVar(&config.DisabledHeuristics, "disable", nil, "Skip a heuristic by name (repeatable, e.g. --disable TagComplianceHeuristic)")
+	scanCmd.Flags().StringSliceVar(&config.OnlyResources, "only", nil, "Scan only these resource types, e.g. ec2-volumes,snapshots; heuristics needing other types are skipped")
+	scanCmd.Flags().StringSliceVar(&config.SkipResources, "skip", nil, "Do not scan these resource types, e.g. s3,cloudfront; heuristics needing them are skipped")
+	scanCmd.Flags().String("metric-window", "", "CloudWatch lookback for metric-based heuristics, e.g. 14d or 36h (default: 7d, 14d for volumes, replicas, DMS and CloudFront)")
+	scanCmd.Flags().StringVar(&config.RulesFile, "rules", "", "Path or s3://bucket/key URL of YAML Policy Rules (e.g. dynamic_rules.yaml)")
 	scanCmd.Flags().BoolVar(&config.StrictMode, "strict", false, "Exit with code 2 on partial failures (Strict Mode)")
+	scanCmd.Flags().Float64Var(&wasteGate.MaxMonthlyCost, "fail-on-waste", 0, "Exit with code 3 in headless mode when monthly waste exceeds this many USD")
+	scanCmd.Flags().IntVar(&wasteGate.MaxCount, "fail-on-count", 0, "Exit with code 3 in headless mode when more than this many resources are waste")
+	scanCmd.Flags().StringVar(&summaryFormat, "summary-format", "text", "Final summary on stdout: text, or json for a single JSON object on the last line")
+	scanCmd.Flags().StringVar(&config.SummaryTemplate, "summary-template", "", "Executive summary template: 'executive', 'technical', or a Go template file")
+	scanCmd.Flags().BoolVar(&config.Stream, "stream", false, "Print findings as they are discovered (headless mode)")
+	scanCmd.Flags().StringVar(&config.StreamWebhook, "stream-webhook", "", "POST findings as NDJSON to this URL while the scan runs")
+	scanCmd.Flags().BoolVar(&config.ProtectCFN, "protect-cfn", false, "Mark CloudFormation-managed waste for template review instead of deletion")
+	scanCmd.Flags().BoolVar(&config.Checkpoint, "checkpoint", false, "Save each completed profile/region to .cloudslash/checkpoint/")
+	scanCmd.Flags().BoolVar(&config.Resume, "resume", false, "Resume an interrupted --checkpoint scan, skipping completed scopes")
+	scanCmd.Flags().BoolVar(&config.CacheGraph, "cache-graph", false, "Reuse the scanned graph of an identical scan within --cache-graph-ttl instead of rescanning")
+	scanCmd.Flags().DurationVar(&config.CacheGraphTTL, "cache-graph-ttl", engine.DefaultGraphCacheTTL, "How long a --cache-graph graph is reused, e.g. 30m or 4h")
+	scanCmd.Flags().StringVar(&config.AssumeRolesFile, "assume-roles", "", "File of role ARNs or account IDs (one per line) to scan by assuming each role")
+	scanCmd.Flags().BoolVar(&config.Org, "org", false, "Scan every active account in the AWS Organization by assuming --org-role")
+	scanCmd.Flags().StringVar(&config.OrgRole, "org-role", aws.DefaultOrgRole, "Role assumed in member accounts for --org and bare account IDs in --assume-roles")
+	scanCmd.Flags().BoolVar(&config.CheckPolicy, "check-policy", false, "Simulate deletes and flag findings blocked by SCPs or permissions boundaries")
+	scanCmd.Flags().StringVar(&config.RemediationPrincipal, "remediation-principal", "", "IAM role/user ARN used for --check-policy (default: scanning identity)")
+	scanCmd.Flags().BoolVar(&config.ComputeOptimizer, "compute-optimizer", false, "Cross-check right-sizing findings with AWS Compute Optimizer")
+	scanCmd.Flags().BoolVar(&config.IncludeMessaging, "include-messaging", false, "Scan SQS queues and SNS topics and flag idle ones")
+	scanCmd.Flags().BoolVar(&config.Compliance, "compliance", false, "Flag unencrypted EBS volumes, RDS instances and S3 buckets as compliance violations")
+	scanCmd.Flags().IntVar(&config.PricingWorkers, "pricing-workers", pricing.DefaultPrefetchWorkers, "Concurrent Pricing API requests when building the solver catalog")
+	scanCmd.Flags().StringVar(&config.CostCenterTag, "cost-center-tag", "", "Tag key for cost centers; writes chargeback.csv (e.g. CostCenter)")
+	scanCmd.Flags().StringVar(&config.FlowLogsGroup, "flow-logs", "", "VPC Flow Logs log group; flags instance pairs with costly cross-AZ traffic")
+	scanCmd.Flags().StringVar(&config.DeprecationsFile, "deprecations", "", "YAML file extending the built-in deprecated service list")
+	scanCmd.Flags().BoolVar(&config.DisableTrailCache, "no-trail-cache", false, "Disable the per-run CloudTrail lookup cache")
+	scanCmd.Flags().StringVar(&config.EnvTag, "env-tag", "", "Tag key holding the environment (e.g. Environment); prod findings require manual review")
+	scanCmd.Flags().StringVar(&config.CostAllocationTags, "tag-from-cost-allocation", "", "Activated cost-allocation tags (comma-separated); reports spend on resources missing any of them")
+	scanCmd.Flags().StringVar(&config.Provider, "provider", "aws", "Clouds to scan (comma-separated: aws, gcp, azure)")
+	scanCmd.Flags().StringVar(&config.GCPProject, "gcp-project", "", "GCP project to scan (default: the application default credentials project)")
+	scanCmd.Flags().StringVar(&config.AzureSubscription, "subscription", "", "Azure subscription ID to scan (default: AZURE_SUBSCRIPTION_ID)")
+	scanCmd.Flags().StringVar(&config.CommitmentCoverageFile, "commitment-coverage", "", "YAML file of Savings Plan/RI coverage per instance family or instance; the solver reports commitment-adjusted savings")
+	scanCmd.Flags().BoolVar(&config.AllowSpot, "allow-spot", false, "Let the solver place instances tagged cloudslash:interruption-tolerant=true on spot capacity")
+	scanCmd.Flags().BoolVar(&config.SankeyJSON, "sankey-json", false, "Export topology as Sankey JSON (topology_sankey.json)")
+	scanCmd.Flags().BoolVar(&config.FOCUS, "focus", false, "Export findings in the FinOps FOCUS 1.0 format (focus_report.csv)")
+	scanCmd.Flags().BoolVar(&config.Diff, "diff", false, "Print waste added and resolved since the previous scan")
+	scanCmd.Flags().BoolVar(&config.PlanOnly, "plan-only", false, "Run the analysis and print the summary without writing any artifacts to the output directory")
+	scanCmd.Flags().StringVar(&config.MetricsFile, "metrics-file", "", "Write waste totals in Prometheus text format to this path")
 }
 
 func printTerraformReport(report *tf.AnalysisReport, provMap map[string]*provenance.ProvenanceRecord) {
@@ -304,18 +446,24 @@ func printTerraformReport(report *tf.AnalysisReport, provMap map[string]*provena
 }
 
 func printProvenanceBox(rec *provenance.ProvenanceRecord) {
-	fmt.Println("  ┌── PROVENANCE AUDIT ──────────────────────────────────────────")
-	fmt.Printf("  │ Author:  %s\n", rec.Author)
-	fmt.Printf("  │ Commit:  %s (%s)\n", rec.CommitHash[:7], rec.CommitDate.Format("2006-01-02"))
-	fmt.Printf("  │ Message: \"%s\"\n", strings.TrimSpace(rec.Message))
-	fmt.Printf("  │ File:    %s:%d\n", rec.FilePath, rec.LineStart)
+	top, side, bottom := "┌──", "│", "└───"
+	rule := "─"
+	if plainOutput {
+		top, side, bottom, rule = "+--", "|", "+---", "-"
+	}
+
+	fmt.Printf("  %s PROVENANCE AUDIT %s\n", top, strings.Repeat(rule, 42))
+	fmt.Printf("  %s Author:  %s\n", side, rec.Author)
+	fmt.Printf("  %s Commit:  %s (%s)\n", side, rec.CommitHash[:7], rec.CommitDate.Format("2006-01-02"))
+	fmt.Printf("  %s Message: \"%s\"\n", side, strings.TrimSpace(rec.Message))
+	fmt.Printf("  %s File:    %s:%d\n", side, rec.FilePath, rec.LineStart)
 
 	if rec.IsLegacy {
-		fmt.Println("  │ Status:  [LEGACY] (> 1 year old)")
+		fmt.Printf("  %s Status:  [LEGACY] (> 1 year old)\n", side)
 	} else {
-		fmt.Println("  │ Status:  [ACTIVE COMMIT] (Recent change)")
+		fmt.Printf("  %s Status:  [ACTIVE COMMIT] (Recent change)\n", side)
 	}
-	fmt.Println("  └──────────────────────────────────────────────────────────────")
+	fmt.Printf("  %s%s\n", bottom, strings.Repeat(rule, 59))
 }
 
 func generateFixScript(report *tf.AnalysisReport) {
@@ -354,68 +502,51 @@ func generateFixScript(report *tf.AnalysisReport) {
 	_ = os.Chmod(f.Name(), 0755)
 }
 
-func runSolver(g *graph.Graph) {
-	fmt.Printf("\n[ %s OPTIMIZATION ENGINE ]\n", version.Current)
-	fmt.Println("Initializing Solver with Dynamic Intelligence...")
-
-	// Setup Logger.
-	var logger *slog.Logger
-	if config.Verbose {
-		logger = slog.New(slog.NewTextHandler(os.Stderr, nil))
-	} else {
-		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
-	}
+// fleetTypes returns the distinct instance types of the EC2 instances in g.
+func fleetTypes(g *graph.Graph) []string {
+	g.Mu.RLock()
+	defer g.Mu.RUnlock()
 
-	// Setup cache directory.
-	home, _ := os.UserHomeDir()
-	cacheDir := filepath.Join(home, ".cloudslash", "cache")
-	if xdg := os.Getenv("XDG_CACHE_HOME"); xdg != "" {
-		cacheDir = filepath.Join(xdg, "cloudslash")
+	seen := make(map[string]bool)
+	var types []string
+	for _, n := range g.Store.GetAllNodes() {
+		if n.TypeStr() != "AWS::EC2::Instance" {
+			continue
+		}
+		instanceType := "m5.large"
+		if t, ok := n.Properties["Type"].(string); ok {
+			instanceType = t
+		}
+		if !seen[instanceType] {
+			seen[instanceType] = true
+			types = append(types, instanceType)
+		}
 	}
-	_ = os.MkdirAll(cacheDir, 0755)
+	return types
+}
 
-	// Initialize pricing client.
-	logger.Info("Initializing Solver", "mock_mode", config.MockMode)
+func runSolver(g *graph.Graph, pc *pricing.Client) {
+	fmt.Printf("\n[ %s OPTIMIZATION ENGINE ]\n", version.Current)
+	fmt.Println("Initializing Solver with Dynamic Intelligence...")
 
-	var pc *pricing.Client
-	var err error
 	ctx := context.Background()
-
+	if pc != nil {
+		defer pc.Flush()
+	}
 	if config.MockMode {
 		fmt.Println(" -> [MOCK] Using static pricing estimation.")
-	} else {
-		done := make(chan bool)
-		fmt.Printf(" -> Connecting to AWS Pricing API... ")
-		go func() {
-			chars := []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
-			i := 0
-			for {
-				select {
-				case <-done:
-					return
-				default:
-					fmt.Printf("\r -> Connecting to AWS Pricing API... %s ", chars[i%len(chars)])
-					time.Sleep(100 * time.Millisecond)
-					i++
-				}
-			}
-		}()
-
-		manualRate := 0.0
-		profile := os.Getenv("AWS_PROFILE")
-		pc, err = pricing.NewClient(ctx, logger, cacheDir, manualRate, profile)
-		done <- true // Stop spinner
-		fmt.Printf("\r -> Connecting to AWS Pricing API... Done.\n")
+	} else if pc == nil {
+		fmt.Printf("[WARN] Pricing API unavailable (Profile: %s). Using static estimation.\n", os.Getenv("AWS_PROFILE"))
+	}
 
-		if err != nil {
-			fmt.Printf("[WARN] Pricing API unavailable: %v\n       (Region: us-east-1, Profile: %s). Using static estimation.\n", err, profile)
-		} else {
-			logger.Info("Pricing Client Initialized")
-		}
+	// Warm fleet prices concurrently; the spend loop below reads them from the cache.
+	if pc != nil {
+		pc.Prefetch(ctx, internalconfig.DefaultRegion, fleetTypes(g), config.PricingWorkers)
 	}
 
 	// Calculate current spend.
 	var workloads []*tetris.Item
+	var fleet []solver.FleetInstance
 	var currentSpend float64
 
 	g.Mu.RLock()
@@ -423,6 +554,29 @@ func runSolver(g *graph.Graph) {
 	totalNodes := len(nodes)
 	fmt.Printf(" -> Analyzing Current Spend (%d resources)...\n", totalNodes)
 
+	// Instances with EBS data volumes attached carry state across a move.
+	// Every instance boots from a root volume, so that one does not count.
+	rootDevices := make(map[string]string)
+	for _, n := range nodes {
+		if n.TypeStr() == "AWS::EC2::Instance" {
+			if root, _ := n.Properties["RootDeviceName"].(string); root != "" {
+				id := n.IDStr()
+				rootDevices[id[strings.LastIndex(id, "/")+1:]] = root
+			}
+		}
+	}
+	stateful := make(map[string]bool)
+	for _, n := range nodes {
+		if n.TypeStr() == "AWS::EC2::Volume" {
+			id, _ := n.Properties["AttachedInstanceId"].(string)
+			device, _ := n.Properties["AttachedDevice"].(string)
+			if id == "" || (device != "" && device == rootDevices[id]) {
+				continue
+			}
+			stateful[id] = true
+		}
+	}
+
 	for i, n := range nodes {
 		if i%5 == 0 {
 			fmt.Printf("\r    [%d/%d] Scanning resource: %s...", i+1, totalNodes, n.IDStr())
@@ -449,13 +603,21 @@ func runSolver(g *graph.Graph) {
 
 			// Add to monthly spend.
 			currentSpend += cost
+			fleet = append(fleet, solver.FleetInstance{ID: n.IDStr(), Type: instanceType, MonthlyCost: cost})
 
+			tags, _ := n.Properties["Tags"].(map[string]string)
+			zone, _ := n.Properties["AvailabilityZone"].(string)
+			id := n.IDStr()
 			workloads = append(workloads, &tetris.Item{
-				ID: n.IDStr(),
+				ID: id,
 				Dimensions: tetris.Dimensions{
 					CPU: specs.VCPU * 1000,
 					RAM: specs.Memory,
 				},
+				InterruptionTolerant: strings.EqualFold(tags[solver.InterruptionTolerantTag], "true"),
+				Zone:                 zone,
+				Stateful:             stateful[id[strings.LastIndex(id, "/")+1:]],
+				Production:           config.EnvTag != "" && heuristics.IsProductionEnv(strings.ToLower(tags[config.EnvTag])),
 			})
 		}
 	}
@@ -468,7 +630,7 @@ func runSolver(g *graph.Graph) {
 	}
 
 	// Initialize solver.
-	riskEngine := oracle.NewRiskEngine(internalconfig.DefaultRiskConfig())
+	riskEngine := oracle.NewRiskEngine(loadRiskConfig())
 	safePolicy := policy.DefaultPolicy()
 	validator := policy.NewValidator(safePolicy)
 	optimizer := solver.NewOptimizer(riskEngine, validator)
@@ -483,21 +645,34 @@ func runSolver(g *graph.Graph) {
 		fmt.Println(" > AWS Pricing API unavailable. Using static estimates.")
 	}
 
+	// Warm all candidate prices concurrently; the loop below reads the results.
+	var live map[string]float64
+	if pc != nil {
+		live = pc.Prefetch(ctx, internalconfig.DefaultRegion, aws.CandidateTypes, config.PricingWorkers)
+	}
+
+	// Spot prices come from EC2 and have no static fallback.
+	var spot map[string]float64
+	if config.AllowSpot {
+		if pc == nil {
+			fmt.Println(" > Spot prices unavailable without the AWS API. Planning on-demand only.")
+		} else {
+			var err error
+			spot, err = pc.GetSpotPrices(ctx, internalconfig.DefaultRegion, aws.CandidateTypes)
+			if err != nil {
+				fmt.Printf("[WARN] Failed to fetch spot prices: %v\n", err)
+			}
+		}
+	}
+
 	successCount := 0
 	fallbackCount := 0
 
-	for i, it := range aws.CandidateTypes {
+	for _, it := range aws.CandidateTypes {
 		specs := aws.GetSpecs(it)
-		var cost float64
-		var err error
-
-		fmt.Printf("\r   [%d/%d] Analyzing: %-12s ", i+1, len(aws.CandidateTypes), it)
 
-		if pc != nil {
-			cost, err = pc.GetEC2InstancePrice(ctx, internalconfig.DefaultRegion, it)
-		}
-
-		if pc == nil || err != nil || cost == 0 {
+		cost, ok := live[it]
+		if !ok {
 			estimator := &aws.StaticCostEstimator{}
 			cost = estimator.GetEstimatedCost(it, internalconfig.DefaultRegion)
 			fallbackCount++
@@ -513,20 +688,30 @@ func runSolver(g *graph.Graph) {
 			CPU:        specs.VCPU * 1000,
 			RAM:        specs.Memory,
 			HourlyCost: hourlyCost,
+			SpotPrice:  spot[it] / 730.0,
 			Zone:       internalconfig.DefaultRegion + "a", // Default zone placement.
 		})
-		
-		if pc == nil {
-			time.Sleep(20 * time.Millisecond)
-		}
 	}
 	fmt.Printf("\n > Catalog Complete. Live Prices: %d | Estimates: %d\n", successCount, fallbackCount)
+	if config.AllowSpot && pc != nil {
+		fmt.Printf(" > Spot Prices: %d\n", len(spot))
+	}
 
 	// Execute optimization.
 	req := solver.OptimizationRequest{
 		Workloads:    workloads,
 		Catalog:      catalog,
 		CurrentSpend: currentSpend,
+		Fleet:        fleet,
+		AllowSpot:    config.AllowSpot,
+	}
+	if config.CommitmentCoverageFile != "" {
+		coverage, err := solver.LoadCoverageModel(config.CommitmentCoverageFile)
+		if err != nil {
+			fmt.Printf("[WARN] Ignoring commitment coverage: %v\n", err)
+		} else {
+			req.Coverage = coverage
+		}
 	}
 
 	plan, err := optimizer.Solve(req)
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/pricing"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/server"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/spf13/cobra"
+)
+
+var (
+	servePort     int
+	serveInterval time.Duration
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve findings as a JSON API, rescanning on an interval",
+	Long: `Run a scan every --interval and serve the latest completed scan over HTTP:
+
+  GET /api/findings   Findings, as in waste_report.json
+  GET /api/summary    Resource and waste totals, with scan timing
+  GET /api/graph      Topology as Sankey nodes and links
+  GET /healthz        Liveness and the last scan's time and error
+
+The API answers 503 until the first scan completes. Later scans run in the
+background; the previous scan is served until the new one finishes, and a
+failed scan leaves it in place.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		config.Headless = true
+		level := slog.LevelInfo
+		if config.Verbose {
+			level = slog.LevelDebug
+		}
+		if config.JsonLogs {
+			config.Logger = slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+		} else {
+			config.Logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+		}
+		if home, err := os.UserHomeDir(); err == nil {
+			config.CacheDir = filepath.Join(home, ".cloudslash")
+		} else {
+			config.CacheDir = ".cloudslash"
+		}
+
+		// One pricing client across scans keeps its price cache warm.
+		var pricingClient *pricing.Client
+		if !config.MockMode {
+			var err error
+			pricingClient, err = pricing.NewClient(cmd.Context(), config.Logger, config.CacheDir, config.DiscountRate, os.Getenv("AWS_PROFILE"))
+			if err != nil {
+				config.Logger.Debug("Pre-init pricing client failed", "error", err)
+				pricingClient = nil
+			}
+		}
+
+		// Every scan gets its own engine, and so its own graph.
+		scan := func(ctx context.Context) (*graph.Graph, error) {
+			eng, err := engine.New(ctx,
+				engine.WithLogger(config.Logger),
+				engine.WithConfig(config),
+				engine.WithPricing(pricingClient),
+				engine.WithConcurrency(config.MaxConcurrency),
+			)
+			if err != nil {
+				return nil, err
+			}
+			_, g, _, err := eng.Run(ctx)
+			return g, err
+		}
+
+		srv := server.New(scan, serveInterval, config.Region, config.Logger)
+		addr := fmt.Sprintf(":%d", servePort)
+		if err := srv.ListenAndServe(cmd.Context(), addr); err != nil {
+			config.Logger.Error("Server failed", "addr", addr, "error", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	serveCmd.Flags().IntVar(&servePort, "port", 8080, "Port to serve the API on")
+	serveCmd.Flags().DurationVar(&serveInterval, "interval", time.Hour, "Time between scans")
+	rootCmd.AddCommand(serveCmd)
+}
@@ -0,0 +1,33 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-isatty"
+	"github.com/muesli/termenv"
+)
+
+// plainOutput disables ANSI styling, spinners and non-ASCII glyphs.
+var plainOutput bool
+
+// configureOutput picks plain output for --no-color/--ci, NO_COLOR, CI or non-TTY stdout.
+func configureOutput(noColor bool) {
+	fd := os.Stdout.Fd()
+	isTTY := isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+
+	plainOutput = noColor || os.Getenv("NO_COLOR") != "" || os.Getenv("CI") != "" || !isTTY
+	if plainOutput {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+	aws.PlainOutput = plainOutput
+}
+
+// glyph returns the styled symbol, or its ASCII fallback in plain mode.
+func glyph(styled, plain string) string {
+	if plainOutput {
+		return plain
+	}
+	return styled
+}
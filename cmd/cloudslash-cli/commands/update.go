@@ -30,7 +30,7 @@ var updateCmd = &cobra.Command{
 		latest := strings.TrimPrefix(latestTag, "v")
 
 		if current != latest {
-			fmt.Printf("\n📦 Update Available: v%s -> v%s\n", current, latest)
+			fmt.Printf("\n%s Update Available: v%s -> v%s\n", glyph("📦", "[UPDATE]"), current, latest)
 			fmt.Println("   Run the following to upgrade:")
 			fmt.Println("\n   brew upgrade cloudslash")
 		} else {
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/policy"
+	"github.com/spf13/cobra"
+)
+
+var validateRulesCmd = &cobra.Command{
+	Use:   "validate-rules [rules.yaml]",
+	Short: "Compile a CEL policy rules file without scanning",
+	Long: `Loads a policy rules file, compiles every rule, and reports each compilation
+error with its line in the file. It also warns about unknown rule keys,
+duplicate rule IDs, non-boolean conditions, and resource fields that no typed
+resource has.
+
+Exits with code 1 if any rule fails to compile, so rule changes can be gated
+in CI. Warnings alone do not fail.
+
+Example:
+  cloudslash validate-rules dynamic_rules.yaml
+  cloudslash validate-rules s3://security-rules/cloudslash/rules.yaml
+  cloudslash validate-rules --rules dynamic_rules.yaml`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path := config.RulesFile
+		if len(args) == 1 {
+			path = args[0]
+		}
+		if path == "" {
+			fmt.Fprintln(os.Stderr, "Error: no rules file given (pass a path or --rules)")
+			os.Exit(1)
+		}
+
+		cacheDir := ".cloudslash"
+		if home, err := os.UserHomeDir(); err == nil {
+			cacheDir = filepath.Join(home, ".cloudslash")
+		}
+		data, err := engine.ReadRules(cmd.Context(), path, cacheDir, nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		count, issues, err := policy.ValidateRules(data)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", path, err)
+			os.Exit(1)
+		}
+
+		errCount, warnCount := 0, 0
+		for _, issue := range issues {
+			label := glyph("❌", "[ERROR]")
+			if issue.Warning {
+				label = glyph("⚠️ ", "[WARN]")
+				warnCount++
+			} else {
+				errCount++
+			}
+			fmt.Printf("%s %s:%d: rule %s: %s\n", label, path, issue.Line, issue.RuleID, issue.Message)
+			if issue.Context != "" {
+				fmt.Println(issue.Context)
+			}
+		}
+
+		fmt.Printf("\nChecked %d rules: %d errors, %d warnings.\n", count, errCount, warnCount)
+		if errCount > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(validateRulesCmd)
+}
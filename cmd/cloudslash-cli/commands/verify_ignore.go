@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/remediation"
+	"github.com/spf13/cobra"
+)
+
+var verifyIgnoreCmd = &cobra.Command{
+	Use:   "verify-ignore [ignore_plan.json]",
+	Short: "Confirm cloudslash:ignore tags were applied",
+	Long: `Re-reads the tags on every resource in an ignore plan and reports any where
+the cloudslash:ignore tag did not stick (partial tagging failures, missing
+permissions, or services that reject the tag).
+
+Exits with code 1 if any resource is untagged.
+
+Example:
+  cloudslash verify-ignore
+  cloudslash verify-ignore cloudslash-out/ignore_plan.json`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		planPath := filepath.Join(config.OutputDir, "ignore_plan.json")
+		if len(args) == 1 {
+			planPath = args[0]
+		}
+
+		plan, err := remediation.ValidatePlan(planPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		targets := remediation.IgnoreTargets(plan)
+		if len(targets) == 0 {
+			fmt.Println("Ignore plan is empty. Nothing to verify.")
+			return
+		}
+
+		var arns []string
+		var index []int
+		for i, t := range targets {
+			if t.Skipped == "" {
+				arns = append(arns, t.ARN)
+				index = append(index, i)
+			}
+		}
+		var tags map[string]map[string]string
+		if len(arns) > 0 {
+			resolved, current, err := fetchPlanTags(context.Background(), arns)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			for j, i := range index {
+				targets[i].ARN = resolved[j]
+			}
+			tags = current
+		}
+
+		results := remediation.VerifyIgnoreTags(targets, tags)
+		sort.Slice(results, func(i, j int) bool { return results[i].ARN < results[j].ARN })
+
+		failed, skipped := 0, 0
+		for _, r := range results {
+			if r.Skipped != "" {
+				skipped++
+				fmt.Printf("%s %s (%s): skipped, %s\n", glyph("⚠️ ", "[SKIP]"), r.ID, r.Type, r.Skipped)
+				continue
+			}
+			if r.Applied {
+				continue
+			}
+			failed++
+			why := "tag missing"
+			if !r.Found {
+				why = "not returned by tagging API (deleted, untaggable, or no tags)"
+			}
+			fmt.Printf("%s %s (%s): %s\n", glyph("❌", "[FAIL]"), r.ID, r.Type, why)
+		}
+
+		fmt.Printf("\nVerified %d resources: %d tagged, %d not tagged, %d skipped.\n", len(results)-skipped, len(results)-skipped-failed, failed, skipped)
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(verifyIgnoreCmd)
+}
@@ -14,11 +14,27 @@ type PolicyConfig struct {
 // RiskConfig defines the parameters for the Bayesian risk engine.
 type RiskConfig struct {
 	// BaselineRisk is the minimum risk score (0.0 - 1.0).
-	BaselineRisk float64
+	BaselineRisk float64 `mapstructure:"baseline_risk"`
 	// DecayFactor is the risk decay rate over time.
-	DecayFactor float64
+	DecayFactor float64 `mapstructure:"decay_factor"`
 	// InterruptionPenalty is the risk spike applied upon failure.
-	InterruptionPenalty float64
+	InterruptionPenalty float64 `mapstructure:"interruption_penalty"`
+	// Weights are the per-factor risk contributions for a proposed change.
+	Weights RiskWeights `mapstructure:"weights"`
+}
+
+// RiskWeights defines how much each change characteristic adds to risk.
+type RiskWeights struct {
+	// Statefulness applies to workloads holding data (databases, caches, volumes).
+	Statefulness float64 `mapstructure:"statefulness"`
+	// CrossAZ applies when the workload moves to a different availability zone.
+	CrossAZ float64 `mapstructure:"cross_az"`
+	// Spot applies when the target capacity is spot.
+	Spot float64 `mapstructure:"spot"`
+	// ArchChange applies when the CPU architecture changes (e.g. x86 to Graviton).
+	ArchChange float64 `mapstructure:"arch_change"`
+	// Production applies to workloads tagged as production.
+	Production float64 `mapstructure:"production"`
 }
 
 // Defaults.
@@ -41,5 +57,12 @@ func DefaultRiskConfig() RiskConfig {
 		BaselineRisk:        0.05,
 		DecayFactor:         0.95,
 		InterruptionPenalty: 1.0,
+		Weights: RiskWeights{
+			Statefulness: 0.25,
+			CrossAZ:      0.10,
+			Spot:         0.20,
+			ArchChange:   0.15,
+			Production:   0.20,
+		},
 	}
 }
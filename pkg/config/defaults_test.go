@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 )
 
 func TestDefaultPolicyConfig(t *testing.T) {
@@ -37,4 +38,36 @@ func TestDefaultRiskConfig(t *testing.T) {
 	if config.DecayFactor >= 1.0 {
 		t.Error("DecayFactor must be less than 1.0 to ensure convergence")
 	}
+
+	w := config.Weights
+	for name, v := range map[string]float64{
+		"statefulness": w.Statefulness,
+		"cross_az":     w.CrossAZ,
+		"spot":         w.Spot,
+		"arch_change":  w.ArchChange,
+		"production":   w.Production,
+	} {
+		if v <= 0 || v > 1.0 {
+			t.Errorf("Expected weight %s in (0, 1], got %f", name, v)
+		}
+	}
+}
+
+func TestParseMetricWindow(t *testing.T) {
+	for in, want := range map[string]time.Duration{
+		"":    0,
+		"14d": 14 * 24 * time.Hour,
+		"30":  30 * 24 * time.Hour,
+		"36h": 36 * time.Hour,
+	} {
+		got, err := ParseMetricWindow(in)
+		if err != nil || got != want {
+			t.Errorf("ParseMetricWindow(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"0d", "-3d", "-1h", "two weeks"} {
+		if _, err := ParseMetricWindow(in); err == nil {
+			t.Errorf("ParseMetricWindow(%q) should fail", in)
+		}
+	}
 }
@@ -1,12 +1,26 @@
 package config
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultMetricWindow is the CloudWatch lookback of heuristics that do not set their own.
+const DefaultMetricWindow = 7 * 24 * time.Hour
 
 // HeuristicConfig defines settings for resource analysis heuristics.
 type HeuristicConfig struct {
 	IdleCluster      IdleClusterConfig      `mapstructure:"idle_cluster"`
 	UnattachedVolume UnattachedVolumeConfig `mapstructure:"unattached_volume"`
 	S3Multipart      S3MultipartConfig      `mapstructure:"s3_multipart"`
+	OrphanedSnapshot OrphanedSnapshotConfig `mapstructure:"orphaned_snapshot"`
+	// MetricWindow is the lookback for every CloudWatch-based heuristic, and for
+	// the scanners that read metrics for them (OpenSearch, SQS/SNS). Zero keeps
+	// each one's default: DefaultMetricWindow, or 14 days for the ones judging
+	// bursty usage (volumes, replicas, DMS, CloudFront, OpenSearch, messaging).
+	MetricWindow time.Duration `mapstructure:"metric_window"`
 }
 
 type IdleClusterConfig struct {
@@ -28,6 +42,11 @@ type S3MultipartConfig struct {
 	AgeThreshold time.Duration `mapstructure:"age_threshold"`
 }
 
+type OrphanedSnapshotConfig struct {
+	// MinAgeDays is the age a snapshot must reach before it is judged.
+	MinAgeDays int `mapstructure:"min_age_days"`
+}
+
 // DefaultHeuristicConfig returns a configuration with sensible default values.
 func DefaultHeuristicConfig() HeuristicConfig {
 	return HeuristicConfig{
@@ -42,5 +61,32 @@ func DefaultHeuristicConfig() HeuristicConfig {
 		S3Multipart: S3MultipartConfig{
 			AgeThreshold: 7 * 24 * time.Hour, // 7 days
 		},
+		OrphanedSnapshot: OrphanedSnapshotConfig{
+			MinAgeDays: 90,
+		},
+	}
+}
+
+// ParseMetricWindow parses a lookback window such as "14d" or "36h". A bare
+// number of days ("14") is accepted too. Empty means zero (the default).
+func ParseMetricWindow(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	days := strings.TrimSuffix(s, "d")
+	if n, err := strconv.Atoi(days); err == nil {
+		if n <= 0 {
+			return 0, fmt.Errorf("metric window must be positive: %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid metric window %q (use e.g. 14d or 36h)", s)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("metric window must be positive: %q", s)
 	}
+	return d, nil
 }
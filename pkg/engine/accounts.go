@@ -0,0 +1,170 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/heuristics"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// accountProperty is the node property holding the AWS account a resource was scanned in.
+const accountProperty = "AccountId"
+
+// scanTarget is one set of credentials to scan with: a shared-config profile
+// ("" for the default chain), optionally assuming a role from it.
+type scanTarget struct {
+	Profile string
+	RoleARN string
+}
+
+// String labels the target in logs, failed scopes and checkpoint names.
+func (t scanTarget) String() string {
+	if t.RoleARN != "" {
+		return t.RoleARN
+	}
+	if t.Profile == "" {
+		return "default"
+	}
+	return t.Profile
+}
+
+// newClient connects to region with the target's credentials.
+func (t scanTarget) newClient(ctx context.Context, region string, verbose bool) (*aws.Client, error) {
+	client, err := aws.NewClient(ctx, region, t.Profile, verbose)
+	if err != nil {
+		return nil, err
+	}
+	if t.RoleARN != "" {
+		client = client.AssumeRole(t.RoleARN)
+	}
+	return client, nil
+}
+
+// scanTargets resolves the credentials to scan with: every local profile
+// with --all-profiles, the roles listed by --assume-roles, or a role in every
+// member account with --org. Roles are assumed from the default credentials.
+func (e *Engine) scanTargets(ctx context.Context) ([]scanTarget, error) {
+	roleName := e.config.OrgRole
+	if roleName == "" {
+		roleName = aws.DefaultOrgRole
+	}
+
+	switch {
+	case e.config.AssumeRolesFile != "":
+		f, err := os.Open(e.config.AssumeRolesFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open role list: %v", err)
+		}
+		defer f.Close()
+		roles, err := aws.ParseRoleList(f, roleName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid role list %s: %v", e.config.AssumeRolesFile, err)
+		}
+		if len(roles) == 0 {
+			return nil, fmt.Errorf("role list %s is empty", e.config.AssumeRolesFile)
+		}
+		e.Logger.Info("Scanning accounts by assumed role", "roles", len(roles))
+		return roleTargets(roles), nil
+
+	case e.config.Org:
+		client, err := aws.NewClient(ctx, e.scanRegion(), "", e.config.Verbose)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create AWS client: %v", err)
+		}
+		roles, err := client.OrgRoleARNs(ctx, roleName)
+		if err != nil {
+			return nil, err
+		}
+		e.Logger.Info("Scanning organization", "member_accounts", len(roles), "role", roleName)
+		// The management account is scanned with the caller's own credentials.
+		return append([]scanTarget{{}}, roleTargets(roles)...), nil
+
+	case e.config.AllProfiles:
+		profiles, err := aws.ListProfiles()
+		if err != nil {
+			e.Logger.Warn("Failed to list profiles. Using default", "error", err)
+			return []scanTarget{{Profile: "default"}}, nil
+		}
+		e.Logger.Info("Deep Scanning enabled", "profiles", len(profiles))
+		targets := make([]scanTarget, 0, len(profiles))
+		for _, p := range profiles {
+			targets = append(targets, scanTarget{Profile: p})
+		}
+		return targets, nil
+	}
+	return []scanTarget{{}}, nil
+}
+
+// summaryAccount labels the scanned accounts in the executive summary.
+func (e *Engine) summaryAccount() string {
+	switch len(e.accounts) {
+	case 0:
+		return "AWS-ACCOUNT"
+	case 1:
+		return e.accounts[0]
+	}
+	return fmt.Sprintf("%d accounts", len(e.accounts))
+}
+
+// sharingAudit builds the AMI and snapshot sharing audit. Sharing with a
+// scanned account is trusted, as is sharing within the organization when
+// client's account may list it.
+func (e *Engine) sharingAudit(ctx context.Context, client *aws.Client, scanned []string, region string) *heuristics.SharingAuditHeuristic {
+	h := &heuristics.SharingAuditHeuristic{Pricing: e.Pricing, Region: region, TrustedAccounts: make(map[string]bool)}
+	for _, id := range scanned {
+		h.TrustedAccounts[id] = true
+	}
+	if client == nil {
+		return h
+	}
+	ids, err := client.OrgAccountIDs(ctx)
+	if err != nil {
+		e.Logger.Debug("Organization accounts unavailable; trusting only scanned accounts for sharing", "error", err)
+		return h
+	}
+	for _, id := range ids {
+		h.TrustedAccounts[id] = true
+	}
+	h.OrgListed = true
+	return h
+}
+
+func roleTargets(roles []string) []scanTarget {
+	targets := make([]scanTarget, 0, len(roles))
+	for _, r := range roles {
+		targets = append(targets, scanTarget{RoleARN: r})
+	}
+	return targets
+}
+
+// isAWSType reports whether a node type belongs to an AWS resource, as
+// opposed to GCP, Azure, Kubernetes or an unresolved edge target.
+func isAWSType(t string) bool {
+	return strings.HasPrefix(t, "AWS::") || strings.HasPrefix(t, "aws_")
+}
+
+// stampAccount records the account on AWS nodes that carry none yet: the
+// one in the node's ARN, else account.
+func stampAccount(g *graph.Graph, account string) {
+	g.Mu.Lock()
+	defer g.Mu.Unlock()
+	for _, node := range g.Store.GetAllNodes() {
+		if _, ok := node.Properties[accountProperty]; ok || !isAWSType(node.TypeStr()) {
+			continue
+		}
+		owner := account
+		// Some scanners write an "account" placeholder into their ARNs.
+		if parsed, err := arn.Parse(node.IDStr()); err == nil && parsed.AccountID != "" && parsed.AccountID != "account" {
+			owner = parsed.AccountID
+		}
+		if owner != "" {
+			node.Properties[accountProperty] = owner
+		}
+	}
+}
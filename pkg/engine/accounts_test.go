@@ -0,0 +1,65 @@
+package engine
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+func TestScanTargetsFromRoleFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "roles.txt")
+	roles := "# prod\narn:aws:iam::111111111111:role/Audit\n222222222222\n"
+	if err := os.WriteFile(path, []byte(roles), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	e := &Engine{config: Config{AssumeRolesFile: path, OrgRole: "Scanner"}, Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	targets, err := e.scanTargets(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"arn:aws:iam::111111111111:role/Audit", "arn:aws:iam::222222222222:role/Scanner"}
+	if len(targets) != len(want) {
+		t.Fatalf("Expected %d targets, got %v", len(want), targets)
+	}
+	for i, target := range targets {
+		if target.RoleARN != want[i] || target.Profile != "" {
+			t.Errorf("target %d = %+v, want role %s from the default credentials", i, target, want[i])
+		}
+	}
+
+	e.config.AssumeRolesFile = filepath.Join(t.TempDir(), "missing.txt")
+	if _, err := e.scanTargets(context.Background()); err == nil {
+		t.Error("Expected an error for a missing role list")
+	}
+}
+
+func TestStampAccount(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:s3:::logs", "AWS::S3::Bucket", map[string]interface{}{})
+	g.AddNode("orders", "aws_dynamodb_table", map[string]interface{}{"AccountId": "111111111111"})
+	g.AddNode("projects/p/zones/z/disks/d", "GCP::Compute::Disk", map[string]interface{}{})
+	g.AddNode("arn:aws:ec2:region:account:volume/vol-1", "AWS::EC2::Volume", map[string]interface{}{})
+	g.CloseAndWait()
+
+	stampAccount(g, "222222222222")
+
+	if got := g.GetNode("arn:aws:ec2:region:account:volume/vol-1").Properties["AccountId"]; got != "222222222222" {
+		t.Errorf("Expected the ARN placeholder to be ignored, got %v", got)
+	}
+
+	if got := g.GetNode("arn:aws:s3:::logs").Properties["AccountId"]; got != "222222222222" {
+		t.Errorf("Expected the bucket stamped with the scanned account, got %v", got)
+	}
+	if got := g.GetNode("orders").Properties["AccountId"]; got != "111111111111" {
+		t.Errorf("Expected an existing account to be kept, got %v", got)
+	}
+	if _, ok := g.GetNode("projects/p/zones/z/disks/d").Properties["AccountId"]; ok {
+		t.Error("Expected non-AWS nodes to be left alone")
+	}
+}
@@ -0,0 +1,47 @@
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/report"
+)
+
+// Summary returns the summary reported by the last run. ok is false when no
+// run has reported one, e.g. before Run or after an interrupted scan.
+func (e *Engine) Summary() (summary report.Summary, ok bool) {
+	if e.summary == nil {
+		return report.Summary{}, false
+	}
+	return *e.summary, true
+}
+
+// Artifacts lists the files the last run wrote to the output directory,
+// sorted. Files left there by earlier runs are excluded. With an S3 output
+// directory, paths are the uploaded s3:// URLs.
+func (e *Engine) Artifacts() []string {
+	if e.started.IsZero() || e.config.PlanOnly {
+		return nil
+	}
+	// Filesystem timestamps may be coarser than the clock.
+	since := e.started.Truncate(time.Second)
+
+	var out []string
+	filepath.Walk(e.outputDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || info.ModTime().Before(since) {
+			return nil
+		}
+		if e.s3Target != "" {
+			if rel, err := filepath.Rel(e.outputDir, path); err == nil {
+				path = strings.TrimSuffix(e.s3Target, "/") + "/" + filepath.ToSlash(rel)
+			}
+		}
+		out = append(out, path)
+		return nil
+	})
+	sort.Strings(out)
+	return out
+}
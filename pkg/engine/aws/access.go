@@ -0,0 +1,42 @@
+package aws
+
+import (
+	"errors"
+
+	"github.com/aws/smithy-go"
+)
+
+// PropertyErrorsKey is the node property listing describe calls that were denied.
+// Values are "<iam action>: AccessDenied" so the gap maps directly to a missing permission.
+const PropertyErrorsKey = "_errors"
+
+// accessDeniedCodes are the authorization error codes used across AWS services.
+var accessDeniedCodes = map[string]bool{
+	"AccessDenied":          true,
+	"AccessDeniedException": true,
+	"UnauthorizedOperation": true,
+	"AuthorizationError":    true,
+	"Forbidden":             true,
+}
+
+// IsAccessDenied reports whether err is an authorization failure.
+func IsAccessDenied(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && accessDeniedCodes[apiErr.ErrorCode()]
+}
+
+// RecordPropertyError notes a denied property fetch in props so findings on the
+// resource can be downgraded. Other errors are not recorded. Reports whether err was recorded.
+func RecordPropertyError(props map[string]interface{}, action string, err error) bool {
+	if !IsAccessDenied(err) {
+		return false
+	}
+	errs, _ := props[PropertyErrorsKey].([]string)
+	for _, e := range errs {
+		if e == action+": AccessDenied" {
+			return true
+		}
+	}
+	props[PropertyErrorsKey] = append(errs, action+": AccessDenied")
+	return true
+}
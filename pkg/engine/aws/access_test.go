@@ -0,0 +1,31 @@
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/smithy-go"
+)
+
+func TestRecordPropertyError(t *testing.T) {
+	props := map[string]interface{}{}
+	denied := fmt.Errorf("operation error S3: GetBucketLifecycleConfiguration: %w", &smithy.GenericAPIError{Code: "AccessDenied"})
+
+	if !RecordPropertyError(props, "s3:GetLifecycleConfiguration", denied) {
+		t.Fatal("Expected wrapped AccessDenied to be recorded")
+	}
+	// Repeated denials of the same call are recorded once.
+	RecordPropertyError(props, "s3:GetLifecycleConfiguration", denied)
+	if RecordPropertyError(props, "s3:GetBucketLocation", &smithy.GenericAPIError{Code: "NoSuchLifecycleConfiguration"}) {
+		t.Error("Expected non-authorization errors to be ignored")
+	}
+	if RecordPropertyError(props, "s3:GetBucketLocation", errors.New("timeout")) {
+		t.Error("Expected plain errors to be ignored")
+	}
+
+	errs, _ := props[PropertyErrorsKey].([]string)
+	if len(errs) != 1 || errs[0] != "s3:GetLifecycleConfiguration: AccessDenied" {
+		t.Errorf("Unexpected _errors: %v", errs)
+	}
+}
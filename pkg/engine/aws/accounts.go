@@ -0,0 +1,141 @@
+package aws
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	orgtypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// DefaultOrgRole is the role Organizations creates in every member account.
+const DefaultOrgRole = "OrganizationAccountAccessRole"
+
+// roleSessionName identifies CloudSlash in member accounts' CloudTrail.
+const roleSessionName = "cloudslash-scan"
+
+var accountIDPattern = regexp.MustCompile(`^\d{12}$`)
+
+// RoleARN returns the ARN of roleName in accountID.
+func RoleARN(partition, accountID, roleName string) string {
+	if partition == "" {
+		partition = "aws"
+	}
+	return fmt.Sprintf("arn:%s:iam::%s:role/%s", partition, accountID, roleName)
+}
+
+// ParseRoleList reads one target per line: a role ARN, or a bare account ID
+// that expands to roleName in that account. Blank lines and # comments are
+// skipped; duplicates are dropped.
+func ParseRoleList(r io.Reader, roleName string) ([]string, error) {
+	var roles []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		entry := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(entry, "#"); i >= 0 {
+			entry = strings.TrimSpace(entry[:i])
+		}
+		if entry == "" {
+			continue
+		}
+
+		switch {
+		case accountIDPattern.MatchString(entry):
+			entry = RoleARN("aws", entry, roleName)
+		case arn.IsARN(entry):
+			parsed, err := arn.Parse(entry)
+			if err != nil || parsed.Service != "iam" || !strings.HasPrefix(parsed.Resource, "role/") {
+				return nil, fmt.Errorf("line %d: %q is not an IAM role ARN", line, entry)
+			}
+		default:
+			return nil, fmt.Errorf("line %d: %q is neither a role ARN nor an account ID", line, entry)
+		}
+		if !seen[entry] {
+			seen[entry] = true
+			roles = append(roles, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read role list: %v", err)
+	}
+	return roles, nil
+}
+
+type orgAccountsAPI interface {
+	organizations.ListAccountsAPIClient
+}
+
+// OrgRoleARNs lists the active accounts of the caller's organization and
+// returns roleName in each. The caller's own account is skipped: it is scanned
+// with the caller's credentials rather than through a role.
+func (c *Client) OrgRoleARNs(ctx context.Context, roleName string) ([]string, error) {
+	caller, err := c.CallerARN(ctx)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := arn.Parse(caller)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse caller ARN: %v", err)
+	}
+	return orgRoleARNs(ctx, organizations.NewFromConfig(c.Config), parsed.Partition, parsed.AccountID, roleName)
+}
+
+func orgRoleARNs(ctx context.Context, client orgAccountsAPI, partition, self, roleName string) ([]string, error) {
+	ids, err := orgAccountIDs(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	var roles []string
+	for _, id := range ids {
+		if id != self {
+			roles = append(roles, RoleARN(partition, id, roleName))
+		}
+	}
+	return roles, nil
+}
+
+// OrgAccountIDs lists the active accounts of the caller's organization. Only
+// the management account and delegated administrators may list them.
+func (c *Client) OrgAccountIDs(ctx context.Context) ([]string, error) {
+	return orgAccountIDs(ctx, organizations.NewFromConfig(c.Config))
+}
+
+func orgAccountIDs(ctx context.Context, client orgAccountsAPI) ([]string, error) {
+	var ids []string
+	paginator := organizations.NewListAccountsPaginator(client, &organizations.ListAccountsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list organization accounts: %v", err)
+		}
+		for _, acct := range page.Accounts {
+			if acct.Status == orgtypes.AccountStatusActive {
+				ids = append(ids, aws.ToString(acct.Id))
+			}
+		}
+	}
+	return ids, nil
+}
+
+// AssumeRole returns a client for the same region whose credentials come from
+// assuming roleARN. Credentials are fetched on first use and refreshed before
+// they expire, so long scans outlive the one-hour session.
+func (c *Client) AssumeRole(roleARN string) *Client {
+	cfg := c.Config.Copy()
+	cfg.Credentials = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(c.STS, roleARN, func(o *stscreds.AssumeRoleOptions) {
+		o.RoleSessionName = roleSessionName
+	}))
+	return &Client{
+		Config: cfg,
+		STS:    sts.NewFromConfig(cfg),
+	}
+}
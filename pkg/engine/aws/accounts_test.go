@@ -0,0 +1,78 @@
+package aws
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	orgtypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+)
+
+func TestParseRoleList(t *testing.T) {
+	input := `# member accounts
+111111111111
+arn:aws:iam::222222222222:role/Audit   # custom role
+111111111111
+
+arn:aws-us-gov:iam::333333333333:role/ops/Scanner
+`
+	roles, err := ParseRoleList(strings.NewReader(input), DefaultOrgRole)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"arn:aws:iam::111111111111:role/OrganizationAccountAccessRole",
+		"arn:aws:iam::222222222222:role/Audit",
+		"arn:aws-us-gov:iam::333333333333:role/ops/Scanner",
+	}
+	if strings.Join(roles, ",") != strings.Join(want, ",") {
+		t.Errorf("ParseRoleList = %v, want %v", roles, want)
+	}
+
+	for _, bad := range []string{"prod-account", "arn:aws:iam::111111111111:user/alice", "12345"} {
+		if _, err := ParseRoleList(strings.NewReader(bad), DefaultOrgRole); err == nil {
+			t.Errorf("Expected %q to be rejected", bad)
+		}
+	}
+}
+
+type fakeOrganizations struct {
+	pages [][]orgtypes.Account
+}
+
+func (f *fakeOrganizations) ListAccounts(ctx context.Context, in *organizations.ListAccountsInput, _ ...func(*organizations.Options)) (*organizations.ListAccountsOutput, error) {
+	i := 0
+	if in.NextToken != nil {
+		i = len(aws.ToString(in.NextToken))
+	}
+	out := &organizations.ListAccountsOutput{Accounts: f.pages[i]}
+	if i+1 < len(f.pages) {
+		out.NextToken = aws.String(strings.Repeat("x", i+1))
+	}
+	return out, nil
+}
+
+func TestOrgRoleARNs(t *testing.T) {
+	client := &fakeOrganizations{pages: [][]orgtypes.Account{
+		{
+			{Id: aws.String("100000000000"), Status: orgtypes.AccountStatusActive},
+			{Id: aws.String("111111111111"), Status: orgtypes.AccountStatusActive},
+		},
+		{
+			{Id: aws.String("222222222222"), Status: orgtypes.AccountStatusSuspended},
+			{Id: aws.String("333333333333"), Status: orgtypes.AccountStatusActive},
+		},
+	}}
+
+	roles, err := orgRoleARNs(context.Background(), client, "aws", "100000000000", "Scanner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The management account and suspended accounts are skipped.
+	want := "arn:aws:iam::111111111111:role/Scanner,arn:aws:iam::333333333333:role/Scanner"
+	if got := strings.Join(roles, ","); got != want {
+		t.Errorf("orgRoleARNs = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,204 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/codebuild"
+	cbtypes "github.com/aws/aws-sdk-go-v2/service/codebuild/types"
+	"github.com/aws/aws-sdk-go-v2/service/codepipeline"
+)
+
+// CICDScanner scans CodeBuild projects and CodePipeline pipelines.
+type CICDScanner struct {
+	CodeBuild    *codebuild.Client
+	CodePipeline *codepipeline.Client
+	Graph        *graph.Graph
+}
+
+// NewCICDScanner initializes a scanner for CI/CD resources.
+func NewCICDScanner(cfg aws.Config, g *graph.Graph) *CICDScanner {
+	return &CICDScanner{
+		CodeBuild:    codebuild.NewFromConfig(cfg),
+		CodePipeline: codepipeline.NewFromConfig(cfg),
+		Graph:        g,
+	}
+}
+
+// artifactBucketARN matches the bucket IDs used by the S3 scanner.
+func artifactBucketARN(location string) string {
+	// Locations may carry a key prefix ("bucket/path").
+	bucket := strings.SplitN(location, "/", 2)[0]
+	return fmt.Sprintf("arn:aws:s3:::bucket/%s", bucket)
+}
+
+// ScanProjects maps CodeBuild projects and their artifact buckets.
+func (s *CICDScanner) ScanProjects(ctx context.Context) error {
+	paginator := codebuild.NewListProjectsPaginator(s.CodeBuild, &codebuild.ListProjectsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list codebuild projects: %v", err)
+		}
+		if len(page.Projects) == 0 {
+			continue
+		}
+
+		out, err := s.CodeBuild.BatchGetProjects(ctx, &codebuild.BatchGetProjectsInput{Names: page.Projects})
+		if err != nil {
+			return fmt.Errorf("failed to describe codebuild projects: %v", err)
+		}
+
+		for _, p := range out.Projects {
+			id := aws.ToString(p.Arn)
+			props := map[string]interface{}{
+				"Name":    aws.ToString(p.Name),
+				"Created": aws.ToTime(p.Created),
+				"Tags":    codebuildTags(p.Tags),
+			}
+			if p.EncryptionKey != nil {
+				props["EncryptionKey"] = aws.ToString(p.EncryptionKey)
+			}
+			s.lastBuildTime(ctx, aws.ToString(p.Name), props)
+
+			var buckets []string
+			if p.Artifacts != nil && p.Artifacts.Type == cbtypes.ArtifactsTypeS3 && p.Artifacts.Location != nil {
+				buckets = append(buckets, artifactBucketARN(*p.Artifacts.Location))
+			}
+			for _, a := range p.SecondaryArtifacts {
+				if a.Type == cbtypes.ArtifactsTypeS3 && a.Location != nil {
+					buckets = append(buckets, artifactBucketARN(*a.Location))
+				}
+			}
+			props["ArtifactBuckets"] = buckets
+
+			s.Graph.AddNode(id, "AWS::CodeBuild::Project", props)
+			for _, b := range buckets {
+				s.Graph.AddTypedEdge(id, b, graph.EdgeTypeUses, 1)
+			}
+		}
+	}
+	return nil
+}
+
+// lastBuildTime records the start time of the most recent build as
+// LastBuildTime. A project that never built gets none; one whose builds
+// could not be read is marked ActivityUnknown.
+func (s *CICDScanner) lastBuildTime(ctx context.Context, project string, props map[string]interface{}) {
+	ids, err := s.CodeBuild.ListBuildsForProject(ctx, &codebuild.ListBuildsForProjectInput{
+		ProjectName: aws.String(project),
+		SortOrder:   cbtypes.SortOrderTypeDescending,
+	})
+	if err != nil {
+		s.recordActivityError(props, "codebuild:ListBuildsForProject", project, err)
+		return
+	}
+	if len(ids.Ids) == 0 {
+		return
+	}
+
+	builds, err := s.CodeBuild.BatchGetBuilds(ctx, &codebuild.BatchGetBuildsInput{Ids: ids.Ids[:1]})
+	if err != nil {
+		s.recordActivityError(props, "codebuild:BatchGetBuilds", project, err)
+		return
+	}
+	if len(builds.Builds) > 0 && builds.Builds[0].StartTime != nil {
+		props["LastBuildTime"] = *builds.Builds[0].StartTime
+	}
+}
+
+// recordActivityError marks a resource whose last activity could not be read,
+// so a throttled or denied call is never mistaken for no activity. Denials
+// are noted on the resource; other errors are reported.
+func (s *CICDScanner) recordActivityError(props map[string]interface{}, action, name string, err error) {
+	props["ActivityUnknown"] = true
+	if !RecordPropertyError(props, action, err) {
+		s.Graph.AddError(fmt.Sprintf("CI/CD [%s]", name), fmt.Errorf("failed to read last activity: %v", err))
+	}
+}
+
+// ScanPipelines maps pipelines, their artifact stores and CodeBuild actions.
+func (s *CICDScanner) ScanPipelines(ctx context.Context) error {
+	paginator := codepipeline.NewListPipelinesPaginator(s.CodePipeline, &codepipeline.ListPipelinesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list pipelines: %v", err)
+		}
+
+		for _, summary := range page.Pipelines {
+			name := aws.ToString(summary.Name)
+			out, err := s.CodePipeline.GetPipeline(ctx, &codepipeline.GetPipelineInput{Name: summary.Name})
+			if err != nil || out.Pipeline == nil || out.Metadata == nil {
+				continue
+			}
+			id := aws.ToString(out.Metadata.PipelineArn)
+
+			props := map[string]interface{}{
+				"Name":    name,
+				"Created": aws.ToTime(summary.Created),
+			}
+
+			execs, err := s.CodePipeline.ListPipelineExecutions(ctx, &codepipeline.ListPipelineExecutionsInput{
+				PipelineName: summary.Name,
+				MaxResults:   aws.Int32(1),
+			})
+			if err != nil {
+				s.recordActivityError(props, "codepipeline:ListPipelineExecutions", name, err)
+			} else if len(execs.PipelineExecutionSummaries) > 0 && execs.PipelineExecutionSummaries[0].StartTime != nil {
+				props["LastExecutionTime"] = *execs.PipelineExecutionSummaries[0].StartTime
+			}
+
+			var buckets []string
+			if store := out.Pipeline.ArtifactStore; store != nil && store.Location != nil {
+				buckets = append(buckets, artifactBucketARN(*store.Location))
+			}
+			for _, store := range out.Pipeline.ArtifactStores {
+				if store.Location != nil {
+					buckets = append(buckets, artifactBucketARN(*store.Location))
+				}
+			}
+			props["ArtifactBuckets"] = buckets
+
+			s.Graph.AddNode(id, "AWS::CodePipeline::Pipeline", props)
+			for _, b := range buckets {
+				s.Graph.AddTypedEdge(id, b, graph.EdgeTypeUses, 1)
+			}
+
+			// CodeBuild actions tie the pipeline's build projects into the same stack.
+			for _, stage := range out.Pipeline.Stages {
+				for _, action := range stage.Actions {
+					if action.ActionTypeId == nil || aws.ToString(action.ActionTypeId.Provider) != "CodeBuild" {
+						continue
+					}
+					if project := action.Configuration["ProjectName"]; project != "" {
+						s.Graph.AddTypedEdge(id, codebuildProjectARN(id, project), graph.EdgeTypeRuns, 1)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// codebuildProjectARN derives a project ARN from the pipeline's region and account.
+func codebuildProjectARN(pipelineARN, project string) string {
+	parts := strings.Split(pipelineARN, ":")
+	if len(parts) < 5 {
+		return project
+	}
+	return fmt.Sprintf("arn:aws:codebuild:%s:%s:project/%s", parts[3], parts[4], project)
+}
+
+func codebuildTags(tags []cbtypes.Tag) map[string]string {
+	out := make(map[string]string)
+	for _, t := range tags {
+		if t.Key != nil && t.Value != nil {
+			out[*t.Key] = *t.Value
+		}
+	}
+	return out
+}
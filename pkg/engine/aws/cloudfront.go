@@ -0,0 +1,210 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// CloudFrontScanner scans CloudFront distributions and links them to their origins.
+type CloudFrontScanner struct {
+	Client *cloudfront.Client
+	Graph  *graph.Graph
+
+	// LoadBalancers maps lowercase DNS names to load balancer ARNs in a region.
+	LoadBalancers func(ctx context.Context, region string) (map[string]string, error)
+	// Resolves reports whether host still has DNS records.
+	Resolves func(ctx context.Context, host string) (bool, error)
+	// BucketExists reports whether a bucket exists in any account.
+	BucketExists func(ctx context.Context, bucket string) (bool, error)
+}
+
+// NewCloudFrontScanner initializes a scanner for CloudFront.
+func NewCloudFrontScanner(cfg aws.Config, g *graph.Graph) *CloudFrontScanner {
+	s3Client := s3.NewFromConfig(cfg)
+	return &CloudFrontScanner{
+		Client: cloudfront.NewFromConfig(cfg),
+		Graph:  g,
+		LoadBalancers: func(ctx context.Context, region string) (map[string]string, error) {
+			return describeLoadBalancersByDNS(ctx, cfg, region)
+		},
+		Resolves: hostResolves,
+		BucketExists: func(ctx context.Context, bucket string) (bool, error) {
+			return bucketExists(ctx, s3Client, bucket)
+		},
+	}
+}
+
+// ScanDistributions maps distributions as AWS::CloudFront::Distribution nodes.
+// S3 and load balancer origins are linked with Uses edges. Origins confirmed
+// gone (a bucket S3 reports missing, a load balancer name that no longer
+// resolves) are recorded in UnresolvedOrigins; origins in other accounts, or
+// that cannot be checked, are left alone.
+func (s *CloudFrontScanner) ScanDistributions(ctx context.Context) error {
+	lbCache := make(map[string]map[string]string)
+
+	paginator := cloudfront.NewListDistributionsPaginator(s.Client, &cloudfront.ListDistributionsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list distributions: %v", err)
+		}
+		if page.DistributionList == nil {
+			continue
+		}
+
+		for _, d := range page.DistributionList.Items {
+			id := aws.ToString(d.ARN)
+			props := distributionProps(d)
+
+			var unresolved []string
+			var targets []string
+			for _, domain := range props["OriginDomains"].([]string) {
+				target, missing := s.resolveOrigin(ctx, lbCache, domain)
+				if target != "" {
+					targets = append(targets, target)
+				}
+				if missing {
+					unresolved = append(unresolved, domain)
+				}
+			}
+			props["UnresolvedOrigins"] = unresolved
+
+			s.Graph.AddNode(id, "AWS::CloudFront::Distribution", props)
+			for _, target := range targets {
+				s.Graph.AddTypedEdge(id, target, graph.EdgeTypeUses, 100)
+			}
+		}
+	}
+	return nil
+}
+
+// resolveOrigin returns the node an origin domain links to, if any, and whether
+// the origin is confirmed gone. Anything that cannot be checked is not missing.
+func (s *CloudFrontScanner) resolveOrigin(ctx context.Context, lbCache map[string]map[string]string, domain string) (string, bool) {
+	if bucket, ok := S3OriginBucket(domain); ok {
+		if s.BucketExists == nil {
+			return S3BucketARN(bucket), false
+		}
+		exists, err := s.BucketExists(ctx, bucket)
+		return S3BucketARN(bucket), err == nil && !exists
+	}
+
+	region, ok := elbOriginRegion(domain)
+	if !ok {
+		return "", false // Custom origins are outside the account.
+	}
+	if byDNS, err := s.loadBalancersByDNS(ctx, lbCache, region); err == nil {
+		if lbARN, found := byDNS[strings.TrimPrefix(strings.ToLower(domain), "dualstack.")]; found {
+			return lbARN, false // Classic load balancers have an empty ARN and no node.
+		}
+	}
+	// Not in this account: it may belong to another one, so only a name
+	// that no longer resolves is missing.
+	if s.Resolves == nil {
+		return "", false
+	}
+	live, err := s.Resolves(ctx, domain)
+	return "", err == nil && !live
+}
+
+// loadBalancersByDNS maps lowercase DNS names to load balancer ARNs in region, cached per scan.
+func (s *CloudFrontScanner) loadBalancersByDNS(ctx context.Context, cache map[string]map[string]string, region string) (map[string]string, error) {
+	if byDNS, ok := cache[region]; ok {
+		return byDNS, nil
+	}
+	if s.LoadBalancers == nil {
+		return nil, fmt.Errorf("no load balancer lookup")
+	}
+
+	byDNS, err := s.LoadBalancers(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+	cache[region] = byDNS
+	return byDNS, nil
+}
+
+// bucketExists asks S3 whether bucket exists. Only NotFound means it does not;
+// any other error (access denied for another account's bucket, a redirect
+// to another region) is returned as is.
+func bucketExists(ctx context.Context, client *s3.Client, bucket string) (bool, error) {
+	_, err := client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(bucket)})
+	var notFound *s3types.NotFound
+	if errors.As(err, &notFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func distributionProps(d cftypes.DistributionSummary) map[string]interface{} {
+	distID := aws.ToString(d.Id)
+	var origins []string
+	if d.Origins != nil {
+		for _, o := range d.Origins.Items {
+			origins = append(origins, aws.ToString(o.DomainName))
+		}
+	}
+	var aliases []string
+	if d.Aliases != nil {
+		aliases = d.Aliases.Items
+	}
+
+	props := map[string]interface{}{
+		"DistributionId": distID,
+		"Name":           aws.ToString(d.DomainName),
+		"Enabled":        aws.ToBool(d.Enabled),
+		"Status":         aws.ToString(d.Status),
+		"PriceClass":     string(d.PriceClass),
+		"Aliases":        aliases,
+		"OriginDomains":  origins,
+		"Region":         "global",
+		// CloudFront publishes metrics in us-east-1 under Region=Global.
+		"MetricDimensions": []string{"DistributionId=" + distID + ",Region=Global"},
+	}
+	if d.LastModifiedTime != nil {
+		props["LastModifiedTime"] = *d.LastModifiedTime
+	}
+	return props
+}
+
+// S3BucketARN is the node ID S3Scanner uses for a bucket.
+func S3BucketARN(bucket string) string {
+	return fmt.Sprintf("arn:aws:s3:::bucket/%s", bucket)
+}
+
+// S3OriginBucket extracts the bucket from an S3 REST or website endpoint,
+// e.g. "assets.s3.us-east-1.amazonaws.com" or "assets.s3-website-us-east-1.amazonaws.com".
+func S3OriginBucket(domain string) (string, bool) {
+	domain = strings.ToLower(domain)
+	if !strings.HasSuffix(domain, ".amazonaws.com") {
+		return "", false
+	}
+	i := strings.LastIndex(domain, ".s3.")
+	if j := strings.LastIndex(domain, ".s3-"); j > i {
+		i = j
+	}
+	if i <= 0 {
+		return "", false
+	}
+	return domain[:i], true
+}
+
+// elbOriginRegion returns the region of an ELB DNS name,
+// e.g. "web-123.eu-west-1.elb.amazonaws.com".
+func elbOriginRegion(domain string) (string, bool) {
+	parts := strings.Split(strings.ToLower(domain), ".")
+	n := len(parts)
+	if n < 5 || parts[n-3] != "elb" || parts[n-2] != "amazonaws" || parts[n-1] != "com" {
+		return "", false
+	}
+	return parts[n-4], true
+}
@@ -0,0 +1,61 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestCloudFrontResolveOrigin(t *testing.T) {
+	s := &CloudFrontScanner{
+		LoadBalancers: func(ctx context.Context, region string) (map[string]string, error) {
+			if region == "eu-west-1" {
+				return nil, fmt.Errorf("access denied")
+			}
+			return map[string]string{
+				"web-1.us-east-1.elb.amazonaws.com":     "arn:aws:elasticloadbalancing:us-east-1:123:loadbalancer/app/web/1",
+				"classic-1.us-east-1.elb.amazonaws.com": "",
+			}, nil
+		},
+		Resolves: func(ctx context.Context, host string) (bool, error) {
+			switch host {
+			case "partner-1.us-east-1.elb.amazonaws.com":
+				return true, nil
+			case "flaky-1.eu-west-1.elb.amazonaws.com":
+				return false, fmt.Errorf("timeout")
+			}
+			return false, nil
+		},
+		BucketExists: func(ctx context.Context, bucket string) (bool, error) {
+			switch bucket {
+			case "deleted-site":
+				return false, nil
+			case "partner-assets":
+				return false, fmt.Errorf("forbidden")
+			}
+			return true, nil
+		},
+	}
+
+	cases := []struct {
+		domain, target string
+		missing        bool
+	}{
+		{"assets.s3.amazonaws.com", S3BucketARN("assets"), false},
+		{"deleted-site.s3.amazonaws.com", S3BucketARN("deleted-site"), true},
+		{"partner-assets.s3.amazonaws.com", S3BucketARN("partner-assets"), false},
+		{"dualstack.web-1.us-east-1.elb.amazonaws.com", "arn:aws:elasticloadbalancing:us-east-1:123:loadbalancer/app/web/1", false},
+		{"classic-1.us-east-1.elb.amazonaws.com", "", false},
+		{"partner-1.us-east-1.elb.amazonaws.com", "", false}, // Another account's load balancer.
+		{"gone-1.us-east-1.elb.amazonaws.com", "", true},
+		{"flaky-1.eu-west-1.elb.amazonaws.com", "", false},
+		{"origin.example.com", "", false},
+	}
+	cache := make(map[string]map[string]string)
+	for _, c := range cases {
+		target, missing := s.resolveOrigin(context.Background(), cache, c.domain)
+		if target != c.target || missing != c.missing {
+			t.Errorf("resolveOrigin(%s) = %q, %v; want %q, %v", c.domain, target, missing, c.target, c.missing)
+		}
+	}
+}
@@ -64,6 +64,58 @@ func (c *CloudTrailClient) LookupCreator(ctx context.Context, resourceID string)
 	return "", fmt.Errorf("creator not found in CloudTrail (90 days)")
 }
 
+// keyUseMaxPages bounds LookupKeyLastUsed on keys with heavy management
+// activity; LookupEvents is limited to 2 calls/s.
+const keyUseMaxPages = 5
+
+// LookupKeyLastUsed searches CloudTrail for the latest cryptographic
+// operation on a KMS key (90 days). A zero time with no error means the key
+// was not used in the window.
+func (c *CloudTrailClient) LookupKeyLastUsed(ctx context.Context, keyARN string) (time.Time, error) {
+	endTime := time.Now()
+	startTime := endTime.AddDate(0, 0, -90)
+
+	input := &cloudtrail.LookupEventsInput{
+		LookupAttributes: []types.LookupAttribute{
+			{
+				AttributeKey:   types.LookupAttributeKeyResourceName,
+				AttributeValue: aws.String(keyARN),
+			},
+		},
+		StartTime:  &startTime,
+		EndTime:    &endTime,
+		MaxResults: aws.Int32(50),
+	}
+
+	// Events arrive newest first; the first use is the last one.
+	paginator := cloudtrail.NewLookupEventsPaginator(c.Client, input)
+	for page := 0; paginator.HasMorePages(); page++ {
+		if page == keyUseMaxPages {
+			return time.Time{}, fmt.Errorf("no key use among the latest %d events", keyUseMaxPages*50)
+		}
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return time.Time{}, err
+		}
+		for _, event := range output.Events {
+			if isKeyUseEvent(aws.ToString(event.EventName)) && event.EventTime != nil {
+				return *event.EventTime, nil
+			}
+		}
+	}
+	return time.Time{}, nil
+}
+
+func isKeyUseEvent(name string) bool {
+	switch name {
+	case "Encrypt", "Decrypt", "ReEncrypt", "GenerateDataKey", "GenerateDataKeyWithoutPlaintext",
+		"GenerateDataKeyPair", "GenerateDataKeyPairWithoutPlaintext", "Sign", "Verify",
+		"GenerateMac", "VerifyMac", "DeriveSharedSecret", "CreateGrant":
+		return true
+	}
+	return false
+}
+
 func isCreationEvent(name string) bool {
 	switch name {
 	case "RunInstances", "CreateVolume", "CreateBucket", "CreateDBInstance", "CreateLoadBalancer", "CreateLoadBalancerV2":
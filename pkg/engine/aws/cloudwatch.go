@@ -2,27 +2,233 @@ package aws
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/smithy-go"
+	"golang.org/x/time/rate"
 )
 
-// CloudWatchClient retrieves metrics.
+const (
+	// CloudWatchRequestsPerSecond caps metric calls per client (one client per region).
+	// GetMetricData allows 50 TPS per account and region; stay well under it so
+	// scanners sharing the account keep headroom.
+	CloudWatchRequestsPerSecond = 20
+	cloudWatchBurst             = 10
+
+	// cloudWatchMaxAttempts bounds retries of a throttled call.
+	cloudWatchMaxAttempts = 5
+	cloudWatchBaseBackoff = 250 * time.Millisecond
+	cloudWatchMaxBackoff  = 8 * time.Second
+
+	// metricDataMaxQueries is the GetMetricData limit on queries per request.
+	metricDataMaxQueries = 500
+
+	// MaxMetricWindow is how far back CloudWatch keeps hourly datapoints; older
+	// metrics are gone, whatever the period.
+	MaxMetricWindow = 455 * 24 * time.Hour
+	// minDailyWindow is the shortest window read at daily granularity. Shorter
+	// windows would collapse into one or two datapoints.
+	minDailyWindow = 2 * 24 * time.Hour
+)
+
+// MetricPeriod is the statistics period, in seconds, for a lookback window:
+// daily, or hourly below two days. Daily points keep even MaxMetricWindow far
+// under the 1,440 datapoints GetMetricStatistics returns per call, and both
+// periods are valid for data older than the 15 and 63 day retention tiers.
+func MetricPeriod(window time.Duration) int32 {
+	if window < minDailyWindow {
+		return 3600
+	}
+	return 86400
+}
+
+// ValidateMetricWindow rejects lookbacks CloudWatch cannot serve.
+func ValidateMetricWindow(window time.Duration) error {
+	if window < time.Hour {
+		return fmt.Errorf("metric window must be at least 1h")
+	}
+	if window > MaxMetricWindow {
+		return fmt.Errorf("metric window of %.0f days exceeds CloudWatch's %d-day retention", window.Hours()/24, int(MaxMetricWindow.Hours()/24))
+	}
+	return nil
+}
+
+// throttleCodes are the error codes AWS uses when a caller exceeds its request rate.
+var throttleCodes = map[string]bool{
+	"Throttling":               true,
+	"ThrottlingException":      true,
+	"ThrottledException":       true,
+	"RequestLimitExceeded":     true,
+	"TooManyRequestsException": true,
+	"SlowDown":                 true, // S3
+}
+
+// IsThrottleError reports whether err is a rate-limit rejection.
+func IsThrottleError(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return throttleCodes[apiErr.ErrorCode()]
+	}
+	return err != nil && strings.Contains(err.Error(), "Throttling")
+}
+
+type cloudWatchAPI interface {
+	cloudwatch.ListMetricsAPIClient
+	GetMetricStatistics(ctx context.Context, params *cloudwatch.GetMetricStatisticsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.GetMetricStatisticsOutput, error)
+	GetMetricData(ctx context.Context, params *cloudwatch.GetMetricDataInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.GetMetricDataOutput, error)
+}
+
+// CloudWatchClient retrieves metrics. Every call waits on a shared token bucket
+// and is retried with exponential backoff when CloudWatch throttles it.
 type CloudWatchClient struct {
-	Client *cloudwatch.Client
+	Client  cloudWatchAPI
+	limiter *rate.Limiter
+	sleep   func(ctx context.Context, d time.Duration) error // Overridden in tests.
+
+	// cfg and scopes let For hand out clients for other accounts and regions.
+	// Both are nil for clients built around a test API.
+	cfg    *aws.Config
+	scopes *cloudWatchScopes
+}
+
+// cloudWatchScopes is shared by a client and every client For derives from it.
+type cloudWatchScopes struct {
+	mu      sync.Mutex
+	configs map[string]aws.Config        // Credentials by account.
+	clients map[string]*CloudWatchClient // By account and region.
 }
 
 func NewCloudWatchClient(cfg aws.Config) *CloudWatchClient {
+	c := newCloudWatchClient(cloudwatch.NewFromConfig(cfg))
+	c.cfg = &cfg
+	c.scopes = &cloudWatchScopes{
+		configs: make(map[string]aws.Config),
+		clients: make(map[string]*CloudWatchClient),
+	}
+	return c
+}
+
+// AddAccount registers the credentials metrics of account are read with.
+func (c *CloudWatchClient) AddAccount(account string, cfg aws.Config) {
+	if c == nil || c.scopes == nil || account == "" {
+		return
+	}
+	c.scopes.mu.Lock()
+	defer c.scopes.mu.Unlock()
+	if _, ok := c.scopes.configs[account]; !ok {
+		c.scopes.configs[account] = cfg
+	}
+}
+
+// For returns a client reading metrics of a resource in account and region.
+// CloudWatch only serves metrics from the account and region they were
+// published in, so multi-account and multi-region scans must route each
+// read. An unregistered account uses c's credentials and an empty region
+// c's region; clients are built once and reused.
+func (c *CloudWatchClient) For(account, region string) *CloudWatchClient {
+	if c == nil || c.scopes == nil {
+		return c
+	}
+	s := c.scopes
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cfg, ok := s.configs[account]
+	if !ok {
+		cfg, account = *c.cfg, ""
+	}
+	if region == "" {
+		region = c.cfg.Region
+	}
+	if account == "" && region == c.cfg.Region {
+		return c
+	}
+
+	key := account + "/" + region
+	if client, ok := s.clients[key]; ok {
+		return client
+	}
+	cfg = cfg.Copy()
+	cfg.Region = region
+	client := newCloudWatchClient(cloudwatch.NewFromConfig(cfg))
+	client.cfg = &cfg
+	client.scopes = s
+	s.clients[key] = client
+	return client
+}
+
+func newCloudWatchClient(api cloudWatchAPI) *CloudWatchClient {
 	return &CloudWatchClient{
-		Client: cloudwatch.NewFromConfig(cfg),
+		Client:  api,
+		limiter: rate.NewLimiter(rate.Limit(CloudWatchRequestsPerSecond), cloudWatchBurst),
+		sleep:   sleepContext,
 	}
 }
 
-// GetMetricHistory retrieves a daily history of maximum values.
+// call runs fn once a token is available, retrying throttled attempts with
+// jittered exponential backoff. The last throttle error is returned when
+// attempts run out, so callers can tell missing data from a real failure.
+func (c *CloudWatchClient) call(ctx context.Context, fn func() error) error {
+	backoff := cloudWatchBaseBackoff
+	var err error
+	for attempt := 1; attempt <= cloudWatchMaxAttempts; attempt++ {
+		if c.limiter != nil {
+			if werr := c.limiter.Wait(ctx); werr != nil {
+				return werr
+			}
+		}
+		err = fn()
+		if err == nil || !IsThrottleError(err) {
+			return err
+		}
+		if attempt == cloudWatchMaxAttempts {
+			break
+		}
+		wait := backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+		sleep := c.sleep
+		if sleep == nil {
+			sleep = sleepContext
+		}
+		if serr := sleep(ctx, wait); serr != nil {
+			return serr
+		}
+		backoff = min(backoff*2, cloudWatchMaxBackoff)
+	}
+	return fmt.Errorf("cloudwatch throttled after %d attempts: %w", cloudWatchMaxAttempts, err)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// getMetricStatistics is GetMetricStatistics through the limiter.
+func (c *CloudWatchClient) getMetricStatistics(ctx context.Context, input *cloudwatch.GetMetricStatisticsInput) (*cloudwatch.GetMetricStatisticsOutput, error) {
+	var out *cloudwatch.GetMetricStatisticsOutput
+	err := c.call(ctx, func() error {
+		var err error
+		out, err = c.Client.GetMetricStatistics(ctx, input)
+		return err
+	})
+	return out, err
+}
+
+// GetMetricHistory retrieves a history of maximum values, one per MetricPeriod.
 func (c *CloudWatchClient) GetMetricHistory(ctx context.Context, namespace, metricName string, dimensions []types.Dimension, startTime, endTime time.Time) ([]float64, error) {
 	input := &cloudwatch.GetMetricStatisticsInput{
 		Namespace:  aws.String(namespace),
@@ -30,13 +236,13 @@ func (c *CloudWatchClient) GetMetricHistory(ctx context.Context, namespace, metr
 		Dimensions: dimensions,
 		StartTime:  aws.Time(startTime),
 		EndTime:    aws.Time(endTime),
-		Period:     aws.Int32(86400), // Daily data points
+		Period:     aws.Int32(MetricPeriod(endTime.Sub(startTime))),
 		Statistics: []types.Statistic{types.StatisticMaximum},
 	}
 
-	result, err := c.Client.GetMetricStatistics(ctx, input)
+	result, err := c.getMetricStatistics(ctx, input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get metric history: %v", err)
+		return nil, fmt.Errorf("failed to get metric history: %w", err)
 	}
 
 	// CloudWatch returns datapoints in random order; sort by timestamp.
@@ -55,56 +261,301 @@ func (c *CloudWatchClient) GetMetricHistory(ctx context.Context, namespace, metr
 	return history, nil
 }
 
+// ErrNoDatapoints is returned by the Observed reads when CloudWatch holds no
+// datapoints for the metric in the window. Missing data is not a zero: the
+// resource may not publish the metric, or the read used the wrong region.
+var ErrNoDatapoints = errors.New("no datapoints")
+
 // GetMetricMax retrieves the single highest value.
 func (c *CloudWatchClient) GetMetricMax(ctx context.Context, namespace, metricName string, dimensions []types.Dimension, startTime, endTime time.Time) (float64, error) {
+	v, _, err := c.metricStatistic(ctx, namespace, metricName, dimensions, startTime, endTime, types.StatisticMaximum)
+	return v, err
+}
+
+// GetMetricSum retrieves the total sum.
+func (c *CloudWatchClient) GetMetricSum(ctx context.Context, namespace, metricName string, dimensions []types.Dimension, startTime, endTime time.Time) (float64, error) {
+	v, _, err := c.metricStatistic(ctx, namespace, metricName, dimensions, startTime, endTime, types.StatisticSum)
+	return v, err
+}
+
+// GetMetricMaxObserved is GetMetricMax, but returns ErrNoDatapoints rather
+// than zero when the window has no datapoints.
+func (c *CloudWatchClient) GetMetricMaxObserved(ctx context.Context, namespace, metricName string, dimensions []types.Dimension, startTime, endTime time.Time) (float64, error) {
+	v, n, err := c.metricStatistic(ctx, namespace, metricName, dimensions, startTime, endTime, types.StatisticMaximum)
+	if err == nil && n == 0 {
+		return 0, ErrNoDatapoints
+	}
+	return v, err
+}
+
+// GetMetricSumObserved is GetMetricSum, but returns ErrNoDatapoints rather
+// than zero when the window has no datapoints.
+func (c *CloudWatchClient) GetMetricSumObserved(ctx context.Context, namespace, metricName string, dimensions []types.Dimension, startTime, endTime time.Time) (float64, error) {
+	v, n, err := c.metricStatistic(ctx, namespace, metricName, dimensions, startTime, endTime, types.StatisticSum)
+	if err == nil && n == 0 {
+		return 0, ErrNoDatapoints
+	}
+	return v, err
+}
+
+// metricStatistic reads one statistic over the window: the highest value for
+// Maximum, the total for Sum. It also returns how many datapoints it saw.
+func (c *CloudWatchClient) metricStatistic(ctx context.Context, namespace, metricName string, dimensions []types.Dimension, startTime, endTime time.Time, stat types.Statistic) (float64, int, error) {
 	input := &cloudwatch.GetMetricStatisticsInput{
 		Namespace:  aws.String(namespace),
 		MetricName: aws.String(metricName),
 		Dimensions: dimensions,
 		StartTime:  aws.Time(startTime),
 		EndTime:    aws.Time(endTime),
-		Period:     aws.Int32(86400), // Daily granularity
-		Statistics: []types.Statistic{types.StatisticMaximum},
+		Period:     aws.Int32(MetricPeriod(endTime.Sub(startTime))),
+		Statistics: []types.Statistic{stat},
 	}
 
-	result, err := c.Client.GetMetricStatistics(ctx, input)
+	result, err := c.getMetricStatistics(ctx, input)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get metric statistics: %v", err)
+		return 0, 0, fmt.Errorf("failed to get metric statistics: %w", err)
 	}
 
-	maxVal := 0.0
+	val, n := 0.0, 0
 	for _, dp := range result.Datapoints {
-		if dp.Maximum != nil && *dp.Maximum > maxVal {
-			maxVal = *dp.Maximum
+		switch {
+		case stat == types.StatisticMaximum && dp.Maximum != nil:
+			if *dp.Maximum > val {
+				val = *dp.Maximum
+			}
+			n++
+		case stat == types.StatisticSum && dp.Sum != nil:
+			val += *dp.Sum
+			n++
 		}
 	}
-
-	return maxVal, nil
+	return val, n, nil
 }
 
-// GetMetricSum retrieves the total sum.
-func (c *CloudWatchClient) GetMetricSum(ctx context.Context, namespace, metricName string, dimensions []types.Dimension, startTime, endTime time.Time) (float64, error) {
-	input := &cloudwatch.GetMetricStatisticsInput{
+// ListMetricDimensions returns the dimension sets a metric is published with,
+// filtered to those matching every name=value pair in filter.
+// Custom namespaces (CWAgent) use dimension sets that are not known up front.
+func (c *CloudWatchClient) ListMetricDimensions(ctx context.Context, namespace, metricName string, filter map[string]string) ([][]types.Dimension, error) {
+	input := &cloudwatch.ListMetricsInput{
 		Namespace:  aws.String(namespace),
 		MetricName: aws.String(metricName),
-		Dimensions: dimensions,
-		StartTime:  aws.Time(startTime),
-		EndTime:    aws.Time(endTime),
-		Period:     aws.Int32(86400),
-		Statistics: []types.Statistic{types.StatisticSum},
+	}
+	for name, value := range filter {
+		input.Dimensions = append(input.Dimensions, types.DimensionFilter{Name: aws.String(name), Value: aws.String(value)})
 	}
 
-	result, err := c.Client.GetMetricStatistics(ctx, input)
-	if err != nil {
-		return 0, fmt.Errorf("failed to get metric statistics: %v", err)
+	var sets [][]types.Dimension
+	paginator := cloudwatch.NewListMetricsPaginator(c.Client, input)
+	for paginator.HasMorePages() {
+		var page *cloudwatch.ListMetricsOutput
+		err := c.call(ctx, func() error {
+			var err error
+			page, err = paginator.NextPage(ctx)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list metrics: %w", err)
+		}
+		for _, m := range page.Metrics {
+			sets = append(sets, m.Dimensions)
+		}
 	}
+	return sets, nil
+}
 
-	sumVal := 0.0
-	for _, dp := range result.Datapoints {
-		if dp.Sum != nil {
-			sumVal += *dp.Sum
+// MetricQuery is one statistic for GetMetricDataBatch. ID is any caller key,
+// such as a resource ID; it need not follow CloudWatch's query ID rules.
+type MetricQuery struct {
+	ID         string
+	Namespace  string
+	MetricName string
+	Dimensions []types.Dimension
+	Stat       string // Maximum, Minimum, Average, Sum, SampleCount or a percentile.
+	StartTime  time.Time
+	EndTime    time.Time
+}
+
+// GetMetricDataBatch resolves many queries with as few GetMetricData requests
+// as possible, up to 500 queries each, and returns one value per query ID.
+// Each query is read at MetricPeriod of its window and its datapoints reduced
+// by Stat: summed for Sum and SampleCount, the lowest for Minimum, the mean
+// for Average and the highest otherwise. A query without datapoints maps to
+// zero, as with GetMetricMax and GetMetricSum. Queries sharing a time window
+// share requests.
+func (c *CloudWatchClient) GetMetricDataBatch(ctx context.Context, queries []MetricQuery) (map[string]float64, error) {
+	return c.metricDataBatch(ctx, queries, true)
+}
+
+// GetMetricDataBatchObserved is GetMetricDataBatch, but leaves queries
+// without datapoints out of the result instead of mapping them to zero.
+func (c *CloudWatchClient) GetMetricDataBatchObserved(ctx context.Context, queries []MetricQuery) (map[string]float64, error) {
+	return c.metricDataBatch(ctx, queries, false)
+}
+
+func (c *CloudWatchClient) metricDataBatch(ctx context.Context, queries []MetricQuery, zeroMissing bool) (map[string]float64, error) {
+	type window struct{ start, end time.Time }
+	var windows []window
+	byWindow := make(map[window][]types.MetricDataQuery)
+	seen := make(map[string]bool, len(queries))
+	for i, q := range queries {
+		if seen[q.ID] {
+			return nil, fmt.Errorf("duplicate metric query ID %q", q.ID)
+		}
+		seen[q.ID] = true
+		// Query IDs must start with a lowercase letter; index them back to callers.
+		qid := fmt.Sprintf("q%d", i)
+
+		w := window{q.StartTime, q.EndTime}
+		if _, ok := byWindow[w]; !ok {
+			windows = append(windows, w)
 		}
+		byWindow[w] = append(byWindow[w], types.MetricDataQuery{
+			Id: aws.String(qid),
+			MetricStat: &types.MetricStat{
+				Metric: &types.Metric{
+					Namespace:  aws.String(q.Namespace),
+					MetricName: aws.String(q.MetricName),
+					Dimensions: q.Dimensions,
+				},
+				Period: aws.Int32(MetricPeriod(q.EndTime.Sub(q.StartTime))),
+				Stat:   aws.String(q.Stat),
+			},
+		})
 	}
 
-	return sumVal, nil
+	series := make(map[string][]float64, len(queries))
+	for _, w := range windows {
+		err := c.getMetricData(ctx, byWindow[w], w.start, w.end, func(res types.MetricDataResult) {
+			qid := aws.ToString(res.Id)
+			series[qid] = append(series[qid], res.Values...)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	results := make(map[string]float64, len(queries))
+	for i, q := range queries {
+		values := series[fmt.Sprintf("q%d", i)]
+		if len(values) == 0 && !zeroMissing {
+			continue
+		}
+		results[q.ID] = reduceMetric(values, q.Stat)
+	}
+	return results, nil
+}
+
+// reduceMetric folds per-period values of stat into one value for the window.
+func reduceMetric(values []float64, stat string) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	out := values[0]
+	for _, v := range values[1:] {
+		switch stat {
+		case "Sum", "SampleCount", "Average":
+			out += v
+		case "Minimum":
+			out = min(out, v)
+		default:
+			out = max(out, v)
+		}
+	}
+	if stat == "Average" {
+		out /= float64(len(values))
+	}
+	return out
+}
+
+// getMetricData runs queries over one time window in requests of up to 500
+// queries, following pagination, and hands every result to handle. A query's
+// values may arrive across several results.
+func (c *CloudWatchClient) getMetricData(ctx context.Context, queries []types.MetricDataQuery, startTime, endTime time.Time, handle func(types.MetricDataResult)) error {
+	for from := 0; from < len(queries); from += metricDataMaxQueries {
+		input := &cloudwatch.GetMetricDataInput{
+			MetricDataQueries: queries[from:min(from+metricDataMaxQueries, len(queries))],
+			StartTime:         aws.Time(startTime),
+			EndTime:           aws.Time(endTime),
+			ScanBy:            types.ScanByTimestampAscending,
+		}
+		for {
+			var out *cloudwatch.GetMetricDataOutput
+			err := c.call(ctx, func() error {
+				var err error
+				out, err = c.Client.GetMetricData(ctx, input)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("failed to get metric data: %w", err)
+			}
+			for _, res := range out.MetricDataResults {
+				handle(res)
+			}
+			if out.NextToken == nil {
+				break
+			}
+			input.NextToken = out.NextToken
+		}
+	}
+	return nil
+}
+
+// InstanceUtilization is the CPU and network history of one EC2 instance, with
+// one point per MetricPeriod (daily unless the window is under two days).
+type InstanceUtilization struct {
+	CPUHistory []float64 // Daily maximum CPUUtilization, oldest first.
+	NetHistory []float64 // Daily maximum NetworkIn, oldest first.
+	MaxCPU     float64
+}
+
+// GetInstanceUtilization fetches CPU and network history for many instances with
+// GetMetricData, two queries per instance and up to 500 queries per request.
+// Instances with no datapoints get an empty entry.
+func (c *CloudWatchClient) GetInstanceUtilization(ctx context.Context, instanceIDs []string, startTime, endTime time.Time) (map[string]*InstanceUtilization, error) {
+	results := make(map[string]*InstanceUtilization, len(instanceIDs))
+	period := MetricPeriod(endTime.Sub(startTime))
+
+	// Query IDs must start with a lowercase letter; index them back to instances.
+	queries := make([]types.MetricDataQuery, 0, 2*len(instanceIDs))
+	owners := make(map[string]*InstanceUtilization, 2*len(instanceIDs))
+	for i, id := range instanceIDs {
+		u := &InstanceUtilization{}
+		results[id] = u
+		for _, metric := range []string{"CPUUtilization", "NetworkIn"} {
+			qid := fmt.Sprintf("%s_%d", strings.ToLower(metric[:3]), i)
+			owners[qid] = u
+			queries = append(queries, types.MetricDataQuery{
+				Id: aws.String(qid),
+				MetricStat: &types.MetricStat{
+					Metric: &types.Metric{
+						Namespace:  aws.String("AWS/EC2"),
+						MetricName: aws.String(metric),
+						Dimensions: []types.Dimension{{Name: aws.String("InstanceId"), Value: aws.String(id)}},
+					},
+					Period: aws.Int32(period),
+					Stat:   aws.String("Maximum"),
+				},
+			})
+		}
+	}
+
+	err := c.getMetricData(ctx, queries, startTime, endTime, func(res types.MetricDataResult) {
+		qid := aws.ToString(res.Id)
+		u, ok := owners[qid]
+		if !ok {
+			return
+		}
+		if strings.HasPrefix(qid, "cpu_") {
+			u.CPUHistory = append(u.CPUHistory, res.Values...)
+			for _, v := range res.Values {
+				u.MaxCPU = max(u.MaxCPU, v)
+			}
+		} else {
+			u.NetHistory = append(u.NetHistory, res.Values...)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
 }
@@ -0,0 +1,293 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/smithy-go"
+)
+
+type fakeCloudWatchAPI struct {
+	throttles int // Calls to fail with ThrottlingException before succeeding.
+	err       error
+	calls     int
+	queries   []int // Queries per GetMetricData call.
+}
+
+func (f *fakeCloudWatchAPI) fail() error {
+	f.calls++
+	if f.err != nil {
+		return f.err
+	}
+	if f.calls <= f.throttles {
+		return &smithy.GenericAPIError{Code: "ThrottlingException", Message: "Rate exceeded"}
+	}
+	return nil
+}
+
+func (f *fakeCloudWatchAPI) GetMetricStatistics(ctx context.Context, in *cloudwatch.GetMetricStatisticsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.GetMetricStatisticsOutput, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	if aws.ToString(in.MetricName) == "Unpublished" {
+		return &cloudwatch.GetMetricStatisticsOutput{}, nil
+	}
+	return &cloudwatch.GetMetricStatisticsOutput{Datapoints: []cwtypes.Datapoint{{Maximum: aws.Float64(42), Timestamp: aws.Time(time.Now())}}}, nil
+}
+
+func (f *fakeCloudWatchAPI) GetMetricData(ctx context.Context, in *cloudwatch.GetMetricDataInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.GetMetricDataOutput, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	f.queries = append(f.queries, len(in.MetricDataQueries))
+	out := &cloudwatch.GetMetricDataOutput{}
+	for i, q := range in.MetricDataQueries {
+		if aws.ToString(q.MetricStat.Metric.MetricName) == "Unpublished" {
+			out.MetricDataResults = append(out.MetricDataResults, cwtypes.MetricDataResult{Id: q.Id})
+			continue
+		}
+		out.MetricDataResults = append(out.MetricDataResults, cwtypes.MetricDataResult{
+			Id:     q.Id,
+			Values: []float64{1, float64(i % 7)},
+		})
+	}
+	return out, nil
+}
+
+func (f *fakeCloudWatchAPI) ListMetrics(ctx context.Context, in *cloudwatch.ListMetricsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.ListMetricsOutput, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	return &cloudwatch.ListMetricsOutput{}, nil
+}
+
+func testCloudWatchClient(api cloudWatchAPI) (*CloudWatchClient, *[]time.Duration) {
+	var waits []time.Duration
+	c := newCloudWatchClient(api)
+	c.limiter = nil
+	c.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	return c, &waits
+}
+
+func TestCloudWatchClientRetriesThrottles(t *testing.T) {
+	api := &fakeCloudWatchAPI{throttles: 2}
+	c, waits := testCloudWatchClient(api)
+
+	v, err := c.GetMetricMax(context.Background(), "AWS/EC2", "CPUUtilization", nil, time.Now().Add(-time.Hour), time.Now())
+	if err != nil || v != 42 {
+		t.Fatalf("GetMetricMax = %v, %v; want 42 after retries", v, err)
+	}
+	if api.calls != 3 || len(*waits) != 2 {
+		t.Fatalf("calls=%d waits=%d, want 3 calls and 2 backoffs", api.calls, len(*waits))
+	}
+	if (*waits)[1] < (*waits)[0]/2 || (*waits)[1] > cloudWatchBaseBackoff*2 {
+		t.Errorf("backoff did not grow within bounds: %v", *waits)
+	}
+}
+
+func TestCloudWatchClientGivesUpOnPersistentThrottle(t *testing.T) {
+	api := &fakeCloudWatchAPI{throttles: 100}
+	c, _ := testCloudWatchClient(api)
+
+	_, err := c.GetMetricSum(context.Background(), "AWS/EC2", "NetworkIn", nil, time.Now().Add(-time.Hour), time.Now())
+	if !IsThrottleError(err) {
+		t.Fatalf("err = %v, want a throttle error", err)
+	}
+	if api.calls != cloudWatchMaxAttempts {
+		t.Errorf("calls = %d, want %d", api.calls, cloudWatchMaxAttempts)
+	}
+}
+
+func TestCloudWatchClientDoesNotRetryOtherErrors(t *testing.T) {
+	api := &fakeCloudWatchAPI{err: fmt.Errorf("AccessDenied")}
+	c, _ := testCloudWatchClient(api)
+
+	_, err := c.GetMetricHistory(context.Background(), "AWS/EC2", "CPUUtilization", nil, time.Now().Add(-time.Hour), time.Now())
+	if err == nil || IsThrottleError(err) {
+		t.Fatalf("err = %v, want a non-throttle error", err)
+	}
+	if api.calls != 1 {
+		t.Errorf("calls = %d, want 1", api.calls)
+	}
+}
+
+func TestGetInstanceUtilizationBatches(t *testing.T) {
+	api := &fakeCloudWatchAPI{}
+	c, _ := testCloudWatchClient(api)
+
+	ids := make([]string, 600)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("i-%04d", i)
+	}
+	got, err := c.GetInstanceUtilization(context.Background(), ids, time.Now().Add(-7*24*time.Hour), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 600 instances x 2 metrics = 1200 queries -> 500, 500, 200.
+	if fmt.Sprint(api.queries) != "[500 500 200]" {
+		t.Errorf("queries per call = %v, want [500 500 200]", api.queries)
+	}
+	if len(got) != len(ids) {
+		t.Fatalf("got %d instances, want %d", len(got), len(ids))
+	}
+	u := got["i-0003"]
+	if u == nil || len(u.CPUHistory) != 2 || len(u.NetHistory) != 2 {
+		t.Fatalf("i-0003 = %+v, want two CPU and two network datapoints", u)
+	}
+	// i-0003 is the fourth instance of its chunk: CPU query index 6, value 6.
+	if u.MaxCPU != 6 {
+		t.Errorf("MaxCPU = %v, want 6", u.MaxCPU)
+	}
+}
+
+func TestGetMetricDataBatch(t *testing.T) {
+	api := &fakeCloudWatchAPI{}
+	c, _ := testCloudWatchClient(api)
+
+	end := time.Now()
+	week, day := end.Add(-7*24*time.Hour), end.Add(-24*time.Hour)
+	var queries []MetricQuery
+	for i := 0; i < 600; i++ {
+		queries = append(queries, MetricQuery{ID: fmt.Sprintf("i-%04d", i), Namespace: "AWS/EC2", MetricName: "CPUUtilization", Stat: "Maximum", StartTime: week, EndTime: end})
+	}
+	// A second window, and caller IDs CloudWatch itself would reject.
+	queries = append(queries,
+		MetricQuery{ID: "vol-1/reads", Namespace: "AWS/EBS", MetricName: "VolumeReadOps", Stat: "Sum", StartTime: day, EndTime: end},
+		MetricQuery{ID: "vol-1/idle", Namespace: "AWS/EBS", MetricName: "VolumeIdleTime", Stat: "Average", StartTime: day, EndTime: end},
+		MetricQuery{ID: "vol-1/min", Namespace: "AWS/EBS", MetricName: "VolumeIdleTime", Stat: "Minimum", StartTime: day, EndTime: end},
+	)
+
+	got, err := c.GetMetricDataBatch(context.Background(), queries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 600 queries over one window -> 500, 100; three over the other -> 3.
+	if fmt.Sprint(api.queries) != "[500 100 3]" {
+		t.Errorf("queries per call = %v, want [500 100 3]", api.queries)
+	}
+	if len(got) != len(queries) {
+		t.Fatalf("got %d results, want %d", len(got), len(queries))
+	}
+	// The fake returns {1, index within the request mod 7}.
+	for id, want := range map[string]float64{
+		"i-0006":      6,
+		"i-0007":      1,
+		"i-0503":      3,
+		"vol-1/reads": 1, // 1 + 0
+		"vol-1/idle":  1, // (1 + 1) / 2
+		"vol-1/min":   1, // min(1, 2)
+	} {
+		if got[id] != want {
+			t.Errorf("%s = %v, want %v", id, got[id], want)
+		}
+	}
+
+	if _, err := c.GetMetricDataBatch(context.Background(), []MetricQuery{{ID: "a"}, {ID: "a"}}); err == nil {
+		t.Error("Expected duplicate query IDs to be rejected")
+	}
+}
+
+func TestMetricWindowPeriodAndRetention(t *testing.T) {
+	day := 24 * time.Hour
+	for _, tc := range []struct {
+		window time.Duration
+		period int32
+	}{
+		{12 * time.Hour, 3600},
+		{36 * time.Hour, 3600},
+		{7 * day, 86400},
+		{MaxMetricWindow, 86400},
+	} {
+		if got := MetricPeriod(tc.window); got != tc.period {
+			t.Errorf("MetricPeriod(%s) = %d, want %d", tc.window, got, tc.period)
+		}
+		// Stays under the 1,440 datapoints GetMetricStatistics returns.
+		if n := tc.window / (time.Duration(tc.period) * time.Second); n > 1440 {
+			t.Errorf("window %s at period %d is %d datapoints", tc.window, tc.period, n)
+		}
+	}
+
+	if err := ValidateMetricWindow(14 * day); err != nil {
+		t.Errorf("14d rejected: %v", err)
+	}
+	if err := ValidateMetricWindow(30 * time.Minute); err == nil {
+		t.Error("a window under an hour should be rejected")
+	}
+	if err := ValidateMetricWindow(MaxMetricWindow + day); err == nil {
+		t.Error("a window beyond CloudWatch retention should be rejected")
+	}
+}
+
+func TestCloudWatchClientForRoutesByAccountAndRegion(t *testing.T) {
+	c := NewCloudWatchClient(aws.Config{Region: "us-east-1"})
+	c.AddAccount("222222222222", aws.Config{Region: "eu-west-1"})
+
+	if got := c.For("", ""); got != c {
+		t.Error("an unknown account in the client's region should reuse the client")
+	}
+	west := c.For("", "us-west-2")
+	if west == c || west.cfg.Region != "us-west-2" {
+		t.Fatalf("expected a us-west-2 client, got region %q", west.cfg.Region)
+	}
+	if again := c.For("", "us-west-2"); again != west {
+		t.Error("regional clients should be reused")
+	}
+	other := c.For("222222222222", "us-east-1")
+	if other == c || other.cfg.Region != "us-east-1" {
+		t.Errorf("expected the other account's client in us-east-1, got region %q", other.cfg.Region)
+	}
+	// Derived clients share the routing table.
+	if west.For("222222222222", "us-east-1") != other {
+		t.Error("derived clients should route through the same scopes")
+	}
+
+	// Clients built around a test API have nothing to route with.
+	fake, _ := testCloudWatchClient(&fakeCloudWatchAPI{})
+	if fake.For("222222222222", "us-west-2") != fake {
+		t.Error("a client without config should route to itself")
+	}
+	var nilClient *CloudWatchClient
+	if nilClient.For("", "us-west-2") != nil {
+		t.Error("For on a nil client should return nil")
+	}
+}
+
+func TestObservedReadsTellMissingDataFromZero(t *testing.T) {
+	c, _ := testCloudWatchClient(&fakeCloudWatchAPI{})
+	ctx := context.Background()
+	end := time.Now()
+	start := end.Add(-24 * time.Hour)
+
+	if v, err := c.GetMetricMaxObserved(ctx, "AWS/EBS", "VolumeReadOps", nil, start, end); err != nil || v != 42 {
+		t.Errorf("GetMetricMaxObserved = %v, %v; want 42", v, err)
+	}
+	if _, err := c.GetMetricSumObserved(ctx, "AWS/EFS", "Unpublished", nil, start, end); !errors.Is(err, ErrNoDatapoints) {
+		t.Errorf("Expected ErrNoDatapoints for a metric without datapoints, got %v", err)
+	}
+	if v, err := c.GetMetricSum(ctx, "AWS/EFS", "Unpublished", nil, start, end); err != nil || v != 0 {
+		t.Errorf("GetMetricSum = %v, %v; want the zero it always returned", v, err)
+	}
+
+	got, err := c.GetMetricDataBatchObserved(ctx, []MetricQuery{
+		{ID: "seen", Namespace: "AWS/EFS", MetricName: "ClientConnections", Stat: "Sum", StartTime: start, EndTime: end},
+		{ID: "missing", Namespace: "AWS/EFS", MetricName: "Unpublished", Stat: "Sum", StartTime: start, EndTime: end},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got["missing"]; ok {
+		t.Error("Expected a query without datapoints to be left out")
+	}
+	if _, ok := got["seen"]; !ok {
+		t.Error("Expected a query with datapoints to be kept")
+	}
+}
@@ -0,0 +1,66 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/computeoptimizer"
+)
+
+// ComputeOptimizerClient retrieves AWS Compute Optimizer recommendations.
+type ComputeOptimizerClient struct {
+	Client *computeoptimizer.Client
+}
+
+func NewComputeOptimizerClient(cfg aws.Config) *ComputeOptimizerClient {
+	return &ComputeOptimizerClient{
+		Client: computeoptimizer.NewFromConfig(cfg),
+	}
+}
+
+// InstanceRecommendation is a condensed Compute Optimizer EC2 finding.
+type InstanceRecommendation struct {
+	InstanceID      string
+	CurrentType     string
+	Finding         string // "Overprovisioned", "Underprovisioned", "Optimized", "NotOptimized"
+	RecommendedType string // Top-ranked option; empty when already optimized.
+}
+
+// GetEC2Recommendations returns recommendations keyed by instance ID.
+// Accounts that have not opted in return an error.
+func (c *ComputeOptimizerClient) GetEC2Recommendations(ctx context.Context) (map[string]InstanceRecommendation, error) {
+	recs := make(map[string]InstanceRecommendation)
+	var nextToken *string
+
+	for {
+		out, err := c.Client.GetEC2InstanceRecommendations(ctx, &computeoptimizer.GetEC2InstanceRecommendationsInput{
+			NextToken: nextToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get compute optimizer recommendations: %v", err)
+		}
+
+		for _, r := range out.InstanceRecommendations {
+			arn := aws.ToString(r.InstanceArn)
+			id := arn[strings.LastIndex(arn, "/")+1:]
+
+			rec := InstanceRecommendation{
+				InstanceID:  id,
+				CurrentType: aws.ToString(r.CurrentInstanceType),
+				Finding:     string(r.Finding),
+			}
+			if len(r.RecommendationOptions) > 0 {
+				rec.RecommendedType = aws.ToString(r.RecommendationOptions[0].InstanceType)
+			}
+			recs[id] = rec
+		}
+
+		if out.NextToken == nil {
+			break
+		}
+		nextToken = out.NextToken
+	}
+	return recs, nil
+}
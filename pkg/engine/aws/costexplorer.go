@@ -0,0 +1,129 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	cetypes "github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+)
+
+const (
+	// ResourceCostDays is how far back Cost Explorer keeps resource-level data.
+	ResourceCostDays = 14
+
+	// billedMetric includes Savings Plan and Reserved Instance discounts, spread
+	// over the term, so billed cost matches what the resource costs the business.
+	billedMetric = "AmortizedCost"
+
+	// daysPerMonth matches the pricing package's 730-hour month.
+	daysPerMonth = 730.0 / 24
+)
+
+type costExplorerAPI interface {
+	GetCostAndUsage(ctx context.Context, params *costexplorer.GetCostAndUsageInput, optFns ...func(*costexplorer.Options)) (*costexplorer.GetCostAndUsageOutput, error)
+	GetCostAndUsageWithResources(ctx context.Context, params *costexplorer.GetCostAndUsageWithResourcesInput, optFns ...func(*costexplorer.Options)) (*costexplorer.GetCostAndUsageWithResourcesOutput, error)
+}
+
+// CostExplorerClient reads billed spend from Cost Explorer. Run from a
+// management account, it covers every linked account.
+type CostExplorerClient struct {
+	Client costExplorerAPI
+}
+
+// NewCostExplorerClient initializes a client. Cost Explorer is only served
+// from us-east-1.
+func NewCostExplorerClient(cfg aws.Config) *CostExplorerClient {
+	ceCfg := cfg.Copy()
+	ceCfg.Region = "us-east-1"
+	return &CostExplorerClient{
+		Client: costexplorer.NewFromConfig(ceCfg),
+	}
+}
+
+// GetServiceCosts returns last calendar month's billed cost by service name
+// (e.g. "Amazon Elastic Compute Cloud - Compute").
+func (c *CostExplorerClient) GetServiceCosts(ctx context.Context, now time.Time) (map[string]float64, error) {
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	input := &costexplorer.GetCostAndUsageInput{
+		TimePeriod: &cetypes.DateInterval{
+			Start: aws.String(thisMonth.AddDate(0, -1, 0).Format(time.DateOnly)),
+			End:   aws.String(thisMonth.Format(time.DateOnly)),
+		},
+		Granularity: cetypes.GranularityMonthly,
+		Metrics:     []string{billedMetric},
+		GroupBy:     []cetypes.GroupDefinition{{Type: cetypes.GroupDefinitionTypeDimension, Key: aws.String(string(cetypes.DimensionService))}},
+	}
+
+	costs := make(map[string]float64)
+	for {
+		out, err := c.Client.GetCostAndUsage(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cost by service: %v", err)
+		}
+		addGroupCosts(costs, out.ResultsByTime, 1)
+		if out.NextPageToken == nil {
+			break
+		}
+		input.NextPageToken = out.NextPageToken
+	}
+	return costs, nil
+}
+
+// GetResourceCosts returns billed cost by Cost Explorer resource ID (an
+// instance or volume ID, a bucket name or an ARN, depending on the service)
+// over the last ResourceCostDays, scaled to a month. Cost Explorer requires a
+// service filter and an opt-in to resource-level data; without the opt-in the
+// call fails.
+func (c *CostExplorerClient) GetResourceCosts(ctx context.Context, now time.Time, services []string) (map[string]float64, error) {
+	costs := make(map[string]float64)
+	if len(services) == 0 {
+		return costs, nil
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	input := &costexplorer.GetCostAndUsageWithResourcesInput{
+		TimePeriod: &cetypes.DateInterval{
+			Start: aws.String(today.AddDate(0, 0, -ResourceCostDays).Format(time.DateOnly)),
+			End:   aws.String(today.Format(time.DateOnly)),
+		},
+		Granularity: cetypes.GranularityDaily,
+		Metrics:     []string{billedMetric},
+		Filter: &cetypes.Expression{
+			Dimensions: &cetypes.DimensionValues{Key: cetypes.DimensionService, Values: services},
+		},
+		GroupBy: []cetypes.GroupDefinition{{Type: cetypes.GroupDefinitionTypeDimension, Key: aws.String(string(cetypes.DimensionResourceId))}},
+	}
+
+	for {
+		out, err := c.Client.GetCostAndUsageWithResources(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cost by resource: %v", err)
+		}
+		addGroupCosts(costs, out.ResultsByTime, daysPerMonth/ResourceCostDays)
+		if out.NextPageToken == nil {
+			break
+		}
+		input.NextPageToken = out.NextPageToken
+	}
+	return costs, nil
+}
+
+// addGroupCosts sums each group's billed cost into costs by its first key,
+// multiplied by scale.
+func addGroupCosts(costs map[string]float64, results []cetypes.ResultByTime, scale float64) {
+	for _, r := range results {
+		for _, group := range r.Groups {
+			if len(group.Keys) == 0 {
+				continue
+			}
+			amount, err := strconv.ParseFloat(aws.ToString(group.Metrics[billedMetric].Amount), 64)
+			if err != nil {
+				continue
+			}
+			costs[group.Keys[0]] += amount * scale
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package aws
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	cetypes "github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+)
+
+type fakeCostExplorer struct {
+	serviceInput  *costexplorer.GetCostAndUsageInput
+	resourceInput *costexplorer.GetCostAndUsageWithResourcesInput
+	pages         []map[string]string // Amount by resource ID, one map per page.
+}
+
+func costGroups(amounts map[string]string) []cetypes.ResultByTime {
+	var groups []cetypes.Group
+	for key, amount := range amounts {
+		groups = append(groups, cetypes.Group{
+			Keys:    []string{key},
+			Metrics: map[string]cetypes.MetricValue{billedMetric: {Amount: aws.String(amount), Unit: aws.String("USD")}},
+		})
+	}
+	return []cetypes.ResultByTime{{Groups: groups}}
+}
+
+func (f *fakeCostExplorer) GetCostAndUsage(ctx context.Context, in *costexplorer.GetCostAndUsageInput, _ ...func(*costexplorer.Options)) (*costexplorer.GetCostAndUsageOutput, error) {
+	f.serviceInput = in
+	return &costexplorer.GetCostAndUsageOutput{ResultsByTime: costGroups(map[string]string{
+		"Amazon Elastic Compute Cloud - Compute": "1200.50",
+		"Amazon Simple Storage Service":          "80",
+	})}, nil
+}
+
+func (f *fakeCostExplorer) GetCostAndUsageWithResources(ctx context.Context, in *costexplorer.GetCostAndUsageWithResourcesInput, _ ...func(*costexplorer.Options)) (*costexplorer.GetCostAndUsageWithResourcesOutput, error) {
+	f.resourceInput = in
+	page := 0
+	if in.NextPageToken != nil {
+		page = 1
+	}
+	out := &costexplorer.GetCostAndUsageWithResourcesOutput{ResultsByTime: costGroups(f.pages[page])}
+	if page+1 < len(f.pages) {
+		out.NextPageToken = aws.String("next")
+	}
+	return out, nil
+}
+
+func TestGetServiceCosts(t *testing.T) {
+	fake := &fakeCostExplorer{}
+	c := &CostExplorerClient{Client: fake}
+
+	costs, err := c.GetServiceCosts(context.Background(), time.Date(2026, 3, 9, 15, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if costs["Amazon Elastic Compute Cloud - Compute"] != 1200.50 || costs["Amazon Simple Storage Service"] != 80 {
+		t.Errorf("Unexpected service costs: %v", costs)
+	}
+	// Last full calendar month; End is exclusive.
+	if got := *fake.serviceInput.TimePeriod.Start + ".." + *fake.serviceInput.TimePeriod.End; got != "2026-02-01..2026-03-01" {
+		t.Errorf("Expected February, got %s", got)
+	}
+}
+
+func TestGetResourceCosts(t *testing.T) {
+	fake := &fakeCostExplorer{pages: []map[string]string{
+		{"i-0abc": "14", "vol-1": "1.4"},
+		{"i-0abc": "14", "arn:aws:rds:us-east-1:123456789012:db:orders": "28"},
+	}}
+	c := &CostExplorerClient{Client: fake}
+
+	costs, err := c.GetResourceCosts(context.Background(), time.Date(2026, 3, 9, 15, 0, 0, 0, time.UTC), []string{"Amazon Relational Database Service"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 14 days of data scale to a 730-hour month.
+	month := daysPerMonth / ResourceCostDays
+	for id, want := range map[string]float64{
+		"i-0abc": 28 * month,
+		"vol-1":  1.4 * month,
+		"arn:aws:rds:us-east-1:123456789012:db:orders": 28 * month,
+	} {
+		if math.Abs(costs[id]-want) > 1e-9 {
+			t.Errorf("%s = %.4f, want %.4f", id, costs[id], want)
+		}
+	}
+	in := fake.resourceInput
+	if *in.TimePeriod.Start != "2026-02-23" || *in.TimePeriod.End != "2026-03-09" {
+		t.Errorf("Expected the last 14 full days, got %s..%s", *in.TimePeriod.Start, *in.TimePeriod.End)
+	}
+	if in.Filter == nil || in.Filter.Dimensions.Key != cetypes.DimensionService {
+		t.Error("Expected a service filter, which Cost Explorer requires")
+	}
+
+	if costs, err := c.GetResourceCosts(context.Background(), time.Now(), nil); err != nil || len(costs) != 0 {
+		t.Errorf("Expected no call without services, got %v, %v", costs, err)
+	}
+}
@@ -0,0 +1,109 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	dms "github.com/aws/aws-sdk-go-v2/service/databasemigrationservice"
+)
+
+// dmsActiveTaskStatuses are task states that keep a replication instance busy.
+var dmsActiveTaskStatuses = map[string]bool{
+	"running":   true,
+	"starting":  true,
+	"resuming":  true,
+	"modifying": true,
+	"moving":    true,
+	"testing":   true,
+}
+
+type dmsAPI interface {
+	dms.DescribeReplicationInstancesAPIClient
+	dms.DescribeReplicationTasksAPIClient
+}
+
+// DMSScanner scans Database Migration Service replication instances.
+type DMSScanner struct {
+	Client dmsAPI
+	Graph  *graph.Graph
+}
+
+// NewDMSScanner initializes a scanner for DMS.
+func NewDMSScanner(cfg aws.Config, g *graph.Graph) *DMSScanner {
+	return &DMSScanner{
+		Client: dms.NewFromConfig(cfg),
+		Graph:  g,
+	}
+}
+
+// ScanReplicationInstances maps replication instances and the tasks attached to them.
+func (s *DMSScanner) ScanReplicationInstances(ctx context.Context) error {
+	// Tasks reference their instance by ARN; index them first.
+	type taskRef struct{ arn, id string }
+	tasks := make(map[string][]taskRef)
+	active := make(map[string]int)
+	taskPages := dms.NewDescribeReplicationTasksPaginator(s.Client, &dms.DescribeReplicationTasksInput{
+		WithoutSettings: aws.Bool(true),
+	})
+	for taskPages.HasMorePages() {
+		page, err := taskPages.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to describe replication tasks: %v", err)
+		}
+		for _, t := range page.ReplicationTasks {
+			instanceARN := aws.ToString(t.ReplicationInstanceArn)
+			taskARN := aws.ToString(t.ReplicationTaskArn)
+			tasks[instanceARN] = append(tasks[instanceARN], taskRef{arn: taskARN, id: dmsResourceID(taskARN)})
+			if dmsActiveTaskStatuses[aws.ToString(t.Status)] {
+				active[instanceARN]++
+			}
+		}
+	}
+
+	paginator := dms.NewDescribeReplicationInstancesPaginator(s.Client, &dms.DescribeReplicationInstancesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to describe replication instances: %v", err)
+		}
+
+		for _, ri := range page.ReplicationInstances {
+			id := aws.ToString(ri.ReplicationInstanceArn)
+			props := map[string]interface{}{
+				"Identifier":    aws.ToString(ri.ReplicationInstanceIdentifier),
+				"InstanceClass": aws.ToString(ri.ReplicationInstanceClass),
+				"Status":        aws.ToString(ri.ReplicationInstanceStatus),
+				"MultiAZ":       ri.MultiAZ,
+				"StorageGB":     int(ri.AllocatedStorage),
+				"EngineVersion": aws.ToString(ri.EngineVersion),
+				"ActiveTasks":   active[id],
+			}
+			if ri.InstanceCreateTime != nil {
+				props["CreateTime"] = *ri.InstanceCreateTime
+			}
+			if parsed, err := arn.Parse(id); err == nil {
+				props["Region"] = parsed.Region
+			}
+			var taskARNs, taskIDs []string
+			for _, t := range tasks[id] {
+				taskARNs = append(taskARNs, t.arn)
+				taskIDs = append(taskIDs, t.id)
+			}
+			props["ReplicationTaskArns"] = taskARNs
+			// CloudWatch task metrics are keyed by the task's resource ID, not its name.
+			props["ReplicationTaskIDs"] = taskIDs
+
+			s.Graph.AddNode(id, "AWS::DMS::ReplicationInstance", props)
+		}
+	}
+	return nil
+}
+
+// dmsResourceID returns the resource ID of a DMS ARN (arn:aws:dms:region:account:task:ID).
+func dmsResourceID(a string) string {
+	return a[strings.LastIndex(a, ":")+1:]
+}
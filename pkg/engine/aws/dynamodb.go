@@ -3,14 +3,11 @@ package aws
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/applicationautoscaling"
 	aaTypes "github.com/aws/aws-sdk-go-v2/service/applicationautoscaling/types"
-	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
-	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
@@ -18,7 +15,6 @@ import (
 type DynamoDBScanner struct {
 	Client   *dynamodb.Client
 	AAClient *applicationautoscaling.Client
-	CWClient *cloudwatch.Client
 	Graph    *graph.Graph
 }
 
@@ -26,12 +22,13 @@ func NewDynamoDBScanner(cfg aws.Config, g *graph.Graph) *DynamoDBScanner {
 	return &DynamoDBScanner{
 		Client:   dynamodb.NewFromConfig(cfg),
 		AAClient: applicationautoscaling.NewFromConfig(cfg),
-		CWClient: cloudwatch.NewFromConfig(cfg),
 		Graph:    g,
 	}
 }
 
-// ScanTables scans tables and analyzes metrics.
+// ScanTables maps tables as aws_dynamodb_table nodes with their billing mode,
+// provisioned capacity (tables and global secondary indexes), size and item
+// count. Usage is read by DynamoDBHeuristic.
 func (s *DynamoDBScanner) ScanTables(ctx context.Context) error {
 	paginator := dynamodb.NewListTablesPaginator(s.Client, &dynamodb.ListTablesInput{})
 
@@ -42,160 +39,89 @@ func (s *DynamoDBScanner) ScanTables(ctx context.Context) error {
 		}
 
 		for _, tableName := range page.TableNames {
-			// Describe table.
 			desc, err := s.Client.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(tableName)})
-			if err != nil {
+			if err != nil || desc.Table == nil {
 				continue
 			}
-			table := desc.Table
-
-			// Check billing mode.
-			isProvisioned := false
-			if table.BillingModeSummary != nil {
-				if table.BillingModeSummary.BillingMode == types.BillingModeProvisioned {
-					isProvisioned = true
-				}
-			} else {
-				// Handle legacy defaults where BillingModeSummary matches nil for Provisioned.
-				if table.ProvisionedThroughput != nil {
-					isProvisioned = true
-				}
-			}
-
-			if !isProvisioned {
-				continue // Skip On-Demand tables as they do not incur idle costs.
+			props := tableProps(desc.Table, s.Client.Options().Region)
+			if props["BillingMode"] == string(types.BillingModeProvisioned) {
+				props["HasAutoScaling"] = s.hasAutoScaling(ctx, tableName)
 			}
 
-			// Get capacity.
-			readCap := *table.ProvisionedThroughput.ReadCapacityUnits
-			writeCap := *table.ProvisionedThroughput.WriteCapacityUnits
-
-			props := map[string]interface{}{
-				"Service":            "DynamoDB",
-				"BillingMode":        "PROVISIONED",
-				"ProvisionedRCU":     float64(readCap),
-				"ProvisionedWCU":     float64(writeCap),
-				"TableSizeBytes":     table.TableSizeBytes,
-				"GlobalTableVersion": table.GlobalTableVersion,
+			id := aws.ToString(desc.Table.TableArn)
+			if id == "" {
+				id = tableName
 			}
-
-			s.Graph.AddNode(tableName, "aws_dynamodb_table", props)
-
-			// Check auto-scaling.
-			go s.checkAutoScaling(ctx, tableName, props)
-
-			// Enrich metrics.
-			go s.enrichTableMetrics(ctx, tableName, props)
+			s.Graph.AddNode(id, "aws_dynamodb_table", props)
 		}
 	}
 	return nil
 }
 
-// checkAutoScaling checks for scaling policies.
-func (s *DynamoDBScanner) checkAutoScaling(ctx context.Context, tableName string, props map[string]interface{}) {
-	// Resource ID.
-	resourceId := fmt.Sprintf("table/%s", tableName)
-
-	out, err := s.AAClient.DescribeScalingPolicies(ctx, &applicationautoscaling.DescribeScalingPoliciesInput{
-		ServiceNamespace: aaTypes.ServiceNamespaceDynamodb,
-		ResourceId:       aws.String(resourceId),
-	})
-
-	hasAS := false
-	if err == nil {
-		if len(out.ScalingPolicies) > 0 {
-			hasAS = true
-		}
+func tableProps(table *types.TableDescription, region string) map[string]interface{} {
+	// Tables created before on-demand existed report no billing mode summary.
+	mode := string(types.BillingModeProvisioned)
+	if table.BillingModeSummary != nil && table.BillingModeSummary.BillingMode != "" {
+		mode = string(table.BillingModeSummary.BillingMode)
 	}
-
-	// Update node.
-	node := s.Graph.GetNode(tableName)
-	if node != nil {
-		s.Graph.Mu.Lock()
-		node.Properties["HasAutoScaling"] = hasAS
-		s.Graph.Mu.Unlock()
+	tableClass := string(types.TableClassStandard)
+	if table.TableClassSummary != nil && table.TableClassSummary.TableClass != "" {
+		tableClass = string(table.TableClassSummary.TableClass)
 	}
-}
 
-// enrichTableMetrics adds CloudWatch metrics.
-func (s *DynamoDBScanner) enrichTableMetrics(ctx context.Context, tableName string, props map[string]interface{}) {
-	// Get node.
-	node := s.Graph.GetNode(tableName)
-
-	if node == nil {
-		return
+	props := map[string]interface{}{
+		"Service":            "DynamoDB",
+		"TableName":          aws.ToString(table.TableName),
+		"TableStatus":        string(table.TableStatus),
+		"BillingMode":        mode,
+		"TableClass":         tableClass,
+		"ItemCount":          aws.ToInt64(table.ItemCount),
+		"TableSizeBytes":     aws.ToInt64(table.TableSizeBytes),
+		"GlobalTableVersion": aws.ToString(table.GlobalTableVersion),
+		"Replicas":           len(table.Replicas),
+		"DeletionProtection": aws.ToBool(table.DeletionProtectionEnabled),
+		"Region":             region,
 	}
-	exists := true
-	if !exists {
-		return
+	if table.CreationDateTime != nil {
+		props["CreationTime"] = *table.CreationDateTime
 	}
-
-	endTime := time.Now()
-	startTime := endTime.Add(-30 * 24 * time.Hour) // 30 Days
-
-	queries := []cwtypes.MetricDataQuery{
-		{
-			Id: aws.String("m_consumed_read"),
-			MetricStat: &cwtypes.MetricStat{
-				Metric: &cwtypes.Metric{
-					Namespace:  aws.String("AWS/DynamoDB"),
-					MetricName: aws.String("ConsumedReadCapacityUnits"),
-					Dimensions: []cwtypes.Dimension{{Name: aws.String("TableName"), Value: aws.String(tableName)}},
-				},
-				Period: aws.Int32(86400),
-				Stat:   aws.String("Sum"), // Sum over day
-			},
-		},
-		{
-			Id: aws.String("m_consumed_write"),
-			MetricStat: &cwtypes.MetricStat{
-				Metric: &cwtypes.Metric{
-					Namespace:  aws.String("AWS/DynamoDB"),
-					MetricName: aws.String("ConsumedWriteCapacityUnits"),
-					Dimensions: []cwtypes.Dimension{{Name: aws.String("TableName"), Value: aws.String(tableName)}},
-				},
-				Period: aws.Int32(86400),
-				Stat:   aws.String("Sum"),
-			},
-		},
+	// Tables on the default AWS owned key report no SSE description.
+	if table.SSEDescription != nil && table.SSEDescription.KMSMasterKeyArn != nil {
+		props["KmsKeyId"] = *table.SSEDescription.KMSMasterKeyArn
 	}
-
-	out, err := s.CWClient.GetMetricData(ctx, &cloudwatch.GetMetricDataInput{
-		MetricDataQueries: queries,
-		StartTime:         &startTime,
-		EndTime:           &endTime,
-	})
-	if err != nil {
-		return
+	// Index reads are reported under the index's own metric dimension.
+	indexes := make([]string, 0, len(table.GlobalSecondaryIndexes))
+	for _, gsi := range table.GlobalSecondaryIndexes {
+		indexes = append(indexes, aws.ToString(gsi.IndexName))
 	}
+	props["GlobalSecondaryIndexes"] = indexes
 
-	var avgConsumedRCU, avgConsumedWCU float64
-	// Calc avg daily.
-
-	for _, res := range out.MetricDataResults {
-		totalSum := 0.0
-		count := 0.0
-		for _, v := range res.Values {
-			totalSum += v
-			count++
-		}
-
-		avgDailySum := 0.0
-		if count > 0 {
-			avgDailySum = totalSum / count
+	if mode == string(types.BillingModeProvisioned) {
+		var rcu, wcu float64
+		if pt := table.ProvisionedThroughput; pt != nil {
+			rcu, wcu = float64(aws.ToInt64(pt.ReadCapacityUnits)), float64(aws.ToInt64(pt.WriteCapacityUnits))
 		}
-
-		avgPerSec := avgDailySum / 86400.0
-
-		if *res.Id == "m_consumed_read" {
-			avgConsumedRCU = avgPerSec
-		} else {
-			avgConsumedWCU = avgPerSec
+		var gsiRCU, gsiWCU float64
+		for _, gsi := range table.GlobalSecondaryIndexes {
+			if pt := gsi.ProvisionedThroughput; pt != nil {
+				gsiRCU += float64(aws.ToInt64(pt.ReadCapacityUnits))
+				gsiWCU += float64(aws.ToInt64(pt.WriteCapacityUnits))
+			}
 		}
+		props["ProvisionedRCU"] = rcu
+		props["ProvisionedWCU"] = wcu
+		props["GSIProvisionedRCU"] = gsiRCU
+		props["GSIProvisionedWCU"] = gsiWCU
 	}
+	return props
+}
 
-	s.Graph.Mu.Lock()
-	node.Properties["AvgConsumedRCU30d"] = avgConsumedRCU
-	node.Properties["AvgConsumedWCU30d"] = avgConsumedWCU
-	s.Graph.Mu.Unlock()
+// hasAutoScaling reports whether the table has a scaling policy. A failed
+// lookup counts as none.
+func (s *DynamoDBScanner) hasAutoScaling(ctx context.Context, tableName string) bool {
+	out, err := s.AAClient.DescribeScalingPolicies(ctx, &applicationautoscaling.DescribeScalingPoliciesInput{
+		ServiceNamespace: aaTypes.ServiceNamespaceDynamodb,
+		ResourceId:       aws.String(fmt.Sprintf("table/%s", tableName)),
+	})
+	return err == nil && len(out.ScalingPolicies) > 0
 }
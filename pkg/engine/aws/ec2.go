@@ -22,6 +22,8 @@ type EC2Client interface {
 	DescribeImages(ctx context.Context, params *ec2.DescribeImagesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeImagesOutput, error)
 	DescribeVolumesModifications(ctx context.Context, params *ec2.DescribeVolumesModificationsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVolumesModificationsOutput, error)
 	DescribeInstanceTypes(ctx context.Context, params *ec2.DescribeInstanceTypesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInstanceTypesOutput, error)
+	DescribeSnapshotAttribute(ctx context.Context, params *ec2.DescribeSnapshotAttributeInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSnapshotAttributeOutput, error)
+	DescribeImageAttribute(ctx context.Context, params *ec2.DescribeImageAttributeInput, optFns ...func(*ec2.Options)) (*ec2.DescribeImageAttributeOutput, error)
 }
 
 // EC2Scanner scans EC2 resources.
@@ -55,10 +57,22 @@ func (s *EC2Scanner) ScanInstances(ctx context.Context) error {
 				arn := fmt.Sprintf("arn:aws:ec2:region:account:instance/%s", id)
 
 				props := map[string]interface{}{
-					"State":      string(instance.State.Name),
-					"Type":       string(instance.InstanceType),
-					"LaunchTime": instance.LaunchTime,
-					"Tags":       parseTags(instance.Tags),
+					"State":           string(instance.State.Name),
+					"Type":            string(instance.InstanceType),
+					"LaunchTime":      instance.LaunchTime,
+					"Tags":            parseTags(instance.Tags),
+					"Platform":        string(instance.Platform), // "windows" or empty
+					"PlatformDetails": aws.ToString(instance.PlatformDetails),
+					"PrivateIPs":      instancePrivateIPs(instance),
+				}
+				if instance.Placement != nil && instance.Placement.AvailabilityZone != nil {
+					props["AvailabilityZone"] = *instance.Placement.AvailabilityZone
+				}
+				if instance.RootDeviceName != nil {
+					props["RootDeviceName"] = *instance.RootDeviceName
+				}
+				if n := len(instance.ElasticInferenceAcceleratorAssociations); n > 0 {
+					props["ElasticInferenceAccelerators"] = n
 				}
 
 				uniqueTypes[string(instance.InstanceType)] = true
@@ -71,9 +85,10 @@ func (s *EC2Scanner) ScanInstances(ctx context.Context) error {
 						Region: "unknown", // Iterate region if available in scanner context?
 						Tags:   parseTags(instance.Tags),
 					},
-					State:        string(instance.State.Name),
-					InstanceType: string(instance.InstanceType),
-					LaunchTime:   *instance.LaunchTime,
+					State:           string(instance.State.Name),
+					InstanceType:    string(instance.InstanceType),
+					LaunchTime:      *instance.LaunchTime,
+					PlatformDetails: aws.ToString(instance.PlatformDetails),
 					// VpcID/SubnetID handle pointers safely below
 				}
 				if instance.VpcId != nil {
@@ -155,6 +170,29 @@ func (s *EC2Scanner) ScanVolumes(ctx context.Context) error {
 				"Tags":        parseTags(volume.Tags),
 				"IsModifying": modMap[id], // Track modification.
 			}
+			// Provisioned performance (io1/io2/gp3; gp3 throughput only).
+			if volume.Iops != nil {
+				props["Iops"] = *volume.Iops
+			}
+			if volume.Throughput != nil {
+				props["Throughput"] = *volume.Throughput
+			}
+			if volume.Encrypted != nil {
+				props["Encrypted"] = *volume.Encrypted
+			}
+			// Ties the volume to its key (LinkKMSKeys).
+			if volume.KmsKeyId != nil {
+				props["KmsKeyId"] = *volume.KmsKeyId
+			}
+
+			// Record termination behavior for safety analysis.
+			for _, att := range volume.Attachments {
+				if att.InstanceId != nil {
+					props["DeleteOnTermination"] = att.DeleteOnTermination
+					props["AttachedInstanceId"] = *att.InstanceId
+					props["AttachedDevice"] = aws.ToString(att.Device)
+				}
+			}
 
 			s.Graph.AddNode(arn, "AWS::EC2::Volume", props)
 
@@ -163,10 +201,6 @@ func (s *EC2Scanner) ScanVolumes(ctx context.Context) error {
 				if att.InstanceId != nil {
 					instanceARN := fmt.Sprintf("arn:aws:ec2:region:account:instance/%s", *att.InstanceId)
 					s.Graph.AddTypedEdge(arn, instanceARN, graph.EdgeTypeAttachedTo, 100)
-
-					// Record termination behavior for safety analysis.
-					props["DeleteOnTermination"] = att.DeleteOnTermination
-					props["AttachedInstanceId"] = *att.InstanceId
 				}
 			}
 		}
@@ -276,8 +310,13 @@ func (s *EC2Scanner) ScanSnapshots(ctx context.Context, ownerID string) error {
 				"VolumeSize":  *snap.VolumeSize,
 				"Description": *snap.Description,
 				"VolumeId":    *snap.VolumeId, // Original volume
+				"CreateTime":  snap.StartTime,
 				"Tags":        parseTags(snap.Tags),
 			}
+			if snap.KmsKeyId != nil {
+				props["KmsKeyId"] = *snap.KmsKeyId
+			}
+			s.recordSnapshotSharing(ctx, id, props)
 			s.Graph.AddNode(arn, "AWS::EC2::Snapshot", props)
 		}
 	}
@@ -303,6 +342,7 @@ func (s *EC2Scanner) ScanImages(ctx context.Context) error {
 			"Name":  *img.Name,
 			"Tags":  parseTags(img.Tags),
 		}
+		s.recordImageSharing(ctx, id, props)
 
 		// Parse creation timestamp.
 		if img.CreationDate != nil {
@@ -327,6 +367,75 @@ func (s *EC2Scanner) ScanImages(ctx context.Context) error {
 	return nil
 }
 
+// recordSnapshotSharing records who may create volumes from a snapshot as
+// CreateVolumePermissions, and Public when anyone may. A failed lookup leaves
+// both unset.
+func (s *EC2Scanner) recordSnapshotSharing(ctx context.Context, id string, props map[string]interface{}) {
+	out, err := s.Client.DescribeSnapshotAttribute(ctx, &ec2.DescribeSnapshotAttributeInput{
+		SnapshotId: aws.String(id),
+		Attribute:  types.SnapshotAttributeNameCreateVolumePermission,
+	})
+	if err != nil {
+		RecordPropertyError(props, "ec2:DescribeSnapshotAttribute", err)
+		return
+	}
+	var grantees []string
+	for _, p := range out.CreateVolumePermissions {
+		grantees = append(grantees, permissionGrantee(p.Group, p.UserId, nil, nil))
+	}
+	recordSharing(props, "CreateVolumePermissions", grantees)
+}
+
+// recordImageSharing records who may launch an AMI as LaunchPermissions, and
+// Public when anyone may. A failed lookup leaves both unset.
+func (s *EC2Scanner) recordImageSharing(ctx context.Context, id string, props map[string]interface{}) {
+	out, err := s.Client.DescribeImageAttribute(ctx, &ec2.DescribeImageAttributeInput{
+		ImageId:   aws.String(id),
+		Attribute: types.ImageAttributeNameLaunchPermission,
+	})
+	if err != nil {
+		RecordPropertyError(props, "ec2:DescribeImageAttribute", err)
+		return
+	}
+	var grantees []string
+	for _, p := range out.LaunchPermissions {
+		grantees = append(grantees, permissionGrantee(p.Group, p.UserId, p.OrganizationArn, p.OrganizationalUnitArn))
+	}
+	recordSharing(props, "LaunchPermissions", grantees)
+}
+
+// permissionGrantee flattens a launch or create-volume permission to "all"
+// (public), an account ID, or an organization or OU ARN.
+func permissionGrantee(group types.PermissionGroup, userID, orgARN, ouARN *string) string {
+	switch {
+	case group == types.PermissionGroupAll:
+		return "all"
+	case userID != nil:
+		return *userID
+	case orgARN != nil:
+		return *orgARN
+	case ouARN != nil:
+		return *ouARN
+	}
+	return ""
+}
+
+func recordSharing(props map[string]interface{}, key string, grantees []string) {
+	public := false
+	kept := make([]string, 0, len(grantees))
+	for _, g := range grantees {
+		if g == "" {
+			continue
+		}
+		if g == "all" {
+			public = true
+		}
+		kept = append(kept, g)
+	}
+	props[key] = kept
+	props["Public"] = public
+}
+
 // parseTags converts AWS tags to a map.
 func parseTags(tags []types.Tag) map[string]string {
 	out := make(map[string]string)
@@ -337,3 +446,19 @@ func parseTags(tags []types.Tag) map[string]string {
 	}
 	return out
 }
+
+// instancePrivateIPs lists the private IPs on all of an instance's ENIs.
+func instancePrivateIPs(instance types.Instance) []string {
+	var ips []string
+	for _, eni := range instance.NetworkInterfaces {
+		for _, addr := range eni.PrivateIpAddresses {
+			if addr.PrivateIpAddress != nil {
+				ips = append(ips, *addr.PrivateIpAddress)
+			}
+		}
+	}
+	if len(ips) == 0 && instance.PrivateIpAddress != nil {
+		ips = append(ips, *instance.PrivateIpAddress)
+	}
+	return ips
+}
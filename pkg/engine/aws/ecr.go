@@ -110,17 +110,18 @@ func (s *ECRScanner) ScanRepositories(ctx context.Context) error {
 			repoName := *repo.RepositoryName
 			repoArn := *repo.RepositoryArn
 
+			props := map[string]interface{}{
+				"Name": repoName,
+			}
+
 			// Check for existing lifecycle policies.
 			hasPolicy := false
 			policyInput := &ecr.GetLifecyclePolicyInput{RepositoryName: aws.String(repoName)}
 			if _, err := s.Client.GetLifecyclePolicy(ctx, policyInput); err == nil {
 				hasPolicy = true
 			} else {
-				// Handle access denied.
-				if strings.Contains(err.Error(), "AccessDenied") {
-					// Log warning on access error.
-					// We cannot determine policy status, so we proceed cautiously.
-				}
+				// We cannot determine policy status on access denied; record the gap.
+				RecordPropertyError(props, "ecr:GetLifecyclePolicy", err)
 			}
 
 			wasteBytes := int64(0)
@@ -130,11 +131,8 @@ func (s *ECRScanner) ScanRepositories(ctx context.Context) error {
 				wasteBytes = s.analyzeImages(ctx, repoName)
 			}
 
-			props := map[string]interface{}{
-				"Name":       repoName,
-				"HasPolicy":  hasPolicy,
-				"WasteBytes": wasteBytes,
-			}
+			props["HasPolicy"] = hasPolicy
+			props["WasteBytes"] = wasteBytes
 
 			s.Graph.AddNode(repoArn, "AWS::ECR::Repository", props)
 		}
@@ -0,0 +1,96 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/efs"
+	efstypes "github.com/aws/aws-sdk-go-v2/service/efs/types"
+)
+
+// EFSScanner scans Elastic File Systems.
+type EFSScanner struct {
+	Client *efs.Client
+	Graph  *graph.Graph
+}
+
+// NewEFSScanner initializes a scanner for EFS.
+func NewEFSScanner(cfg aws.Config, g *graph.Graph) *EFSScanner {
+	return &EFSScanner{
+		Client: efs.NewFromConfig(cfg),
+		Graph:  g,
+	}
+}
+
+// ScanFileSystems maps file systems, their storage class split and lifecycle policy.
+func (s *EFSScanner) ScanFileSystems(ctx context.Context) error {
+	paginator := efs.NewDescribeFileSystemsPaginator(s.Client, &efs.DescribeFileSystemsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to describe file systems: %v", err)
+		}
+
+		for _, fs := range page.FileSystems {
+			id := aws.ToString(fs.FileSystemArn)
+			props := map[string]interface{}{
+				"FileSystemId":         aws.ToString(fs.FileSystemId),
+				"Name":                 aws.ToString(fs.Name),
+				"State":                string(fs.LifeCycleState),
+				"NumberOfMountTargets": fs.NumberOfMountTargets,
+				"ThroughputMode":       string(fs.ThroughputMode),
+				"CreationTime":         aws.ToTime(fs.CreationTime),
+				"Tags":                 efsTags(fs.Tags),
+			}
+			if parsed, err := arn.Parse(id); err == nil {
+				props["Region"] = parsed.Region
+			}
+			if fs.KmsKeyId != nil {
+				props["KmsKeyId"] = *fs.KmsKeyId
+			}
+			if fs.ProvisionedThroughputInMibps != nil {
+				props["ProvisionedThroughput"] = *fs.ProvisionedThroughputInMibps
+			}
+			if size := fs.SizeInBytes; size != nil {
+				props["SizeBytes"] = size.Value
+				props["SizeStandardBytes"] = aws.ToInt64(size.ValueInStandard)
+				props["SizeIABytes"] = aws.ToInt64(size.ValueInIA)
+				props["SizeArchiveBytes"] = aws.ToInt64(size.ValueInArchive)
+			}
+
+			// Leave the lifecycle unknown on error so heuristics don't guess.
+			lc, err := s.Client.DescribeLifecycleConfiguration(ctx, &efs.DescribeLifecycleConfigurationInput{
+				FileSystemId: fs.FileSystemId,
+			})
+			if err == nil {
+				var toIA, toArchive string
+				for _, p := range lc.LifecyclePolicies {
+					if p.TransitionToIA != "" {
+						toIA = string(p.TransitionToIA)
+					}
+					if p.TransitionToArchive != "" {
+						toArchive = string(p.TransitionToArchive)
+					}
+				}
+				props["TransitionToIA"] = toIA
+				props["TransitionToArchive"] = toArchive
+			}
+
+			s.Graph.AddNode(id, "AWS::EFS::FileSystem", props)
+		}
+	}
+	return nil
+}
+
+func efsTags(tags []efstypes.Tag) map[string]string {
+	out := make(map[string]string)
+	for _, t := range tags {
+		if t.Key != nil && t.Value != nil {
+			out[*t.Key] = *t.Value
+		}
+	}
+	return out
+}
@@ -3,186 +3,154 @@ package aws
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
-	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/service/elasticache"
 )
 
+type elasticacheAPI interface {
+	elasticache.DescribeCacheClustersAPIClient
+	elasticache.DescribeReservedCacheNodesAPIClient
+}
+
 // ElasticacheScanner scans ElastiCache clusters.
 type ElasticacheScanner struct {
-	Client   *elasticache.Client
-	CWClient *cloudwatch.Client
-	Graph    *graph.Graph
+	Client elasticacheAPI
+	Graph  *graph.Graph
 }
 
 // NewElasticacheScanner initializes a scanner for ElastiCache resources.
 func NewElasticacheScanner(cfg aws.Config, g *graph.Graph) *ElasticacheScanner {
 	return &ElasticacheScanner{
-		Client:   elasticache.NewFromConfig(cfg),
-		CWClient: cloudwatch.NewFromConfig(cfg),
-		Graph:    g,
+		Client: elasticache.NewFromConfig(cfg),
+		Graph:  g,
 	}
 }
 
-// ScanClusters scans clusters and analyzes metrics.
+// reservationPool is the active reserved capacity for one node type and engine.
+type reservationPool struct {
+	nodes  int
+	expiry time.Time // Earliest end of the reservations in the pool.
+}
+
+// ScanClusters maps cache clusters with their node type, node count and the
+// reserved nodes that cover them. Usage metrics are read by the heuristics.
 func (s *ElasticacheScanner) ScanClusters(ctx context.Context) error {
-	paginator := elasticache.NewDescribeCacheClustersPaginator(s.Client, &elasticache.DescribeCacheClustersInput{
-		ShowCacheNodeInfo: aws.Bool(true),
-	})
+	pools, err := s.reservations(ctx)
+	if err != nil {
+		return err
+	}
+
+	type cluster struct {
+		id      string
+		created time.Time
+		props   map[string]interface{}
+	}
+	var clusters []cluster
 
+	paginator := elasticache.NewDescribeCacheClustersPaginator(s.Client, &elasticache.DescribeCacheClustersInput{})
 	for paginator.HasMorePages() {
 		page, err := paginator.NextPage(ctx)
 		if err != nil {
-			return err
+			return fmt.Errorf("failed to describe cache clusters: %v", err)
 		}
 
-		for _, cluster := range page.CacheClusters {
-
-
-			// Group by ClusterID.
-			id := *cluster.CacheClusterId
-
+		for _, c := range page.CacheClusters {
+			id := aws.ToString(c.ARN)
+			if id == "" {
+				continue
+			}
 			props := map[string]interface{}{
-				"Service":       "Elasticache",
-				"Engine":        *cluster.Engine,
-				"Status":        *cluster.CacheClusterStatus,
-				"NodeType":      *cluster.CacheNodeType,
-				"EngineVersion": *cluster.EngineVersion,
-				"NumCacheNodes": cluster.NumCacheNodes,
+				"Service":        "Elasticache",
+				"CacheClusterId": aws.ToString(c.CacheClusterId),
+				"Engine":         aws.ToString(c.Engine),
+				"EngineVersion":  aws.ToString(c.EngineVersion),
+				"Status":         aws.ToString(c.CacheClusterStatus),
+				"NodeType":       aws.ToString(c.CacheNodeType),
+				"NumCacheNodes":  int(aws.ToInt32(c.NumCacheNodes)),
 			}
-			// Fetch metrics.
-
-			go s.enrichClusterMetrics(ctx, id, cluster.CacheNodeType, props)
+			if c.ReplicationGroupId != nil {
+				props["ReplicationGroupId"] = *c.ReplicationGroupId
+			}
+			var created time.Time
+			if c.CacheClusterCreateTime != nil {
+				created = *c.CacheClusterCreateTime
+				props["CreateTime"] = created
+			}
+			if parsed, err := arn.Parse(id); err == nil {
+				props["Region"] = parsed.Region
+			}
+			clusters = append(clusters, cluster{id: id, created: created, props: props})
 		}
 	}
-	return nil
-}
 
-// enrichClusterMetrics retrieves performance metrics.
-func (s *ElasticacheScanner) enrichClusterMetrics(ctx context.Context, clusterID string, nodeType *string, props map[string]interface{}) {
-	node := s.Graph.GetNode(clusterID)
-	// s.Graph.Mu.Unlock() - Removed, GetNode handles lock
-	exists := (node != nil)
-	if !exists {
-		return
+	// Reservations are a regional discount, not tied to a cluster. Attribute
+	// them to the oldest clusters of the matching type first.
+	sort.SliceStable(clusters, func(i, j int) bool { return clusters[i].created.Before(clusters[j].created) })
+	for _, c := range clusters {
+		allocateReservedNodes(c.props, pools)
+		s.Graph.AddNode(c.id, "aws_elasticache_cluster", c.props)
 	}
+	return nil
+}
 
-	endTime := time.Now()
-	startTime := endTime.Add(-7 * 24 * time.Hour) // 7-day window.
-
-	// Metrics.
-	queries := []cwtypes.MetricDataQuery{
-		{
-			Id: aws.String("m_conn"),
-			MetricStat: &cwtypes.MetricStat{
-				Metric: &cwtypes.Metric{
-					Namespace:  aws.String("AWS/ElastiCache"),
-					MetricName: aws.String("CurrConnections"),
-					Dimensions: []cwtypes.Dimension{{Name: aws.String("CacheClusterId"), Value: aws.String(clusterID)}},
-				},
-				Period: aws.Int32(86400), // 1-day granularity.
-				Stat:   aws.String("Sum"),
-			},
-		},
-		{
-			Id: aws.String("m_hits"),
-			MetricStat: &cwtypes.MetricStat{
-				Metric: &cwtypes.Metric{
-					Namespace:  aws.String("AWS/ElastiCache"),
-					MetricName: aws.String("CacheHits"),
-					Dimensions: []cwtypes.Dimension{{Name: aws.String("CacheClusterId"), Value: aws.String(clusterID)}},
-				},
-				Period: aws.Int32(86400),
-				Stat:   aws.String("Sum"),
-			},
-		},
-		{
-			Id: aws.String("m_misses"),
-			MetricStat: &cwtypes.MetricStat{
-				Metric: &cwtypes.Metric{
-					Namespace:  aws.String("AWS/ElastiCache"),
-					MetricName: aws.String("CacheMisses"),
-					Dimensions: []cwtypes.Dimension{{Name: aws.String("CacheClusterId"), Value: aws.String(clusterID)}},
-				},
-				Period: aws.Int32(86400),
-				Stat:   aws.String("Sum"),
-			},
-		},
-		{
-			Id: aws.String("m_cpu"),
-			MetricStat: &cwtypes.MetricStat{
-				Metric: &cwtypes.Metric{
-					Namespace:  aws.String("AWS/ElastiCache"),
-					MetricName: aws.String("CPUUtilization"),
-					Dimensions: []cwtypes.Dimension{{Name: aws.String("CacheClusterId"), Value: aws.String(clusterID)}},
-				},
-				Period: aws.Int32(86400),
-				Stat:   aws.String("Maximum"),
-			},
-		},
-		{
-			Id: aws.String("m_net"),
-			MetricStat: &cwtypes.MetricStat{
-				Metric: &cwtypes.Metric{
-					Namespace:  aws.String("AWS/ElastiCache"),
-					MetricName: aws.String("NetworkBytesIn"),
-					Dimensions: []cwtypes.Dimension{{Name: aws.String("CacheClusterId"), Value: aws.String(clusterID)}},
-				},
-				Period: aws.Int32(86400),
-				Stat:   aws.String("Sum"),
-			},
-		},
+// reservations totals active reserved nodes by node type and engine.
+func (s *ElasticacheScanner) reservations(ctx context.Context) (map[string]*reservationPool, error) {
+	pools := make(map[string]*reservationPool)
+	paginator := elasticache.NewDescribeReservedCacheNodesPaginator(s.Client, &elasticache.DescribeReservedCacheNodesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe reserved cache nodes: %v", err)
+		}
+		for _, r := range page.ReservedCacheNodes {
+			if aws.ToString(r.State) != "active" {
+				continue
+			}
+			key := reservationKey(aws.ToString(r.CacheNodeType), aws.ToString(r.ProductDescription))
+			pool := pools[key]
+			if pool == nil {
+				pool = &reservationPool{}
+				pools[key] = pool
+			}
+			pool.nodes += int(aws.ToInt32(r.CacheNodeCount))
+			if r.StartTime != nil {
+				end := r.StartTime.Add(time.Duration(aws.ToInt32(r.Duration)) * time.Second)
+				if pool.expiry.IsZero() || end.Before(pool.expiry) {
+					pool.expiry = end
+				}
+			}
+		}
 	}
+	return pools, nil
+}
 
-	out, err := s.CWClient.GetMetricData(ctx, &cloudwatch.GetMetricDataInput{
-		MetricDataQueries: queries,
-		StartTime:         &startTime,
-		EndTime:           &endTime,
-	})
+// allocateReservedNodes records how many of a cluster's nodes are covered by
+// reservations and draws them from the pool.
+func allocateReservedNodes(props map[string]interface{}, pools map[string]*reservationPool) {
+	nodeType, _ := props["NodeType"].(string)
+	engine, _ := props["Engine"].(string)
+	nodes, _ := props["NumCacheNodes"].(int)
 
-	if err != nil {
-		fmt.Printf("Error fetching metrics for %s: %v\n", clusterID, err)
+	pool := pools[reservationKey(nodeType, engine)]
+	if pool == nil || pool.nodes == 0 {
 		return
 	}
-
-	// Parse metrics.
-	var totalConn, totalHits, totalMisses, totalNet float64
-	var maxCPU float64
-
-	for _, res := range out.MetricDataResults {
-		sum := 0.0
-		max := 0.0
-		for _, val := range res.Values {
-			sum += val
-			if val > max {
-				max = val
-			}
-		}
-
-		switch *res.Id {
-		case "m_conn":
-			totalConn = sum
-		case "m_hits":
-			totalHits = sum
-		case "m_misses":
-			totalMisses = sum
-		case "m_cpu":
-			maxCPU = max // Peak CPU.
-		case "m_net":
-			totalNet = sum
-		}
+	covered := min(nodes, pool.nodes)
+	pool.nodes -= covered
+	props["ReservedNodes"] = covered
+	if !pool.expiry.IsZero() {
+		props["ReservationExpiry"] = pool.expiry
 	}
+}
 
-	// Update node.
-	s.Graph.Mu.Lock()
-	node.Properties["SumConnections7d"] = totalConn
-	node.Properties["SumHits7d"] = totalHits
-	node.Properties["SumMisses7d"] = totalMisses
-	node.Properties["MaxCPU7d"] = maxCPU
-	node.Properties["SumNetworkBytesIn7d"] = totalNet
-	s.Graph.Mu.Unlock()
+// reservationKey matches a reservation's ProductDescription ("redis") to a
+// cluster's Engine.
+func reservationKey(nodeType, engine string) string {
+	return nodeType + "/" + strings.ToLower(engine)
 }
@@ -0,0 +1,33 @@
+package aws
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAllocateReservedNodes(t *testing.T) {
+	expiry := time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC)
+	pools := map[string]*reservationPool{
+		reservationKey("cache.r6g.large", "redis"): {nodes: 3, expiry: expiry},
+	}
+
+	oldest := map[string]interface{}{"NodeType": "cache.r6g.large", "Engine": "redis", "NumCacheNodes": 2}
+	next := map[string]interface{}{"NodeType": "cache.r6g.large", "Engine": "Redis", "NumCacheNodes": 2}
+	last := map[string]interface{}{"NodeType": "cache.r6g.large", "Engine": "redis", "NumCacheNodes": 1}
+	memcached := map[string]interface{}{"NodeType": "cache.r6g.large", "Engine": "memcached", "NumCacheNodes": 1}
+	for _, props := range []map[string]interface{}{oldest, next, last, memcached} {
+		allocateReservedNodes(props, pools)
+	}
+
+	if oldest["ReservedNodes"] != 2 || oldest["ReservationExpiry"] != expiry {
+		t.Errorf("oldest cluster: %v, want 2 reserved nodes expiring %v", oldest, expiry)
+	}
+	if next["ReservedNodes"] != 1 {
+		t.Errorf("next cluster ReservedNodes = %v, want the 1 node left in the pool", next["ReservedNodes"])
+	}
+	for name, props := range map[string]map[string]interface{}{"exhausted pool": last, "other engine": memcached} {
+		if _, ok := props["ReservedNodes"]; ok {
+			t.Errorf("%s: unexpected ReservedNodes %v", name, props["ReservedNodes"])
+		}
+	}
+}
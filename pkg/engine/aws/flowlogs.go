@@ -0,0 +1,85 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+)
+
+// flowLogQuery sums accepted bytes per address pair.
+// Logs Insights discovers srcAddr/dstAddr/bytes for the default flow log format.
+const flowLogQuery = `filter action = "ACCEPT"
+| stats sum(bytes) as total by srcAddr, dstAddr
+| sort total desc
+| limit 10000`
+
+// FlowTotal is the bytes sent from one address to another.
+type FlowTotal struct {
+	SrcAddr string
+	DstAddr string
+	Bytes   float64
+}
+
+// QueryFlowTotals aggregates VPC Flow Log traffic per address pair with Logs Insights.
+func (c *CloudWatchLogsClient) QueryFlowTotals(ctx context.Context, logGroup string, window time.Duration) ([]FlowTotal, error) {
+	end := time.Now()
+	start := end.Add(-window)
+
+	out, err := c.Client.StartQuery(ctx, &cloudwatchlogs.StartQueryInput{
+		LogGroupName: aws.String(logGroup),
+		StartTime:    aws.Int64(start.Unix()),
+		EndTime:      aws.Int64(end.Unix()),
+		QueryString:  aws.String(flowLogQuery),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start flow log query: %v", err)
+	}
+
+	for {
+		res, err := c.Client.GetQueryResults(ctx, &cloudwatchlogs.GetQueryResultsInput{QueryId: out.QueryId})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get flow log query results: %v", err)
+		}
+
+		switch res.Status {
+		case types.QueryStatusComplete:
+			return parseFlowTotals(res.Results), nil
+		case types.QueryStatusFailed, types.QueryStatusCancelled, types.QueryStatusTimeout:
+			return nil, fmt.Errorf("flow log query ended with status %s", res.Status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
+// parseFlowTotals converts Insights result rows into FlowTotals.
+func parseFlowTotals(rows [][]types.ResultField) []FlowTotal {
+	var totals []FlowTotal
+	for _, row := range rows {
+		var ft FlowTotal
+		for _, f := range row {
+			value := aws.ToString(f.Value)
+			switch aws.ToString(f.Field) {
+			case "srcAddr":
+				ft.SrcAddr = value
+			case "dstAddr":
+				ft.DstAddr = value
+			case "total":
+				ft.Bytes, _ = strconv.ParseFloat(value, 64)
+			}
+		}
+		if ft.SrcAddr != "" && ft.DstAddr != "" && ft.Bytes > 0 {
+			totals = append(totals, ft)
+		}
+	}
+	return totals
+}
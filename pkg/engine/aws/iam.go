@@ -3,6 +3,7 @@ package aws
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
@@ -70,3 +71,73 @@ func (c *IAMClient) GetRolesFromInstanceProfile(ctx context.Context, profileName
 	}
 	return roleArns, nil
 }
+
+// SimulateDeletes evaluates a delete action against resources for a principal.
+// Returns the resources a guardrail would deny, mapped to "SCP" or "permissions boundary".
+// Ordinary implicit denies are ignored; the scanning role is usually read-only.
+func (c *IAMClient) SimulateDeletes(ctx context.Context, principalArn, action string, resourceArns []string) (map[string]string, error) {
+	blocked := make(map[string]string)
+
+	// Keep requests well below the simulator's per-call resource limit.
+	const batchSize = 20
+	for start := 0; start < len(resourceArns); start += batchSize {
+		end := min(start+batchSize, len(resourceArns))
+
+		paginator := iam.NewSimulatePrincipalPolicyPaginator(c.Client, &iam.SimulatePrincipalPolicyInput{
+			PolicySourceArn: aws.String(principalArn),
+			ActionNames:     []string{action},
+			ResourceArns:    resourceArns[start:end],
+		})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("policy simulation failed: %v", err)
+			}
+			for arn, by := range policyBlockers(page.EvaluationResults) {
+				blocked[arn] = by
+			}
+		}
+	}
+	return blocked, nil
+}
+
+// policyBlockers extracts resources denied by an SCP or permissions boundary.
+func policyBlockers(results []types.EvaluationResult) map[string]string {
+	blocked := make(map[string]string)
+	for _, r := range results {
+		by := ""
+		if d := r.OrganizationsDecisionDetail; d != nil && !d.AllowedByOrganizations {
+			by = "SCP"
+		} else if d := r.PermissionsBoundaryDecisionDetail; d != nil && !d.AllowedByPermissionsBoundary {
+			by = "permissions boundary"
+		}
+
+		if len(r.ResourceSpecificResults) == 0 {
+			if by != "" {
+				blocked[aws.ToString(r.EvalResourceName)] = by
+			}
+			continue
+		}
+		for _, rs := range r.ResourceSpecificResults {
+			rsBy := by
+			if d := rs.PermissionsBoundaryDecisionDetail; rsBy == "" && d != nil && !d.AllowedByPermissionsBoundary {
+				rsBy = "permissions boundary"
+			}
+			if rsBy != "" {
+				blocked[aws.ToString(rs.EvalResourceName)] = rsBy
+			}
+		}
+	}
+	return blocked
+}
+
+// SimulationPrincipal converts a caller identity ARN into one the simulator accepts.
+// Assumed-role sessions map back to their IAM role (role paths are not recoverable).
+func SimulationPrincipal(callerArn string) string {
+	parts := strings.Split(callerArn, ":")
+	if len(parts) != 6 || parts[2] != "sts" || !strings.HasPrefix(parts[5], "assumed-role/") {
+		return callerArn
+	}
+	role := strings.Split(strings.TrimPrefix(parts[5], "assumed-role/"), "/")[0]
+	return fmt.Sprintf("arn:%s:iam::%s:role/%s", parts[1], parts[4], role)
+}
@@ -0,0 +1,57 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+)
+
+func TestPolicyBlockers(t *testing.T) {
+	results := []types.EvaluationResult{
+		{
+			EvalActionName:              aws.String("ec2:DeleteVolume"),
+			EvalResourceName:            aws.String("arn:aws:ec2:us-east-1:123:volume/vol-scp"),
+			EvalDecision:                types.PolicyEvaluationDecisionTypeExplicitDeny,
+			OrganizationsDecisionDetail: &types.OrganizationsDecisionDetail{AllowedByOrganizations: false},
+		},
+		{
+			EvalActionName:   aws.String("ec2:DeleteVolume"),
+			EvalResourceName: aws.String("arn:aws:ec2:us-east-1:123:volume/vol-pb"),
+			EvalDecision:     types.PolicyEvaluationDecisionTypeImplicitDeny,
+			PermissionsBoundaryDecisionDetail: &types.PermissionsBoundaryDecisionDetail{
+				AllowedByPermissionsBoundary: false,
+			},
+		},
+		{
+			// Plain implicit deny: the scanning role just lacks the permission.
+			EvalActionName:   aws.String("ec2:DeleteVolume"),
+			EvalResourceName: aws.String("arn:aws:ec2:us-east-1:123:volume/vol-readonly"),
+			EvalDecision:     types.PolicyEvaluationDecisionTypeImplicitDeny,
+		},
+	}
+
+	blocked := policyBlockers(results)
+	if len(blocked) != 2 {
+		t.Fatalf("Expected 2 blocked resources, got %v", blocked)
+	}
+	if blocked["arn:aws:ec2:us-east-1:123:volume/vol-scp"] != "SCP" {
+		t.Errorf("Expected SCP block, got %q", blocked["arn:aws:ec2:us-east-1:123:volume/vol-scp"])
+	}
+	if blocked["arn:aws:ec2:us-east-1:123:volume/vol-pb"] != "permissions boundary" {
+		t.Errorf("Expected boundary block, got %q", blocked["arn:aws:ec2:us-east-1:123:volume/vol-pb"])
+	}
+}
+
+func TestSimulationPrincipal(t *testing.T) {
+	cases := map[string]string{
+		"arn:aws:sts::123456789012:assumed-role/Admin/alice":   "arn:aws:iam::123456789012:role/Admin",
+		"arn:aws:iam::123456789012:user/ci":                    "arn:aws:iam::123456789012:user/ci",
+		"arn:aws-us-gov:sts::123456789012:assumed-role/Ops/s1": "arn:aws-us-gov:iam::123456789012:role/Ops",
+	}
+	for in, want := range cases {
+		if got := SimulationPrincipal(in); got != want {
+			t.Errorf("SimulationPrincipal(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
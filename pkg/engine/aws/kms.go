@@ -0,0 +1,205 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+)
+
+type kmsAPI interface {
+	kms.ListKeysAPIClient
+	kms.ListAliasesAPIClient
+	kms.ListKeyRotationsAPIClient
+	kms.ListResourceTagsAPIClient
+	DescribeKey(ctx context.Context, params *kms.DescribeKeyInput, optFns ...func(*kms.Options)) (*kms.DescribeKeyOutput, error)
+	GetKeyRotationStatus(ctx context.Context, params *kms.GetKeyRotationStatusInput, optFns ...func(*kms.Options)) (*kms.GetKeyRotationStatusOutput, error)
+}
+
+// KMSScanner scans customer managed KMS keys. AWS managed keys are free and
+// cannot be deleted, so they are left out.
+type KMSScanner struct {
+	Client kmsAPI
+	Region string
+	Graph  *graph.Graph
+}
+
+// NewKMSScanner initializes a scanner for KMS.
+func NewKMSScanner(cfg aws.Config, g *graph.Graph) *KMSScanner {
+	return &KMSScanner{
+		Client: kms.NewFromConfig(cfg),
+		Region: cfg.Region,
+		Graph:  g,
+	}
+}
+
+// ScanKeys maps customer managed keys with their state, rotation and aliases.
+// Last use is read from CloudTrail by forensics.Detective.TraceKeyUsage.
+func (s *KMSScanner) ScanKeys(ctx context.Context) error {
+	aliases, err := s.aliases(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list kms aliases: %v", err)
+	}
+
+	paginator := kms.NewListKeysPaginator(s.Client, &kms.ListKeysInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list kms keys: %v", err)
+		}
+
+		for _, entry := range page.Keys {
+			desc, err := s.Client.DescribeKey(ctx, &kms.DescribeKeyInput{KeyId: entry.KeyId})
+			if err != nil || desc.KeyMetadata == nil {
+				continue
+			}
+			key := desc.KeyMetadata
+			if key.KeyManager != kmstypes.KeyManagerTypeCustomer {
+				continue
+			}
+			keyID := aws.ToString(key.KeyId)
+
+			props := map[string]interface{}{
+				"KeyId":       keyID,
+				"KeyState":    string(key.KeyState),
+				"KeyManager":  string(key.KeyManager),
+				"Enabled":     key.Enabled,
+				"Origin":      string(key.Origin),
+				"KeySpec":     string(key.KeySpec),
+				"KeyUsage":    string(key.KeyUsage),
+				"Description": aws.ToString(key.Description),
+				"MultiRegion": aws.ToBool(key.MultiRegion),
+				"Aliases":     aliases[keyID],
+				"Region":      s.Region,
+			}
+			if key.CreationDate != nil {
+				props["CreateTime"] = *key.CreationDate
+			}
+			if key.DeletionDate != nil {
+				props["DeletionDate"] = *key.DeletionDate
+			}
+			if tags := s.tags(ctx, keyID); len(tags) > 0 {
+				props["Tags"] = tags
+			}
+			// Only symmetric keys with KMS-generated material rotate.
+			if key.KeySpec == kmstypes.KeySpecSymmetricDefault && key.Origin == kmstypes.OriginTypeAwsKms {
+				if rot, err := s.Client.GetKeyRotationStatus(ctx, &kms.GetKeyRotationStatusInput{KeyId: key.KeyId}); err == nil {
+					props["RotationEnabled"] = rot.KeyRotationEnabled
+				}
+				if n, err := s.rotations(ctx, keyID); err == nil {
+					props["Rotations"] = n
+				}
+			}
+
+			s.Graph.AddNode(aws.ToString(key.Arn), "AWS::KMS::Key", props)
+		}
+	}
+	return nil
+}
+
+// aliases maps key IDs to their alias names.
+func (s *KMSScanner) aliases(ctx context.Context) (map[string][]string, error) {
+	out := make(map[string][]string)
+	paginator := kms.NewListAliasesPaginator(s.Client, &kms.ListAliasesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range page.Aliases {
+			if a.TargetKeyId == nil || strings.HasPrefix(aws.ToString(a.AliasName), "alias/aws/") {
+				continue
+			}
+			id := aws.ToString(a.TargetKeyId)
+			out[id] = append(out[id], aws.ToString(a.AliasName))
+		}
+	}
+	return out, nil
+}
+
+// rotations counts the times a key's material has been rotated.
+func (s *KMSScanner) rotations(ctx context.Context, keyID string) (int, error) {
+	n := 0
+	paginator := kms.NewListKeyRotationsPaginator(s.Client, &kms.ListKeyRotationsInput{KeyId: aws.String(keyID)})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return 0, err
+		}
+		n += len(page.Rotations)
+	}
+	return n, nil
+}
+
+func (s *KMSScanner) tags(ctx context.Context, keyID string) map[string]string {
+	out := make(map[string]string)
+	paginator := kms.NewListResourceTagsPaginator(s.Client, &kms.ListResourceTagsInput{KeyId: aws.String(keyID)})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			break
+		}
+		for _, t := range page.Tags {
+			out[aws.ToString(t.TagKey)] = aws.ToString(t.TagValue)
+		}
+	}
+	return out
+}
+
+// LinkKMSKeys adds an edge from every resource recording a KmsKeyId to the
+// key it names, by ARN, key ID or alias, so heuristics can tell which keys
+// still protect something. Call it after scanning and before the heuristics
+// run.
+func LinkKMSKeys(g *graph.Graph) int {
+	type link struct{ resource, key string }
+	var links []link
+
+	// Scanners queue their writes; wait for them to land.
+	g.Flush()
+
+	g.Mu.RLock()
+	keys := make(map[string]string)
+	for _, node := range g.Store.GetAllNodes() {
+		if node.TypeStr() != "AWS::KMS::Key" {
+			continue
+		}
+		keyARN := node.IDStr()
+		keys[keyARN] = keyARN
+		if id, _ := node.Properties["KeyId"].(string); id != "" {
+			keys[id] = keyARN
+		}
+		aliases, _ := node.Properties["Aliases"].([]string)
+		for _, a := range aliases {
+			keys[a] = keyARN
+		}
+	}
+	if len(keys) > 0 {
+		for _, node := range g.Store.GetAllNodes() {
+			ref, _ := node.Properties["KmsKeyId"].(string)
+			if ref == "" {
+				continue
+			}
+			// Alias ARNs end in the alias name.
+			if i := strings.Index(ref, ":alias/"); i >= 0 {
+				ref = ref[i+1:]
+			}
+			if keyARN, ok := keys[ref]; ok {
+				links = append(links, link{node.IDStr(), keyARN})
+			}
+		}
+	}
+	g.Mu.RUnlock()
+
+	for _, l := range links {
+		g.AddTypedEdge(l.resource, l.key, graph.EdgeTypeUses, 100)
+	}
+	// Heuristics read the edges; let them land.
+	if len(links) > 0 {
+		g.Flush()
+	}
+	return len(links)
+}
@@ -0,0 +1,106 @@
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+)
+
+type fakeKMSAPI struct {
+	keys map[string]kmstypes.KeyMetadata
+}
+
+func (f *fakeKMSAPI) ListKeys(ctx context.Context, in *kms.ListKeysInput, optFns ...func(*kms.Options)) (*kms.ListKeysOutput, error) {
+	out := &kms.ListKeysOutput{}
+	for id := range f.keys {
+		out.Keys = append(out.Keys, kmstypes.KeyListEntry{KeyId: aws.String(id)})
+	}
+	return out, nil
+}
+
+func (f *fakeKMSAPI) DescribeKey(ctx context.Context, in *kms.DescribeKeyInput, optFns ...func(*kms.Options)) (*kms.DescribeKeyOutput, error) {
+	key := f.keys[aws.ToString(in.KeyId)]
+	return &kms.DescribeKeyOutput{KeyMetadata: &key}, nil
+}
+
+func (f *fakeKMSAPI) ListAliases(ctx context.Context, in *kms.ListAliasesInput, optFns ...func(*kms.Options)) (*kms.ListAliasesOutput, error) {
+	return &kms.ListAliasesOutput{Aliases: []kmstypes.AliasListEntry{
+		{AliasName: aws.String("alias/app"), TargetKeyId: aws.String("k-customer")},
+		{AliasName: aws.String("alias/aws/ebs"), TargetKeyId: aws.String("k-aws")},
+	}}, nil
+}
+
+func (f *fakeKMSAPI) GetKeyRotationStatus(ctx context.Context, in *kms.GetKeyRotationStatusInput, optFns ...func(*kms.Options)) (*kms.GetKeyRotationStatusOutput, error) {
+	return &kms.GetKeyRotationStatusOutput{KeyRotationEnabled: true}, nil
+}
+
+func (f *fakeKMSAPI) ListKeyRotations(ctx context.Context, in *kms.ListKeyRotationsInput, optFns ...func(*kms.Options)) (*kms.ListKeyRotationsOutput, error) {
+	return &kms.ListKeyRotationsOutput{Rotations: make([]kmstypes.RotationsListEntry, 2)}, nil
+}
+
+func (f *fakeKMSAPI) ListResourceTags(ctx context.Context, in *kms.ListResourceTagsInput, optFns ...func(*kms.Options)) (*kms.ListResourceTagsOutput, error) {
+	return &kms.ListResourceTagsOutput{Tags: []kmstypes.Tag{{TagKey: aws.String("team"), TagValue: aws.String("data")}}}, nil
+}
+
+func TestKMSScanner(t *testing.T) {
+	created := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	api := &fakeKMSAPI{keys: map[string]kmstypes.KeyMetadata{
+		"k-customer": {
+			KeyId: aws.String("k-customer"), Arn: aws.String("arn:aws:kms:us-east-1:123:key/k-customer"),
+			KeyManager: kmstypes.KeyManagerTypeCustomer, KeyState: kmstypes.KeyStateEnabled, Enabled: true,
+			KeySpec: kmstypes.KeySpecSymmetricDefault, Origin: kmstypes.OriginTypeAwsKms, CreationDate: &created,
+		},
+		"k-aws": {
+			KeyId: aws.String("k-aws"), Arn: aws.String("arn:aws:kms:us-east-1:123:key/k-aws"),
+			KeyManager: kmstypes.KeyManagerTypeAws, KeyState: kmstypes.KeyStateEnabled,
+		},
+	}}
+	g := graph.NewGraph()
+	s := &KMSScanner{Client: api, Region: "us-east-1", Graph: g}
+	if err := s.ScanKeys(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	g.CloseAndWait()
+
+	if g.GetNode("arn:aws:kms:us-east-1:123:key/k-aws") != nil {
+		t.Error("Expected AWS managed keys to be skipped")
+	}
+	node := g.GetNode("arn:aws:kms:us-east-1:123:key/k-customer")
+	if node == nil {
+		t.Fatal("Expected the customer managed key")
+	}
+	if node.Properties["KeyState"] != "Enabled" || node.Properties["RotationEnabled"] != true || node.Properties["Rotations"] != 2 {
+		t.Errorf("Unexpected props %v", node.Properties)
+	}
+	if aliases, _ := node.Properties["Aliases"].([]string); len(aliases) != 1 || aliases[0] != "alias/app" {
+		t.Errorf("Expected alias/app, got %v", aliases)
+	}
+	if c, ok := node.CreatedAt(); !ok || !c.Equal(created) {
+		t.Errorf("Expected creation time %v, got %v", created, c)
+	}
+}
+
+func TestLinkKMSKeys(t *testing.T) {
+	g := graph.NewGraph()
+	key := "arn:aws:kms:us-east-1:123:key/k-1"
+	g.AddNode(key, "AWS::KMS::Key", map[string]interface{}{"KeyId": "k-1", "Aliases": []string{"alias/app"}})
+	g.AddNode("vol-arn", "AWS::EC2::Volume", map[string]interface{}{"KmsKeyId": key})
+	g.AddNode("db-id", "AWS::RDS::DBInstance", map[string]interface{}{"KmsKeyId": "k-1"})
+	g.AddNode("fs-alias", "AWS::EFS::FileSystem", map[string]interface{}{"KmsKeyId": "arn:aws:kms:us-east-1:123:alias/app"})
+	g.AddNode("vol-other", "AWS::EC2::Volume", map[string]interface{}{"KmsKeyId": "arn:aws:kms:us-east-1:123:key/unscanned"})
+
+	// No flush before linking: queued nodes must still be seen.
+	if n := LinkKMSKeys(g); n != 3 {
+		t.Fatalf("Expected 3 links, got %d", n)
+	}
+	g.CloseAndWait()
+
+	if refs := g.GetReverseEdges(g.GetNode(key).Index); len(refs) != 3 {
+		t.Errorf("Expected 3 resources referencing the key, got %d", len(refs))
+	}
+}
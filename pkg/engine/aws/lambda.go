@@ -2,6 +2,8 @@ package aws
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
@@ -13,23 +15,41 @@ import (
 
 // LambdaScanner scans Lambda functions.
 type LambdaScanner struct {
-	Client   *lambda.Client
-	CWClient *cloudwatch.Client
-	Graph    *graph.Graph
+	Client *lambda.Client
+	CW     *CloudWatchClient
+	Graph  *graph.Graph
 }
 
 func NewLambdaScanner(cfg aws.Config, g *graph.Graph) *LambdaScanner {
 	return &LambdaScanner{
-		Client:   lambda.NewFromConfig(cfg),
-		CWClient: cloudwatch.NewFromConfig(cfg),
-		Graph:    g,
+		Client: lambda.NewFromConfig(cfg),
+		CW:     NewCloudWatchClient(cfg),
+		Graph:  g,
 	}
 }
 
+const (
+	// lambdaRotWindow is the lookback for SumInvocations90d (code rot).
+	lambdaRotWindow = 90 * 24 * time.Hour
+	// LambdaIdleWindow is the lookback for the 30-day invocation, duration and
+	// provisioned-concurrency utilization properties.
+	LambdaIdleWindow = 30 * 24 * time.Hour
+)
+
+// lambdaFunction is a function being scanned; metrics land in props before the
+// node is added, so heuristics never see a half-populated function.
+type lambdaFunction struct {
+	name, arn string
+	props     map[string]interface{}
+	// qualifiers are the aliases/versions with provisioned concurrency.
+	qualifiers []string
+}
+
 // ScanFunctions scans functions and checks usage.
 func (s *LambdaScanner) ScanFunctions(ctx context.Context) error {
 	paginator := lambda.NewListFunctionsPaginator(s.Client, &lambda.ListFunctionsInput{})
 
+	var fns []*lambdaFunction
 	for paginator.HasMorePages() {
 		page, err := paginator.NextPage(ctx)
 		if err != nil {
@@ -40,72 +60,225 @@ func (s *LambdaScanner) ScanFunctions(ctx context.Context) error {
 			name := *fn.FunctionName
 			arn := *fn.FunctionArn
 
+			arch := "x86_64"
+			if len(fn.Architectures) > 0 {
+				arch = string(fn.Architectures[0])
+			}
 			props := map[string]interface{}{
 				"Service":      "Lambda",
 				"Runtime":      string(fn.Runtime),
 				"LastModified": *fn.LastModified, // Timestamp string.
 				"CodeSize":     fn.CodeSize,
-				"MemorySize":   fn.MemorySize,
+				"MemorySize":   aws.ToInt32(fn.MemorySize), // MB
+				"Architecture": arch,
 			}
 
-			s.Graph.AddNode(name, "aws_lambda_function", props)
-
-			// Analyze execution metrics to detect potential staleness (code rot).
-			go s.checkCodeRot(ctx, name, props)
+			f := &lambdaFunction{name: name, arn: arn, props: props}
+			s.scanTags(ctx, f)
+			s.scanProvisionedConcurrency(ctx, f)
+			fns = append(fns, f)
+		}
+	}
 
-			// Catalog function versions and aliases.
-			go s.scanVersionsAndAliases(ctx, name, arn)
+	// Analyze execution metrics to detect staleness (code rot) and idle provisioned concurrency.
+	if s.CW != nil && len(fns) > 0 {
+		if err := s.collectMetrics(ctx, fns); err != nil {
+			s.Graph.AddError("CloudWatch [ScanLambdaFunctions]", err)
 		}
 	}
+
+	for _, f := range fns {
+		s.Graph.AddNode(f.name, "aws_lambda_function", f.props)
+	}
+	s.Graph.Flush()
+
+	// Catalog function versions and aliases.
+	for _, f := range fns {
+		go s.scanVersionsAndAliases(ctx, f.name, f.arn)
+	}
 	return nil
 }
 
-// checkCodeRot checks invocations over 90 days.
-func (s *LambdaScanner) checkCodeRot(ctx context.Context, funcName string, props map[string]interface{}) {
-	node := s.Graph.GetNode(funcName)
-	exists := (node != nil)
-	if !exists {
+// scanTags records the function's tags. A denied lambda:ListTags is noted on
+// the function; other errors are reported and leave Tags unset.
+func (s *LambdaScanner) scanTags(ctx context.Context, f *lambdaFunction) {
+	out, err := s.Client.ListTags(ctx, &lambda.ListTagsInput{Resource: aws.String(f.arn)})
+	if err != nil {
+		if !RecordPropertyError(f.props, "lambda:ListTags", err) {
+			s.Graph.AddError(fmt.Sprintf("Lambda [%s]", f.name), fmt.Errorf("failed to list tags: %v", err))
+		}
 		return
 	}
+	if len(out.Tags) > 0 {
+		f.props["Tags"] = out.Tags
+	}
+}
+
+// scanProvisionedConcurrency records the provisioned concurrency allocated
+// across the function's aliases and versions. Errors (e.g. missing
+// lambda:ListProvisionedConcurrencyConfigs) leave the properties unset.
+func (s *LambdaScanner) scanProvisionedConcurrency(ctx context.Context, f *lambdaFunction) {
+	total := 0
+	p := lambda.NewListProvisionedConcurrencyConfigsPaginator(s.Client, &lambda.ListProvisionedConcurrencyConfigsInput{FunctionName: aws.String(f.name)})
+	for p.HasMorePages() {
+		page, err := p.NextPage(ctx)
+		if err != nil {
+			return
+		}
+		for _, cfg := range page.ProvisionedConcurrencyConfigs {
+			n := aws.ToInt32(cfg.AllocatedProvisionedConcurrentExecutions)
+			if n == 0 {
+				n = aws.ToInt32(cfg.RequestedProvisionedConcurrentExecutions)
+			}
+			if n == 0 {
+				continue
+			}
+			total += int(n)
+			// The config ARN ends in the qualifier: ...:function:name:alias.
+			arn := aws.ToString(cfg.FunctionArn)
+			f.qualifiers = append(f.qualifiers, arn[strings.LastIndex(arn, ":")+1:])
+		}
+	}
+	if total > 0 {
+		f.props["ProvisionedConcurrency"] = total
+		f.props["ProvisionedConcurrencyQualifiers"] = f.qualifiers
+	}
+}
+
+// lambdaSeries is one query's datapoints.
+type lambdaSeries struct {
+	values     []float64
+	timestamps []time.Time
+}
+
+// sumSince adds the datapoints at or after t.
+func (ls lambdaSeries) sumSince(t time.Time) float64 {
+	total := 0.0
+	for i, v := range ls.values {
+		if i < len(ls.timestamps) && ls.timestamps[i].Before(t) {
+			continue
+		}
+		total += v
+	}
+	return total
+}
 
-	endTime := time.Now()
-	startTime := endTime.Add(-90 * 24 * time.Hour) // 90-day window.
+// collectMetrics fetches daily Invocations and Duration sums for every function
+// and provisioned-concurrency utilization for every configured qualifier,
+// packing the queries into as few GetMetricData requests as possible.
+func (s *LambdaScanner) collectMetrics(ctx context.Context, fns []*lambdaFunction) error {
+	end := time.Now()
+	start := end.Add(-lambdaRotWindow)
 
-	queries := []cwtypes.MetricDataQuery{
-		{
-			Id: aws.String("m_invocations"),
+	var queries []cwtypes.MetricDataQuery
+	add := func(id, metric, stat string, dims ...cwtypes.Dimension) {
+		queries = append(queries, cwtypes.MetricDataQuery{
+			Id: aws.String(id),
 			MetricStat: &cwtypes.MetricStat{
 				Metric: &cwtypes.Metric{
 					Namespace:  aws.String("AWS/Lambda"),
-					MetricName: aws.String("Invocations"),
-					Dimensions: []cwtypes.Dimension{{Name: aws.String("FunctionName"), Value: aws.String(funcName)}},
+					MetricName: aws.String(metric),
+					Dimensions: dims,
 				},
 				Period: aws.Int32(86400),
-				Stat:   aws.String("Sum"),
+				Stat:   aws.String(stat),
 			},
-		},
+		})
+	}
+	for i, f := range fns {
+		fnDim := cwtypes.Dimension{Name: aws.String("FunctionName"), Value: aws.String(f.name)}
+		add(fmt.Sprintf("inv_%d", i), "Invocations", "Sum", fnDim)
+		add(fmt.Sprintf("dur_%d", i), "Duration", "Sum", fnDim)
+		for j, q := range f.qualifiers {
+			resDim := cwtypes.Dimension{Name: aws.String("Resource"), Value: aws.String(f.name + ":" + q)}
+			add(fmt.Sprintf("pca_%d_%d", i, j), "ProvisionedConcurrencyUtilization", "Average", fnDim, resDim)
+			add(fmt.Sprintf("pcm_%d_%d", i, j), "ProvisionedConcurrencyUtilization", "Maximum", fnDim, resDim)
+		}
 	}
 
-	out, err := s.CWClient.GetMetricData(ctx, &cloudwatch.GetMetricDataInput{
-		MetricDataQueries: queries,
-		StartTime:         &startTime,
-		EndTime:           &endTime,
-	})
+	series := make(map[string]*lambdaSeries, len(queries))
+	for from := 0; from < len(queries); from += metricDataMaxQueries {
+		input := &cloudwatch.GetMetricDataInput{
+			MetricDataQueries: queries[from:min(from+metricDataMaxQueries, len(queries))],
+			StartTime:         aws.Time(start),
+			EndTime:           aws.Time(end),
+		}
+		for {
+			var out *cloudwatch.GetMetricDataOutput
+			err := s.CW.call(ctx, func() error {
+				var err error
+				out, err = s.CW.Client.GetMetricData(ctx, input)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("failed to get Lambda metrics: %w", err)
+			}
+			for _, res := range out.MetricDataResults {
+				id := aws.ToString(res.Id)
+				ls := series[id]
+				if ls == nil {
+					ls = &lambdaSeries{}
+					series[id] = ls
+				}
+				ls.values = append(ls.values, res.Values...)
+				ls.timestamps = append(ls.timestamps, res.Timestamps...)
+			}
+			if out.NextToken == nil {
+				break
+			}
+			input.NextToken = out.NextToken
+		}
+	}
 
-	if err != nil {
-		return
+	for i, f := range fns {
+		applyLambdaMetrics(f, i, series, end.Add(-LambdaIdleWindow))
 	}
+	return nil
+}
 
-	totalInvocations := 0.0
-	for _, res := range out.MetricDataResults {
-		for _, v := range res.Values {
-			totalInvocations += v
+// applyLambdaMetrics turns the function's query results into properties:
+// SumInvocations90d, Invocations30d, DurationMs30d (total billed milliseconds)
+// and, with provisioned concurrency, PCUtilizationAvg30d/PCUtilizationMax30d
+// (fractions of the provisioned executions in use).
+func applyLambdaMetrics(f *lambdaFunction, i int, series map[string]*lambdaSeries, since time.Time) {
+	get := func(id string) lambdaSeries {
+		if ls := series[id]; ls != nil {
+			return *ls
 		}
+		return lambdaSeries{}
 	}
 
-	s.Graph.Mu.Lock()
-	node.Properties["SumInvocations90d"] = totalInvocations
-	s.Graph.Mu.Unlock()
+	inv := get(fmt.Sprintf("inv_%d", i))
+	f.props["SumInvocations90d"] = inv.sumSince(time.Time{})
+	f.props["Invocations30d"] = inv.sumSince(since)
+	f.props["DurationMs30d"] = get(fmt.Sprintf("dur_%d", i)).sumSince(since)
+
+	var avgSum float64
+	var avgCount int
+	peak := -1.0
+	for j := range f.qualifiers {
+		avg := get(fmt.Sprintf("pca_%d_%d", i, j))
+		for k, v := range avg.values {
+			if k < len(avg.timestamps) && avg.timestamps[k].Before(since) {
+				continue
+			}
+			avgSum += v
+			avgCount++
+		}
+		mx := get(fmt.Sprintf("pcm_%d_%d", i, j))
+		for k, v := range mx.values {
+			if k < len(mx.timestamps) && mx.timestamps[k].Before(since) {
+				continue
+			}
+			peak = max(peak, v)
+		}
+	}
+	if avgCount > 0 {
+		f.props["PCUtilizationAvg30d"] = avgSum / float64(avgCount)
+	}
+	if peak >= 0 {
+		f.props["PCUtilizationMax30d"] = peak
+	}
 }
 
 // scanVersionsAndAliases maps versions and aliases.
@@ -0,0 +1,63 @@
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestApplyLambdaMetrics(t *testing.T) {
+	now := time.Now()
+	since := now.Add(-LambdaIdleWindow)
+	old, recent := now.Add(-60*24*time.Hour), now.Add(-2*24*time.Hour)
+
+	f := &lambdaFunction{name: "api", props: map[string]interface{}{}, qualifiers: []string{"live"}}
+	series := map[string]*lambdaSeries{
+		"inv_0":   {values: []float64{40, 2}, timestamps: []time.Time{old, recent}},
+		"dur_0":   {values: []float64{9000, 300}, timestamps: []time.Time{old, recent}},
+		"pca_0_0": {values: []float64{0.9, 0.05, 0.15}, timestamps: []time.Time{old, recent, now}},
+		"pcm_0_0": {values: []float64{1, 0.3}, timestamps: []time.Time{old, recent}},
+	}
+	applyLambdaMetrics(f, 0, series, since)
+
+	if f.props["SumInvocations90d"] != 42.0 || f.props["Invocations30d"] != 2.0 {
+		t.Errorf("invocations = %v / %v, want 42 over 90d and 2 over 30d", f.props["SumInvocations90d"], f.props["Invocations30d"])
+	}
+	if f.props["DurationMs30d"] != 300.0 {
+		t.Errorf("DurationMs30d = %v, want 300", f.props["DurationMs30d"])
+	}
+	if avg, _ := f.props["PCUtilizationAvg30d"].(float64); avg < 0.099 || avg > 0.101 {
+		t.Errorf("PCUtilizationAvg30d = %v, want 0.1", avg)
+	}
+	if f.props["PCUtilizationMax30d"] != 0.3 {
+		t.Errorf("PCUtilizationMax30d = %v, want 0.3", f.props["PCUtilizationMax30d"])
+	}
+
+	// No provisioned concurrency: no utilization properties.
+	plain := &lambdaFunction{name: "cron", props: map[string]interface{}{}}
+	applyLambdaMetrics(plain, 1, series, since)
+	if _, ok := plain.props["PCUtilizationAvg30d"]; ok || plain.props["Invocations30d"] != 0.0 {
+		t.Errorf("unexpected props for a function without data: %v", plain.props)
+	}
+}
+
+func TestCollectLambdaMetricsBatches(t *testing.T) {
+	api := &fakeCloudWatchAPI{}
+	cw, _ := testCloudWatchClient(api)
+	s := &LambdaScanner{CW: cw}
+
+	fns := make([]*lambdaFunction, 300)
+	for i := range fns {
+		fns[i] = &lambdaFunction{name: "fn", props: map[string]interface{}{}}
+	}
+	if err := s.collectMetrics(context.Background(), fns); err != nil {
+		t.Fatalf("collectMetrics: %v", err)
+	}
+	// Two queries per function, 500 per request.
+	if len(api.queries) != 2 || api.queries[0] != 500 || api.queries[1] != 100 {
+		t.Errorf("queries per request = %v, want [500 100]", api.queries)
+	}
+	if _, ok := fns[299].props["Invocations30d"]; !ok {
+		t.Error("last function has no metrics")
+	}
+}
@@ -19,6 +19,8 @@ type CloudWatchLogsClient struct {
 	CWClient       *cloudwatch.Client
 	Graph          *graph.Graph
 	DisableMetrics bool
+	Region         string
+	AccountID      string // Set by the caller when known.
 }
 
 func NewCloudWatchLogsClient(cfg aws.Config, g *graph.Graph, disableMetrics bool) *CloudWatchLogsClient {
@@ -27,6 +29,7 @@ func NewCloudWatchLogsClient(cfg aws.Config, g *graph.Graph, disableMetrics bool
 		CWClient:       cloudwatch.NewFromConfig(cfg),
 		Graph:          g,
 		DisableMetrics: disableMetrics,
+		Region:         cfg.Region,
 	}
 }
 
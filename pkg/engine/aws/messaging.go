@@ -0,0 +1,279 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// MessagingMetricWindow is the default CloudWatch lookback for queue and topic activity.
+const MessagingMetricWindow = 14 * 24 * time.Hour
+
+type sqsAPI interface {
+	sqs.ListQueuesAPIClient
+	GetQueueAttributes(ctx context.Context, params *sqs.GetQueueAttributesInput, optFns ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error)
+}
+
+type snsAPI interface {
+	sns.ListTopicsAPIClient
+	sns.ListSubscriptionsByTopicAPIClient
+	GetTopicAttributes(ctx context.Context, params *sns.GetTopicAttributesInput, optFns ...func(*sns.Options)) (*sns.GetTopicAttributesOutput, error)
+}
+
+// MessagingScanner scans SQS queues and SNS topics.
+type MessagingScanner struct {
+	SQS      sqsAPI
+	SNS      snsAPI
+	CWClient metricDataAPI
+	Graph    *graph.Graph
+	Window   time.Duration // Metric lookback; zero means MessagingMetricWindow.
+}
+
+// NewMessagingScanner initializes a scanner for SQS and SNS.
+func NewMessagingScanner(cfg aws.Config, g *graph.Graph) *MessagingScanner {
+	return &MessagingScanner{
+		SQS:      sqs.NewFromConfig(cfg),
+		SNS:      sns.NewFromConfig(cfg),
+		CWClient: cloudwatch.NewFromConfig(cfg),
+		Graph:    g,
+	}
+}
+
+// ScanQueues maps queues (AWS::SQS::Queue) with their backlog, dead-letter
+// target and messages sent and received over the metric window.
+func (s *MessagingScanner) ScanQueues(ctx context.Context) error {
+	paginator := sqs.NewListQueuesPaginator(s.SQS, &sqs.ListQueuesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list sqs queues: %v", err)
+		}
+		for _, url := range page.QueueUrls {
+			out, err := s.SQS.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+				QueueUrl:       aws.String(url),
+				AttributeNames: []sqstypes.QueueAttributeName{sqstypes.QueueAttributeNameAll},
+			})
+			if err != nil {
+				// Deleted since listing, or no access; skip the queue.
+				continue
+			}
+			id, props := queueProps(url, out.Attributes)
+			if id == "" {
+				continue
+			}
+			name, _ := props["QueueName"].(string)
+			s.enrichMetrics(ctx, "AWS/SQS", "QueueName", name, props, map[string]string{
+				"NumberOfMessagesSent":     "MessagesSent",
+				"NumberOfMessagesReceived": "MessagesReceived",
+			})
+			s.Graph.AddNode(id, "AWS::SQS::Queue", props)
+		}
+	}
+	return nil
+}
+
+// queueProps flattens queue attributes. The node ID is the queue ARN.
+func queueProps(url string, attrs map[string]string) (string, map[string]interface{}) {
+	id := attrs[string(sqstypes.QueueAttributeNameQueueArn)]
+	props := map[string]interface{}{
+		"QueueUrl": url,
+		"IsFIFO":   attrs[string(sqstypes.QueueAttributeNameFifoQueue)] == "true",
+	}
+	if parsed, err := arn.Parse(id); err == nil {
+		props["QueueName"] = parsed.Resource
+		props["Region"] = parsed.Region
+		props["AccountId"] = parsed.AccountID
+	}
+	if n, err := strconv.Atoi(attrs[string(sqstypes.QueueAttributeNameApproximateNumberOfMessages)]); err == nil {
+		props["ApproximateNumberOfMessages"] = n
+	}
+	if ts, err := strconv.ParseInt(attrs[string(sqstypes.QueueAttributeNameCreatedTimestamp)], 10, 64); err == nil {
+		props["CreatedTimestamp"] = time.Unix(ts, 0)
+	}
+	if policy := attrs[string(sqstypes.QueueAttributeNameRedrivePolicy)]; policy != "" {
+		var redrive struct {
+			DeadLetterTargetArn string `json:"deadLetterTargetArn"`
+		}
+		if json.Unmarshal([]byte(policy), &redrive) == nil && redrive.DeadLetterTargetArn != "" {
+			props["DeadLetterQueue"] = redrive.DeadLetterTargetArn
+		}
+	}
+	return id, props
+}
+
+// ScanTopics maps topics (AWS::SNS::Topic) with their subscriptions and
+// messages published over the metric window. Subscription endpoints are
+// kept in Subscriptions; LinkSubscriptions turns them into edges.
+func (s *MessagingScanner) ScanTopics(ctx context.Context) error {
+	paginator := sns.NewListTopicsPaginator(s.SNS, &sns.ListTopicsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list sns topics: %v", err)
+		}
+		for _, t := range page.Topics {
+			id := aws.ToString(t.TopicArn)
+			props := map[string]interface{}{}
+			if parsed, err := arn.Parse(id); err == nil {
+				props["TopicName"] = parsed.Resource
+				props["Region"] = parsed.Region
+				props["AccountId"] = parsed.AccountID
+			}
+
+			if out, err := s.SNS.GetTopicAttributes(ctx, &sns.GetTopicAttributesInput{TopicArn: t.TopicArn}); err == nil {
+				if n, err := strconv.Atoi(out.Attributes["SubscriptionsConfirmed"]); err == nil {
+					props["SubscriptionsConfirmed"] = n
+				}
+				if n, err := strconv.Atoi(out.Attributes["SubscriptionsPending"]); err == nil {
+					props["SubscriptionsPending"] = n
+				}
+			}
+			if endpoints, err := s.topicSubscriptions(ctx, id); err == nil {
+				props["Subscriptions"] = endpoints
+				props["SubscriptionCount"] = len(endpoints)
+			}
+
+			name, _ := props["TopicName"].(string)
+			s.enrichMetrics(ctx, "AWS/SNS", "TopicName", name, props, map[string]string{
+				"NumberOfMessagesPublished": "MessagesPublished",
+			})
+			s.Graph.AddNode(id, "AWS::SNS::Topic", props)
+		}
+	}
+	return nil
+}
+
+// topicSubscriptions returns the endpoint of every subscription on a topic.
+func (s *MessagingScanner) topicSubscriptions(ctx context.Context, topicARN string) ([]string, error) {
+	endpoints := []string{}
+	paginator := sns.NewListSubscriptionsByTopicPaginator(s.SNS, &sns.ListSubscriptionsByTopicInput{TopicArn: aws.String(topicARN)})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, sub := range page.Subscriptions {
+			endpoints = append(endpoints, aws.ToString(sub.Endpoint))
+		}
+	}
+	return endpoints, nil
+}
+
+// enrichMetrics sums each metric over the metric window into the mapped
+// property. Properties are left unset when CloudWatch cannot be read, so a
+// failed lookup never looks like an idle resource.
+func (s *MessagingScanner) enrichMetrics(ctx context.Context, namespace, dimension, value string, props map[string]interface{}, metrics map[string]string) {
+	if s.CWClient == nil || value == "" {
+		return
+	}
+	window := s.Window
+	if window <= 0 {
+		window = MessagingMetricWindow
+	}
+	var queries []cwtypes.MetricDataQuery
+	ids := make(map[string]string)
+	for metric, prop := range metrics {
+		qid := "m_" + strings.ToLower(metric)
+		ids[qid] = prop
+		queries = append(queries, cwtypes.MetricDataQuery{
+			Id: aws.String(qid),
+			MetricStat: &cwtypes.MetricStat{
+				Metric: &cwtypes.Metric{
+					Namespace:  aws.String(namespace),
+					MetricName: aws.String(metric),
+					Dimensions: []cwtypes.Dimension{{Name: aws.String(dimension), Value: aws.String(value)}},
+				},
+				Period: aws.Int32(MetricPeriod(window)),
+				Stat:   aws.String("Sum"),
+			},
+		})
+	}
+
+	endTime := time.Now()
+	startTime := endTime.Add(-window)
+	out, err := s.CWClient.GetMetricData(ctx, &cloudwatch.GetMetricDataInput{
+		MetricDataQueries: queries,
+		StartTime:         &startTime,
+		EndTime:           &endTime,
+	})
+	if err != nil {
+		return
+	}
+	for _, res := range out.MetricDataResults {
+		prop, ok := ids[aws.ToString(res.Id)]
+		if !ok {
+			continue
+		}
+		total := 0.0
+		for _, v := range res.Values {
+			total += v
+		}
+		props[prop] = total
+	}
+}
+
+// LinkSubscriptions adds a FlowsTo edge from each SNS topic to the queues and
+// Lambda functions in the graph that subscribe to it. Endpoints outside the
+// graph (other accounts, HTTP, email) get no edge. Call it after scanning.
+func LinkSubscriptions(g *graph.Graph) int {
+	type link struct{ topic, target string }
+	var links []link
+
+	// Scanners queue their writes; wait for them to land.
+	g.Flush()
+
+	g.Mu.RLock()
+	for _, topic := range g.Store.GetAllNodes() {
+		if topic.TypeStr() != "AWS::SNS::Topic" {
+			continue
+		}
+		endpoints, _ := topic.Properties["Subscriptions"].([]string)
+		for _, endpoint := range endpoints {
+			if target := subscriptionTarget(g, endpoint); target != "" {
+				links = append(links, link{topic.IDStr(), target})
+			}
+		}
+	}
+	g.Mu.RUnlock()
+
+	for _, l := range links {
+		g.AddTypedEdge(l.topic, l.target, graph.EdgeTypeFlowsTo, 100)
+	}
+	return len(links)
+}
+
+// subscriptionTarget returns the ID of the graph node an endpoint delivers to.
+// Lambda functions are keyed by name; a qualified ARN (alias or version) resolves
+// to its function. Requires g.Mu held.
+func subscriptionTarget(g *graph.Graph, endpoint string) string {
+	parsed, err := arn.Parse(endpoint)
+	if err != nil {
+		return ""
+	}
+	switch parsed.Service {
+	case "sqs":
+		if _, ok := g.Store.GetNodeID(endpoint); ok {
+			return endpoint
+		}
+	case "lambda":
+		parts := strings.Split(parsed.Resource, ":") // function:NAME[:QUALIFIER]
+		if len(parts) >= 2 {
+			if _, ok := g.Store.GetNodeID(parts[1]); ok {
+				return parts[1]
+			}
+		}
+	}
+	return ""
+}
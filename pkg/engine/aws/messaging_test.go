@@ -0,0 +1,97 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+)
+
+type fakeSQSAPI struct{}
+
+func (fakeSQSAPI) ListQueues(ctx context.Context, in *sqs.ListQueuesInput, optFns ...func(*sqs.Options)) (*sqs.ListQueuesOutput, error) {
+	return &sqs.ListQueuesOutput{QueueUrls: []string{
+		"https://sqs.us-east-1.amazonaws.com/123/jobs",
+		"https://sqs.us-east-1.amazonaws.com/123/gone",
+	}}, nil
+}
+
+func (fakeSQSAPI) GetQueueAttributes(ctx context.Context, in *sqs.GetQueueAttributesInput, optFns ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error) {
+	if aws.ToString(in.QueueUrl) != "https://sqs.us-east-1.amazonaws.com/123/jobs" {
+		return nil, fmt.Errorf("AWS.SimpleQueueService.NonExistentQueue")
+	}
+	return &sqs.GetQueueAttributesOutput{Attributes: map[string]string{
+		"QueueArn":                    "arn:aws:sqs:us-east-1:123:jobs",
+		"ApproximateNumberOfMessages": "7",
+		"CreatedTimestamp":            "1700000000",
+		"RedrivePolicy":               `{"deadLetterTargetArn":"arn:aws:sqs:us-east-1:123:jobs-dlq","maxReceiveCount":5}`,
+	}}, nil
+}
+
+type fakeSNSAPI struct{}
+
+func (fakeSNSAPI) ListTopics(ctx context.Context, in *sns.ListTopicsInput, optFns ...func(*sns.Options)) (*sns.ListTopicsOutput, error) {
+	return &sns.ListTopicsOutput{Topics: []snstypes.Topic{{TopicArn: aws.String("arn:aws:sns:us-east-1:123:events")}}}, nil
+}
+
+func (fakeSNSAPI) GetTopicAttributes(ctx context.Context, in *sns.GetTopicAttributesInput, optFns ...func(*sns.Options)) (*sns.GetTopicAttributesOutput, error) {
+	return &sns.GetTopicAttributesOutput{Attributes: map[string]string{"SubscriptionsConfirmed": "3"}}, nil
+}
+
+func (fakeSNSAPI) ListSubscriptionsByTopic(ctx context.Context, in *sns.ListSubscriptionsByTopicInput, optFns ...func(*sns.Options)) (*sns.ListSubscriptionsByTopicOutput, error) {
+	return &sns.ListSubscriptionsByTopicOutput{Subscriptions: []snstypes.Subscription{
+		{Protocol: aws.String("sqs"), Endpoint: aws.String("arn:aws:sqs:us-east-1:123:jobs")},
+		{Protocol: aws.String("lambda"), Endpoint: aws.String("arn:aws:lambda:us-east-1:123:function:notify:live")},
+		{Protocol: aws.String("email"), Endpoint: aws.String("ops@example.com")},
+	}}, nil
+}
+
+func TestMessagingScanner(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("notify", "aws_lambda_function", map[string]interface{}{})
+	s := &MessagingScanner{SQS: fakeSQSAPI{}, SNS: fakeSNSAPI{}, CWClient: fakeMetricData{}, Graph: g}
+	if err := s.ScanQueues(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ScanTopics(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if n := LinkSubscriptions(g); n != 2 {
+		t.Errorf("Expected edges to the queue and the function, got %d", n)
+	}
+	g.CloseAndWait()
+
+	queue := g.GetNode("arn:aws:sqs:us-east-1:123:jobs")
+	if queue == nil {
+		t.Fatal("Expected queue node keyed by ARN")
+	}
+	if queue.Properties["QueueName"] != "jobs" || queue.Properties["ApproximateNumberOfMessages"] != 7 {
+		t.Errorf("Unexpected queue props %v", queue.Properties)
+	}
+	if queue.Properties["DeadLetterQueue"] != "arn:aws:sqs:us-east-1:123:jobs-dlq" {
+		t.Errorf("Expected dead-letter target, got %v", queue.Properties["DeadLetterQueue"])
+	}
+	if sent, ok := queue.Properties["MessagesSent"].(float64); !ok || sent != 0 {
+		t.Errorf("Expected MessagesSent from CloudWatch, got %v", queue.Properties["MessagesSent"])
+	}
+	if g.GetNode("arn:aws:sqs:us-east-1:123:gone") != nil {
+		t.Error("Expected unreadable queue to be skipped")
+	}
+
+	topic := g.GetNode("arn:aws:sns:us-east-1:123:events")
+	if topic.Properties["SubscriptionCount"] != 3 || topic.Properties["SubscriptionsConfirmed"] != 3 {
+		t.Errorf("Unexpected topic props %v", topic.Properties)
+	}
+	down := g.GetDownstream("arn:aws:sns:us-east-1:123:events")
+	if len(down) != 2 {
+		t.Errorf("Expected FlowsTo edges to queue and lambda, got %v", down)
+	}
+	if g.GetNode("ops@example.com") != nil {
+		t.Error("Expected no node for an email endpoint")
+	}
+}
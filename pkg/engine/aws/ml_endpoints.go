@@ -0,0 +1,361 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/comprehend"
+	comprehendtypes "github.com/aws/aws-sdk-go-v2/service/comprehend/types"
+	"github.com/aws/aws-sdk-go-v2/service/rekognition"
+	rekognitiontypes "github.com/aws/aws-sdk-go-v2/service/rekognition/types"
+	"github.com/aws/aws-sdk-go-v2/service/sagemaker"
+	sagemakertypes "github.com/aws/aws-sdk-go-v2/service/sagemaker/types"
+)
+
+// MLEndpoint is a provisioned inference endpoint that bills per hour while it exists.
+type MLEndpoint struct {
+	ARN          string
+	ResourceType string
+	Service      string
+	Name         string
+	Status       string
+	Units        int    // Instances or inference units provisioned.
+	UnitType     string // e.g. "ml.m5.large" or "inference unit".
+	HourlyCost   float64
+	CreatedAt    time.Time
+
+	// Usage metric. The endpoint is idle when the metric sums to zero across every dimension set.
+	MetricNamespace  string
+	MetricName       string
+	MetricDimensions [][]cwtypes.Dimension
+}
+
+// MLEndpointProvider lists the provisioned endpoints of one ML service.
+type MLEndpointProvider interface {
+	Service() string
+	ListEndpoints(ctx context.Context) ([]MLEndpoint, error)
+}
+
+// mlProviders builds the provider for each supported service.
+// Supporting a new service means adding its provider here.
+var mlProviders = []func(cfg aws.Config) MLEndpointProvider{
+	func(cfg aws.Config) MLEndpointProvider { return &SageMakerEndpoints{Client: sagemaker.NewFromConfig(cfg)} },
+	func(cfg aws.Config) MLEndpointProvider { return &ComprehendEndpoints{Client: comprehend.NewFromConfig(cfg)} },
+	func(cfg aws.Config) MLEndpointProvider { return &RekognitionEndpoints{Client: rekognition.NewFromConfig(cfg)} },
+}
+
+// MLEndpointScanner maps provisioned ML endpoints across services.
+type MLEndpointScanner struct {
+	Providers []MLEndpointProvider
+	Graph     *graph.Graph
+}
+
+// NewMLEndpointScanner initializes a scanner with every supported ML service.
+func NewMLEndpointScanner(cfg aws.Config, g *graph.Graph) *MLEndpointScanner {
+	s := &MLEndpointScanner{Graph: g}
+	for _, newProvider := range mlProviders {
+		s.Providers = append(s.Providers, newProvider(cfg))
+	}
+	return s
+}
+
+// ScanEndpoints adds one node per provisioned endpoint.
+// A failing service (not offered in the region, access denied) does not stop the others.
+func (s *MLEndpointScanner) ScanEndpoints(ctx context.Context) error {
+	var errs []error
+	for _, p := range s.Providers {
+		endpoints, err := p.ListEndpoints(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list %s endpoints: %v", p.Service(), err))
+			continue
+		}
+		for _, ep := range endpoints {
+			s.Graph.AddNode(ep.ARN, ep.ResourceType, mlEndpointProps(ep))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func mlEndpointProps(ep MLEndpoint) map[string]interface{} {
+	props := map[string]interface{}{
+		"MLEndpoint":       true,
+		"Service":          ep.Service,
+		"Name":             ep.Name,
+		"Status":           ep.Status,
+		"Units":            ep.Units,
+		"UnitType":         ep.UnitType,
+		"HourlyCost":       ep.HourlyCost,
+		"MetricNamespace":  ep.MetricNamespace,
+		"MetricName":       ep.MetricName,
+		"MetricDimensions": EncodeMetricDimensions(ep.MetricDimensions),
+	}
+	if !ep.CreatedAt.IsZero() {
+		props["CreationTime"] = ep.CreatedAt
+	}
+	if parsed, err := arn.Parse(ep.ARN); err == nil {
+		props["Region"] = parsed.Region
+	}
+	return props
+}
+
+// EncodeMetricDimensions flattens dimension sets to "Name=Value,Name=Value" strings
+// so they survive graph snapshots.
+func EncodeMetricDimensions(sets [][]cwtypes.Dimension) []string {
+	out := make([]string, 0, len(sets))
+	for _, dims := range sets {
+		parts := make([]string, 0, len(dims))
+		for _, d := range dims {
+			parts = append(parts, aws.ToString(d.Name)+"="+aws.ToString(d.Value))
+		}
+		out = append(out, strings.Join(parts, ","))
+	}
+	return out
+}
+
+// DecodeMetricDimensions reverses EncodeMetricDimensions for one set.
+func DecodeMetricDimensions(s string) []cwtypes.Dimension {
+	var dims []cwtypes.Dimension
+	for _, part := range strings.Split(s, ",") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		dims = append(dims, cwtypes.Dimension{Name: aws.String(name), Value: aws.String(value)})
+	}
+	return dims
+}
+
+func metricDims(kv ...string) []cwtypes.Dimension {
+	var out []cwtypes.Dimension
+	for i := 0; i+1 < len(kv); i += 2 {
+		out = append(out, cwtypes.Dimension{Name: aws.String(kv[i]), Value: aws.String(kv[i+1])})
+	}
+	return out
+}
+
+// --- SageMaker ---
+
+// sageMakerHourly is real-time inference on-demand pricing (us-east-1) for common instance types.
+var sageMakerHourly = map[string]float64{
+	"ml.t2.medium":    0.056,
+	"ml.t2.large":     0.111,
+	"ml.m5.large":     0.115,
+	"ml.m5.xlarge":    0.23,
+	"ml.m5.2xlarge":   0.461,
+	"ml.c5.large":     0.102,
+	"ml.c5.xlarge":    0.204,
+	"ml.c5.2xlarge":   0.408,
+	"ml.r5.large":     0.151,
+	"ml.inf1.xlarge":  0.297,
+	"ml.inf2.xlarge":  0.99,
+	"ml.g4dn.xlarge":  0.736,
+	"ml.g4dn.2xlarge": 1.052,
+	"ml.g5.xlarge":    1.408,
+	"ml.g5.2xlarge":   1.515,
+	"ml.p3.2xlarge":   3.825,
+}
+
+// sageMakerDefaultHourly is used for instance types missing from the table (ml.m5.xlarge).
+const sageMakerDefaultHourly = 0.23
+
+type sageMakerEndpointsAPI interface {
+	sagemaker.ListEndpointsAPIClient
+	DescribeEndpoint(ctx context.Context, params *sagemaker.DescribeEndpointInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeEndpointOutput, error)
+	DescribeEndpointConfig(ctx context.Context, params *sagemaker.DescribeEndpointConfigInput, optFns ...func(*sagemaker.Options)) (*sagemaker.DescribeEndpointConfigOutput, error)
+}
+
+// SageMakerEndpoints lists instance-backed SageMaker real-time endpoints.
+// Serverless variants bill per request and are skipped.
+type SageMakerEndpoints struct {
+	Client sageMakerEndpointsAPI
+}
+
+func (p *SageMakerEndpoints) Service() string { return "SageMaker" }
+
+func (p *SageMakerEndpoints) ListEndpoints(ctx context.Context) ([]MLEndpoint, error) {
+	var out []MLEndpoint
+	paginator := sagemaker.NewListEndpointsPaginator(p.Client, &sagemaker.ListEndpointsInput{
+		StatusEquals: sagemakertypes.EndpointStatusInService,
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, summary := range page.Endpoints {
+			desc, err := p.Client.DescribeEndpoint(ctx, &sagemaker.DescribeEndpointInput{EndpointName: summary.EndpointName})
+			if err != nil {
+				continue
+			}
+			instanceTypes := make(map[string]string)
+			if cfg, err := p.Client.DescribeEndpointConfig(ctx, &sagemaker.DescribeEndpointConfigInput{EndpointConfigName: desc.EndpointConfigName}); err == nil {
+				for _, v := range cfg.ProductionVariants {
+					instanceTypes[aws.ToString(v.VariantName)] = string(v.InstanceType)
+				}
+			}
+
+			name := aws.ToString(summary.EndpointName)
+			ep := MLEndpoint{
+				ARN:             aws.ToString(summary.EndpointArn),
+				ResourceType:    "AWS::SageMaker::Endpoint",
+				Service:         p.Service(),
+				Name:            name,
+				Status:          string(summary.EndpointStatus),
+				CreatedAt:       aws.ToTime(summary.CreationTime),
+				MetricNamespace: "AWS/SageMaker",
+				MetricName:      "Invocations",
+			}
+			var variantTypes []string
+			for _, v := range desc.ProductionVariants {
+				if v.CurrentServerlessConfig != nil {
+					continue
+				}
+				count := int(aws.ToInt32(v.CurrentInstanceCount))
+				instanceType := instanceTypes[aws.ToString(v.VariantName)]
+				hourly, ok := sageMakerHourly[instanceType]
+				if !ok {
+					hourly = sageMakerDefaultHourly
+				}
+				ep.Units += count
+				ep.HourlyCost += hourly * float64(count)
+				variantTypes = append(variantTypes, instanceType)
+				ep.MetricDimensions = append(ep.MetricDimensions, metricDims("EndpointName", name, "VariantName", aws.ToString(v.VariantName)))
+			}
+			if ep.Units == 0 {
+				continue
+			}
+			ep.UnitType = strings.Join(variantTypes, "+")
+			out = append(out, ep)
+		}
+	}
+	return out, nil
+}
+
+// --- Comprehend ---
+
+// comprehendHourlyPerIU is $0.0005 per inference unit per second.
+const comprehendHourlyPerIU = 1.80
+
+// ComprehendEndpoints lists Comprehend custom classification and entity endpoints.
+type ComprehendEndpoints struct {
+	Client comprehend.ListEndpointsAPIClient
+}
+
+func (p *ComprehendEndpoints) Service() string { return "Comprehend" }
+
+func (p *ComprehendEndpoints) ListEndpoints(ctx context.Context) ([]MLEndpoint, error) {
+	var out []MLEndpoint
+	paginator := comprehend.NewListEndpointsPaginator(p.Client, &comprehend.ListEndpointsInput{
+		Filter: &comprehendtypes.EndpointFilter{Status: comprehendtypes.EndpointStatusInService},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range page.EndpointPropertiesList {
+			units := int(aws.ToInt32(e.CurrentInferenceUnits))
+			if units == 0 {
+				continue
+			}
+			endpointARN := aws.ToString(e.EndpointArn)
+			out = append(out, MLEndpoint{
+				ARN:              endpointARN,
+				ResourceType:     "AWS::Comprehend::Endpoint",
+				Service:          p.Service(),
+				Name:             endpointARN[strings.LastIndex(endpointARN, "/")+1:],
+				Status:           string(e.Status),
+				Units:            units,
+				UnitType:         "inference unit",
+				HourlyCost:       comprehendHourlyPerIU * float64(units),
+				CreatedAt:        aws.ToTime(e.CreationTime),
+				MetricNamespace:  "AWS/Comprehend",
+				MetricName:       "SuccessfulRequestCount",
+				MetricDimensions: [][]cwtypes.Dimension{metricDims("EndpointArn", endpointARN)},
+			})
+		}
+	}
+	return out, nil
+}
+
+// --- Rekognition ---
+
+// rekognitionHourlyPerIU is Custom Labels inference pricing per running inference unit.
+const rekognitionHourlyPerIU = 4.00
+
+type rekognitionEndpointsAPI interface {
+	rekognition.DescribeProjectsAPIClient
+	rekognition.DescribeProjectVersionsAPIClient
+}
+
+// RekognitionEndpoints lists running Rekognition Custom Labels model versions.
+type RekognitionEndpoints struct {
+	Client rekognitionEndpointsAPI
+}
+
+func (p *RekognitionEndpoints) Service() string { return "Rekognition" }
+
+func (p *RekognitionEndpoints) ListEndpoints(ctx context.Context) ([]MLEndpoint, error) {
+	var out []MLEndpoint
+	projects := rekognition.NewDescribeProjectsPaginator(p.Client, &rekognition.DescribeProjectsInput{})
+	for projects.HasMorePages() {
+		page, err := projects.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, project := range page.ProjectDescriptions {
+			versions := rekognition.NewDescribeProjectVersionsPaginator(p.Client, &rekognition.DescribeProjectVersionsInput{
+				ProjectArn: project.ProjectArn,
+			})
+			for versions.HasMorePages() {
+				vpage, err := versions.NextPage(ctx)
+				if err != nil {
+					break
+				}
+				for _, v := range vpage.ProjectVersionDescriptions {
+					if v.Status != rekognitiontypes.ProjectVersionStatusRunning {
+						continue
+					}
+					projectName, versionName := rekognitionVersionNames(aws.ToString(v.ProjectVersionArn))
+					units := int(aws.ToInt32(v.MinInferenceUnits))
+					if units == 0 {
+						units = 1
+					}
+					out = append(out, MLEndpoint{
+						ARN:              aws.ToString(v.ProjectVersionArn),
+						ResourceType:     "AWS::Rekognition::ProjectVersion",
+						Service:          p.Service(),
+						Name:             projectName + "/" + versionName,
+						Status:           string(v.Status),
+						Units:            units,
+						UnitType:         "inference unit",
+						HourlyCost:       rekognitionHourlyPerIU * float64(units),
+						CreatedAt:        aws.ToTime(v.CreationTimestamp),
+						MetricNamespace:  "AWS/Rekognition",
+						MetricName:       "SuccessfulRequestCount",
+						MetricDimensions: [][]cwtypes.Dimension{metricDims("ProjectName", projectName, "VersionName", versionName)},
+					})
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
+// rekognitionVersionNames splits
+// arn:aws:rekognition:region:account:project/<project>/version/<version>/<timestamp>.
+func rekognitionVersionNames(versionARN string) (project, version string) {
+	_, resource, _ := strings.Cut(versionARN, ":project/")
+	parts := strings.Split(resource, "/")
+	if len(parts) >= 3 && parts[1] == "version" {
+		return parts[0], parts[2]
+	}
+	return resource, ""
+}
@@ -0,0 +1,102 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/comprehend"
+	comprehendtypes "github.com/aws/aws-sdk-go-v2/service/comprehend/types"
+	"github.com/aws/aws-sdk-go-v2/service/rekognition"
+	rekognitiontypes "github.com/aws/aws-sdk-go-v2/service/rekognition/types"
+)
+
+type fakeComprehendAPI struct{}
+
+func (fakeComprehendAPI) ListEndpoints(ctx context.Context, in *comprehend.ListEndpointsInput, optFns ...func(*comprehend.Options)) (*comprehend.ListEndpointsOutput, error) {
+	return &comprehend.ListEndpointsOutput{EndpointPropertiesList: []comprehendtypes.EndpointProperties{
+		{
+			EndpointArn:           aws.String("arn:aws:comprehend:us-east-1:123:document-classifier-endpoint/support-router"),
+			Status:                comprehendtypes.EndpointStatusInService,
+			CurrentInferenceUnits: aws.Int32(2),
+			CreationTime:          aws.Time(time.Now().Add(-30 * 24 * time.Hour)),
+		},
+	}}, nil
+}
+
+type fakeRekognitionAPI struct{}
+
+func (fakeRekognitionAPI) DescribeProjects(ctx context.Context, in *rekognition.DescribeProjectsInput, optFns ...func(*rekognition.Options)) (*rekognition.DescribeProjectsOutput, error) {
+	return &rekognition.DescribeProjectsOutput{ProjectDescriptions: []rekognitiontypes.ProjectDescription{
+		{ProjectArn: aws.String("arn:aws:rekognition:us-east-1:123:project/defects/1600000000000")},
+	}}, nil
+}
+
+func (fakeRekognitionAPI) DescribeProjectVersions(ctx context.Context, in *rekognition.DescribeProjectVersionsInput, optFns ...func(*rekognition.Options)) (*rekognition.DescribeProjectVersionsOutput, error) {
+	return &rekognition.DescribeProjectVersionsOutput{ProjectVersionDescriptions: []rekognitiontypes.ProjectVersionDescription{
+		{
+			ProjectVersionArn: aws.String("arn:aws:rekognition:us-east-1:123:project/defects/version/v3/1600000000001"),
+			Status:            rekognitiontypes.ProjectVersionStatusRunning,
+			MinInferenceUnits: aws.Int32(1),
+		},
+		{
+			ProjectVersionArn: aws.String("arn:aws:rekognition:us-east-1:123:project/defects/version/v2/1600000000002"),
+			Status:            rekognitiontypes.ProjectVersionStatusStopped,
+		},
+	}}, nil
+}
+
+type failingProvider struct{}
+
+func (failingProvider) Service() string { return "Broken" }
+func (failingProvider) ListEndpoints(ctx context.Context) ([]MLEndpoint, error) {
+	return nil, fmt.Errorf("AccessDeniedException")
+}
+
+func TestMLEndpointScanner(t *testing.T) {
+	g := graph.NewGraph()
+	s := &MLEndpointScanner{
+		Graph: g,
+		Providers: []MLEndpointProvider{
+			failingProvider{},
+			&ComprehendEndpoints{Client: fakeComprehendAPI{}},
+			&RekognitionEndpoints{Client: fakeRekognitionAPI{}},
+		},
+	}
+
+	if err := s.ScanEndpoints(context.Background()); err == nil {
+		t.Error("Expected the failing provider's error to be reported")
+	}
+	g.CloseAndWait()
+
+	comp := g.GetNode("arn:aws:comprehend:us-east-1:123:document-classifier-endpoint/support-router")
+	if comp == nil {
+		t.Fatal("Comprehend endpoint not added despite another provider failing")
+	}
+	if cost := comp.Properties["HourlyCost"].(float64); cost != 3.60 {
+		t.Errorf("Expected 2 IU at $1.80/hr = $3.60/hr, got %.2f", cost)
+	}
+	if comp.Properties["Region"] != "us-east-1" {
+		t.Errorf("Expected region from ARN, got %v", comp.Properties["Region"])
+	}
+
+	rek := g.GetNode("arn:aws:rekognition:us-east-1:123:project/defects/version/v3/1600000000001")
+	if rek == nil {
+		t.Fatal("Running Rekognition model not added")
+	}
+	dims, _ := rek.Properties["MetricDimensions"].([]string)
+	if len(dims) != 1 || dims[0] != "ProjectName=defects,VersionName=v3" {
+		t.Errorf("Unexpected metric dimensions: %v", dims)
+	}
+	if g.GetNode("arn:aws:rekognition:us-east-1:123:project/defects/version/v2/1600000000002") != nil {
+		t.Error("Stopped model versions do not bill and should be skipped")
+	}
+
+	decoded := DecodeMetricDimensions(dims[0])
+	if len(decoded) != 2 || aws.ToString(decoded[1].Name) != "VersionName" || aws.ToString(decoded[1].Value) != "v3" {
+		t.Errorf("Dimension round trip failed: %+v", decoded)
+	}
+}
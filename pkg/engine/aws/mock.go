@@ -30,8 +30,10 @@ func (s *MockScanner) Scan(ctx context.Context) error {
 
 	// Create an unattached EBS volume.
 	s.Graph.AddNode("arn:aws:ec2:us-east-1:123456789012:volume/vol-0mock1234567890", "AWS::EC2::Volume", map[string]interface{}{
-		"State": "available",
-		"Size":  100, // GB
+		"State":      "available",
+		"Size":       100, // GB
+		"TF_ADDRESS": "aws_ebs_volume.scratch",
+		"Encrypted":  false,
 	})
 	nodeMockVol := s.Graph.GetNode("arn:aws:ec2:us-east-1:123456789012:volume/vol-0mock1234567890")
 	if nodeMockVol != nil {
@@ -41,6 +43,17 @@ func (s *MockScanner) Scan(ctx context.Context) error {
 		s.Graph.Mu.Unlock()
 	}
 
+	// Create an unattached volume owned by a CloudFormation stack.
+	s.Graph.AddNode("arn:aws:ec2:us-east-1:123456789012:volume/vol-0mockStackData", "AWS::EC2::Volume", map[string]interface{}{
+		"State": "available",
+		"Size":  50, // GB
+		"Tags": map[string]string{
+			"aws:cloudformation:stack-name": "billing-api",
+			"aws:cloudformation:stack-id":   "arn:aws:cloudformation:us-east-1:123456789012:stack/billing-api/4f1c2a70-1d2e-11ef-9e3a-0a1b2c3d4e5f",
+			"aws:cloudformation:logical-id": "ReportCacheVolume",
+		},
+	})
+
 	// Create an unused volume that is technically "in-use" but by a zombie resource.
 	s.Graph.AddNode("arn:aws:ec2:us-east-1:123456789012:volume/vol-0mockPseudoUse", "AWS::EC2::Volume", map[string]interface{}{
 		"State":               "in-use",
@@ -65,6 +78,26 @@ func (s *MockScanner) Scan(ctx context.Context) error {
 		"VolumeSize": 100,
 	})
 
+	// Create an old snapshot whose source volume was deleted long ago.
+	s.Graph.AddNode("arn:aws:ec2:us-east-1:123456789012:snapshot/snap-0mockOrphan", "AWS::EC2::Snapshot", map[string]interface{}{
+		"State":      "completed",
+		"VolumeId":   "vol-0mockDeleted",
+		"VolumeSize": 50,
+		"CreateTime": time.Now().Add(-200 * 24 * time.Hour), // 200 days old
+		"Region":     "us-east-1",
+	})
+
+	// Create a snapshot made public by mistake while sharing it with a vendor.
+	s.Graph.AddNode("arn:aws:ec2:us-east-1:123456789012:snapshot/snap-0mockPublic", "AWS::EC2::Snapshot", map[string]interface{}{
+		"State":                   "completed",
+		"VolumeId":                "vol-0mock1234567890",
+		"VolumeSize":              20,
+		"CreateVolumePermissions": []string{"all", "999988887777"},
+		"Public":                  true,
+		"CreateTime":              time.Now().Add(-30 * 24 * time.Hour),
+		"Region":                  "us-east-1",
+	})
+
 	// Create a properly configured Elastic IP (Safe).
 	eipArn := "arn:aws:ec2:us-east-1:123456789012:eip/eipalloc-0mock123"
 	s.Graph.AddNode(eipArn, "aws_eip", map[string]interface{}{
@@ -80,14 +113,41 @@ func (s *MockScanner) Scan(ctx context.Context) error {
 		"PublicIp":      "203.0.113.99",
 		"Region":        "us-east-1",
 		"AssociationId": "",
-		"FoundInDNS":    true,
-		"DNSZone":       "production.com",
 	})
 
+	// DNS for production.com: one record still points at the unused EIP above,
+	// another aliases a load balancer that was deleted.
+	zoneArn := Route53ZoneARN("Z0MOCKPROD")
+	s.Graph.AddNode(zoneArn, "AWS::Route53::HostedZone", map[string]interface{}{
+		"Name":   "production.com.",
+		"ZoneId": "Z0MOCKPROD",
+		"Region": "global",
+	})
+	for _, rec := range []struct {
+		name, recordType string
+		props            map[string]interface{}
+	}{
+		{"legacy-api.production.com.", "A", map[string]interface{}{"Values": []string{"203.0.113.99"}}},
+		{"shop.production.com.", "A", map[string]interface{}{
+			"AliasTarget":    "dualstack.k8s-shop-1234567890.us-east-1.elb.amazonaws.com.",
+			"DanglingTarget": "k8s-shop-1234567890.us-east-1.elb.amazonaws.com",
+		}},
+	} {
+		id := zoneArn + "/recordset/" + rec.recordType + "/" + rec.name
+		rec.props["Name"] = rec.name
+		rec.props["Type"] = rec.recordType
+		rec.props["Zone"] = "production.com."
+		rec.props["ZoneId"] = "Z0MOCKPROD"
+		rec.props["Region"] = "global"
+		s.Graph.AddNode(id, "AWS::Route53::RecordSet", rec.props)
+		s.Graph.AddTypedEdge(zoneArn, id, graph.EdgeTypeContains, 1)
+	}
+
 	// Create an S3 bucket with incomplete multipart uploads.
 	s.Graph.AddNode("arn:aws:s3:::mock-bucket-iceberg", "AWS::S3::Bucket", map[string]interface{}{
 		"Name":              "mock-bucket-iceberg",
 		"HasAbortLifecycle": false,
+		"DefaultEncryption": "", // No default encryption (compliance).
 	})
 	s.Graph.AddNode("arn:aws:s3:::multipart/mock-bucket-iceberg/upload-1", "AWS::S3::MultipartUpload", map[string]interface{}{
 		"Initiated": time.Now().Add(-15 * 24 * time.Hour), // 15 days old
@@ -100,6 +160,7 @@ func (s *MockScanner) Scan(ctx context.Context) error {
 		"Size":        int32(500), // 500GB
 		"VolumeType":  "gp2",
 		"IsModifying": false,
+		"KmsKeyId":    "arn:aws:kms:us-east-1:123456789012:key/0b1c2d3e-0000-4000-8000-00000000d15c",
 		"Region":      "us-east-1",
 	})
 
@@ -185,9 +246,166 @@ func (s *MockScanner) Scan(ctx context.Context) error {
 		"DBInstanceIdentifier": "legacy-postgres",
 		"Status":               "stopped",
 		"Region":               "us-east-1",
+		"StorageEncrypted":     false,
 	})
 	// RDSHeuristic handles stopped instances without CloudWatch metrics.
 
+	// Create a CloudFront distribution whose S3 origin bucket was deleted.
+	// The bucket edge target is never scanned, so it stays an Unknown node.
+	cfARN := "arn:aws:cloudfront::123456789012:distribution/E2MOCKORPHAN"
+	s.Graph.AddNode(cfARN, "AWS::CloudFront::Distribution", map[string]interface{}{
+		"DistributionId":   "E2MOCKORPHAN",
+		"Name":             "d111111abcdef8.cloudfront.net",
+		"Enabled":          true,
+		"OriginDomains":    []string{"legacy-marketing-site.s3.us-east-1.amazonaws.com"},
+		"Region":           "global",
+		"MetricDimensions": []string{"DistributionId=E2MOCKORPHAN,Region=Global"},
+	})
+	s.Graph.AddTypedEdge(cfARN, S3BucketARN("legacy-marketing-site"), graph.EdgeTypeUses, 100)
+
+	// Create a web ACL whose load balancer was deleted long ago.
+	s.Graph.AddNode("arn:aws:wafv2:us-east-1:123456789012:regional/webacl/legacy-api-acl/a1b2c3d4", "AWS::WAFv2::WebACL", map[string]interface{}{
+		"Name":                   "legacy-api-acl",
+		"WebACLId":               "a1b2c3d4",
+		"Scope":                  "REGIONAL",
+		"RuleCount":              6,
+		"AssociatedResources":    0,
+		"AssociatedResourceArns": []string{},
+		"AssociationsComplete":   true,
+		"Region":                 "us-east-1",
+		"MetricDimensions":       []string{"WebACL=legacy-api-acl,Region=us-east-1,Rule=ALL"},
+	})
+
+	// Create a provisioned DynamoDB table nothing has touched since its feature shipped.
+	s.Graph.AddNode("arn:aws:dynamodb:us-east-1:123456789012:table/legacy-sessions", "aws_dynamodb_table", map[string]interface{}{
+		"Service":                "DynamoDB",
+		"TableName":              "legacy-sessions",
+		"TableStatus":            "ACTIVE",
+		"BillingMode":            "PROVISIONED",
+		"TableClass":             "STANDARD",
+		"ProvisionedRCU":         100.0,
+		"ProvisionedWCU":         50.0,
+		"GSIProvisionedRCU":      0.0,
+		"GSIProvisionedWCU":      0.0,
+		"GlobalSecondaryIndexes": []string{},
+		"HasAutoScaling":         false,
+		"ItemCount":              int64(0),
+		"TableSizeBytes":         int64(0),
+		"Replicas":               0,
+		"ConsumedReadUnits":      0.0,
+		"ConsumedWriteUnits":     0.0,
+		"CreationTime":           time.Now().Add(-400 * 24 * time.Hour),
+		"Region":                 "us-east-1",
+	})
+
+	// Create an EFS file system left behind by a decommissioned app.
+	s.Graph.AddNode("arn:aws:elasticfilesystem:us-east-1:123456789012:file-system/fs-0mockOrphan", "AWS::EFS::FileSystem", map[string]interface{}{
+		"FileSystemId":          "fs-0mockOrphan",
+		"Name":                  "legacy-uploads",
+		"State":                 "available",
+		"NumberOfMountTargets":  int32(0),
+		"ThroughputMode":        "provisioned",
+		"ProvisionedThroughput": 10.0,
+		"SizeBytes":             int64(50 << 30),
+		"SizeStandardBytes":     int64(50 << 30),
+		"CreationTime":          time.Now().Add(-200 * 24 * time.Hour),
+		"Region":                "us-east-1",
+	})
+
+	// Create an OpenSearch domain nobody has queried or written to in two weeks.
+	s.Graph.AddNode("arn:aws:es:us-east-1:123456789012:domain/legacy-logs", "AWS::OpenSearch::Domain", map[string]interface{}{
+		"DomainName":          "legacy-logs",
+		"EngineVersion":       "OpenSearch_2.11",
+		"InstanceType":        "r6g.large.search",
+		"InstanceCount":       3,
+		"EBSVolumeSize":       100,
+		"EBSVolumeType":       "gp3",
+		"SearchRate":          0.0,
+		"IndexingRate":        0.0,
+		"SearchableDocuments": 1250000.0,
+		"Region":              "us-east-1",
+	})
+
+	// Create a Redis cluster nothing has connected to in a week.
+	s.Graph.AddNode("arn:aws:elasticache:us-east-1:123456789012:cluster:session-cache-old", "aws_elasticache_cluster", map[string]interface{}{
+		"CacheClusterId":    "session-cache-old",
+		"Engine":            "redis",
+		"EngineVersion":     "7.1",
+		"Status":            "available",
+		"NodeType":          "cache.r6g.large",
+		"NumCacheNodes":     1,
+		"CreateTime":        time.Now().Add(-400 * 24 * time.Hour),
+		"PeakConnections":   2.0,
+		"CacheHits":         0.0,
+		"PeakCPU":           1.4,
+		"PeakMemoryPercent": 3.0,
+		"Region":            "us-east-1",
+	})
+
+	// Create a reserved Redis cluster sized well above its load.
+	s.Graph.AddNode("arn:aws:elasticache:us-east-1:123456789012:cluster:catalog-cache", "aws_elasticache_cluster", map[string]interface{}{
+		"CacheClusterId":    "catalog-cache",
+		"Engine":            "redis",
+		"EngineVersion":     "7.1",
+		"Status":            "available",
+		"NodeType":          "cache.r6g.xlarge",
+		"NumCacheNodes":     1,
+		"ReservedNodes":     1,
+		"ReservationExpiry": time.Now().Add(45 * 24 * time.Hour),
+		"CreateTime":        time.Now().Add(-300 * 24 * time.Hour),
+		"PeakConnections":   38.0,
+		"CacheHits":         1.2e6,
+		"PeakCPU":           3.2,
+		"PeakMemoryPercent": 17.0,
+		"Region":            "us-east-1",
+	})
+
+	// Create a Redshift cluster nobody has queried in a week.
+	s.Graph.AddNode("analytics-legacy", "aws_redshift_cluster", map[string]interface{}{
+		"Service":           "Redshift",
+		"ClusterIdentifier": "analytics-legacy",
+		"NodeType":          "dc2.large",
+		"NumberOfNodes":     2,
+		"ClusterStatus":     "available",
+		"CreateTime":        time.Now().Add(-500 * 24 * time.Hour),
+		"PeakConnections":   0.0,
+		"QueriesCompleted":  0.0,
+		"AvgCPU":            0.8,
+		"PeakDiskPercent":   12.0,
+		"Region":            "us-east-1",
+	})
+
+	// Create KMS keys: one encrypting the gp2 volume, one nothing has used in
+	// over 90 days, and one whose imported key material never arrived.
+	s.Graph.AddNode("arn:aws:kms:us-east-1:123456789012:key/0b1c2d3e-0000-4000-8000-00000000d15c", "AWS::KMS::Key", map[string]interface{}{
+		"KeyId":       "0b1c2d3e-0000-4000-8000-00000000d15c",
+		"KeyState":    "Enabled",
+		"KeyManager":  "CUSTOMER",
+		"Aliases":     []string{"alias/ebs-data"},
+		"CreateTime":  time.Now().Add(-700 * 24 * time.Hour),
+		"UsageTraced": true,
+		"Region":      "us-east-1",
+	})
+	s.Graph.AddNode("arn:aws:kms:us-east-1:123456789012:key/7f8e9d0c-0000-4000-8000-0000000001d1", "AWS::KMS::Key", map[string]interface{}{
+		"KeyId":           "7f8e9d0c-0000-4000-8000-0000000001d1",
+		"KeyState":        "Enabled",
+		"KeyManager":      "CUSTOMER",
+		"Aliases":         []string{"alias/legacy-app-secrets"},
+		"RotationEnabled": true,
+		"Rotations":       2,
+		"CreateTime":      time.Now().Add(-900 * 24 * time.Hour),
+		"UsageTraced":     true,
+		"Region":          "us-east-1",
+	})
+	s.Graph.AddNode("arn:aws:kms:us-east-1:123456789012:key/5a6b7c8d-0000-4000-8000-00000000e4e7", "AWS::KMS::Key", map[string]interface{}{
+		"KeyId":      "5a6b7c8d-0000-4000-8000-00000000e4e7",
+		"KeyState":   "PendingImport",
+		"KeyManager": "CUSTOMER",
+		"Origin":     "EXTERNAL",
+		"CreateTime": time.Now().Add(-200 * 24 * time.Hour),
+		"Region":     "us-east-1",
+	})
+
 	// Create an unused Application Load Balancer.
 	elbArn := "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/unused-internal-lb/50dc6c495c0c9999"
 	s.Graph.AddNode(elbArn, "AWS::ElasticLoadBalancingV2::LoadBalancer", map[string]interface{}{
@@ -359,3 +577,45 @@ func (s *MockScanner) Scan(ctx context.Context) error {
 
 	return nil
 }
+
+// ScanMessaging generates queues and topics for --include-messaging.
+func (s *MockScanner) ScanMessaging(ctx context.Context) error {
+	old := time.Now().Add(-90 * 24 * time.Hour)
+	queue := func(name string, sent, received float64, extra map[string]interface{}) string {
+		id := "arn:aws:sqs:us-east-1:123456789012:" + name
+		props := map[string]interface{}{
+			"QueueName":        name,
+			"Region":           "us-east-1",
+			"CreatedTimestamp": old,
+			"MessagesSent":     sent,
+			"MessagesReceived": received,
+		}
+		for k, v := range extra {
+			props[k] = v
+		}
+		s.Graph.AddNode(id, "AWS::SQS::Queue", props)
+		return id
+	}
+
+	// Active queue with an (empty, healthy) dead-letter queue.
+	dlq := queue("orders-dlq", 0, 0, nil)
+	orders := queue("orders", 5200, 5200, map[string]interface{}{"DeadLetterQueue": dlq})
+	// Abandoned queue.
+	queue("legacy-import-jobs", 0, 0, map[string]interface{}{"ApproximateNumberOfMessages": 0})
+
+	s.Graph.AddNode("arn:aws:sns:us-east-1:123456789012:order-events", "AWS::SNS::Topic", map[string]interface{}{
+		"TopicName":         "order-events",
+		"Region":            "us-east-1",
+		"Subscriptions":     []string{orders},
+		"SubscriptionCount": 1,
+		"MessagesPublished": 5200.0,
+	})
+	s.Graph.AddNode("arn:aws:sns:us-east-1:123456789012:deploy-notifications", "AWS::SNS::Topic", map[string]interface{}{
+		"TopicName":         "deploy-notifications",
+		"Region":            "us-east-1",
+		"Subscriptions":     []string{},
+		"SubscriptionCount": 0,
+		"MessagesPublished": 12.0,
+	})
+	return nil
+}
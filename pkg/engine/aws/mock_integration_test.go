@@ -57,6 +57,12 @@ func (m *mockEC2Client) DescribeImages(ctx context.Context, params *ec2.Describe
 func (m *mockEC2Client) DescribeVolumesModifications(ctx context.Context, params *ec2.DescribeVolumesModificationsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVolumesModificationsOutput, error) {
 	return &ec2.DescribeVolumesModificationsOutput{}, nil
 }
+func (m *mockEC2Client) DescribeSnapshotAttribute(ctx context.Context, params *ec2.DescribeSnapshotAttributeInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSnapshotAttributeOutput, error) {
+	return &ec2.DescribeSnapshotAttributeOutput{}, nil
+}
+func (m *mockEC2Client) DescribeImageAttribute(ctx context.Context, params *ec2.DescribeImageAttributeInput, optFns ...func(*ec2.Options)) (*ec2.DescribeImageAttributeOutput, error) {
+	return &ec2.DescribeImageAttributeOutput{}, nil
+}
 
 func TestEC2Scanner_ScanInstances_Mocked(t *testing.T) {
 	g := graph.NewGraph()
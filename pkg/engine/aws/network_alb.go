@@ -56,6 +56,9 @@ func (s *ALBScanner) ScanALBs(ctx context.Context) error {
 			}
 
 			s.Graph.AddNode(arn, "aws_alb", props)
+			if lb.VpcId != nil {
+				s.Graph.AddTypedEdge(vpcARN(*lb.VpcId), arn, graph.EdgeTypeContains, 100)
+			}
 
 			// Check traffic metrics.
 			go s.checkRequests(ctx, arn, props)
@@ -2,12 +2,14 @@ package aws
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/route53"
+	r53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
 )
 
 // EIPScanner scans Elastic IPs.
@@ -31,6 +33,12 @@ func (s *EIPScanner) ScanAddresses(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if len(out.Addresses) == 0 {
+		return nil
+	}
+
+	// One pass over Route53 serves every address.
+	dns, dnsErr := s.dnsRecordsByIP(ctx)
 
 	for _, addr := range out.Addresses {
 		ip := *addr.PublicIp
@@ -40,6 +48,7 @@ func (s *EIPScanner) ScanAddresses(ctx context.Context) error {
 			"Service":      "EIP",
 			"PublicIp":     ip,
 			"AllocationId": id,
+			"Tags":         parseTags(addr.Tags),
 		}
 
 		if addr.AssociationId != nil {
@@ -50,75 +59,74 @@ func (s *EIPScanner) ScanAddresses(ctx context.Context) error {
 			}
 		}
 
-		s.Graph.AddNode(id, "aws_eip", props)
+		if dnsErr != nil {
+			// Unknown is not the same as "not in DNS".
+			props["DNSCheckError"] = dnsErr.Error()
+		} else {
+			refs := dns[ip]
+			props["FoundInDNS"] = len(refs) > 0
+			if len(refs) > 0 {
+				props["DNSZone"] = refs[0].Zone
+				props["DNSRecord"] = refs[0].Name
+				names := make([]string, len(refs))
+				for i, r := range refs {
+					names[i] = r.Name
+				}
+				props["DNSRecords"] = names
+			}
+		}
 
-		// Check for DNS records pointing to this IP.
-		go s.checkDNS(ctx, id, ip)
+		s.Graph.AddNode(id, "aws_eip", props)
 	}
 	return nil
 }
 
-// checkDNS checks Route53 for IP records.
-func (s *EIPScanner) checkDNS(ctx context.Context, id, ip string) {
-	// List Hosted Zones.
-	zonesPaginator := route53.NewListHostedZonesPaginator(s.R53Client, &route53.ListHostedZonesInput{})
+// DNSRef is a Route53 record pointing at an IP.
+type DNSRef struct {
+	Zone string
+	Name string
+}
 
-	foundInDNS := false
-	badZone := ""
+// dnsRecordsByIP indexes A/AAAA record values across all hosted zones.
+func (s *EIPScanner) dnsRecordsByIP(ctx context.Context) (map[string][]DNSRef, error) {
+	index := make(map[string][]DNSRef)
 
+	zonesPaginator := route53.NewListHostedZonesPaginator(s.R53Client, &route53.ListHostedZonesInput{})
 	for zonesPaginator.HasMorePages() {
 		page, err := zonesPaginator.NextPage(ctx)
 		if err != nil {
-			break
+			return nil, fmt.Errorf("failed to list hosted zones: %v", err)
 		}
 
 		for _, zone := range page.HostedZones {
-			// Search zone.
-			// Scan records.
-
 			recPaginator := route53.NewListResourceRecordSetsPaginator(s.R53Client, &route53.ListResourceRecordSetsInput{
 				HostedZoneId: zone.Id,
 			})
-
 			for recPaginator.HasMorePages() {
 				recPage, err := recPaginator.NextPage(ctx)
 				if err != nil {
-					break
-				}
-
-				for _, rec := range recPage.ResourceRecordSets {
-					// Check records.
-					for _, rr := range rec.ResourceRecords {
-						if rr.Value != nil && strings.Contains(*rr.Value, ip) {
-							foundInDNS = true
-							badZone = *zone.Name
-							break
-						}
-					}
-					if foundInDNS {
-						break
-					}
-				}
-				if foundInDNS {
-					break
+					return nil, fmt.Errorf("failed to list records for zone %s: %v", aws.ToString(zone.Name), err)
 				}
+				indexDNSRecords(index, aws.ToString(zone.Name), recPage.ResourceRecordSets)
 			}
-			if foundInDNS {
-				break
-			}
-		}
-		if foundInDNS {
-			break
 		}
 	}
+	return index, nil
+}
 
-	node := s.Graph.GetNode(id)
-	if node != nil {
-		s.Graph.Mu.Lock()
-		node.Properties["FoundInDNS"] = foundInDNS
-		if foundInDNS {
-			node.Properties["DNSZone"] = badZone
+// indexDNSRecords adds a zone's address records to the index.
+// Values are matched exactly; a substring match would tie 1.2.3.4 to 11.2.3.45.
+func indexDNSRecords(index map[string][]DNSRef, zone string, records []r53types.ResourceRecordSet) {
+	for _, rec := range records {
+		if rec.Type != r53types.RRTypeA && rec.Type != r53types.RRTypeAaaa {
+			continue
+		}
+		for _, rr := range rec.ResourceRecords {
+			ip := strings.TrimSpace(aws.ToString(rr.Value))
+			if ip == "" {
+				continue
+			}
+			index[ip] = append(index[ip], DNSRef{Zone: zone, Name: aws.ToString(rec.Name)})
 		}
-		s.Graph.Mu.Unlock()
 	}
 }
@@ -0,0 +1,38 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	r53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+)
+
+func TestIndexDNSRecords(t *testing.T) {
+	index := make(map[string][]DNSRef)
+	indexDNSRecords(index, "example.com.", []r53types.ResourceRecordSet{
+		{
+			Name:            aws.String("api.example.com."),
+			Type:            r53types.RRTypeA,
+			ResourceRecords: []r53types.ResourceRecord{{Value: aws.String("203.0.113.10")}},
+		},
+		{
+			Name:            aws.String("old.example.com."),
+			Type:            r53types.RRTypeA,
+			ResourceRecords: []r53types.ResourceRecord{{Value: aws.String("203.0.113.100")}},
+		},
+		{
+			// Only address records count.
+			Name:            aws.String("example.com."),
+			Type:            r53types.RRTypeTxt,
+			ResourceRecords: []r53types.ResourceRecord{{Value: aws.String("\"v=spf1 ip4:203.0.113.10 -all\"")}},
+		},
+	})
+
+	refs := index["203.0.113.10"]
+	if len(refs) != 1 || refs[0].Name != "api.example.com." || refs[0].Zone != "example.com." {
+		t.Errorf("Expected exact A record match, got %+v", refs)
+	}
+	if _, ok := index["203.0.113.1"]; ok {
+		t.Error("Prefix of a recorded IP must not match")
+	}
+}
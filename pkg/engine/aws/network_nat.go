@@ -52,9 +52,12 @@ func (s *NATScanner) ScanNATGateways(ctx context.Context) error {
 				"SubnetId": *nat.SubnetId,
 				"State":    string(nat.State),
 				"PublicIp": extractPublicIp(nat.NatGatewayAddresses),
+				"Region":   s.Client.Options().Region,
+				"Tags":     parseTags(nat.Tags),
 			}
 
 			s.Graph.AddNode(id, "aws_nat_gateway", props)
+			s.Graph.AddTypedEdge(subnetARN(*nat.SubnetId), id, graph.EdgeTypeContains, 100)
 
 			// Check traffic volume.
 			go s.checkTraffic(ctx, id, props)
@@ -73,7 +76,7 @@ func extractPublicIp(addrs []types.NatGatewayAddress) string {
 	return ""
 }
 
-// checkTraffic queries connections (7 days).
+// checkTraffic queries connections and bytes sent (7 days).
 func (s *NATScanner) checkTraffic(ctx context.Context, id string, props map[string]interface{}) {
 	node := s.Graph.GetNode(id)
 	if node == nil {
@@ -96,6 +99,18 @@ func (s *NATScanner) checkTraffic(ctx context.Context, id string, props map[stri
 				Stat:   aws.String("Sum"),
 			},
 		},
+		{
+			Id: aws.String("m_bytes"),
+			MetricStat: &cwtypes.MetricStat{
+				Metric: &cwtypes.Metric{
+					Namespace:  aws.String("AWS/NATGateway"),
+					MetricName: aws.String("BytesOutToDestination"),
+					Dimensions: []cwtypes.Dimension{{Name: aws.String("NatGatewayId"), Value: aws.String(id)}},
+				},
+				Period: aws.Int32(86400),
+				Stat:   aws.String("Sum"),
+			},
+		},
 	}
 
 	out, err := s.CWClient.GetMetricData(ctx, &cloudwatch.GetMetricDataInput{
@@ -108,15 +123,20 @@ func (s *NATScanner) checkTraffic(ctx context.Context, id string, props map[stri
 		return
 	}
 
-	totalConns := 0.0
+	totalConns, totalBytes := 0.0, 0.0
 	for _, res := range out.MetricDataResults {
 		for _, v := range res.Values {
-			totalConns += v
+			if aws.ToString(res.Id) == "m_bytes" {
+				totalBytes += v
+			} else {
+				totalConns += v
+			}
 		}
 	}
 
 	s.Graph.Mu.Lock()
 	node.Properties["SumConnections7d"] = totalConns
+	node.Properties["SumBytesOut7d"] = totalBytes
 	s.Graph.Mu.Unlock()
 }
 
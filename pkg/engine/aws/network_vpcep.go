@@ -53,6 +53,7 @@ func (s *VpcEndpointScanner) ScanEndpoints(ctx context.Context) error {
 			}
 
 			s.Graph.AddNode(id, "aws_vpc_endpoint", props)
+			s.Graph.AddTypedEdge(vpcARN(*ep.VpcId), id, graph.EdgeTypeContains, 100)
 
 			go s.checkFlow(ctx, id, props)
 		}
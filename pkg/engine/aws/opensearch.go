@@ -0,0 +1,171 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/opensearch"
+	ostypes "github.com/aws/aws-sdk-go-v2/service/opensearch/types"
+)
+
+// OpenSearchMetricWindow is the default CloudWatch lookback for domain activity.
+const OpenSearchMetricWindow = 14 * 24 * time.Hour
+
+// openSearchDescribeBatch is the DescribeDomains limit on names per call.
+const openSearchDescribeBatch = 5
+
+type openSearchAPI interface {
+	ListDomainNames(ctx context.Context, params *opensearch.ListDomainNamesInput, optFns ...func(*opensearch.Options)) (*opensearch.ListDomainNamesOutput, error)
+	DescribeDomains(ctx context.Context, params *opensearch.DescribeDomainsInput, optFns ...func(*opensearch.Options)) (*opensearch.DescribeDomainsOutput, error)
+}
+
+type metricDataAPI interface {
+	GetMetricData(ctx context.Context, params *cloudwatch.GetMetricDataInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.GetMetricDataOutput, error)
+}
+
+// OpenSearchScanner scans OpenSearch Service domains.
+type OpenSearchScanner struct {
+	Client   openSearchAPI
+	CWClient metricDataAPI
+	Graph    *graph.Graph
+	Window   time.Duration // Metric lookback; zero means OpenSearchMetricWindow.
+}
+
+// NewOpenSearchScanner initializes a scanner for OpenSearch.
+func NewOpenSearchScanner(cfg aws.Config, g *graph.Graph) *OpenSearchScanner {
+	return &OpenSearchScanner{
+		Client:   opensearch.NewFromConfig(cfg),
+		CWClient: cloudwatch.NewFromConfig(cfg),
+		Graph:    g,
+	}
+}
+
+// ScanDomains maps domains with their cluster shape and recent search and indexing activity.
+func (s *OpenSearchScanner) ScanDomains(ctx context.Context) error {
+	list, err := s.Client.ListDomainNames(ctx, &opensearch.ListDomainNamesInput{})
+	if err != nil {
+		return fmt.Errorf("failed to list opensearch domains: %v", err)
+	}
+	var names []string
+	for _, d := range list.DomainNames {
+		names = append(names, aws.ToString(d.DomainName))
+	}
+
+	for start := 0; start < len(names); start += openSearchDescribeBatch {
+		end := min(start+openSearchDescribeBatch, len(names))
+		out, err := s.Client.DescribeDomains(ctx, &opensearch.DescribeDomainsInput{DomainNames: names[start:end]})
+		if err != nil {
+			return fmt.Errorf("failed to describe opensearch domains: %v", err)
+		}
+		for _, d := range out.DomainStatusList {
+			if aws.ToBool(d.Deleted) {
+				continue
+			}
+			id := aws.ToString(d.ARN)
+			props := openSearchDomainProps(d)
+			s.enrichDomainMetrics(ctx, id, props)
+			s.Graph.AddNode(id, "AWS::OpenSearch::Domain", props)
+		}
+	}
+	return nil
+}
+
+// openSearchDomainProps flattens the cluster and storage configuration of a domain.
+func openSearchDomainProps(d ostypes.DomainStatus) map[string]interface{} {
+	props := map[string]interface{}{
+		"DomainName":    aws.ToString(d.DomainName),
+		"EngineVersion": aws.ToString(d.EngineVersion),
+		"Processing":    aws.ToBool(d.Processing),
+	}
+	if c := d.ClusterConfig; c != nil {
+		props["InstanceType"] = string(c.InstanceType)
+		props["InstanceCount"] = int(aws.ToInt32(c.InstanceCount))
+		if aws.ToBool(c.DedicatedMasterEnabled) {
+			props["DedicatedMasterType"] = string(c.DedicatedMasterType)
+			props["DedicatedMasterCount"] = int(aws.ToInt32(c.DedicatedMasterCount))
+		}
+		if aws.ToBool(c.WarmEnabled) {
+			props["WarmType"] = string(c.WarmType)
+			props["WarmCount"] = int(aws.ToInt32(c.WarmCount))
+		}
+	}
+	if e := d.EBSOptions; e != nil && aws.ToBool(e.EBSEnabled) {
+		props["EBSVolumeSize"] = int(aws.ToInt32(e.VolumeSize))
+		props["EBSVolumeType"] = string(e.VolumeType)
+	}
+	if parsed, err := arn.Parse(aws.ToString(d.ARN)); err == nil {
+		props["Region"] = parsed.Region
+		props["AccountId"] = parsed.AccountID
+	}
+	return props
+}
+
+// enrichDomainMetrics adds SearchRate and IndexingRate (summed over the metric
+// window) and SearchableDocuments (peak). They are left unset when CloudWatch cannot be read,
+// so a failed lookup never looks like an idle domain.
+func (s *OpenSearchScanner) enrichDomainMetrics(ctx context.Context, id string, props map[string]interface{}) {
+	if s.CWClient == nil {
+		return
+	}
+	window := s.Window
+	if window <= 0 {
+		window = OpenSearchMetricWindow
+	}
+	domain, _ := props["DomainName"].(string)
+	account, _ := props["AccountId"].(string)
+	dims := []cwtypes.Dimension{
+		{Name: aws.String("DomainName"), Value: aws.String(domain)},
+		{Name: aws.String("ClientId"), Value: aws.String(account)},
+	}
+	query := func(qid, metric, stat string) cwtypes.MetricDataQuery {
+		return cwtypes.MetricDataQuery{
+			Id: aws.String(qid),
+			MetricStat: &cwtypes.MetricStat{
+				Metric: &cwtypes.Metric{
+					Namespace:  aws.String("AWS/ES"),
+					MetricName: aws.String(metric),
+					Dimensions: dims,
+				},
+				Period: aws.Int32(MetricPeriod(window)),
+				Stat:   aws.String(stat),
+			},
+		}
+	}
+
+	endTime := time.Now()
+	startTime := endTime.Add(-window)
+	out, err := s.CWClient.GetMetricData(ctx, &cloudwatch.GetMetricDataInput{
+		MetricDataQueries: []cwtypes.MetricDataQuery{
+			query("m_search", "SearchRate", "Sum"),
+			query("m_indexing", "IndexingRate", "Sum"),
+			query("m_docs", "SearchableDocuments", "Maximum"),
+		},
+		StartTime: &startTime,
+		EndTime:   &endTime,
+	})
+	if err != nil {
+		return
+	}
+
+	for _, res := range out.MetricDataResults {
+		total, peak := 0.0, 0.0
+		for _, v := range res.Values {
+			total += v
+			peak = max(peak, v)
+		}
+		switch aws.ToString(res.Id) {
+		case "m_search":
+			props["SearchRate"] = total
+		case "m_indexing":
+			props["IndexingRate"] = total
+		case "m_docs":
+			props["SearchableDocuments"] = peak
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/opensearch"
+	ostypes "github.com/aws/aws-sdk-go-v2/service/opensearch/types"
+)
+
+type fakeOpenSearchAPI struct {
+	names []string
+	calls int
+}
+
+func (f *fakeOpenSearchAPI) ListDomainNames(ctx context.Context, in *opensearch.ListDomainNamesInput, optFns ...func(*opensearch.Options)) (*opensearch.ListDomainNamesOutput, error) {
+	out := &opensearch.ListDomainNamesOutput{}
+	for _, n := range f.names {
+		out.DomainNames = append(out.DomainNames, ostypes.DomainInfo{DomainName: aws.String(n)})
+	}
+	return out, nil
+}
+
+func (f *fakeOpenSearchAPI) DescribeDomains(ctx context.Context, in *opensearch.DescribeDomainsInput, optFns ...func(*opensearch.Options)) (*opensearch.DescribeDomainsOutput, error) {
+	f.calls++
+	if len(in.DomainNames) > 5 {
+		return nil, fmt.Errorf("ValidationException: too many domain names")
+	}
+	out := &opensearch.DescribeDomainsOutput{}
+	for _, n := range in.DomainNames {
+		out.DomainStatusList = append(out.DomainStatusList, ostypes.DomainStatus{
+			ARN:        aws.String("arn:aws:es:eu-west-1:123456789012:domain/" + n),
+			DomainName: aws.String(n),
+			ClusterConfig: &ostypes.ClusterConfig{
+				InstanceType:           ostypes.OpenSearchPartitionInstanceTypeR6gLargeSearch,
+				InstanceCount:          aws.Int32(2),
+				DedicatedMasterEnabled: aws.Bool(true),
+				DedicatedMasterType:    ostypes.OpenSearchPartitionInstanceTypeM6gLargeSearch,
+				DedicatedMasterCount:   aws.Int32(3),
+			},
+			EBSOptions: &ostypes.EBSOptions{EBSEnabled: aws.Bool(true), VolumeSize: aws.Int32(50), VolumeType: ostypes.VolumeTypeGp3},
+		})
+	}
+	return out, nil
+}
+
+type fakeMetricData struct {
+	err error
+}
+
+func (f fakeMetricData) GetMetricData(ctx context.Context, in *cloudwatch.GetMetricDataInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.GetMetricDataOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	out := &cloudwatch.GetMetricDataOutput{}
+	for _, q := range in.MetricDataQueries {
+		values := []float64{0, 0}
+		if aws.ToString(q.MetricStat.Metric.MetricName) == "SearchableDocuments" {
+			values = []float64{900, 1000}
+		}
+		out.MetricDataResults = append(out.MetricDataResults, cwtypes.MetricDataResult{Id: q.Id, Values: values})
+	}
+	return out, nil
+}
+
+func TestOpenSearchScanner(t *testing.T) {
+	g := graph.NewGraph()
+	api := &fakeOpenSearchAPI{names: []string{"a", "b", "c", "d", "e", "f", "g"}}
+	s := &OpenSearchScanner{Client: api, CWClient: fakeMetricData{}, Graph: g}
+	if err := s.ScanDomains(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	g.CloseAndWait()
+
+	if api.calls != 2 {
+		t.Errorf("Expected 7 domains to be described in 2 batches, got %d calls", api.calls)
+	}
+	node := g.GetNode("arn:aws:es:eu-west-1:123456789012:domain/g")
+	if node == nil {
+		t.Fatal("Domain from the second batch not added")
+	}
+	if node.Properties["InstanceType"] != "r6g.large.search" || node.Properties["InstanceCount"] != 2 {
+		t.Errorf("Unexpected cluster shape: %v x %v", node.Properties["InstanceCount"], node.Properties["InstanceType"])
+	}
+	if node.Properties["DedicatedMasterCount"] != 3 || node.Properties["EBSVolumeSize"] != 50 {
+		t.Errorf("Unexpected master/storage config: %v", node.Properties)
+	}
+	if node.Properties["Region"] != "eu-west-1" {
+		t.Errorf("Expected region from ARN, got %v", node.Properties["Region"])
+	}
+	if node.Properties["SearchRate"] != 0.0 || node.Properties["SearchableDocuments"] != 1000.0 {
+		t.Errorf("Unexpected metrics: search=%v docs=%v", node.Properties["SearchRate"], node.Properties["SearchableDocuments"])
+	}
+}
+
+func TestOpenSearchScannerMetricFailure(t *testing.T) {
+	g := graph.NewGraph()
+	s := &OpenSearchScanner{
+		Client:   &fakeOpenSearchAPI{names: []string{"logs"}},
+		CWClient: fakeMetricData{err: fmt.Errorf("AccessDenied")},
+		Graph:    g,
+	}
+	if err := s.ScanDomains(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	g.CloseAndWait()
+
+	node := g.GetNode("arn:aws:es:eu-west-1:123456789012:domain/logs")
+	if node == nil {
+		t.Fatal("Domain not added when CloudWatch fails")
+	}
+	if _, ok := node.Properties["SearchRate"]; ok {
+		t.Error("Metrics must stay unset when CloudWatch cannot be read")
+	}
+}
+
+// recordingMetricData keeps every GetMetricData request.
+type recordingMetricData struct {
+	fakeMetricData
+	inputs []*cloudwatch.GetMetricDataInput
+}
+
+func (r *recordingMetricData) GetMetricData(ctx context.Context, in *cloudwatch.GetMetricDataInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.GetMetricDataOutput, error) {
+	r.inputs = append(r.inputs, in)
+	return r.fakeMetricData.GetMetricData(ctx, in, optFns...)
+}
+
+func TestOpenSearchScannerMetricWindow(t *testing.T) {
+	for _, tc := range []struct {
+		window, want time.Duration
+	}{
+		{0, OpenSearchMetricWindow},
+		{36 * time.Hour, 36 * time.Hour},
+	} {
+		cw := &recordingMetricData{}
+		s := &OpenSearchScanner{Client: &fakeOpenSearchAPI{names: []string{"logs"}}, CWClient: cw, Graph: graph.NewGraph(), Window: tc.window}
+		if err := s.ScanDomains(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		s.Graph.CloseAndWait()
+
+		if len(cw.inputs) != 1 {
+			t.Fatalf("Expected 1 metric request, got %d", len(cw.inputs))
+		}
+		in := cw.inputs[0]
+		if got := in.EndTime.Sub(*in.StartTime); got != tc.want {
+			t.Errorf("Window %v: read %v of metrics, want %v", tc.window, got, tc.want)
+		}
+		if got := aws.ToInt32(in.MetricDataQueries[0].MetricStat.Period); got != MetricPeriod(tc.want) {
+			t.Errorf("Window %v: period %d, want %d", tc.window, got, MetricPeriod(tc.want))
+		}
+	}
+}
@@ -3,10 +3,12 @@ package aws
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
+	rdstypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
 )
 
 // RDSScanner scans RDS instances.
@@ -40,10 +42,61 @@ func (s *RDSScanner) ScanInstances(ctx context.Context) error {
 				"Status":        *instance.DBInstanceStatus,
 				"InstanceClass": *instance.DBInstanceClass,
 				"Engine":        *instance.Engine,
+				"EngineVersion": aws.ToString(instance.EngineVersion),
+				"IsReadReplica": false,
+				"Tags":          rdsTags(instance.TagList),
+			}
+			if instance.StorageEncrypted != nil {
+				props["StorageEncrypted"] = *instance.StorageEncrypted
+			}
+			if instance.KmsKeyId != nil {
+				props["KmsKeyId"] = *instance.KmsKeyId
+			}
+
+			// Replicas are tracked separately from primaries.
+			var sourceARN string
+			if instance.ReadReplicaSourceDBInstanceIdentifier != nil {
+				source := *instance.ReadReplicaSourceDBInstanceIdentifier
+				sourceARN = resolveSourceARN(arn, source)
+				props["IsReadReplica"] = true
+				props["ReplicaSource"] = source
 			}
 
 			s.Graph.AddNode(arn, "AWS::RDS::DBInstance", props)
+
+			if instance.DBSubnetGroup != nil && instance.DBSubnetGroup.VpcId != nil {
+				s.Graph.AddTypedEdge(vpcARN(*instance.DBSubnetGroup.VpcId), arn, graph.EdgeTypeContains, 100)
+			}
+
+			if sourceARN != "" {
+				s.Graph.AddTypedEdge(arn, sourceARN, graph.EdgeTypeUses, 1)
+			}
 		}
 	}
 	return nil
 }
+
+// resolveSourceARN expands a replica source identifier into an ARN.
+// Cross-region sources are already reported as ARNs; same-region sources
+// are bare identifiers that share the replica's ARN prefix.
+func resolveSourceARN(replicaARN, source string) string {
+	if strings.HasPrefix(source, "arn:") {
+		return source
+	}
+	idx := strings.LastIndex(replicaARN, ":")
+	if idx == -1 {
+		return source
+	}
+	return replicaARN[:idx+1] + source
+}
+
+// rdsTags flattens an RDS tag list.
+func rdsTags(tags []rdstypes.Tag) map[string]string {
+	out := make(map[string]string)
+	for _, t := range tags {
+		if t.Key != nil && t.Value != nil {
+			out[*t.Key] = *t.Value
+		}
+	}
+	return out
+}
@@ -0,0 +1,17 @@
+package aws
+
+import "testing"
+
+func TestResolveSourceARN(t *testing.T) {
+	replica := "arn:aws:rds:us-east-1:123456789012:db:orders-replica"
+	for _, tc := range []struct {
+		name, source, want string
+	}{
+		{"same-region identifier", "orders", "arn:aws:rds:us-east-1:123456789012:db:orders"},
+		{"cross-region ARN", "arn:aws:rds:eu-west-1:123456789012:db:orders", "arn:aws:rds:eu-west-1:123456789012:db:orders"},
+	} {
+		if got := resolveSourceARN(replica, tc.source); got != tc.want {
+			t.Errorf("%s: resolveSourceARN(%q) = %q, want %q", tc.name, tc.source, got, tc.want)
+		}
+	}
+}
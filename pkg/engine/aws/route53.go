@@ -0,0 +1,337 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	r53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+)
+
+type route53API interface {
+	route53.ListHostedZonesAPIClient
+	route53.ListResourceRecordSetsAPIClient
+}
+
+// Route53Scanner scans hosted zones and their record sets.
+// Alias and CNAME records aimed at load balancers or CloudFront are resolved
+// against live resources. A target missing from the account is looked up in
+// DNS: one that no longer resolves is recorded in DanglingTarget, one that
+// still does (another account's) in ExternalTarget.
+type Route53Scanner struct {
+	Client route53API
+	Graph  *graph.Graph
+
+	// LoadBalancers maps lowercase DNS names to load balancer ARNs in a region.
+	// Classic load balancers, which have no node, map to "".
+	LoadBalancers func(ctx context.Context, region string) (map[string]string, error)
+	// Distributions maps lowercase CloudFront domain names to distribution ARNs.
+	Distributions func(ctx context.Context) (map[string]string, error)
+	// Resolves reports whether host still has DNS records.
+	Resolves func(ctx context.Context, host string) (bool, error)
+}
+
+// NewRoute53Scanner initializes a scanner for Route53.
+func NewRoute53Scanner(cfg aws.Config, g *graph.Graph) *Route53Scanner {
+	cf := cloudfront.NewFromConfig(cfg)
+	return &Route53Scanner{
+		Client: route53.NewFromConfig(cfg),
+		Graph:  g,
+		LoadBalancers: func(ctx context.Context, region string) (map[string]string, error) {
+			return describeLoadBalancersByDNS(ctx, cfg, region)
+		},
+		Distributions: func(ctx context.Context) (map[string]string, error) {
+			return distributionsByDomain(ctx, cf)
+		},
+		Resolves: hostResolves,
+	}
+}
+
+// ScanRecords maps hosted zones (AWS::Route53::HostedZone) and their record sets
+// (AWS::Route53::RecordSet). Zones contain their records; records use the
+// load balancers and distributions they resolve to.
+func (s *Route53Scanner) ScanRecords(ctx context.Context) error {
+	targets := &dnsTargets{scanner: s, lbs: make(map[string]map[string]string), lbErrs: make(map[string]error)}
+
+	zones := route53.NewListHostedZonesPaginator(s.Client, &route53.ListHostedZonesInput{})
+	for zones.HasMorePages() {
+		page, err := zones.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list hosted zones: %v", err)
+		}
+
+		for _, zone := range page.HostedZones {
+			zoneID := strings.TrimPrefix(aws.ToString(zone.Id), "/hostedzone/")
+			zoneARN := Route53ZoneARN(zoneID)
+			private := zone.Config != nil && zone.Config.PrivateZone
+			s.Graph.AddNode(zoneARN, "AWS::Route53::HostedZone", map[string]interface{}{
+				"Name":        aws.ToString(zone.Name),
+				"ZoneId":      zoneID,
+				"PrivateZone": private,
+				"RecordCount": aws.ToInt64(zone.ResourceRecordSetCount),
+				"Region":      "global",
+			})
+
+			records := route53.NewListResourceRecordSetsPaginator(s.Client, &route53.ListResourceRecordSetsInput{HostedZoneId: zone.Id})
+			for records.HasMorePages() {
+				recPage, err := records.NextPage(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to list records for zone %s: %v", aws.ToString(zone.Name), err)
+				}
+				for _, rec := range recPage.ResourceRecordSets {
+					id, props := recordSetProps(zoneID, aws.ToString(zone.Name), private, rec)
+					target := targets.resolve(ctx, props)
+					s.Graph.AddNode(id, "AWS::Route53::RecordSet", props)
+					s.Graph.AddTypedEdge(zoneARN, id, graph.EdgeTypeContains, 1)
+					if target != "" {
+						s.Graph.AddTypedEdge(id, target, graph.EdgeTypeUses, 100)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Route53ZoneARN is the node ID of a hosted zone.
+func Route53ZoneARN(zoneID string) string {
+	return "arn:aws:route53:::hostedzone/" + zoneID
+}
+
+// recordSetProps flattens a record set. The node ID is unique per type, name and
+// routing set identifier, since weighted and latency records share a name.
+func recordSetProps(zoneID, zoneName string, private bool, rec r53types.ResourceRecordSet) (string, map[string]interface{}) {
+	name := aws.ToString(rec.Name)
+	id := fmt.Sprintf("%s/recordset/%s/%s", Route53ZoneARN(zoneID), rec.Type, name)
+	if rec.SetIdentifier != nil {
+		id += "#" + aws.ToString(rec.SetIdentifier)
+	}
+
+	var values []string
+	for _, rr := range rec.ResourceRecords {
+		values = append(values, strings.TrimSpace(aws.ToString(rr.Value)))
+	}
+	props := map[string]interface{}{
+		"Name":        name,
+		"Type":        string(rec.Type),
+		"Zone":        zoneName,
+		"ZoneId":      zoneID,
+		"PrivateZone": private,
+		"Values":      values,
+		"Region":      "global",
+	}
+	if rec.TTL != nil {
+		props["TTL"] = *rec.TTL
+	}
+	if rec.AliasTarget != nil {
+		props["AliasTarget"] = aws.ToString(rec.AliasTarget.DNSName)
+	}
+	return id, props
+}
+
+// dnsTargets resolves record targets once per scan.
+type dnsTargets struct {
+	scanner *Route53Scanner
+	lbs     map[string]map[string]string
+	lbErrs  map[string]error
+	dists   map[string]string
+	distErr error
+	loaded  bool
+}
+
+// resolve returns the node ID a record points at, or "" when the target has
+// no node or could not be checked. An AWS target that no longer resolves is
+// recorded in the record's DanglingTarget property.
+func (t *dnsTargets) resolve(ctx context.Context, props map[string]interface{}) string {
+	host, _ := props["AliasTarget"].(string)
+	if host == "" && props["Type"] == string(r53types.RRTypeCname) {
+		if values, _ := props["Values"].([]string); len(values) == 1 {
+			host = values[0]
+		}
+	}
+	host = strings.TrimPrefix(strings.TrimSuffix(strings.ToLower(host), "."), "dualstack.")
+	if host == "" {
+		return ""
+	}
+
+	var byDNS map[string]string
+	if region, ok := elbOriginRegion(host); ok && t.scanner.LoadBalancers != nil {
+		if _, cached := t.lbs[region]; !cached && t.lbErrs[region] == nil {
+			t.lbs[region], t.lbErrs[region] = t.scanner.LoadBalancers(ctx, region)
+		}
+		if t.lbErrs[region] != nil {
+			return "" // Unknown is not missing.
+		}
+		byDNS = t.lbs[region]
+	} else if strings.HasSuffix(host, ".cloudfront.net") && t.scanner.Distributions != nil {
+		if !t.loaded {
+			t.dists, t.distErr = t.scanner.Distributions(ctx)
+			t.loaded = true
+		}
+		if t.distErr != nil {
+			return ""
+		}
+		byDNS = t.dists
+	} else {
+		return ""
+	}
+
+	if target, ok := byDNS[host]; ok {
+		if target != "" {
+			props["Target"] = target
+		}
+		return target
+	}
+
+	// Not in this account: another account may own it. Only a name that no
+	// longer resolves is dangling; a failed lookup is unknown.
+	if t.scanner.Resolves == nil {
+		return ""
+	}
+	live, err := t.scanner.Resolves(ctx, host)
+	switch {
+	case err != nil:
+	case live:
+		props["ExternalTarget"] = host
+	default:
+		props["DanglingTarget"] = host
+	}
+	return ""
+}
+
+// hostResolves looks host up in DNS. A name that does not exist is false
+// with no error.
+func hostResolves(ctx context.Context, host string) (bool, error) {
+	_, err := net.DefaultResolver.LookupHost(ctx, host)
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// describeLoadBalancersByDNS maps lowercase DNS names to load balancer ARNs in
+// region. Classic load balancers are included with an empty ARN.
+func describeLoadBalancersByDNS(ctx context.Context, cfg aws.Config, region string) (map[string]string, error) {
+	client := elasticloadbalancingv2.NewFromConfig(cfg, func(o *elasticloadbalancingv2.Options) {
+		o.Region = region
+	})
+	byDNS := make(map[string]string)
+	paginator := elasticloadbalancingv2.NewDescribeLoadBalancersPaginator(client, &elasticloadbalancingv2.DescribeLoadBalancersInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe load balancers in %s: %v", region, err)
+		}
+		for _, lb := range page.LoadBalancers {
+			byDNS[strings.ToLower(aws.ToString(lb.DNSName))] = aws.ToString(lb.LoadBalancerArn)
+		}
+	}
+
+	classic := elasticloadbalancing.NewFromConfig(cfg, func(o *elasticloadbalancing.Options) {
+		o.Region = region
+	})
+	classicPages := elasticloadbalancing.NewDescribeLoadBalancersPaginator(classic, &elasticloadbalancing.DescribeLoadBalancersInput{})
+	for classicPages.HasMorePages() {
+		page, err := classicPages.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe classic load balancers in %s: %v", region, err)
+		}
+		for _, lb := range page.LoadBalancerDescriptions {
+			byDNS[strings.ToLower(aws.ToString(lb.DNSName))] = ""
+		}
+	}
+	return byDNS, nil
+}
+
+// distributionsByDomain maps lowercase CloudFront domain names to distribution ARNs.
+func distributionsByDomain(ctx context.Context, client *cloudfront.Client) (map[string]string, error) {
+	byDomain := make(map[string]string)
+	paginator := cloudfront.NewListDistributionsPaginator(client, &cloudfront.ListDistributionsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list distributions: %v", err)
+		}
+		if page.DistributionList == nil {
+			continue
+		}
+		for _, d := range page.DistributionList.Items {
+			byDomain[strings.ToLower(aws.ToString(d.DomainName))] = aws.ToString(d.ARN)
+		}
+	}
+	return byDomain, nil
+}
+
+// LinkDNSRecords ties address records to the Elastic IPs they resolve to. Each
+// matched EIP gets FoundInDNS, DNSZone, DNSRecord and DNSRecords, so EIP
+// heuristics see every record in the graph, and a Uses edge from the record.
+// Call it after scanning and before the heuristics run.
+func LinkDNSRecords(g *graph.Graph) int {
+	type link struct{ record, eip string }
+	var links []link
+
+	// Scanners queue their writes; wait for them to land.
+	g.Flush()
+
+	g.Mu.Lock()
+	eips := make(map[string]*graph.Node)
+	for _, node := range g.Store.GetAllNodes() {
+		if t := node.TypeStr(); t == "aws_eip" || t == "AWS::EC2::EIP" {
+			if ip, _ := node.Properties["PublicIp"].(string); ip != "" {
+				eips[ip] = node
+			}
+		}
+	}
+	for _, rec := range g.Store.GetAllNodes() {
+		if rec.TypeStr() != "AWS::Route53::RecordSet" {
+			continue
+		}
+		if t, _ := rec.Properties["Type"].(string); t != string(r53types.RRTypeA) && t != string(r53types.RRTypeAaaa) {
+			continue
+		}
+		values, _ := rec.Properties["Values"].([]string)
+		for _, ip := range values {
+			eip, ok := eips[ip]
+			if !ok {
+				continue
+			}
+			name, _ := rec.Properties["Name"].(string)
+			names, _ := eip.Properties["DNSRecords"].([]string)
+			if containsString(names, name) {
+				continue
+			}
+			if len(names) == 0 {
+				eip.Properties["DNSZone"], _ = rec.Properties["Zone"].(string)
+				eip.Properties["DNSRecord"] = name
+			}
+			eip.Properties["DNSRecords"] = append(names, name)
+			eip.Properties["FoundInDNS"] = true
+			delete(eip.Properties, "DNSCheckError")
+			links = append(links, link{rec.IDStr(), eip.IDStr()})
+		}
+	}
+	g.Mu.Unlock()
+
+	for _, l := range links {
+		g.AddTypedEdge(l.record, l.eip, graph.EdgeTypeUses, 100)
+	}
+	return len(links)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	r53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+)
+
+type fakeRoute53API struct {
+	records []r53types.ResourceRecordSet
+}
+
+func (f *fakeRoute53API) ListHostedZones(ctx context.Context, in *route53.ListHostedZonesInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesOutput, error) {
+	return &route53.ListHostedZonesOutput{HostedZones: []r53types.HostedZone{
+		{Id: aws.String("/hostedzone/Z1"), Name: aws.String("example.com."), ResourceRecordSetCount: aws.Int64(int64(len(f.records)))},
+	}}, nil
+}
+
+func (f *fakeRoute53API) ListResourceRecordSets(ctx context.Context, in *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error) {
+	return &route53.ListResourceRecordSetsOutput{ResourceRecordSets: f.records}, nil
+}
+
+func aliasRecord(name, target string) r53types.ResourceRecordSet {
+	return r53types.ResourceRecordSet{
+		Name:        aws.String(name),
+		Type:        r53types.RRTypeA,
+		AliasTarget: &r53types.AliasTarget{DNSName: aws.String(target)},
+	}
+}
+
+func TestRoute53ScannerResolvesTargets(t *testing.T) {
+	lbARN := "arn:aws:elasticloadbalancing:us-east-1:123:loadbalancer/app/web/abc"
+	api := &fakeRoute53API{records: []r53types.ResourceRecordSet{
+		aliasRecord("www.example.com.", "dualstack.web-1.us-east-1.elb.amazonaws.com."),
+		aliasRecord("old.example.com.", "gone-2.us-east-1.elb.amazonaws.com."),
+		aliasRecord("cdn.example.com.", "d111.cloudfront.net."),
+		// Region that cannot be read: unknown, not dangling.
+		aliasRecord("eu.example.com.", "api-3.eu-west-1.elb.amazonaws.com."),
+		// Another account's load balancer still resolves.
+		aliasRecord("partner.example.com.", "partner-4.us-east-1.elb.amazonaws.com."),
+		// A lookup that fails is unknown.
+		aliasRecord("flaky.example.com.", "flaky-5.us-east-1.elb.amazonaws.com."),
+		// Classic load balancers have no node but are live.
+		aliasRecord("legacy.example.com.", "legacy-6.us-east-1.elb.amazonaws.com."),
+		{Name: aws.String("ext.example.com."), Type: r53types.RRTypeCname, TTL: aws.Int64(300),
+			ResourceRecords: []r53types.ResourceRecord{{Value: aws.String("example.herokuapp.com")}}},
+	}}
+	g := graph.NewGraph()
+	g.AddNode(lbARN, "AWS::ElasticLoadBalancingV2::LoadBalancer", map[string]interface{}{})
+	s := &Route53Scanner{
+		Client: api,
+		Graph:  g,
+		LoadBalancers: func(ctx context.Context, region string) (map[string]string, error) {
+			if region != "us-east-1" {
+				return nil, fmt.Errorf("AccessDenied")
+			}
+			return map[string]string{"web-1.us-east-1.elb.amazonaws.com": lbARN, "legacy-6.us-east-1.elb.amazonaws.com": ""}, nil
+		},
+		Distributions: func(ctx context.Context) (map[string]string, error) {
+			return map[string]string{}, nil
+		},
+		Resolves: func(ctx context.Context, host string) (bool, error) {
+			switch host {
+			case "partner-4.us-east-1.elb.amazonaws.com":
+				return true, nil
+			case "flaky-5.us-east-1.elb.amazonaws.com":
+				return false, fmt.Errorf("i/o timeout")
+			}
+			return false, nil
+		},
+	}
+	if err := s.ScanRecords(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	g.CloseAndWait()
+
+	zone := Route53ZoneARN("Z1")
+	if g.GetNode(zone) == nil {
+		t.Fatal("Expected hosted zone node")
+	}
+	record := func(recordType, name string) *graph.Node {
+		node := g.GetNode(zone + "/recordset/" + recordType + "/" + name)
+		if node == nil {
+			t.Fatalf("Missing record %s %s", recordType, name)
+		}
+		return node
+	}
+
+	if target, _ := record("A", "www.example.com.").Properties["Target"].(string); target != lbARN {
+		t.Errorf("Expected www to resolve to the load balancer, got %q", target)
+	}
+	if up := g.GetUpstream(lbARN); len(up) != 1 {
+		t.Errorf("Expected a Uses edge from the record to the load balancer, got %v", up)
+	}
+	if target, _ := record("A", "old.example.com.").Properties["DanglingTarget"].(string); target != "gone-2.us-east-1.elb.amazonaws.com" {
+		t.Errorf("Expected old to dangle, got %q", target)
+	}
+	if target, _ := record("A", "cdn.example.com.").Properties["DanglingTarget"].(string); target != "d111.cloudfront.net" {
+		t.Errorf("Expected cdn to dangle, got %q", target)
+	}
+	if target, _ := record("A", "partner.example.com.").Properties["ExternalTarget"].(string); target != "partner-4.us-east-1.elb.amazonaws.com" {
+		t.Errorf("Expected partner to resolve outside the account, got %q", target)
+	}
+	for _, n := range []*graph.Node{record("A", "eu.example.com."), record("CNAME", "ext.example.com."),
+		record("A", "partner.example.com."), record("A", "flaky.example.com."), record("A", "legacy.example.com.")} {
+		if _, ok := n.Properties["DanglingTarget"]; ok {
+			t.Errorf("Expected %s not to be judged", n.Properties["Name"])
+		}
+	}
+}
+
+func TestLinkDNSRecords(t *testing.T) {
+	g := graph.NewGraph()
+	eip := "arn:aws:ec2:us-east-1:123:eip/eipalloc-1"
+	g.AddNode(eip, "aws_eip", map[string]interface{}{
+		"PublicIp":      "198.51.100.7",
+		"FoundInDNS":    false,
+		"DNSCheckError": "AccessDenied",
+	})
+	g.AddNode("arn:aws:ec2:us-east-1:123:eip/eipalloc-2", "aws_eip", map[string]interface{}{"PublicIp": "198.51.100.8"})
+	for _, name := range []string{"a.example.com.", "b.example.com."} {
+		g.AddNode(Route53ZoneARN("Z1")+"/recordset/A/"+name, "AWS::Route53::RecordSet", map[string]interface{}{
+			"Name": name, "Type": "A", "Zone": "example.com.", "Values": []string{"198.51.100.7"},
+		})
+	}
+
+	// No flush before linking: queued nodes must still be seen.
+	if n := LinkDNSRecords(g); n != 2 {
+		t.Fatalf("Expected 2 links, got %d", n)
+	}
+	g.CloseAndWait()
+
+	node := g.GetNode(eip)
+	if inDNS, _ := node.Properties["FoundInDNS"].(bool); !inDNS {
+		t.Fatal("Expected EIP to be found in DNS")
+	}
+	if node.Properties["DNSRecord"] != "a.example.com." || node.Properties["DNSZone"] != "example.com." {
+		t.Errorf("Unexpected record %v in zone %v", node.Properties["DNSRecord"], node.Properties["DNSZone"])
+	}
+	if names, _ := node.Properties["DNSRecords"].([]string); len(names) != 2 {
+		t.Errorf("Expected both records, got %v", names)
+	}
+	if _, ok := node.Properties["DNSCheckError"]; ok {
+		t.Error("Expected DNSCheckError to be cleared")
+	}
+	if len(g.GetUpstream(eip)) != 2 {
+		t.Error("Expected Uses edges from both records")
+	}
+	if other := g.GetNode("arn:aws:ec2:us-east-1:123:eip/eipalloc-2"); other.Properties["FoundInDNS"] != nil {
+		t.Error("Expected unreferenced EIP to be left alone")
+	}
+}
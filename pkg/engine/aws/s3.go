@@ -2,33 +2,58 @@ package aws
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
+	"time"
 
 	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+// S3Client is the subset of the S3 API the bucket scanner calls.
+type S3Client interface {
+	ListBuckets(ctx context.Context, params *s3.ListBucketsInput, optFns ...func(*s3.Options)) (*s3.ListBucketsOutput, error)
+	GetBucketLocation(ctx context.Context, params *s3.GetBucketLocationInput, optFns ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error)
+	GetBucketLifecycleConfiguration(ctx context.Context, params *s3.GetBucketLifecycleConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error)
+	ListMultipartUploads(ctx context.Context, params *s3.ListMultipartUploadsInput, optFns ...func(*s3.Options)) (*s3.ListMultipartUploadsOutput, error)
+	HeadBucket(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error)
+	GetBucketEncryption(ctx context.Context, params *s3.GetBucketEncryptionInput, optFns ...func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error)
+	GetBucketTagging(ctx context.Context, params *s3.GetBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error)
+}
+
+// Per-bucket calls are retried on throttling (SlowDown) and server errors.
+const (
+	s3MaxAttempts = 3
+	s3BaseBackoff = 500 * time.Millisecond
 )
 
 // S3Scanner scans S3 buckets and contents.
 type S3Scanner struct {
-	Client          *s3.Client
+	Client          S3Client
 	BaseConfig      aws.Config
-	RegionalClients map[string]*s3.Client
+	RegionalClients map[string]S3Client
 	Graph           *graph.Graph
+	Encryption      bool // Read each bucket's default encryption (compliance checks).
+
+	sleep func(ctx context.Context, d time.Duration) error // Overridden in tests.
 }
 
 func NewS3Scanner(cfg aws.Config, g *graph.Graph) *S3Scanner {
 	return &S3Scanner{
 		Client:          s3.NewFromConfig(cfg),
 		BaseConfig:      cfg,
-		RegionalClients: make(map[string]*s3.Client),
+		RegionalClients: make(map[string]S3Client),
 		Graph:           g,
+		sleep:           sleepContext,
 	}
 }
 
 // getRegionalClient returns a region-specific S3 client.
-func (s *S3Scanner) getRegionalClient(region string) *s3.Client {
+func (s *S3Scanner) getRegionalClient(region string) S3Client {
 	if region == "" {
 		return s.Client
 	}
@@ -43,96 +68,254 @@ func (s *S3Scanner) getRegionalClient(region string) *s3.Client {
 	return client
 }
 
-// ScanBuckets analyzes S3 buckets and their configurations.
+// ScanBuckets analyzes S3 buckets and their configurations. Buckets are
+// scanned independently: a bucket that cannot be read is recorded as a failed
+// scope and the rest are still scanned. Only a failure to list buckets fails
+// the scan.
 func (s *S3Scanner) ScanBuckets(ctx context.Context) error {
-	result, err := s.Client.ListBuckets(ctx, &s3.ListBucketsInput{})
+	var result *s3.ListBucketsOutput
+	err := s.retry(ctx, func() error {
+		var err error
+		result, err = s.Client.ListBuckets(ctx, &s3.ListBucketsInput{})
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to list buckets: %v", err)
 	}
 
 	for _, bucket := range result.Buckets {
-		name := *bucket.Name
-		arn := fmt.Sprintf("arn:aws:s3:::bucket/%s", name)
-
-		// Find bucket region.
-		var region string
-		loc, err := s.Client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{Bucket: &name})
-		if err == nil && loc.LocationConstraint != "" {
-			region = string(loc.LocationConstraint)
-			// Map legacy 'EU' location constraint to 'eu-west-1'.
-			if region == "EU" {
-				region = "eu-west-1"
-			}
-		} else {
-			// Without LocationConstraint, it "should" be us-east-1, but we must verify.
-			// Permissions issues can also cause empty responses.
-			if s.verifyBucketRegion(ctx, name, "us-east-1") {
-				region = "us-east-1"
-			} else {
-				// Fallback: If region determination fails, mark as unknown to prevent cascading errors.
-				region = "RegionUnknown"
-				fmt.Printf("Warning: Could not determine region for bucket %s. Scanning metadata only.\n", name)
-			}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		name := aws.ToString(bucket.Name)
+		if err := s.scanBucket(ctx, bucket); err != nil {
+			s.Graph.AddError(fmt.Sprintf("S3 [%s]", name), err)
 		}
+	}
+	return nil
+}
+
+// scanBucket records one bucket and its incomplete multipart uploads. Denied
+// property reads are noted on the node; other failures are returned once the
+// bucket itself has been recorded.
+func (s *S3Scanner) scanBucket(ctx context.Context, bucket types.Bucket) error {
+	name := aws.ToString(bucket.Name)
+	arn := S3BucketARN(name)
 
-		// Get client.
-		regionalClient := s.getRegionalClient(region)
+	props := map[string]interface{}{
+		"Name":         name,
+		"CreationDate": bucket.CreationDate,
+	}
 
-		props := map[string]interface{}{
-			"Name":         name,
-			"Region":       region,
-			"CreationDate": bucket.CreationDate,
+	// Find bucket region.
+	var region string
+	var loc *s3.GetBucketLocationOutput
+	err := s.retry(ctx, func() error {
+		var err error
+		loc, err = s.Client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{Bucket: &name})
+		return err
+	})
+	if err != nil {
+		RecordPropertyError(props, "s3:GetBucketLocation", err)
+	}
+	if err == nil && loc.LocationConstraint != "" {
+		region = string(loc.LocationConstraint)
+		// Map legacy 'EU' location constraint to 'eu-west-1'.
+		if region == "EU" {
+			region = "eu-west-1"
+		}
+	} else {
+		// Without LocationConstraint, it "should" be us-east-1, but we must verify.
+		// Permissions issues can also cause empty responses.
+		if s.verifyBucketRegion(ctx, name, "us-east-1") {
+			region = "us-east-1"
+		} else {
+			// Fallback: If region determination fails, mark as unknown to prevent cascading errors.
+			region = "RegionUnknown"
+			fmt.Printf("Warning: Could not determine region for bucket %s. Scanning metadata only.\n", name)
 		}
+	}
+
+	// Get client.
+	regionalClient := s.getRegionalClient(region)
 
-		// Check for lifecycle rules that abort incomplete multipart uploads.
-		// Note: We use the regional client for GetBucketLifecycleConfiguration to avoid redirection errors.
-		hasAbortRule := s.hasAbortLifecycle(ctx, regionalClient, name)
-		props["HasAbortLifecycle"] = hasAbortRule
+	props["Region"] = region
+
+	// Check for lifecycle rules that abort incomplete multipart uploads.
+	// Note: We use the regional client for GetBucketLifecycleConfiguration to avoid redirection errors.
+	var failure error
+	hasAbortRule, err := s.hasAbortLifecycle(ctx, regionalClient, name)
+	if err != nil && !RecordPropertyError(props, "s3:GetLifecycleConfiguration", err) {
+		failure = fmt.Errorf("failed to get lifecycle configuration: %v", err)
+	}
+	props["HasAbortLifecycle"] = hasAbortRule
+	denied, _ := props[PropertyErrorsKey].([]string)
 
-		s.Graph.AddNode(arn, "AWS::S3::Bucket", props)
+	tags, err := s.bucketTags(ctx, regionalClient, name)
+	if err != nil {
+		if !RecordPropertyError(props, "s3:GetBucketTagging", err) {
+			failure = errors.Join(failure, fmt.Errorf("failed to get bucket tagging: %v", err))
+		}
+	} else if len(tags) > 0 {
+		props["Tags"] = tags
+	}
 
-		// Scan for incomplete multipart uploads if no abort rule exists.
-		if !hasAbortRule {
-			if err := s.scanMultipartUploads(ctx, regionalClient, name, arn); err != nil {
-				fmt.Printf("Failed to scan multipart uploads for bucket %s (%s): %v\n", name, region, err)
+	// Read after denied is taken: uploads do not depend on the encryption check.
+	if s.Encryption {
+		algorithm, err := s.defaultEncryption(ctx, regionalClient, name)
+		if err != nil {
+			if !RecordPropertyError(props, "s3:GetEncryptionConfiguration", err) {
+				failure = errors.Join(failure, fmt.Errorf("failed to get encryption configuration: %v", err))
 			}
+		} else {
+			props["DefaultEncryption"] = algorithm
 		}
 	}
-	return nil
+
+	s.Graph.AddNode(arn, "AWS::S3::Bucket", props)
+
+	// Scan for incomplete multipart uploads if no abort rule exists.
+	if !hasAbortRule {
+		if err := s.scanMultipartUploads(ctx, regionalClient, name, arn, denied); err != nil {
+			return errors.Join(failure, fmt.Errorf("failed to list multipart uploads (%s): %v", region, err))
+		}
+	}
+	return failure
+}
+
+// retry runs fn, retrying throttled and server-side failures with jittered
+// exponential backoff. Other errors, such as access denied, return at once.
+func (s *S3Scanner) retry(ctx context.Context, fn func() error) error {
+	backoff := s3BaseBackoff
+	var err error
+	for attempt := 1; attempt <= s3MaxAttempts; attempt++ {
+		if err = fn(); err == nil || !retryableS3Error(err) || attempt == s3MaxAttempts {
+			return err
+		}
+		wait := backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+		sleep := s.sleep
+		if sleep == nil {
+			sleep = sleepContext
+		}
+		if serr := sleep(ctx, wait); serr != nil {
+			return serr
+		}
+		backoff *= 2
+	}
+	return err
+}
+
+// retryableS3Error reports whether err is a throttle or a 5xx response.
+func retryableS3Error(err error) bool {
+	if IsThrottleError(err) {
+		return true
+	}
+	var status interface{ HTTPStatusCode() int }
+	return errors.As(err, &status) && status.HTTPStatusCode() >= 500
 }
 
 // hasAbortLifecycle checks for multipart upload abort rules.
-func (s *S3Scanner) hasAbortLifecycle(ctx context.Context, client *s3.Client, bucket string) bool {
-	lc, err := client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{
-		Bucket: aws.String(bucket),
+// On error it reports false (assume unsafe) along with the error. A bucket
+// without any lifecycle configuration is not an error.
+func (s *S3Scanner) hasAbortLifecycle(ctx context.Context, client S3Client, bucket string) (bool, error) {
+	var lc *s3.GetBucketLifecycleConfigurationOutput
+	err := s.retry(ctx, func() error {
+		var err error
+		lc, err = client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{
+			Bucket: aws.String(bucket),
+		})
+		return err
 	})
 	if err != nil {
-		return false // Assume unsafe configuration on error.
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchLifecycleConfiguration" {
+			return false, nil
+		}
+		return false, err
 	}
 
 	for _, rule := range lc.Rules {
 		if rule.Status == types.ExpirationStatusEnabled && rule.AbortIncompleteMultipartUpload != nil {
-			return true
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// bucketTags returns the bucket's tags, or nil when it has none.
+func (s *S3Scanner) bucketTags(ctx context.Context, client S3Client, bucket string) (map[string]string, error) {
+	var out *s3.GetBucketTaggingOutput
+	err := s.retry(ctx, func() error {
+		var err error
+		out, err = client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{
+			Bucket: aws.String(bucket),
+		})
+		return err
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchTagSet" {
+			return nil, nil
+		}
+		return nil, err
+	}
+	tags := make(map[string]string, len(out.TagSet))
+	for _, t := range out.TagSet {
+		tags[aws.ToString(t.Key)] = aws.ToString(t.Value)
+	}
+	return tags, nil
+}
+
+// defaultEncryption returns the algorithm of the bucket's default encryption
+// rule, or "" when the bucket has none.
+func (s *S3Scanner) defaultEncryption(ctx context.Context, client S3Client, bucket string) (string, error) {
+	var out *s3.GetBucketEncryptionOutput
+	err := s.retry(ctx, func() error {
+		var err error
+		out, err = client.GetBucketEncryption(ctx, &s3.GetBucketEncryptionInput{
+			Bucket: aws.String(bucket),
+		})
+		return err
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "ServerSideEncryptionConfigurationNotFoundError" {
+			return "", nil
+		}
+		return "", err
+	}
+	if out.ServerSideEncryptionConfiguration == nil {
+		return "", nil
+	}
+	for _, rule := range out.ServerSideEncryptionConfiguration.Rules {
+		if rule.ApplyServerSideEncryptionByDefault != nil {
+			return string(rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm), nil
 		}
 	}
-	return false
+	return "", nil
 }
 
 // scanMultipartUploads finds incomplete multipart uploads.
-func (s *S3Scanner) scanMultipartUploads(ctx context.Context, client *s3.Client, bucketName, bucketARN string) error {
+// Uploads inherit the bucket's denied calls: they are only findings because no abort rule was seen.
+func (s *S3Scanner) scanMultipartUploads(ctx context.Context, client S3Client, bucketName, bucketARN string, denied []string) error {
 	paginator := s3.NewListMultipartUploadsPaginator(client, &s3.ListMultipartUploadsInput{
 		Bucket: aws.String(bucketName),
 	})
 
 	for paginator.HasMorePages() {
-		page, err := paginator.NextPage(ctx)
+		var page *s3.ListMultipartUploadsOutput
+		err := s.retry(ctx, func() error {
+			var err error
+			page, err = paginator.NextPage(ctx)
+			return err
+		})
 		if err != nil {
 			return err
 		}
 
 		for _, upload := range page.Uploads {
-			key := *upload.Key
-			uploadId := *upload.UploadId
+			key := aws.ToString(upload.Key)
+			uploadId := aws.ToString(upload.UploadId)
 			arn := fmt.Sprintf("arn:aws:s3:::multipart/%s/%s", bucketName, uploadId)
 
 			props := map[string]interface{}{
@@ -141,6 +324,9 @@ func (s *S3Scanner) scanMultipartUploads(ctx context.Context, client *s3.Client,
 				"UploadId":  uploadId,
 				"Initiated": upload.Initiated,
 			}
+			if len(denied) > 0 {
+				props[PropertyErrorsKey] = append([]string(nil), denied...)
+			}
 
 			s.Graph.AddNode(arn, "AWS::S3::MultipartUpload", props)
 			s.Graph.AddEdge(arn, bucketARN) // Establish dependency.
@@ -152,6 +338,9 @@ func (s *S3Scanner) scanMultipartUploads(ctx context.Context, client *s3.Client,
 // verifyBucketRegion confirms bucket accessibility in region.
 func (s *S3Scanner) verifyBucketRegion(ctx context.Context, bucket, region string) bool {
 	client := s.getRegionalClient(region)
-	_, err := client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(bucket)})
+	err := s.retry(ctx, func() error {
+		_, err := client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(bucket)})
+		return err
+	})
 	return err == nil
 }
@@ -2,11 +2,15 @@ package aws
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 )
 
 // MockS3RegionalClient is a minimal mock for testing regional logic
@@ -15,13 +19,47 @@ type MockS3RegionalClient struct {
 	GetBucketLocationFunc               func(ctx context.Context, params *s3.GetBucketLocationInput, optFns ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error)
 	GetBucketLifecycleConfigurationFunc func(ctx context.Context, params *s3.GetBucketLifecycleConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error)
 	ListMultipartUploadsFunc            func(ctx context.Context, params *s3.ListMultipartUploadsInput, optFns ...func(*s3.Options)) (*s3.ListMultipartUploadsOutput, error)
+	HeadBucketFunc                      func(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error)
+	GetBucketEncryptionFunc             func(ctx context.Context, params *s3.GetBucketEncryptionInput, optFns ...func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error)
+	GetBucketTaggingFunc                func(ctx context.Context, params *s3.GetBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error)
 }
 
-// Ensure the S3Scanner treats our mock as a *s3.Client is hard because *s3.Client is a struct, not an interface.
-// However, our code uses *s3.Client directly.
-// To test this PROPERLY without integration tests, we need to inspect the 'getRegionalClient' logic directly or refactor S3Scanner to accept an interface.
-// Given strict "no refactor" on interfaces request earlier and trying to stay minimal:
-// We will test the `getRegionalClient` method logic via a lightweight test.
+func (m *MockS3RegionalClient) ListBuckets(ctx context.Context, params *s3.ListBucketsInput, optFns ...func(*s3.Options)) (*s3.ListBucketsOutput, error) {
+	return m.ListBucketsFunc(ctx, params, optFns...)
+}
+
+func (m *MockS3RegionalClient) GetBucketLocation(ctx context.Context, params *s3.GetBucketLocationInput, optFns ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error) {
+	return m.GetBucketLocationFunc(ctx, params, optFns...)
+}
+
+func (m *MockS3RegionalClient) GetBucketLifecycleConfiguration(ctx context.Context, params *s3.GetBucketLifecycleConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+	return m.GetBucketLifecycleConfigurationFunc(ctx, params, optFns...)
+}
+
+func (m *MockS3RegionalClient) ListMultipartUploads(ctx context.Context, params *s3.ListMultipartUploadsInput, optFns ...func(*s3.Options)) (*s3.ListMultipartUploadsOutput, error) {
+	return m.ListMultipartUploadsFunc(ctx, params, optFns...)
+}
+
+func (m *MockS3RegionalClient) HeadBucket(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+	if m.HeadBucketFunc == nil {
+		return &s3.HeadBucketOutput{}, nil
+	}
+	return m.HeadBucketFunc(ctx, params, optFns...)
+}
+
+func (m *MockS3RegionalClient) GetBucketEncryption(ctx context.Context, params *s3.GetBucketEncryptionInput, optFns ...func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+	if m.GetBucketEncryptionFunc == nil {
+		return nil, &smithy.GenericAPIError{Code: "ServerSideEncryptionConfigurationNotFoundError"}
+	}
+	return m.GetBucketEncryptionFunc(ctx, params, optFns...)
+}
+
+func (m *MockS3RegionalClient) GetBucketTagging(ctx context.Context, params *s3.GetBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+	if m.GetBucketTaggingFunc == nil {
+		return nil, &smithy.GenericAPIError{Code: "NoSuchTagSet"}
+	}
+	return m.GetBucketTaggingFunc(ctx, params, optFns...)
+}
 
 func TestGetRegionalClient_Caching(t *testing.T) {
 	g := graph.NewGraph()
@@ -53,5 +91,141 @@ func TestGetRegionalClient_Caching(t *testing.T) {
 	}
 }
 
-// Note: Testing the full flow requires mocking the AWS SDK *Client struct which is complex.
-// The caching test above proves the core mechanic: we ARE switching clients based on input strings.
+func TestScanBucketsIsolatesBucketFailures(t *testing.T) {
+	throttles := 0
+	mock := &MockS3RegionalClient{
+		ListBucketsFunc: func(ctx context.Context, params *s3.ListBucketsInput, optFns ...func(*s3.Options)) (*s3.ListBucketsOutput, error) {
+			return &s3.ListBucketsOutput{Buckets: []types.Bucket{
+				{Name: aws.String("denied")},
+				{Name: aws.String("broken")},
+				{Name: aws.String("flaky")},
+				{Name: aws.String("healthy")},
+			}}, nil
+		},
+		GetBucketLocationFunc: func(ctx context.Context, params *s3.GetBucketLocationInput, optFns ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error) {
+			return &s3.GetBucketLocationOutput{LocationConstraint: types.BucketLocationConstraintEuWest1}, nil
+		},
+		GetBucketLifecycleConfigurationFunc: func(ctx context.Context, params *s3.GetBucketLifecycleConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+			switch *params.Bucket {
+			case "denied":
+				return nil, &smithy.GenericAPIError{Code: "AccessDenied"}
+			case "flaky":
+				if throttles < 2 {
+					throttles++
+					return nil, &smithy.GenericAPIError{Code: "SlowDown"}
+				}
+			}
+			return nil, &smithy.GenericAPIError{Code: "NoSuchLifecycleConfiguration"}
+		},
+		ListMultipartUploadsFunc: func(ctx context.Context, params *s3.ListMultipartUploadsInput, optFns ...func(*s3.Options)) (*s3.ListMultipartUploadsOutput, error) {
+			if *params.Bucket == "broken" {
+				return nil, errors.New("connection reset")
+			}
+			return &s3.ListMultipartUploadsOutput{Uploads: []types.MultipartUpload{
+				{Key: aws.String("big.bin"), UploadId: aws.String("up-" + *params.Bucket)},
+			}}, nil
+		},
+	}
+
+	g := graph.NewGraph()
+	scanner := NewS3Scanner(aws.Config{Region: "us-east-1"}, g)
+	scanner.Client = mock
+	scanner.RegionalClients["eu-west-1"] = mock
+	scanner.sleep = func(context.Context, time.Duration) error { return nil }
+
+	if err := scanner.ScanBuckets(context.Background()); err != nil {
+		t.Fatalf("ScanBuckets failed: %v", err)
+	}
+	g.CloseAndWait()
+
+	for _, name := range []string{"denied", "broken", "flaky", "healthy"} {
+		if g.GetNode(S3BucketARN(name)) == nil {
+			t.Errorf("bucket %s not recorded", name)
+		}
+	}
+	for _, name := range []string{"denied", "flaky", "healthy"} {
+		if g.GetNode("arn:aws:s3:::multipart/"+name+"/up-"+name) == nil {
+			t.Errorf("multipart upload in %s not recorded", name)
+		}
+	}
+	if throttles != 2 {
+		t.Errorf("expected the throttled lifecycle call to be retried, got %d throttles", throttles)
+	}
+	if errs, _ := g.GetNode(S3BucketARN("denied")).Properties[PropertyErrorsKey].([]string); len(errs) != 1 {
+		t.Errorf("denied lifecycle read not recorded on the bucket: %v", errs)
+	}
+
+	failed := g.Metadata.FailedScopes
+	if len(failed) != 1 || failed[0].Scope != "S3 [broken]" {
+		t.Fatalf("expected only the broken bucket as a failed scope, got %+v", failed)
+	}
+}
+
+func TestScanBucketsReadsDefaultEncryption(t *testing.T) {
+	mock := &MockS3RegionalClient{
+		ListBucketsFunc: func(ctx context.Context, params *s3.ListBucketsInput, optFns ...func(*s3.Options)) (*s3.ListBucketsOutput, error) {
+			return &s3.ListBucketsOutput{Buckets: []types.Bucket{
+				{Name: aws.String("plain")},
+				{Name: aws.String("sse")},
+				{Name: aws.String("denied")},
+			}}, nil
+		},
+		GetBucketLocationFunc: func(ctx context.Context, params *s3.GetBucketLocationInput, optFns ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error) {
+			return &s3.GetBucketLocationOutput{LocationConstraint: types.BucketLocationConstraintEuWest1}, nil
+		},
+		GetBucketLifecycleConfigurationFunc: func(ctx context.Context, params *s3.GetBucketLifecycleConfigurationInput, optFns ...func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+			return nil, &smithy.GenericAPIError{Code: "NoSuchLifecycleConfiguration"}
+		},
+		ListMultipartUploadsFunc: func(ctx context.Context, params *s3.ListMultipartUploadsInput, optFns ...func(*s3.Options)) (*s3.ListMultipartUploadsOutput, error) {
+			return &s3.ListMultipartUploadsOutput{}, nil
+		},
+		GetBucketEncryptionFunc: func(ctx context.Context, params *s3.GetBucketEncryptionInput, optFns ...func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+			switch *params.Bucket {
+			case "sse":
+				return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: &types.ServerSideEncryptionConfiguration{
+					Rules: []types.ServerSideEncryptionRule{{ApplyServerSideEncryptionByDefault: &types.ServerSideEncryptionByDefault{SSEAlgorithm: types.ServerSideEncryptionAes256}}},
+				}}, nil
+			case "denied":
+				return nil, &smithy.GenericAPIError{Code: "AccessDenied"}
+			}
+			return nil, &smithy.GenericAPIError{Code: "ServerSideEncryptionConfigurationNotFoundError"}
+		},
+		GetBucketTaggingFunc: func(ctx context.Context, params *s3.GetBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+			if *params.Bucket == "sse" {
+				return &s3.GetBucketTaggingOutput{TagSet: []types.Tag{{Key: aws.String("Environment"), Value: aws.String("dev")}}}, nil
+			}
+			return nil, &smithy.GenericAPIError{Code: "NoSuchTagSet"}
+		},
+	}
+
+	g := graph.NewGraph()
+	scanner := NewS3Scanner(aws.Config{Region: "us-east-1"}, g)
+	scanner.Client = mock
+	scanner.RegionalClients["eu-west-1"] = mock
+	scanner.Encryption = true
+
+	if err := scanner.ScanBuckets(context.Background()); err != nil {
+		t.Fatalf("ScanBuckets failed: %v", err)
+	}
+	g.CloseAndWait()
+
+	if alg, ok := g.GetNode(S3BucketARN("plain")).Properties["DefaultEncryption"].(string); !ok || alg != "" {
+		t.Errorf("Expected plain bucket to have no default encryption, got %q (%v)", alg, ok)
+	}
+	if alg, _ := g.GetNode(S3BucketARN("sse")).Properties["DefaultEncryption"].(string); alg != "AES256" {
+		t.Errorf("Expected AES256, got %q", alg)
+	}
+	if tags, _ := g.GetNode(S3BucketARN("sse")).Properties["Tags"].(map[string]string); tags["Environment"] != "dev" {
+		t.Errorf("Expected bucket tags to be recorded, got %v", tags)
+	}
+	if _, ok := g.GetNode(S3BucketARN("plain")).Properties["Tags"]; ok {
+		t.Error("Expected no tags on an untagged bucket")
+	}
+	denied := g.GetNode(S3BucketARN("denied")).Properties
+	if _, ok := denied["DefaultEncryption"]; ok {
+		t.Error("Expected no encryption status when the read is denied")
+	}
+	if errs, _ := denied[PropertyErrorsKey].([]string); len(errs) != 1 {
+		t.Errorf("Expected the denied read to be recorded, got %v", errs)
+	}
+}
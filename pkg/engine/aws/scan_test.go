@@ -13,7 +13,9 @@ import (
 
 // MockEC2Client implements a mock EC2Client for unit testing purposes.
 type MockEC2Client struct {
-	DescribeVolumesFunc func(ctx context.Context, params *ec2.DescribeVolumesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVolumesOutput, error)
+	DescribeVolumesFunc           func(ctx context.Context, params *ec2.DescribeVolumesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVolumesOutput, error)
+	DescribeSnapshotsFunc         func(ctx context.Context, params *ec2.DescribeSnapshotsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSnapshotsOutput, error)
+	DescribeSnapshotAttributeFunc func(ctx context.Context, params *ec2.DescribeSnapshotAttributeInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSnapshotAttributeOutput, error)
 	// Add other mock functions if needed
 }
 
@@ -44,9 +46,23 @@ func (m *MockEC2Client) DescribeImages(ctx context.Context, params *ec2.Describe
 }
 
 func (m *MockEC2Client) DescribeSnapshots(ctx context.Context, params *ec2.DescribeSnapshotsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSnapshotsOutput, error) {
+	if m.DescribeSnapshotsFunc != nil {
+		return m.DescribeSnapshotsFunc(ctx, params, optFns...)
+	}
 	return &ec2.DescribeSnapshotsOutput{}, nil
 }
 
+func (m *MockEC2Client) DescribeSnapshotAttribute(ctx context.Context, params *ec2.DescribeSnapshotAttributeInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSnapshotAttributeOutput, error) {
+	if m.DescribeSnapshotAttributeFunc != nil {
+		return m.DescribeSnapshotAttributeFunc(ctx, params, optFns...)
+	}
+	return &ec2.DescribeSnapshotAttributeOutput{}, nil
+}
+
+func (m *MockEC2Client) DescribeImageAttribute(ctx context.Context, params *ec2.DescribeImageAttributeInput, optFns ...func(*ec2.Options)) (*ec2.DescribeImageAttributeOutput, error) {
+	return &ec2.DescribeImageAttributeOutput{}, nil
+}
+
 func (m *MockEC2Client) DescribeInstanceTypes(ctx context.Context, params *ec2.DescribeInstanceTypesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInstanceTypesOutput, error) {
 	return &ec2.DescribeInstanceTypesOutput{}, nil
 }
@@ -155,3 +171,43 @@ func TestScanVolumes(t *testing.T) {
 		})
 	}
 }
+
+func TestScanSnapshotsRecordsSharing(t *testing.T) {
+	snapshot := func(id string) types.Snapshot {
+		return types.Snapshot{
+			SnapshotId: aws.String(id), VolumeId: aws.String("vol-1"), VolumeSize: aws.Int32(8),
+			Description: aws.String(""), StartTime: aws.Time(time.Now()),
+		}
+	}
+	permissions := map[string][]types.CreateVolumePermission{
+		"snap-public":  {{Group: types.PermissionGroupAll}},
+		"snap-partner": {{UserId: aws.String("999999999999")}},
+		"snap-private": nil,
+	}
+
+	g := graph.NewGraph()
+	scanner := &EC2Scanner{Graph: g, Client: &MockEC2Client{
+		DescribeSnapshotsFunc: func(ctx context.Context, params *ec2.DescribeSnapshotsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSnapshotsOutput, error) {
+			return &ec2.DescribeSnapshotsOutput{Snapshots: []types.Snapshot{snapshot("snap-public"), snapshot("snap-partner"), snapshot("snap-private")}}, nil
+		},
+		DescribeSnapshotAttributeFunc: func(ctx context.Context, params *ec2.DescribeSnapshotAttributeInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSnapshotAttributeOutput, error) {
+			return &ec2.DescribeSnapshotAttributeOutput{CreateVolumePermissions: permissions[*params.SnapshotId]}, nil
+		},
+	}}
+	if err := scanner.ScanSnapshots(context.Background(), ""); err != nil {
+		t.Fatal(err)
+	}
+	g.CloseAndWait()
+
+	for id, want := range map[string]struct {
+		public   bool
+		grantees int
+	}{"snap-public": {true, 1}, "snap-partner": {false, 1}, "snap-private": {false, 0}} {
+		node := g.GetNode("arn:aws:ec2:region:account:snapshot/" + id)
+		public, _ := node.Properties["Public"].(bool)
+		grantees, _ := node.Properties["CreateVolumePermissions"].([]string)
+		if public != want.public || len(grantees) != want.grantees {
+			t.Errorf("%s: public=%v grantees=%v, want public=%v with %d grantees", id, public, grantees, want.public, want.grantees)
+		}
+	}
+}
@@ -15,10 +15,14 @@ import (
 	smithyhttp "github.com/aws/smithy-go/transport/http"
 )
 
+// PlainOutput disables ANSI styling in verbose SDK tracing (set by --no-color/--ci).
+var PlainOutput bool
+
 // Client wraps the AWS SDK client.
 type Client struct {
-	Config aws.Config
-	STS    *sts.Client
+	Config    aws.Config
+	STS       *sts.Client
+	AccountID string // Set once the identity is verified.
 }
 
 // NewClient initializes a new AWS SDK client.
@@ -67,7 +71,11 @@ func NewClient(ctx context.Context, region, profile string, verbose bool) (*Clie
 				middleware.InitializeOutput, middleware.Metadata, error,
 			) {
 				opName := middleware.GetOperationName(ctx)
-				fmt.Printf("\033[2m\033[32m[AWS-SDK] API Call: %s\033[0m\n", opName)
+				if PlainOutput {
+					fmt.Printf("[AWS-SDK] API Call: %s\n", opName)
+				} else {
+					fmt.Printf("\033[2m\033[32m[AWS-SDK] API Call: %s\033[0m\n", opName)
+				}
 				return next.HandleInitialize(ctx, input)
 			}), middleware.Before)
 		})
@@ -86,7 +94,17 @@ func (c *Client) VerifyIdentity(ctx context.Context) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("failed to get caller identity: %v", err)
 	}
-	return *result.Account, nil
+	c.AccountID = aws.ToString(result.Account)
+	return c.AccountID, nil
+}
+
+// CallerARN returns the ARN of the authenticated principal.
+func (c *Client) CallerARN(ctx context.Context) (string, error) {
+	result, err := c.STS.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get caller identity: %v", err)
+	}
+	return aws.ToString(result.Arn), nil
 }
 
 // GetConfigForRegion returns a config copy for the specified region.
@@ -55,6 +55,11 @@ var specsMap = map[string]InstanceSpecs{
 	"m5.2xlarge": {VCPU: 8, Memory: 32768, Arch: "x86_64"},
 	"m5.4xlarge": {VCPU: 16, Memory: 65536, Arch: "x86_64"},
 
+	// M6i (GP).
+	"m6i.large":   {VCPU: 2, Memory: 8192, Arch: "x86_64"},
+	"m6i.xlarge":  {VCPU: 4, Memory: 16384, Arch: "x86_64"},
+	"m6i.2xlarge": {VCPU: 8, Memory: 32768, Arch: "x86_64"},
+
 	// M6g (Graviton).
 	"m6g.medium":  {VCPU: 1, Memory: 4096, Arch: "arm64"},
 	"m6g.large":   {VCPU: 2, Memory: 8192, Arch: "arm64"},
@@ -66,6 +71,11 @@ var specsMap = map[string]InstanceSpecs{
 	"c5.xlarge":  {VCPU: 4, Memory: 8192, Arch: "x86_64"},
 	"c5.2xlarge": {VCPU: 8, Memory: 16384, Arch: "x86_64"},
 
+	// C6i (Compute).
+	"c6i.large":   {VCPU: 2, Memory: 4096, Arch: "x86_64"},
+	"c6i.xlarge":  {VCPU: 4, Memory: 8192, Arch: "x86_64"},
+	"c6i.2xlarge": {VCPU: 8, Memory: 16384, Arch: "x86_64"},
+
 	// C6g (Graviton).
 	"c6g.medium":  {VCPU: 1, Memory: 2048, Arch: "arm64"},
 	"c6g.large":   {VCPU: 2, Memory: 4096, Arch: "arm64"},
@@ -76,15 +86,30 @@ var specsMap = map[string]InstanceSpecs{
 	"r5.large":   {VCPU: 2, Memory: 16384, Arch: "x86_64"},
 	"r5.xlarge":  {VCPU: 4, Memory: 32768, Arch: "x86_64"},
 	"r5.2xlarge": {VCPU: 8, Memory: 65536, Arch: "x86_64"},
+
+	// R6i (Memory).
+	"r6i.large":   {VCPU: 2, Memory: 16384, Arch: "x86_64"},
+	"r6i.xlarge":  {VCPU: 4, Memory: 32768, Arch: "x86_64"},
+	"r6i.2xlarge": {VCPU: 8, Memory: 65536, Arch: "x86_64"},
+
+	// R6g (Graviton).
+	"r6g.large":   {VCPU: 2, Memory: 16384, Arch: "arm64"},
+	"r6g.xlarge":  {VCPU: 4, Memory: 32768, Arch: "arm64"},
+	"r6g.2xlarge": {VCPU: 8, Memory: 65536, Arch: "arm64"},
 }
 
-// GetSpecs retrieves instance specifications, falling back to a baseline if unknown.
-func GetSpecs(instanceType string) InstanceSpecs {
+// LookupSpecs retrieves instance specifications; ok is false for a type
+// neither in the static catalog nor synced from a scan.
+func LookupSpecs(instanceType string) (specs InstanceSpecs, ok bool) {
 	specsMu.RLock()
-	specs, ok := specsMap[instanceType]
-	specsMu.RUnlock()
+	defer specsMu.RUnlock()
+	specs, ok = specsMap[instanceType]
+	return specs, ok
+}
 
-	if ok {
+// GetSpecs retrieves instance specifications, falling back to a baseline if unknown.
+func GetSpecs(instanceType string) InstanceSpecs {
+	if specs, ok := LookupSpecs(instanceType); ok {
 		return specs
 	}
 
@@ -42,6 +42,12 @@ func (s *SpyClient) DescribeVolumesModifications(ctx context.Context, params *ec
 func (s *SpyClient) DescribeInstanceTypes(ctx context.Context, params *ec2.DescribeInstanceTypesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInstanceTypesOutput, error) {
 	return &ec2.DescribeInstanceTypesOutput{}, nil
 }
+func (s *SpyClient) DescribeSnapshotAttribute(ctx context.Context, params *ec2.DescribeSnapshotAttributeInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSnapshotAttributeOutput, error) {
+	return &ec2.DescribeSnapshotAttributeOutput{}, nil
+}
+func (s *SpyClient) DescribeImageAttribute(ctx context.Context, params *ec2.DescribeImageAttributeInput, optFns ...func(*ec2.Options)) (*ec2.DescribeImageAttributeOutput, error) {
+	return &ec2.DescribeImageAttributeOutput{}, nil
+}
 
 // Mutating Methods (Trap)
 // Note: These methods are NOT in the EC2Client interface used by EC2Scanner (which is GOOD).
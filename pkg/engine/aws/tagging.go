@@ -0,0 +1,118 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+)
+
+// tagReadBatch is the GetResources limit for ResourceARNList.
+const tagReadBatch = 100
+
+// tagWriteBatch is the TagResources limit for ResourceARNList.
+const tagWriteBatch = 20
+
+// TaggingAPI abstracts the Resource Groups Tagging API.
+type TaggingAPI interface {
+	GetResources(ctx context.Context, params *resourcegroupstaggingapi.GetResourcesInput, optFns ...func(*resourcegroupstaggingapi.Options)) (*resourcegroupstaggingapi.GetResourcesOutput, error)
+	TagResources(ctx context.Context, params *resourcegroupstaggingapi.TagResourcesInput, optFns ...func(*resourcegroupstaggingapi.Options)) (*resourcegroupstaggingapi.TagResourcesOutput, error)
+}
+
+// TaggingClient reads and writes tags across services.
+type TaggingClient struct {
+	Client TaggingAPI
+}
+
+func NewTaggingClient(cfg aws.Config) *TaggingClient {
+	return &TaggingClient{
+		Client: resourcegroupstaggingapi.NewFromConfig(cfg),
+	}
+}
+
+// GetTags returns the current tags of each ARN.
+// ARNs the API does not return (deleted, untaggable, or never tagged) are absent.
+func (c *TaggingClient) GetTags(ctx context.Context, arns []string) (map[string]map[string]string, error) {
+	tags := make(map[string]map[string]string)
+	for start := 0; start < len(arns); start += tagReadBatch {
+		end := start + tagReadBatch
+		if end > len(arns) {
+			end = len(arns)
+		}
+
+		input := &resourcegroupstaggingapi.GetResourcesInput{ResourceARNList: arns[start:end]}
+		for {
+			out, err := c.Client.GetResources(ctx, input)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read tags: %v", err)
+			}
+			for _, m := range out.ResourceTagMappingList {
+				t := make(map[string]string, len(m.Tags))
+				for _, tag := range m.Tags {
+					t[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+				}
+				tags[aws.ToString(m.ResourceARN)] = t
+			}
+			if aws.ToString(out.PaginationToken) == "" {
+				break
+			}
+			input.PaginationToken = out.PaginationToken
+		}
+	}
+	return tags, nil
+}
+
+// TagResources applies tags to each ARN, in batches, and returns why each
+// failed ARN was not tagged. If a call fails outright, only its batch is
+// reported failed; later batches are still tried and err is set.
+func (c *TaggingClient) TagResources(ctx context.Context, arns []string, tags map[string]string) (map[string]string, error) {
+	failed := make(map[string]string)
+	var lastErr error
+	for start := 0; start < len(arns); start += tagWriteBatch {
+		end := start + tagWriteBatch
+		if end > len(arns) {
+			end = len(arns)
+		}
+
+		out, err := c.Client.TagResources(ctx, &resourcegroupstaggingapi.TagResourcesInput{
+			ResourceARNList: arns[start:end],
+			Tags:            tags,
+		})
+		if err != nil {
+			for _, a := range arns[start:end] {
+				failed[a] = err.Error()
+			}
+			lastErr = err
+			continue
+		}
+		for a, info := range out.FailedResourcesMap {
+			msg := aws.ToString(info.ErrorMessage)
+			if msg == "" {
+				msg = string(info.ErrorCode)
+			}
+			failed[a] = msg
+		}
+	}
+	if lastErr != nil {
+		return failed, fmt.Errorf("failed to tag resources: %v", lastErr)
+	}
+	return failed, nil
+}
+
+// ResolveARN fills the "region" and "account" placeholders some scanners use
+// in node IDs (e.g. arn:aws:ec2:region:account:instance/i-123).
+func ResolveARN(arn, region, account string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 {
+		return arn
+	}
+	if parts[3] == "region" {
+		parts[3] = region
+	}
+	if parts[4] == "account" {
+		parts[4] = account
+	}
+	return strings.Join(parts, ":")
+}
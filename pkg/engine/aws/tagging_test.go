@@ -0,0 +1,145 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+)
+
+type fakeTaggingAPI struct {
+	calls  int
+	tags   map[string]map[string]string
+	reject map[string]bool // ARNs TagResources reports as failed.
+	fail   map[string]bool // ARNs whose whole TagResources call errors.
+}
+
+func (f *fakeTaggingAPI) GetResources(ctx context.Context, in *resourcegroupstaggingapi.GetResourcesInput, optFns ...func(*resourcegroupstaggingapi.Options)) (*resourcegroupstaggingapi.GetResourcesOutput, error) {
+	f.calls++
+	if len(in.ResourceARNList) > tagReadBatch {
+		return nil, fmt.Errorf("too many ARNs: %d", len(in.ResourceARNList))
+	}
+	out := &resourcegroupstaggingapi.GetResourcesOutput{}
+	for _, a := range in.ResourceARNList {
+		t, ok := f.tags[a]
+		if !ok {
+			continue
+		}
+		m := types.ResourceTagMapping{ResourceARN: aws.String(a)}
+		for k, v := range t {
+			m.Tags = append(m.Tags, types.Tag{Key: aws.String(k), Value: aws.String(v)})
+		}
+		out.ResourceTagMappingList = append(out.ResourceTagMappingList, m)
+	}
+	return out, nil
+}
+
+func (f *fakeTaggingAPI) TagResources(ctx context.Context, in *resourcegroupstaggingapi.TagResourcesInput, optFns ...func(*resourcegroupstaggingapi.Options)) (*resourcegroupstaggingapi.TagResourcesOutput, error) {
+	f.calls++
+	if len(in.ResourceARNList) > tagWriteBatch {
+		return nil, fmt.Errorf("too many ARNs: %d", len(in.ResourceARNList))
+	}
+	for _, a := range in.ResourceARNList {
+		if f.fail[a] {
+			return nil, fmt.Errorf("throttled")
+		}
+	}
+	out := &resourcegroupstaggingapi.TagResourcesOutput{FailedResourcesMap: map[string]types.FailureInfo{}}
+	for _, a := range in.ResourceARNList {
+		if f.reject[a] {
+			out.FailedResourcesMap[a] = types.FailureInfo{ErrorCode: types.ErrorCodeInvalidParameterException, ErrorMessage: aws.String("tag not supported")}
+			continue
+		}
+		if f.tags[a] == nil {
+			f.tags[a] = map[string]string{}
+		}
+		for k, v := range in.Tags {
+			f.tags[a][k] = v
+		}
+	}
+	return out, nil
+}
+
+func TestTaggingClientTagResources(t *testing.T) {
+	var arns []string
+	for i := 0; i < 45; i++ {
+		arns = append(arns, fmt.Sprintf("arn:aws:ec2:us-east-1:123:volume/vol-%d", i))
+	}
+	fake := &fakeTaggingAPI{tags: map[string]map[string]string{}, reject: map[string]bool{arns[7]: true}}
+
+	failed, err := (&TaggingClient{Client: fake}).TagResources(context.Background(), arns, map[string]string{"cloudslash:ignore": "true"})
+	if err != nil {
+		t.Fatalf("TagResources failed: %v", err)
+	}
+	if fake.calls != 3 {
+		t.Errorf("Expected 3 batched calls, got %d", fake.calls)
+	}
+	if len(failed) != 1 || failed[arns[7]] != "tag not supported" {
+		t.Errorf("Expected one per-resource failure, got %v", failed)
+	}
+	if len(fake.tags) != 44 || fake.tags[arns[44]]["cloudslash:ignore"] != "true" {
+		t.Errorf("Expected 44 tagged resources, got %d", len(fake.tags))
+	}
+}
+
+func TestTaggingClientTagResourcesBatchError(t *testing.T) {
+	var arns []string
+	for i := 0; i < 45; i++ {
+		arns = append(arns, fmt.Sprintf("arn:aws:ec2:us-east-1:123:volume/vol-%d", i))
+	}
+	fake := &fakeTaggingAPI{tags: map[string]map[string]string{}, fail: map[string]bool{arns[25]: true}}
+
+	failed, err := (&TaggingClient{Client: fake}).TagResources(context.Background(), arns, map[string]string{"cloudslash:ignore": "true"})
+	if err == nil {
+		t.Fatal("Expected the failed batch to set err")
+	}
+	if fake.calls != 3 {
+		t.Errorf("Expected later batches to still be tried, got %d calls", fake.calls)
+	}
+	if len(failed) != tagWriteBatch || failed[arns[25]] != "throttled" {
+		t.Errorf("Expected only the failed batch reported, got %d failures", len(failed))
+	}
+	if fake.tags[arns[44]]["cloudslash:ignore"] != "true" {
+		t.Error("Expected the batch after the failure to be tagged")
+	}
+}
+
+func TestTaggingClientGetTags(t *testing.T) {
+	fake := &fakeTaggingAPI{tags: map[string]map[string]string{}}
+	var arns []string
+	for i := 0; i < 150; i++ {
+		a := fmt.Sprintf("arn:aws:ec2:us-east-1:123:volume/vol-%d", i)
+		arns = append(arns, a)
+		if i%2 == 0 {
+			fake.tags[a] = map[string]string{"cloudslash:ignore": "true"}
+		}
+	}
+
+	tags, err := (&TaggingClient{Client: fake}).GetTags(context.Background(), arns)
+	if err != nil {
+		t.Fatalf("GetTags failed: %v", err)
+	}
+	if fake.calls != 2 {
+		t.Errorf("Expected 2 batched calls, got %d", fake.calls)
+	}
+	if len(tags) != 75 {
+		t.Errorf("Expected 75 tagged resources, got %d", len(tags))
+	}
+	if tags[arns[0]]["cloudslash:ignore"] != "true" {
+		t.Errorf("Expected ignore tag on %s", arns[0])
+	}
+}
+
+func TestResolveARN(t *testing.T) {
+	got := ResolveARN("arn:aws:ec2:region:account:instance/i-123", "eu-west-1", "111122223333")
+	if got != "arn:aws:ec2:eu-west-1:111122223333:instance/i-123" {
+		t.Errorf("Unexpected ARN %q", got)
+	}
+	real := "arn:aws:s3:::my-bucket"
+	if got := ResolveARN(real, "eu-west-1", "111122223333"); got != real {
+		t.Errorf("Real ARN should be unchanged, got %q", got)
+	}
+}
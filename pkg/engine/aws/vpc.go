@@ -0,0 +1,82 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+)
+
+// VPCScanner scans VPCs and subnets.
+type VPCScanner struct {
+	Client *ec2.Client
+	Graph  *graph.Graph
+}
+
+// NewVPCScanner initializes a scanner for network containers.
+func NewVPCScanner(cfg aws.Config, g *graph.Graph) *VPCScanner {
+	return &VPCScanner{
+		Client: ec2.NewFromConfig(cfg),
+		Graph:  g,
+	}
+}
+
+// vpcARN matches the synthetic VPC IDs used by Contains edges.
+func vpcARN(vpcID string) string {
+	return fmt.Sprintf("arn:aws:ec2:region:account:vpc/%s", vpcID)
+}
+
+// subnetARN matches the synthetic subnet IDs used by Contains edges.
+func subnetARN(subnetID string) string {
+	return fmt.Sprintf("arn:aws:ec2:region:account:subnet/%s", subnetID)
+}
+
+// ScanVPCs maps VPCs.
+func (s *VPCScanner) ScanVPCs(ctx context.Context) error {
+	paginator := ec2.NewDescribeVpcsPaginator(s.Client, &ec2.DescribeVpcsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to describe vpcs: %v", err)
+		}
+
+		for _, vpc := range page.Vpcs {
+			props := map[string]interface{}{
+				"VpcId":     aws.ToString(vpc.VpcId),
+				"CidrBlock": aws.ToString(vpc.CidrBlock),
+				"IsDefault": aws.ToBool(vpc.IsDefault),
+				"State":     string(vpc.State),
+				"Tags":      parseTags(vpc.Tags),
+			}
+			s.Graph.AddNode(vpcARN(aws.ToString(vpc.VpcId)), "AWS::EC2::VPC", props)
+		}
+	}
+	return nil
+}
+
+// ScanSubnets maps subnets and links them to their VPC.
+func (s *VPCScanner) ScanSubnets(ctx context.Context) error {
+	paginator := ec2.NewDescribeSubnetsPaginator(s.Client, &ec2.DescribeSubnetsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to describe subnets: %v", err)
+		}
+
+		for _, subnet := range page.Subnets {
+			id := subnetARN(aws.ToString(subnet.SubnetId))
+			props := map[string]interface{}{
+				"SubnetId":         aws.ToString(subnet.SubnetId),
+				"VpcId":            aws.ToString(subnet.VpcId),
+				"CidrBlock":        aws.ToString(subnet.CidrBlock),
+				"AvailabilityZone": aws.ToString(subnet.AvailabilityZone),
+				"Tags":             parseTags(subnet.Tags),
+			}
+			s.Graph.AddNode(id, "AWS::EC2::Subnet", props)
+			s.Graph.AddTypedEdge(vpcARN(aws.ToString(subnet.VpcId)), id, graph.EdgeTypeContains, 100)
+		}
+	}
+	return nil
+}
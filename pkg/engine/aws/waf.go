@@ -0,0 +1,173 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2"
+	waftypes "github.com/aws/aws-sdk-go-v2/service/wafv2/types"
+)
+
+// wafRegionalResourceTypes are the resources a regional web ACL can protect.
+var wafRegionalResourceTypes = []waftypes.ResourceType{
+	waftypes.ResourceTypeApplicationLoadBalancer,
+	waftypes.ResourceTypeApiGateway,
+	waftypes.ResourceTypeAppsync,
+	waftypes.ResourceTypeCognitioUserPool,
+	waftypes.ResourceTypeAppRunnerService,
+	waftypes.ResourceTypeVerifiedAccessInstance,
+}
+
+type wafAPI interface {
+	ListWebACLs(ctx context.Context, params *wafv2.ListWebACLsInput, optFns ...func(*wafv2.Options)) (*wafv2.ListWebACLsOutput, error)
+	GetWebACL(ctx context.Context, params *wafv2.GetWebACLInput, optFns ...func(*wafv2.Options)) (*wafv2.GetWebACLOutput, error)
+	ListResourcesForWebACL(ctx context.Context, params *wafv2.ListResourcesForWebACLInput, optFns ...func(*wafv2.Options)) (*wafv2.ListResourcesForWebACLOutput, error)
+}
+
+type webACLDistributionsAPI interface {
+	ListDistributionsByWebACLId(ctx context.Context, params *cloudfront.ListDistributionsByWebACLIdInput, optFns ...func(*cloudfront.Options)) (*cloudfront.ListDistributionsByWebACLIdOutput, error)
+}
+
+// WAFScanner scans WAFv2 web ACLs in the scan region and the CloudFront scope.
+type WAFScanner struct {
+	Client       wafAPI // Regional scope.
+	GlobalClient wafAPI // us-east-1, where CloudFront-scope ACLs live.
+	CloudFront   webACLDistributionsAPI
+	Graph        *graph.Graph
+	Region       string
+}
+
+// NewWAFScanner initializes a scanner for WAFv2.
+func NewWAFScanner(cfg aws.Config, g *graph.Graph) *WAFScanner {
+	globalCfg := cfg.Copy()
+	globalCfg.Region = "us-east-1"
+	return &WAFScanner{
+		Client:       wafv2.NewFromConfig(cfg),
+		GlobalClient: wafv2.NewFromConfig(globalCfg),
+		CloudFront:   cloudfront.NewFromConfig(cfg),
+		Graph:        g,
+		Region:       cfg.Region,
+	}
+}
+
+// ScanWebACLs maps web ACLs as AWS::WAFv2::WebACL nodes with their rule count
+// and protected resources. Load balancers and distributions are linked to
+// their ACL with SecuredBy edges.
+func (s *WAFScanner) ScanWebACLs(ctx context.Context) error {
+	if err := s.scanScope(ctx, s.Client, waftypes.ScopeRegional); err != nil {
+		return err
+	}
+	return s.scanScope(ctx, s.GlobalClient, waftypes.ScopeCloudfront)
+}
+
+func (s *WAFScanner) scanScope(ctx context.Context, client wafAPI, scope waftypes.Scope) error {
+	var marker *string
+	for {
+		page, err := client.ListWebACLs(ctx, &wafv2.ListWebACLsInput{Scope: scope, NextMarker: marker, Limit: aws.Int32(100)})
+		if err != nil {
+			return fmt.Errorf("failed to list %s web ACLs: %v", scope, err)
+		}
+
+		for _, summary := range page.WebACLs {
+			out, err := client.GetWebACL(ctx, &wafv2.GetWebACLInput{Id: summary.Id, Name: summary.Name, Scope: scope})
+			if err != nil || out.WebACL == nil {
+				return fmt.Errorf("failed to get web ACL %s: %v", aws.ToString(summary.Name), err)
+			}
+
+			var resources []string
+			complete := true
+			if scope == waftypes.ScopeCloudfront {
+				resources, err = s.distributionsFor(ctx, aws.ToString(out.WebACL.ARN))
+			} else {
+				resources, err = regionalResourcesFor(ctx, client, aws.ToString(out.WebACL.ARN))
+			}
+			if err != nil {
+				// Unknown is not unassociated.
+				complete = false
+			}
+
+			region := s.Region
+			if scope == waftypes.ScopeCloudfront {
+				region = "global"
+			}
+			id := aws.ToString(out.WebACL.ARN)
+			s.Graph.AddNode(id, "AWS::WAFv2::WebACL", webACLProps(out.WebACL, scope, region, resources, complete))
+			for _, r := range resources {
+				if strings.Contains(r, ":loadbalancer/app/") || strings.Contains(r, ":distribution/") {
+					s.Graph.AddTypedEdge(r, id, graph.EdgeTypeSecuredBy, 100)
+				}
+			}
+		}
+
+		if aws.ToString(page.NextMarker) == "" || len(page.WebACLs) == 0 {
+			return nil
+		}
+		marker = page.NextMarker
+	}
+}
+
+// regionalResourcesFor lists the ARNs of every resource type a regional ACL protects.
+func regionalResourcesFor(ctx context.Context, client wafAPI, aclARN string) ([]string, error) {
+	var arns []string
+	for _, rt := range wafRegionalResourceTypes {
+		out, err := client.ListResourcesForWebACL(ctx, &wafv2.ListResourcesForWebACLInput{WebACLArn: aws.String(aclARN), ResourceType: rt})
+		if err != nil {
+			return arns, err
+		}
+		arns = append(arns, out.ResourceArns...)
+	}
+	return arns, nil
+}
+
+// distributionsFor lists the ARNs of the distributions a CloudFront-scope ACL protects.
+func (s *WAFScanner) distributionsFor(ctx context.Context, aclARN string) ([]string, error) {
+	var arns []string
+	var marker *string
+	for {
+		out, err := s.CloudFront.ListDistributionsByWebACLId(ctx, &cloudfront.ListDistributionsByWebACLIdInput{WebACLId: aws.String(aclARN), Marker: marker})
+		if err != nil {
+			return arns, err
+		}
+		if out.DistributionList == nil {
+			return arns, nil
+		}
+		for _, d := range out.DistributionList.Items {
+			arns = append(arns, aws.ToString(d.ARN))
+		}
+		if !aws.ToBool(out.DistributionList.IsTruncated) {
+			return arns, nil
+		}
+		marker = out.DistributionList.NextMarker
+	}
+}
+
+func webACLProps(acl *waftypes.WebACL, scope waftypes.Scope, region string, resources []string, complete bool) map[string]interface{} {
+	name := aws.ToString(acl.Name)
+	// CloudFront-scope metrics are published in us-east-1 without a Region dimension.
+	dims := []cwtypes.Dimension{{Name: aws.String("WebACL"), Value: aws.String(name)}}
+	if scope == waftypes.ScopeRegional {
+		dims = append(dims, cwtypes.Dimension{Name: aws.String("Region"), Value: aws.String(region)})
+	}
+	dims = append(dims, cwtypes.Dimension{Name: aws.String("Rule"), Value: aws.String("ALL")})
+	if resources == nil {
+		resources = []string{}
+	}
+	return map[string]interface{}{
+		"Name":                     name,
+		"WebACLId":                 aws.ToString(acl.Id),
+		"Scope":                    string(scope),
+		"RuleCount":                len(acl.Rules),
+		"Capacity":                 acl.Capacity,
+		"AssociatedResources":      len(resources),
+		"AssociatedResourceArns":   resources,
+		"AssociationsComplete":     complete,
+		"ManagedByFirewallManager": acl.ManagedByFirewallManager,
+		"Region":                   region,
+		"MetricDimensions":         EncodeMetricDimensions([][]cwtypes.Dimension{dims}),
+	}
+}
@@ -0,0 +1,130 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	cftypes "github.com/aws/aws-sdk-go-v2/service/cloudfront/types"
+	"github.com/aws/aws-sdk-go-v2/service/wafv2"
+	waftypes "github.com/aws/aws-sdk-go-v2/service/wafv2/types"
+)
+
+type fakeWAF struct {
+	acls      []waftypes.WebACL
+	resources map[string][]string // ACL ARN -> ALB ARNs.
+	failARN   string
+}
+
+func (f *fakeWAF) ListWebACLs(ctx context.Context, in *wafv2.ListWebACLsInput, _ ...func(*wafv2.Options)) (*wafv2.ListWebACLsOutput, error) {
+	out := &wafv2.ListWebACLsOutput{}
+	for _, acl := range f.acls {
+		out.WebACLs = append(out.WebACLs, waftypes.WebACLSummary{ARN: acl.ARN, Id: acl.Id, Name: acl.Name})
+	}
+	return out, nil
+}
+
+func (f *fakeWAF) GetWebACL(ctx context.Context, in *wafv2.GetWebACLInput, _ ...func(*wafv2.Options)) (*wafv2.GetWebACLOutput, error) {
+	for _, acl := range f.acls {
+		if aws.ToString(acl.Id) == aws.ToString(in.Id) {
+			return &wafv2.GetWebACLOutput{WebACL: &acl}, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (f *fakeWAF) ListResourcesForWebACL(ctx context.Context, in *wafv2.ListResourcesForWebACLInput, _ ...func(*wafv2.Options)) (*wafv2.ListResourcesForWebACLOutput, error) {
+	if aws.ToString(in.WebACLArn) == f.failARN {
+		return nil, errors.New("AccessDenied")
+	}
+	if in.ResourceType != waftypes.ResourceTypeApplicationLoadBalancer {
+		return &wafv2.ListResourcesForWebACLOutput{}, nil
+	}
+	return &wafv2.ListResourcesForWebACLOutput{ResourceArns: f.resources[aws.ToString(in.WebACLArn)]}, nil
+}
+
+type fakeWebACLDistributions map[string][]string
+
+func (f fakeWebACLDistributions) ListDistributionsByWebACLId(ctx context.Context, in *cloudfront.ListDistributionsByWebACLIdInput, _ ...func(*cloudfront.Options)) (*cloudfront.ListDistributionsByWebACLIdOutput, error) {
+	list := &cftypes.DistributionList{IsTruncated: aws.Bool(false)}
+	for _, arn := range f[aws.ToString(in.WebACLId)] {
+		list.Items = append(list.Items, cftypes.DistributionSummary{ARN: aws.String(arn)})
+	}
+	return &cloudfront.ListDistributionsByWebACLIdOutput{DistributionList: list}, nil
+}
+
+func TestScanWebACLs(t *testing.T) {
+	webACL := func(scope, name string, rules int) waftypes.WebACL {
+		return waftypes.WebACL{
+			ARN:   aws.String("arn:aws:wafv2:us-east-1:123:" + scope + "/webacl/" + name + "/id-" + name),
+			Id:    aws.String("id-" + name),
+			Name:  aws.String(name),
+			Rules: make([]waftypes.Rule, rules),
+		}
+	}
+	albARN := "arn:aws:elasticloadbalancing:us-east-1:123:loadbalancer/app/web/abc"
+	cfARN := "arn:aws:cloudfront::123:distribution/E123"
+
+	protected := webACL("regional", "protected", 3)
+	stale := webACL("regional", "stale", 2)
+	denied := webACL("regional", "denied", 1)
+	edge := webACL("global", "edge", 5)
+
+	g := graph.NewGraph()
+	s := &WAFScanner{
+		Client: &fakeWAF{
+			acls:      []waftypes.WebACL{protected, stale, denied},
+			resources: map[string][]string{aws.ToString(protected.ARN): {albARN}},
+			failARN:   aws.ToString(denied.ARN),
+		},
+		GlobalClient: &fakeWAF{acls: []waftypes.WebACL{edge}},
+		CloudFront:   fakeWebACLDistributions{aws.ToString(edge.ARN): {cfARN}},
+		Graph:        g,
+		Region:       "us-east-1",
+	}
+	if err := s.ScanWebACLs(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	g.CloseAndWait()
+
+	node := g.GetNode(aws.ToString(protected.ARN))
+	if node == nil || node.TypeStr() != "AWS::WAFv2::WebACL" {
+		t.Fatalf("Expected a web ACL node, got %v", node)
+	}
+	if node.Properties["RuleCount"] != 3 || node.Properties["AssociatedResources"] != 1 {
+		t.Errorf("protected ACL: %v", node.Properties)
+	}
+	if dims, _ := node.Properties["MetricDimensions"].([]string); len(dims) != 1 || dims[0] != "WebACL=protected,Region=us-east-1,Rule=ALL" {
+		t.Errorf("Unexpected metric dimensions %v", dims)
+	}
+
+	if n := g.GetNode(aws.ToString(stale.ARN)); n.Properties["AssociatedResources"] != 0 || n.Properties["AssociationsComplete"] != true {
+		t.Errorf("stale ACL: %v", n.Properties)
+	}
+	if complete, _ := g.GetNode(aws.ToString(denied.ARN)).Properties["AssociationsComplete"].(bool); complete {
+		t.Error("Expected a failed association lookup to leave associations incomplete")
+	}
+
+	global := g.GetNode(aws.ToString(edge.ARN))
+	if global.Properties["Region"] != "global" || global.Properties["AssociatedResources"] != 1 {
+		t.Errorf("CloudFront ACL: %v", global.Properties)
+	}
+	if dims, _ := global.Properties["MetricDimensions"].([]string); len(dims) != 1 || dims[0] != "WebACL=edge,Rule=ALL" {
+		t.Errorf("Unexpected CloudFront metric dimensions %v", dims)
+	}
+
+	for resource, acl := range map[string]string{albARN: aws.ToString(protected.ARN), cfARN: aws.ToString(edge.ARN)} {
+		found := false
+		for _, e := range g.GetEdges(g.GetNode(resource).Index) {
+			if target := g.GetNodeByID(e.TargetID); target != nil && target.IDStr() == acl && e.Type == graph.EdgeTypeSecuredBy {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected %s SecuredBy %s", resource, acl)
+		}
+	}
+}
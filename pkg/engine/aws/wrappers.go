@@ -99,6 +99,26 @@ func (s *S3ScannerWrapper) Scan(ctx context.Context, g *graph.Graph) error {
 	return s.Scanner.ScanBuckets(ctx)
 }
 
+// CloudFrontScannerWrapper implements Scanner for ScanDistributions.
+type CloudFrontScannerWrapper struct {
+	Scanner *CloudFrontScanner
+}
+
+func (s *CloudFrontScannerWrapper) Name() string { return "ScanCloudFrontDistributions" }
+func (s *CloudFrontScannerWrapper) Scan(ctx context.Context, g *graph.Graph) error {
+	return s.Scanner.ScanDistributions(ctx)
+}
+
+// WAFScannerWrapper implements Scanner for ScanWebACLs.
+type WAFScannerWrapper struct {
+	Scanner *WAFScanner
+}
+
+func (s *WAFScannerWrapper) Name() string { return "ScanWAFWebACLs" }
+func (s *WAFScannerWrapper) Scan(ctx context.Context, g *graph.Graph) error {
+	return s.Scanner.ScanWebACLs(ctx)
+}
+
 // RDSScannerWrapper implements Scanner for ScanInstances.
 type RDSScannerWrapper struct {
 	Scanner *RDSScanner
@@ -149,6 +169,16 @@ func (s *RedshiftScannerWrapper) Scan(ctx context.Context, g *graph.Graph) error
 	return s.Scanner.ScanClusters(ctx)
 }
 
+// KMSScannerWrapper implements Scanner for ScanKeys.
+type KMSScannerWrapper struct {
+	Scanner *KMSScanner
+}
+
+func (s *KMSScannerWrapper) Name() string { return "ScanKMSKeys" }
+func (s *KMSScannerWrapper) Scan(ctx context.Context, g *graph.Graph) error {
+	return s.Scanner.ScanKeys(ctx)
+}
+
 // DynamoDBScannerWrapper implements Scanner for ScanTables.
 type DynamoDBScannerWrapper struct {
 	Scanner *DynamoDBScanner
@@ -168,3 +198,113 @@ func (s *LambdaScannerWrapper) Name() string { return "ScanLambdaFunctions" }
 func (s *LambdaScannerWrapper) Scan(ctx context.Context, g *graph.Graph) error {
 	return s.Scanner.ScanFunctions(ctx)
 }
+
+// VPCScannerWrapper implements Scanner for ScanVPCs.
+type VPCScannerWrapper struct {
+	Scanner *VPCScanner
+}
+
+func (s *VPCScannerWrapper) Name() string { return "ScanVPCs" }
+func (s *VPCScannerWrapper) Scan(ctx context.Context, g *graph.Graph) error {
+	return s.Scanner.ScanVPCs(ctx)
+}
+
+// SubnetScannerWrapper implements Scanner for ScanSubnets.
+type SubnetScannerWrapper struct {
+	Scanner *VPCScanner
+}
+
+func (s *SubnetScannerWrapper) Name() string { return "ScanSubnets" }
+func (s *SubnetScannerWrapper) Scan(ctx context.Context, g *graph.Graph) error {
+	return s.Scanner.ScanSubnets(ctx)
+}
+
+// CodeBuildScannerWrapper implements Scanner for ScanProjects.
+type CodeBuildScannerWrapper struct {
+	Scanner *CICDScanner
+}
+
+func (s *CodeBuildScannerWrapper) Name() string { return "ScanCodeBuildProjects" }
+func (s *CodeBuildScannerWrapper) Scan(ctx context.Context, g *graph.Graph) error {
+	return s.Scanner.ScanProjects(ctx)
+}
+
+// CodePipelineScannerWrapper implements Scanner for ScanPipelines.
+type CodePipelineScannerWrapper struct {
+	Scanner *CICDScanner
+}
+
+func (s *CodePipelineScannerWrapper) Name() string { return "ScanCodePipelines" }
+func (s *CodePipelineScannerWrapper) Scan(ctx context.Context, g *graph.Graph) error {
+	return s.Scanner.ScanPipelines(ctx)
+}
+
+// EFSScannerWrapper implements Scanner for ScanFileSystems.
+type EFSScannerWrapper struct {
+	Scanner *EFSScanner
+}
+
+func (s *EFSScannerWrapper) Name() string { return "ScanEFSFileSystems" }
+func (s *EFSScannerWrapper) Scan(ctx context.Context, g *graph.Graph) error {
+	return s.Scanner.ScanFileSystems(ctx)
+}
+
+// MLEndpointScannerWrapper implements Scanner for ScanEndpoints.
+type MLEndpointScannerWrapper struct {
+	Scanner *MLEndpointScanner
+}
+
+func (s *MLEndpointScannerWrapper) Name() string { return "ScanMLEndpoints" }
+func (s *MLEndpointScannerWrapper) Scan(ctx context.Context, g *graph.Graph) error {
+	return s.Scanner.ScanEndpoints(ctx)
+}
+
+// DMSScannerWrapper implements Scanner for ScanReplicationInstances.
+type DMSScannerWrapper struct {
+	Scanner *DMSScanner
+}
+
+func (s *DMSScannerWrapper) Name() string { return "ScanDMSReplicationInstances" }
+func (s *DMSScannerWrapper) Scan(ctx context.Context, g *graph.Graph) error {
+	return s.Scanner.ScanReplicationInstances(ctx)
+}
+
+// OpenSearchScannerWrapper implements Scanner for ScanDomains.
+type OpenSearchScannerWrapper struct {
+	Scanner *OpenSearchScanner
+}
+
+func (s *OpenSearchScannerWrapper) Name() string { return "ScanOpenSearchDomains" }
+func (s *OpenSearchScannerWrapper) Scan(ctx context.Context, g *graph.Graph) error {
+	return s.Scanner.ScanDomains(ctx)
+}
+
+// Route53ScannerWrapper implements Scanner for ScanRecords.
+type Route53ScannerWrapper struct {
+	Scanner *Route53Scanner
+}
+
+func (s *Route53ScannerWrapper) Name() string { return "ScanRoute53Records" }
+func (s *Route53ScannerWrapper) Scan(ctx context.Context, g *graph.Graph) error {
+	return s.Scanner.ScanRecords(ctx)
+}
+
+// SQSScannerWrapper implements Scanner for ScanQueues.
+type SQSScannerWrapper struct {
+	Scanner *MessagingScanner
+}
+
+func (s *SQSScannerWrapper) Name() string { return "ScanSQSQueues" }
+func (s *SQSScannerWrapper) Scan(ctx context.Context, g *graph.Graph) error {
+	return s.Scanner.ScanQueues(ctx)
+}
+
+// SNSScannerWrapper implements Scanner for ScanTopics.
+type SNSScannerWrapper struct {
+	Scanner *MessagingScanner
+}
+
+func (s *SNSScannerWrapper) Name() string { return "ScanSNSTopics" }
+func (s *SNSScannerWrapper) Scan(ctx context.Context, g *graph.Graph) error {
+	return s.Scanner.ScanTopics(ctx)
+}
@@ -0,0 +1,123 @@
+package engine
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// reconcileBilledCosts replaces pricing estimates with the cost Cost Explorer
+// actually billed, where it has resource-level data. Findings it cannot match
+// keep their estimate.
+func (e *Engine) reconcileBilledCosts(ctx context.Context, ce *aws.CostExplorerClient) {
+	now := time.Now()
+	services, err := ce.GetServiceCosts(ctx, now)
+	if err != nil {
+		e.Logger.Warn("Cost Explorer unavailable; keeping estimated costs", "error", err)
+		return
+	}
+	var names []string
+	var total float64
+	for name, cost := range services {
+		if cost > 0 {
+			names = append(names, name)
+			total += cost
+		}
+	}
+	sort.Strings(names)
+	e.Logger.Info("Billed spend last month", "total", total, "services", len(names))
+
+	resources, err := ce.GetResourceCosts(ctx, now, names)
+	if err != nil {
+		e.Logger.Warn("Resource-level cost data unavailable (enable it in Cost Explorer preferences); keeping estimated costs", "error", err)
+		return
+	}
+	matched, estimated, billed := applyBilledCosts(e.Graph, resources)
+	e.Logger.Info("Reconciled findings with billed cost", "matched", matched, "estimated", estimated, "billed", billed)
+}
+
+// applyBilledCosts records BilledCost on every node Cost Explorer has a cost
+// for. Findings that price the whole resource take the billed cost as their
+// Cost, keeping the estimate as EstimatedCost; right-sizing findings price a
+// saving, not the resource, and keep it. Returns the number of findings
+// matched and their estimated and billed totals.
+func applyBilledCosts(g *graph.Graph, billed map[string]float64) (int, float64, float64) {
+	short := make(map[string]float64)
+	ambiguous := make(map[string]bool)
+	for id, cost := range billed {
+		s := shortResourceID(id)
+		if _, dup := short[s]; dup {
+			ambiguous[s] = true
+		}
+		short[s] = cost
+	}
+	lookup := func(id string) (float64, bool) {
+		if cost, ok := billed[id]; ok {
+			return cost, true
+		}
+		s := shortResourceID(id)
+		if ambiguous[s] {
+			return 0, false
+		}
+		cost, ok := short[s]
+		return cost, ok
+	}
+
+	var matched int
+	var estimated, actual float64
+	g.Mu.Lock()
+	defer g.Mu.Unlock()
+	for _, node := range g.Store.GetAllNodes() {
+		if !isAWSType(node.TypeStr()) {
+			continue
+		}
+		cost, ok := lookup(node.IDStr())
+		if !ok {
+			continue
+		}
+		node.Properties["BilledCost"] = cost
+		if !node.IsWaste || pricesSaving(node) {
+			continue
+		}
+		if _, done := node.Properties["EstimatedCost"]; !done {
+			node.Properties["EstimatedCost"] = node.Cost
+		}
+		estimated += node.Properties["EstimatedCost"].(float64)
+		actual += cost
+		node.Cost = cost
+		matched++
+	}
+	return matched, estimated, actual
+}
+
+// pricesSaving reports whether a finding's cost is the saving of a smaller
+// configuration rather than the resource's own cost.
+func pricesSaving(node *graph.Node) bool {
+	for key := range node.Properties {
+		if strings.HasPrefix(key, "Recommended") {
+			return true
+		}
+	}
+	return false
+}
+
+// shortResourceID reduces an ARN to its final resource name, so instance and
+// volume IDs, bucket names and ARNs compare equal whichever form each side uses.
+func shortResourceID(id string) string {
+	if !strings.HasPrefix(id, "arn:") {
+		return id
+	}
+	parts := strings.SplitN(id, ":", 6)
+	if len(parts) < 6 {
+		return id
+	}
+	resource := parts[5]
+	if i := strings.LastIndexAny(resource, "/:"); i >= 0 {
+		resource = resource[i+1:]
+	}
+	return resource
+}
@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+func TestApplyBilledCosts(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:ec2:us-east-1:123456789012:instance/i-0abc", "AWS::EC2::Instance", map[string]interface{}{})
+	g.AddNode("arn:aws:s3:::logs", "AWS::S3::Bucket", map[string]interface{}{})
+	g.AddNode("arn:aws:elasticache:us-east-1:123456789012:cluster:cache", "aws_elasticache_cluster", map[string]interface{}{"RecommendedNodeType": "cache.t4g.small"})
+	g.AddNode("arn:aws:ec2:us-east-1:123456789012:volume/vol-1", "AWS::EC2::Volume", map[string]interface{}{})
+	g.AddNode("arn:aws:lambda:us-east-1:123456789012:function:worker", "AWS::Lambda::Function", map[string]interface{}{})
+	g.CloseAndWait()
+	for id, cost := range map[string]float64{
+		"arn:aws:ec2:us-east-1:123456789012:instance/i-0abc": 70,
+		"arn:aws:s3:::logs": 5,
+		"arn:aws:elasticache:us-east-1:123456789012:cluster:cache": 40,
+		"arn:aws:lambda:us-east-1:123456789012:function:worker":    3,
+	} {
+		g.MarkWaste(id, 80)
+		g.GetNode(id).Cost = cost
+	}
+
+	matched, estimated, billed := applyBilledCosts(g, map[string]float64{
+		"i-0abc": 55.5,
+		"logs":   7,
+		"arn:aws:elasticache:us-east-1:123456789012:cluster:cache": 180,
+		"vol-1": 8,
+		// The same function name in two regions cannot be told apart.
+		"arn:aws:lambda:eu-west-1:123456789012:function:worker": 9,
+		"arn:aws:lambda:us-west-2:123456789012:function:worker": 10,
+	})
+
+	if matched != 2 || estimated != 75 || billed != 62.5 {
+		t.Errorf("applyBilledCosts = %d, %.2f, %.2f; want 2, 75, 62.5", matched, estimated, billed)
+	}
+	instance := g.GetNode("arn:aws:ec2:us-east-1:123456789012:instance/i-0abc")
+	if instance.Cost != 55.5 || instance.Properties["EstimatedCost"] != 70.0 {
+		t.Errorf("Expected the instance billed at 55.5 with its estimate kept, got %v / %v", instance.Cost, instance.Properties["EstimatedCost"])
+	}
+	if g.GetNode("arn:aws:s3:::logs").Cost != 7 {
+		t.Error("Expected the bucket matched by name")
+	}
+	cache := g.GetNode("arn:aws:elasticache:us-east-1:123456789012:cluster:cache")
+	if cache.Cost != 40 || cache.Properties["BilledCost"] != 180.0 {
+		t.Errorf("Expected a right-sizing saving kept, with the billed cost recorded; got %v / %v", cache.Cost, cache.Properties["BilledCost"])
+	}
+	volume := g.GetNode("arn:aws:ec2:us-east-1:123456789012:volume/vol-1")
+	if volume.Cost != 0 || volume.Properties["BilledCost"] != 8.0 {
+		t.Errorf("Expected a non-waste node annotated only, got %v / %v", volume.Cost, volume.Properties["BilledCost"])
+	}
+	if fn := g.GetNode("arn:aws:lambda:us-east-1:123456789012:function:worker"); fn.Cost != 3 {
+		t.Errorf("Expected an ambiguous name left unmatched, got %v", fn.Cost)
+	}
+}
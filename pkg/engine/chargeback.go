@@ -0,0 +1,37 @@
+package engine
+
+import (
+	"path/filepath"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/report"
+)
+
+// writeChargeback emits chargeback.csv and reports unattributable waste.
+// Waste without a cost-center tag cannot be charged back, which is a
+// governance gap in its own right.
+func (e *Engine) writeChargeback() {
+	rows := report.Chargeback(e.Graph, e.config.CostCenterTag)
+	path := filepath.Join(e.outputDir, "chargeback.csv")
+	if err := report.GenerateChargebackCSV(rows, path); err != nil {
+		e.Logger.Error("Failed to generate chargeback CSV", "error", err)
+		return
+	}
+
+	var total float64
+	for _, row := range rows {
+		total += row.MonthlyWaste
+	}
+	for _, row := range rows {
+		if row.CostCenter != report.UntaggedCostCenter {
+			continue
+		}
+		share := 0.0
+		if total > 0 {
+			share = row.MonthlyWaste / total * 100
+		}
+		e.Logger.Warn("Waste cannot be charged back: missing cost-center tag",
+			"tag", e.config.CostCenterTag, "resources", row.ResourceCount,
+			"monthly", row.MonthlyWaste, "share_pct", share)
+	}
+	e.Logger.Info("Chargeback report generated", "path", path, "cost_centers", len(rows))
+}
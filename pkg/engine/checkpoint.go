@@ -0,0 +1,243 @@
+package engine
+
+import (
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/DrSkyle/cloudslash/v2/pkg/resource"
+)
+
+// checkpointDir holds per-scope scan results for --resume.
+const checkpointDir = ".cloudslash/checkpoint"
+
+func init() {
+	gob.Register(&resource.EC2Instance{})
+}
+
+var unsafeScopeChars = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+// checkpointProgress is the progress marker written after each scope.
+type checkpointProgress struct {
+	Completed map[string]time.Time `json:"completed"`
+}
+
+// checkpointer persists each (profile, region) scope once its scanners finish,
+// so an interrupted scan can skip completed scopes on the next run.
+type checkpointer struct {
+	dir string
+
+	// qualify prefixes non-ARN IDs with each scope's account, so the same
+	// name in two accounts stays two nodes.
+	qualify bool
+
+	mu       sync.Mutex
+	progress checkpointProgress
+	scopes   map[string]*graph.Graph // Scopes scanned in this run.
+	accounts map[string]string       // Account of each scope in scopes.
+}
+
+// newCheckpointer prepares the checkpoint directory.
+// Without resume, any previous checkpoint is discarded. An empty dir keeps
+// scopes in memory only, which multi-account scans use to attribute each
+// scope's resources to its account.
+func newCheckpointer(dir string, resume bool) (*checkpointer, error) {
+	if dir == "" {
+		return &checkpointer{
+			progress: checkpointProgress{Completed: make(map[string]time.Time)},
+			scopes:   make(map[string]*graph.Graph),
+			accounts: make(map[string]string),
+		}, nil
+	}
+	if !resume {
+		if err := os.RemoveAll(dir); err != nil {
+			return nil, fmt.Errorf("failed to clear checkpoint: %v", err)
+		}
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint dir: %v", err)
+	}
+
+	c := &checkpointer{
+		dir:      dir,
+		progress: checkpointProgress{Completed: make(map[string]time.Time)},
+		scopes:   make(map[string]*graph.Graph),
+		accounts: make(map[string]string),
+	}
+	if resume {
+		data, err := os.ReadFile(filepath.Join(dir, "progress.json"))
+		if err == nil {
+			if err := json.Unmarshal(data, &c.progress); err != nil {
+				return nil, fmt.Errorf("failed to parse checkpoint progress: %v", err)
+			}
+			if c.progress.Completed == nil {
+				c.progress.Completed = make(map[string]time.Time)
+			}
+		}
+	}
+	return c, nil
+}
+
+// scopeKey names a scope on disk.
+func scopeKey(profile, region string) string {
+	if profile == "" {
+		profile = "default"
+	}
+	return unsafeScopeChars.ReplaceAllString(profile+"_"+region, "-")
+}
+
+// Completed reports whether a scope finished in an earlier run.
+func (c *checkpointer) Completed(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.progress.Completed[key]
+	return ok
+}
+
+// Load reads a completed scope's graph.
+func (c *checkpointer) Load(key string) (*graph.Snapshot, error) {
+	f, err := os.Open(filepath.Join(c.dir, key+".gob"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open checkpoint: %v", err)
+	}
+	defer f.Close()
+	return graph.DecodeSnapshot(f)
+}
+
+// Save writes a scope's graph, then marks the scope complete.
+func (c *checkpointer) Save(key string, s *graph.Snapshot) error {
+	if c.dir == "" {
+		return nil
+	}
+	path := filepath.Join(c.dir, key+".gob")
+	if err := writeAtomic(path, func(f *os.File) error { return s.Encode(f) }); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.progress.Completed[key] = time.Now()
+	return writeAtomic(filepath.Join(c.dir, "progress.json"), func(f *os.File) error {
+		return json.NewEncoder(f).Encode(c.progress)
+	})
+}
+
+// writeAtomic writes via a temp file so a crash never leaves a torn checkpoint.
+func writeAtomic(path string, write func(*os.File) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write checkpoint: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %v", err)
+	}
+	return nil
+}
+
+// scanScopeWithCheckpoint restores a completed scope, or scans it into its own
+// graph and checkpoints that graph once the scope's scanners finish. Nodes are
+// stamped with the scope's account before they reach the live graph.
+// The returned client is used by the analysis phases either way.
+func (e *Engine) scanScopeWithCheckpoint(ctx context.Context, cp *checkpointer, region string, target scanTarget, scanWg *sync.WaitGroup) (*aws.Client, error) {
+	key := scopeKey(target.String(), region)
+
+	if cp.Completed(key) {
+		snap, err := cp.Load(key)
+		if err == nil {
+			e.Logger.Info("Resuming scope from checkpoint", "target", target.String(), "region", region, "nodes", len(snap.Nodes))
+			if err := e.Graph.Merge(snap); err != nil {
+				return nil, err
+			}
+			return e.connectScope(ctx, region, target)
+		}
+		e.Logger.Warn("Checkpoint unreadable, rescanning scope", "target", target.String(), "region", region, "error", err)
+	}
+
+	scopeGraph := graph.NewGraph()
+	var scopeWg sync.WaitGroup
+	client, err := runScanForProfile(ctx, region, target, e.config.Verbose, e.config.IncludeMessaging, e.config.Compliance, e.config.Heuristics.MetricWindow, e.scopes.skippedScanners(), scopeGraph, e.Swarm, &scopeWg)
+	if err != nil {
+		return nil, err
+	}
+
+	scanWg.Add(1)
+	go func() {
+		defer scanWg.Done()
+		scopeWg.Wait()
+		scopeGraph.CloseAndWait()
+		stampAccount(scopeGraph, client.AccountID)
+
+		snap := scopeGraph.Snapshot()
+		if cp.qualify {
+			snap.Qualify(client.AccountID)
+		}
+		if err := e.Graph.Merge(snap); err != nil {
+			e.Logger.Error("Failed to merge scope", "target", target.String(), "region", region, "error", err)
+			return
+		}
+		cp.mu.Lock()
+		cp.scopes[key] = scopeGraph
+		cp.accounts[key] = client.AccountID
+		cp.mu.Unlock()
+
+		if err := cp.Save(key, snap); err != nil {
+			// The scan continues; this scope is simply rescanned on resume.
+			e.Logger.Warn("Failed to checkpoint scope", "target", target.String(), "region", region, "error", err)
+			return
+		}
+		if cp.dir != "" {
+			e.Logger.Info("Scope checkpointed", "target", target.String(), "region", region, "nodes", len(snap.Nodes))
+		}
+	}()
+
+	return client, nil
+}
+
+// Refresh folds late metric enrichment into the live graph and checkpoints.
+// Some scanners keep annotating nodes in the background after Scan returns.
+func (c *checkpointer) Refresh(g *graph.Graph) {
+	c.mu.Lock()
+	scopes := make(map[string]*graph.Graph, len(c.scopes))
+	accounts := make(map[string]string, len(c.accounts))
+	for k, sg := range c.scopes {
+		scopes[k] = sg
+		accounts[k] = c.accounts[k]
+	}
+	c.mu.Unlock()
+
+	for key, sg := range scopes {
+		snap := sg.Snapshot()
+		if c.qualify {
+			snap.Qualify(accounts[key])
+		}
+
+		g.Mu.Lock()
+		for _, n := range snap.Nodes {
+			if live := g.Store.GetNodeByStringID(n.ID); live != nil {
+				for k, v := range n.Properties {
+					live.Properties[k] = v
+				}
+			}
+		}
+		g.Mu.Unlock()
+
+		_ = c.Save(key, snap)
+	}
+}
@@ -0,0 +1,70 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/DrSkyle/cloudslash/v2/pkg/resource"
+)
+
+func TestCheckpointResume(t *testing.T) {
+	dir := t.TempDir()
+
+	src := graph.NewGraph()
+	src.AddTypedNode("arn:aws:ec2:us-east-1:123:instance/i-1", "AWS::EC2::Instance",
+		map[string]interface{}{"State": "running"},
+		&resource.EC2Instance{State: "running", LaunchTime: time.Now().UTC()})
+	src.CloseAndWait()
+
+	cp, err := newCheckpointer(dir, false)
+	if err != nil {
+		t.Fatalf("newCheckpointer failed: %v", err)
+	}
+	key := scopeKey("", "us-east-1")
+	if err := cp.Save(key, src.Snapshot()); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// A resumed run sees the completed scope.
+	resumed, err := newCheckpointer(dir, true)
+	if err != nil {
+		t.Fatalf("newCheckpointer (resume) failed: %v", err)
+	}
+	if !resumed.Completed(key) {
+		t.Fatal("Expected scope to be marked complete")
+	}
+	if resumed.Completed(scopeKey("prod", "eu-west-1")) {
+		t.Error("Unscanned scope should not be complete")
+	}
+
+	snap, err := resumed.Load(key)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(snap.Nodes) != 1 {
+		t.Fatalf("Expected 1 node, got %d", len(snap.Nodes))
+	}
+	// CEL binds the pointer type; it must survive the round trip.
+	if _, ok := snap.Nodes[0].TypedData.(*resource.EC2Instance); !ok {
+		t.Errorf("Expected *resource.EC2Instance, got %T", snap.Nodes[0].TypedData)
+	}
+
+	// A fresh run discards the old checkpoint.
+	fresh, err := newCheckpointer(dir, false)
+	if err != nil {
+		t.Fatalf("newCheckpointer (fresh) failed: %v", err)
+	}
+	if fresh.Completed(key) {
+		t.Error("Expected a non-resume run to discard the checkpoint")
+	}
+}
+
+func TestScopeKey(t *testing.T) {
+	if got := scopeKey("", "us-east-1"); got != "default_us-east-1" {
+		t.Errorf("Unexpected key %q", got)
+	}
+	if got := scopeKey("org/acct 1", "eu-west-1"); got != "org-acct-1_eu-west-1" {
+		t.Errorf("Expected path-safe key, got %q", got)
+	}
+}
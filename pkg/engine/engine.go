@@ -8,11 +8,14 @@ import (
 	"runtime/debug"
 	"strings"
 	"errors"
+	"time"
 
 	internalconfig "github.com/DrSkyle/cloudslash/v2/pkg/config"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/heuristics"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/history"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/notifier"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/pricing"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/report"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/swarm"
 	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
 	"github.com/DrSkyle/cloudslash/v2/pkg/telemetry"
@@ -26,15 +29,28 @@ import (
 // ErrPartialResult indicates the scan completed but some resources were skipped due to API errors.
 var ErrPartialResult = errors.New("scan completed with partial results")
 
+// ErrInterrupted indicates the run context was cancelled (e.g. Ctrl-C) before the scan finished.
+var ErrInterrupted = errors.New("scan interrupted")
+
+// shutdownTimeout bounds how long an interrupted run waits for in-flight tasks.
+const shutdownTimeout = 10 * time.Second
+
 // Config holds engine settings.
 type Config struct {
 	Region           string
 	TFStatePath      string
+	IaC              string // "terraform", "pulumi", or "" to auto-detect
+	PulumiStatePath  string // `pulumi stack export` output; empty runs the pulumi CLI
 	MockMode         bool
 	AllProfiles      bool
 	RequiredTags     string
 	SlackWebhook     string
 	SlackChannel     string
+	SlackToken       string
+	TeamsWebhook     string
+	DiscordWebhook   string
+	DatadogAPIKey    string
+	DatadogSite      string // Default notifier.DefaultDatadogSite.
 	Headless         bool
 	DisableCWMetrics bool
 	Verbose          bool
@@ -48,8 +64,127 @@ type Config struct {
 	// StrictMode forces a non-zero exit code on partial failures.
 	StrictMode bool
 
+	// SankeyJSON writes the topology Sankey data as a standalone artifact.
+	SankeyJSON bool
+
+	// FOCUS writes findings in the FinOps FOCUS 1.0 format (focus_report.csv).
+	FOCUS bool
+
+	// Diff prints waste added and resolved since the previous stored snapshot.
+	Diff bool
+
+	// PlanOnly runs scanners and heuristics and reports the summary, but writes
+	// no artifacts and never creates the output directory.
+	PlanOnly bool
+
+	// MetricsFile writes waste totals in Prometheus text format (e.g. for the node_exporter textfile collector).
+	MetricsFile string
+
+	// SummaryTemplate selects a built-in summary ("executive", "technical") or a template file.
+	SummaryTemplate string
+
+	// Report sets the risk thresholds the HTML reports grade findings by.
+	// Zero values take report.DefaultReportConfig.
+	Report report.ReportConfig
+
+	// Stream prints findings as heuristics complete (headless only).
+	Stream        bool
+	StreamWebhook string // NDJSON endpoint receiving findings as they are discovered
+
+	// ProtectCFN routes CloudFormation-managed findings to review instead of deletion.
+	ProtectCFN bool
+
+	// Checkpoint persists each completed (profile, region) scope; Resume skips
+	// scopes completed by an earlier, interrupted run.
+	Checkpoint bool
+	Resume     bool
+
+	// CacheGraph reuses the graph of an identical scan finished within
+	// CacheGraphTTL (default DefaultGraphCacheTTL) instead of rescanning, and
+	// caches this scan's graph otherwise. Analysis always runs afresh.
+	CacheGraph    bool
+	CacheGraphTTL time.Duration
+
+	// AssumeRolesFile lists role ARNs or account IDs, one per line, to scan by
+	// assuming each role. Org scans every active account of the organization
+	// instead. Bare account IDs and Org use OrgRole (default
+	// OrganizationAccountAccessRole).
+	AssumeRolesFile string
+	Org             bool
+	OrgRole         string
+
+	// CostExplorer replaces estimated finding costs with the cost Cost Explorer
+	// billed, where resource-level data is available.
+	CostExplorer bool
+
+	// CheckPolicy simulates deletes and flags findings blocked by SCPs or permissions boundaries.
+	CheckPolicy          bool
+	RemediationPrincipal string // IAM ARN to simulate as (default: scanning identity)
+
+	// ComputeOptimizer cross-checks right-sizing findings against AWS Compute Optimizer.
+	ComputeOptimizer bool
+
+	// IncludeMessaging scans SQS queues and SNS topics and flags idle ones.
+	// Off by default: they cost little and exist in large numbers.
+	IncludeMessaging bool
+
+	// Compliance reads encryption status and flags unencrypted EBS volumes,
+	// RDS instances and S3 buckets as compliance violations, apart from waste.
+	Compliance bool
+
+	// CostCenterTag is the tag key used to attribute waste in chargeback.csv.
+	CostCenterTag string
+
+	// FlowLogsGroup is a VPC Flow Logs log group used for cross-AZ transfer analysis.
+	FlowLogsGroup string
+
+	// DeprecationsFile extends or overrides the built-in deprecated service list.
+	DeprecationsFile string
+
+	// DisableTrailCache turns off the per-run CloudTrail lookup cache.
+	DisableTrailCache bool
+
+	// EnvTag is the tag key holding the environment (e.g. "Environment").
+	// Production findings are forced to manual review; sandbox ones are suggested for cleanup first.
+	EnvTag string
+
+	// CostAllocationTags lists the activated cost-allocation tag keys (comma-separated).
+	// Cost-bearing resources missing any of them are reported as unattributable spend.
+	CostAllocationTags string
+
+	// Provider selects the clouds to scan (comma-separated: "aws", "gcp", "azure"). Empty means aws.
+	Provider string
+
+	// GCPProject is the GCP project to scan. Empty falls back to the ADC project.
+	GCPProject string
+
+	// AzureSubscription is the Azure subscription to scan. Empty falls back to AZURE_SUBSCRIPTION_ID.
+	AzureSubscription string
+
+	// CommitmentCoverageFile describes Savings Plan and Reserved Instance coverage
+	// (see solver.LoadCoverageModel). The solver then reports commitment-adjusted savings.
+	CommitmentCoverageFile string
+
+	// AllowSpot lets the solver move instances tagged
+	// cloudslash:interruption-tolerant=true to spot capacity.
+	AllowSpot bool
+
+	// DisabledHeuristics lists heuristics to skip, by Name().
+	DisabledHeuristics []string
+
+	// OnlyResources and SkipResources select resource types to scan by the
+	// names in ResourceScopeNames. Heuristics that read a left-out type are
+	// skipped too. Both empty scans everything.
+	OnlyResources []string
+	SkipResources []string
+
 	// Pricing overrides.
-	DiscountRate float64 // Manual EDP/RI rate (e.g. 0.82)
+	DiscountRate   float64 // Manual EDP/RI rate (e.g. 0.82)
+	PricingWorkers int     // Concurrent Pricing API requests for the solver catalog
+
+	// Budget is the monthly spend budget in USD. Cost anomaly analysis projects
+	// month-end spend against it; zero disables budget tracking.
+	Budget float64
 
 	// Telemetry config.
 	OtelEndpoint  string // "http://localhost:4318" or via env
@@ -75,11 +210,26 @@ type Engine struct {
 
 	// External dependencies.
 	History  *history.Client
-	Notifier *notifier.SlackClient
+	Notifier notifier.Notifier // Fans out to every configured channel; nil if none.
 	Pricing  *pricing.Client
 
+	// pricingInjected is set by WithPricing, even with a nil client, so the
+	// pipeline never builds (and calibrates) a second one.
+	pricingInjected bool
+
 	// Runtime state.
 	doneChan chan struct{}
+	scanID   string          // Ties the plans and summary of one run together.
+	scopes   *scopeSelection // From OnlyResources/SkipResources; nil scans everything.
+	accounts []string        // AWS accounts scanned, in scan order.
+	started  time.Time
+	summary  *report.Summary // Set once a run reports its summary.
+
+	// embedded skips process-wide side effects such as slog.SetDefault.
+	embedded bool
+
+	// findingSink streams findings and the exit summary as NDJSON in machine mode.
+	findingSink *heuristics.NDJSONSink
 }
 
 // Option defines a functional configuration override.
@@ -104,7 +254,20 @@ func New(ctx context.Context, opts ...Option) (*Engine, error) {
 		opt(e)
 	}
 
-	slog.SetDefault(e.Logger)
+	if !e.embedded {
+		slog.SetDefault(e.Logger)
+	}
+
+	scopes, err := resolveResourceScopes(e.config.OnlyResources, e.config.SkipResources)
+	if err != nil {
+		return nil, err
+	}
+	e.scopes = scopes
+
+	// Machine mode (--headless --json) writes findings to stdout as they are found.
+	if e.config.JsonLogs && e.config.Headless {
+		e.findingSink = heuristics.NewNDJSONSink(os.Stdout)
+	}
 
 	// Initialize telemetry.
 	if !e.config.SkipTelemetry {
@@ -126,6 +289,10 @@ func New(ctx context.Context, opts ...Option) (*Engine, error) {
 
 	e.History = history.NewClient(backend)
 
+	if notifiers := e.buildNotifiers(); len(notifiers) > 0 {
+		e.Notifier = &notifier.Fanout{Notifiers: notifiers, Logger: e.Logger}
+	}
+
 	return e, nil
 }
 
@@ -136,6 +303,14 @@ func WithLogger(l *slog.Logger) Option {
 	}
 }
 
+// WithEmbedded leaves the process-wide logger untouched.
+// Use it when the engine runs inside another program.
+func WithEmbedded() Option {
+	return func(e *Engine) {
+		e.embedded = true
+	}
+}
+
 // WithConcurrency sets the swarm limit.
 func WithConcurrency(n int) Option {
 	return func(e *Engine) {
@@ -146,9 +321,30 @@ func WithConcurrency(n int) Option {
 }
 
 // WithPricing sets pricing provider.
+// A nil client means pricing is unavailable; the engine will not retry.
 func WithPricing(p *pricing.Client) Option {
 	return func(e *Engine) {
 		e.Pricing = p
+		e.pricingInjected = true
+	}
+}
+
+// newPricingClient is swapped in tests.
+var newPricingClient = pricing.NewClient
+
+// initPricing builds the pricing client once unless the caller injected one.
+// Each client runs discount calibration, so sharing one keeps it to a single
+// Cost Explorer call per invocation.
+func (e *Engine) initPricing(ctx context.Context) {
+	if e.pricingInjected {
+		return
+	}
+	e.pricingInjected = true
+
+	var err error
+	e.Pricing, err = newPricingClient(ctx, e.Logger, e.config.CacheDir, e.config.DiscountRate, os.Getenv("AWS_PROFILE"))
+	if err != nil {
+		e.Logger.Warn("Pricing Client initialization failed", "error", err)
 	}
 }
 
@@ -180,27 +376,46 @@ func (e *Engine) Run(ctx context.Context) (bool, *graph.Graph, *swarm.Engine, er
 
 	// Crash safety.
 	defer e.recoverPanic(ctx)
+	e.started = time.Now()
 
 	if !e.config.Headless && !e.config.JsonLogs {
 		fmt.Printf("%s %s [%s]\n", version.AppName, version.Current, version.License)
 	}
 
+	if e.findingSink != nil {
+		defer e.writeExitSummary(time.Now())
+	}
+
 	e.Logger.Info("Starting CloudSlash Engine", "concurrency", e.Swarm.MaxWorkers)
 	e.Swarm.Start(ctx)
 	defer e.Swarm.Stop()
 
+	// Prices fetched during the run are persisted once, at the end.
+	defer func() {
+		if e.Pricing != nil {
+			e.Pricing.Flush()
+		}
+	}()
+
 	// Execute strategy.
 	if e.config.MockMode {
+		e.scanID = fmt.Sprintf("cs-mock-%d", time.Now().Unix())
 		runMockMode(ctx, e)
 		return true, e.Graph, e.Swarm, nil
 	}
 
 	// Real mode.
+	e.scanID = fmt.Sprintf("cs-scan-%d", time.Now().Unix())
 	done := runRealPipeline(ctx, e)
 
 	// Await completion.
 	if done != nil {
-		<-done
+		select {
+		case <-done:
+		case <-ctx.Done():
+			e.shutdown(done)
+			return true, e.Graph, e.Swarm, ErrInterrupted
+		}
 	}
 
 	// --- FAANG PATTERN: State Inspection ---
@@ -227,6 +442,26 @@ func (e *Engine) Run(ctx context.Context) (bool, *graph.Graph, *swarm.Engine, er
 	return true, e.Graph, e.Swarm, nil
 }
 
+// shutdown stops an interrupted run: queued scanner tasks run with the
+// cancelled context so the pipeline's WaitGroup drains, then the graph builder
+// exits. Tasks that ignore cancellation are abandoned after shutdownTimeout.
+func (e *Engine) shutdown(done <-chan struct{}) {
+	e.Logger.Warn("Interrupted; stopping in-flight scans", "timeout", shutdownTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := e.Swarm.Shutdown(ctx); err != nil {
+		e.Logger.Warn("Abandoning scan tasks that did not stop in time", "error", err)
+	} else {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			e.Logger.Warn("Pipeline did not stop in time", "error", ctx.Err())
+		}
+	}
+	e.Graph.CloseAndWait()
+}
+
 // recoverPanic handles failures.
 func (e *Engine) recoverPanic(ctx context.Context) {
 	if r := recover(); r != nil {
@@ -1,9 +1,19 @@
 package engine
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/heuristics"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/history"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/pricing"
 )
 
 func TestEngineInitialization(t *testing.T) {
@@ -39,3 +49,105 @@ func TestEngineConfigValidation(t *testing.T) {
 		}
 	}
 }
+
+func TestInitPricingBuildsOneClient(t *testing.T) {
+	calls := 0
+	orig := newPricingClient
+	newPricingClient = func(ctx context.Context, logger *slog.Logger, cacheDir string, rate float64, profile string) (*pricing.Client, error) {
+		calls++
+		return nil, errors.New("no credentials")
+	}
+	defer func() { newPricingClient = orig }()
+
+	ctx := context.Background()
+	cfg := Config{Logger: slog.Default(), SkipTelemetry: true}
+
+	// Injected (even nil after a failed init): never rebuilt.
+	injected, _ := New(ctx, WithConfig(cfg), WithPricing(nil))
+	injected.initPricing(ctx)
+	if calls != 0 {
+		t.Errorf("Expected injected client to be reused, got %d builds", calls)
+	}
+
+	// Not injected: built once, and a failure is not retried.
+	own, _ := New(ctx, WithConfig(cfg))
+	own.initPricing(ctx)
+	own.initPricing(ctx)
+	if calls != 1 {
+		t.Errorf("Expected 1 pricing client build, got %d", calls)
+	}
+}
+
+func TestWriteExitSummary(t *testing.T) {
+	eng, _ := New(context.Background(), WithConfig(Config{Logger: slog.Default(), SkipTelemetry: true}))
+	var buf bytes.Buffer
+	eng.findingSink = heuristics.NewNDJSONSink(&buf)
+
+	eng.Graph.AddNode("vol-1", "AWS::EC2::Volume", map[string]interface{}{})
+	eng.Graph.AddNode("vol-2", "AWS::EC2::Volume", map[string]interface{}{})
+	eng.Graph.CloseAndWait()
+	eng.Graph.MarkWaste("vol-1", 90)
+	eng.Graph.GetNode("vol-1").Cost = 8
+	eng.Graph.AddError("default:us-east-1 [ScanEFSFileSystems]", errors.New("AccessDenied"))
+
+	eng.writeExitSummary(time.Now())
+
+	var got exitSummary
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Summary is not a JSON line: %v (%q)", err, buf.String())
+	}
+	if got.Event != "summary" || got.Resources != 2 || got.Findings != 1 || got.MonthlyWaste != 8 {
+		t.Errorf("Unexpected summary: %+v", got)
+	}
+	if !got.Partial || len(got.FailedScopes) != 1 {
+		t.Errorf("Expected a partial scan with 1 failed scope, got %+v", got)
+	}
+}
+
+func TestDiffSinceLastScanTagsNewWaste(t *testing.T) {
+	eng, _ := New(context.Background(), WithConfig(Config{Logger: slog.Default(), SkipTelemetry: true}))
+	eng.Graph.AddNode("vol-1", "AWS::EC2::Volume", map[string]interface{}{})
+	eng.Graph.AddNode("vol-2", "AWS::EC2::Volume", map[string]interface{}{})
+	eng.Graph.CloseAndWait()
+	eng.Graph.MarkWaste("vol-1", 90)
+	eng.Graph.MarkWaste("vol-2", 90)
+
+	prev := &history.Snapshot{WasteCount: 2, Waste: []history.WasteEntry{
+		{ID: "vol-1", Type: "AWS::EC2::Volume"},
+		{ID: "snap-1", Type: "AWS::EC2::Snapshot"},
+	}}
+	d := eng.diffSinceLastScan(prev, takeSnapshot(eng.Graph))
+	if d == nil || len(d.Added) != 1 || len(d.Resolved) != 1 {
+		t.Fatalf("Expected 1 added and 1 resolved, got %+v", d)
+	}
+	if eng.Graph.GetNode("vol-2").Properties["WasteDiff"] != "new" {
+		t.Error("Expected vol-2 to be tagged as new waste")
+	}
+	if _, ok := eng.Graph.GetNode("vol-1").Properties["WasteDiff"]; ok {
+		t.Error("vol-1 was flagged before and should not be tagged")
+	}
+
+	if eng.diffSinceLastScan(nil, takeSnapshot(eng.Graph)) != nil {
+		t.Error("Expected no diff without a baseline")
+	}
+}
+
+func TestIaCToolDetection(t *testing.T) {
+	dir := t.TempDir()
+	if got := iacTool("", dir); got != "terraform" {
+		t.Errorf("Expected terraform without a Pulumi project, got %s", got)
+	}
+
+	os.WriteFile(filepath.Join(dir, "Pulumi.yaml"), []byte("name: app\nruntime: go\n"), 0644)
+	if got := iacTool("auto", dir); got != "pulumi" {
+		t.Errorf("Expected pulumi for a Pulumi project, got %s", got)
+	}
+
+	os.WriteFile(filepath.Join(dir, "main.tf"), []byte(""), 0644)
+	if got := iacTool("", dir); got != "terraform" {
+		t.Errorf("Expected terraform when .tf files are present, got %s", got)
+	}
+	if got := iacTool("Pulumi", dir); got != "pulumi" {
+		t.Errorf("Expected --iac to override detection, got %s", got)
+	}
+}
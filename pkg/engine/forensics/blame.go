@@ -4,13 +4,18 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
 	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
 )
 
+// creatorWindow matches the 90-day lookback of CloudTrailClient.LookupCreator.
+const creatorWindow = 90 * 24 * time.Hour
+
 type Detective struct {
-	CT *aws.CloudTrailClient
+	CT    *aws.CloudTrailClient
+	Cache *TrailCache // Optional; shared with CloudTrail-backed heuristics.
 }
 
 func NewDetective(ct *aws.CloudTrailClient) *Detective {
@@ -49,7 +54,9 @@ func (d *Detective) IdentifyOwner(ctx context.Context, node *graph.Node) string
 			resourceID = parts[len(parts)-1]
 		}
 
-		user, err := d.CT.LookupCreator(ctx, resourceID)
+		user, err := d.Cache.Do(ctx, resourceID, "create", creatorWindow, func(ctx context.Context) (string, error) {
+			return d.CT.LookupCreator(ctx, resourceID)
+		})
 		if err == nil {
 			return fmt.Sprintf("IAM:%s", user)
 		}
@@ -0,0 +1,83 @@
+package forensics
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TrailCache memoizes CloudTrail lookups for the length of one run.
+// LookupEvents is rate limited to 2 calls/s per account and region, so the
+// detective and CloudTrail-backed heuristics share answers instead of re-querying.
+// A nil *TrailCache is valid and disables caching.
+type TrailCache struct {
+	mu      sync.Mutex
+	entries map[trailKey]*trailEntry
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+type trailKey struct {
+	resource  string
+	eventType string
+	window    time.Duration
+}
+
+// trailEntry resolves once; concurrent callers for the same key wait on the first fetch.
+type trailEntry struct {
+	once  sync.Once
+	value string
+	err   error
+}
+
+// NewTrailCache returns an empty cache.
+func NewTrailCache() *TrailCache {
+	return &TrailCache{entries: make(map[trailKey]*trailEntry)}
+}
+
+// Do returns the cached result for (resource, eventType, window), calling fetch
+// on the first request only. Errors are cached too: a resource with no creation
+// event in the window will not have one on the next pass of the same run.
+func (c *TrailCache) Do(ctx context.Context, resource, eventType string, window time.Duration, fetch func(context.Context) (string, error)) (string, error) {
+	if c == nil {
+		return fetch(ctx)
+	}
+
+	key := trailKey{resource, eventType, window}
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &trailEntry{}
+		c.entries[key] = entry
+	}
+	c.mu.Unlock()
+
+	if ok {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+	entry.once.Do(func() {
+		entry.value, entry.err = fetch(ctx)
+	})
+	return entry.value, entry.err
+}
+
+// Stats returns the number of cache hits and misses so far.
+func (c *TrailCache) Stats() (hits, misses int64) {
+	if c == nil {
+		return 0, 0
+	}
+	return c.hits.Load(), c.misses.Load()
+}
+
+// HitRate is hits / lookups, or 0 before the first lookup.
+func (c *TrailCache) HitRate() float64 {
+	hits, misses := c.Stats()
+	if hits+misses == 0 {
+		return 0
+	}
+	return float64(hits) / float64(hits+misses)
+}
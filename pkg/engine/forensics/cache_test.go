@@ -0,0 +1,74 @@
+package forensics
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTrailCache_FetchesOncePerKey(t *testing.T) {
+	cache := NewTrailCache()
+	ctx := context.Background()
+	var calls atomic.Int32
+	fetch := func(context.Context) (string, error) {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return "alice", nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got, err := cache.Do(ctx, "vol-1", "create", creatorWindow, fetch); err != nil || got != "alice" {
+				t.Errorf("Do() = %q, %v", got, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("Expected 1 CloudTrail call for concurrent lookups, got %d", calls.Load())
+	}
+	if hits, misses := cache.Stats(); hits != 19 || misses != 1 {
+		t.Errorf("Expected 19 hits / 1 miss, got %d / %d", hits, misses)
+	}
+
+	// A different window is a different question.
+	cache.Do(ctx, "vol-1", "create", time.Hour, fetch)
+	if calls.Load() != 2 {
+		t.Errorf("Expected a new lookup for a different window, got %d calls", calls.Load())
+	}
+}
+
+func TestTrailCache_CachesErrorsAndNilDisables(t *testing.T) {
+	ctx := context.Background()
+	var calls int
+	fetch := func(context.Context) (string, error) {
+		calls++
+		return "", errors.New("creator not found")
+	}
+
+	cache := NewTrailCache()
+	cache.Do(ctx, "i-1", "create", creatorWindow, fetch)
+	if _, err := cache.Do(ctx, "i-1", "create", creatorWindow, fetch); err == nil {
+		t.Error("Expected cached error")
+	}
+	if calls != 1 {
+		t.Errorf("Expected not-found to be cached, got %d calls", calls)
+	}
+
+	var disabled *TrailCache
+	disabled.Do(ctx, "i-1", "create", creatorWindow, fetch)
+	disabled.Do(ctx, "i-1", "create", creatorWindow, fetch)
+	if calls != 3 {
+		t.Errorf("Expected nil cache to pass through, got %d calls", calls)
+	}
+	if disabled.HitRate() != 0 {
+		t.Error("Expected zero hit rate for nil cache")
+	}
+}
@@ -0,0 +1,79 @@
+package forensics
+
+import (
+	"context"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+)
+
+// keyUseWindow matches the 90-day lookback of CloudTrailClient.LookupKeyLastUsed.
+const keyUseWindow = 90 * 24 * time.Hour
+
+// keyLookup returns a key's last cryptographic use, or a zero time when it
+// was not used in the window.
+type keyLookup func(ctx context.Context, keyARN string) (time.Time, error)
+
+// TraceKeyUsage records when each KMS key was last used for a cryptographic
+// operation, from CloudTrail. Traced keys get UsageTraced, and LastUsed when
+// a use was found. CloudTrail only answers for its own account and region,
+// so keys elsewhere are left untraced; so are keys whose lookup failed.
+func (d *Detective) TraceKeyUsage(ctx context.Context, g *graph.Graph, account string) int {
+	if d.CT == nil {
+		return 0
+	}
+	return d.traceKeys(ctx, g, account, d.CT.Client.Options().Region, d.CT.LookupKeyLastUsed)
+}
+
+func (d *Detective) traceKeys(ctx context.Context, g *graph.Graph, account, region string, lookup keyLookup) int {
+	var keys []string
+	g.Mu.RLock()
+	for _, node := range g.Store.GetAllNodes() {
+		if node.TypeStr() != "AWS::KMS::Key" {
+			continue
+		}
+		parsed, err := arn.Parse(node.IDStr())
+		if err != nil || parsed.Region != region || (account != "" && parsed.AccountID != account) {
+			continue
+		}
+		keys = append(keys, node.IDStr())
+	}
+	g.Mu.RUnlock()
+
+	// CloudTrail calls are rate limited; make them outside the lock.
+	lastUsed := make(map[string]time.Time)
+	for _, key := range keys {
+		v, err := d.Cache.Do(ctx, key, "use", keyUseWindow, func(ctx context.Context) (string, error) {
+			t, err := lookup(ctx, key)
+			if err != nil || t.IsZero() {
+				return "", err
+			}
+			return t.Format(time.RFC3339), nil
+		})
+		if err != nil {
+			continue
+		}
+		var t time.Time
+		if v != "" {
+			if t, err = time.Parse(time.RFC3339, v); err != nil {
+				continue
+			}
+		}
+		lastUsed[key] = t
+	}
+
+	g.Mu.Lock()
+	defer g.Mu.Unlock()
+	for key, t := range lastUsed {
+		node := g.GetNode(key)
+		if node == nil {
+			continue
+		}
+		node.Properties["UsageTraced"] = true
+		if !t.IsZero() {
+			node.Properties["LastUsed"] = t
+		}
+	}
+	return len(lastUsed)
+}
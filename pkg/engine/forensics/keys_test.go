@@ -0,0 +1,65 @@
+package forensics
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+func TestTraceKeys(t *testing.T) {
+	const prefix = "arn:aws:kms:us-east-1:111111111111:key/"
+	used := time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	g := graph.NewGraph()
+	for _, id := range []string{"used", "idle", "broken"} {
+		g.AddNode(prefix+id, "AWS::KMS::Key", map[string]interface{}{})
+	}
+	g.AddNode("arn:aws:kms:eu-west-1:111111111111:key/elsewhere", "AWS::KMS::Key", map[string]interface{}{})
+	g.AddNode("arn:aws:kms:us-east-1:222222222222:key/other-account", "AWS::KMS::Key", map[string]interface{}{})
+	g.CloseAndWait()
+
+	var looked []string
+	lookup := func(ctx context.Context, key string) (time.Time, error) {
+		looked = append(looked, key)
+		switch key {
+		case prefix + "used":
+			return used, nil
+		case prefix + "broken":
+			return time.Time{}, fmt.Errorf("ThrottlingException")
+		}
+		return time.Time{}, nil
+	}
+
+	d := &Detective{Cache: NewTrailCache()}
+	if n := d.traceKeys(context.Background(), g, "111111111111", "us-east-1", lookup); n != 2 {
+		t.Fatalf("Expected 2 traced keys, got %d", n)
+	}
+	if len(looked) != 3 {
+		t.Errorf("Expected lookups for the 3 keys in the trail's account and region, got %v", looked)
+	}
+
+	if last, _ := g.GetNode(prefix + "used").Properties["LastUsed"].(time.Time); !last.Equal(used) {
+		t.Errorf("Expected LastUsed %v, got %v", used, last)
+	}
+	idle := g.GetNode(prefix + "idle")
+	if traced, _ := idle.Properties["UsageTraced"].(bool); !traced {
+		t.Error("Expected the idle key to be traced")
+	}
+	if _, ok := idle.Properties["LastUsed"]; ok {
+		t.Error("Expected no LastUsed on the idle key")
+	}
+	for _, id := range []string{prefix + "broken", "arn:aws:kms:eu-west-1:111111111111:key/elsewhere", "arn:aws:kms:us-east-1:222222222222:key/other-account"} {
+		if _, ok := g.GetNode(id).Properties["UsageTraced"]; ok {
+			t.Errorf("Expected %s to stay untraced", id)
+		}
+	}
+
+	// A second pass in the same run is answered from the cache.
+	d.traceKeys(context.Background(), g, "111111111111", "us-east-1", lookup)
+	if len(looked) != 3 {
+		t.Errorf("Expected cached answers on the second pass, got %d lookups", len(looked))
+	}
+}
@@ -0,0 +1,97 @@
+package engine
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/DrSkyle/cloudslash/v2/pkg/version"
+)
+
+// graphCacheDir holds scanned graphs for --cache-graph.
+const graphCacheDir = ".cloudslash/graph-cache"
+
+// DefaultGraphCacheTTL is how long a cached graph is reused when
+// CacheGraphTTL is zero.
+const DefaultGraphCacheTTL = time.Hour
+
+// graphCachePath names the cache file for this run's scan. Anything that
+// changes what is scanned changes the name, so a cache is never reused for
+// other accounts, regions or resource types. The version is included because
+// node properties change between releases.
+func (e *Engine) graphCachePath(targets []scanTarget) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "version=%s\n", version.Current)
+	for _, t := range targets {
+		fmt.Fprintf(h, "target=%s\n", t.String())
+	}
+	fmt.Fprintf(h, "region=%s\n", e.config.Region)
+	fmt.Fprintf(h, "provider=%s\n", e.config.Provider)
+	fmt.Fprintf(h, "gcp=%s\n", e.config.GCPProject)
+	fmt.Fprintf(h, "azure=%s\n", e.config.AzureSubscription)
+	fmt.Fprintf(h, "messaging=%t\n", e.config.IncludeMessaging)
+	fmt.Fprintf(h, "compliance=%t\n", e.config.Compliance)
+	fmt.Fprintf(h, "skip=%s\n", strings.Join(e.scopes.skippedScanners(), ","))
+	return filepath.Join(graphCacheDir, hex.EncodeToString(h.Sum(nil))[:16]+".bin")
+}
+
+// loadCachedGraph returns the graph cached at path, or nil when there is
+// none or it is older than ttl.
+func loadCachedGraph(path string, ttl time.Duration) (*graph.Graph, time.Duration, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, 0, nil
+		}
+		return nil, 0, fmt.Errorf("failed to read graph cache: %v", err)
+	}
+	if ttl <= 0 {
+		ttl = DefaultGraphCacheTTL
+	}
+	age := time.Since(info.ModTime())
+	if age > ttl {
+		return nil, age, nil
+	}
+	g, err := graph.LoadBinary(path)
+	if err != nil {
+		return nil, age, err
+	}
+	return g, age, nil
+}
+
+// saveCachedGraph writes the scanned graph for later --cache-graph runs.
+// Partial scans are not cached; the next run should retry the failed scopes.
+func (e *Engine) saveCachedGraph(path string) {
+	e.Graph.Mu.RLock()
+	failed := len(e.Graph.Metadata.FailedScopes)
+	e.Graph.Mu.RUnlock()
+	if failed > 0 {
+		e.Logger.Info("Graph not cached: scan was partial", "failed_scopes", failed)
+		return
+	}
+	if err := e.Graph.SaveBinary(path); err != nil {
+		e.Logger.Warn("Failed to cache graph", "error", err)
+		return
+	}
+	e.Logger.Info("Graph cached", "path", path, "nodes", e.Graph.Store.NodeCount())
+}
+
+// connectScope builds the client the analysis phases use for a scope whose
+// resources came from a checkpoint or cache rather than a scan.
+func (e *Engine) connectScope(ctx context.Context, region string, target scanTarget) (*aws.Client, error) {
+	client, err := target.newClient(ctx, region, e.config.Verbose)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.VerifyIdentity(ctx); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
@@ -0,0 +1,70 @@
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/DrSkyle/cloudslash/v2/pkg/resource"
+)
+
+func TestGraphCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "graph.bin")
+
+	if g, _, err := loadCachedGraph(path, time.Hour); g != nil || err != nil {
+		t.Fatalf("Expected a miss without a cache file, got %v, %v", g, err)
+	}
+
+	src := graph.NewGraph()
+	src.AddTypedNode("arn:aws:ec2:us-east-1:123:instance/i-1", "AWS::EC2::Instance",
+		map[string]interface{}{"State": "running"},
+		&resource.EC2Instance{State: "running"})
+	src.CloseAndWait()
+	if err := src.SaveBinary(path); err != nil {
+		t.Fatalf("SaveBinary failed: %v", err)
+	}
+
+	g, _, err := loadCachedGraph(path, time.Hour)
+	if err != nil || g == nil {
+		t.Fatalf("Expected a hit, got %v, %v", g, err)
+	}
+	node := g.GetNode("arn:aws:ec2:us-east-1:123:instance/i-1")
+	if node == nil {
+		t.Fatal("Expected cached instance")
+	}
+	if inst, ok := node.TypedData.(*resource.EC2Instance); !ok || inst.State != "running" {
+		t.Errorf("Expected typed data to survive, got %#v", node.TypedData)
+	}
+
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if g, age, err := loadCachedGraph(path, time.Hour); g != nil || err != nil || age < time.Hour {
+		t.Errorf("Expected a stale cache to be ignored, got %v, %s, %v", g, age, err)
+	}
+}
+
+func TestGraphCachePath(t *testing.T) {
+	targets := []scanTarget{{Profile: "prod"}}
+	a := &Engine{config: Config{Region: "us-east-1"}}
+	b := &Engine{config: Config{Region: "us-east-1"}}
+	if a.graphCachePath(targets) != b.graphCachePath(targets) {
+		t.Error("Identical scans should share a cache file")
+	}
+
+	for name, other := range map[string]*Engine{
+		"region":    {config: Config{Region: "eu-west-1"}},
+		"messaging": {config: Config{Region: "us-east-1", IncludeMessaging: true}},
+		"skip":      {config: Config{Region: "us-east-1"}, scopes: &scopeSelection{scanners: map[string]bool{"ScanBuckets": true}}},
+	} {
+		if a.graphCachePath(targets) == other.graphCachePath(targets) {
+			t.Errorf("A different %s should use another cache file", name)
+		}
+	}
+	if a.graphCachePath(targets) == a.graphCachePath([]scanTarget{{Profile: "dev"}}) {
+		t.Error("Other accounts should use another cache file")
+	}
+}
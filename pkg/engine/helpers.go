@@ -5,40 +5,55 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	internalconfig "github.com/DrSkyle/cloudslash/v2/pkg/config"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/heuristics"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/history"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/notifier"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/policy"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/report"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/scanner"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/swarm"
 	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/DrSkyle/cloudslash/v2/pkg/providers/azure"
+	"github.com/DrSkyle/cloudslash/v2/pkg/providers/gcp"
 	"github.com/DrSkyle/cloudslash/v2/pkg/providers/k8s"
+	"github.com/DrSkyle/cloudslash/v2/pkg/providers/pulumi"
 	"gopkg.in/yaml.v3"
 )
 
-func runScanForProfile(ctx context.Context, region, profile string, verbose bool, g *graph.Graph, engine *swarm.Engine, scanWg *sync.WaitGroup) (*aws.Client, error) {
-	awsClient, err := aws.NewClient(ctx, region, profile, verbose)
+// runScanForProfile scans one target and region. skip names scanners to leave
+// out (see resourceScopes); metricWindow is the scanners' CloudWatch lookback,
+// zero for each scanner's default.
+func runScanForProfile(ctx context.Context, region string, target scanTarget, verbose, includeMessaging, compliance bool, metricWindow time.Duration, skip []string, g *graph.Graph, engine *swarm.Engine, scanWg *sync.WaitGroup) (*aws.Client, error) {
+	awsClient, err := target.newClient(ctx, region, verbose)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create AWS client: %v", err)
 	}
 
 	identity, err := awsClient.VerifyIdentity(ctx)
 	if err != nil {
+		if target.RoleARN != "" {
+			return nil, fmt.Errorf("failed to assume %s: %v", target.RoleARN, err)
+		}
 		if strings.Contains(err.Error(), "no EC2 IMDS role found") || strings.Contains(err.Error(), "failed to get caller identity") {
 			return nil, fmt.Errorf("\n[ERROR] Unable to find AWS Credentials.\n   Please run 'aws configure' or set AWS_PROFILE.\n   (Error: %v)", err)
 		}
 		return nil, fmt.Errorf("failed to verify identity: %v", err)
 	}
 	eLog := slog.Default() // Use default which is set in Engine.Run
-	eLog.Info("Connected to AWS", "profile", profile, "account", identity)
+	eLog.Info("Connected to AWS", "target", target.String(), "account", identity)
 
 	// Scanners
 	ec2Scanner := aws.NewEC2Scanner(awsClient.Config, g)
 	s3Scanner := aws.NewS3Scanner(awsClient.Config, g)
+	s3Scanner.Encryption = compliance
 	rdsScanner := aws.NewRDSScanner(awsClient.Config, g)
 	eksScanner := aws.NewEKSScanner(awsClient.Config, g)
 	natScanner := aws.NewNATScanner(awsClient.Config, g)
@@ -49,10 +64,22 @@ func runScanForProfile(ctx context.Context, region, profile string, verbose bool
 	elasticacheScanner := aws.NewElasticacheScanner(awsClient.Config, g)
 	redshiftScanner := aws.NewRedshiftScanner(awsClient.Config, g)
 	dynamoScanner := aws.NewDynamoDBScanner(awsClient.Config, g)
+	kmsScanner := aws.NewKMSScanner(awsClient.Config, g)
 	lambdaScanner := aws.NewLambdaScanner(awsClient.Config, g)
+	vpcScanner := aws.NewVPCScanner(awsClient.Config, g)
+	cicdScanner := aws.NewCICDScanner(awsClient.Config, g)
+	efsScanner := aws.NewEFSScanner(awsClient.Config, g)
+	mlScanner := aws.NewMLEndpointScanner(awsClient.Config, g)
+	dmsScanner := aws.NewDMSScanner(awsClient.Config, g)
+	openSearchScanner := aws.NewOpenSearchScanner(awsClient.Config, g)
+	openSearchScanner.Window = metricWindow
+	cloudFrontScanner := aws.NewCloudFrontScanner(awsClient.Config, g)
+	wafScanner := aws.NewWAFScanner(awsClient.Config, g)
+	route53Scanner := aws.NewRoute53Scanner(awsClient.Config, g)
 
 	// Initialize Registry
 	reg := scanner.NewRegistry()
+	reg.Skip(skip...)
 
 	// Register Scanners
 	reg.Register(&aws.EC2InstanceScanner{Scanner: ec2Scanner})
@@ -70,7 +97,27 @@ func runScanForProfile(ctx context.Context, region, profile string, verbose bool
 	reg.Register(&aws.ElasticacheScannerWrapper{Scanner: elasticacheScanner})
 	reg.Register(&aws.RedshiftScannerWrapper{Scanner: redshiftScanner})
 	reg.Register(&aws.DynamoDBScannerWrapper{Scanner: dynamoScanner})
+	reg.Register(&aws.KMSScannerWrapper{Scanner: kmsScanner})
 	reg.Register(&aws.LambdaScannerWrapper{Scanner: lambdaScanner})
+	reg.Register(&aws.VPCScannerWrapper{Scanner: vpcScanner})
+	reg.Register(&aws.SubnetScannerWrapper{Scanner: vpcScanner})
+	reg.Register(&aws.CodeBuildScannerWrapper{Scanner: cicdScanner})
+	reg.Register(&aws.CodePipelineScannerWrapper{Scanner: cicdScanner})
+	reg.Register(&aws.EFSScannerWrapper{Scanner: efsScanner})
+	reg.Register(&aws.MLEndpointScannerWrapper{Scanner: mlScanner})
+	reg.Register(&aws.DMSScannerWrapper{Scanner: dmsScanner})
+	reg.Register(&aws.OpenSearchScannerWrapper{Scanner: openSearchScanner})
+	reg.Register(&aws.CloudFrontScannerWrapper{Scanner: cloudFrontScanner})
+	reg.Register(&aws.WAFScannerWrapper{Scanner: wafScanner})
+	reg.Register(&aws.Route53ScannerWrapper{Scanner: route53Scanner})
+
+	// Queues and topics are cheap but numerous; opt-in only.
+	if includeMessaging {
+		messagingScanner := aws.NewMessagingScanner(awsClient.Config, g)
+		messagingScanner.Window = metricWindow
+		reg.Register(&aws.SQSScannerWrapper{Scanner: messagingScanner})
+		reg.Register(&aws.SNSScannerWrapper{Scanner: messagingScanner})
+	}
 
 	if k8sClient, err := k8s.NewClient(); err == nil {
 		k8sScanner := k8s.NewScanner(k8sClient, g)
@@ -78,32 +125,193 @@ func runScanForProfile(ctx context.Context, region, profile string, verbose bool
 	}
 
 	// Execute All Scanners
-	reg.RunAll(ctx, g, engine, scanWg, region, profile)
+	reg.RunAll(ctx, g, engine, scanWg, region, target.String())
 
 
 	return awsClient, nil
 }
 
-// performSignalAnalysis detects cost anomalies.
-func performSignalAnalysis(g *graph.Graph, slack *notifier.SlackClient, hClient *history.Client) {
-	// Snapshot state.
+// runGCPScan scans one GCP project. Compute Engine lists are aggregated across
+// zones, so unlike runScanForProfile it runs once rather than per region.
+func runGCPScan(ctx context.Context, project string, g *graph.Graph, engine *swarm.Engine, scanWg *sync.WaitGroup) error {
+	client, err := gcp.NewClient(ctx, project)
+	if err != nil {
+		return fmt.Errorf("\n[ERROR] Unable to find GCP Credentials.\n   Please run 'gcloud auth application-default login'.\n   (Error: %v)", err)
+	}
+	slog.Default().Info("Connected to GCP", "project", client.Project)
+
+	reg := scanner.NewRegistry()
+	reg.Register(gcp.NewComputeScanner(client, g))
+	reg.RunAll(ctx, g, engine, scanWg, "global", client.Project)
+	return nil
+}
+
+// newHeuristicEngine returns a heuristics engine that skips Config.DisabledHeuristics
+// and heuristics reading resource types --only or --skip left out.
+// Both pipelines build their engines here so these flags behave the same in each.
+func (e *Engine) newHeuristicEngine() *heuristics.Engine {
+	h := heuristics.NewEngine()
+	h.Disable(e.config.DisabledHeuristics...)
+	h.Disable(e.scopes.skippedHeuristics()...)
+	return h
+}
+
+// warnOnCycles logs the graph's cycles. Dependency edges should never form one;
+// a cycle usually means a scanner added an edge in both directions, and
+// analysis that follows edges (reachability, deletion order) may be wrong.
+func (e *Engine) warnOnCycles() {
+	cycles := e.Graph.DetectCycles()
+	if len(cycles) == 0 {
+		return
+	}
+	e.Logger.Warn("Resource graph contains cycles; reachability and deletion order may be inaccurate", "cycles", len(cycles))
+	for _, c := range cycles {
+		e.Logger.Warn("Graph cycle", "size", len(c), "nodes", strings.Join(c, " <-> "))
+	}
+}
+
+// scanRegion is the first configured region, the default for pricing nodes
+// that carry no region of their own.
+func (e *Engine) scanRegion() string {
+	for _, r := range strings.Split(e.config.Region, ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			return r
+		}
+	}
+	return internalconfig.DefaultRegion
+}
+
+// runAzureScan scans one Azure subscription. VM and disk lists span all
+// regions, so like runGCPScan it runs once.
+func runAzureScan(ctx context.Context, subscription string, g *graph.Graph, engine *swarm.Engine, scanWg *sync.WaitGroup) error {
+	client, err := azure.NewClient(subscription)
+	if err != nil {
+		return fmt.Errorf("\n[ERROR] Unable to find Azure Credentials.\n   Please run 'az login' or set AZURE_CLIENT_ID/AZURE_TENANT_ID/AZURE_CLIENT_SECRET.\n   (Error: %v)", err)
+	}
+	slog.Default().Info("Connected to Azure", "subscription", client.SubscriptionID)
+
+	reg := scanner.NewRegistry()
+	reg.Register(azure.NewComputeScanner(client, g))
+	reg.RunAll(ctx, g, engine, scanWg, "global", client.SubscriptionID)
+	return nil
+}
+
+// providerEnabled reports whether name is in the comma-separated provider list.
+// An empty list selects aws only.
+func providerEnabled(providers, name string) bool {
+	if strings.TrimSpace(providers) == "" {
+		return name == "aws"
+	}
+	for _, p := range strings.Split(providers, ",") {
+		if strings.EqualFold(strings.TrimSpace(p), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// iacTool returns the IaC tool to reconcile against: setting when given, else
+// "pulumi" for a Pulumi project with no Terraform files in dir, else "terraform".
+func iacTool(setting, dir string) string {
+	if s := strings.ToLower(strings.TrimSpace(setting)); s != "" && s != "auto" {
+		return s
+	}
+	if !pulumi.IsProject(dir) {
+		return "terraform"
+	}
+	if tfFiles, _ := filepath.Glob(filepath.Join(dir, "*.tf")); len(tfFiles) > 0 {
+		return "terraform"
+	}
+	return "pulumi"
+}
+
+// buildNotifiers returns a notifier for each configured chat channel and Datadog.
+func (e *Engine) buildNotifiers() []notifier.Notifier {
+	var notifiers []notifier.Notifier
+	if e.config.SlackWebhook != "" || e.config.SlackToken != "" {
+		slack := notifier.NewSlackClient(e.config.SlackWebhook, e.config.SlackChannel)
+		slack.Token = e.config.SlackToken
+		notifiers = append(notifiers, slack)
+	}
+	if e.config.TeamsWebhook != "" {
+		notifiers = append(notifiers, notifier.NewTeamsClient(e.config.TeamsWebhook))
+	}
+	if e.config.DiscordWebhook != "" {
+		notifiers = append(notifiers, notifier.NewDiscordClient(e.config.DiscordWebhook))
+	}
+	if e.config.DatadogAPIKey != "" {
+		notifiers = append(notifiers, notifier.NewDatadogClient(e.config.DatadogAPIKey, e.config.DatadogSite))
+	}
+	return notifiers
+}
+
+// takeSnapshot records the graph's cost, resource counts and waste IDs.
+func takeSnapshot(g *graph.Graph) history.Snapshot {
 	s := history.Snapshot{
 		Timestamp:      time.Now().Unix(),
 		ResourceCounts: make(map[string]int),
 	}
 
 	g.Mu.RLock()
-	var wasteVector history.Vector
 	for _, n := range g.GetNodes() {
 		s.TotalMonthlyCost += n.Cost
 		s.ResourceCounts[n.TypeStr()]++
 		if n.IsWaste {
 			s.WasteCount++
-			wasteVector = append(wasteVector, n.Cost)
+			s.Vector = append(s.Vector, n.Cost)
+			s.Waste = append(s.Waste, history.WasteEntry{ID: n.IDStr(), Type: n.TypeStr(), Cost: n.Cost})
 		}
 	}
 	g.Mu.RUnlock()
 
+	sort.Slice(s.Waste, func(i, j int) bool { return s.Waste[i].ID < s.Waste[j].ID })
+	return s
+}
+
+// previousSnapshot loads the most recent stored snapshot when --diff is set.
+func (e *Engine) previousSnapshot() *history.Snapshot {
+	if !e.config.Diff || e.History == nil {
+		return nil
+	}
+	prev, err := e.History.Latest()
+	if err != nil {
+		e.Logger.Warn("Failed to load previous snapshot, skipping diff", "error", err)
+		return nil
+	}
+	if prev == nil {
+		fmt.Println("\n[INFO] No previous snapshot found. This scan is the baseline for --diff.")
+		return nil
+	}
+	if !prev.HasResourceIDs() {
+		fmt.Println("\n[INFO] The previous snapshot predates per-resource history. This scan is the baseline for --diff.")
+		return nil
+	}
+	return prev
+}
+
+// diffSinceLastScan compares s with prev, printing the delta and tagging newly
+// flagged nodes with WasteDiff=new for the TUI. Returns nil without a baseline.
+func (e *Engine) diffSinceLastScan(prev *history.Snapshot, s history.Snapshot) *history.Delta {
+	if prev == nil {
+		return nil
+	}
+
+	d := history.Diff(*prev, s)
+	e.Graph.Mu.Lock()
+	for _, w := range d.Added {
+		if n := e.Graph.GetNode(w.ID); n != nil {
+			n.Properties["WasteDiff"] = "new"
+		}
+	}
+	e.Graph.Mu.Unlock()
+
+	report.WriteDiff(os.Stdout, d)
+	return &d
+}
+
+// performSignalAnalysis persists s and detects cost anomalies. A budget > 0
+// reports spend against it and alerts when month-end spend is projected over it.
+func performSignalAnalysis(s history.Snapshot, alerts notifier.Notifier, hClient *history.Client, budget float64) {
 	// Persist
 	if err := hClient.Append(s); err != nil {
 		// Non-critical failure, just log to debug if needed
@@ -112,8 +320,7 @@ func performSignalAnalysis(g *graph.Graph, slack *notifier.SlackClient, hClient
 	// Analyze window.
 	window, err := hClient.LoadWindow(10)
 	if err == nil {
-		// Analyze with zero budget.
-		res := history.Analyze(window, 0)
+		res := history.Analyze(window, budget)
 
 		// Alert critical signals.
 		if len(res.Alerts) > 0 {
@@ -124,25 +331,41 @@ func performSignalAnalysis(g *graph.Graph, slack *notifier.SlackClient, hClient
 			fmt.Printf(" Current Velocity: %+.2f $/mo per hour\n", res.Velocity)
 			if res.Acceleration > 0 {
 				fmt.Printf(" Acceleration:     %+.2f $/mo/h^2 (SPEND ACCELERATING)\n", res.Acceleration)
+			}
+			if res.Budget > 0 {
+				fmt.Printf(" %s\n", budgetLine(res))
+			}
 
-				// Budget alert.
-				if slack != nil && res.Acceleration > 20.0 {
-					slack.SendBudgetAlert(res.Velocity, res.Acceleration)
-				}
+			// Budget alert.
+			if alerts != nil && (res.Acceleration > 20.0 || res.OverBudget()) {
+				alerts.SendBudgetAlert(notifier.BudgetAlert{
+					Velocity:          res.Velocity,
+					Acceleration:      res.Acceleration,
+					Budget:            res.Budget,
+					ProjectedMonthEnd: res.ProjectedMonthEnd,
+				})
 			}
 			fmt.Println("-----------------------------------------------------------------")
+		} else if res.Budget > 0 {
+			fmt.Printf("\n%s\n", budgetLine(res))
 		} else if res.Velocity != 0 {
 
 		}
 	}
 }
 
-// runPolicyEngine executes CEL policies.
-func runPolicyEngine(ctx context.Context, rulesFile string, g *graph.Graph) error {
+// budgetLine renders spend against the monthly budget.
+func budgetLine(res history.AnalysisResult) string {
+	return fmt.Sprintf("Budget: %.0f%% consumed / %.0f%% projected ($%.2f now, $%.2f at month end of $%.2f/mo)",
+		res.BudgetConsumed*100, res.BudgetProjected*100, res.CurrentBurnRate, res.ProjectedMonthEnd, res.Budget)
+}
+
+// runPolicyEngine executes CEL policies from a local path or s3:// URL.
+func runPolicyEngine(ctx context.Context, rulesFile, cacheDir string, g *graph.Graph) error {
 	// Read rules.
-	data, err := os.ReadFile(rulesFile)
+	data, err := ReadRules(ctx, rulesFile, cacheDir, slog.Default())
 	if err != nil {
-		return fmt.Errorf("failed to read rules file: %w", err)
+		return err
 	}
 
 	type RuleConfig struct {
@@ -66,15 +66,17 @@ func (h *AgedAMIHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicS
 	// Mark waste.
 	for _, arn := range candidates {
 		// Respect ignore tags.
-		g.MarkWaste(arn, 40)
+		g.AddFinding(arn, graph.Finding{
+			Heuristic: h.Name(),
+			Reason:    "Aged Artifact: AMI is > 90 days old and has no active instances.",
+			Score:     40,
+			Savings:   1.00, // Approx storage cost
+		})
 
-		// Enrich metadata.
 		node := g.GetNode(arn)
 		if node != nil {
 			g.Mu.Lock()
 			if node.IsWaste {
-				node.Properties["Reason"] = "Aged Artifact: AMI is > 90 days old and has no active instances."
-				node.Cost = 1.00 // Approx storage cost
 				stats.ItemsFound++
 				stats.ProjectedSavings += node.Cost
 			}
@@ -0,0 +1,163 @@
+package heuristics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// IdleCIHeuristic detects CodeBuild projects and pipelines with no activity in 90 days.
+// Both are usage-billed, so they are flagged for review; the value is in the
+// artifact buckets they keep alive, which are grouped into the same CI stack.
+type IdleCIHeuristic struct{}
+
+func (h *IdleCIHeuristic) Name() string { return "IdleCIHeuristic" }
+
+func (h *IdleCIHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	stats := &HeuristicStats{}
+	cutoff := time.Now().AddDate(0, 0, -90)
+
+	type finding struct {
+		id     string
+		reason string
+		stack  string
+	}
+	var findings []finding
+
+	g.Mu.RLock()
+	idle := make(map[uint32]bool)
+	stacks := make(map[uint32]string)
+	var ciNodes []*graph.Node
+
+	for _, node := range g.Store.GetAllNodes() {
+		if !isCINode(node) {
+			continue
+		}
+		ciNodes = append(ciNodes, node)
+		idle[node.Index] = lastCIActivity(node).Before(cutoff)
+
+		// Pipelines name the stack; their build projects join it.
+		if node.TypeStr() == "AWS::CodePipeline::Pipeline" {
+			name, _ := node.Properties["Name"].(string)
+			stacks[node.Index] = name
+			for _, e := range g.Store.GetEdges(node.Index) {
+				if e.Type == graph.EdgeTypeRuns {
+					stacks[e.TargetID] = name
+				}
+			}
+		}
+	}
+	for _, node := range ciNodes {
+		if _, ok := stacks[node.Index]; !ok {
+			stacks[node.Index], _ = node.Properties["Name"].(string)
+		}
+	}
+
+	buckets := make(map[uint32]bool)
+	for _, node := range ciNodes {
+		if !idle[node.Index] {
+			continue
+		}
+
+		kind, activity := "CodeBuild project", "builds"
+		if node.TypeStr() == "AWS::CodePipeline::Pipeline" {
+			kind, activity = "CodePipeline pipeline", "executions"
+		}
+		findings = append(findings, finding{
+			id:     node.IDStr(),
+			reason: fmt.Sprintf("Idle %s: no %s in 90 days (usage-billed; review)", kind, activity),
+			stack:  stacks[node.Index],
+		})
+
+		for _, e := range g.Store.GetEdges(node.Index) {
+			if e.Type == graph.EdgeTypeUses {
+				buckets[e.TargetID] = true
+			}
+		}
+	}
+
+	// A bucket is only dead if every CI resource writing to it is idle.
+	for idx := range buckets {
+		bucket := g.Store.GetNode(idx)
+		if bucket == nil || bucket.TypeStr() != "AWS::S3::Bucket" {
+			continue
+		}
+
+		var owners []string
+		shared := false
+		for _, e := range g.GetReverseEdges(idx) {
+			src := g.Store.GetNode(e.TargetID)
+			if src == nil || !isCINode(src) {
+				continue
+			}
+			if !idle[src.Index] {
+				shared = true
+				break
+			}
+			owners = append(owners, stacks[src.Index])
+		}
+		if shared || len(owners) == 0 {
+			continue
+		}
+
+		owners = uniqueSorted(owners)
+		findings = append(findings, finding{
+			id:     bucket.IDStr(),
+			reason: fmt.Sprintf("Artifact bucket of idle CI stack: %s", strings.Join(owners, ", ")),
+			stack:  strings.Join(owners, ","),
+		})
+	}
+	g.Mu.RUnlock()
+
+	for _, f := range findings {
+		g.AddFinding(f.id, graph.Finding{Heuristic: h.Name(), Reason: f.reason, Score: 40})
+
+		node := g.GetNode(f.id)
+		if node == nil {
+			continue
+		}
+		g.Mu.Lock()
+		if node.IsWaste {
+			node.Properties["CIStack"] = f.stack
+			stats.ItemsFound++
+			stats.ProjectedSavings += node.Cost
+		}
+		g.Mu.Unlock()
+	}
+	return stats, nil
+}
+
+func isCINode(n *graph.Node) bool {
+	t := n.TypeStr()
+	return t == "AWS::CodeBuild::Project" || t == "AWS::CodePipeline::Pipeline"
+}
+
+// lastCIActivity falls back to the creation date for resources that never ran.
+// A resource whose activity the scanner could not read counts as recent.
+func lastCIActivity(n *graph.Node) time.Time {
+	if unknown, _ := n.Properties["ActivityUnknown"].(bool); unknown {
+		return time.Now()
+	}
+	for _, key := range []string{"LastBuildTime", "LastExecutionTime", "Created"} {
+		if t, ok := n.Properties[key].(time.Time); ok && !t.IsZero() {
+			return t
+		}
+	}
+	// Unknown age: treat as recent rather than guess.
+	return time.Now()
+}
+
+func uniqueSorted(in []string) []string {
+	sort.Strings(in)
+	out := in[:0]
+	for i, s := range in {
+		if i == 0 || s != in[i-1] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
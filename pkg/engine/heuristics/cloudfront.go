@@ -0,0 +1,135 @@
+package heuristics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	internalaws "github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+const (
+	cloudFrontWindow = 14 * 24 * time.Hour
+	// cloudFrontIdleRequests is the request count below which a distribution is idle
+	// (health checks and crawlers never quite reach zero).
+	cloudFrontIdleRequests = 100
+
+	// Approximate North America rates; CloudFront has no fixed monthly fee.
+	cloudFrontPerTenKRequests = 0.01
+	cloudFrontPerGB           = 0.085
+)
+
+// CloudFrontUsage is a distribution's traffic over the lookback window.
+type CloudFrontUsage struct {
+	Requests float64
+	Bytes    float64
+}
+
+// CloudFrontHeuristic flags distributions whose origins the scanner confirmed
+// gone (UnresolvedOrigins) and distributions that served almost no requests.
+// CW must be a us-east-1 client, where CloudFront publishes its metrics;
+// without it only origins are checked.
+type CloudFrontHeuristic struct {
+	CW     *internalaws.CloudWatchClient
+	Window time.Duration // Metric lookback; zero means cloudFrontWindow.
+}
+
+func (h *CloudFrontHeuristic) Name() string { return "CloudFrontHeuristic" }
+
+func (h *CloudFrontHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	type candidate struct {
+		id   string
+		dims string
+		cw   *internalaws.CloudWatchClient // us-east-1 in the distribution's account.
+	}
+	var candidates []candidate
+	g.Mu.RLock()
+	for _, node := range g.Store.GetAllNodes() {
+		if node.TypeStr() != "AWS::CloudFront::Distribution" {
+			continue
+		}
+		dims, _ := node.Properties["MetricDimensions"].([]string)
+		if len(dims) > 0 {
+			candidates = append(candidates, candidate{node.IDStr(), dims[0], h.CW.For(NodeAccount(node), "")})
+		}
+	}
+	g.Mu.RUnlock()
+
+	// Only distributions with a successful metric read are judged idle.
+	usage := make(map[string]CloudFrontUsage)
+	window := metricWindow(h.Window, cloudFrontWindow)
+	if h.CW != nil {
+		now := time.Now()
+		start := now.Add(-window)
+		for _, c := range candidates {
+			dims := internalaws.DecodeMetricDimensions(c.dims)
+			requests, err := c.cw.GetMetricSum(ctx, "AWS/CloudFront", "Requests", dims, start, now)
+			if err != nil {
+				continue
+			}
+			bytes, err := c.cw.GetMetricSum(ctx, "AWS/CloudFront", "BytesDownloaded", dims, start, now)
+			if err != nil {
+				continue
+			}
+			usage[c.id] = CloudFrontUsage{Requests: requests, Bytes: bytes}
+		}
+	}
+
+	return applyCloudFront(g, usage, window), nil
+}
+
+// applyCloudFront marks distributions with orphaned origins (risk 70) or idle
+// traffic (risk 40). Cost is the projected monthly request and transfer charge.
+// window is the lookback usage was measured over.
+func applyCloudFront(g *graph.Graph, usage map[string]CloudFrontUsage, window time.Duration) *HeuristicStats {
+	stats := &HeuristicStats{}
+
+	var pending []pendingFinding
+	g.Mu.RLock()
+	for _, node := range g.Store.GetAllNodes() {
+		if node.TypeStr() != "AWS::CloudFront::Distribution" || node.IsWaste {
+			continue
+		}
+
+		// An origin missing from the graph may live in another account, so only
+		// origins the scanner confirmed gone count.
+		orphaned, _ := node.Properties["UnresolvedOrigins"].([]string)
+
+		name, _ := node.Properties["Name"].(string)
+		enabled, _ := node.Properties["Enabled"].(bool)
+		u, measured := usage[node.IDStr()]
+		monthly := 0.0
+		if measured {
+			scale := (30 * 24 * time.Hour).Hours() / window.Hours()
+			monthly = (u.Requests/10000*cloudFrontPerTenKRequests + u.Bytes/(1<<30)*cloudFrontPerGB) * scale
+		}
+
+		finding := graph.Finding{Heuristic: "CloudFrontHeuristic"}
+		switch {
+		case len(orphaned) > 0:
+			finding.Score = 70
+			finding.Savings = monthly
+			finding.Reason = fmt.Sprintf("Orphaned CloudFront Origin: distribution %s points at origins that no longer exist (%s). Requests still reach CloudFront and are billed.",
+				name, strings.Join(orphaned, ", "))
+		case !enabled:
+			finding.Score = 30
+			finding.Reason = fmt.Sprintf("Disabled CloudFront Distribution: %s is disabled and serves no traffic. Delete it if it is not being kept for a rollback.", name)
+		case measured && u.Requests < cloudFrontIdleRequests:
+			finding.Score = 40
+			finding.Savings = monthly
+			finding.Reason = fmt.Sprintf("Idle CloudFront Distribution: %s served %.0f requests in %s.",
+				name, u.Requests, windowLabel(window))
+		default:
+			continue
+		}
+		pending = append(pending, pendingFinding{node.IDStr(), finding})
+	}
+	g.Mu.RUnlock()
+
+	for _, f := range pending {
+		stats.record(g, f.id, f.Finding)
+	}
+	return stats
+}
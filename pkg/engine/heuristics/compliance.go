@@ -0,0 +1,53 @@
+package heuristics
+
+import (
+	"context"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// EncryptionComplianceHeuristic flags EBS volumes, RDS instances and S3
+// buckets stored without encryption. Findings are compliance violations,
+// not waste: they carry no cost and are reported apart from cost findings.
+// Resources whose encryption status was not read are skipped.
+type EncryptionComplianceHeuristic struct{}
+
+func (h *EncryptionComplianceHeuristic) Name() string { return "EncryptionComplianceHeuristic" }
+
+func (h *EncryptionComplianceHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	findings := make(map[string]string)
+
+	g.Mu.RLock()
+	for _, node := range g.Store.GetAllNodes() {
+		if reason := encryptionViolation(node); reason != "" {
+			findings[node.IDStr()] = reason
+		}
+	}
+	g.Mu.RUnlock()
+
+	for id, reason := range findings {
+		g.MarkCompliance(id, reason)
+	}
+	return &HeuristicStats{ItemsFound: len(findings)}, nil
+}
+
+// encryptionViolation describes why node fails the encryption check, or
+// returns "" when it passes or its status is unknown.
+func encryptionViolation(node *graph.Node) string {
+	switch node.TypeStr() {
+	case "AWS::EC2::Volume":
+		if encrypted, ok := node.Properties["Encrypted"].(bool); ok && !encrypted {
+			return "EBS volume is not encrypted"
+		}
+	case "AWS::RDS::DBInstance":
+		if encrypted, ok := node.Properties["StorageEncrypted"].(bool); ok && !encrypted {
+			return "RDS storage is not encrypted"
+		}
+	case "AWS::S3::Bucket":
+		// Empty when the bucket has no default encryption configuration.
+		if algorithm, ok := node.Properties["DefaultEncryption"].(string); ok && algorithm == "" {
+			return "S3 bucket has no default encryption"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,107 @@
+package heuristics
+
+import (
+	"context"
+	"sort"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// runCostAllocation is the --tag-from-cost-allocation mode of TagComplianceHeuristic.
+// Spend on a resource missing an activated cost-allocation tag cannot be charged
+// back; it is annotated as unattributable rather than marked as waste.
+func (h *TagComplianceHeuristic) runCostAllocation(ctx context.Context, g *graph.Graph) *HeuristicStats {
+	return applyCostAllocation(g, h.CostAllocationTags, func(node *graph.Node) float64 {
+		return h.monthlyCost(ctx, node)
+	})
+}
+
+// monthlyCost is the node's known cost, or its on-demand price for the
+// resource types most spend sits on. Other resources count as free.
+func (h *TagComplianceHeuristic) monthlyCost(ctx context.Context, node *graph.Node) float64 {
+	if node.Cost > 0 || h.Pricing == nil {
+		return node.Cost
+	}
+	region := NodeRegion(node, h.Region)
+
+	var price float64
+	var err error
+	switch node.TypeStr() {
+	case "AWS::EC2::Instance":
+		if state, _ := node.Properties["State"].(string); state != "running" {
+			return 0
+		}
+		instanceType, _ := node.Properties["Type"].(string)
+		platform, _ := node.Properties["PlatformDetails"].(string)
+		price, err = h.Pricing.GetEC2InstancePriceForPlatform(ctx, region, instanceType, platform)
+	case "AWS::EC2::Volume":
+		volumeType, _ := node.Properties["VolumeType"].(string)
+		size, _ := node.Properties["Size"].(int32)
+		price, err = h.Pricing.GetEBSPrice(ctx, region, volumeType, int(size))
+	case "AWS::RDS::DBInstance":
+		class, _ := node.Properties["InstanceClass"].(string)
+		engine, _ := node.Properties["Engine"].(string)
+		price, err = h.Pricing.GetRDSInstancePrice(ctx, region, class, engine)
+	case "aws_nat_gateway":
+		price, err = h.Pricing.GetNATGatewayPrice(ctx, region)
+	default:
+		return 0
+	}
+	if err != nil {
+		return 0
+	}
+	return price
+}
+
+// applyCostAllocation annotates every cost-bearing node that is missing any of
+// the given tags with MissingCostTags and UnattributableCost.
+// ProjectedSavings carries the unattributable spend total.
+func applyCostAllocation(g *graph.Graph, tags []string, costOf func(*graph.Node) float64) *HeuristicStats {
+	stats := &HeuristicStats{}
+
+	type gap struct {
+		node    *graph.Node
+		missing []string
+	}
+	var gaps []gap
+	g.Mu.RLock()
+	for _, node := range g.Store.GetAllNodes() {
+		nodeTags, ok := node.Properties["Tags"].(map[string]string)
+		if !ok {
+			// Untagged EC2 resources omit the map; elsewhere tags were not collected.
+			if node.TypeStr() != "AWS::EC2::Instance" && node.TypeStr() != "AWS::EC2::Volume" {
+				continue
+			}
+		}
+		var missing []string
+		for _, t := range tags {
+			if _, ok := nodeTags[t]; !ok {
+				missing = append(missing, t)
+			}
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			gaps = append(gaps, gap{node, missing})
+		}
+	}
+	g.Mu.RUnlock()
+
+	// Pricing lookups may hit the network; resolve them outside the lock.
+	costs := make([]float64, len(gaps))
+	for i, gp := range gaps {
+		costs[i] = costOf(gp.node)
+	}
+
+	g.Mu.Lock()
+	defer g.Mu.Unlock()
+	for i, gp := range gaps {
+		if costs[i] <= 0 {
+			continue
+		}
+		gp.node.Properties["MissingCostTags"] = gp.missing
+		gp.node.Properties["UnattributableCost"] = costs[i]
+		stats.ItemsFound++
+		stats.ProjectedSavings += costs[i]
+	}
+	return stats
+}
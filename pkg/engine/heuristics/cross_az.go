@@ -0,0 +1,147 @@
+package heuristics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	internalaws "github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+const (
+	crossAZWindow     = 7 * 24 * time.Hour
+	crossAZPerGB      = 0.02 // $0.01/GB charged on each side of the AZ boundary.
+	crossAZMinMonthly = 10.0 // Ignore pairs below this; not worth re-architecting for.
+)
+
+// CrossAZTransferHeuristic finds instance pairs in different AZs that exchange
+// enough traffic for inter-AZ transfer charges to matter. Findings are
+// placement recommendations, not waste: both instances are busy.
+// It reads VPC Flow Logs, so it only runs when a flow log group is given. The
+// group is queried in every scanned account and region, and each log's flows
+// are matched only to instances in that account and region.
+type CrossAZTransferHeuristic struct {
+	Logs     []*internalaws.CloudWatchLogsClient
+	LogGroup string
+}
+
+func (h *CrossAZTransferHeuristic) Name() string { return "CrossAZTransferHeuristic" }
+
+func (h *CrossAZTransferHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	stats := &HeuristicStats{}
+	if h.LogGroup == "" {
+		return stats, nil
+	}
+
+	var errs []error
+	for _, logs := range h.Logs {
+		flows, err := logs.QueryFlowTotals(ctx, h.LogGroup, crossAZWindow)
+		if err != nil {
+			// The group need not exist in every region.
+			errs = append(errs, fmt.Errorf("%s: %v", logs.Region, err))
+			continue
+		}
+		s := applyCrossAZTransfer(g, flows, crossAZWindow, logs.AccountID, logs.Region)
+		stats.ItemsFound += s.ItemsFound
+		stats.ProjectedSavings += s.ProjectedSavings
+	}
+	if len(errs) > 0 && len(errs) == len(h.Logs) {
+		return nil, errors.Join(errs...)
+	}
+	return stats, nil
+}
+
+// applyCrossAZTransfer maps flow addresses to the instances of one account
+// and region and records cross-AZ hotspots. An empty account or region
+// matches any. Each pair is attributed to one instance (the lower ID) so
+// costs are not double counted.
+func applyCrossAZTransfer(g *graph.Graph, flows []internalaws.FlowTotal, window time.Duration, account, region string) *HeuristicStats {
+	stats := &HeuristicStats{}
+
+	g.Mu.Lock()
+	defer g.Mu.Unlock()
+
+	byIP := make(map[string]*graph.Node)
+	for _, node := range g.Store.GetAllNodes() {
+		if node.TypeStr() != "AWS::EC2::Instance" {
+			continue
+		}
+		az, _ := node.Properties["AvailabilityZone"].(string)
+		if az == "" || (region != "" && !strings.HasPrefix(az, region)) {
+			continue
+		}
+		if account != "" && NodeAccount(node) != "" && NodeAccount(node) != account {
+			continue
+		}
+		ips, _ := node.Properties["PrivateIPs"].([]string)
+		for _, ip := range ips {
+			byIP[ip] = node
+		}
+	}
+
+	// Sum both directions per unordered instance pair.
+	type pair struct{ a, b *graph.Node }
+	pairBytes := make(map[pair]float64)
+	for _, f := range flows {
+		src, dst := byIP[f.SrcAddr], byIP[f.DstAddr]
+		if src == nil || dst == nil || src == dst {
+			continue
+		}
+		if src.Properties["AvailabilityZone"] == dst.Properties["AvailabilityZone"] {
+			continue
+		}
+		if src.IDStr() > dst.IDStr() {
+			src, dst = dst, src
+		}
+		pairBytes[pair{src, dst}] += f.Bytes
+	}
+
+	type hotspot struct {
+		peer    *graph.Node
+		monthly float64
+		gb      float64
+	}
+	byOwner := make(map[*graph.Node][]hotspot)
+	scale := float64(30*24*time.Hour) / float64(window)
+	for p, bytes := range pairBytes {
+		gb := bytes / 1e9 * scale
+		monthly := gb * crossAZPerGB
+		if monthly < crossAZMinMonthly {
+			continue
+		}
+		byOwner[p.a] = append(byOwner[p.a], hotspot{peer: p.b, monthly: monthly, gb: gb})
+	}
+
+	for node, spots := range byOwner {
+		if node.IsWaste {
+			// Already flagged for removal; co-location is moot.
+			continue
+		}
+		if _, ok := node.Properties["CrossAZPeers"]; ok {
+			continue
+		}
+		sort.Slice(spots, func(i, j int) bool { return spots[i].monthly > spots[j].monthly })
+
+		var total float64
+		var peers, details []string
+		for _, s := range spots {
+			total += s.monthly
+			peers = append(peers, s.peer.IDStr())
+			details = append(details, fmt.Sprintf("%s in %v: %.0f GB/mo, $%.2f/mo", s.peer.IDStr(), s.peer.Properties["AvailabilityZone"], s.gb, s.monthly))
+		}
+
+		// Moving an instance is a placement change, never a stop or delete.
+		node.RiskScore = 10
+		node.Cost = total
+		node.Properties["CrossAZPeers"] = peers
+		node.Properties["Reason"] = fmt.Sprintf("Cross-AZ Transfer Hotspot: %v exchanges traffic with instances in other AZs (%s). Co-locate them in one AZ to save $%.2f/mo.", node.Properties["AvailabilityZone"], strings.Join(details, "; "), total)
+
+		stats.ItemsFound++
+		stats.ProjectedSavings += total
+	}
+	return stats
+}
@@ -0,0 +1,55 @@
+package heuristics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// DanglingDNSHeuristic flags Route53 records whose alias or CNAME target is a
+// load balancer or CloudFront distribution that no longer exists. Whoever
+// recreates that name can serve traffic for the record (subdomain takeover).
+type DanglingDNSHeuristic struct{}
+
+func (h *DanglingDNSHeuristic) Name() string { return "DanglingDNSHeuristic" }
+
+func (h *DanglingDNSHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	return applyDanglingDNS(g), nil
+}
+
+// applyDanglingDNS flags records carrying a DanglingTarget. Records cost
+// nothing; deleting them removes the takeover risk.
+func applyDanglingDNS(g *graph.Graph) *HeuristicStats {
+	stats := &HeuristicStats{}
+
+	// Evidence goes on the node first, so the waste listener sees it.
+	var pending []pendingFinding
+	g.Mu.Lock()
+	for _, node := range g.Store.GetAllNodes() {
+		if node.TypeStr() != "AWS::Route53::RecordSet" || node.IsWaste {
+			continue
+		}
+		target, _ := node.Properties["DanglingTarget"].(string)
+		if target == "" {
+			continue
+		}
+		name, _ := node.Properties["Name"].(string)
+		recordType, _ := node.Properties["Type"].(string)
+		zone, _ := node.Properties["Zone"].(string)
+
+		node.Properties["DNSRisk"] = "SubdomainTakeover"
+		pending = append(pending, pendingFinding{node.IDStr(), graph.Finding{
+			Heuristic: "DanglingDNSHeuristic",
+			Reason: fmt.Sprintf("Dangling DNS: %s record %s in zone %s points at %s, which no longer exists. Delete the record (subdomain takeover risk).",
+				recordType, name, zone, target),
+			Score: 80,
+		}})
+	}
+	g.Mu.Unlock()
+
+	for _, f := range pending {
+		stats.record(g, f.id, f.Finding)
+	}
+	return stats
+}
@@ -0,0 +1,181 @@
+package heuristics
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/pricing"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed deprecations.yaml
+var defaultDeprecations []byte
+
+// DeprecationRule describes one deprecated or sunsetting service.
+type DeprecationRule struct {
+	ID           string            `yaml:"id"`
+	Service      string            `yaml:"service"`
+	ResourceType string            `yaml:"resource_type"`
+	Property     string            `yaml:"property"`
+	Values       []string          `yaml:"values,omitempty"`
+	Prefixes     []string          `yaml:"prefixes,omitempty"`
+	Present      bool              `yaml:"present,omitempty"`
+	When         map[string]string `yaml:"when,omitempty"`
+	Deadline     string            `yaml:"deadline,omitempty"`
+	Migration    string            `yaml:"migration"`
+}
+
+type deprecationFile struct {
+	Deprecations []DeprecationRule `yaml:"deprecations"`
+}
+
+// LoadDeprecationRules returns the built-in deprecation list, merged with path if given.
+// Entries in path replace built-in entries with the same id; new ids are appended.
+func LoadDeprecationRules(path string) ([]DeprecationRule, error) {
+	var base deprecationFile
+	if err := yaml.Unmarshal(defaultDeprecations, &base); err != nil {
+		return nil, fmt.Errorf("failed to parse built-in deprecations: %v", err)
+	}
+	if path == "" {
+		return base.Deprecations, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deprecations file: %v", err)
+	}
+	var user deprecationFile
+	if err := yaml.Unmarshal(data, &user); err != nil {
+		return nil, fmt.Errorf("failed to parse deprecations file %s: %v", path, err)
+	}
+
+	rules := base.Deprecations
+	index := make(map[string]int, len(rules))
+	for i, r := range rules {
+		index[r.ID] = i
+	}
+	for _, r := range user.Deprecations {
+		if r.ResourceType == "" || r.Property == "" {
+			return nil, fmt.Errorf("deprecation %q: resource_type and property are required", r.ID)
+		}
+		if i, ok := index[r.ID]; ok && r.ID != "" {
+			rules[i] = r
+			continue
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+// Matches reports whether node uses the deprecated service described by r.
+func (r DeprecationRule) Matches(node *graph.Node) bool {
+	if node.TypeStr() != r.ResourceType {
+		return false
+	}
+	for k, want := range r.When {
+		if got := fmt.Sprint(node.Properties[k]); !strings.EqualFold(got, want) {
+			return false
+		}
+	}
+
+	v, ok := node.Properties[r.Property]
+	if !ok || v == nil {
+		return false
+	}
+	if r.Present {
+		return true
+	}
+	s := fmt.Sprint(v)
+	for _, want := range r.Values {
+		if s == want {
+			return true
+		}
+	}
+	for _, p := range r.Prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// DeprecationHeuristic flags resources running on deprecated or sunsetting services.
+// These are migration risks, not waste: nodes are annotated but never marked for deletion.
+type DeprecationHeuristic struct {
+	Rules   []DeprecationRule
+	Pricing *pricing.Client
+	Region  string // Scan region; prices nodes that carry no region of their own.
+}
+
+func (h *DeprecationHeuristic) Name() string { return "DeprecationHeuristic" }
+
+func (h *DeprecationHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	return applyDeprecations(g, h.Rules, func(node *graph.Node) float64 {
+		return h.costAtStake(ctx, node)
+	}), nil
+}
+
+// costAtStake is the node's known monthly cost, or the on-demand price for
+// EC2 instances, which are not costed unless another heuristic flagged them.
+func (h *DeprecationHeuristic) costAtStake(ctx context.Context, node *graph.Node) float64 {
+	if node.Cost > 0 || h.Pricing == nil || node.TypeStr() != "AWS::EC2::Instance" {
+		return node.Cost
+	}
+	instanceType, _ := node.Properties["Type"].(string)
+	if instanceType == "" {
+		return 0
+	}
+	price, err := h.Pricing.GetEC2InstancePrice(ctx, NodeRegion(node, h.Region), instanceType)
+	if err != nil {
+		return 0
+	}
+	return price
+}
+
+// applyDeprecations annotates every node matching a rule with DeprecationRisk,
+// DeprecationMigration, DeprecationDeadline and DeprecationCost.
+// The first matching rule wins.
+func applyDeprecations(g *graph.Graph, rules []DeprecationRule, costOf func(*graph.Node) float64) *HeuristicStats {
+	stats := &HeuristicStats{}
+
+	type match struct {
+		node *graph.Node
+		rule DeprecationRule
+	}
+	var matches []match
+	g.Mu.RLock()
+	for _, node := range g.Store.GetAllNodes() {
+		for _, r := range rules {
+			if r.Matches(node) {
+				matches = append(matches, match{node, r})
+				break
+			}
+		}
+	}
+	g.Mu.RUnlock()
+
+	// Pricing lookups may hit the network; resolve them outside the lock.
+	costs := make([]float64, len(matches))
+	for i, m := range matches {
+		if costOf != nil {
+			costs[i] = costOf(m.node)
+		}
+	}
+
+	g.Mu.Lock()
+	defer g.Mu.Unlock()
+	for i, m := range matches {
+		m.node.Properties["DeprecationRisk"] = m.rule.Service
+		m.node.Properties["DeprecationMigration"] = m.rule.Migration
+		if m.rule.Deadline != "" {
+			m.node.Properties["DeprecationDeadline"] = m.rule.Deadline
+		}
+		m.node.Properties["DeprecationCost"] = costs[i]
+		stats.ItemsFound++
+	}
+	return stats
+}
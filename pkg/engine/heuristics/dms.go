@@ -0,0 +1,137 @@
+package heuristics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	internalaws "github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/pricing"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+const dmsWindow = 14 * 24 * time.Hour
+
+// dmsTaskMetrics are summed per task; any non-zero value means data moved.
+var dmsTaskMetrics = []string{"FullLoadThroughputRowsSource", "CDCThroughputRowsSource", "CDCLatencySource"}
+
+// IdleDMSHeuristic detects replication instances left running after a migration.
+// An instance is idle when none of its tasks is running and none moved data in
+// the lookback window (14 days by default).
+type IdleDMSHeuristic struct {
+	CW      *internalaws.CloudWatchClient
+	Pricing *pricing.Client
+	Window  time.Duration // Metric lookback; zero means dmsWindow.
+}
+
+func (h *IdleDMSHeuristic) Name() string { return "IdleDMSHeuristic" }
+
+func (h *IdleDMSHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	type candidate struct {
+		id, identifier, region, class string
+		multiAZ                       bool
+		tasks                         []string
+		cw                            *internalaws.CloudWatchClient
+	}
+
+	now := time.Now()
+	window := metricWindow(h.Window, dmsWindow)
+	var candidates []candidate
+	g.Mu.RLock()
+	for _, node := range g.Store.GetAllNodes() {
+		if node.TypeStr() != "AWS::DMS::ReplicationInstance" {
+			continue
+		}
+		if status, _ := node.Properties["Status"].(string); status != "available" {
+			continue
+		}
+		if n, _ := node.Properties["ActiveTasks"].(int); n > 0 {
+			continue
+		}
+		if created, ok := node.CreatedAt(); ok && now.Sub(created) < window {
+			continue
+		}
+		c := candidate{id: node.IDStr(), cw: scopedCW(h.CW, node)}
+		c.identifier, _ = node.Properties["Identifier"].(string)
+		c.region, _ = node.Properties["Region"].(string)
+		c.class, _ = node.Properties["InstanceClass"].(string)
+		c.multiAZ, _ = node.Properties["MultiAZ"].(bool)
+		c.tasks, _ = node.Properties["ReplicationTaskIDs"].([]string)
+		candidates = append(candidates, c)
+	}
+	g.Mu.RUnlock()
+
+	idle := make(map[string]float64)
+	for _, c := range candidates {
+		// Stopped tasks may still have moved data inside the window.
+		if len(c.tasks) > 0 {
+			if c.cw == nil || dmsTasksMovedData(ctx, c.cw, c.identifier, c.tasks, now.Add(-window), now) {
+				continue
+			}
+		}
+
+		cost := pricing.EstimateDMSInstancePrice(c.class, c.multiAZ)
+		if h.Pricing != nil && c.region != "" {
+			if p, err := h.Pricing.GetDMSInstancePrice(ctx, c.region, c.class, c.multiAZ); err == nil {
+				cost = p
+			}
+		}
+		idle[c.id] = cost
+	}
+
+	return applyIdleDMS(g, idle, window), nil
+}
+
+// dmsTasksMovedData reports whether any task shows throughput or CDC latency.
+// Metric errors count as activity so that missing data never flags an instance.
+func dmsTasksMovedData(ctx context.Context, cw *internalaws.CloudWatchClient, instance string, tasks []string, start, end time.Time) bool {
+	for _, task := range tasks {
+		dims := []types.Dimension{
+			{Name: aws.String("ReplicationInstanceIdentifier"), Value: aws.String(instance)},
+			{Name: aws.String("ReplicationTaskIdentifier"), Value: aws.String(task)},
+		}
+		for _, metric := range dmsTaskMetrics {
+			sum, err := cw.GetMetricSum(ctx, "AWS/DMS", metric, dims, start, end)
+			if err != nil || sum > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// applyIdleDMS flags the given replication instances at their monthly cost.
+// window is the lookback their tasks were measured over.
+func applyIdleDMS(g *graph.Graph, idle map[string]float64, window time.Duration) *HeuristicStats {
+	stats := &HeuristicStats{}
+
+	var findings []pendingFinding
+	g.Mu.RLock()
+	for id, cost := range idle {
+		node := g.GetNode(id)
+		if node == nil || node.IsWaste {
+			continue
+		}
+		class, _ := node.Properties["InstanceClass"].(string)
+		tasks, _ := node.Properties["ReplicationTaskIDs"].([]string)
+
+		reason := fmt.Sprintf("Idle DMS Replication Instance: %s has no replication tasks. Migration leftover costing $%.2f/mo.", class, cost)
+		if len(tasks) > 0 {
+			reason = fmt.Sprintf("Idle DMS Replication Instance: %s has %d stopped task(s) and moved no data in %s. Migration leftover costing $%.2f/mo.", class, len(tasks), windowLabel(window), cost)
+		}
+		findings = append(findings, pendingFinding{id, graph.Finding{
+			Heuristic: "IdleDMSHeuristic",
+			Reason:    reason,
+			Score:     75,
+			Savings:   cost,
+		}})
+	}
+	g.Mu.RUnlock()
+
+	for _, f := range findings {
+		stats.record(g, f.id, f.Finding)
+	}
+	return stats
+}
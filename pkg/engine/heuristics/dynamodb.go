@@ -0,0 +1,362 @@
+package heuristics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	internalaws "github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/pricing"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+const (
+	dynamoDBWindow = 30 * 24 * time.Hour
+
+	// dynamoDBLowUtilization is the fraction of provisioned capacity below
+	// which a table is over-provisioned.
+	dynamoDBLowUtilization = 0.15
+
+	// dynamoDBTargetUtilization sizes lowered capacity so average use lands at
+	// auto scaling's default target, leaving headroom for peaks.
+	dynamoDBTargetUtilization = 0.70
+
+	// dynamoDBFreeTierUnits is the capacity the free tier covers per account;
+	// tables at or under it in both dimensions cost next to nothing.
+	dynamoDBFreeTierUnits = 25
+)
+
+// DynamoDBHeuristic flags tables that served no reads or writes over the
+// metric window, and provisioned-mode tables using a small fraction of their
+// capacity. Usage covers the table and its global secondary indexes. Without
+// CloudWatch (mock mode) the usage recorded on the node is used.
+type DynamoDBHeuristic struct {
+	CW      *internalaws.CloudWatchClient
+	Pricing *pricing.Client
+	Region  string        // Scan region; prices tables that carry no region of their own.
+	Window  time.Duration // Metric lookback; zero means dynamoDBWindow.
+}
+
+func (h *DynamoDBHeuristic) Name() string { return "DynamoDBHeuristic" }
+
+// dynamoDBFinding is the evidence for one flagged table. Cost is the table's
+// monthly cost when dead, or the saving of the cheaper configuration.
+type dynamoDBFinding struct {
+	Reads, Writes float64 // Consumed capacity units over the window.
+	Dead          bool
+	Utilization   float64
+	BillingMode   string  // Recommended mode when over-provisioned.
+	RCU, WCU      float64 // Recommended capacity when staying provisioned.
+	Cost          float64
+}
+
+// dynamoDBUsage is the capacity a table and its indexes consumed over the window.
+type dynamoDBUsage struct {
+	Reads, Writes float64
+}
+
+type dynamoDBCandidate struct {
+	id, name, region string
+	indexes          []string
+	provisioned      bool
+	autoScaling      bool
+	canBeDead        bool
+	rcu, wcu         float64 // Table and index capacity combined.
+	storageGB        float64
+	recorded         *dynamoDBUsage
+	cw               *internalaws.CloudWatchClient
+}
+
+func (h *DynamoDBHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	now := time.Now()
+	window := metricWindow(h.Window, dynamoDBWindow)
+	var candidates []dynamoDBCandidate
+	g.Mu.RLock()
+	for _, node := range g.Store.GetAllNodes() {
+		if node.TypeStr() != "aws_dynamodb_table" || node.IsWaste {
+			continue
+		}
+		if status, _ := node.Properties["TableStatus"].(string); status != "ACTIVE" {
+			continue
+		}
+		c := dynamoDBCandidate{id: node.IDStr(), region: NodeRegion(node, h.Region), cw: scopedCW(h.CW, node)}
+		c.name, _ = node.Properties["TableName"].(string)
+		c.indexes, _ = node.Properties["GlobalSecondaryIndexes"].([]string)
+		mode, _ := node.Properties["BillingMode"].(string)
+		c.provisioned = mode == "PROVISIONED"
+		c.autoScaling, _ = node.Properties["HasAutoScaling"].(bool)
+		rcu, _ := node.Properties["ProvisionedRCU"].(float64)
+		wcu, _ := node.Properties["ProvisionedWCU"].(float64)
+		gsiRCU, _ := node.Properties["GSIProvisionedRCU"].(float64)
+		gsiWCU, _ := node.Properties["GSIProvisionedWCU"].(float64)
+		c.rcu, c.wcu = rcu+gsiRCU, wcu+gsiWCU
+		size, _ := node.Properties["TableSizeBytes"].(int64)
+		c.storageGB = float64(size) / (1 << 30)
+
+		// A table younger than the window has not had the chance to be used,
+		// and a global table's replicas may be the ones serving traffic.
+		created, ok := node.CreatedAt()
+		young := ok && now.Sub(created) < window
+		replicas, _ := node.Properties["Replicas"].(int)
+		c.canBeDead = !young && replicas == 0
+		if !c.canBeDead && !c.provisioned {
+			continue
+		}
+
+		if h.CW == nil {
+			reads, okR := node.Properties["ConsumedReadUnits"].(float64)
+			writes, okW := node.Properties["ConsumedWriteUnits"].(float64)
+			if !okR || !okW {
+				continue
+			}
+			c.recorded = &dynamoDBUsage{Reads: reads, Writes: writes}
+		}
+		candidates = append(candidates, c)
+	}
+	g.Mu.RUnlock()
+
+	usage, err := h.usage(ctx, candidates, now.Add(-window), now)
+	if err != nil {
+		if internalaws.IsThrottleError(err) {
+			g.AddError(fmt.Sprintf("CloudWatch [%s]", h.Name()), err)
+		}
+		return &HeuristicStats{}, nil
+	}
+
+	// Pricing calls hit the network; resolve them outside the lock.
+	seconds := window.Seconds()
+	months := window.Hours() / pricing.HoursPerMonth
+	findings := make(map[string]dynamoDBFinding)
+	for _, c := range candidates {
+		u, ok := usage[c.id]
+		if !ok {
+			continue
+		}
+		f := dynamoDBFinding{Reads: u.Reads, Writes: u.Writes}
+		var current float64
+		if c.provisioned {
+			current = h.provisionedPrice(ctx, c.region, c.rcu, c.wcu, c.storageGB)
+		} else {
+			current = h.onDemandPrice(ctx, c.region, 0, 0, c.storageGB)
+		}
+
+		if u.Reads == 0 && u.Writes == 0 {
+			if !c.canBeDead || current == 0 {
+				continue
+			}
+			f.Dead = true
+			f.Cost = current
+			findings[c.id] = f
+			continue
+		}
+
+		if !c.provisioned || c.rcu+c.wcu == 0 || (c.rcu <= dynamoDBFreeTierUnits && c.wcu <= dynamoDBFreeTierUnits) {
+			continue
+		}
+		f.Utilization = (u.Reads + u.Writes) / ((c.rcu + c.wcu) * seconds)
+		if f.Utilization >= dynamoDBLowUtilization {
+			continue
+		}
+
+		// Lowered capacity keeps average use at the target, and at least one unit.
+		f.RCU = math.Max(1, math.Ceil(u.Reads/seconds/dynamoDBTargetUtilization))
+		f.WCU = math.Max(1, math.Ceil(u.Writes/seconds/dynamoDBTargetUtilization))
+		f.BillingMode = "PROVISIONED"
+		best := h.provisionedPrice(ctx, c.region, f.RCU, f.WCU, c.storageGB)
+		// With auto scaling the fix is a lower minimum; switching mode would drop it.
+		if !c.autoScaling {
+			if onDemand := h.onDemandPrice(ctx, c.region, u.Reads/months, u.Writes/months, c.storageGB); onDemand < best {
+				f.BillingMode = "PAY_PER_REQUEST"
+				best = onDemand
+			}
+		}
+		if f.Cost = current - best; f.Cost > 0 {
+			findings[c.id] = f
+		}
+	}
+
+	return applyDynamoDB(g, findings, window), nil
+}
+
+// usage reads consumed read and write capacity for each candidate's table and
+// indexes, keyed by node ID, in one batch per account and region. Without
+// CloudWatch it returns the recorded usage.
+//
+// DynamoDB publishes consumed capacity only while a table is used, so a table
+// without those datapoints counts as unused only when its provisioned
+// capacity, which is always published, proves the read reached its metrics.
+// Other tables are left out: their usage is unknown.
+func (h *DynamoDBHeuristic) usage(ctx context.Context, candidates []dynamoDBCandidate, start, end time.Time) (map[string]dynamoDBUsage, error) {
+	usage := make(map[string]dynamoDBUsage)
+	if h.CW == nil {
+		for _, c := range candidates {
+			if c.recorded != nil {
+				usage[c.id] = *c.recorded
+			}
+		}
+		return usage, nil
+	}
+
+	byCW := make(map[*internalaws.CloudWatchClient][]dynamoDBCandidate)
+	for _, c := range candidates {
+		byCW[c.cw] = append(byCW[c.cw], c)
+	}
+	for cw, group := range byCW {
+		if err := h.scopeUsage(ctx, cw, group, start, end, usage); err != nil {
+			return nil, err
+		}
+	}
+	return usage, nil
+}
+
+// scopeUsage reads usage for candidates in the account and region cw reads.
+func (h *DynamoDBHeuristic) scopeUsage(ctx context.Context, cw *internalaws.CloudWatchClient, candidates []dynamoDBCandidate, start, end time.Time, usage map[string]dynamoDBUsage) error {
+	type ref struct {
+		id    string
+		write bool
+		probe bool
+	}
+	refs := make(map[string]ref)
+	var queries []internalaws.MetricQuery
+	for i, c := range candidates {
+		if c.name == "" {
+			continue
+		}
+		dimSets := [][]types.Dimension{{{Name: aws.String("TableName"), Value: aws.String(c.name)}}}
+		for _, index := range c.indexes {
+			dimSets = append(dimSets, []types.Dimension{
+				{Name: aws.String("TableName"), Value: aws.String(c.name)},
+				{Name: aws.String("GlobalSecondaryIndexName"), Value: aws.String(index)},
+			})
+		}
+		for j, dims := range dimSets {
+			for _, metric := range []string{"ConsumedReadCapacityUnits", "ConsumedWriteCapacityUnits"} {
+				id := fmt.Sprintf("t%d_%d_%s", i, j, metric)
+				refs[id] = ref{id: c.id, write: metric == "ConsumedWriteCapacityUnits"}
+				queries = append(queries, internalaws.MetricQuery{
+					ID:         id,
+					Namespace:  "AWS/DynamoDB",
+					MetricName: metric,
+					Dimensions: dims,
+					Stat:       "Sum",
+					StartTime:  start,
+					EndTime:    end,
+				})
+			}
+		}
+		if c.provisioned {
+			id := fmt.Sprintf("t%d_provisioned", i)
+			refs[id] = ref{id: c.id, probe: true}
+			queries = append(queries, internalaws.MetricQuery{
+				ID:         id,
+				Namespace:  "AWS/DynamoDB",
+				MetricName: "ProvisionedReadCapacityUnits",
+				Dimensions: dimSets[0],
+				Stat:       "Maximum",
+				StartTime:  start,
+				EndTime:    end,
+			})
+		}
+	}
+	if len(queries) == 0 {
+		return nil
+	}
+
+	sums, err := cw.GetMetricDataBatchObserved(ctx, queries)
+	if err != nil {
+		return err
+	}
+	for id, r := range refs {
+		v, ok := sums[id]
+		if !ok {
+			continue
+		}
+		u := usage[r.id]
+		switch {
+		case r.probe:
+		case r.write:
+			u.Writes += v
+		default:
+			u.Reads += v
+		}
+		usage[r.id] = u
+	}
+	return nil
+}
+
+func (h *DynamoDBHeuristic) provisionedPrice(ctx context.Context, region string, rcu, wcu, storageGB float64) float64 {
+	if h.Pricing != nil {
+		if p, err := h.Pricing.GetDynamoDBPrice(ctx, region, rcu, wcu, storageGB); err == nil {
+			return p
+		}
+	}
+	return pricing.EstimateDynamoDBProvisionedPrice(rcu, wcu, storageGB)
+}
+
+func (h *DynamoDBHeuristic) onDemandPrice(ctx context.Context, region string, monthlyReads, monthlyWrites, storageGB float64) float64 {
+	if h.Pricing != nil {
+		if p, err := h.Pricing.GetDynamoDBOnDemandPrice(ctx, region, monthlyReads, monthlyWrites, storageGB); err == nil {
+			return p
+		}
+	}
+	return pricing.EstimateDynamoDBOnDemandPrice(monthlyReads, monthlyWrites, storageGB)
+}
+
+// applyDynamoDB marks dead tables as waste and over-provisioned tables for
+// review. window is the lookback usage was measured over.
+func applyDynamoDB(g *graph.Graph, findings map[string]dynamoDBFinding, window time.Duration) *HeuristicStats {
+	stats := &HeuristicStats{}
+
+	// Evidence goes on the node first, so the waste listener sees it.
+	var pending []pendingFinding
+	g.Mu.Lock()
+	for id, f := range findings {
+		node := g.GetNode(id)
+		if node == nil || node.IsWaste {
+			continue
+		}
+		name, _ := node.Properties["TableName"].(string)
+		items, _ := node.Properties["ItemCount"].(int64)
+
+		finding := graph.Finding{Heuristic: "DynamoDBHeuristic", Savings: f.Cost}
+		node.Properties["ConsumedReadUnits"] = f.Reads
+		node.Properties["ConsumedWriteUnits"] = f.Writes
+		switch {
+		case f.Dead && items == 0:
+			finding.Score = 75
+			finding.Reason = fmt.Sprintf("Unused DynamoDB Table: %s is empty and served no reads or writes in %s ($%.2f/mo).",
+				name, windowLabel(window), f.Cost)
+		case f.Dead:
+			// The data may still matter even if nothing reads it.
+			finding.Score = 50
+			finding.Reason = fmt.Sprintf("Unused DynamoDB Table: %s (%d items) served no reads or writes in %s ($%.2f/mo). Back up the table before deleting it.",
+				name, items, windowLabel(window), f.Cost)
+		case f.BillingMode == "PAY_PER_REQUEST":
+			finding.Score = 40
+			node.Properties["RecommendedBillingMode"] = f.BillingMode
+			finding.Reason = fmt.Sprintf("Over-provisioned DynamoDB Table: %s used %.1f%% of its provisioned capacity in %s. Recommendation: Switch to on-demand (saves $%.2f/mo).",
+				name, f.Utilization*100, windowLabel(window), f.Cost)
+		default:
+			finding.Score = 40
+			node.Properties["RecommendedBillingMode"] = f.BillingMode
+			node.Properties["RecommendedRCU"] = f.RCU
+			node.Properties["RecommendedWCU"] = f.WCU
+			action := "Lower provisioned capacity"
+			if hasAS, _ := node.Properties["HasAutoScaling"].(bool); hasAS {
+				action = "Lower the auto scaling minimum capacity"
+			}
+			finding.Reason = fmt.Sprintf("Over-provisioned DynamoDB Table: %s used %.1f%% of its provisioned capacity in %s. Recommendation: %s to %.0f RCU / %.0f WCU (saves $%.2f/mo).",
+				name, f.Utilization*100, windowLabel(window), action, f.RCU, f.WCU, f.Cost)
+		}
+		pending = append(pending, pendingFinding{id, finding})
+	}
+	g.Mu.Unlock()
+
+	for _, f := range pending {
+		stats.record(g, f.id, f.Finding)
+	}
+	return stats
+}
@@ -0,0 +1,226 @@
+package heuristics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	internalaws "github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/pricing"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+const (
+	// overallocMinSizeGB skips volumes too small for a migration to pay off.
+	overallocMinSizeGB = 100
+	// overallocMaxUsedPercent is the peak filesystem usage below which a volume is over-allocated.
+	overallocMaxUsedPercent = 10.0
+	overallocWindow         = 14 * 24 * time.Hour
+)
+
+// ebsPerGBEstimate is us-east-1 $/GB-month, used when the Pricing API is unavailable.
+var ebsPerGBEstimate = map[string]float64{
+	"gp3": 0.08, "gp2": 0.10, "io1": 0.125, "io2": 0.125, "st1": 0.045, "sc1": 0.015, "standard": 0.05,
+}
+
+// OverallocatedVolumeHeuristic recommends a smaller size for in-use EBS
+// volumes whose filesystems stay nearly empty, using the CloudWatch agent's
+// disk_used_percent. The volume is in use, so this is a resize recommendation,
+// not waste. Volumes on instances without the agent are annotated with
+// DiskMetricsMissing instead.
+type OverallocatedVolumeHeuristic struct {
+	CW      *internalaws.CloudWatchClient
+	Pricing *pricing.Client
+	Region  string        // Scan region; prices nodes that carry no region of their own.
+	Window  time.Duration // Metric lookback; zero means overallocWindow.
+}
+
+func (h *OverallocatedVolumeHeuristic) Name() string { return "OverallocatedVolumeHeuristic" }
+
+type overallocCandidate struct {
+	id, instance, device, volumeType, region string
+	cw                                       *internalaws.CloudWatchClient
+}
+
+func (h *OverallocatedVolumeHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	if h.CW == nil {
+		return &HeuristicStats{}, nil
+	}
+
+	byInstance := make(map[string][]overallocCandidate)
+	attached := make(map[string]int)
+	regionTypes := make(map[string][2]string) // Volume ID -> region, volume type.
+	g.Mu.RLock()
+	for _, node := range g.Store.GetAllNodes() {
+		if node.TypeStr() != "AWS::EC2::Volume" {
+			continue
+		}
+		instance, _ := node.Properties["AttachedInstanceId"].(string)
+		if state, _ := node.Properties["State"].(string); state != "in-use" || instance == "" {
+			continue
+		}
+		attached[instance]++
+		if node.IsWaste || volumeSize(node) < overallocMinSizeGB {
+			continue
+		}
+		c := overallocCandidate{id: node.IDStr(), instance: instance, region: NodeRegion(node, h.Region), cw: scopedCW(h.CW, node)}
+		c.device, _ = node.Properties["AttachedDevice"].(string)
+		c.volumeType, _ = node.Properties["VolumeType"].(string)
+		byInstance[instance] = append(byInstance[instance], c)
+		regionTypes[c.id] = [2]string{c.region, c.volumeType}
+	}
+	g.Mu.RUnlock()
+
+	usage := make(map[string]float64)
+	var missing []string
+	now := time.Now()
+	window := metricWindow(h.Window, overallocWindow)
+	for instance, candidates := range byInstance {
+		// An instance's volumes share its account and region.
+		cw := candidates[0].cw
+		sets, err := cw.ListMetricDimensions(ctx, "CWAgent", "disk_used_percent", map[string]string{"InstanceId": instance})
+		if err != nil {
+			continue
+		}
+		if len(sets) == 0 {
+			for _, c := range candidates {
+				missing = append(missing, c.id)
+			}
+			continue
+		}
+		for _, c := range candidates {
+			// With a single volume every filesystem is on it; otherwise match by device name.
+			matched := false
+			peak := 0.0
+			for _, dims := range sets {
+				if attached[instance] != 1 && !sameDevice(c.device, dimensionValue(dims, "device")) {
+					continue
+				}
+				v, err := cw.GetMetricMaxObserved(ctx, "CWAgent", "disk_used_percent", dims, now.Add(-window), now)
+				if err != nil {
+					matched = false
+					break
+				}
+				matched = true
+				peak = math.Max(peak, v)
+			}
+			if matched {
+				usage[c.id] = peak
+			}
+		}
+	}
+
+	prices := make(map[[2]string]float64)
+	perGB := make(map[string]float64, len(regionTypes))
+	for id, key := range regionTypes {
+		p, ok := prices[key]
+		if !ok {
+			p = h.pricePerGB(ctx, key[0], key[1])
+			prices[key] = p
+		}
+		perGB[id] = p
+	}
+	return applyOverallocatedVolumes(g, usage, missing, perGB, window), nil
+}
+
+// pricePerGB is the monthly $/GB for a volume type in region.
+func (h *OverallocatedVolumeHeuristic) pricePerGB(ctx context.Context, region, volumeType string) float64 {
+	if h.Pricing != nil {
+		if p, err := h.Pricing.GetEBSPrice(ctx, region, volumeType, 1); err == nil && p > 0 {
+			return p
+		}
+	}
+	if p, ok := ebsPerGBEstimate[volumeType]; ok {
+		return p
+	}
+	return ebsPerGBEstimate["gp3"]
+}
+
+// applyOverallocatedVolumes recommends a size that would run at ~50% full for
+// volumes whose peak filesystem usage is below the threshold. Cost is the
+// monthly saving of the smaller volume. The volumes hold live data and
+// shrinking needs a migration, so they are not marked as waste and no
+// remediation is generated for them.
+// perGB is the monthly $/GB of each volume, by ID; window is the lookback usage was measured over.
+func applyOverallocatedVolumes(g *graph.Graph, usage map[string]float64, missing []string, perGB map[string]float64, window time.Duration) *HeuristicStats {
+	stats := &HeuristicStats{}
+
+	g.Mu.Lock()
+	defer g.Mu.Unlock()
+
+	for _, id := range missing {
+		if node := g.GetNode(id); node != nil {
+			node.Properties["DiskMetricsMissing"] = true
+		}
+	}
+
+	for id, used := range usage {
+		if used >= overallocMaxUsedPercent {
+			continue
+		}
+		node := g.GetNode(id)
+		if node == nil || node.IsWaste {
+			continue
+		}
+		size := volumeSize(node)
+		volumeType, _ := node.Properties["VolumeType"].(string)
+		usedGB := float64(size) * used / 100
+		recommended := max(int(math.Ceil(usedGB*2/10))*10, 10)
+		if recommended >= size {
+			continue
+		}
+		savings := float64(size-recommended) * perGB[id]
+
+		node.RiskScore = 30
+		node.Cost = savings
+		node.Properties["RecommendedSizeGB"] = recommended
+		node.Properties["Reason"] = fmt.Sprintf("Over-allocated EBS Volume: %d GB %s is at most %.1f%% full (%.0f GB used) over %s. A %d GB volume would save $%.2f/mo; EBS cannot shrink in place, so this needs a new volume and a data copy.",
+			size, volumeType, used, usedGB, windowLabel(window), recommended, savings)
+
+		stats.ItemsFound++
+		stats.ProjectedSavings += savings
+	}
+	return stats
+}
+
+// volumeSize reads the Size property, which the scanner stores as int32.
+func volumeSize(node *graph.Node) int {
+	switch s := node.Properties["Size"].(type) {
+	case int32:
+		return int(s)
+	case int:
+		return s
+	}
+	return 0
+}
+
+// dimensionValue returns the value of the named dimension, or "".
+func dimensionValue(dims []types.Dimension, name string) string {
+	for _, d := range dims {
+		if d.Name != nil && *d.Name == name && d.Value != nil {
+			return *d.Value
+		}
+	}
+	return ""
+}
+
+// sameDevice matches an EBS attachment device (/dev/sdf) against a CloudWatch agent
+// device (xvdf, xvdf1). NVMe names (nvme1n1) cannot be mapped without the volume serial.
+func sameDevice(attachment, agent string) bool {
+	a, b := normalizeDevice(attachment), normalizeDevice(agent)
+	return a != "" && a == b
+}
+
+func normalizeDevice(d string) string {
+	d = strings.TrimPrefix(d, "/dev/")
+	if strings.HasPrefix(d, "sd") {
+		d = "xvd" + d[2:]
+	}
+	if strings.HasPrefix(d, "xvd") {
+		d = strings.TrimRight(d, "0123456789")
+	}
+	return d
+}
@@ -0,0 +1,197 @@
+package heuristics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	internalaws "github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/pricing"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+const (
+	gp3Window = 14 * 24 * time.Hour
+	// gp3PeakHeadroom is the share of the baseline a volume's peak must stay under
+	// before its extra provisioning counts as unused.
+	gp3PeakHeadroom = 0.8
+)
+
+// OverprovisionedGP3Heuristic flags in-use gp3 volumes provisioned above the
+// included 3000 IOPS / 125 MiB/s baseline whose CloudWatch peak never comes
+// near it. EBSModernizerHeuristic covers gp2; this covers gp3 bumped by hand.
+type OverprovisionedGP3Heuristic struct {
+	CW      *internalaws.CloudWatchClient
+	Pricing *pricing.Client
+	Region  string        // Scan region; prices nodes that carry no region of their own.
+	Window  time.Duration // Metric lookback; zero means gp3Window.
+}
+
+func (h *OverprovisionedGP3Heuristic) Name() string { return "OverprovisionedGP3Heuristic" }
+
+type gp3Candidate struct {
+	id, volumeID, region   string
+	size, iops, throughput int
+	cw                     *internalaws.CloudWatchClient
+}
+
+// gp3Finding is a volume to downshift, with its observed peaks and monthly saving.
+type gp3Finding struct {
+	peakIOPS, peakMiBps            float64
+	recommendedIOPS, recommendedTP int
+	savings                        float64
+}
+
+func (h *OverprovisionedGP3Heuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	if h.CW == nil {
+		return &HeuristicStats{}, nil
+	}
+
+	var candidates []gp3Candidate
+	g.Mu.RLock()
+	for _, node := range g.Store.GetAllNodes() {
+		if node.TypeStr() != "AWS::EC2::Volume" || node.IsWaste {
+			continue
+		}
+		if typ, _ := node.Properties["VolumeType"].(string); typ != "gp3" {
+			continue
+		}
+		if state, _ := node.Properties["State"].(string); state != "in-use" {
+			continue
+		}
+		if mod, _ := node.Properties["IsModifying"].(bool); mod {
+			continue
+		}
+		c := gp3Candidate{
+			id:         node.IDStr(),
+			volumeID:   node.IDStr()[strings.LastIndex(node.IDStr(), "/")+1:],
+			region:     NodeRegion(node, h.Region),
+			size:       volumeSize(node),
+			iops:       intProperty(node, "Iops"),
+			throughput: intProperty(node, "Throughput"),
+			cw:         scopedCW(h.CW, node),
+		}
+		if c.iops > pricing.GP3BaselineIOPS || c.throughput > pricing.GP3BaselineThroughput {
+			candidates = append(candidates, c)
+		}
+	}
+	g.Mu.RUnlock()
+
+	var throttled throttleTracker
+	defer func() { throttled.report(g, h.Name()) }()
+
+	now := time.Now()
+	window := metricWindow(h.Window, gp3Window)
+	findings := make(map[string]gp3Finding)
+	for _, c := range candidates {
+		dims := []types.Dimension{{Name: aws.String("VolumeId"), Value: aws.String(c.volumeID)}}
+		// EBS publishes per-minute sums; the daily Maximum is the busiest minute.
+		// A volume without datapoints is skipped: its peak is unknown, not zero.
+		var peaks [4]float64
+		failed := false
+		for i, metric := range []string{"VolumeReadOps", "VolumeWriteOps", "VolumeReadBytes", "VolumeWriteBytes"} {
+			v, err := c.cw.GetMetricMaxObserved(ctx, "AWS/EBS", metric, dims, now.Add(-window), now)
+			if err != nil {
+				throttled.check(err)
+				failed = true
+				break
+			}
+			peaks[i] = v
+		}
+		if failed {
+			continue
+		}
+		f := gp3Finding{
+			peakIOPS:  (peaks[0] + peaks[1]) / 60,
+			peakMiBps: (peaks[2] + peaks[3]) / 60 / (1 << 20),
+		}
+		var ok bool
+		f.recommendedIOPS, f.recommendedTP, ok = gp3Recommendation(c.iops, c.throughput, f.peakIOPS, f.peakMiBps)
+		if !ok {
+			continue
+		}
+		f.savings = h.monthlyPrice(ctx, c.region, c.size, c.iops, c.throughput) -
+			h.monthlyPrice(ctx, c.region, c.size, f.recommendedIOPS, f.recommendedTP)
+		findings[c.id] = f
+	}
+	return applyOverprovisionedGP3(g, findings, window), nil
+}
+
+func (h *OverprovisionedGP3Heuristic) monthlyPrice(ctx context.Context, region string, size, iops, throughput int) float64 {
+	if h.Pricing != nil {
+		if p, err := h.Pricing.GetGP3Price(ctx, region, size, iops, throughput); err == nil {
+			return p
+		}
+	}
+	return pricing.EstimateGP3Price(size, iops, throughput)
+}
+
+// gp3Recommendation returns the IOPS and throughput to downshift to, dropping
+// each dimension to the baseline when its peak stays under the headroom.
+// Reports false when neither can come down.
+func gp3Recommendation(iops, throughput int, peakIOPS, peakMiBps float64) (int, int, bool) {
+	recIOPS, recTP := iops, throughput
+	if iops > pricing.GP3BaselineIOPS && peakIOPS < pricing.GP3BaselineIOPS*gp3PeakHeadroom {
+		recIOPS = pricing.GP3BaselineIOPS
+	}
+	if throughput > pricing.GP3BaselineThroughput && peakMiBps < pricing.GP3BaselineThroughput*gp3PeakHeadroom {
+		recTP = pricing.GP3BaselineThroughput
+	}
+	return recIOPS, recTP, recIOPS != iops || recTP != throughput
+}
+
+// applyOverprovisionedGP3 marks each volume for an in-place modify-volume to
+// the recommended performance. Cost is the monthly saving; window is the
+// lookback the peaks were measured over.
+func applyOverprovisionedGP3(g *graph.Graph, findings map[string]gp3Finding, window time.Duration) *HeuristicStats {
+	stats := &HeuristicStats{}
+
+	// Evidence goes on the node first, so the waste listener sees it.
+	var pending []pendingFinding
+	g.Mu.Lock()
+	for id, f := range findings {
+		node := g.GetNode(id)
+		if node == nil || node.IsWaste || f.savings <= 0 {
+			continue
+		}
+		iops, throughput := intProperty(node, "Iops"), intProperty(node, "Throughput")
+
+		var changes []string
+		if f.recommendedIOPS != iops {
+			changes = append(changes, fmt.Sprintf("%d IOPS provisioned, peak %.0f", iops, f.peakIOPS))
+		}
+		if f.recommendedTP != throughput {
+			changes = append(changes, fmt.Sprintf("%d MiB/s provisioned, peak %.1f", throughput, f.peakMiBps))
+		}
+
+		finding := graph.Finding{Heuristic: "OverprovisionedGP3Heuristic", Score: 30, Savings: f.savings}
+		node.Properties["GP3Downshift"] = true
+		node.Properties["RecommendedIops"] = f.recommendedIOPS
+		node.Properties["RecommendedThroughput"] = f.recommendedTP
+		finding.Reason = fmt.Sprintf("Over-provisioned gp3 Volume: %s over %s. Modify to %d IOPS / %d MiB/s to save $%.2f/mo (in place, no downtime).",
+			strings.Join(changes, "; "), windowLabel(window), f.recommendedIOPS, f.recommendedTP, f.savings)
+		pending = append(pending, pendingFinding{id, finding})
+	}
+	g.Mu.Unlock()
+
+	for _, f := range pending {
+		stats.record(g, f.id, f.Finding)
+	}
+	return stats
+}
+
+// intProperty reads an integer property stored as int32 (scanner) or int.
+func intProperty(node *graph.Node, key string) int {
+	switch v := node.Properties[key].(type) {
+	case int32:
+		return int(v)
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return 0
+}
@@ -97,11 +97,12 @@ func (h *IdleClusterHeuristic) Run(ctx context.Context, g *graph.Graph) (*Heuris
 		}
 
 		if isWaste {
-			g.MarkWaste(cluster.IDStr(), 85)
+			g.AddFinding(cluster.IDStr(), graph.Finding{
+				Heuristic: h.Name(),
+				Reason:    fmt.Sprintf("Idle Cluster: %d active Container Instances (>1h uptime) with 0 running tasks.", regInstances),
+				Score:     85,
+			})
 			stats.ItemsFound++
-
-			reason := fmt.Sprintf("Idle Cluster: %d active Container Instances (>1h uptime) with 0 running tasks.", regInstances)
-			cluster.Properties["Reason"] = reason
 		}
 	}
 
@@ -179,8 +180,11 @@ func (h *EmptyServiceHeuristic) Run(ctx context.Context, g *graph.Graph) (*Heuri
 				}
 			}
 
-			g.MarkWaste(service.IDStr(), 90)
-			service.Properties["Reason"] = fmt.Sprintf("STUCK Service. Desired: %d, Running: 0. %s", desired, diagnosis)
+			g.AddFinding(service.IDStr(), graph.Finding{
+				Heuristic: h.Name(),
+				Reason:    fmt.Sprintf("STUCK Service. Desired: %d, Running: 0. %s", desired, diagnosis),
+				Score:     90,
+			})
 			stats.ItemsFound++
 		}
 	}
@@ -0,0 +1,84 @@
+package heuristics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// EFS list prices (us-east-1, per GB-month).
+const (
+	efsStandardGBMonth = 0.30
+	efsIAGBMonth       = 0.016
+
+	// efsColdFraction is the share of data AWS reports as rarely read after 30 days.
+	efsColdFraction = 0.8
+)
+
+// EFSLifecycleHeuristic flags in-use file systems that keep everything in Standard.
+// It recommends a lifecycle policy rather than deletion.
+type EFSLifecycleHeuristic struct{}
+
+func (h *EFSLifecycleHeuristic) Name() string { return "EFSLifecycle" }
+
+func (h *EFSLifecycleHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	stats := &HeuristicStats{}
+
+	// The recommendation goes on first, so the waste listener sees it; it is
+	// withdrawn when an ignore tag suppresses the finding.
+	var pending []pendingFinding
+	g.Mu.Lock()
+	for _, node := range g.Store.GetAllNodes() {
+		if node.TypeStr() != "AWS::EFS::FileSystem" || node.IsWaste {
+			continue
+		}
+		if f, ok := h.analyzeFileSystem(node); ok {
+			pending = append(pending, pendingFinding{node.IDStr(), f})
+		}
+	}
+	g.Mu.Unlock()
+
+	for _, f := range pending {
+		if !stats.record(g, f.id, f.Finding) {
+			g.Mu.Lock()
+			if node := g.GetNode(f.id); node != nil {
+				delete(node.Properties, "LifecycleRecommendation")
+				delete(node.Properties, "FixRecommendation")
+			}
+			g.Mu.Unlock()
+		}
+	}
+	return stats, nil
+}
+
+// analyzeFileSystem returns the tiering finding for n, recording the fix on
+// the node. The caller holds g.Mu.
+func (h *EFSLifecycleHeuristic) analyzeFileSystem(n *graph.Node) (graph.Finding, bool) {
+	// Unused file systems are a deletion candidate, not a tiering one.
+	if mounts, _ := n.Properties["NumberOfMountTargets"].(int32); mounts == 0 {
+		return graph.Finding{}, false
+	}
+	// Absent means the lifecycle lookup failed; don't guess.
+	toIA, known := n.Properties["TransitionToIA"].(string)
+	if !known || toIA != "" {
+		return graph.Finding{}, false
+	}
+
+	standardBytes, _ := n.Properties["SizeStandardBytes"].(int64)
+	standardGB := float64(standardBytes) / (1 << 30)
+	if standardGB < 1 {
+		return graph.Finding{}, false
+	}
+
+	savings := standardGB * efsColdFraction * (efsStandardGBMonth - efsIAGBMonth)
+
+	n.Properties["LifecycleRecommendation"] = true
+	n.Properties["FixRecommendation"] = "Add a lifecycle policy: TransitionToIA=AFTER_30_DAYS, TransitionToPrimaryStorageClass=AFTER_1_ACCESS."
+	return graph.Finding{
+		Heuristic: h.Name(),
+		Reason:    fmt.Sprintf("EFS Lifecycle: %.0f GB in Standard with no IA transition. Est. save $%.2f/mo by tiering cold data.", standardGB, savings),
+		Score:     5,
+		Savings:   savings,
+	}, true
+}
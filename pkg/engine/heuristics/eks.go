@@ -9,7 +9,13 @@ import (
 	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
 )
 
-// IdleEKSClusterHeuristic checks idle EKS.
+// eksControlPlaneHourly is the EKS control plane rate under standard support.
+const eksControlPlaneHourly = 0.10
+
+// IdleEKSClusterHeuristic checks idle EKS. A cluster whose Fargate profiles
+// run only kube-system pods counts as idle, and those pods add to its cost.
+// Fargate usage is recorded by AbandonedFargateHeuristic; without it, any
+// Fargate profile counts as compute.
 type IdleEKSClusterHeuristic struct{}
 
 // Name returns the name of the heuristic.
@@ -18,21 +24,26 @@ func (h *IdleEKSClusterHeuristic) Name() string { return "IdleEKSClusterHeuristi
 // Run executes the heuristic analysis.
 func (h *IdleEKSClusterHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
 	stats := &HeuristicStats{}
-	g.Mu.Lock()
-	defer g.Mu.Unlock()
+	var pending []pendingFinding
+	g.Mu.RLock()
 
 	elbs := h.indexELBs(g)
+	profiles := h.indexFargateProfiles(g)
 
 	// Fix: Access Store directly to avoid Deadlock (GetNodes tries to RLock, but we hold Lock)
 	for _, node := range g.Store.GetAllNodes() {
 		if node.TypeStr() != "AWS::EKS::Cluster" {
 			continue
 		}
-		if h.analyzeCluster(node, elbs, stats) {
-			stats.ItemsFound++
+		if finding, ok := h.analyzeCluster(node, elbs, profiles[node.IDStr()]); ok {
+			pending = append(pending, pendingFinding{node.IDStr(), finding})
 		}
 	}
+	g.Mu.RUnlock()
 
+	for _, f := range pending {
+		stats.record(g, f.id, f.Finding)
+	}
 	return stats, nil
 }
 
@@ -53,23 +64,69 @@ func (h *IdleEKSClusterHeuristic) indexELBs(g *graph.Graph) []elbInfo {
 	return elbs
 }
 
-func (h *IdleEKSClusterHeuristic) analyzeCluster(node *graph.Node, elbs []elbInfo, stats *HeuristicStats) bool {
+// fargateUsage is the running pod usage of a cluster's Fargate profiles.
+type fargateUsage struct {
+	Profiles  int
+	Known     int // Profiles with recorded usage.
+	Pods      int
+	Workloads int // Pods outside kube-system.
+	VCPU      float64
+	MemoryGB  float64
+}
+
+// monthlyCost prices the usage as if it ran all month.
+func (u fargateUsage) monthlyCost() float64 {
+	return (u.VCPU*fargateVCPUHourly + u.MemoryGB*fargateGBHourly) * 730
+}
+
+// indexFargateProfiles sums Fargate profile usage by cluster ARN.
+func (h *IdleEKSClusterHeuristic) indexFargateProfiles(g *graph.Graph) map[string]*fargateUsage {
+	usage := make(map[string]*fargateUsage)
+	for _, node := range g.Store.GetAllNodes() {
+		if node.TypeStr() != "AWS::EKS::FargateProfile" {
+			continue
+		}
+		clusterARN, _ := node.Properties["ClusterARN"].(string)
+		u, ok := usage[clusterARN]
+		if !ok {
+			u = &fargateUsage{}
+			usage[clusterARN] = u
+		}
+		u.Profiles++
+
+		pods, ok := node.Properties["FargatePods"].(int)
+		if !ok {
+			continue
+		}
+		u.Known++
+		u.Pods += pods
+		workloads, _ := node.Properties["FargateWorkloadPods"].(int)
+		u.Workloads += workloads
+		vcpu, _ := node.Properties["FargateVCPU"].(float64)
+		u.VCPU += vcpu
+		memGB, _ := node.Properties["FargateMemoryGB"].(float64)
+		u.MemoryGB += memGB
+	}
+	return usage
+}
+
+func (h *IdleEKSClusterHeuristic) analyzeCluster(node *graph.Node, elbs []elbInfo, fargate *fargateUsage) (graph.Finding, bool) {
 	// Check status.
 	status, _ := node.Properties["Status"].(string)
 	if status != "ACTIVE" {
-		return false
+		return graph.Finding{}, false
 	}
 
-	// Check age.
-	createdAt, ok := node.Properties["CreatedAt"].(time.Time)
+	// Check age. The scanner stores the SDK's *time.Time.
+	createdAt, ok := node.CreatedAt()
 	if !ok || time.Since(createdAt) < 7*24*time.Hour {
-		return false
+		return graph.Finding{}, false
 	}
 
 	// Check Karpenter.
 	karpenter, _ := node.Properties["KarpenterEnabled"].(bool)
 	if karpenter {
-		return false
+		return graph.Finding{}, false
 	}
 
 	// Check compute.
@@ -77,23 +134,40 @@ func (h *IdleEKSClusterHeuristic) analyzeCluster(node *graph.Node, elbs []elbInf
 	hasFargate, _ := node.Properties["HasFargate"].(bool)
 	hasSelf, _ := node.Properties["HasSelfManagedNodes"].(bool)
 
-	if hasManaged || hasFargate || hasSelf {
-		return false
+	if hasManaged || hasSelf {
+		return graph.Finding{}, false
+	}
+	// Fargate profiles only count as compute while they run workload pods.
+	if hasFargate && (fargate == nil || fargate.Known < fargate.Profiles || fargate.Workloads > 0) {
+		return graph.Finding{}, false
 	}
 
-	// Mark waste.
-	node.IsWaste = true
-	node.RiskScore = 90    // High confidence.
-	node.Cost = 0.10 * 730 // Est. cost.
-	stats.ProjectedSavings += node.Cost
+	// Idle: the control plane and any kube-system pods are the savings.
+	controlPlane := eksControlPlaneHourly * 730
+	cost := controlPlane
+	if fargate != nil {
+		cost += fargate.monthlyCost()
+	}
 
-	reason := "Idle Control Plane: Active EKS cluster with zero compute nodes for > 7 days."
+	var reason string
+	if fargate != nil {
+		reason = "Idle Control Plane: Active EKS cluster with no compute nodes or workload pods for > 7 days."
+		reason += fmt.Sprintf("\nIdle cost: $%.2f/mo control plane + $%.2f/mo Fargate (%d kube-system pods, %.2f vCPU, %.1f GB).",
+			controlPlane, fargate.monthlyCost(), fargate.Pods, fargate.VCPU, fargate.MemoryGB)
+	} else {
+		reason = "Idle Control Plane: Active EKS cluster with zero compute nodes for > 7 days."
+		reason += fmt.Sprintf("\nIdle cost: $%.2f/mo control plane.", controlPlane)
+	}
 
 	// Check orphaned ELBs.
 	h.checkOrphanedELBs(node, elbs, &reason)
 
-	node.Properties["Reason"] = reason
-	return true
+	return graph.Finding{
+		Heuristic: h.Name(),
+		Reason:    reason,
+		Score:     90, // High confidence.
+		Savings:   cost,
+	}, true
 }
 
 func (h *IdleEKSClusterHeuristic) checkOrphanedELBs(node *graph.Node, elbs []elbInfo, reason *string) {
@@ -7,6 +7,9 @@ import (
 	"time"
 
 	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestZombieEKSHeuristic_WithOrphanedELBs(t *testing.T) {
@@ -80,3 +83,99 @@ func TestZombieEKSHeuristic_WithOrphanedELBs(t *testing.T) {
 		t.Errorf("Expected reason NOT to contain normal ELB ARN, got: %s", reason)
 	}
 }
+
+func TestIdleEKSClusterHeuristic_FargateCost(t *testing.T) {
+	g := graph.NewGraph()
+	addCluster := func(name string) string {
+		arn := "arn:aws:eks:us-east-1:123456789012:cluster/" + name
+		g.AddNode(arn, "AWS::EKS::Cluster", map[string]interface{}{
+			"Status":     "ACTIVE",
+			"CreatedAt":  time.Now().Add(-30 * 24 * time.Hour),
+			"HasFargate": true,
+		})
+		return arn
+	}
+	addProfile := func(clusterARN, name string, props map[string]interface{}) {
+		props["ProfileName"] = name
+		props["ClusterARN"] = clusterARN
+		g.AddNode(clusterARN+"/fargateprofile/"+name, "AWS::EKS::FargateProfile", props)
+	}
+
+	idle := addCluster("idle")
+	addProfile(idle, "fp-default", map[string]interface{}{
+		"FargatePods": 2, "FargateWorkloadPods": 0, "FargateVCPU": 0.5, "FargateMemoryGB": 1.0,
+	})
+	busy := addCluster("busy")
+	addProfile(busy, "apps", map[string]interface{}{
+		"FargatePods": 3, "FargateWorkloadPods": 3, "FargateVCPU": 3.0, "FargateMemoryGB": 6.0,
+	})
+	unknown := addCluster("unknown") // No k8s access: usage not recorded.
+	addProfile(unknown, "apps", map[string]interface{}{})
+	g.CloseAndWait()
+
+	if _, err := (&IdleEKSClusterHeuristic{}).Run(context.Background(), g); err != nil {
+		t.Fatalf("Heuristic run failed: %v", err)
+	}
+
+	node := g.GetNode(idle)
+	if !node.IsWaste {
+		t.Fatal("Expected cluster running only kube-system Fargate pods to be idle")
+	}
+	fargate := (0.5*fargateVCPUHourly + 1.0*fargateGBHourly) * 730
+	if want := 73 + fargate; node.Cost < want-0.01 || node.Cost > want+0.01 {
+		t.Errorf("Expected control plane + Fargate cost %.2f, got %.2f", want, node.Cost)
+	}
+	reason, _ := node.Properties["Reason"].(string)
+	if !strings.Contains(reason, "$73.00/mo control plane") || !strings.Contains(reason, "2 kube-system pods") {
+		t.Errorf("Expected cost breakdown in reason, got: %s", reason)
+	}
+
+	if g.GetNode(busy).IsWaste {
+		t.Error("Expected cluster with Fargate workloads to stay active")
+	}
+	if g.GetNode(unknown).IsWaste {
+		t.Error("Expected cluster with unrecorded Fargate usage to be skipped")
+	}
+}
+
+func TestIdleEKSClusterHeuristic_ScannerTimestamp(t *testing.T) {
+	// EKSScanner records CreatedAt as the SDK's *time.Time.
+	created := time.Now().Add(-30 * 24 * time.Hour)
+	g := graph.NewGraph()
+	arn := "arn:aws:eks:us-east-1:123456789012:cluster/empty"
+	g.AddNode(arn, "AWS::EKS::Cluster", map[string]interface{}{
+		"Status":    "ACTIVE",
+		"CreatedAt": &created,
+	})
+	g.CloseAndWait()
+
+	if _, err := (&IdleEKSClusterHeuristic{}).Run(context.Background(), g); err != nil {
+		t.Fatalf("Heuristic run failed: %v", err)
+	}
+	if !g.GetNode(arn).IsWaste {
+		t.Error("Expected a month-old cluster with no compute to be flagged")
+	}
+}
+
+func TestFargatePodSize(t *testing.T) {
+	annotated := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{"CapacityProvisioned": "0.25vCPU 0.5GB"},
+	}}
+	if cpu, mem := fargatePodSize(annotated); cpu != 0.25 || mem != 0.5 {
+		t.Errorf("Expected 0.25 vCPU / 0.5 GB from annotation, got %v / %v", cpu, mem)
+	}
+
+	requested := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+		Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("1"),
+			corev1.ResourceMemory: resource.MustParse("2Gi"),
+		}},
+	}}}}
+	if cpu, mem := fargatePodSize(requested); cpu != 1 || mem != 2 {
+		t.Errorf("Expected 1 vCPU / 2 GB from requests, got %v / %v", cpu, mem)
+	}
+
+	if cpu, mem := fargatePodSize(&corev1.Pod{}); cpu != 0.25 || mem != 0.5 {
+		t.Errorf("Expected Fargate minimum size, got %v / %v", cpu, mem)
+	}
+}
@@ -0,0 +1,284 @@
+package heuristics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	internalaws "github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/pricing"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+const (
+	elastiCacheWindow = 7 * 24 * time.Hour
+
+	// Replication and monitoring agents hold a few connections on an idle cluster.
+	elastiCacheIdleConnections = 5.0
+	elastiCacheIdleHits        = 100.0 // CacheHits summed over the window.
+
+	// A reserved cluster is worth renewing one size down when both CPU and
+	// memory stay low; the next size has half the memory.
+	elastiCacheLowCPU    = 10.0
+	elastiCacheLowMemory = 40.0
+)
+
+// IdleElastiCacheHeuristic flags Redis, Valkey and Memcached clusters that
+// served almost no connections or cache hits over the metric window, and
+// reserved clusters whose reservation should be renewed one size smaller.
+// Members of a replication group are judged together: a replica that serves
+// no reads is still part of a group whose primary is busy.
+// Without CloudWatch (mock mode) it uses the usage recorded on the node.
+type IdleElastiCacheHeuristic struct {
+	CW      *internalaws.CloudWatchClient
+	Pricing *pricing.Client
+	Region  string        // Scan region; prices clusters that carry no region of their own.
+	Window  time.Duration // Metric lookback; zero means elastiCacheWindow.
+}
+
+func (h *IdleElastiCacheHeuristic) Name() string { return "IdleElastiCacheHeuristic" }
+
+// elastiCacheUsage is a cluster's peak load over the window.
+type elastiCacheUsage struct {
+	PeakConnections float64
+	CacheHits       float64
+	PeakCPU         float64
+	PeakMemory      float64 // DatabaseMemoryUsagePercentage; not published for Memcached.
+	HasMemory       bool
+}
+
+// elastiCacheFinding is the evidence for one flagged cluster. Cost is the
+// cluster's monthly cost when idle, or the saving of the smaller node type.
+type elastiCacheFinding struct {
+	Usage   elastiCacheUsage
+	Idle    bool
+	Smaller string
+	Cost    float64
+}
+
+func (h *IdleElastiCacheHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	type candidate struct {
+		id, clusterID, group, region, nodeType, engine string
+		nodes, reserved                                int
+		recorded                                       *elastiCacheUsage
+		cw                                             *internalaws.CloudWatchClient
+	}
+
+	now := time.Now()
+	window := metricWindow(h.Window, elastiCacheWindow)
+	var candidates []candidate
+	g.Mu.RLock()
+	for _, node := range g.Store.GetAllNodes() {
+		if node.TypeStr() != "aws_elasticache_cluster" || node.IsWaste {
+			continue
+		}
+		if status, _ := node.Properties["Status"].(string); status != "available" {
+			continue
+		}
+		if created, ok := node.CreatedAt(); ok && now.Sub(created) < window {
+			continue
+		}
+		c := candidate{id: node.IDStr(), region: NodeRegion(node, h.Region)}
+		c.clusterID, _ = node.Properties["CacheClusterId"].(string)
+		c.nodeType, _ = node.Properties["NodeType"].(string)
+		c.engine, _ = node.Properties["Engine"].(string)
+		c.nodes, _ = node.Properties["NumCacheNodes"].(int)
+		c.reserved, _ = node.Properties["ReservedNodes"].(int)
+		c.group, _ = node.Properties["ReplicationGroupId"].(string)
+		if c.group == "" {
+			c.group = c.id
+		}
+		c.cw = scopedCW(h.CW, node)
+		if h.CW == nil {
+			c.recorded = recordedElastiCacheUsage(node)
+		}
+		candidates = append(candidates, c)
+	}
+	g.Mu.RUnlock()
+
+	// Metric and pricing calls hit the network; resolve them outside the lock.
+	// A group is judged on the combined usage of its members, and only when
+	// every member's usage is known.
+	usages := make(map[string]elastiCacheUsage)
+	unknown := make(map[string]bool)
+	for _, c := range candidates {
+		var usage elastiCacheUsage
+		switch {
+		case c.cw != nil && c.clusterID != "":
+			u, err := elastiCacheMetrics(ctx, c.cw, c.clusterID, c.engine, now.Add(-window), now)
+			if err != nil {
+				// Only a successful read counts; missing data is not idleness.
+				unknown[c.group] = true
+				continue
+			}
+			usage = u
+		case c.recorded != nil:
+			usage = *c.recorded
+		default:
+			unknown[c.group] = true
+			continue
+		}
+		if prev, ok := usages[c.group]; ok {
+			usage = combineElastiCacheUsage(prev, usage)
+		}
+		usages[c.group] = usage
+	}
+
+	findings := make(map[string]elastiCacheFinding)
+	for _, c := range candidates {
+		usage, ok := usages[c.group]
+		if !ok || unknown[c.group] {
+			continue
+		}
+
+		f := elastiCacheFinding{Usage: usage}
+		price := h.nodePrice(ctx, c.region, c.nodeType, c.engine)
+		if usage.PeakConnections <= elastiCacheIdleConnections && usage.CacheHits < elastiCacheIdleHits {
+			f.Idle = true
+			f.Cost = price * float64(c.nodes)
+		} else if c.reserved > 0 && usage.HasMemory && usage.PeakCPU < elastiCacheLowCPU && usage.PeakMemory < elastiCacheLowMemory {
+			smaller, ok := pricing.SmallerElastiCacheNodeType(c.nodeType)
+			if !ok {
+				continue
+			}
+			f.Smaller = smaller
+			f.Cost = (price - h.nodePrice(ctx, c.region, smaller, c.engine)) * float64(c.reserved)
+			if f.Cost <= 0 {
+				continue
+			}
+		} else {
+			continue
+		}
+		findings[c.id] = f
+	}
+
+	return applyIdleElastiCache(g, findings, window), nil
+}
+
+// elastiCacheMetrics reads a cluster's peak connections, CPU and memory and
+// its total cache hits. Any failed read, or a metric without datapoints,
+// fails the whole cluster.
+func elastiCacheMetrics(ctx context.Context, cw *internalaws.CloudWatchClient, clusterID, engine string, start, end time.Time) (elastiCacheUsage, error) {
+	var u elastiCacheUsage
+	dims := []types.Dimension{{Name: aws.String("CacheClusterId"), Value: aws.String(clusterID)}}
+	memcached := strings.EqualFold(engine, "memcached")
+	// Memcached counts hits as GetHits.
+	hits := "CacheHits"
+	if memcached {
+		hits = "GetHits"
+	}
+
+	var err error
+	if u.PeakConnections, err = cw.GetMetricMaxObserved(ctx, "AWS/ElastiCache", "CurrConnections", dims, start, end); err != nil {
+		return u, err
+	}
+	if u.CacheHits, err = cw.GetMetricSumObserved(ctx, "AWS/ElastiCache", hits, dims, start, end); err != nil {
+		return u, err
+	}
+	if u.PeakCPU, err = cw.GetMetricMaxObserved(ctx, "AWS/ElastiCache", "CPUUtilization", dims, start, end); err != nil {
+		return u, err
+	}
+	if !memcached {
+		if u.PeakMemory, err = cw.GetMetricMaxObserved(ctx, "AWS/ElastiCache", "DatabaseMemoryUsagePercentage", dims, start, end); err != nil {
+			return u, err
+		}
+		u.HasMemory = true
+	}
+	return u, nil
+}
+
+// combineElastiCacheUsage folds two members of a replication group into the
+// group's usage: peaks are the higher of the two and hits add up.
+func combineElastiCacheUsage(a, b elastiCacheUsage) elastiCacheUsage {
+	return elastiCacheUsage{
+		PeakConnections: max(a.PeakConnections, b.PeakConnections),
+		CacheHits:       a.CacheHits + b.CacheHits,
+		PeakCPU:         max(a.PeakCPU, b.PeakCPU),
+		PeakMemory:      max(a.PeakMemory, b.PeakMemory),
+		HasMemory:       a.HasMemory && b.HasMemory,
+	}
+}
+
+// recordedElastiCacheUsage returns usage already on the node, or nil when
+// connections or hits were never recorded.
+func recordedElastiCacheUsage(node *graph.Node) *elastiCacheUsage {
+	conns, ok := node.Properties["PeakConnections"].(float64)
+	if !ok {
+		return nil
+	}
+	hits, ok := node.Properties["CacheHits"].(float64)
+	if !ok {
+		return nil
+	}
+	u := &elastiCacheUsage{PeakConnections: conns, CacheHits: hits}
+	u.PeakCPU, _ = node.Properties["PeakCPU"].(float64)
+	u.PeakMemory, u.HasMemory = node.Properties["PeakMemoryPercent"].(float64)
+	return u
+}
+
+func (h *IdleElastiCacheHeuristic) nodePrice(ctx context.Context, region, nodeType, engine string) float64 {
+	if h.Pricing != nil && region != "" {
+		if p, err := h.Pricing.GetElastiCachePrice(ctx, region, nodeType, engine); err == nil {
+			return p
+		}
+	}
+	return pricing.EstimateElastiCachePrice(nodeType, engine)
+}
+
+// applyIdleElastiCache marks idle clusters as waste and reserved clusters
+// worth downsizing for review. window is the lookback usage was measured over.
+func applyIdleElastiCache(g *graph.Graph, findings map[string]elastiCacheFinding, window time.Duration) *HeuristicStats {
+	stats := &HeuristicStats{}
+
+	// Evidence goes on the node first, so the waste listener sees it.
+	var pending []pendingFinding
+	g.Mu.Lock()
+	for id, f := range findings {
+		node := g.GetNode(id)
+		if node == nil || node.IsWaste {
+			continue
+		}
+		name, _ := node.Properties["CacheClusterId"].(string)
+		if group, _ := node.Properties["ReplicationGroupId"].(string); group != "" {
+			name = fmt.Sprintf("%s (replication group %s)", name, group)
+		}
+		nodeType, _ := node.Properties["NodeType"].(string)
+		engine, _ := node.Properties["Engine"].(string)
+		nodes, _ := node.Properties["NumCacheNodes"].(int)
+
+		node.Properties["PeakConnections"] = f.Usage.PeakConnections
+		node.Properties["CacheHits"] = f.Usage.CacheHits
+		node.Properties["PeakCPU"] = f.Usage.PeakCPU
+		if f.Usage.HasMemory {
+			node.Properties["PeakMemoryPercent"] = f.Usage.PeakMemory
+		}
+
+		finding := graph.Finding{Heuristic: "IdleElastiCacheHeuristic", Savings: f.Cost}
+		if f.Idle {
+			finding.Score = 75
+			finding.Reason = fmt.Sprintf("Idle ElastiCache Cluster: %s (%s, %d × %s) peaked at %.0f connections with %.0f cache hits in %s ($%.2f/mo).",
+				name, engine, nodes, nodeType, f.Usage.PeakConnections, f.Usage.CacheHits, windowLabel(window), f.Cost)
+		} else {
+			reserved, _ := node.Properties["ReservedNodes"].(int)
+			renew := "at renewal"
+			if expiry, ok := node.Properties["ReservationExpiry"].(time.Time); ok {
+				renew = "when it expires on " + expiry.Format("2006-01-02")
+			}
+			// The reservation is prepaid; the saving only lands at renewal.
+			finding.Score = 40
+			node.Properties["RecommendedNodeType"] = f.Smaller
+			finding.Reason = fmt.Sprintf("Underutilized Reserved ElastiCache: %s (%d reserved × %s) peaked at %.1f%% CPU and %.0f%% memory in %s. Renew the reservation as %s %s to save $%.2f/mo.",
+				name, reserved, nodeType, f.Usage.PeakCPU, f.Usage.PeakMemory, windowLabel(window), f.Smaller, renew, f.Cost)
+		}
+		pending = append(pending, pendingFinding{id, finding})
+	}
+	g.Mu.Unlock()
+
+	for _, f := range pending {
+		stats.record(g, f.id, f.Finding)
+	}
+	return stats
+}
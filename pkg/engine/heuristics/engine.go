@@ -3,6 +3,7 @@ package heuristics
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"sync"
 	"time"
 
@@ -28,15 +29,53 @@ type HeuristicStats struct {
 	ProjectedSavings float64 // Monthly savings in USD
 }
 
+// pendingFinding is a finding collected under the graph lock, to be recorded
+// once the lock is released.
+type pendingFinding struct {
+	id string
+	graph.Finding
+}
+
+// record adds f to the node through g.AddFinding, which honours the
+// cloudslash:ignore tag and notifies the waste listener, and counts it when
+// the node ends up flagged. The caller must not hold g.Mu.
+func (s *HeuristicStats) record(g *graph.Graph, id string, f graph.Finding) bool {
+	g.AddFinding(id, f)
+
+	g.Mu.RLock()
+	defer g.Mu.RUnlock()
+	if node := g.GetNode(id); node == nil || !node.IsWaste {
+		return false
+	}
+	s.ItemsFound++
+	s.ProjectedSavings += f.Savings
+	return true
+}
+
 // WeightedHeuristic interface.
 type WeightedHeuristic interface {
 	Name() string
 	Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error)
 }
 
+// Finding is a waste item reported while the analysis is still running.
+type Finding struct {
+	ID        string  `json:"id"`
+	Type      string  `json:"type"`
+	Region    string  `json:"region,omitempty"`
+	Reason    string  `json:"reason"`
+	Cost      float64 `json:"monthly_cost"`
+	RiskScore int     `json:"risk_score"`
+}
+
+// FindingHandler receives findings in batches, once per completed heuristic.
+type FindingHandler func(findings []Finding)
+
 // Engine runs heuristics.
 type Engine struct {
 	heuristics []WeightedHeuristic
+	disabled   map[string]bool
+	onFindings FindingHandler
 }
 
 // NewEngine initializes engine.
@@ -46,16 +85,43 @@ func NewEngine() *Engine {
 	}
 }
 
-// Register heuristic.
+// Disable skips heuristics by Name() in later Register calls.
+func (e *Engine) Disable(names ...string) {
+	if e.disabled == nil {
+		e.disabled = make(map[string]bool, len(names))
+	}
+	for _, name := range names {
+		e.disabled[name] = true
+	}
+}
+
+// Register heuristic, unless it is disabled.
 func (e *Engine) Register(h WeightedHeuristic) {
+	if e.disabled[h.Name()] {
+		slog.Info("Heuristic disabled, skipping", "heuristic", h.Name())
+		return
+	}
 	e.heuristics = append(e.heuristics, h)
 }
 
+// OnFindings enables streaming. The handler is called from heuristic goroutines,
+// but never concurrently with itself.
+func (e *Engine) OnFindings(fn FindingHandler) {
+	e.onFindings = fn
+}
+
 // Run executes heuristics.
 func (e *Engine) Run(ctx context.Context, g *graph.Graph) error {
 	var wg sync.WaitGroup
 	errs := make(chan error, len(e.heuristics))
 
+	var stream *findingStream
+	if e.onFindings != nil {
+		stream = newFindingStream(g, e.onFindings)
+		g.SetWasteListener(stream.add)
+		defer g.SetWasteListener(nil)
+	}
+
 	tracer := otel.Tracer("cloudslash/heuristics")
 
 	for _, h := range e.heuristics {
@@ -86,12 +152,22 @@ func (e *Engine) Run(ctx context.Context, g *graph.Graph) error {
 					attribute.Float64("projected_savings_usd", stats.ProjectedSavings),
 				)
 			}
+
+			// Emit what this and any earlier heuristic reported; nodes are
+			// complete by the time AddFinding notifies.
+			if stream != nil {
+				stream.flush()
+			}
 		}(h)
 	}
 
 	wg.Wait()
 	close(errs)
 
+	if stream != nil {
+		stream.flush()
+	}
+
 	for err := range errs {
 		// Return first error.
 		return err
@@ -99,3 +175,74 @@ func (e *Engine) Run(ctx context.Context, g *graph.Graph) error {
 
 	return nil
 }
+
+// findingStream buffers IDs reported by the waste listener until they can be emitted.
+type findingStream struct {
+	g       *graph.Graph
+	handler FindingHandler
+
+	mu      sync.Mutex
+	pending []string
+	seen    map[string]bool
+
+	emitMu sync.Mutex
+}
+
+func newFindingStream(g *graph.Graph, handler FindingHandler) *findingStream {
+	return &findingStream{
+		g:       g,
+		handler: handler,
+		seen:    make(map[string]bool),
+	}
+}
+
+func (s *findingStream) add(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.seen[id] {
+		return
+	}
+	s.seen[id] = true
+	s.pending = append(s.pending, id)
+}
+
+func (s *findingStream) flush() {
+	s.emitMu.Lock()
+	defer s.emitMu.Unlock()
+
+	s.mu.Lock()
+	ids := s.pending
+	s.pending = nil
+	s.mu.Unlock()
+
+	if len(ids) == 0 {
+		return
+	}
+
+	batch := make([]Finding, 0, len(ids))
+	s.g.Mu.RLock()
+	for _, id := range ids {
+		node := s.g.GetNode(id)
+		if node == nil || !node.IsWaste || node.Ignored {
+			continue
+		}
+		reason, _ := node.Properties["Reason"].(string)
+		if reason == "" {
+			reason = node.WasteReason
+		}
+		region, _ := node.Properties["Region"].(string)
+		batch = append(batch, Finding{
+			ID:        id,
+			Type:      node.TypeStr(),
+			Region:    region,
+			Reason:    reason,
+			Cost:      node.Cost,
+			RiskScore: node.RiskScore,
+		})
+	}
+	s.g.Mu.RUnlock()
+
+	if len(batch) > 0 {
+		s.handler(batch)
+	}
+}
@@ -0,0 +1,86 @@
+package heuristics
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// Remediation caution levels derived from the environment tag (--env-tag).
+const (
+	CautionManualReview = "manual-review" // Production: never scripted, always reviewed.
+	CautionSafeDelete   = "safe-delete"   // Sandbox/dev: safe to clean up first.
+)
+
+// ApplyEnvironment annotates findings with the value of tagKey as Environment
+// and sets RemediationCaution from it. Production findings are capped below the
+// REVIEW threshold so they never reach the cleanup scripts. Run it after all
+// heuristics. Returns the number of findings annotated.
+func ApplyEnvironment(g *graph.Graph, tagKey string) int {
+	if tagKey == "" {
+		return 0
+	}
+
+	g.Mu.Lock()
+	defer g.Mu.Unlock()
+
+	annotated := 0
+	for _, node := range g.Store.GetAllNodes() {
+		if !node.IsWaste {
+			continue
+		}
+		env := tagValueFold(nodeTags(node), tagKey)
+		if env == "" {
+			continue
+		}
+		node.Properties["Environment"] = env
+		annotated++
+
+		switch lower := strings.ToLower(env); {
+		case IsProductionEnv(lower):
+			if _, done := node.Properties["RemediationCaution"]; !done && node.RiskScore >= 50 {
+				node.RiskScore = 49
+			}
+			node.Properties["RemediationCaution"] = CautionManualReview
+			if reason, _ := node.Properties["Reason"].(string); !strings.Contains(reason, "manual review required") {
+				node.Properties["Reason"] = fmt.Sprintf("%s (%s=%s: manual review required)", reason, tagKey, env)
+			}
+		case isNonProdEnv(lower):
+			node.Properties["RemediationCaution"] = CautionSafeDelete
+		}
+	}
+	return annotated
+}
+
+// nodeTags returns the node's tags. Scanners store map[string]string; graphs
+// decoded without property types carry map[string]interface{}.
+func nodeTags(node *graph.Node) map[string]string {
+	switch tags := node.Properties["Tags"].(type) {
+	case map[string]string:
+		return tags
+	case map[string]interface{}:
+		out := make(map[string]string, len(tags))
+		for k, v := range tags {
+			if s, ok := v.(string); ok {
+				out[k] = s
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// tagValueFold returns the tag value for key, matching the key case-insensitively
+// when there is no exact match.
+func tagValueFold(tags map[string]string, key string) string {
+	if v, ok := tags[key]; ok {
+		return v
+	}
+	for k, v := range tags {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return ""
+}
@@ -8,9 +8,16 @@ import (
 	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
 	"github.com/DrSkyle/cloudslash/v2/pkg/providers/k8s"
 	"github.com/aws/aws-sdk-go-v2/service/eks/types"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// Fargate on-demand rates (us-east-1, Linux/x86).
+const (
+	fargateVCPUHourly = 0.04048
+	fargateGBHourly   = 0.004445
+)
+
 type AbandonedFargateHeuristic struct {
 	K8sClient *k8s.Client
 }
@@ -33,15 +40,17 @@ func (h *AbandonedFargateHeuristic) Run(ctx context.Context, g *graph.Graph) (*H
 		}
 
 		profileName, _ := node.Properties["ProfileName"].(string)
+		selectors, _ := node.Properties["Selectors"].([]types.FargateProfileSelector)
+
+		// Record running pods for IdleEKSClusterHeuristic, system profiles included.
+		h.recordUsage(ctx, node, profileName, selectors)
 
 		// Exclude system profiles.
 		if profileName == "fp-default" || strings.Contains(strings.ToLower(profileName), "coredns") {
 			continue
 		}
 
-		// Parse selectors.
-		selectors, ok := node.Properties["Selectors"].([]types.FargateProfileSelector)
-		if !ok || len(selectors) == 0 {
+		if len(selectors) == 0 {
 			// Empty profile.
 			node.IsWaste = true
 			node.RiskScore = 100
@@ -138,6 +147,73 @@ func (h *AbandonedFargateHeuristic) Run(ctx context.Context, g *graph.Graph) (*H
 	return stats, nil
 }
 
+// recordUsage stores the profile's running pods and their billed size on
+// the node: FargatePods, FargateWorkloadPods (outside kube-system),
+// FargateVCPU and FargateMemoryGB. Nothing is stored if a pod list fails.
+func (h *AbandonedFargateHeuristic) recordUsage(ctx context.Context, node *graph.Node, profileName string, selectors []types.FargateProfileSelector) {
+	seen := make(map[string]bool)
+	var pods, workloads int
+	var vcpu, memGB float64
+
+	for _, sel := range selectors {
+		if sel.Namespace == nil {
+			continue
+		}
+		list, err := h.K8sClient.Clientset.CoreV1().Pods(*sel.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: formatLabelSelector(sel.Labels),
+		})
+		if err != nil {
+			return
+		}
+		for i := range list.Items {
+			pod := &list.Items[i]
+			key := pod.Namespace + "/" + pod.Name
+			if seen[key] || pod.Status.Phase != corev1.PodRunning || pod.Labels["eks.amazonaws.com/fargate-profile"] != profileName {
+				continue
+			}
+			seen[key] = true
+
+			pods++
+			if pod.Namespace != "kube-system" {
+				workloads++
+			}
+			c, m := fargatePodSize(pod)
+			vcpu += c
+			memGB += m
+		}
+	}
+
+	node.Properties["FargatePods"] = pods
+	node.Properties["FargateWorkloadPods"] = workloads
+	node.Properties["FargateVCPU"] = vcpu
+	node.Properties["FargateMemoryGB"] = memGB
+}
+
+// fargatePodSize returns the vCPU and memory (GB) Fargate bills a pod for.
+// Fargate reports the provisioned size in the CapacityProvisioned annotation;
+// without it the container requests are used, at the 0.25 vCPU / 0.5 GB minimum.
+func fargatePodSize(pod *corev1.Pod) (float64, float64) {
+	var vcpu, memGB float64
+	if capacity, ok := pod.Annotations["CapacityProvisioned"]; ok {
+		if n, _ := fmt.Sscanf(capacity, "%gvCPU %gGB", &vcpu, &memGB); n == 2 {
+			return vcpu, memGB
+		}
+		vcpu, memGB = 0, 0
+	}
+
+	for _, c := range pod.Spec.Containers {
+		vcpu += c.Resources.Requests.Cpu().AsApproximateFloat64()
+		memGB += c.Resources.Requests.Memory().AsApproximateFloat64() / (1 << 30)
+	}
+	if vcpu < 0.25 {
+		vcpu = 0.25
+	}
+	if memGB < 0.5 {
+		memGB = 0.5
+	}
+	return vcpu, memGB
+}
+
 // formatLabelSelector formats labels.
 func formatLabelSelector(labels map[string]string) string {
 	if len(labels) == 0 {
@@ -0,0 +1,54 @@
+package heuristics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/pricing"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/DrSkyle/cloudslash/v2/pkg/providers/gcp"
+)
+
+// gcpIdleCPUPercent is the peak CPU below which a running VM is idle, as for EC2.
+const gcpIdleCPUPercent = 5.0
+
+// IdleGCPInstanceHeuristic flags running Compute Engine VMs whose peak CPU
+// stayed under 5% over gcp.CPUWindow. The GCP scanner reads the peak from
+// Cloud Monitoring; VMs without it are never judged.
+type IdleGCPInstanceHeuristic struct{}
+
+func (h *IdleGCPInstanceHeuristic) Name() string { return "IdleGCPInstanceHeuristic" }
+
+func (h *IdleGCPInstanceHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	stats := &HeuristicStats{}
+
+	g.Mu.Lock()
+	defer g.Mu.Unlock()
+
+	for _, node := range g.Store.GetAllNodes() {
+		if node.TypeStr() != gcp.InstanceType {
+			continue
+		}
+		if state, _ := node.Properties["State"].(string); state != "running" {
+			continue
+		}
+		peak, ok := node.Properties["PeakCPUPercent"].(float64)
+		if !ok || peak >= gcpIdleCPUPercent {
+			continue
+		}
+
+		name, _ := node.Properties["Name"].(string)
+		machineType, _ := node.Properties["Type"].(string)
+		cost := pricing.EstimateGCPInstancePrice(machineType)
+		node.AddFinding(graph.Finding{
+			Heuristic: h.Name(),
+			Reason:    fmt.Sprintf("Idle GCP VM: %s (%s) peaked at %.2f%% CPU over %s", name, machineType, peak, windowLabel(gcp.CPUWindow)),
+			Score:     60,
+			Action:    "Stop the VM, or delete it once its disks are snapshotted",
+			Savings:   cost,
+		})
+		stats.ItemsFound++
+		stats.ProjectedSavings += cost
+	}
+	return stats, nil
+}
@@ -4,13 +4,14 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/DrSkyle/cloudslash/v2/pkg/config"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
 	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
 )
 
 // GhostNodeGroupHeuristic checks node groups.
-type GhostNodeGroupHeuristic struct{}
+type GhostNodeGroupHeuristic struct {
+	Region string // Scan region; prices nodes that carry no region of their own.
+}
 
 func (h *GhostNodeGroupHeuristic) Name() string { return "GhostNodeGroupHeuristic" }
 
@@ -43,10 +44,7 @@ func (h *GhostNodeGroupHeuristic) Run(ctx context.Context, g *graph.Graph) (*Heu
 			if it, ok := node.Properties["InstanceType"].(string); ok && it != "" {
 				instanceType = it
 			}
-			region := config.DefaultRegion
-			if r, ok := node.Properties["Region"].(string); ok && r != "" {
-				region = r
-			}
+			region := NodeRegion(node, h.Region)
 
 			estimator := &aws.StaticCostEstimator{}
 			estCostPerNode := estimator.GetEstimatedCost(instanceType, region)
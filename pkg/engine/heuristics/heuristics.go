@@ -18,6 +18,8 @@ import (
 type NATGatewayHeuristic struct {
 	CW      *internalaws.CloudWatchClient
 	Pricing *pricing.Client
+	Region  string        // Scan region; prices nodes that carry no region of their own.
+	Window  time.Duration // Metric lookback; zero means internalconfig.DefaultMetricWindow.
 }
 
 
@@ -34,10 +36,13 @@ func (h *NATGatewayHeuristic) Run(ctx context.Context, g *graph.Graph) (*Heurist
 	}
 	g.Mu.RUnlock()
 
+	var throttled throttleTracker
+	defer func() { throttled.report(g, h.Name()) }()
+
 	for _, node := range natGateways {
 		// ... (metric logic)
 		endTime := time.Now()
-		startTime := endTime.Add(-7 * 24 * time.Hour)
+		startTime := endTime.Add(-metricWindow(h.Window, internalconfig.DefaultMetricWindow))
 		var id string
 		fmt.Sscanf(node.IDStr(), "arn:aws:ec2:region:account:natgateway/%s", &id)
 		if id == "" {
@@ -55,37 +60,45 @@ func (h *NATGatewayHeuristic) Run(ctx context.Context, g *graph.Graph) (*Heurist
 		if h.CW == nil {
 			continue
 		}
+		cw := scopedCW(h.CW, node)
 
-		maxConns, err := h.CW.GetMetricMax(ctx, "AWS/NATGateway", "ActiveConnectionCount", dims, startTime, endTime)
+		maxConns, err := cw.GetMetricMax(ctx, "AWS/NATGateway", "ActiveConnectionCount", dims, startTime, endTime)
 		if err != nil {
+			throttled.check(err)
 			continue
 		}
-		sumBytes, err := h.CW.GetMetricSum(ctx, "AWS/NATGateway", "BytesOutToDestination", dims, startTime, endTime)
+		sumBytes, err := cw.GetMetricSum(ctx, "AWS/NATGateway", "BytesOutToDestination", dims, startTime, endTime)
 		if err != nil {
+			throttled.check(err)
 			continue
 		}
 
 		if maxConns < 5 && sumBytes < 1e9 {
-			g.MarkWaste(node.IDStr(), 80)
-			node.Properties["Reason"] = fmt.Sprintf("Unused NAT Gateway: MaxConns=%.0f, BytesOut=%.0f", maxConns, sumBytes)
-			stats.ItemsFound++
-
+			var cost float64
 			if h.Pricing != nil {
-				cost, err := h.Pricing.GetNATGatewayPrice(ctx, "us-east-1")
-				if err == nil {
-					node.Cost = cost
-					stats.ProjectedSavings += cost
+				if price, err := h.Pricing.GetNATGatewayPrice(ctx, NodeRegion(node, h.Region)); err == nil {
+					cost = price
 				}
 			}
+			g.AddFinding(node.IDStr(), graph.Finding{
+				Heuristic: h.Name(),
+				Reason:    fmt.Sprintf("Unused NAT Gateway: MaxConns=%.0f, BytesOut=%.0f", maxConns, sumBytes),
+				Score:     80,
+				Action:    "Delete the NAT Gateway",
+				Savings:   cost,
+			})
+			stats.ItemsFound++
+			stats.ProjectedSavings += cost
 		}
 	}
 	return stats, nil
 }
 
-// UnattachedVolumeHeuristic detects idle volumes.
+// UnattachedVolumeHeuristic detects idle volumes: EBS volumes and GCP persistent disks.
 type UnattachedVolumeHeuristic struct {
 	Pricing *pricing.Client
 	Config  internalconfig.UnattachedVolumeConfig
+	Region  string // Scan region; prices nodes that carry no region of their own.
 }
 
 
@@ -101,12 +114,15 @@ func (h *UnattachedVolumeHeuristic) Run(ctx context.Context, g *graph.Graph) (*H
 		Size             int
 		Type             string
 		AttachedInstance string
+		InstanceNode     string
 		DeleteOnTerm     bool
+		GCP              bool
+		Azure            bool
 	}
 	var volumes []volumeData
 
 	for _, node := range g.Store.GetAllNodes() {
-		if node.TypeStr() == "AWS::EC2::Volume" {
+		if node.TypeStr() == "AWS::EC2::Volume" || node.TypeStr() == "GCP::Compute::Disk" || node.TypeStr() == "Azure::Compute::Disk" {
 			sizeVal := 0
 			if s, ok := node.Properties["Size"].(int32); ok {
 				sizeVal = int(s)
@@ -117,6 +133,7 @@ func (h *UnattachedVolumeHeuristic) Run(ctx context.Context, g *graph.Graph) (*H
 			state, _ := node.Properties["State"].(string)
 			volType, _ := node.Properties["VolumeType"].(string)
 			attachedInstance, _ := node.Properties["AttachedInstanceId"].(string)
+			instanceNode, _ := node.Properties["AttachedInstanceNode"].(string)
 
 			volumes = append(volumes, volumeData{
 				Node:             node,
@@ -124,6 +141,9 @@ func (h *UnattachedVolumeHeuristic) Run(ctx context.Context, g *graph.Graph) (*H
 				Size:             sizeVal,
 				Type:             volType,
 				AttachedInstance: attachedInstance,
+				InstanceNode:     instanceNode,
+				GCP:              node.TypeStr() == "GCP::Compute::Disk",
+				Azure:            node.TypeStr() == "Azure::Compute::Disk",
 				DeleteOnTerm:     func() bool { v, _ := node.Properties["DeleteOnTermination"].(bool); return v }(),
 			})
 		}
@@ -139,8 +159,16 @@ func (h *UnattachedVolumeHeuristic) Run(ctx context.Context, g *graph.Graph) (*H
 			isWaste = true
 			score = 90
 			reason = "Unattached EBS Volume"
+			if vol.GCP {
+				reason = "Unattached Persistent Disk"
+			} else if vol.Azure {
+				reason = "Unattached Managed Disk"
+			}
 		} else if vol.State == "in-use" && vol.AttachedInstance != "" {
 			instanceARN := fmt.Sprintf("arn:aws:ec2:region:account:instance/%s", vol.AttachedInstance)
+			if vol.InstanceNode != "" {
+				instanceARN = vol.InstanceNode
+			}
 			instanceNode := g.GetNode(instanceARN)
 			var instanceState string
 			var launchTime time.Time
@@ -162,22 +190,35 @@ func (h *UnattachedVolumeHeuristic) Run(ctx context.Context, g *graph.Graph) (*H
 					isWaste = true
 					score = 70
 					reason = fmt.Sprintf("Idle EBS: Attached to stopped instance > %d days", thresholdDays)
+					if vol.GCP {
+						reason = fmt.Sprintf("Idle Persistent Disk: Attached to stopped instance > %d days", thresholdDays)
+					} else if vol.Azure {
+						reason = fmt.Sprintf("Idle Managed Disk: Attached to deallocated VM > %d days", thresholdDays)
+					}
 				}
 			}
 		}
 
 		if isWaste {
-			g.MarkWaste(vol.Node.IDStr(), score)
-			vol.Node.Properties["Reason"] = reason
-			stats.ItemsFound++
-
-			if h.Pricing != nil && vol.Size > 0 {
-				cost, err := h.Pricing.GetEBSPrice(ctx, "us-east-1", vol.Type, vol.Size)
-				if err == nil {
-					vol.Node.Cost = cost
-					stats.ProjectedSavings += cost
+			var cost float64
+			if vol.GCP && vol.Size > 0 {
+				cost = pricing.EstimateGCPDiskPrice(vol.Type, vol.Size)
+			} else if vol.Azure && vol.Size > 0 {
+				cost = pricing.EstimateAzureDiskPrice(vol.Type, vol.Size)
+			} else if h.Pricing != nil && vol.Size > 0 {
+				if price, err := h.Pricing.GetEBSPrice(ctx, NodeRegion(vol.Node, h.Region), vol.Type, vol.Size); err == nil {
+					cost = price
 				}
 			}
+			g.AddFinding(vol.Node.IDStr(), graph.Finding{
+				Heuristic: h.Name(),
+				Reason:    reason,
+				Score:     score,
+				Action:    "Snapshot and delete the volume",
+				Savings:   cost,
+			})
+			stats.ItemsFound++
+			stats.ProjectedSavings += cost
 		}
 	}
 	return stats, nil
@@ -186,6 +227,7 @@ func (h *UnattachedVolumeHeuristic) Run(ctx context.Context, g *graph.Graph) (*H
 // ElasticIPHeuristic detects unused EIPs.
 type ElasticIPHeuristic struct {
 	Pricing *pricing.Client
+	Region  string // Scan region; prices nodes that carry no region of their own.
 }
 
 
@@ -206,10 +248,17 @@ func (h *ElasticIPHeuristic) Run(ctx context.Context, g *graph.Graph) (*Heuristi
 			node.IsWaste = true
 			node.RiskScore = 50
 			node.Properties["Reason"] = "Unattached Elastic IP"
+			if inDNS, _ := node.Properties["FoundInDNS"].(bool); inDNS {
+				// Releasing would leave a dangling record (subdomain takeover).
+				zone, _ := node.Properties["DNSZone"].(string)
+				node.RiskScore = 10
+				node.Properties["DNSRisk"] = "SubdomainTakeover"
+				node.Properties["Reason"] = fmt.Sprintf("Unattached Elastic IP still referenced in DNS zone %s. Remove the record before releasing.", zone)
+			}
 			stats.ItemsFound++
 
 			if h.Pricing != nil {
-				cost, err := h.Pricing.GetEIPPrice(ctx, "us-east-1")
+				cost, err := h.Pricing.GetEIPPrice(ctx, NodeRegion(node, h.Region))
 				if err == nil {
 					node.Cost = cost
 					stats.ProjectedSavings += cost
@@ -267,7 +316,8 @@ func (h *S3MultipartHeuristic) Run(ctx context.Context, g *graph.Graph) (*Heuris
 
 // RDSHeuristic detects idle DBs.
 type RDSHeuristic struct {
-	CW *internalaws.CloudWatchClient
+	CW     *internalaws.CloudWatchClient
+	Window time.Duration // Metric lookback; zero means internalconfig.DefaultMetricWindow.
 }
 
 
@@ -278,25 +328,34 @@ func (h *RDSHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats
 	g.Mu.RLock()
 	var rdsInstances []*graph.Node
 	for _, node := range g.Store.GetAllNodes() {
-		if node.TypeStr() == "AWS::RDS::DBInstance" {
+		// Replicas are handled by IdleReadReplicaHeuristic.
+		if node.TypeStr() == "AWS::RDS::DBInstance" && !isReadReplica(node) {
 			rdsInstances = append(rdsInstances, node)
 		}
 	}
 	g.Mu.RUnlock()
 
+	var throttled throttleTracker
+	defer func() { throttled.report(g, h.Name()) }()
+
+	window := metricWindow(h.Window, internalconfig.DefaultMetricWindow)
 	for _, node := range rdsInstances {
 		status, _ := node.Properties["Status"].(string)
 
 		if status == "stopped" {
-			g.MarkWaste(node.IDStr(), 80)
-			node.Properties["Reason"] = "RDS Instance is stopped"
+			g.AddFinding(node.IDStr(), graph.Finding{
+				Heuristic: h.Name(),
+				Reason:    "RDS Instance is stopped",
+				Score:     80,
+				Action:    "Take a final snapshot and delete the instance",
+			})
 			stats.ItemsFound++
 			continue
 		}
 
 		// ... (Metric Checks)
 		endTime := time.Now()
-		startTime := endTime.Add(-7 * 24 * time.Hour)
+		startTime := endTime.Add(-window)
 		var id string
 		fmt.Sscanf(node.IDStr(), "arn:aws:rds:region:account:db:%s", &id)
 		if id == "" {
@@ -311,14 +370,19 @@ func (h *RDSHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats
 			continue
 		}
 
-		maxConns, err := h.CW.GetMetricMax(ctx, "AWS/RDS", "DatabaseConnections", dims, startTime, endTime)
+		maxConns, err := scopedCW(h.CW, node).GetMetricMax(ctx, "AWS/RDS", "DatabaseConnections", dims, startTime, endTime)
 		if err != nil {
+			throttled.check(err)
 			continue
 		}
 
 		if maxConns == 0 {
-			g.MarkWaste(node.IDStr(), 60)
-			node.Properties["Reason"] = "RDS Instance has 0 connections in 7 days"
+			g.AddFinding(node.IDStr(), graph.Finding{
+				Heuristic: h.Name(),
+				Reason:    fmt.Sprintf("RDS Instance has 0 connections in %s", windowLabel(window)),
+				Score:     60,
+				Action:    "Take a final snapshot and delete the instance",
+			})
 			stats.ItemsFound++
 		}
 	}
@@ -327,7 +391,8 @@ func (h *RDSHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats
 
 // ELBHeuristic detects unused ELBs.
 type ELBHeuristic struct {
-	CW *internalaws.CloudWatchClient
+	CW     *internalaws.CloudWatchClient
+	Window time.Duration // Metric lookback; zero means internalconfig.DefaultMetricWindow.
 }
 
 
@@ -344,10 +409,14 @@ func (h *ELBHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats
 	}
 	g.Mu.RUnlock()
 
+	var throttled throttleTracker
+	defer func() { throttled.report(g, h.Name()) }()
+
+	window := metricWindow(h.Window, internalconfig.DefaultMetricWindow)
 	for _, node := range elbs {
 		// ... (Logic)
 		endTime := time.Now()
-		startTime := endTime.Add(-7 * 24 * time.Hour)
+		startTime := endTime.Add(-window)
 		var lbDimValue string
 		parts := strings.Split(node.IDStr(), ":loadbalancer/")
 		if len(parts) > 1 {
@@ -364,14 +433,19 @@ func (h *ELBHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats
 			continue
 		}
 
-		requestCount, err := h.CW.GetMetricSum(ctx, "AWS/ApplicationELB", "RequestCount", dims, startTime, endTime)
+		requestCount, err := scopedCW(h.CW, node).GetMetricSum(ctx, "AWS/ApplicationELB", "RequestCount", dims, startTime, endTime)
 		if err != nil {
+			throttled.check(err)
 			continue
 		}
 
 		if requestCount < 10 {
-			g.MarkWaste(node.IDStr(), 70)
-			node.Properties["Reason"] = fmt.Sprintf("ELB unused: Only %.0f requests in 7 days", requestCount)
+			g.AddFinding(node.IDStr(), graph.Finding{
+				Heuristic: h.Name(),
+				Reason:    fmt.Sprintf("ELB unused: Only %.0f requests in %s", requestCount, windowLabel(window)),
+				Score:     70,
+				Action:    "Delete the load balancer",
+			})
 			stats.ItemsFound++
 		}
 	}
@@ -382,6 +456,8 @@ func (h *ELBHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats
 type UnderutilizedInstanceHeuristic struct {
 	CW      *internalaws.CloudWatchClient
 	Pricing *pricing.Client
+	Region  string        // Scan region; prices nodes that carry no region of their own.
+	Window  time.Duration // Metric lookback; zero means internalconfig.DefaultMetricWindow.
 }
 
 
@@ -398,14 +474,13 @@ func (h *UnderutilizedInstanceHeuristic) Run(ctx context.Context, g *graph.Graph
 	}
 	g.Mu.RUnlock()
 
+	running := make(map[*graph.Node]string)
+	var runningNodes []*graph.Node
 	for _, node := range instances {
-		// ... logic
 		state, _ := node.Properties["State"].(string)
 		if state != "running" {
 			continue
 		}
-
-		instanceType, _ := node.Properties["InstanceType"].(string)
 		instanceID := ""
 		if parts := strings.Split(node.IDStr(), "/"); len(parts) > 1 {
 			instanceID = parts[len(parts)-1]
@@ -413,48 +488,154 @@ func (h *UnderutilizedInstanceHeuristic) Run(ctx context.Context, g *graph.Graph
 		if instanceID == "" {
 			continue
 		}
+		running[node] = instanceID
+		runningNodes = append(runningNodes, node)
+	}
 
-		endTime := time.Now()
-		startTime := endTime.Add(-7 * 24 * time.Hour)
-		dims := []types.Dimension{
-			{Name: aws.String("InstanceId"), Value: aws.String(instanceID)},
+	// One batched read of peak CPU, memory and network per account and region
+	// instead of calls per instance. Memory is published only by the
+	// CloudWatch agent.
+	window := metricWindow(h.Window, internalconfig.DefaultMetricWindow)
+	endTime := time.Now()
+	startTime := endTime.Add(-window)
+	peaks := make(map[string]float64)
+	for cw, nodes := range groupByCW(h.CW, runningNodes) {
+		queries := make([]internalaws.MetricQuery, 0, 4*len(nodes))
+		for _, node := range nodes {
+			id := running[node]
+			dims := []types.Dimension{{Name: aws.String("InstanceId"), Value: aws.String(id)}}
+			for _, m := range []struct{ suffix, namespace, name string }{
+				{"", "AWS/EC2", "CPUUtilization"},
+				{"/mem", "CWAgent", "mem_used_percent"},
+				{"/in", "AWS/EC2", "NetworkIn"},
+				{"/out", "AWS/EC2", "NetworkOut"},
+			} {
+				queries = append(queries, internalaws.MetricQuery{
+					ID:         id + m.suffix,
+					Namespace:  m.namespace,
+					MetricName: m.name,
+					Dimensions: dims,
+					Stat:       "Maximum",
+					StartTime:  startTime,
+					EndTime:    endTime,
+				})
+			}
+		}
+		values, err := cw.GetMetricDataBatch(ctx, queries)
+		if err != nil {
+			if internalaws.IsThrottleError(err) {
+				g.AddError(fmt.Sprintf("CloudWatch [%s]", h.Name()), err)
+			}
+			continue
+		}
+		for id, v := range values {
+			peaks[id] = v
+		}
+	}
+
+	var flagged []*graph.Node
+
+	for _, node := range instances {
+		instanceID, ok := running[node]
+		if !ok {
+			continue
 		}
 
-		// History Fetch (Safe to ignore error)
-		var maxCPU float64
-		var err error
+		instanceType, _ := node.Properties["InstanceType"].(string)
+		if instanceType == "" {
+			instanceType, _ = node.Properties["Type"].(string)
+		}
+		platform, _ := node.Properties["PlatformDetails"].(string)
 
+		var usage instanceUsage
 		if h.CW != nil {
-			cpuHistory, _ := h.CW.GetMetricHistory(ctx, "AWS/EC2", "CPUUtilization", dims, startTime, endTime)
-			node.Properties["MetricsHistoryCPU"] = cpuHistory
-			netHistory, _ := h.CW.GetMetricHistory(ctx, "AWS/EC2", "NetworkIn", dims, startTime, endTime)
-			node.Properties["MetricsHistoryNet"] = netHistory
-		
-			maxCPU, err = h.CW.GetMetricMax(ctx, "AWS/EC2", "CPUUtilization", dims, startTime, endTime)
-			if err != nil {
+			peak, ok := peaks[instanceID]
+			if !ok {
 				continue
 			}
+			usage.CPUPercent = peak
+			usage.MemoryPercent = peaks[instanceID+"/mem"]
+			// Byte counts are summed per metric period.
+			period := float64(internalaws.MetricPeriod(window))
+			usage.NetworkBytesPerSec = (peaks[instanceID+"/in"] + peaks[instanceID+"/out"]) / period
 		} else {
 			// Mock Mode: Simulate idle instance
-			maxCPU = 1.0
+			usage.CPUPercent = 1.0
 		}
 
-		if maxCPU < 5.0 {
-			g.MarkWaste(node.IDStr(), 60)
-			node.Properties["Reason"] = fmt.Sprintf("Right-Sizing Opportunity: Max CPU %.2f%% < 5%% over 7 days", maxCPU)
-			stats.ItemsFound++
+		// Without the Pricing API, static estimates still rank candidates.
+		region := NodeRegion(node, h.Region)
+		price := func(t string) (float64, bool) {
+			if h.Pricing == nil {
+				return (&internalaws.StaticCostEstimator{}).GetEstimatedCost(t, region), true
+			}
+			cost, err := h.Pricing.GetEC2InstancePriceForPlatform(ctx, region, t, platform)
+			return cost, err == nil && cost > 0
+		}
+		rec, resize := recommendInstanceType(instanceType, usage, price)
+		idle := usage.CPUPercent < 5.0
+		if !idle && !resize {
+			continue
+		}
 
-			if h.Pricing != nil {
-				region := "us-east-1"
-				parts := strings.Split(node.IDStr(), ":")
-				if len(parts) > 3 {
-					region = parts[3]
-				}
+		flagged = append(flagged, node)
+		stats.ItemsFound++
+		node.Properties["PeakCPUPercent"] = usage.CPUPercent
+		var reason string
+		score, action := 60, "Stop or terminate the instance"
+		if idle {
+			reason = fmt.Sprintf("Right-Sizing Opportunity: Max CPU %.2f%% < 5%% over %s", usage.CPUPercent, windowLabel(window))
+		} else {
+			// Oversized rather than idle: lower confidence.
+			score = 40
+			reason = fmt.Sprintf("Right-Sizing Opportunity: Max CPU %.2f%%", usage.CPUPercent)
+			if usage.MemoryPercent > 0 {
+				reason += fmt.Sprintf(", memory %.2f%%", usage.MemoryPercent)
+			}
+			reason += " over " + windowLabel(window)
+		}
+
+		var savings float64
+		if resize {
+			reason += "; " + rec.String()
+			action = "Resize to " + rec.To
+			node.Properties["RecommendedInstanceType"] = rec.To
+			savings = rec.Savings
+			node.Cost = rec.Savings
+		} else if h.Pricing != nil {
+			cost, err := h.Pricing.GetEC2InstancePriceForPlatform(ctx, region, instanceType, platform)
+			if err == nil {
+				savings = cost
+				node.Cost = cost
+			}
+		}
+		stats.ProjectedSavings += savings
 
-				cost, err := h.Pricing.GetEC2InstancePrice(ctx, region, instanceType)
-				if err == nil {
-					node.Cost = cost
-					stats.ProjectedSavings += cost
+		// Licensed OSes make idle capacity far more expensive.
+		if isLicensedPlatform(platform) {
+			node.Properties["LicensedPlatform"] = platform
+			reason = fmt.Sprintf("%s (%s license included in cost)", reason, platform)
+		}
+		g.AddFinding(node.IDStr(), graph.Finding{
+			Heuristic: h.Name(),
+			Reason:    reason,
+			Score:     score,
+			Action:    action,
+			Savings:   savings,
+		})
+	}
+
+	// The detail view charts CPU and network history; only flagged instances need it.
+	for cw, nodes := range groupByCW(h.CW, flagged) {
+		ids := make([]string, 0, len(nodes))
+		for _, node := range nodes {
+			ids = append(ids, running[node])
+		}
+		if history, err := cw.GetInstanceUtilization(ctx, ids, startTime, endTime); err == nil {
+			for _, node := range nodes {
+				if u := history[running[node]]; u != nil {
+					node.Properties["MetricsHistoryCPU"] = u.CPUHistory
+					node.Properties["MetricsHistoryNet"] = u.NetHistory
 				}
 			}
 		}
@@ -462,15 +643,30 @@ func (h *UnderutilizedInstanceHeuristic) Run(ctx context.Context, g *graph.Graph
 	return stats, nil
 }
 
+// isLicensedPlatform reports whether the hourly rate includes an OS or software license.
+func isLicensedPlatform(platformDetails string) bool {
+	opSys, sw := pricing.EC2PricingOS(platformDetails)
+	return opSys != "Linux" || sw != "NA"
+}
+
 // TagComplianceHeuristic checks tags.
 type TagComplianceHeuristic struct {
 	RequiredTags []string
+
+	// CostAllocationTags switches to cost-allocation mode: cost-bearing resources
+	// missing any activated cost-allocation tag are reported as unattributable spend.
+	CostAllocationTags []string
+	Pricing            *pricing.Client
+	Region             string // Scan region; prices nodes that carry no region of their own.
 }
 
 
 func (h *TagComplianceHeuristic) Name() string { return "TagComplianceHeuristic" }
 
 func (h *TagComplianceHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	if len(h.CostAllocationTags) > 0 {
+		return h.runCostAllocation(ctx, g), nil
+	}
 	if len(h.RequiredTags) == 0 {
 		return nil, nil
 	}
@@ -502,14 +698,14 @@ func (h *TagComplianceHeuristic) Run(ctx context.Context, g *graph.Graph) (*Heur
 
 		if len(missing) > 0 {
 			if !node.IsWaste {
-				node.IsWaste = true
-				node.RiskScore = 40
-				node.Properties["Reason"] = fmt.Sprintf("Compliance Violation: Missing Tags: %s", strings.Join(missing, ", "))
 				stats.ItemsFound++
-			} else {
-				currentReason, _ := node.Properties["Reason"].(string)
-				node.Properties["Reason"] = currentReason + fmt.Sprintf("; Compliance: Missing %s", strings.Join(missing, ", "))
 			}
+			node.AddFinding(graph.Finding{
+				Heuristic: h.Name(),
+				Reason:    fmt.Sprintf("Compliance Violation: Missing Tags: %s", strings.Join(missing, ", ")),
+				Score:     40,
+				Action:    "Add the missing tags",
+			})
 		}
 	}
 	return stats, nil
@@ -555,14 +751,27 @@ func (h *IAMHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats
 
 		roles, err := h.IAM.GetRolesFromInstanceProfile(ctx, profileName)
 		if err != nil {
+			g.Mu.Lock()
+			internalaws.RecordPropertyError(node.Properties, "iam:GetInstanceProfile", err)
+			g.Mu.Unlock()
 			continue
 		}
 
 		for _, roleArn := range roles {
 			risks, err := h.IAM.SimulatePrivileges(ctx, roleArn)
-			if err == nil && len(risks) > 0 {
-				g.MarkWaste(node.IDStr(), 95)
-				node.Properties["Reason"] = fmt.Sprintf("SECURITY ALERT: Formal Verification confirmed dangerous permission(s) on Instance Profile '%s': %s", profileName, strings.Join(risks, ", "))
+			if err != nil {
+				g.Mu.Lock()
+				internalaws.RecordPropertyError(node.Properties, "iam:SimulatePrincipalPolicy", err)
+				g.Mu.Unlock()
+				continue
+			}
+			if len(risks) > 0 {
+				g.AddFinding(node.IDStr(), graph.Finding{
+					Heuristic: h.Name(),
+					Reason:    fmt.Sprintf("SECURITY ALERT: Formal Verification confirmed dangerous permission(s) on Instance Profile '%s': %s", profileName, strings.Join(risks, ", ")),
+					Score:     95,
+					Action:    "Remove the dangerous permissions from the instance profile",
+				})
 				stats.ItemsFound++
 			}
 		}
@@ -573,6 +782,7 @@ func (h *IAMHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats
 // SnapshotChildrenHeuristic checks snapshots.
 type SnapshotChildrenHeuristic struct {
 	Pricing *pricing.Client
+	Region  string // Scan region; prices snapshots that carry no region of their own.
 }
 
 
@@ -605,22 +815,16 @@ func (h *SnapshotChildrenHeuristic) Run(ctx context.Context, g *graph.Graph) (*H
 		}
 
 		if wasteVolumes[volID] {
-			g.MarkWaste(snap.IDStr(), 90)
-			snap.Properties["Reason"] = fmt.Sprintf("Snapshot of Unused Volume (%s)", volID)
+			cost := snapshotCost(ctx, h.Pricing, NodeRegion(snap, h.Region), snap)
+			g.AddFinding(snap.IDStr(), graph.Finding{
+				Heuristic: h.Name(),
+				Reason:    fmt.Sprintf("Snapshot of Unused Volume (%s)", volID),
+				Score:     90,
+				Action:    "Delete the snapshot",
+				Savings:   cost,
+			})
 			stats.ItemsFound++
-
-			sizeGB := 0
-			if s, ok := snap.Properties["VolumeSize"].(int32); ok {
-				sizeGB = int(s)
-			} else if s, ok := snap.Properties["VolumeSize"].(int); ok {
-				sizeGB = s
-			}
-
-			if sizeGB > 0 {
-				cost := float64(sizeGB) * 0.05
-				snap.Cost = cost
-				stats.ProjectedSavings += cost
-			}
+			stats.ProjectedSavings += cost
 		}
 	}
 
@@ -1,11 +1,23 @@
 package heuristics
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"math"
+	"os"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	internalaws "github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/pricing"
 	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/smithy-go"
 )
 
 func TestZombieEBSHeuristic(t *testing.T) {
@@ -106,3 +118,2258 @@ func TestS3MultipartHeuristic(t *testing.T) {
 		t.Error("Expected upload-new NOT to be waste")
 	}
 }
+
+func TestEmptyVPCHeuristic(t *testing.T) {
+	g := graph.NewGraph()
+	ctx := context.Background()
+
+	emptyVPC := "arn:aws:ec2:region:account:vpc/vpc-empty"
+	emptySubnet := "arn:aws:ec2:region:account:subnet/subnet-empty"
+	g.AddNode(emptyVPC, "AWS::EC2::VPC", map[string]interface{}{"IsDefault": false})
+	g.AddNode(emptySubnet, "AWS::EC2::Subnet", map[string]interface{}{})
+	g.AddNode("arn:aws:ec2:region:account:instance/i-stopped", "AWS::EC2::Instance", map[string]interface{}{"State": "stopped"})
+	g.AddTypedEdge(emptyVPC, emptySubnet, graph.EdgeTypeContains, 100)
+	g.AddTypedEdge(emptySubnet, "arn:aws:ec2:region:account:instance/i-stopped", graph.EdgeTypeContains, 100)
+
+	activeVPC := "arn:aws:ec2:region:account:vpc/vpc-active"
+	activeSubnet := "arn:aws:ec2:region:account:subnet/subnet-active"
+	g.AddNode(activeVPC, "AWS::EC2::VPC", map[string]interface{}{"IsDefault": false})
+	g.AddNode(activeSubnet, "AWS::EC2::Subnet", map[string]interface{}{})
+	g.AddNode("arn:aws:ec2:region:account:instance/i-running", "AWS::EC2::Instance", map[string]interface{}{"State": "running"})
+	g.AddTypedEdge(activeVPC, activeSubnet, graph.EdgeTypeContains, 100)
+	g.AddTypedEdge(activeSubnet, "arn:aws:ec2:region:account:instance/i-running", graph.EdgeTypeContains, 100)
+
+	g.AddNode("arn:aws:ec2:region:account:vpc/vpc-default", "AWS::EC2::VPC", map[string]interface{}{"IsDefault": true})
+
+	g.CloseAndWait()
+
+	h := &EmptyVPCHeuristic{}
+	stats, err := h.Run(ctx, g)
+	if err != nil {
+		t.Fatalf("Heuristic run failed: %v", err)
+	}
+	if stats.ItemsFound != 1 {
+		t.Errorf("Expected 1 empty VPC, got %d", stats.ItemsFound)
+	}
+
+	g.Mu.RLock()
+	defer g.Mu.RUnlock()
+
+	node := g.GetNode(emptyVPC)
+	if !node.IsWaste {
+		t.Fatal("Expected vpc-empty to be marked as waste")
+	}
+	remaining, _ := node.Properties["RemainingResources"].([]string)
+	if len(remaining) != 2 || remaining[0] != "i-stopped" || remaining[1] != "subnet-empty" {
+		t.Errorf("Unexpected remaining resources: %v", remaining)
+	}
+
+	if g.GetNode(activeVPC).IsWaste {
+		t.Error("Expected vpc-active (running instance) to be left alone")
+	}
+	if g.GetNode("arn:aws:ec2:region:account:vpc/vpc-default").IsWaste {
+		t.Error("Expected default VPC to be skipped")
+	}
+}
+
+func TestReconcileComputeOptimizer(t *testing.T) {
+	g := graph.NewGraph()
+
+	agreeID := "arn:aws:ec2:region:account:instance/i-agree"
+	disagreeID := "arn:aws:ec2:region:account:instance/i-disagree"
+	idleID := "arn:aws:ec2:region:account:instance/i-idle"
+	g.AddNode(agreeID, "AWS::EC2::Instance", map[string]interface{}{})
+	g.AddNode(disagreeID, "AWS::EC2::Instance", map[string]interface{}{"RecommendedInstanceType": "m5.large", "PeakCPUPercent": 22.0})
+	g.AddNode(idleID, "AWS::EC2::Instance", map[string]interface{}{"PeakCPUPercent": 1.2})
+	g.CloseAndWait()
+
+	g.MarkWaste(agreeID, 60)
+	g.MarkWaste(disagreeID, 60)
+	g.MarkWaste(idleID, 60)
+
+	reconcileComputeOptimizer(g, map[string]internalaws.InstanceRecommendation{
+		"i-agree":    {InstanceID: "i-agree", Finding: "Overprovisioned", RecommendedType: "m5.large"},
+		"i-disagree": {InstanceID: "i-disagree", Finding: "Optimized"},
+		"i-idle":     {InstanceID: "i-idle", Finding: "Optimized"},
+	})
+
+	g.Mu.RLock()
+	defer g.Mu.RUnlock()
+
+	agree := g.GetNode(agreeID)
+	if agree.Properties["ComputeOptimizer"] != "Compute Optimizer agrees: recommends m5.large" {
+		t.Errorf("Unexpected annotation: %v", agree.Properties["ComputeOptimizer"])
+	}
+	if agree.RiskScore != 80 {
+		t.Errorf("Expected agreement to raise RiskScore to 80, got %d", agree.RiskScore)
+	}
+
+	disagree := g.GetNode(disagreeID)
+	if review, _ := disagree.Properties["NeedsReview"].(bool); !review {
+		t.Error("Expected disagreement to be flagged for review")
+	}
+	if disagree.RiskScore >= 50 {
+		t.Errorf("Expected disagreement to drop below review threshold, got %d", disagree.RiskScore)
+	}
+
+	// Compute Optimizer does not judge idleness; an idle finding keeps its score.
+	idle := g.GetNode(idleID)
+	if idle.RiskScore != 60 || idle.Properties["NeedsReview"] != nil {
+		t.Errorf("Expected idle finding to keep risk 60, got %d (review %v)", idle.RiskScore, idle.Properties["NeedsReview"])
+	}
+	if idle.Properties["ComputeOptimizer"] == nil {
+		t.Error("Expected the disagreement to still be noted")
+	}
+}
+
+func TestEngineStreamsFindings(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:ec2:region:account:vpc/vpc-empty", "AWS::EC2::VPC", map[string]interface{}{
+		"IsDefault": false,
+	})
+	g.CloseAndWait()
+
+	var batches [][]Finding
+	e := NewEngine()
+	e.Register(&EmptyVPCHeuristic{})
+	e.OnFindings(func(findings []Finding) {
+		batches = append(batches, findings)
+	})
+
+	if err := e.Run(context.Background(), g); err != nil {
+		t.Fatalf("Engine run failed: %v", err)
+	}
+
+	if len(batches) != 1 || len(batches[0]) != 1 {
+		t.Fatalf("Expected one batch with one finding, got %v", batches)
+	}
+	f := batches[0][0]
+	if f.ID != "arn:aws:ec2:region:account:vpc/vpc-empty" || f.Type != "AWS::EC2::VPC" {
+		t.Errorf("Unexpected finding: %+v", f)
+	}
+	if f.Reason == "" {
+		t.Error("Expected streamed finding to carry the heuristic's reason")
+	}
+}
+
+// The waste listener may read a node as soon as it fires, before the
+// heuristic that flagged it returns, so reason and cost must already be set.
+func TestWasteListenerSeesCompleteFinding(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:ec2:us-east-1:123:image/ami-old", "AWS::EC2::AMI", map[string]interface{}{
+		"CreateTime": time.Now().AddDate(-1, 0, 0),
+	})
+	g.CloseAndWait()
+
+	var reason string
+	var cost float64
+	g.SetWasteListener(func(id string) {
+		g.Mu.RLock()
+		defer g.Mu.RUnlock()
+		node := g.GetNode(id)
+		reason, _ = node.Properties["Reason"].(string)
+		cost = node.Cost
+	})
+	if _, err := (&AgedAMIHeuristic{}).Run(context.Background(), g); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(reason, "Aged Artifact") || cost != 1 {
+		t.Errorf("Listener saw reason=%q cost=%.2f, want the finding's", reason, cost)
+	}
+}
+
+func TestIdleCIHeuristic(t *testing.T) {
+	g := graph.NewGraph()
+	stale := time.Now().AddDate(0, 0, -120)
+
+	pipeline := "arn:aws:codepipeline:us-east-1:123456789012:legacy-app"
+	project := "arn:aws:codebuild:us-east-1:123456789012:project/legacy-build"
+	active := "arn:aws:codebuild:us-east-1:123456789012:project/live-build"
+	unread := "arn:aws:codebuild:us-east-1:123456789012:project/throttled-build"
+	deadBucket := "arn:aws:s3:::bucket/legacy-artifacts"
+	sharedBucket := "arn:aws:s3:::bucket/shared-artifacts"
+
+	g.AddNode(pipeline, "AWS::CodePipeline::Pipeline", map[string]interface{}{
+		"Name": "legacy-app", "Created": stale, "LastExecutionTime": stale,
+	})
+	g.AddNode(project, "AWS::CodeBuild::Project", map[string]interface{}{
+		"Name": "legacy-build", "Created": stale, "LastBuildTime": stale,
+	})
+	g.AddNode(active, "AWS::CodeBuild::Project", map[string]interface{}{
+		"Name": "live-build", "Created": stale, "LastBuildTime": time.Now().AddDate(0, 0, -2),
+	})
+	g.AddNode(unread, "AWS::CodeBuild::Project", map[string]interface{}{
+		"Name": "throttled-build", "Created": stale, "ActivityUnknown": true,
+	})
+	g.AddNode(deadBucket, "AWS::S3::Bucket", map[string]interface{}{"Name": "legacy-artifacts"})
+	g.AddNode(sharedBucket, "AWS::S3::Bucket", map[string]interface{}{"Name": "shared-artifacts"})
+
+	g.AddTypedEdge(pipeline, project, graph.EdgeTypeRuns, 1)
+	g.AddTypedEdge(pipeline, deadBucket, graph.EdgeTypeUses, 1)
+	g.AddTypedEdge(project, sharedBucket, graph.EdgeTypeUses, 1)
+	g.AddTypedEdge(active, sharedBucket, graph.EdgeTypeUses, 1)
+	g.CloseAndWait()
+
+	h := &IdleCIHeuristic{}
+	stats, err := h.Run(context.Background(), g)
+	if err != nil {
+		t.Fatalf("Heuristic run failed: %v", err)
+	}
+	if stats.ItemsFound != 3 {
+		t.Errorf("Expected 3 findings, got %d", stats.ItemsFound)
+	}
+
+	g.Mu.RLock()
+	defer g.Mu.RUnlock()
+
+	for _, id := range []string{pipeline, project, deadBucket} {
+		node := g.GetNode(id)
+		if node == nil || !node.IsWaste {
+			t.Errorf("Expected %s to be flagged", id)
+			continue
+		}
+		if stack := node.Properties["CIStack"]; stack != "legacy-app" {
+			t.Errorf("Expected %s grouped under legacy-app, got %v", id, stack)
+		}
+	}
+	if g.GetNode(active).IsWaste {
+		t.Error("Active project should not be flagged")
+	}
+	if g.GetNode(unread).IsWaste {
+		t.Error("Project with unreadable build history should not be flagged")
+	}
+	if g.GetNode(sharedBucket).IsWaste {
+		t.Error("Bucket shared with an active project should not be flagged")
+	}
+}
+
+func TestEFSLifecycleHeuristic(t *testing.T) {
+	g := graph.NewGraph()
+	const gib = int64(1 << 30)
+
+	g.AddNode("fs-hot", "AWS::EFS::FileSystem", map[string]interface{}{
+		"NumberOfMountTargets": int32(2),
+		"SizeStandardBytes":    100 * gib,
+		"TransitionToIA":       "",
+	})
+	g.AddNode("fs-tiered", "AWS::EFS::FileSystem", map[string]interface{}{
+		"NumberOfMountTargets": int32(2),
+		"SizeStandardBytes":    100 * gib,
+		"TransitionToIA":       "AFTER_30_DAYS",
+	})
+	g.AddNode("fs-unmounted", "AWS::EFS::FileSystem", map[string]interface{}{
+		"NumberOfMountTargets": int32(0),
+		"SizeStandardBytes":    100 * gib,
+		"TransitionToIA":       "",
+	})
+	g.CloseAndWait()
+
+	h := &EFSLifecycleHeuristic{}
+	stats, err := h.Run(context.Background(), g)
+	if err != nil {
+		t.Fatalf("Heuristic run failed: %v", err)
+	}
+	if stats.ItemsFound != 1 {
+		t.Fatalf("Expected 1 finding, got %d", stats.ItemsFound)
+	}
+
+	node := g.GetNode("fs-hot")
+	if !node.IsWaste || node.Properties["LifecycleRecommendation"] != true {
+		t.Error("Expected fs-hot to get a lifecycle recommendation")
+	}
+	// 100 GB * 80% cold * ($0.30 - $0.016)
+	if want := 100 * 0.8 * (0.30 - 0.016); node.Cost < want-0.01 || node.Cost > want+0.01 {
+		t.Errorf("Expected savings ~%.2f, got %.2f", want, node.Cost)
+	}
+	if g.GetNode("fs-tiered").IsWaste || g.GetNode("fs-unmounted").IsWaste {
+		t.Error("Tiered and unmounted file systems should not be flagged")
+	}
+}
+
+func TestApplyPolicyBlocks(t *testing.T) {
+	g := graph.NewGraph()
+
+	blockedID := "arn:aws:ec2:us-east-1:123:volume/vol-locked"
+	freeID := "arn:aws:ec2:us-east-1:123:volume/vol-free"
+	g.AddNode(blockedID, "AWS::EC2::Volume", map[string]interface{}{})
+	g.AddNode(freeID, "AWS::EC2::Volume", map[string]interface{}{})
+	g.CloseAndWait()
+
+	g.MarkWaste(blockedID, 80)
+	g.MarkWaste(freeID, 80)
+	g.GetNode(blockedID).Properties["Reason"] = "Unattached volume"
+
+	if n := applyPolicyBlocks(g, map[string]string{blockedID: "SCP"}); n != 1 {
+		t.Fatalf("Expected 1 annotated finding, got %d", n)
+	}
+
+	g.Mu.RLock()
+	defer g.Mu.RUnlock()
+
+	blocked := g.GetNode(blockedID)
+	if blocked.Properties["RemediationBlocked"] != "SCP" {
+		t.Errorf("Expected RemediationBlocked=SCP, got %v", blocked.Properties["RemediationBlocked"])
+	}
+	if reason, _ := blocked.Properties["Reason"].(string); !strings.Contains(reason, "cannot remediate: blocked by policy (SCP)") {
+		t.Errorf("Unexpected reason: %q", reason)
+	}
+	if _, ok := g.GetNode(freeID).Properties["RemediationBlocked"]; ok {
+		t.Error("Unblocked finding should not be annotated")
+	}
+}
+
+func TestPolicyTargets(t *testing.T) {
+	g := graph.NewGraph()
+
+	placeholder := "arn:aws:ec2:region:account:volume/vol-1"
+	unknown := "arn:aws:ec2:region:account:volume/vol-2"
+	bucket := "arn:aws:s3:::logs"
+	g.AddNode(placeholder, "AWS::EC2::Volume", map[string]interface{}{"AccountId": "123456789012", "Region": "eu-west-1"})
+	g.AddNode(unknown, "AWS::EC2::Volume", map[string]interface{}{})
+	g.AddNode(bucket, "AWS::S3::Bucket", map[string]interface{}{"AccountId": "123456789012"})
+	g.CloseAndWait()
+	for _, id := range []string{placeholder, unknown, bucket} {
+		g.MarkWaste(id, 80)
+	}
+
+	got := policyTargets(g, "us-east-1")
+	want := map[string]map[string]string{
+		"ec2:DeleteVolume": {"arn:aws:ec2:eu-west-1:123456789012:volume/vol-1": placeholder},
+		"s3:DeleteBucket":  {bucket: bucket},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("policyTargets() = %v, want %v", got, want)
+	}
+}
+
+func TestNATInstanceHeuristic(t *testing.T) {
+	g := graph.NewGraph()
+
+	g.AddNode("arn:aws:ec2:region:account:vpc/vpc-dev", "AWS::EC2::VPC", map[string]interface{}{
+		"VpcId": "vpc-dev", "Tags": map[string]string{"Environment": "dev"},
+	})
+	g.AddNode("arn:aws:ec2:region:account:vpc/vpc-prod", "AWS::EC2::VPC", map[string]interface{}{
+		"VpcId": "vpc-prod", "Tags": map[string]string{"Environment": "production"},
+	})
+	g.AddNode("arn:aws:ec2:region:account:vpc/vpc-none", "AWS::EC2::VPC", map[string]interface{}{
+		"VpcId": "vpc-none", "Tags": map[string]string{},
+	})
+
+	// Busy dev gateway: recommended on the tag alone.
+	g.AddNode("nat-dev", "aws_nat_gateway", map[string]interface{}{"VpcId": "vpc-dev", "SumBytesOut7d": 500e9})
+	// Quiet production gateway: never recommended.
+	g.AddNode("nat-prod", "aws_nat_gateway", map[string]interface{}{"VpcId": "vpc-prod", "SumBytesOut7d": 1e9})
+	// Untagged: recommended only below the traffic threshold.
+	g.AddNode("nat-quiet", "aws_nat_gateway", map[string]interface{}{"VpcId": "vpc-none", "SumBytesOut7d": 5e9})
+	g.AddNode("nat-busy", "aws_nat_gateway", map[string]interface{}{"VpcId": "vpc-none", "SumBytesOut7d": 500e9})
+	g.CloseAndWait()
+
+	h := &NATInstanceHeuristic{}
+	stats, err := h.Run(context.Background(), g)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if stats.ItemsFound != 2 {
+		t.Errorf("Expected 2 recommendations, got %d", stats.ItemsFound)
+	}
+
+	dev := g.GetNode("nat-dev")
+	if !dev.IsWaste || dev.Properties["NATInstanceRecommendation"] != "t4g.nano" {
+		t.Error("Expected dev NAT Gateway to get a NAT instance recommendation")
+	}
+	if dev.RiskScore >= 50 {
+		t.Errorf("Recommendation should stay below the REVIEW threshold, got %d", dev.RiskScore)
+	}
+	if reason, _ := dev.Properties["Reason"].(string); !strings.Contains(reason, "not highly available") {
+		t.Errorf("Expected availability caveat, got %q", reason)
+	}
+	if !g.GetNode("nat-quiet").IsWaste {
+		t.Error("Expected low-traffic untagged NAT Gateway to be recommended")
+	}
+	if g.GetNode("nat-prod").IsWaste {
+		t.Error("Production NAT Gateway must never be recommended")
+	}
+	if g.GetNode("nat-busy").IsWaste {
+		t.Error("High-traffic untagged NAT Gateway should not be recommended")
+	}
+}
+
+func TestNetworkForensicsEIPDNSRisk(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("eipalloc-dns", "aws_eip", map[string]interface{}{
+		"FoundInDNS": true, "DNSZone": "example.com.", "DNSRecord": "api.example.com.",
+	})
+	g.AddNode("eipalloc-safe", "aws_eip", map[string]interface{}{"FoundInDNS": false})
+	g.AddNode("eipalloc-unknown", "aws_eip", map[string]interface{}{"DNSCheckError": "AccessDenied"})
+
+	// Two addresses on one instance; DNS points only at the second.
+	g.AddNode("eipalloc-a", "aws_eip", map[string]interface{}{
+		"AssociationId": "eipassoc-a", "InstanceId": "i-1", "FoundInDNS": false,
+	})
+	g.AddNode("eipalloc-b", "aws_eip", map[string]interface{}{
+		"AssociationId": "eipassoc-b", "InstanceId": "i-1", "FoundInDNS": true,
+	})
+	g.CloseAndWait()
+
+	h := &NetworkForensicsHeuristic{}
+	h.Analyze(g)
+
+	dns := g.GetNode("eipalloc-dns")
+	if dns.RiskScore >= 50 || dns.Properties["DNSRisk"] != "SubdomainTakeover" {
+		t.Errorf("DNS-referenced EIP should be low confidence with takeover risk, got score %d", dns.RiskScore)
+	}
+	if reason, _ := dns.Properties["Reason"].(string); !strings.Contains(reason, "api.example.com.") {
+		t.Errorf("Expected record name in reason, got %q", reason)
+	}
+	if safe := g.GetNode("eipalloc-safe"); safe.RiskScore < 50 {
+		t.Errorf("Verified-safe EIP should be releasable, got score %d", safe.RiskScore)
+	}
+	if unknown := g.GetNode("eipalloc-unknown"); unknown.RiskScore >= 50 {
+		t.Errorf("Unverified EIP should require review, got score %d", unknown.RiskScore)
+	}
+
+	if !g.GetNode("eipalloc-a").IsWaste {
+		t.Error("Expected spare EIP without DNS to be flagged as duplicate")
+	}
+	if g.GetNode("eipalloc-b").IsWaste {
+		t.Error("EIP referenced in DNS must be kept")
+	}
+}
+
+func TestApplyCrossAZTransfer(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("i-a", "AWS::EC2::Instance", map[string]interface{}{"AvailabilityZone": "us-east-1a", "PrivateIPs": []string{"10.0.1.10"}})
+	g.AddNode("i-b", "AWS::EC2::Instance", map[string]interface{}{"AvailabilityZone": "us-east-1b", "PrivateIPs": []string{"10.0.2.10"}})
+	g.AddNode("i-c", "AWS::EC2::Instance", map[string]interface{}{"AvailabilityZone": "us-east-1a", "PrivateIPs": []string{"10.0.1.20"}})
+	g.CloseAndWait()
+
+	week := 7 * 24 * time.Hour
+	flows := []internalaws.FlowTotal{
+		// 1 TB/week across AZs, both directions: ~8.6 TB/mo, ~$171/mo.
+		{SrcAddr: "10.0.1.10", DstAddr: "10.0.2.10", Bytes: 1e12},
+		{SrcAddr: "10.0.2.10", DstAddr: "10.0.1.10", Bytes: 1e12},
+		// Same AZ: free.
+		{SrcAddr: "10.0.1.10", DstAddr: "10.0.1.20", Bytes: 5e12},
+		// Outside the graph.
+		{SrcAddr: "10.0.9.9", DstAddr: "10.0.2.10", Bytes: 5e12},
+	}
+
+	stats := applyCrossAZTransfer(g, flows, week, "", "us-east-1")
+	if stats.ItemsFound != 1 {
+		t.Fatalf("Expected 1 hotspot, got %d", stats.ItemsFound)
+	}
+
+	a := g.GetNode("i-a")
+	if a.Cost < 170 || a.Cost > 172 {
+		t.Errorf("Expected i-a flagged at ~$171/mo, got cost=%.2f", a.Cost)
+	}
+	if a.IsWaste {
+		t.Error("A busy instance is a placement recommendation, not waste")
+	}
+	if peers, _ := a.Properties["CrossAZPeers"].([]string); len(peers) != 1 || peers[0] != "i-b" {
+		t.Errorf("Expected peer i-b, got %v", a.Properties["CrossAZPeers"])
+	}
+	for _, id := range []string{"i-b", "i-c"} {
+		if _, ok := g.GetNode(id).Properties["CrossAZPeers"]; ok {
+			t.Errorf("Pair cost should be attributed to one instance only, got a hotspot on %s", id)
+		}
+	}
+
+	// Flows from another region's log do not match these instances.
+	if stats := applyCrossAZTransfer(g, flows, week, "", "eu-west-1"); stats.ItemsFound != 0 {
+		t.Errorf("Expected no hotspots for another region, got %d", stats.ItemsFound)
+	}
+}
+
+func TestApplyDeprecations(t *testing.T) {
+	dir := t.TempDir()
+	override := dir + "/deprecations.yaml"
+	os.WriteFile(override, []byte(`deprecations:
+  - id: ebs-magnetic
+    service: Magnetic EBS
+    resource_type: AWS::EC2::Volume
+    property: VolumeType
+    values: ["standard", "sc1"]
+    migration: Move to gp3.
+`), 0644)
+
+	rules, err := LoadDeprecationRules(override)
+	if err != nil {
+		t.Fatalf("LoadDeprecationRules failed: %v", err)
+	}
+
+	g := graph.NewGraph()
+	g.AddNode("i-old", "AWS::EC2::Instance", map[string]interface{}{"Type": "m3.large"})
+	g.AddNode("i-new", "AWS::EC2::Instance", map[string]interface{}{"Type": "m7g.large"})
+	g.AddNode("i-ei", "AWS::EC2::Instance", map[string]interface{}{"Type": "c5.large", "ElasticInferenceAccelerators": 1})
+	g.AddNode("vol-cold", "AWS::EC2::Volume", map[string]interface{}{"VolumeType": "sc1"})
+	g.AddNode("db-mysql", "AWS::RDS::DBInstance", map[string]interface{}{"Engine": "mysql", "EngineVersion": "5.7.44"})
+	g.AddNode("db-aurora", "AWS::RDS::DBInstance", map[string]interface{}{"Engine": "aurora-mysql", "EngineVersion": "5.7.mysql_aurora.2.11.2"})
+	g.AddNode("fn", "aws_lambda_function", map[string]interface{}{"Runtime": "python3.7"})
+	g.CloseAndWait()
+
+	stats := applyDeprecations(g, rules, func(n *graph.Node) float64 { return 42 })
+	if stats.ItemsFound != 5 {
+		t.Errorf("Expected 5 deprecated resources, got %d", stats.ItemsFound)
+	}
+
+	for _, id := range []string{"i-old", "i-ei", "vol-cold", "db-mysql", "fn"} {
+		node := g.GetNode(id)
+		if _, ok := node.Properties["DeprecationRisk"]; !ok {
+			t.Errorf("Expected %s flagged as deprecated", id)
+		}
+		if node.IsWaste {
+			t.Errorf("Deprecation must not mark %s as waste", id)
+		}
+		if cost, _ := node.Properties["DeprecationCost"].(float64); cost != 42 {
+			t.Errorf("Expected cost at stake 42 for %s, got %v", id, node.Properties["DeprecationCost"])
+		}
+	}
+	for _, id := range []string{"i-new", "db-aurora"} {
+		if _, ok := g.GetNode(id).Properties["DeprecationRisk"]; ok {
+			t.Errorf("Did not expect %s flagged", id)
+		}
+	}
+	if got := g.GetNode("vol-cold").Properties["DeprecationRisk"]; got != "Magnetic EBS" {
+		t.Errorf("Expected override to replace built-in entry, got %v", got)
+	}
+	if got := g.GetNode("db-mysql").Properties["DeprecationDeadline"]; got != "2024-02-29" {
+		t.Errorf("Expected MySQL 5.7 deadline, got %v", got)
+	}
+}
+
+func TestApplyIdleMLEndpoints(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("ep-idle", "AWS::SageMaker::Endpoint", map[string]interface{}{
+		"MLEndpoint": true, "Service": "SageMaker", "Name": "churn", "Units": 2, "UnitType": "ml.m5.large", "HourlyCost": 0.23, "Region": "eu-west-1",
+	})
+	g.AddNode("ep-busy", "AWS::Comprehend::Endpoint", map[string]interface{}{
+		"MLEndpoint": true, "Service": "Comprehend", "Name": "router", "Units": 1, "UnitType": "inference unit", "HourlyCost": 1.80,
+	})
+	g.AddNode("ep-unknown", "AWS::Rekognition::ProjectVersion", map[string]interface{}{
+		"MLEndpoint": true, "Service": "Rekognition", "Units": 1, "HourlyCost": 4.0,
+	})
+	g.CloseAndWait()
+
+	// ep-unknown has no usage entry (metric read failed) and must not be judged.
+	stats := applyIdleMLEndpoints(g, map[string]float64{"ep-idle": 0, "ep-busy": 1200}, mlIdleWindow)
+	if stats.ItemsFound != 1 {
+		t.Fatalf("Expected 1 idle endpoint, got %d", stats.ItemsFound)
+	}
+
+	idle := g.GetNode("ep-idle")
+	if !idle.IsWaste || idle.RiskScore >= 50 {
+		t.Errorf("Expected idle endpoint flagged for review, got waste=%v risk=%d", idle.IsWaste, idle.RiskScore)
+	}
+	if idle.Cost < 167.8 || idle.Cost > 168 {
+		t.Errorf("Expected $0.23/hr * 730 = $167.90/mo, got %.2f", idle.Cost)
+	}
+	if reason, _ := idle.Properties["Reason"].(string); !strings.Contains(reason, "$0.23/hr (us-east-1 estimate)") {
+		t.Errorf("Expected hourly cost labelled as an estimate in reason, got %q", reason)
+	}
+	if estimated, _ := idle.Properties["CostEstimated"].(bool); !estimated {
+		t.Error("Expected a cost outside us-east-1 to be marked as estimated")
+	}
+	if g.GetNode("ep-busy").IsWaste || g.GetNode("ep-unknown").IsWaste {
+		t.Error("Busy or unmeasured endpoints must not be flagged")
+	}
+}
+
+func TestIdleDMSHeuristic(t *testing.T) {
+	old := time.Now().Add(-30 * 24 * time.Hour)
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:dms:us-east-1:123:rep:EMPTY", "AWS::DMS::ReplicationInstance", map[string]interface{}{
+		"Identifier": "leftover", "InstanceClass": "dms.t3.medium", "MultiAZ": true, "Status": "available",
+		"ActiveTasks": 0, "CreateTime": old,
+	})
+	g.AddNode("arn:aws:dms:us-east-1:123:rep:RUNNING", "AWS::DMS::ReplicationInstance", map[string]interface{}{
+		"Identifier": "cdc", "InstanceClass": "dms.r5.large", "Status": "available",
+		"ActiveTasks": 1, "ReplicationTaskIDs": []string{"TASK1"}, "CreateTime": old,
+	})
+	g.AddNode("arn:aws:dms:us-east-1:123:rep:STOPPED", "AWS::DMS::ReplicationInstance", map[string]interface{}{
+		"Identifier": "paused", "InstanceClass": "dms.c5.large", "Status": "available",
+		"ActiveTasks": 0, "ReplicationTaskIDs": []string{"TASK2"}, "CreateTime": old,
+	})
+	g.AddNode("arn:aws:dms:us-east-1:123:rep:NEW", "AWS::DMS::ReplicationInstance", map[string]interface{}{
+		"Identifier": "fresh", "InstanceClass": "dms.t3.micro", "Status": "available",
+		"ActiveTasks": 0, "CreateTime": time.Now().Add(-2 * 24 * time.Hour),
+	})
+	g.CloseAndWait()
+
+	// Without CloudWatch, stopped tasks cannot be proven idle and are left alone.
+	h := &IdleDMSHeuristic{}
+	stats, err := h.Run(context.Background(), g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.ItemsFound != 1 {
+		t.Fatalf("Expected 1 idle replication instance, got %d", stats.ItemsFound)
+	}
+
+	empty := g.GetNode("arn:aws:dms:us-east-1:123:rep:EMPTY")
+	if !empty.IsWaste {
+		t.Fatal("Expected task-less instance to be flagged")
+	}
+	// dms.t3.medium is $0.073/hr, doubled for Multi-AZ.
+	if empty.Cost < 106 || empty.Cost > 107 {
+		t.Errorf("Expected ~$106.58/mo, got %.2f", empty.Cost)
+	}
+	for _, id := range []string{"RUNNING", "STOPPED", "NEW"} {
+		if g.GetNode("arn:aws:dms:us-east-1:123:rep:" + id).IsWaste {
+			t.Errorf("Expected %s not to be flagged", id)
+		}
+	}
+
+	// A stopped instance whose tasks moved no data is flagged with its task count.
+	stats = applyIdleDMS(g, map[string]float64{"arn:aws:dms:us-east-1:123:rep:STOPPED": 112.42}, dmsWindow)
+	if stats.ItemsFound != 1 {
+		t.Fatalf("Expected stopped instance flagged, got %d", stats.ItemsFound)
+	}
+	if reason, _ := g.GetNode("arn:aws:dms:us-east-1:123:rep:STOPPED").Properties["Reason"].(string); !strings.Contains(reason, "moved no data") {
+		t.Errorf("Unexpected reason %q", reason)
+	}
+}
+
+func TestIdleElastiCacheHeuristic(t *testing.T) {
+	old := time.Now().Add(-90 * 24 * time.Hour)
+	cluster := func(nodeType string, nodes int, extra map[string]interface{}) map[string]interface{} {
+		props := map[string]interface{}{
+			"Engine": "redis", "Status": "available", "NodeType": nodeType, "NumCacheNodes": nodes, "CreateTime": old,
+		}
+		for k, v := range extra {
+			props[k] = v
+		}
+		return props
+	}
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:elasticache:us-east-1:123:cluster:idle", "aws_elasticache_cluster", cluster("cache.r6g.large", 2, map[string]interface{}{
+		"CacheClusterId": "idle", "PeakConnections": 3.0, "CacheHits": 0.0, "PeakCPU": 1.0,
+	}))
+	g.AddNode("arn:aws:elasticache:us-east-1:123:cluster:busy", "aws_elasticache_cluster", cluster("cache.r6g.large", 1, map[string]interface{}{
+		"CacheClusterId": "busy", "PeakConnections": 120.0, "CacheHits": 5e6, "PeakCPU": 45.0, "PeakMemoryPercent": 70.0,
+	}))
+	g.AddNode("arn:aws:elasticache:us-east-1:123:cluster:reserved", "aws_elasticache_cluster", cluster("cache.r6g.2xlarge", 1, map[string]interface{}{
+		"CacheClusterId": "reserved", "PeakConnections": 40.0, "CacheHits": 1e6, "PeakCPU": 4.0, "PeakMemoryPercent": 20.0,
+		"ReservedNodes": 1, "ReservationExpiry": time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC),
+	}))
+	// Quiet but on demand: a downsize is a plain right-sizing call, not a reservation one.
+	g.AddNode("arn:aws:elasticache:us-east-1:123:cluster:ondemand", "aws_elasticache_cluster", cluster("cache.r6g.2xlarge", 1, map[string]interface{}{
+		"CacheClusterId": "ondemand", "PeakConnections": 40.0, "CacheHits": 1e6, "PeakCPU": 4.0, "PeakMemoryPercent": 20.0,
+	}))
+	g.AddNode("arn:aws:elasticache:us-east-1:123:cluster:new", "aws_elasticache_cluster", map[string]interface{}{
+		"Engine": "redis", "Status": "available", "NodeType": "cache.t3.micro", "NumCacheNodes": 1,
+		"CreateTime": time.Now().Add(-24 * time.Hour), "PeakConnections": 0.0, "CacheHits": 0.0,
+	})
+	g.AddNode("arn:aws:elasticache:us-east-1:123:cluster:unmeasured", "aws_elasticache_cluster", cluster("cache.t3.micro", 1, nil))
+	g.CloseAndWait()
+
+	stats, err := (&IdleElastiCacheHeuristic{}).Run(context.Background(), g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.ItemsFound != 2 {
+		t.Fatalf("Expected 2 findings, got %d", stats.ItemsFound)
+	}
+
+	idle := g.GetNode("arn:aws:elasticache:us-east-1:123:cluster:idle")
+	// cache.r6g.large is $0.206/hr per node.
+	if !idle.IsWaste || idle.RiskScore != 75 || idle.Cost < 300 || idle.Cost > 301 {
+		t.Errorf("idle cluster: waste=%v risk=%d cost=%.2f, want risk 75 at ~$300.76/mo", idle.IsWaste, idle.RiskScore, idle.Cost)
+	}
+	if reason, _ := idle.Properties["Reason"].(string); !strings.Contains(reason, "7 days") {
+		t.Errorf("Unexpected reason %q", reason)
+	}
+
+	reserved := g.GetNode("arn:aws:elasticache:us-east-1:123:cluster:reserved")
+	if !reserved.IsWaste || reserved.RiskScore > 50 || reserved.Properties["RecommendedNodeType"] != "cache.r6g.xlarge" {
+		t.Errorf("reserved cluster: waste=%v risk=%d recommended=%v", reserved.IsWaste, reserved.RiskScore, reserved.Properties["RecommendedNodeType"])
+	}
+	// Saving is the price gap between 2xlarge and xlarge.
+	if reserved.Cost < 299 || reserved.Cost > 301 {
+		t.Errorf("Expected ~$300/mo downsizing saving, got %.2f", reserved.Cost)
+	}
+	if reason, _ := reserved.Properties["Reason"].(string); !strings.Contains(reason, "2027-03-01") {
+		t.Errorf("Expected the reservation expiry in %q", reason)
+	}
+
+	for _, id := range []string{"busy", "ondemand", "new", "unmeasured"} {
+		if g.GetNode("arn:aws:elasticache:us-east-1:123:cluster:" + id).IsWaste {
+			t.Errorf("Expected %s not to be flagged", id)
+		}
+	}
+
+	// The configured window is quoted in the reason.
+	applyIdleElastiCache(g, map[string]elastiCacheFinding{
+		"arn:aws:elasticache:us-east-1:123:cluster:busy": {Idle: true, Cost: 150.38},
+	}, 30*24*time.Hour)
+	if reason, _ := g.GetNode("arn:aws:elasticache:us-east-1:123:cluster:busy").Properties["Reason"].(string); !strings.Contains(reason, "30 days") {
+		t.Errorf("Expected the 30-day window in %q", reason)
+	}
+}
+
+func TestSmallerElastiCacheNodeType(t *testing.T) {
+	cases := map[string]string{
+		"cache.r6g.xlarge":  "cache.r6g.large",
+		"cache.m5.12xlarge": "cache.m5.4xlarge", // m5 has no 8xlarge.
+		"cache.t3.small":    "cache.t3.micro",
+		"cache.t3.micro":    "",
+		"cache.r6g.large":   "",
+		"bogus":             "",
+	}
+	for in, want := range cases {
+		got, ok := pricing.SmallerElastiCacheNodeType(in)
+		if got != want || ok != (want != "") {
+			t.Errorf("SmallerElastiCacheNodeType(%q) = %q, %v; want %q", in, got, ok, want)
+		}
+	}
+}
+
+func TestIdleWAFHeuristic(t *testing.T) {
+	acl := func(name string, rules, associated int, extra map[string]interface{}) map[string]interface{} {
+		props := map[string]interface{}{
+			"Name": name, "Scope": "REGIONAL", "RuleCount": rules, "AssociatedResources": associated,
+			"AssociationsComplete": true, "Region": "us-east-1",
+		}
+		for k, v := range extra {
+			props[k] = v
+		}
+		return props
+	}
+	arn := func(name string) string { return "arn:aws:wafv2:us-east-1:123:regional/webacl/" + name + "/id" }
+
+	g := graph.NewGraph()
+	g.AddNode(arn("stale"), "AWS::WAFv2::WebACL", acl("stale", 4, 0, nil))
+	g.AddNode(arn("quiet"), "AWS::WAFv2::WebACL", acl("quiet", 2, 1, map[string]interface{}{"InspectedRequests": 0.0}))
+	g.AddNode(arn("busy"), "AWS::WAFv2::WebACL", acl("busy", 6, 1, map[string]interface{}{"InspectedRequests": 2e6}))
+	g.AddNode(arn("unmeasured"), "AWS::WAFv2::WebACL", acl("unmeasured", 2, 1, nil))
+	// A failed association lookup is not evidence of an unused ACL.
+	g.AddNode(arn("unknown"), "AWS::WAFv2::WebACL", acl("unknown", 2, 0, map[string]interface{}{"AssociationsComplete": false}))
+	g.AddNode(arn("fms"), "AWS::WAFv2::WebACL", acl("fms", 2, 0, map[string]interface{}{"ManagedByFirewallManager": true}))
+	g.CloseAndWait()
+
+	stats, err := (&IdleWAFHeuristic{}).Run(context.Background(), g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.ItemsFound != 2 {
+		t.Fatalf("Expected 2 findings, got %d", stats.ItemsFound)
+	}
+
+	// $5 for the ACL plus $1 per rule.
+	stale := g.GetNode(arn("stale"))
+	if !stale.IsWaste || stale.RiskScore != 75 || stale.Cost != 9 {
+		t.Errorf("stale ACL: waste=%v risk=%d cost=%.2f, want risk 75 at $9/mo", stale.IsWaste, stale.RiskScore, stale.Cost)
+	}
+	quiet := g.GetNode(arn("quiet"))
+	if !quiet.IsWaste || quiet.RiskScore > 50 || quiet.Cost != 7 {
+		t.Errorf("quiet ACL: waste=%v risk=%d cost=%.2f, want review at $7/mo", quiet.IsWaste, quiet.RiskScore, quiet.Cost)
+	}
+	if reason, _ := quiet.Properties["Reason"].(string); !strings.Contains(reason, "14 days") {
+		t.Errorf("Unexpected reason %q", reason)
+	}
+
+	for _, name := range []string{"busy", "unmeasured", "unknown", "fms"} {
+		if g.GetNode(arn(name)).IsWaste {
+			t.Errorf("Expected %s not to be flagged", name)
+		}
+	}
+}
+
+func TestDynamoDBHeuristic(t *testing.T) {
+	window := 30 * 24 * time.Hour
+	perSecond := window.Seconds()
+	table := func(name, mode string, rcu, wcu, reads, writes float64, extra map[string]interface{}) map[string]interface{} {
+		props := map[string]interface{}{
+			"TableName": name, "TableStatus": "ACTIVE", "BillingMode": mode,
+			"ProvisionedRCU": rcu, "ProvisionedWCU": wcu, "ItemCount": int64(0), "TableSizeBytes": int64(0),
+			"ConsumedReadUnits": reads, "ConsumedWriteUnits": writes,
+			"CreationTime": time.Now().Add(-365 * 24 * time.Hour), "Region": "us-east-1",
+		}
+		for k, v := range extra {
+			props[k] = v
+		}
+		return props
+	}
+	arn := func(name string) string { return "arn:aws:dynamodb:us-east-1:123:table/" + name }
+
+	g := graph.NewGraph()
+	g.AddNode(arn("dead"), "aws_dynamodb_table", table("dead", "PROVISIONED", 100, 50, 0, 0, nil))
+	g.AddNode(arn("archive"), "aws_dynamodb_table", table("archive", "PAY_PER_REQUEST", 0, 0, 0, 0,
+		map[string]interface{}{"ItemCount": int64(5000), "TableSizeBytes": int64(10 << 30)}))
+	g.AddNode(arn("sparse"), "aws_dynamodb_table", table("sparse", "PROVISIONED", 1000, 1000, 1000, 1000, nil))
+	g.AddNode(arn("scaled"), "aws_dynamodb_table", table("scaled", "PROVISIONED", 100, 100, 5*perSecond, 5*perSecond,
+		map[string]interface{}{"HasAutoScaling": true}))
+	g.AddNode(arn("busy"), "aws_dynamodb_table", table("busy", "PROVISIONED", 100, 100, 50*perSecond, 50*perSecond, nil))
+	g.AddNode(arn("free-tier"), "aws_dynamodb_table", table("free-tier", "PROVISIONED", 20, 20, 100, 100, nil))
+	// An empty on-demand table costs nothing.
+	g.AddNode(arn("empty-on-demand"), "aws_dynamodb_table", table("empty-on-demand", "PAY_PER_REQUEST", 0, 0, 0, 0, nil))
+	g.AddNode(arn("new"), "aws_dynamodb_table", table("new", "PROVISIONED", 100, 50, 0, 0,
+		map[string]interface{}{"CreationTime": time.Now().Add(-24 * time.Hour)}))
+	g.AddNode(arn("global"), "aws_dynamodb_table", table("global", "PROVISIONED", 100, 50, 0, 0,
+		map[string]interface{}{"Replicas": 2}))
+	g.CloseAndWait()
+
+	stats, err := (&DynamoDBHeuristic{Window: window}).Run(context.Background(), g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.ItemsFound != 4 {
+		t.Fatalf("Expected 4 findings, got %d", stats.ItemsFound)
+	}
+
+	dead := g.GetNode(arn("dead"))
+	if want := pricing.EstimateDynamoDBProvisionedPrice(100, 50, 0); !dead.IsWaste || dead.RiskScore != 75 || dead.Cost != want {
+		t.Errorf("dead table: waste=%v risk=%d cost=%.2f, want risk 75 at $%.2f/mo", dead.IsWaste, dead.RiskScore, dead.Cost, want)
+	}
+	// A table holding data is only reported for review.
+	archive := g.GetNode(arn("archive"))
+	if !archive.IsWaste || archive.RiskScore > 50 || archive.Cost != 2.5 {
+		t.Errorf("archive table: waste=%v risk=%d cost=%.2f, want review at $2.50/mo", archive.IsWaste, archive.RiskScore, archive.Cost)
+	}
+
+	sparse := g.GetNode(arn("sparse"))
+	if mode, _ := sparse.Properties["RecommendedBillingMode"].(string); !sparse.IsWaste || mode != "PAY_PER_REQUEST" {
+		t.Errorf("sparse table: waste=%v mode=%q, want on-demand", sparse.IsWaste, mode)
+	}
+	if sparse.Cost < 500 {
+		t.Errorf("sparse table: saving %.2f, want nearly all of the provisioned capacity", sparse.Cost)
+	}
+
+	// Auto scaling stays; its minimum comes down so average use sits near 70%.
+	scaled := g.GetNode(arn("scaled"))
+	rcu, _ := scaled.Properties["RecommendedRCU"].(float64)
+	if !scaled.IsWaste || scaled.Properties["RecommendedBillingMode"] != "PROVISIONED" || rcu != 8 {
+		t.Errorf("scaled table: waste=%v mode=%v rcu=%.0f, want 8 provisioned RCU", scaled.IsWaste, scaled.Properties["RecommendedBillingMode"], rcu)
+	}
+	if reason, _ := scaled.Properties["Reason"].(string); !strings.Contains(reason, "auto scaling minimum") || !strings.Contains(reason, "30 days") {
+		t.Errorf("Unexpected reason %q", reason)
+	}
+
+	for _, name := range []string{"busy", "free-tier", "empty-on-demand", "new", "global"} {
+		if g.GetNode(arn(name)).IsWaste {
+			t.Errorf("Expected %s not to be flagged", name)
+		}
+	}
+}
+
+func TestRedshiftHeuristic(t *testing.T) {
+	old := time.Now().Add(-90 * 24 * time.Hour)
+	cluster := func(nodeType string, nodes int, extra map[string]interface{}) map[string]interface{} {
+		props := map[string]interface{}{
+			"ClusterStatus": "available", "NodeType": nodeType, "NumberOfNodes": nodes, "CreateTime": old,
+		}
+		for k, v := range extra {
+			props[k] = v
+		}
+		return props
+	}
+	busy := map[string]interface{}{"PeakConnections": 40.0, "QueriesCompleted": 1.2e5}
+	withCPU := func(cpu, disk float64) map[string]interface{} {
+		props := map[string]interface{}{"AvgCPU": cpu, "PeakDiskPercent": disk}
+		for k, v := range busy {
+			props[k] = v
+		}
+		return props
+	}
+
+	g := graph.NewGraph()
+	g.AddNode("idle", "aws_redshift_cluster", cluster("dc2.large", 2, map[string]interface{}{
+		"PeakConnections": 0.0, "QueriesCompleted": 0.0, "AvgCPU": 0.5,
+	}))
+	g.AddNode("oversized", "aws_redshift_cluster", cluster("ra3.4xlarge", 6, withCPU(4.0, 80.0)))
+	// Data lives on DC2 nodes; half the cluster could not hold it.
+	g.AddNode("full-disk", "aws_redshift_cluster", cluster("dc2.large", 4, withCPU(3.0, 60.0)))
+	// RA3.4xlarge clusters need at least two nodes.
+	g.AddNode("minimum", "aws_redshift_cluster", cluster("ra3.4xlarge", 2, withCPU(2.0, 10.0)))
+	g.AddNode("busy", "aws_redshift_cluster", cluster("ra3.xlplus", 4, withCPU(55.0, 30.0)))
+	g.AddNode("paused", "aws_redshift_cluster", cluster("dc2.large", 2, map[string]interface{}{
+		"ClusterStatus": "paused", "PeakConnections": 0.0, "QueriesCompleted": 0.0,
+	}))
+	g.AddNode("new", "aws_redshift_cluster", map[string]interface{}{
+		"ClusterStatus": "available", "NodeType": "dc2.large", "NumberOfNodes": 1,
+		"CreateTime": time.Now().Add(-24 * time.Hour), "PeakConnections": 0.0, "QueriesCompleted": 0.0,
+	})
+	g.AddNode("unmeasured", "aws_redshift_cluster", cluster("dc2.large", 1, nil))
+	g.CloseAndWait()
+
+	stats, err := (&RedshiftHeuristic{}).Run(context.Background(), g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.ItemsFound != 2 {
+		t.Fatalf("Expected 2 findings, got %d", stats.ItemsFound)
+	}
+
+	idle := g.GetNode("idle")
+	// dc2.large is $0.25/hr per node.
+	if !idle.IsWaste || idle.RiskScore != 70 || idle.Cost != 365 || idle.Properties["RedshiftAction"] != "pause" {
+		t.Errorf("idle cluster: waste=%v risk=%d cost=%.2f action=%v, want a $365/mo pause at risk 70",
+			idle.IsWaste, idle.RiskScore, idle.Cost, idle.Properties["RedshiftAction"])
+	}
+	if reason, _ := idle.Properties["Reason"].(string); !strings.Contains(reason, "7 days") || !strings.Contains(reason, "Pause") {
+		t.Errorf("Unexpected reason %q", reason)
+	}
+
+	oversized := g.GetNode("oversized")
+	if !oversized.IsWaste || oversized.RiskScore > 50 || oversized.Properties["RecommendedNodes"] != 3 {
+		t.Errorf("oversized cluster: waste=%v risk=%d nodes=%v", oversized.IsWaste, oversized.RiskScore, oversized.Properties["RecommendedNodes"])
+	}
+	// Three ra3.4xlarge nodes at $3.26/hr; managed storage does not limit the resize.
+	if oversized.Cost < 7139 || oversized.Cost > 7140 {
+		t.Errorf("Expected ~$7139.40/mo resize saving, got %.2f", oversized.Cost)
+	}
+
+	for _, id := range []string{"full-disk", "minimum", "busy", "paused", "new", "unmeasured"} {
+		if g.GetNode(id).IsWaste {
+			t.Errorf("Expected %s not to be flagged", id)
+		}
+	}
+}
+
+func TestKMSKeyHeuristic(t *testing.T) {
+	old := time.Now().Add(-400 * 24 * time.Hour)
+	key := func(id string) string { return "arn:aws:kms:us-east-1:123:key/" + id }
+	g := graph.NewGraph()
+	add := func(id, state string, extra map[string]interface{}) {
+		props := map[string]interface{}{"KeyId": id, "KeyState": state, "KeyManager": "CUSTOMER", "CreateTime": old, "UsageTraced": true}
+		for k, v := range extra {
+			props[k] = v
+		}
+		g.AddNode(key(id), "AWS::KMS::Key", props)
+	}
+	add("unused", "Enabled", map[string]interface{}{"Rotations": 3, "Aliases": []string{"alias/old-app"}})
+	add("stale", "Enabled", map[string]interface{}{"LastUsed": time.Now().Add(-120 * 24 * time.Hour)})
+	add("recent", "Enabled", map[string]interface{}{"LastUsed": time.Now().Add(-24 * time.Hour)})
+	add("referenced", "Enabled", nil)
+	add("untraced", "Enabled", map[string]interface{}{"UsageTraced": false})
+	add("young", "Enabled", map[string]interface{}{"CreateTime": time.Now().Add(-10 * 24 * time.Hour)})
+	add("disabled", "Disabled", nil)
+	add("aws-managed", "Enabled", map[string]interface{}{"KeyManager": "AWS"})
+	add("import", "PendingImport", map[string]interface{}{"UsageTraced": false})
+	add("deleting", "PendingDeletion", map[string]interface{}{"DeletionDate": time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)})
+	g.AddNode("vol-1", "AWS::EC2::Volume", nil)
+	g.AddNode("vol-2", "AWS::EC2::Volume", nil)
+	g.AddTypedEdge("vol-1", key("referenced"), graph.EdgeTypeUses, 100)
+	g.AddTypedEdge("vol-2", key("deleting"), graph.EdgeTypeUses, 100)
+	g.CloseAndWait()
+
+	stats, err := (&KMSKeyHeuristic{}).Run(context.Background(), g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.ItemsFound != 4 {
+		t.Fatalf("Expected 4 findings, got %d", stats.ItemsFound)
+	}
+
+	unused := g.GetNode(key("unused"))
+	// $1 for the key plus $1 for each of its first two rotations.
+	if !unused.IsWaste || unused.Cost != 3 || unused.Properties["KMSFinding"] != "unused" {
+		t.Errorf("unused key: waste=%v cost=%.2f finding=%v", unused.IsWaste, unused.Cost, unused.Properties["KMSFinding"])
+	}
+	// Deleting a key is irreversible, so every finding is left for review.
+	if unused.RiskScore > 50 {
+		t.Errorf("unused key: risk %d is above the review threshold", unused.RiskScore)
+	}
+	if reason, _ := unused.Properties["Reason"].(string); !strings.Contains(reason, "alias/old-app") {
+		t.Errorf("Expected the alias in %q", reason)
+	}
+	stale := g.GetNode(key("stale"))
+	if reason, _ := stale.Properties["Reason"].(string); !stale.IsWaste || !strings.Contains(reason, "last used") {
+		t.Errorf("stale key: waste=%v reason=%q", stale.IsWaste, reason)
+	}
+
+	imp := g.GetNode(key("import"))
+	if !imp.IsWaste || imp.Cost != 1 || imp.Properties["KMSFinding"] != "pending-import" || imp.RiskScore > 50 {
+		t.Errorf("pending import key: waste=%v cost=%.2f finding=%v risk=%d", imp.IsWaste, imp.Cost, imp.Properties["KMSFinding"], imp.RiskScore)
+	}
+
+	// Keys pending deletion are no longer billed; a referenced one is worth a look.
+	deleting := g.GetNode(key("deleting"))
+	if !deleting.IsWaste || deleting.Cost != 0 || deleting.RiskScore != 45 {
+		t.Errorf("pending deletion key: waste=%v cost=%.2f risk=%d", deleting.IsWaste, deleting.Cost, deleting.RiskScore)
+	}
+	if reason, _ := deleting.Properties["Reason"].(string); !strings.Contains(reason, "2026-11-01") || !strings.Contains(reason, "1 scanned resource") {
+		t.Errorf("Unexpected reason %q", reason)
+	}
+
+	for _, id := range []string{"recent", "referenced", "untraced", "young", "disabled", "aws-managed"} {
+		if g.GetNode(key(id)).IsWaste {
+			t.Errorf("Expected %s not to be flagged", id)
+		}
+	}
+}
+
+func TestSharingAuditHeuristic(t *testing.T) {
+	snap := func(id string) string { return "arn:aws:ec2:region:account:snapshot/" + id }
+	ami := func(id string) string { return "arn:aws:ec2:region:account:image/" + id }
+
+	g := graph.NewGraph()
+	g.AddNode(snap("snap-public"), "AWS::EC2::Snapshot", map[string]interface{}{
+		"VolumeSize": int32(100), "Public": true, "CreateVolumePermissions": []string{"all"},
+	})
+	g.AddNode(snap("snap-partner"), "AWS::EC2::Snapshot", map[string]interface{}{
+		"VolumeSize": int32(10), "Public": false, "CreateVolumePermissions": []string{"111111111111", "999999999999"},
+	})
+	g.AddNode(snap("snap-member"), "AWS::EC2::Snapshot", map[string]interface{}{
+		"VolumeSize": int32(10), "Public": false, "CreateVolumePermissions": []string{"111111111111"},
+	})
+	g.AddNode(snap("snap-root"), "AWS::EC2::Snapshot", map[string]interface{}{"VolumeSize": int32(8)})
+	g.AddNode(ami("ami-aged"), "AWS::EC2::AMI", map[string]interface{}{
+		"Public": false, "LaunchPermissions": []string{"999999999999"},
+	})
+	g.AddTypedEdge(ami("ami-aged"), snap("snap-root"), graph.EdgeTypeContains, 100)
+	g.AddNode(ami("ami-org"), "AWS::EC2::AMI", map[string]interface{}{
+		"Public": false, "LaunchPermissions": []string{"arn:aws:organizations::111111111111:organization/o-abc"},
+	})
+	g.CloseAndWait()
+
+	// Already flagged for cost by another heuristic.
+	aged := g.GetNode(ami("ami-aged"))
+	aged.IsWaste, aged.RiskScore, aged.Cost = true, 60, 1.5
+	aged.Properties["Reason"] = "Aged AMI"
+
+	h := &SharingAuditHeuristic{TrustedAccounts: map[string]bool{"111111111111": true}, OrgListed: true}
+	stats, err := h.Run(context.Background(), g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.ItemsFound != 3 {
+		t.Fatalf("Expected 3 findings, got %d", stats.ItemsFound)
+	}
+
+	// Exposure is a compliance violation; nothing becomes a delete.
+	public := g.GetNode(snap("snap-public"))
+	if public.IsWaste || public.Cost != 0 || !public.ComplianceViolation || public.Properties["SecurityExposure"] != "public" ||
+		!strings.Contains(public.ComplianceReason, "($5.00/mo storage)") {
+		t.Errorf("public snapshot: waste=%v cost=%.2f violation=%v exposure=%v reason=%q",
+			public.IsWaste, public.Cost, public.ComplianceViolation, public.Properties["SecurityExposure"], public.ComplianceReason)
+	}
+	partner := g.GetNode(snap("snap-partner"))
+	if partner.IsWaste || !partner.ComplianceViolation || !strings.Contains(partner.ComplianceReason, "outside the organization: 999999999999 ") {
+		t.Errorf("partner snapshot: waste=%v reason=%q", partner.IsWaste, partner.ComplianceReason)
+	}
+
+	// The cost finding is left as it was.
+	reason, _ := aged.Properties["Reason"].(string)
+	if aged.RiskScore != 60 || aged.Cost != 1.5 || reason != "Aged AMI" || !strings.HasPrefix(aged.ComplianceReason, "SECURITY ALERT: AMI") {
+		t.Errorf("aged AMI: risk=%d cost=%.2f reason=%q compliance=%q", aged.RiskScore, aged.Cost, reason, aged.ComplianceReason)
+	}
+
+	for _, id := range []string{snap("snap-member"), snap("snap-root"), ami("ami-org")} {
+		if node := g.GetNode(id); node.IsWaste || node.ComplianceViolation {
+			t.Errorf("Expected %s not to be flagged", id)
+		}
+	}
+}
+
+func TestApplyShadowInfra(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:ec2:us-east-1:123:instance/i-managed", "AWS::EC2::Instance", map[string]interface{}{})
+	g.AddNode("arn:aws:ec2:us-east-1:123:volume/vol-clickops", "AWS::EC2::Volume", map[string]interface{}{})
+	g.AddNode("arn:aws:s3:::managed-bucket", "AWS::S3::Bucket", map[string]interface{}{})
+	g.AddNode("arn:aws:ec2:us-east-1:123:natgateway/nat-clickops", "aws_nat_gateway", map[string]interface{}{})
+	g.AddNode("k8s-pod", "K8s::Pod", map[string]interface{}{})
+	g.CloseAndWait()
+	g.MarkWaste("arn:aws:ec2:us-east-1:123:volume/vol-clickops", 80)
+	g.GetNode("arn:aws:ec2:us-east-1:123:volume/vol-clickops").Cost = 12.5
+
+	managed := map[string]bool{"i-managed": true, "arn:aws:s3:::managed-bucket": true}
+	stats := applyShadowInfra(g, managed)
+	if stats.ItemsFound != 2 {
+		t.Fatalf("Expected 2 unmanaged resources, got %d", stats.ItemsFound)
+	}
+	if stats.ProjectedSavings != 12.5 {
+		t.Errorf("Expected unmanaged waste cost 12.5, got %.2f", stats.ProjectedSavings)
+	}
+
+	for _, id := range []string{"arn:aws:ec2:us-east-1:123:volume/vol-clickops", "arn:aws:ec2:us-east-1:123:natgateway/nat-clickops"} {
+		if unmanaged, _ := g.GetNode(id).Properties["Unmanaged"].(bool); !unmanaged {
+			t.Errorf("Expected %s missing from state to be marked Unmanaged", id)
+		}
+	}
+	for _, id := range []string{"arn:aws:ec2:us-east-1:123:instance/i-managed", "arn:aws:s3:::managed-bucket", "k8s-pod"} {
+		if _, ok := g.GetNode(id).Properties["Unmanaged"]; ok {
+			t.Errorf("Expected %s not to be marked", id)
+		}
+	}
+	if g.GetNode("arn:aws:s3:::managed-bucket").IsWaste {
+		t.Error("Shadow infrastructure is a governance signal and must not mark waste")
+	}
+}
+
+func TestApplyCostAllocation(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("i-tagged", "AWS::EC2::Instance", map[string]interface{}{
+		"Tags": map[string]string{"team": "data", "env": "prod"},
+	})
+	g.AddNode("i-partial", "AWS::EC2::Instance", map[string]interface{}{
+		"Tags": map[string]string{"env": "prod"},
+	})
+	g.AddNode("i-untagged", "AWS::EC2::Instance", map[string]interface{}{})
+	g.AddNode("eip-free", "AWS::EC2::EIP", map[string]interface{}{"Tags": map[string]string{}})
+	g.AddNode("bucket-no-tags-collected", "AWS::S3::Bucket", map[string]interface{}{})
+	g.CloseAndWait()
+
+	costs := map[string]float64{"i-tagged": 70, "i-partial": 60, "i-untagged": 30, "bucket-no-tags-collected": 5}
+	stats := applyCostAllocation(g, []string{"team", "env"}, func(n *graph.Node) float64 { return costs[n.IDStr()] })
+	if stats.ItemsFound != 2 {
+		t.Fatalf("Expected 2 unattributable resources, got %d", stats.ItemsFound)
+	}
+	if stats.ProjectedSavings != 90 {
+		t.Errorf("Expected $90 unattributable spend, got %.2f", stats.ProjectedSavings)
+	}
+
+	partial := g.GetNode("i-partial")
+	if missing, _ := partial.Properties["MissingCostTags"].([]string); len(missing) != 1 || missing[0] != "team" {
+		t.Errorf("Expected missing [team], got %v", missing)
+	}
+	if partial.IsWaste {
+		t.Error("Unattributable spend is a governance signal and must not mark waste")
+	}
+	if _, ok := g.GetNode("i-untagged").Properties["UnattributableCost"]; !ok {
+		t.Error("Expected untagged instance to count as unattributable")
+	}
+	// Fully tagged, free, and resources without collected tags are skipped.
+	for _, id := range []string{"i-tagged", "eip-free", "bucket-no-tags-collected"} {
+		if _, ok := g.GetNode(id).Properties["UnattributableCost"]; ok {
+			t.Errorf("Expected %s to be skipped", id)
+		}
+	}
+}
+
+func TestDiscountIncompleteData(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("upload-partial", "AWS::S3::MultipartUpload", map[string]interface{}{
+		"_errors": []string{"s3:GetLifecycleConfiguration: AccessDenied"},
+	})
+	g.AddNode("upload-full", "AWS::S3::MultipartUpload", map[string]interface{}{})
+	g.AddNode("bucket-clean", "AWS::S3::Bucket", map[string]interface{}{
+		"_errors": []string{"s3:GetBucketLocation: AccessDenied"},
+	})
+	g.CloseAndWait()
+	g.MarkWaste("upload-partial", 70)
+	g.GetNode("upload-partial").Properties["Reason"] = "Stale multipart upload"
+	g.MarkWaste("upload-full", 70)
+
+	if n := DiscountIncompleteData(g); n != 1 {
+		t.Fatalf("Expected 1 finding downgraded, got %d", n)
+	}
+	// Idempotent: a second pass must not lower the score again.
+	DiscountIncompleteData(g)
+
+	partial := g.GetNode("upload-partial")
+	if partial.RiskScore != 40 {
+		t.Errorf("Expected risk 70-30=40 (review), got %d", partial.RiskScore)
+	}
+	if reason, _ := partial.Properties["Reason"].(string); !strings.Contains(reason, "incomplete data: s3:GetLifecycleConfiguration: AccessDenied") {
+		t.Errorf("Expected denied call in reason, got %q", reason)
+	}
+	if g.GetNode("upload-full").RiskScore != 70 {
+		t.Error("Findings with complete data must keep their score")
+	}
+	if g.GetNode("bucket-clean").IsWaste {
+		t.Error("Incomplete data alone must not create a finding")
+	}
+}
+
+func TestApplyOverallocatedVolumes(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("vol-empty", "AWS::EC2::Volume", map[string]interface{}{"Size": int32(1000), "VolumeType": "gp3", "State": "in-use"})
+	g.AddNode("vol-busy", "AWS::EC2::Volume", map[string]interface{}{"Size": int32(500), "VolumeType": "gp3", "State": "in-use"})
+	g.AddNode("vol-noagent", "AWS::EC2::Volume", map[string]interface{}{"Size": int32(200), "VolumeType": "gp2", "State": "in-use"})
+	g.CloseAndWait()
+
+	stats := applyOverallocatedVolumes(g,
+		map[string]float64{"vol-empty": 5, "vol-busy": 62},
+		[]string{"vol-noagent"},
+		map[string]float64{"vol-empty": 0.08, "vol-busy": 0.08, "vol-noagent": 0.10},
+		overallocWindow)
+	if stats.ItemsFound != 1 {
+		t.Fatalf("Expected 1 over-allocated volume, got %d", stats.ItemsFound)
+	}
+
+	empty := g.GetNode("vol-empty")
+	// 50 GB used; 100 GB keeps it at 50%, saving 900 GB * $0.08.
+	if size, _ := empty.Properties["RecommendedSizeGB"].(int); size != 100 {
+		t.Errorf("Expected 100 GB recommendation, got %d", size)
+	}
+	if empty.Cost < 71.99 || empty.Cost > 72.01 {
+		t.Errorf("Expected $72.00/mo savings, got %.2f", empty.Cost)
+	}
+	if empty.RiskScore >= 50 {
+		t.Errorf("Expected review-level risk, got %d", empty.RiskScore)
+	}
+	if empty.IsWaste {
+		t.Error("An attached volume is a resize recommendation, not waste")
+	}
+	if reason, _ := empty.Properties["Reason"].(string); !strings.Contains(reason, "cannot shrink in place") {
+		t.Errorf("Expected migration note in reason, got %q", reason)
+	}
+	if g.GetNode("vol-busy").IsWaste {
+		t.Error("Well-used volume must not be flagged")
+	}
+	noAgent := g.GetNode("vol-noagent")
+	if missing, _ := noAgent.Properties["DiskMetricsMissing"].(bool); !missing || noAgent.IsWaste {
+		t.Error("Expected volume without agent metrics to be annotated, not flagged")
+	}
+}
+
+func TestGP3Recommendation(t *testing.T) {
+	cases := []struct {
+		iops, throughput    int
+		peakIOPS, peakMiBps float64
+		wantIOPS, wantTP    int
+		wantOK              bool
+	}{
+		{16000, 125, 900, 40, 3000, 125, true},  // IOPS never used
+		{6000, 500, 900, 300, 3000, 500, true},  // throughput still needed
+		{6000, 500, 5000, 40, 6000, 125, true},  // IOPS still needed
+		{6000, 125, 2900, 40, 6000, 125, false}, // peak too close to baseline
+		{3000, 125, 10, 1, 3000, 125, false},    // already at baseline
+	}
+	for _, c := range cases {
+		iops, tp, ok := gp3Recommendation(c.iops, c.throughput, c.peakIOPS, c.peakMiBps)
+		if iops != c.wantIOPS || tp != c.wantTP || ok != c.wantOK {
+			t.Errorf("gp3Recommendation(%d, %d, %.0f, %.0f) = %d, %d, %v; want %d, %d, %v",
+				c.iops, c.throughput, c.peakIOPS, c.peakMiBps, iops, tp, ok, c.wantIOPS, c.wantTP, c.wantOK)
+		}
+	}
+}
+
+func TestApplyOverprovisionedGP3(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("vol-fast", "AWS::EC2::Volume", map[string]interface{}{"Size": int32(500), "VolumeType": "gp3", "State": "in-use", "Iops": int32(16000), "Throughput": int32(125)})
+	g.CloseAndWait()
+
+	// 13000 IOPS over baseline at $0.005.
+	savings := pricing.GP3ProvisionedSurcharge(16000, 125) - pricing.GP3ProvisionedSurcharge(3000, 125)
+	stats := applyOverprovisionedGP3(g, map[string]gp3Finding{
+		"vol-fast": {peakIOPS: 850, peakMiBps: 12, recommendedIOPS: 3000, recommendedTP: 125, savings: savings},
+	}, gp3Window)
+	if stats.ItemsFound != 1 {
+		t.Fatalf("Expected 1 over-provisioned volume, got %d", stats.ItemsFound)
+	}
+
+	node := g.GetNode("vol-fast")
+	if node.Cost < 64.99 || node.Cost > 65.01 {
+		t.Errorf("Expected $65.00/mo savings, got %.2f", node.Cost)
+	}
+	if downshift, _ := node.Properties["GP3Downshift"].(bool); !downshift {
+		t.Error("Expected GP3Downshift so remediation modifies instead of deleting")
+	}
+	if iops, _ := node.Properties["RecommendedIops"].(int); iops != 3000 {
+		t.Errorf("Expected 3000 IOPS recommendation, got %d", iops)
+	}
+	if reason, _ := node.Properties["Reason"].(string); !strings.Contains(reason, "16000 IOPS provisioned, peak 850") {
+		t.Errorf("Expected usage in reason, got %q", reason)
+	}
+}
+
+func TestLambdaHeuristic(t *testing.T) {
+	old := time.Now().Add(-120 * 24 * time.Hour).UTC().Format("2006-01-02T15:04:05.000+0000")
+	g := graph.NewGraph()
+	g.AddNode("dead", "aws_lambda_function", map[string]interface{}{
+		"LastModified": old, "MemorySize": int32(1024), "Architecture": "x86_64",
+		"Invocations30d": 0.0, "SumInvocations90d": 0.0, "DurationMs30d": 0.0,
+	})
+	g.AddNode("warm", "aws_lambda_function", map[string]interface{}{
+		"LastModified": old, "MemorySize": int32(2048), "Architecture": "x86_64",
+		"Invocations30d": 50000.0, "DurationMs30d": 1e7,
+		"ProvisionedConcurrency": 50, "PCUtilizationAvg30d": 0.04, "PCUtilizationMax30d": 0.1,
+	})
+	g.AddNode("busy", "aws_lambda_function", map[string]interface{}{
+		"LastModified": old, "MemorySize": int32(512),
+		"Invocations30d": 1e6, "ProvisionedConcurrency": 10, "PCUtilizationAvg30d": 0.7, "PCUtilizationMax30d": 0.95,
+	})
+	g.AddNode("unmeasured", "aws_lambda_function", map[string]interface{}{"LastModified": old})
+	g.AddNode("hoarder", "aws_lambda_function", map[string]interface{}{
+		"LastModified": old, "MemorySize": int32(512), "Invocations30d": 1e6,
+		"AllVersions": []string{"1", "2", "3", "4", "5", "6", "7"}, "AliasVersions": map[string]bool{"7": true},
+	})
+	g.CloseAndWait()
+
+	stats := (&LambdaHeuristic{}).Analyze(g)
+	if stats.ItemsFound != 3 {
+		t.Fatalf("ItemsFound = %d, want 3", stats.ItemsFound)
+	}
+
+	dead := g.GetNode("dead")
+	if reason, _ := dead.Properties["Reason"].(string); !strings.HasPrefix(reason, "Dead function: 0 invocations in 90 days") {
+		t.Errorf("dead reason = %q", reason)
+	}
+
+	warm := g.GetNode("warm")
+	if rec, _ := warm.Properties["RecommendedProvisionedConcurrency"].(int); rec != 6 {
+		t.Errorf("recommended concurrency = %d, want 6 (peak 5 plus headroom)", rec)
+	}
+	// 44 fewer 2 GB environments for a month.
+	want := 2.0 * 44 * pricing.HoursPerMonth * 3600 * 0.0000041667
+	if math.Abs(warm.Cost-want) > 0.01 {
+		t.Errorf("warm savings = %.2f, want %.2f", warm.Cost, want)
+	}
+	if reason, _ := warm.Properties["Reason"].(string); !strings.HasPrefix(reason, "Over-provisioned concurrency: 50 provisioned, 4% used") {
+		t.Errorf("warm reason = %q", reason)
+	}
+	if _, ok := warm.Properties["EstimatedMonthlyCost"].(float64); !ok {
+		t.Error("expected EstimatedMonthlyCost on measured functions")
+	}
+
+	if g.GetNode("busy").IsWaste || g.GetNode("unmeasured").IsWaste {
+		t.Error("busy and unmeasured functions must not be flagged")
+	}
+
+	// An in-use function with old versions stays below the REVIEW threshold.
+	if hoarder := g.GetNode("hoarder"); !hoarder.IsWaste || hoarder.RiskScore > 50 {
+		t.Errorf("hoarder: waste=%v risk=%d, want a finding at or below 50", hoarder.IsWaste, hoarder.RiskScore)
+	}
+}
+
+func TestSameDevice(t *testing.T) {
+	cases := []struct {
+		attachment, agent string
+		want              bool
+	}{
+		{"/dev/sdf", "xvdf", true},
+		{"/dev/xvda", "xvda1", true},
+		{"/dev/sdf", "xvdg", false},
+		{"/dev/sdf", "nvme1n1", false},
+		{"", "", false},
+	}
+	for _, c := range cases {
+		if got := sameDevice(c.attachment, c.agent); got != c.want {
+			t.Errorf("sameDevice(%q, %q) = %v, want %v", c.attachment, c.agent, got, c.want)
+		}
+	}
+}
+
+func TestApplyEnvironment(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("i-prod", "AWS::EC2::Instance", map[string]interface{}{"Tags": map[string]string{"Environment": "Production"}})
+	g.AddNode("i-sandbox", "AWS::EC2::Instance", map[string]interface{}{"Tags": map[string]string{"environment": "sandbox"}})
+	g.AddNode("i-untagged", "AWS::EC2::Instance", map[string]interface{}{})
+	g.AddNode("i-clean", "AWS::EC2::Instance", map[string]interface{}{"Tags": map[string]string{"Environment": "prod"}})
+	// Decoded without property types, tags come back as a generic map.
+	g.AddNode("fn-decoded", "aws_lambda_function", map[string]interface{}{"Tags": map[string]interface{}{"Environment": "prod"}})
+	g.CloseAndWait()
+	for _, id := range []string{"i-prod", "i-sandbox", "i-untagged", "fn-decoded"} {
+		g.MarkWaste(id, 80)
+	}
+	g.GetNode("i-prod").Properties["Reason"] = "Idle"
+
+	if n := ApplyEnvironment(g, "Environment"); n != 3 {
+		t.Fatalf("Expected 3 findings annotated, got %d", n)
+	}
+	ApplyEnvironment(g, "Environment") // Idempotent.
+
+	prod := g.GetNode("i-prod")
+	if prod.RiskScore != 49 || prod.Properties["RemediationCaution"] != CautionManualReview {
+		t.Errorf("Expected prod forced to manual review, got risk=%d caution=%v", prod.RiskScore, prod.Properties["RemediationCaution"])
+	}
+	if reason, _ := prod.Properties["Reason"].(string); reason != "Idle (Environment=Production: manual review required)" {
+		t.Errorf("Unexpected reason %q", reason)
+	}
+
+	sandbox := g.GetNode("i-sandbox")
+	if sandbox.Properties["Environment"] != "sandbox" || sandbox.Properties["RemediationCaution"] != CautionSafeDelete || sandbox.RiskScore != 80 {
+		t.Errorf("Expected sandbox suggested for safe delete, got %v", sandbox.Properties)
+	}
+	if decoded := g.GetNode("fn-decoded"); decoded.RiskScore != 49 || decoded.Properties["RemediationCaution"] != CautionManualReview {
+		t.Errorf("Expected generic-map tags to be read, got risk=%d caution=%v", decoded.RiskScore, decoded.Properties["RemediationCaution"])
+	}
+	if _, ok := g.GetNode("i-untagged").Properties["Environment"]; ok {
+		t.Error("Untagged finding must not get an environment")
+	}
+	if _, ok := g.GetNode("i-clean").Properties["Environment"]; ok {
+		t.Error("Only findings are annotated")
+	}
+}
+
+func TestUnattachedVolumeHeuristic_GCPDisks(t *testing.T) {
+	g := graph.NewGraph()
+	stopped := "projects/p/zones/us-central1-a/instances/stopped"
+	running := "projects/p/zones/us-central1-a/instances/running"
+
+	g.AddNode(stopped, "GCP::Compute::Instance", map[string]interface{}{
+		"State":      "stopped",
+		"LaunchTime": time.Now().Add(-60 * 24 * time.Hour),
+	})
+	g.AddNode(running, "GCP::Compute::Instance", map[string]interface{}{
+		"State":      "running",
+		"LaunchTime": time.Now().Add(-60 * 24 * time.Hour),
+	})
+	g.AddNode("disk-orphan", "GCP::Compute::Disk", map[string]interface{}{
+		"State":      "available",
+		"Size":       100,
+		"VolumeType": "pd-ssd",
+	})
+	g.AddNode("disk-stopped", "GCP::Compute::Disk", map[string]interface{}{
+		"State":                "in-use",
+		"Size":                 50,
+		"VolumeType":           "pd-standard",
+		"AttachedInstanceId":   "stopped",
+		"AttachedInstanceNode": stopped,
+	})
+	g.AddNode("disk-running", "GCP::Compute::Disk", map[string]interface{}{
+		"State":                "in-use",
+		"Size":                 50,
+		"AttachedInstanceId":   "running",
+		"AttachedInstanceNode": running,
+	})
+	g.CloseAndWait()
+
+	h := &UnattachedVolumeHeuristic{}
+	stats, err := h.Run(context.Background(), g)
+	if err != nil {
+		t.Fatalf("Heuristic run failed: %v", err)
+	}
+	if stats.ItemsFound != 2 {
+		t.Errorf("ItemsFound = %d, want 2", stats.ItemsFound)
+	}
+
+	orphan := g.GetNode("disk-orphan")
+	if !orphan.IsWaste || orphan.RiskScore != 90 {
+		t.Errorf("orphan disk: IsWaste=%v RiskScore=%d, want true/90", orphan.IsWaste, orphan.RiskScore)
+	}
+	if orphan.Cost != 17 {
+		t.Errorf("orphan disk cost = %.2f, want 17.00 (100 GB pd-ssd)", orphan.Cost)
+	}
+	if reason, _ := orphan.Properties["Reason"].(string); reason != "Unattached Persistent Disk" {
+		t.Errorf("orphan disk reason = %q", reason)
+	}
+	if n := g.GetNode("disk-stopped"); !n.IsWaste || n.RiskScore != 70 {
+		t.Errorf("disk on stopped VM: IsWaste=%v RiskScore=%d, want true/70", n.IsWaste, n.RiskScore)
+	}
+	if g.GetNode("disk-running").IsWaste {
+		t.Error("disk on running VM should not be waste")
+	}
+}
+
+func TestUnattachedVolumeHeuristic_AzureDisks(t *testing.T) {
+	g := graph.NewGraph()
+	vm := "/subscriptions/s/resourcegroups/rg/providers/microsoft.compute/virtualmachines/old"
+
+	g.AddNode(vm, "Azure::Compute::VirtualMachine", map[string]interface{}{
+		"State":      "stopped",
+		"LaunchTime": time.Now().Add(-60 * 24 * time.Hour),
+	})
+	g.AddNode("disk-orphan", "Azure::Compute::Disk", map[string]interface{}{
+		"State":      "available",
+		"Size":       100,
+		"VolumeType": "Premium_LRS",
+	})
+	g.AddNode("disk-dealloc", "Azure::Compute::Disk", map[string]interface{}{
+		"State":                "in-use",
+		"Size":                 30,
+		"VolumeType":           "StandardSSD_LRS",
+		"AttachedInstanceId":   "old",
+		"AttachedInstanceNode": vm,
+	})
+	g.CloseAndWait()
+
+	h := &UnattachedVolumeHeuristic{}
+	stats, err := h.Run(context.Background(), g)
+	if err != nil {
+		t.Fatalf("Heuristic run failed: %v", err)
+	}
+	if stats.ItemsFound != 2 {
+		t.Errorf("ItemsFound = %d, want 2", stats.ItemsFound)
+	}
+
+	orphan := g.GetNode("disk-orphan")
+	if reason, _ := orphan.Properties["Reason"].(string); reason != "Unattached Managed Disk" {
+		t.Errorf("orphan disk reason = %q", reason)
+	}
+	if orphan.Cost < 13.49 || orphan.Cost > 13.51 {
+		t.Errorf("orphan disk cost = %.2f, want 13.50 (100 GB Premium_LRS)", orphan.Cost)
+	}
+	if n := g.GetNode("disk-dealloc"); !n.IsWaste || n.RiskScore != 70 {
+		t.Errorf("disk on deallocated VM: IsWaste=%v RiskScore=%d, want true/70", n.IsWaste, n.RiskScore)
+	}
+}
+
+func TestIdleEFSHeuristic(t *testing.T) {
+	g := graph.NewGraph()
+	old := time.Now().Add(-90 * 24 * time.Hour)
+	g.AddNode("fs-orphan", "AWS::EFS::FileSystem", map[string]interface{}{
+		"FileSystemId":          "fs-orphan",
+		"State":                 "available",
+		"NumberOfMountTargets":  int32(0),
+		"SizeStandardBytes":     int64(10 << 30),
+		"ProvisionedThroughput": 5.0,
+		"CreationTime":          old,
+	})
+	g.AddNode("fs-mounted", "AWS::EFS::FileSystem", map[string]interface{}{
+		"FileSystemId":         "fs-mounted",
+		"State":                "available",
+		"NumberOfMountTargets": int32(2),
+		"CreationTime":         old,
+	})
+	g.AddNode("fs-new", "AWS::EFS::FileSystem", map[string]interface{}{
+		"State":                "available",
+		"NumberOfMountTargets": int32(0),
+		"CreationTime":         time.Now(),
+	})
+	g.CloseAndWait()
+
+	// Without CloudWatch only the mount-target check runs.
+	stats, err := (&IdleEFSHeuristic{}).Run(context.Background(), g)
+	if err != nil {
+		t.Fatalf("Heuristic run failed: %v", err)
+	}
+	if stats.ItemsFound != 1 {
+		t.Errorf("ItemsFound = %d, want 1", stats.ItemsFound)
+	}
+	orphan := g.GetNode("fs-orphan")
+	if !orphan.IsWaste || orphan.RiskScore != 80 {
+		t.Errorf("fs-orphan: IsWaste=%v RiskScore=%d, want true/80", orphan.IsWaste, orphan.RiskScore)
+	}
+	// 10 GB Standard at $0.30 plus 5 MiB/s provisioned at $6.00.
+	if orphan.Cost != 33 {
+		t.Errorf("fs-orphan cost = %.2f, want 33.00", orphan.Cost)
+	}
+	if g.GetNode("fs-mounted").IsWaste || g.GetNode("fs-new").IsWaste {
+		t.Error("mounted and new file systems should not be flagged without metrics")
+	}
+}
+
+func TestApplyIdleEFS_ReplacesLifecycleRecommendation(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("fs-1", "AWS::EFS::FileSystem", map[string]interface{}{"Name": "reports"})
+	g.CloseAndWait()
+
+	node := g.GetNode("fs-1")
+	node.IsWaste = true
+	node.RiskScore = 5
+	node.Properties["LifecycleRecommendation"] = true
+	node.Properties["FixRecommendation"] = "Add a lifecycle policy"
+
+	stats := applyIdleEFS(g, map[string]efsIdle{"fs-1": {Cost: 12}}, efsIdleWindow)
+	if stats.ItemsFound != 1 {
+		t.Fatalf("ItemsFound = %d, want 1", stats.ItemsFound)
+	}
+	if node.RiskScore != 60 || node.Cost != 12 {
+		t.Errorf("RiskScore=%d Cost=%.2f, want 60/12.00", node.RiskScore, node.Cost)
+	}
+	if _, ok := node.Properties["LifecycleRecommendation"]; ok {
+		t.Error("lifecycle recommendation should be replaced by deletion")
+	}
+	if conns, _ := node.Properties["ClientConnections"].(int); conns != 0 {
+		t.Errorf("ClientConnections = %v, want 0", node.Properties["ClientConnections"])
+	}
+}
+
+func TestNDJSONSinkStreamsFindings(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:ec2:us-east-1:123:vpc/vpc-empty", "AWS::EC2::VPC", map[string]interface{}{
+		"IsDefault": false,
+		"Region":    "us-east-1",
+	})
+	g.CloseAndWait()
+
+	var buf bytes.Buffer
+	sink := NewNDJSONSink(&buf)
+	e := NewEngine()
+	e.Register(&EmptyVPCHeuristic{})
+	e.OnFindings(SinkHandler(sink, func(err error) { t.Errorf("sink error: %v", err) }))
+
+	if err := e.Run(context.Background(), g); err != nil {
+		t.Fatalf("Engine run failed: %v", err)
+	}
+
+	// The handler flushes after each batch, so the line is visible without an explicit Flush.
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("Expected 1 NDJSON line, got %d: %q", len(lines), buf.String())
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &got); err != nil {
+		t.Fatalf("Line is not JSON: %v", err)
+	}
+	if got["event"] != "finding" || got["id"] != "arn:aws:ec2:us-east-1:123:vpc/vpc-empty" || got["region"] != "us-east-1" {
+		t.Errorf("Unexpected finding line: %v", got)
+	}
+	for _, key := range []string{"type", "reason", "monthly_cost", "risk_score"} {
+		if _, ok := got[key]; !ok {
+			t.Errorf("Finding line missing %q: %v", key, got)
+		}
+	}
+}
+
+func TestEngineSkipsDisabledHeuristics(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:ec2:region:account:vpc/vpc-empty", "AWS::EC2::VPC", map[string]interface{}{
+		"IsDefault": false,
+	})
+	g.CloseAndWait()
+
+	e := NewEngine()
+	e.Disable("EmptyVPCHeuristic")
+	e.Register(&EmptyVPCHeuristic{})
+
+	if err := e.Run(context.Background(), g); err != nil {
+		t.Fatalf("Engine run failed: %v", err)
+	}
+	if node := g.GetNode("arn:aws:ec2:region:account:vpc/vpc-empty"); node.IsWaste {
+		t.Error("Expected disabled heuristic not to run")
+	}
+}
+
+func TestCloudFrontHeuristic(t *testing.T) {
+	g := graph.NewGraph()
+	dist := func(id string, enabled bool) {
+		g.AddNode(id, "AWS::CloudFront::Distribution", map[string]interface{}{
+			"Name":             id + ".cloudfront.net",
+			"Enabled":          enabled,
+			"MetricDimensions": []string{"DistributionId=" + id + ",Region=Global"},
+		})
+	}
+	g.AddNode("orphan", "AWS::CloudFront::Distribution", map[string]interface{}{
+		"Name":              "orphan.cloudfront.net",
+		"Enabled":           true,
+		"UnresolvedOrigins": []string{"deleted-site.s3.amazonaws.com"},
+	})
+	dist("live", true)
+	dist("idle", true)
+	dist("unmeasured", true)
+	dist("disabled", false)
+	dist("alb-gone", true)
+	g.AddNode("alb-gone", "AWS::CloudFront::Distribution", map[string]interface{}{
+		"UnresolvedOrigins": []string{"web-1.us-east-1.elb.amazonaws.com"},
+	})
+	g.AddNode("arn:aws:s3:::bucket/assets", "AWS::S3::Bucket", map[string]interface{}{})
+	g.AddTypedEdge("orphan", "arn:aws:s3:::bucket/deleted-site", graph.EdgeTypeUses, 100)
+	g.AddTypedEdge("live", "arn:aws:s3:::bucket/assets", graph.EdgeTypeUses, 100)
+	g.CloseAndWait()
+
+	usage := map[string]CloudFrontUsage{
+		"orphan": {Requests: 1e6, Bytes: 0},
+		"live":   {Requests: 5e6, Bytes: 1 << 40},
+		"idle":   {Requests: 12},
+	}
+	stats := applyCloudFront(g, usage, cloudFrontWindow)
+	if stats.ItemsFound != 4 {
+		t.Errorf("ItemsFound = %d, want 4", stats.ItemsFound)
+	}
+
+	orphan := g.GetNode("orphan")
+	if !orphan.IsWaste || orphan.RiskScore != 70 {
+		t.Errorf("Expected orphaned origin finding, got waste=%v risk=%d", orphan.IsWaste, orphan.RiskScore)
+	}
+	if reason, _ := orphan.Properties["Reason"].(string); !strings.Contains(reason, "deleted-site.s3.amazonaws.com") {
+		t.Errorf("Expected the missing bucket in the reason, got %q", reason)
+	}
+	// 1M requests over 14 days is about $2.14 over 30 days.
+	if orphan.Cost < 2.1 || orphan.Cost > 2.2 {
+		t.Errorf("Orphan cost = %.2f, want ~2.14", orphan.Cost)
+	}
+	if n := g.GetNode("alb-gone"); !n.IsWaste || n.RiskScore != 70 {
+		t.Error("Expected unresolved load balancer origin to be flagged")
+	}
+	if n := g.GetNode("idle"); !n.IsWaste || n.RiskScore != 40 {
+		t.Error("Expected idle distribution to be flagged")
+	}
+	if g.GetNode("disabled").RiskScore != 30 {
+		t.Error("Expected disabled distribution to be flagged")
+	}
+	for _, id := range []string{"live", "unmeasured"} {
+		if g.GetNode(id).IsWaste {
+			t.Errorf("Did not expect %s to be flagged", id)
+		}
+	}
+}
+
+func TestCloudFrontHeuristicSkipsUnscannedOrigins(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:s3:::bucket/assets", "AWS::S3::Bucket", map[string]interface{}{})
+	g.AddNode("dist", "AWS::CloudFront::Distribution", map[string]interface{}{"Enabled": true})
+	// A bucket in another account is never scanned, but still serves.
+	g.AddTypedEdge("dist", "arn:aws:s3:::bucket/partner-assets", graph.EdgeTypeUses, 100)
+	g.CloseAndWait()
+
+	if stats := applyCloudFront(g, nil, cloudFrontWindow); stats.ItemsFound != 0 {
+		t.Errorf("Expected origins outside the scan to stay unknown, got %d findings", stats.ItemsFound)
+	}
+}
+
+func TestNodeRegion(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:ec2:eu-west-1:123:volume/vol-1", "AWS::EC2::Volume", map[string]interface{}{})
+	g.AddNode("arn:aws:ec2:eu-west-1:123:volume/vol-2", "AWS::EC2::Volume", map[string]interface{}{"Region": "ap-south-1"})
+	g.AddNode("arn:aws:ec2:region:account:natgateway/nat-1", "AWS::EC2::NatGateway", map[string]interface{}{})
+	g.AddNode("arn:aws:s3:::bucket/assets", "AWS::S3::Bucket", map[string]interface{}{"Region": "global"})
+	g.CloseAndWait()
+
+	cases := []struct {
+		id, fallback, want string
+	}{
+		{"arn:aws:ec2:eu-west-1:123:volume/vol-1", "us-west-2", "eu-west-1"},
+		{"arn:aws:ec2:eu-west-1:123:volume/vol-2", "us-west-2", "ap-south-1"},
+		{"arn:aws:ec2:region:account:natgateway/nat-1", "us-west-2", "us-west-2"},
+		{"arn:aws:s3:::bucket/assets", "", "us-east-1"},
+	}
+	for _, c := range cases {
+		if got := NodeRegion(g.GetNode(c.id), c.fallback); got != c.want {
+			t.Errorf("NodeRegion(%s, %q) = %q, want %q", c.id, c.fallback, got, c.want)
+		}
+	}
+}
+
+func TestApplyOrphanedSnapshots(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-200 * 24 * time.Hour)
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:ec2:us-east-1:123:volume/vol-live", "AWS::EC2::Volume", map[string]interface{}{})
+	g.AddNode("arn:aws:ec2:us-east-1:123:snapshot/snap-orphan", "AWS::EC2::Snapshot", map[string]interface{}{
+		"VolumeId": "vol-gone", "VolumeSize": int32(100), "CreateTime": &old,
+	})
+	g.AddNode("arn:aws:ec2:us-east-1:123:snapshot/snap-live", "AWS::EC2::Snapshot", map[string]interface{}{
+		"VolumeId": "vol-live", "VolumeSize": int32(100), "CreateTime": &old,
+	})
+	g.AddNode("arn:aws:ec2:us-east-1:123:snapshot/snap-ami", "AWS::EC2::Snapshot", map[string]interface{}{
+		"VolumeId": "vol-gone", "VolumeSize": int32(8), "CreateTime": &old,
+	})
+	g.AddNode("arn:aws:ec2:us-east-1:123:image/ami-1", "AWS::EC2::AMI", map[string]interface{}{})
+	g.AddTypedEdge("arn:aws:ec2:us-east-1:123:image/ami-1", "arn:aws:ec2:us-east-1:123:snapshot/snap-ami", graph.EdgeTypeContains, 100)
+	g.AddNode("arn:aws:ec2:us-east-1:123:snapshot/snap-young", "AWS::EC2::Snapshot", map[string]interface{}{
+		"VolumeId": "vol-gone", "VolumeSize": int32(100), "CreateTime": now.Add(-10 * 24 * time.Hour),
+	})
+	g.AddNode("arn:aws:ec2:us-east-1:123:snapshot/snap-undated", "AWS::EC2::Snapshot", map[string]interface{}{
+		"VolumeId": "vol-gone", "VolumeSize": int32(100),
+	})
+	g.CloseAndWait()
+
+	stats := applyOrphanedSnapshots(g, 90*24*time.Hour, now, func(n *graph.Node) float64 {
+		return snapshotCost(context.Background(), nil, "us-east-1", n)
+	})
+	if stats.ItemsFound != 1 {
+		t.Fatalf("Expected 1 orphaned snapshot, got %d", stats.ItemsFound)
+	}
+
+	orphan := g.GetNode("arn:aws:ec2:us-east-1:123:snapshot/snap-orphan")
+	if !orphan.IsWaste || orphan.Cost != 5 {
+		t.Errorf("Expected orphan flagged at 100 GB * $0.05 = $5/mo, got waste=%v cost=%.2f", orphan.IsWaste, orphan.Cost)
+	}
+	if reason, _ := orphan.Properties["Reason"].(string); !strings.Contains(reason, "200 days") || !strings.Contains(reason, "vol-gone") {
+		t.Errorf("Unexpected reason %q", reason)
+	}
+	for _, id := range []string{"snap-live", "snap-ami", "snap-young", "snap-undated"} {
+		if g.GetNode("arn:aws:ec2:us-east-1:123:snapshot/" + id).IsWaste {
+			t.Errorf("%s must not be flagged", id)
+		}
+	}
+}
+
+func TestIdleOpenSearchHeuristic(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:es:us-east-1:123:domain/idle", "AWS::OpenSearch::Domain", map[string]interface{}{
+		"DomainName": "idle", "InstanceType": "r6g.large.search", "InstanceCount": 2,
+		"DedicatedMasterType": "m6g.large.search", "DedicatedMasterCount": 3, "EBSVolumeSize": 100,
+		"SearchRate": 0.0, "IndexingRate": 0.0, "SearchableDocuments": 5000.0,
+	})
+	g.AddNode("arn:aws:es:us-east-1:123:domain/ingest", "AWS::OpenSearch::Domain", map[string]interface{}{
+		"DomainName": "ingest", "InstanceType": "r6g.large.search", "InstanceCount": 2,
+		"SearchRate": 0.0, "IndexingRate": 4200.0,
+	})
+	// No metrics: CloudWatch could not be read.
+	g.AddNode("arn:aws:es:us-east-1:123:domain/unknown", "AWS::OpenSearch::Domain", map[string]interface{}{
+		"DomainName": "unknown", "InstanceType": "r6g.large.search", "InstanceCount": 2,
+	})
+	g.CloseAndWait()
+
+	stats, err := (&IdleOpenSearchHeuristic{Window: 30 * 24 * time.Hour}).Run(context.Background(), g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.ItemsFound != 1 {
+		t.Fatalf("Expected 1 idle domain, got %d", stats.ItemsFound)
+	}
+
+	idle := g.GetNode("arn:aws:es:us-east-1:123:domain/idle")
+	if !idle.IsWaste {
+		t.Fatal("Expected domain with no searches or indexing to be flagged")
+	}
+	// 2 x r6g.large ($0.167/hr) + 3 x m6g.large ($0.128/hr) + 200 GB at $0.135.
+	want := (2*0.167+3*0.128)*730 + 200*0.135
+	if idle.Cost < want-0.01 || idle.Cost > want+0.01 {
+		t.Errorf("Expected $%.2f/mo, got %.2f", want, idle.Cost)
+	}
+	if reason, _ := idle.Properties["Reason"].(string); !strings.Contains(reason, "2 x r6g.large.search") || !strings.Contains(reason, "in 30 days") {
+		t.Errorf("Unexpected reason %q", reason)
+	}
+	for _, id := range []string{"ingest", "unknown"} {
+		if g.GetNode("arn:aws:es:us-east-1:123:domain/" + id).IsWaste {
+			t.Errorf("Expected %s not to be flagged", id)
+		}
+	}
+}
+
+func TestDanglingDNSHeuristic(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:route53:::hostedzone/Z1/recordset/A/old.example.com.", "AWS::Route53::RecordSet", map[string]interface{}{
+		"Name": "old.example.com.", "Type": "A", "Zone": "example.com.",
+		"DanglingTarget": "gone-1.us-east-1.elb.amazonaws.com",
+	})
+	g.AddNode("arn:aws:route53:::hostedzone/Z1/recordset/A/www.example.com.", "AWS::Route53::RecordSet", map[string]interface{}{
+		"Name": "www.example.com.", "Type": "A", "Zone": "example.com.",
+		"Target": "arn:aws:elasticloadbalancing:us-east-1:123:loadbalancer/app/web/abc",
+	})
+	g.CloseAndWait()
+
+	stats, err := (&DanglingDNSHeuristic{}).Run(context.Background(), g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.ItemsFound != 1 {
+		t.Fatalf("Expected 1 dangling record, got %d", stats.ItemsFound)
+	}
+	old := g.GetNode("arn:aws:route53:::hostedzone/Z1/recordset/A/old.example.com.")
+	if !old.IsWaste || old.Properties["DNSRisk"] != "SubdomainTakeover" {
+		t.Fatal("Expected dangling record to be flagged as a takeover risk")
+	}
+	if reason, _ := old.Properties["Reason"].(string); !strings.Contains(reason, "gone-1.us-east-1.elb.amazonaws.com") {
+		t.Errorf("Unexpected reason %q", reason)
+	}
+	if g.GetNode("arn:aws:route53:::hostedzone/Z1/recordset/A/www.example.com.").IsWaste {
+		t.Error("Expected resolved record not to be flagged")
+	}
+}
+
+func TestIdleMessagingHeuristic(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-60 * 24 * time.Hour)
+	g := graph.NewGraph()
+	queue := func(name string, sent float64, props map[string]interface{}) {
+		p := map[string]interface{}{"QueueName": name, "CreatedTimestamp": old, "MessagesSent": sent, "MessagesReceived": 0.0}
+		for k, v := range props {
+			p[k] = v
+		}
+		g.AddNode("arn:aws:sqs:us-east-1:123:"+name, "AWS::SQS::Queue", p)
+	}
+	queue("idle", 0, nil)
+	queue("busy", 10, map[string]interface{}{"DeadLetterQueue": "arn:aws:sqs:us-east-1:123:busy-dlq"})
+	queue("busy-dlq", 0, nil)
+	queue("new", 0, map[string]interface{}{"CreatedTimestamp": now.Add(-time.Hour)})
+	// No metrics: CloudWatch could not be read.
+	g.AddNode("arn:aws:sqs:us-east-1:123:unknown", "AWS::SQS::Queue", map[string]interface{}{"QueueName": "unknown"})
+
+	g.AddNode("arn:aws:sns:us-east-1:123:orphan", "AWS::SNS::Topic", map[string]interface{}{"TopicName": "orphan", "SubscriptionCount": 0, "MessagesPublished": 3.0})
+	g.AddNode("arn:aws:sns:us-east-1:123:quiet", "AWS::SNS::Topic", map[string]interface{}{"TopicName": "quiet", "SubscriptionCount": 2, "MessagesPublished": 0.0})
+	g.AddNode("arn:aws:sns:us-east-1:123:live", "AWS::SNS::Topic", map[string]interface{}{"TopicName": "live", "SubscriptionCount": 2, "MessagesPublished": 9.0})
+	g.AddNode("arn:aws:sns:us-east-1:123:kept", "AWS::SNS::Topic", map[string]interface{}{
+		"TopicName": "kept", "SubscriptionCount": 0, "Tags": map[string]string{"cloudslash:ignore": "true"},
+	})
+	g.CloseAndWait()
+
+	streamed := 0
+	g.SetWasteListener(func(string) { streamed++ })
+
+	stats := applyIdleMessaging(g, now, 30*24*time.Hour)
+	if stats.ItemsFound != 3 || streamed != 3 {
+		t.Errorf("Expected 3 findings, all streamed, got %d (%d streamed)", stats.ItemsFound, streamed)
+	}
+	for _, id := range []string{"sqs:us-east-1:123:idle", "sns:us-east-1:123:orphan", "sns:us-east-1:123:quiet"} {
+		if node := g.GetNode("arn:aws:" + id); !node.IsWaste || node.RiskScore != 40 {
+			t.Errorf("Expected %s to be flagged for review", id)
+		}
+	}
+	for _, id := range []string{"sqs:us-east-1:123:busy", "sqs:us-east-1:123:busy-dlq", "sqs:us-east-1:123:new", "sqs:us-east-1:123:unknown", "sns:us-east-1:123:live", "sns:us-east-1:123:kept"} {
+		if g.GetNode("arn:aws:" + id).IsWaste {
+			t.Errorf("Expected %s not to be flagged", id)
+		}
+	}
+	if reason, _ := g.GetNode("arn:aws:sns:us-east-1:123:orphan").Properties["Reason"].(string); !strings.Contains(reason, "no subscriptions") {
+		t.Errorf("Unexpected reason %q", reason)
+	}
+	// The reason names the window the metrics were read over.
+	if reason, _ := g.GetNode("arn:aws:sqs:us-east-1:123:idle").Properties["Reason"].(string); !strings.Contains(reason, "in 30 days") {
+		t.Errorf("Expected the 30-day window in the reason, got %q", reason)
+	}
+}
+
+// TestFindingsHonourIgnoreTag runs heuristics over a resource and an identical
+// one tagged cloudslash:ignore=true. Only the first may be flagged, and it must
+// reach the waste listener with a recorded finding.
+func TestFindingsHonourIgnoreTag(t *testing.T) {
+	cases := []struct {
+		name  string
+		typ   string
+		props map[string]interface{}
+		apply func(g *graph.Graph, ids []string) *HeuristicStats
+	}{
+		{
+			name:  "IdleDMSHeuristic",
+			typ:   "AWS::DMS::ReplicationInstance",
+			props: map[string]interface{}{"InstanceClass": "dms.t3.medium"},
+			apply: func(g *graph.Graph, ids []string) *HeuristicStats {
+				return applyIdleDMS(g, map[string]float64{ids[0]: 50, ids[1]: 50}, dmsWindow)
+			},
+		},
+		{
+			name:  "IdleOpenSearchHeuristic",
+			typ:   "AWS::OpenSearch::Domain",
+			props: map[string]interface{}{"DomainName": "logs"},
+			apply: func(g *graph.Graph, ids []string) *HeuristicStats {
+				return applyIdleOpenSearch(g, map[string]float64{ids[0]: 120, ids[1]: 120}, 14*24*time.Hour)
+			},
+		},
+		{
+			name:  "IdleElastiCacheHeuristic",
+			typ:   "aws_elasticache_cluster",
+			props: map[string]interface{}{"CacheClusterId": "sessions", "Engine": "redis", "NodeType": "cache.t3.micro", "NumCacheNodes": 1},
+			apply: func(g *graph.Graph, ids []string) *HeuristicStats {
+				f := elastiCacheFinding{Idle: true, Cost: 12}
+				return applyIdleElastiCache(g, map[string]elastiCacheFinding{ids[0]: f, ids[1]: f}, elastiCacheWindow)
+			},
+		},
+		{
+			name:  "RedshiftHeuristic",
+			typ:   "aws_redshift_cluster",
+			props: map[string]interface{}{"ClusterIdentifier": "warehouse", "NodeType": "ra3.xlplus", "NumberOfNodes": 2},
+			apply: func(g *graph.Graph, ids []string) *HeuristicStats {
+				f := redshiftFinding{Idle: true, Cost: 800}
+				return applyRedshift(g, map[string]redshiftFinding{ids[0]: f, ids[1]: f}, redshiftWindow)
+			},
+		},
+		{
+			name:  "KMSKeyHeuristic",
+			typ:   "AWS::KMS::Key",
+			props: map[string]interface{}{"KeyId": "1234abcd"},
+			apply: func(g *graph.Graph, ids []string) *HeuristicStats {
+				f := kmsFinding{Kind: kmsFindingUnused, Cost: 1}
+				return applyKMSKeys(g, map[string]kmsFinding{ids[0]: f, ids[1]: f})
+			},
+		},
+		{
+			name:  "IdleWAFHeuristic",
+			typ:   "AWS::WAFv2::WebACL",
+			props: map[string]interface{}{"Name": "edge", "RuleCount": 3},
+			apply: func(g *graph.Graph, ids []string) *HeuristicStats {
+				f := wafFinding{Unassociated: true, Cost: 8}
+				return applyIdleWAF(g, map[string]wafFinding{ids[0]: f, ids[1]: f}, wafWindow)
+			},
+		},
+		{
+			name:  "DynamoDBHeuristic",
+			typ:   "aws_dynamodb_table",
+			props: map[string]interface{}{"TableName": "sessions", "ItemCount": int64(0)},
+			apply: func(g *graph.Graph, ids []string) *HeuristicStats {
+				f := dynamoDBFinding{Dead: true, Cost: 25}
+				return applyDynamoDB(g, map[string]dynamoDBFinding{ids[0]: f, ids[1]: f}, dynamoDBWindow)
+			},
+		},
+		{
+			name:  "CloudFrontHeuristic",
+			typ:   "AWS::CloudFront::Distribution",
+			props: map[string]interface{}{"Name": "E123", "Enabled": true, "UnresolvedOrigins": []string{"gone-bucket"}},
+			apply: func(g *graph.Graph, ids []string) *HeuristicStats {
+				return applyCloudFront(g, nil, cloudFrontWindow)
+			},
+		},
+		{
+			name:  "IdleMLEndpointHeuristic",
+			typ:   "AWS::SageMaker::Endpoint",
+			props: map[string]interface{}{"Name": "churn", "HourlyCost": 0.5, "Region": mlPriceRegion},
+			apply: func(g *graph.Graph, ids []string) *HeuristicStats {
+				return applyIdleMLEndpoints(g, map[string]float64{ids[0]: 0, ids[1]: 0}, mlIdleWindow)
+			},
+		},
+		{
+			name:  "OrphanedSnapshotHeuristic",
+			typ:   "AWS::EC2::Snapshot",
+			props: map[string]interface{}{"VolumeId": "vol-gone", "CreationTime": time.Now().AddDate(-1, 0, 0)},
+			apply: func(g *graph.Graph, ids []string) *HeuristicStats {
+				return applyOrphanedSnapshots(g, 90*24*time.Hour, time.Now(), nil)
+			},
+		},
+		{
+			name:  "OverprovisionedGP3Heuristic",
+			typ:   "AWS::EC2::Volume",
+			props: map[string]interface{}{"VolumeType": "gp3", "Iops": int32(6000), "Throughput": int32(125)},
+			apply: func(g *graph.Graph, ids []string) *HeuristicStats {
+				f := gp3Finding{recommendedIOPS: 3000, recommendedTP: 125, savings: 15}
+				return applyOverprovisionedGP3(g, map[string]gp3Finding{ids[0]: f, ids[1]: f}, gp3Window)
+			},
+		},
+		{
+			name:  "IdleEFSHeuristic",
+			typ:   "AWS::EFS::FileSystem",
+			props: map[string]interface{}{"FileSystemId": "fs-1"},
+			apply: func(g *graph.Graph, ids []string) *HeuristicStats {
+				ev := efsIdle{NoMountTargets: true, Cost: 30}
+				return applyIdleEFS(g, map[string]efsIdle{ids[0]: ev, ids[1]: ev}, efsIdleWindow)
+			},
+		},
+		{
+			name:  "DanglingDNSHeuristic",
+			typ:   "AWS::Route53::RecordSet",
+			props: map[string]interface{}{"Name": "www.example.com.", "Type": "CNAME", "DanglingTarget": "gone.elb.amazonaws.com"},
+			apply: func(g *graph.Graph, ids []string) *HeuristicStats {
+				return applyDanglingDNS(g)
+			},
+		},
+		{
+			name:  "NATInstanceHeuristic",
+			typ:   "aws_nat_gateway",
+			props: map[string]interface{}{"SumBytesOut7d": 1e9},
+			apply: func(g *graph.Graph, ids []string) *HeuristicStats {
+				stats, _ := (&NATInstanceHeuristic{}).Run(context.Background(), g)
+				if _, ok := g.GetNode(ids[1]).Properties["NATInstanceRecommendation"]; ok {
+					t.Error("Expected no NAT instance recommendation on the ignore-tagged gateway")
+				}
+				return stats
+			},
+		},
+		{
+			name:  "EFSLifecycle",
+			typ:   "AWS::EFS::FileSystem",
+			props: map[string]interface{}{"NumberOfMountTargets": int32(2), "TransitionToIA": "", "SizeStandardBytes": int64(100 << 30)},
+			apply: func(g *graph.Graph, ids []string) *HeuristicStats {
+				stats, _ := (&EFSLifecycleHeuristic{}).Run(context.Background(), g)
+				if _, ok := g.GetNode(ids[1]).Properties["LifecycleRecommendation"]; ok {
+					t.Error("Expected no lifecycle recommendation on the ignore-tagged file system")
+				}
+				return stats
+			},
+		},
+		{
+			name:  "IdleEKSClusterHeuristic",
+			typ:   "AWS::EKS::Cluster",
+			props: map[string]interface{}{"Status": "ACTIVE", "CreatedAt": time.Now().Add(-8 * 24 * time.Hour)},
+			apply: func(g *graph.Graph, ids []string) *HeuristicStats {
+				stats, _ := (&IdleEKSClusterHeuristic{}).Run(context.Background(), g)
+				return stats
+			},
+		},
+		{
+			name:  "LambdaForensics",
+			typ:   "aws_lambda_function",
+			props: map[string]interface{}{"Invocations30d": 0.0, "LastModified": "2020-01-01T00:00:00.000+0000"},
+			apply: func(g *graph.Graph, ids []string) *HeuristicStats {
+				return (&LambdaHeuristic{}).Analyze(g)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := graph.NewGraph()
+			live, kept := "arn:aws:test:us-east-1:123:live", "arn:aws:test:us-east-1:123:kept"
+			for _, id := range []string{live, kept} {
+				props := make(map[string]interface{})
+				for k, v := range tc.props {
+					props[k] = v
+				}
+				if id == kept {
+					props["Tags"] = map[string]string{"cloudslash:ignore": "true"}
+				}
+				g.AddNode(id, tc.typ, props)
+			}
+			g.CloseAndWait()
+
+			var streamed []string
+			g.SetWasteListener(func(id string) { streamed = append(streamed, id) })
+
+			stats := tc.apply(g, []string{live, kept})
+			if stats.ItemsFound != 1 {
+				t.Errorf("Expected 1 finding, got %d", stats.ItemsFound)
+			}
+			if !reflect.DeepEqual(streamed, []string{live}) {
+				t.Errorf("Expected only %s streamed, got %v", live, streamed)
+			}
+			if node := g.GetNode(live); !node.IsWaste || len(node.Findings) != 1 || node.Findings[0].Heuristic != tc.name {
+				t.Errorf("Expected %s finding on the untagged resource, got %+v", tc.name, node.Findings)
+			}
+			if g.GetNode(kept).IsWaste {
+				t.Error("Expected the ignore-tagged resource not to be flagged")
+			}
+		})
+	}
+}
+
+// fakeRDSMetrics serves a maximum per DB instance and metric name. Metrics it
+// does not know read as zero; every request is counted by instance.
+type fakeRDSMetrics struct {
+	max   map[string]map[string]float64
+	calls map[string]int
+}
+
+func (f *fakeRDSMetrics) ListMetrics(ctx context.Context, in *cloudwatch.ListMetricsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.ListMetricsOutput, error) {
+	return &cloudwatch.ListMetricsOutput{}, nil
+}
+
+func (f *fakeRDSMetrics) GetMetricData(ctx context.Context, in *cloudwatch.GetMetricDataInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.GetMetricDataOutput, error) {
+	return &cloudwatch.GetMetricDataOutput{}, nil
+}
+
+func (f *fakeRDSMetrics) GetMetricStatistics(ctx context.Context, in *cloudwatch.GetMetricStatisticsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.GetMetricStatisticsOutput, error) {
+	id := aws.ToString(in.Dimensions[0].Value)
+	f.calls[id]++
+	v := f.max[id][aws.ToString(in.MetricName)]
+	return &cloudwatch.GetMetricStatisticsOutput{
+		Datapoints: []cwtypes.Datapoint{{Timestamp: in.EndTime, Maximum: aws.Float64(v), Sum: aws.Float64(v)}},
+	}, nil
+}
+
+func TestIdleReadReplicaHeuristic(t *testing.T) {
+	g := graph.NewGraph()
+	replica := func(id string) {
+		g.AddNode("arn:aws:rds:us-east-1:123456789012:db:"+id, "AWS::RDS::DBInstance", map[string]interface{}{
+			"IsReadReplica": true, "ReplicaSource": "orders", "InstanceClass": "db.r6g.large", "Engine": "postgres",
+		})
+	}
+	replica("orders-idle")
+	replica("orders-reporting")
+	// Primaries are RDSHeuristic's concern, however quiet they are.
+	g.AddNode("arn:aws:rds:us-east-1:123456789012:db:orders", "AWS::RDS::DBInstance", map[string]interface{}{
+		"InstanceClass": "db.r6g.large", "Engine": "postgres",
+	})
+	g.CloseAndWait()
+
+	api := &fakeRDSMetrics{
+		max: map[string]map[string]float64{
+			// Reporting jobs read through short-lived connections.
+			"orders-reporting": {"ReadIOPS": 850},
+		},
+		calls: make(map[string]int),
+	}
+	h := &IdleReadReplicaHeuristic{CW: &internalaws.CloudWatchClient{Client: api}}
+	stats, err := h.Run(context.Background(), g)
+	if err != nil {
+		t.Fatalf("Heuristic run failed: %v", err)
+	}
+	if stats.ItemsFound != 1 {
+		t.Fatalf("Expected 1 idle replica, got %d", stats.ItemsFound)
+	}
+
+	idle := g.GetNode("arn:aws:rds:us-east-1:123456789012:db:orders-idle")
+	if !idle.IsWaste {
+		t.Fatal("Expected replica with no connections or reads to be flagged")
+	}
+	if reason, _ := idle.Properties["Reason"].(string); !strings.Contains(reason, "Read replica of orders has 0 connections in 14 days") {
+		t.Errorf("Unexpected reason %q", reason)
+	}
+	if g.GetNode("arn:aws:rds:us-east-1:123456789012:db:orders-reporting").IsWaste {
+		t.Error("Expected replica serving reads not to be flagged")
+	}
+	if g.GetNode("arn:aws:rds:us-east-1:123456789012:db:orders").IsWaste || api.calls["orders"] != 0 {
+		t.Errorf("Expected the primary to be left alone, got %d metric reads", api.calls["orders"])
+	}
+}
+
+func TestThrottleTrackerReportsOnce(t *testing.T) {
+	g := graph.NewGraph()
+
+	var throttled throttleTracker
+	throttled.check(&smithy.GenericAPIError{Code: "ThrottlingException"})
+	throttled.check(&smithy.GenericAPIError{Code: "ThrottlingException"})
+	throttled.check(&smithy.GenericAPIError{Code: "AccessDenied"})
+	throttled.report(g, "RDSHeuristic")
+
+	if len(g.Metadata.FailedScopes) != 1 {
+		t.Fatalf("FailedScopes = %+v, want one entry", g.Metadata.FailedScopes)
+	}
+	if fs := g.Metadata.FailedScopes[0]; fs.Scope != "CloudWatch [RDSHeuristic]" || !strings.Contains(fs.Error, "2 resources") {
+		t.Errorf("FailedScopes[0] = %+v", fs)
+	}
+
+	var clean throttleTracker
+	clean.report(g, "ELBHeuristic")
+	if len(g.Metadata.FailedScopes) != 1 {
+		t.Errorf("a run without throttles recorded a failure")
+	}
+}
+
+func TestEncryptionComplianceHeuristic(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("vol-plain", "AWS::EC2::Volume", map[string]interface{}{"Encrypted": false})
+	g.AddNode("vol-kms", "AWS::EC2::Volume", map[string]interface{}{"Encrypted": true})
+	g.AddNode("vol-unknown", "AWS::EC2::Volume", map[string]interface{}{})
+	g.AddNode("db-plain", "AWS::RDS::DBInstance", map[string]interface{}{"StorageEncrypted": false})
+	g.AddNode("db-kms", "AWS::RDS::DBInstance", map[string]interface{}{"StorageEncrypted": true})
+	g.AddNode("arn:aws:s3:::plain", "AWS::S3::Bucket", map[string]interface{}{"DefaultEncryption": ""})
+	g.AddNode("arn:aws:s3:::sse", "AWS::S3::Bucket", map[string]interface{}{"DefaultEncryption": "AES256"})
+	g.AddNode("arn:aws:s3:::unread", "AWS::S3::Bucket", map[string]interface{}{})
+	g.CloseAndWait()
+	g.MarkWaste("vol-plain", 60)
+	g.GetNode("vol-plain").Cost = 8
+
+	stats, err := (&EncryptionComplianceHeuristic{}).Run(context.Background(), g)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if stats.ItemsFound != 3 || stats.ProjectedSavings != 0 {
+		t.Errorf("Expected 3 violations and no savings, got %+v", stats)
+	}
+
+	for id, want := range map[string]bool{
+		"vol-plain": true, "vol-kms": false, "vol-unknown": false,
+		"db-plain": true, "db-kms": false,
+		"arn:aws:s3:::plain": true, "arn:aws:s3:::sse": false, "arn:aws:s3:::unread": false,
+	} {
+		node := g.GetNode(id)
+		if node.ComplianceViolation != want {
+			t.Errorf("%s: expected violation %v, got %v (%q)", id, want, node.ComplianceViolation, node.ComplianceReason)
+		}
+		if id != "vol-plain" && node.IsWaste {
+			t.Errorf("%s: compliance must not mark waste", id)
+		}
+	}
+	if vol := g.GetNode("vol-plain"); vol.Cost != 8 || vol.ComplianceReason != "EBS volume is not encrypted" {
+		t.Errorf("Expected waste cost kept and reason set, got %.2f %q", vol.Cost, vol.ComplianceReason)
+	}
+}
+
+func TestTagComplianceKeepsEarlierFinding(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("i-idle", "AWS::EC2::Instance", map[string]interface{}{"Tags": map[string]string{"Name": "batch"}})
+	g.CloseAndWait()
+	g.AddFinding("i-idle", graph.Finding{Heuristic: "UnderutilizedInstanceHeuristic", Reason: "Right-Sizing Opportunity", Score: 60, Savings: 25})
+
+	h := &TagComplianceHeuristic{RequiredTags: []string{"Owner"}}
+	if _, err := h.Run(context.Background(), g); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	node := g.GetNode("i-idle")
+	if len(node.Findings) != 2 || node.Findings[0].Heuristic != "UnderutilizedInstanceHeuristic" || node.Findings[1].Heuristic != "TagComplianceHeuristic" {
+		t.Fatalf("Expected both heuristics attributed, got %+v", node.Findings)
+	}
+	if node.RiskScore != 60 || node.Cost != 25 {
+		t.Errorf("Expected aggregates to keep the stronger finding, got risk=%d cost=%.2f", node.RiskScore, node.Cost)
+	}
+	if reason := node.Properties["Reason"]; reason != "Right-Sizing Opportunity; Compliance Violation: Missing Tags: Owner" {
+		t.Errorf("Unexpected reason %q", reason)
+	}
+}
+
+func TestIdleGCPInstanceHeuristic(t *testing.T) {
+	g := graph.NewGraph()
+	vm := func(name, state string, peak interface{}) {
+		props := map[string]interface{}{"Name": name, "State": state, "Type": "n2-standard-4"}
+		if peak != nil {
+			props["PeakCPUPercent"] = peak
+		}
+		g.AddNode("https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/"+name, "GCP::Compute::Instance", props)
+	}
+	vm("idle", "running", 1.5)
+	vm("busy", "running", 62.0)
+	vm("unmeasured", "running", nil)
+	vm("stopped", "stopped", 0.0)
+	g.CloseAndWait()
+
+	stats, err := (&IdleGCPInstanceHeuristic{}).Run(context.Background(), g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.ItemsFound != 1 {
+		t.Fatalf("Expected 1 idle VM, got %d", stats.ItemsFound)
+	}
+	idle := g.GetNode("https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/idle")
+	// 4 vCPUs at $0.0486/hour.
+	if !idle.IsWaste || idle.Cost < 141 || idle.Cost > 142 {
+		t.Errorf("idle VM: waste=%v cost=%.2f", idle.IsWaste, idle.Cost)
+	}
+	if reason, _ := idle.Properties["Reason"].(string); !strings.Contains(reason, "Idle GCP VM") {
+		t.Errorf("Unexpected reason %q", reason)
+	}
+}
@@ -0,0 +1,183 @@
+package heuristics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	internalaws "github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/pricing"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+const efsIdleWindow = 7 * 24 * time.Hour
+
+// IdleEFSHeuristic flags file systems nobody mounts: no mount targets at all,
+// or no client connections over the CloudWatch window.
+// Storage and provisioned throughput bill either way.
+type IdleEFSHeuristic struct {
+	CW      *internalaws.CloudWatchClient
+	Pricing *pricing.Client
+	Window  time.Duration // Metric lookback; zero means efsIdleWindow.
+}
+
+func (h *IdleEFSHeuristic) Name() string { return "IdleEFSHeuristic" }
+
+// efsIdle is the evidence for one idle file system.
+type efsIdle struct {
+	NoMountTargets bool
+	Cost           float64
+}
+
+func (h *IdleEFSHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	type candidate struct {
+		id, fsID, region          string
+		mounts                    int32
+		standard, ia, archive, pt float64
+		cw                        *internalaws.CloudWatchClient
+	}
+	now := time.Now()
+	window := metricWindow(h.Window, efsIdleWindow)
+	var candidates []candidate
+	g.Mu.RLock()
+	for _, node := range g.Store.GetAllNodes() {
+		if node.TypeStr() != "AWS::EFS::FileSystem" {
+			continue
+		}
+		if tiering, _ := node.Properties["LifecycleRecommendation"].(bool); node.IsWaste && !tiering {
+			continue
+		}
+		if state, _ := node.Properties["State"].(string); state != "available" {
+			continue
+		}
+		// Too new to judge.
+		if created, ok := node.CreatedAt(); ok && now.Sub(created) < window {
+			continue
+		}
+		c := candidate{id: node.IDStr()}
+		c.fsID, _ = node.Properties["FileSystemId"].(string)
+		c.region, _ = node.Properties["Region"].(string)
+		c.mounts, _ = node.Properties["NumberOfMountTargets"].(int32)
+		c.standard = bytesToGB(node.Properties["SizeStandardBytes"])
+		c.ia = bytesToGB(node.Properties["SizeIABytes"])
+		c.archive = bytesToGB(node.Properties["SizeArchiveBytes"])
+		c.pt, _ = node.Properties["ProvisionedThroughput"].(float64)
+		c.cw = scopedCW(h.CW, node)
+		candidates = append(candidates, c)
+	}
+	g.Mu.RUnlock()
+
+	// Metric and pricing calls hit the network; resolve them outside the lock.
+	idle := make(map[string]efsIdle)
+	for _, c := range candidates {
+		if c.mounts > 0 {
+			if c.cw == nil || c.fsID == "" {
+				continue
+			}
+			idleFS, err := efsUnconnected(ctx, c.cw, c.fsID, now.Add(-window), now)
+			if err != nil || !idleFS {
+				continue
+			}
+		}
+
+		cost := pricing.EstimateEFSPrice(c.standard, c.ia, c.archive, c.pt)
+		if h.Pricing != nil && c.region != "" {
+			if price, err := h.Pricing.GetEFSPrice(ctx, c.region, c.standard, c.ia, c.archive, c.pt); err == nil {
+				cost = price
+			}
+		}
+		idle[c.id] = efsIdle{NoMountTargets: c.mounts == 0, Cost: cost}
+	}
+
+	return applyIdleEFS(g, idle, window), nil
+}
+
+// efsUnconnected reports whether a file system had no client connections.
+// EFS publishes ClientConnections only while clients are connected, so a
+// window without datapoints means zero connections only when StorageBytes,
+// which EFS always publishes, proves the read reached the file system's
+// metrics. Otherwise the connections are unknown and an error is returned.
+func efsUnconnected(ctx context.Context, cw *internalaws.CloudWatchClient, fsID string, start, end time.Time) (bool, error) {
+	dims := []types.Dimension{{Name: aws.String("FileSystemId"), Value: aws.String(fsID)}}
+	conns, err := cw.GetMetricSumObserved(ctx, "AWS/EFS", "ClientConnections", dims, start, end)
+	if err == nil {
+		return conns == 0, nil
+	}
+	if !errors.Is(err, internalaws.ErrNoDatapoints) {
+		return false, err
+	}
+	storage := append(dims, types.Dimension{Name: aws.String("StorageClass"), Value: aws.String("Total")})
+	if _, err := cw.GetMetricMaxObserved(ctx, "AWS/EFS", "StorageBytes", storage, start, end); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// applyIdleEFS marks idle file systems as waste. Deletion outranks a lifecycle
+// (tiering) recommendation, so those are replaced. window is the lookback
+// client connections were measured over.
+func applyIdleEFS(g *graph.Graph, idle map[string]efsIdle, window time.Duration) *HeuristicStats {
+	stats := &HeuristicStats{}
+
+	// Evidence goes on the node first, so the waste listener sees it.
+	var pending []pendingFinding
+	g.Mu.Lock()
+	for id, ev := range idle {
+		node := g.GetNode(id)
+		if node == nil {
+			continue
+		}
+		if node.IsWaste {
+			if tiering, _ := node.Properties["LifecycleRecommendation"].(bool); !tiering {
+				continue
+			}
+			clearLifecycleFinding(node)
+		}
+
+		name, _ := node.Properties["Name"].(string)
+		if name == "" {
+			name, _ = node.Properties["FileSystemId"].(string)
+		}
+
+		finding := graph.Finding{Heuristic: "IdleEFSHeuristic", Savings: ev.Cost}
+		if ev.NoMountTargets {
+			finding.Score = 80
+			finding.Reason = fmt.Sprintf("Idle EFS: %s has no mount targets; nothing can mount it ($%.2f/mo).", name, ev.Cost)
+		} else {
+			finding.Score = 60
+			node.Properties["ClientConnections"] = 0
+			finding.Reason = fmt.Sprintf("Idle EFS: %s had 0 client connections in %s ($%.2f/mo).", name, windowLabel(window), ev.Cost)
+		}
+		pending = append(pending, pendingFinding{id, finding})
+	}
+	g.Mu.Unlock()
+
+	for _, f := range pending {
+		stats.record(g, f.id, f.Finding)
+	}
+	return stats
+}
+
+// clearLifecycleFinding withdraws a tiering recommendation so a deletion
+// finding can take its place with its own score and cost. The caller holds g.Mu.
+func clearLifecycleFinding(node *graph.Node) {
+	delete(node.Properties, "LifecycleRecommendation")
+	delete(node.Properties, "FixRecommendation")
+	delete(node.Properties, "Reason")
+	kept := node.Findings[:0]
+	for _, f := range node.Findings {
+		if f.Heuristic != (&EFSLifecycleHeuristic{}).Name() {
+			kept = append(kept, f)
+		}
+	}
+	node.Findings = kept
+	node.IsWaste, node.RiskScore, node.Cost = false, 0, 0
+}
+
+func bytesToGB(v interface{}) float64 {
+	b, _ := v.(int64)
+	return float64(b) / (1 << 30)
+}
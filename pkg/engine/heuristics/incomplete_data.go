@@ -0,0 +1,40 @@
+package heuristics
+
+import (
+	"fmt"
+	"strings"
+
+	internalaws "github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// incompleteDataPenalty is taken off the risk score of findings built on partial data.
+// It moves most findings below 50, so they land in REVIEW rather than the cleanup scripts.
+const incompleteDataPenalty = 30
+
+// DiscountIncompleteData lowers the confidence of findings on resources where a
+// describe call was denied (Properties["_errors"]); the finding may rest on a
+// property that was never read. Run it after all heuristics. Returns the number
+// of findings downgraded.
+func DiscountIncompleteData(g *graph.Graph) int {
+	g.Mu.Lock()
+	defer g.Mu.Unlock()
+
+	downgraded := 0
+	for _, node := range g.Store.GetAllNodes() {
+		errs, _ := node.Properties[internalaws.PropertyErrorsKey].([]string)
+		if !node.IsWaste || len(errs) == 0 {
+			continue
+		}
+		if done, _ := node.Properties["IncompleteData"].(bool); done {
+			continue
+		}
+
+		node.RiskScore = max(node.RiskScore-incompleteDataPenalty, 0)
+		node.Properties["IncompleteData"] = true
+		reason, _ := node.Properties["Reason"].(string)
+		node.Properties["Reason"] = strings.TrimPrefix(fmt.Sprintf("%s (incomplete data: %s)", reason, strings.Join(errs, ", ")), " ")
+		downgraded++
+	}
+	return downgraded
+}
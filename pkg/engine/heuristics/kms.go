@@ -0,0 +1,187 @@
+package heuristics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/pricing"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// kmsUnusedWindow is how long an enabled key must go without a cryptographic
+// operation; it is also CloudTrail's event history.
+const kmsUnusedWindow = 90 * 24 * time.Hour
+
+// KMS findings, recorded as KMSFinding.
+const (
+	kmsFindingUnused          = "unused"
+	kmsFindingPendingDeletion = "pending-deletion"
+	kmsFindingPendingImport   = "pending-import"
+)
+
+// KMSKeyHeuristic flags customer managed keys that still bill monthly but
+// protect nothing: enabled keys with no cryptographic use in 90 days
+// (forensics.Detective.TraceKeyUsage) and no scanned resource referencing
+// them (aws.LinkKMSKeys), and keys whose imported material never arrived.
+// Keys scheduled for deletion are reported separately, at no cost, so a
+// deletion that would strand referenced data is caught in time.
+type KMSKeyHeuristic struct {
+	Pricing *pricing.Client
+	Region  string // Scan region; prices keys that carry no region of their own.
+}
+
+func (h *KMSKeyHeuristic) Name() string { return "KMSKeyHeuristic" }
+
+// kmsFinding is the evidence for one flagged key.
+type kmsFinding struct {
+	Kind       string
+	LastUsed   time.Time // Zero when not used in the window.
+	References int       // Scanned resources encrypted with the key.
+	Cost       float64
+}
+
+func (h *KMSKeyHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	type candidate struct {
+		id, region string
+		rotations  int
+		finding    kmsFinding
+	}
+
+	now := time.Now()
+	var candidates []candidate
+	g.Mu.RLock()
+	for _, node := range g.Store.GetAllNodes() {
+		if node.TypeStr() != "AWS::KMS::Key" || node.IsWaste {
+			continue
+		}
+		if manager, _ := node.Properties["KeyManager"].(string); manager != "" && manager != "CUSTOMER" {
+			continue
+		}
+		c := candidate{id: node.IDStr(), region: NodeRegion(node, h.Region)}
+		c.rotations, _ = node.Properties["Rotations"].(int)
+		for _, edge := range g.Store.GetReverseEdges(node.Index) {
+			if edge.Type == graph.EdgeTypeUses {
+				c.finding.References++
+			}
+		}
+
+		switch state, _ := node.Properties["KeyState"].(string); state {
+		case "PendingDeletion":
+			c.finding.Kind = kmsFindingPendingDeletion
+		case "PendingImport":
+			c.finding.Kind = kmsFindingPendingImport
+		case "Enabled":
+			if c.finding.References > 0 {
+				continue
+			}
+			// Only CloudTrail can say a key is unused, and only for 90 days back.
+			if traced, _ := node.Properties["UsageTraced"].(bool); !traced {
+				continue
+			}
+			if created, ok := node.CreatedAt(); ok && now.Sub(created) < kmsUnusedWindow {
+				continue
+			}
+			if last, ok := node.Properties["LastUsed"].(time.Time); ok {
+				if now.Sub(last) < kmsUnusedWindow {
+					continue
+				}
+				c.finding.LastUsed = last
+			}
+			c.finding.Kind = kmsFindingUnused
+		default:
+			continue
+		}
+		candidates = append(candidates, c)
+	}
+	g.Mu.RUnlock()
+
+	findings := make(map[string]kmsFinding)
+	for _, c := range candidates {
+		// A key pending deletion is no longer billed.
+		if c.finding.Kind != kmsFindingPendingDeletion {
+			c.finding.Cost = h.keyPrice(ctx, c.region, c.rotations)
+		}
+		findings[c.id] = c.finding
+	}
+
+	return applyKMSKeys(g, findings), nil
+}
+
+func (h *KMSKeyHeuristic) keyPrice(ctx context.Context, region string, rotations int) float64 {
+	if h.Pricing != nil {
+		if p, err := h.Pricing.GetKMSKeyPrice(ctx, region, rotations); err == nil {
+			return p
+		}
+	}
+	return pricing.EstimateKMSKeyPrice(rotations)
+}
+
+// applyKMSKeys marks flagged keys. Every finding stays at or below the review
+// threshold: a deleted key cannot be recovered, so nothing here may be
+// remediated unattended. Keys pending deletion cost nothing; one still
+// encrypting scanned resources ranks above the others.
+func applyKMSKeys(g *graph.Graph, findings map[string]kmsFinding) *HeuristicStats {
+	stats := &HeuristicStats{}
+
+	// Evidence goes on the node first, so the waste listener sees it.
+	var pending []pendingFinding
+	g.Mu.Lock()
+	for id, f := range findings {
+		node := g.GetNode(id)
+		if node == nil || node.IsWaste {
+			continue
+		}
+		name := kmsKeyName(node)
+		finding := graph.Finding{Heuristic: "KMSKeyHeuristic", Savings: f.Cost}
+
+		switch f.Kind {
+		case kmsFindingUnused:
+			last := "no use in 90 days"
+			if !f.LastUsed.IsZero() {
+				last = "last used " + f.LastUsed.Format("2006-01-02")
+			}
+			// Data outside the scan (S3 objects, secrets, backups) may still need the key.
+			finding.Score = 40
+			finding.Reason = fmt.Sprintf("Unused KMS Key: %s has %s and no scanned resource uses it ($%.2f/mo). Recommendation: Disable the key, then schedule deletion once nothing breaks.",
+				name, last, f.Cost)
+		case kmsFindingPendingImport:
+			finding.Score = 35
+			finding.Reason = fmt.Sprintf("KMS Key Pending Import: %s was created for external key material that was never imported; it cannot be used ($%.2f/mo). Recommendation: Import the material or schedule deletion.",
+				name, f.Cost)
+		case kmsFindingPendingDeletion:
+			when := "soon"
+			if t, ok := node.Properties["DeletionDate"].(time.Time); ok {
+				when = "on " + t.Format("2006-01-02")
+			}
+			finding.Score = 20
+			if f.References > 0 {
+				finding.Score = 45
+				finding.Reason = fmt.Sprintf("KMS Key Pending Deletion: %s is deleted %s but %d scanned resource(s) are encrypted with it. Recommendation: Cancel the deletion or re-encrypt them first.",
+					name, when, f.References)
+			} else {
+				finding.Reason = fmt.Sprintf("KMS Key Pending Deletion: %s is deleted %s; no scanned resource uses it.", name, when)
+			}
+		}
+		node.Properties["KMSFinding"] = f.Kind
+		node.Properties["KeyReferences"] = f.References
+		pending = append(pending, pendingFinding{id, finding})
+	}
+	g.Mu.Unlock()
+
+	for _, f := range pending {
+		stats.record(g, f.id, f.Finding)
+	}
+	return stats
+}
+
+// kmsKeyName is the key's first alias, or its key ID.
+func kmsKeyName(node *graph.Node) string {
+	if aliases, _ := node.Properties["Aliases"].([]string); len(aliases) > 0 {
+		return aliases[0]
+	}
+	if id, _ := node.Properties["KeyId"].(string); id != "" {
+		return id
+	}
+	return node.IDStr()
+}
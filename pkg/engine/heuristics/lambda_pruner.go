@@ -3,11 +3,26 @@ package heuristics
 import (
 	"context"
 	"fmt"
+	"math"
+	"strings"
 	"time"
 
+	internalaws "github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/pricing"
 	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
 )
 
+const (
+	// lambdaIdlePCUtilization is the 30-day average provisioned-concurrency
+	// utilization below which the provisioned environments are mostly idle.
+	lambdaIdlePCUtilization = 0.2
+	// lambdaPCHeadroom is kept above the observed peak when sizing concurrency down.
+	lambdaPCHeadroom = 1.2
+)
+
+// LambdaHeuristic flags dead functions (no invocations in 30 days), provisioned
+// concurrency that mostly sits idle, and functions hoarding old versions.
+// Metrics come from LambdaScanner; functions without them are left alone.
 type LambdaHeuristic struct{}
 
 func (h *LambdaHeuristic) Name() string {
@@ -20,38 +35,71 @@ func (h *LambdaHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicSt
 
 func (h *LambdaHeuristic) Analyze(g *graph.Graph) *HeuristicStats {
 	stats := &HeuristicStats{}
+	var pending []pendingFinding
+	// Evidence goes on the node first, so the waste listener sees it.
 	g.Mu.Lock()
-	defer g.Mu.Unlock()
-
 	for _, node := range g.Store.GetAllNodes() {
 		if node.TypeStr() == "aws_lambda_function" {
-			if h.analyzeFunction(node) {
-				stats.ItemsFound++
-				// Lambda cost is harder to project
+			if finding, ok := h.analyzeFunction(node); ok {
+				pending = append(pending, pendingFinding{node.IDStr(), finding})
 			}
 		}
 	}
+	g.Mu.Unlock()
+
+	for _, f := range pending {
+		stats.record(g, f.id, f.Finding)
+	}
 	return stats
 }
 
-func (h *LambdaHeuristic) analyzeFunction(node *graph.Node) bool {
-	// Check inactivity.
-	invocations := getFloat(node, "SumInvocations90d")
-	lastModStr, _ := node.Properties["LastModified"].(string)
+func (h *LambdaHeuristic) analyzeFunction(node *graph.Node) (graph.Finding, bool) {
+	memoryMB := intProperty(node, "MemorySize")
+	arch, _ := node.Properties["Architecture"].(string)
+	concurrency := intProperty(node, "ProvisionedConcurrency")
+	pcMonthly := pricing.EstimateLambdaProvisionedConcurrencyMonthly(memoryMB, arch, concurrency)
+
+	invocations, hasMetrics := node.Properties["Invocations30d"].(float64)
+	if hasMetrics {
+		// The last 30 days stand in for a month.
+		node.Properties["EstimatedMonthlyCost"] = pricing.EstimateLambdaInvocationCost(memoryMB, arch, invocations, getFloat(node, "DurationMs30d")) + pcMonthly
+	}
 
-	// Parse time.
+	lastModStr, _ := node.Properties["LastModified"].(string)
 	lastMod, err := time.Parse("2006-01-02T15:04:05.000+0000", lastModStr)
 	if err != nil {
 		// Fallback RFC3339.
 		lastMod, _ = time.Parse(time.RFC3339, lastModStr)
 	}
 
-	isOld := time.Since(lastMod) > 90*24*time.Hour
+	var reasons []string
+	risk := 100
+	waste := 0.0
 
-	if isOld && invocations == 0 {
-		node.IsWaste = true
-		node.RiskScore = 8
-		node.Justification = "Inactive Function: 0 Invocations in 90d. Last Modified > 90d."
+	if hasMetrics && invocations == 0 && time.Since(lastMod) > internalaws.LambdaIdleWindow {
+		window := 30
+		if sum90, ok := node.Properties["SumInvocations90d"].(float64); ok && sum90 == 0 {
+			window = 90
+		}
+		reason := fmt.Sprintf("Dead function: 0 invocations in %d days, last modified %s.", window, lastMod.Format("2006-01-02"))
+		if concurrency > 0 {
+			reason += fmt.Sprintf(" Its %d provisioned concurrency still bills $%.2f/mo.", concurrency, pcMonthly)
+			waste += pcMonthly
+		}
+		node.Properties["DeadFunction"] = true
+		reasons = append(reasons, reason)
+		risk = min(risk, 8)
+	} else if avg, ok := node.Properties["PCUtilizationAvg30d"].(float64); ok && concurrency > 0 && avg < lambdaIdlePCUtilization {
+		peak := getFloat(node, "PCUtilizationMax30d")
+		recommended := int(math.Ceil(peak * float64(concurrency) * lambdaPCHeadroom))
+		if recommended < concurrency {
+			savings := pcMonthly - pricing.EstimateLambdaProvisionedConcurrencyMonthly(memoryMB, arch, recommended)
+			node.Properties["RecommendedProvisionedConcurrency"] = recommended
+			reasons = append(reasons, fmt.Sprintf("Over-provisioned concurrency: %d provisioned, %.0f%% used on average, peak %.0f%% (30d). Reduce to %d to save $%.2f/mo.",
+				concurrency, avg*100, peak*100, recommended, savings))
+			waste += savings
+			risk = min(risk, 40)
+		}
 	}
 
 	// Identify stale versions.
@@ -69,12 +117,30 @@ func (h *LambdaHeuristic) analyzeFunction(node *graph.Node) bool {
 		pruneCount++
 	}
 	if pruneCount > 5 {
-		node.IsWaste = true
-		wasteGB := (float64(pruneCount) * getFloat(node, "CodeSize")) / (1024 * 1024 * 1024)
-		if wasteGB > 0.1 {
-			node.Justification += fmt.Sprintf(" Excess storage: %d old versions (%.2f GB) can be pruned.", pruneCount, wasteGB)
+		reason := fmt.Sprintf("Excess versions: %d unaliased versions can be pruned.", pruneCount)
+		codeSize, _ := node.Properties["CodeSize"].(int64)
+		if wasteGB := float64(int64(pruneCount)*codeSize) / (1024 * 1024 * 1024); wasteGB > 0.1 {
+			reason = fmt.Sprintf("Excess versions: %d unaliased versions (%.2f GB) can be pruned.", pruneCount, wasteGB)
 		}
-		return true // Waste found.
+		reasons = append(reasons, reason)
+		// The function may be in use; pruning is for review, never a delete.
+		risk = min(risk, 40)
+	}
+
+	if len(reasons) == 0 {
+		return graph.Finding{}, false
+	}
+	return graph.Finding{
+		Heuristic: h.Name(),
+		Reason:    strings.Join(reasons, " "),
+		Score:     risk,
+		Savings:   waste,
+	}, true
+}
+
+func getFloat(n *graph.Node, key string) float64 {
+	if v, ok := n.Properties[key].(float64); ok {
+		return v
 	}
-	return false
+	return 0.0
 }
@@ -0,0 +1,94 @@
+package heuristics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	internalaws "github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// IdleMessagingHeuristic flags SQS queues that sent and received nothing, and
+// SNS topics with no subscriptions or no publishes, over the scanner's metric
+// window. Both cost next to nothing; the finding is clutter and the risk of a
+// zombie producer, so the risk score keeps them in review.
+type IdleMessagingHeuristic struct {
+	Window time.Duration // Scanner's metric lookback; zero means internalaws.MessagingMetricWindow.
+}
+
+func (h *IdleMessagingHeuristic) Name() string { return "IdleMessagingHeuristic" }
+
+func (h *IdleMessagingHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	return applyIdleMessaging(g, time.Now(), metricWindow(h.Window, internalaws.MessagingMetricWindow)), nil
+}
+
+func applyIdleMessaging(g *graph.Graph, now time.Time, window time.Duration) *HeuristicStats {
+	stats := &HeuristicStats{}
+
+	type finding struct {
+		id     string
+		reason string
+	}
+	var findings []finding
+
+	g.Mu.RLock()
+	nodes := g.Store.GetAllNodes()
+	// A dead-letter queue is meant to stay empty.
+	deadLetter := make(map[string]bool)
+	for _, node := range nodes {
+		if dlq, ok := node.Properties["DeadLetterQueue"].(string); ok {
+			deadLetter[dlq] = true
+		}
+	}
+
+	for _, node := range nodes {
+		if node.IsWaste {
+			continue
+		}
+		var reason string
+		switch node.TypeStr() {
+		case "AWS::SQS::Queue":
+			if deadLetter[node.IDStr()] {
+				continue
+			}
+			if created, ok := node.Properties["CreatedTimestamp"].(time.Time); ok && now.Sub(created) < window {
+				continue
+			}
+			sent, okSent := node.Properties["MessagesSent"].(float64)
+			received, okReceived := node.Properties["MessagesReceived"].(float64)
+			if !okSent || !okReceived || sent != 0 || received != 0 {
+				continue
+			}
+			name, _ := node.Properties["QueueName"].(string)
+			backlog, _ := node.Properties["ApproximateNumberOfMessages"].(int)
+			reason = fmt.Sprintf("Idle SQS Queue: %s had no messages sent or received in %s (%d messages waiting).",
+				name, windowLabel(window), backlog)
+
+		case "AWS::SNS::Topic":
+			name, _ := node.Properties["TopicName"].(string)
+			subs, okSubs := node.Properties["SubscriptionCount"].(int)
+			published, okPublished := node.Properties["MessagesPublished"].(float64)
+			switch {
+			case okSubs && subs == 0:
+				reason = fmt.Sprintf("Unused SNS Topic: %s has no subscriptions; anything published to it is dropped.", name)
+			case okPublished && published == 0:
+				reason = fmt.Sprintf("Idle SNS Topic: %s published no messages in %s (%d subscriptions).",
+					name, windowLabel(window), subs)
+			default:
+				continue
+			}
+
+		default:
+			continue
+		}
+
+		findings = append(findings, finding{id: node.IDStr(), reason: reason})
+	}
+	g.Mu.RUnlock()
+
+	for _, f := range findings {
+		stats.record(g, f.id, graph.Finding{Heuristic: "IdleMessagingHeuristic", Reason: f.reason, Score: 40})
+	}
+	return stats
+}
@@ -0,0 +1,32 @@
+package heuristics
+
+import (
+	"fmt"
+
+	internalaws "github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// throttleTracker counts resources skipped because CloudWatch kept throttling
+// after retries. Skipping one is not a finding, but the scan is incomplete,
+// so report records it on the graph once per heuristic run.
+type throttleTracker struct {
+	skipped int
+}
+
+// check notes err if it is a throttle. Other metric errors stay silent: the
+// metric may simply not exist for the resource.
+func (t *throttleTracker) check(err error) {
+	if internalaws.IsThrottleError(err) {
+		t.skipped++
+	}
+}
+
+// report adds a failed scope to g if anything was skipped. g.Mu must not be held.
+func (t *throttleTracker) report(g *graph.Graph, heuristic string) {
+	if t.skipped == 0 {
+		return
+	}
+	g.AddError(fmt.Sprintf("CloudWatch [%s]", heuristic),
+		fmt.Errorf("throttled after retries; metrics missing for %d resources", t.skipped))
+}
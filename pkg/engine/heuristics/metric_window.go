@@ -0,0 +1,31 @@
+package heuristics
+
+import (
+	"fmt"
+	"time"
+)
+
+// metricWindow is the CloudWatch lookback a heuristic uses: the configured
+// window (--metric-window), or the heuristic's own default when none is set.
+func metricWindow(configured, def time.Duration) time.Duration {
+	if configured > 0 {
+		return configured
+	}
+	return def
+}
+
+// windowLabel renders a lookback window for a finding reason ("7 days"),
+// in hours when the window is shorter than a day.
+func windowLabel(w time.Duration) string {
+	if w < 24*time.Hour {
+		return plural(int(w.Hours()), "hour")
+	}
+	return plural(int(w.Hours()/24), "day")
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
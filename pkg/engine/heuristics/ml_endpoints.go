@@ -0,0 +1,132 @@
+package heuristics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	internalaws "github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+const (
+	mlIdleWindow = 7 * 24 * time.Hour
+	// mlPriceRegion is the region the scanner's hourly prices are for.
+	mlPriceRegion = "us-east-1"
+)
+
+// IdleMLEndpointHeuristic flags provisioned ML endpoints (SageMaker, Comprehend,
+// Rekognition Custom Labels) that served no requests in the lookback window
+// (a week by default). Endpoints bill per hour whether or not they are called.
+// Hourly costs come from us-east-1 list prices, so costs elsewhere are
+// labelled as estimates.
+type IdleMLEndpointHeuristic struct {
+	CW     *internalaws.CloudWatchClient
+	Window time.Duration // Metric lookback; zero means mlIdleWindow.
+}
+
+func (h *IdleMLEndpointHeuristic) Name() string { return "IdleMLEndpointHeuristic" }
+
+func (h *IdleMLEndpointHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	if h.CW == nil {
+		return &HeuristicStats{}, nil
+	}
+
+	type candidate struct {
+		id        string
+		namespace string
+		metric    string
+		dims      []string
+		cw        *internalaws.CloudWatchClient
+	}
+	now := time.Now()
+	window := metricWindow(h.Window, mlIdleWindow)
+	var candidates []candidate
+	g.Mu.RLock()
+	for _, node := range g.Store.GetAllNodes() {
+		if ok, _ := node.Properties["MLEndpoint"].(bool); !ok {
+			continue
+		}
+		// Too new to judge.
+		if created, ok := node.CreatedAt(); ok && now.Sub(created) < window {
+			continue
+		}
+		namespace, _ := node.Properties["MetricNamespace"].(string)
+		metric, _ := node.Properties["MetricName"].(string)
+		dims, _ := node.Properties["MetricDimensions"].([]string)
+		if namespace == "" || metric == "" || len(dims) == 0 {
+			continue
+		}
+		candidates = append(candidates, candidate{node.IDStr(), namespace, metric, dims, scopedCW(h.CW, node)})
+	}
+	g.Mu.RUnlock()
+
+	// Only endpoints with a successful metric read are judged; missing data is not idleness.
+	usage := make(map[string]float64)
+	start := now.Add(-window)
+	for _, c := range candidates {
+		total := 0.0
+		failed := false
+		for _, set := range c.dims {
+			sum, err := c.cw.GetMetricSum(ctx, c.namespace, c.metric, internalaws.DecodeMetricDimensions(set), start, now)
+			if err != nil {
+				failed = true
+				break
+			}
+			total += sum
+		}
+		if !failed {
+			usage[c.id] = total
+		}
+	}
+
+	return applyIdleMLEndpoints(g, usage, window), nil
+}
+
+// applyIdleMLEndpoints flags endpoints whose usage is zero.
+// They are review items: an endpoint may back a rarely used but critical path.
+// window is the lookback usage was measured over.
+func applyIdleMLEndpoints(g *graph.Graph, usage map[string]float64, window time.Duration) *HeuristicStats {
+	stats := &HeuristicStats{}
+
+	// Evidence goes on the node first, so the waste listener sees it.
+	var pending []pendingFinding
+	g.Mu.Lock()
+	for id, requests := range usage {
+		if requests > 0 {
+			continue
+		}
+		node := g.GetNode(id)
+		if node == nil || node.IsWaste {
+			continue
+		}
+
+		hourly, _ := node.Properties["HourlyCost"].(float64)
+		units, _ := node.Properties["Units"].(int)
+		unitType, _ := node.Properties["UnitType"].(string)
+		service, _ := node.Properties["Service"].(string)
+		name, _ := node.Properties["Name"].(string)
+
+		price := fmt.Sprintf("$%.2f/hr", hourly)
+		if region, _ := node.Properties["Region"].(string); region != mlPriceRegion {
+			// List prices vary by region; the figure is a us-east-1 estimate.
+			node.Properties["CostEstimated"] = true
+			price = fmt.Sprintf("~$%.2f/hr (%s estimate)", hourly, mlPriceRegion)
+		}
+
+		cost := hourly * 730
+		pending = append(pending, pendingFinding{id, graph.Finding{
+			Heuristic: "IdleMLEndpointHeuristic",
+			Reason: fmt.Sprintf("Idle ML Endpoint: %s endpoint %s served 0 requests in %s but keeps %d x %s provisioned at %s ($%.2f/mo).",
+				service, name, windowLabel(window), units, unitType, price, cost),
+			Score:   40,
+			Savings: cost,
+		}})
+	}
+	g.Mu.Unlock()
+
+	for _, f := range pending {
+		stats.record(g, f.id, f.Finding)
+	}
+	return stats
+}
@@ -0,0 +1,147 @@
+package heuristics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/pricing"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+const (
+	natInstanceType        = "t4g.nano"
+	natInstanceMonthlyCost = 3.07  // t4g.nano on-demand, us-east-1 (fallback).
+	natProcessingPerGB     = 0.045 // NAT Gateway data processing charge.
+	natInstanceMaxGBMonth  = 100.0 // Above this, recommend only for tagged non-prod VPCs.
+)
+
+// environmentTagKeys are checked in order on the NAT gateway, then its VPC.
+var environmentTagKeys = []string{"Environment", "environment", "Env", "env", "Stage", "stage"}
+
+// NATInstanceHeuristic recommends a self-managed NAT instance (fck-nat) in place
+// of a managed NAT Gateway for dev and low-traffic VPCs.
+// A single instance is not highly available, so production is never recommended.
+// Runs after idle detection; idle gateways are already flagged for deletion.
+type NATInstanceHeuristic struct {
+	Pricing *pricing.Client
+	Region  string // Scan region; prices nodes that carry no region of their own.
+}
+
+func (h *NATInstanceHeuristic) Name() string { return "NATInstanceHeuristic" }
+
+func (h *NATInstanceHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	stats := &HeuristicStats{}
+
+	type candidate struct {
+		node     *graph.Node
+		region   string
+		env      string
+		gbPerMon float64
+	}
+	var candidates []candidate
+
+	g.Mu.RLock()
+	vpcTags := make(map[string]map[string]string)
+	for _, node := range g.Store.GetAllNodes() {
+		if node.TypeStr() == "AWS::EC2::VPC" {
+			id, _ := node.Properties["VpcId"].(string)
+			tags, _ := node.Properties["Tags"].(map[string]string)
+			vpcTags[id] = tags
+		}
+	}
+
+	for _, node := range g.Store.GetAllNodes() {
+		if node.IsWaste || (node.TypeStr() != "aws_nat_gateway" && node.TypeStr() != "AWS::EC2::NatGateway") {
+			continue
+		}
+
+		natTags, _ := node.Properties["Tags"].(map[string]string)
+		vpcID, _ := node.Properties["VpcId"].(string)
+		env := environmentOf(natTags, vpcTags[vpcID])
+		if IsProductionEnv(env) {
+			continue
+		}
+
+		bytes, ok := node.Properties["SumBytesOut7d"].(float64)
+		if !ok && !isNonProdEnv(env) {
+			// Unknown traffic and no non-prod tag: not enough evidence.
+			continue
+		}
+		gbPerMon := bytes / 1e9 * 30 / 7
+		if !isNonProdEnv(env) && gbPerMon >= natInstanceMaxGBMonth {
+			continue
+		}
+
+		candidates = append(candidates, candidate{node: node, region: NodeRegion(node, h.Region), env: env, gbPerMon: gbPerMon})
+	}
+	g.Mu.RUnlock()
+
+	for _, c := range candidates {
+		natCost := pricing.DefaultNATPrice * pricing.HoursPerMonth
+		instCost := natInstanceMonthlyCost
+		if h.Pricing != nil {
+			if p, err := h.Pricing.GetNATGatewayPrice(ctx, c.region); err == nil {
+				natCost = p
+			}
+			if p, err := h.Pricing.GetEC2InstancePrice(ctx, c.region, natInstanceType); err == nil {
+				instCost = p
+			}
+		}
+
+		savings := natCost + c.gbPerMon*natProcessingPerGB - instCost
+		if savings <= 0 {
+			continue
+		}
+
+		why := fmt.Sprintf("%.0f GB/mo processed", c.gbPerMon)
+		if c.env != "" {
+			why = fmt.Sprintf("environment=%s, %s", c.env, why)
+		}
+
+		// The recommendation goes on first, so the waste listener sees it; it
+		// is withdrawn when an ignore tag suppresses the finding.
+		g.Mu.Lock()
+		id := c.node.IDStr()
+		c.node.Properties["NATInstanceRecommendation"] = natInstanceType
+		g.Mu.Unlock()
+
+		flagged := stats.record(g, id, graph.Finding{
+			Heuristic: h.Name(),
+			Reason:    fmt.Sprintf("NAT Arbitrage: replace NAT Gateway with a %s NAT instance (fck-nat) (%s). Save $%.2f/mo. Caveat: a single instance is not highly available; use only where brief egress outages are acceptable.", natInstanceType, why, savings),
+			Score:     10,
+			Savings:   savings,
+		})
+		if !flagged {
+			g.Mu.Lock()
+			delete(c.node.Properties, "NATInstanceRecommendation")
+			g.Mu.Unlock()
+		}
+	}
+	return stats, nil
+}
+
+// environmentOf returns the first environment tag on the resource or its parent.
+func environmentOf(tagSets ...map[string]string) string {
+	for _, tags := range tagSets {
+		for _, key := range environmentTagKeys {
+			if v := tags[key]; v != "" {
+				return strings.ToLower(v)
+			}
+		}
+	}
+	return ""
+}
+
+// IsProductionEnv reports whether a lowercase environment tag value names production.
+func IsProductionEnv(env string) bool {
+	return strings.HasPrefix(env, "prod") || env == "prd" || env == "live"
+}
+
+func isNonProdEnv(env string) bool {
+	switch env {
+	case "dev", "development", "test", "testing", "qa", "staging", "stage", "sandbox", "nonprod", "non-prod", "demo":
+		return true
+	}
+	return false
+}
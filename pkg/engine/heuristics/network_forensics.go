@@ -3,6 +3,8 @@ package heuristics
 import (
 	"context"
 	"fmt"
+	"sort"
+
 	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
 )
 
@@ -16,12 +18,14 @@ func (h *NetworkForensicsHeuristic) Run(ctx context.Context, g *graph.Graph) (*H
 
 func (h *NetworkForensicsHeuristic) Analyze(g *graph.Graph) *HeuristicStats {
 	stats := &HeuristicStats{}
+	var eips []*graph.Node
 	for _, n := range g.GetNodes() {
 		var isWaste bool
 		switch n.TypeStr() {
 		case "aws_nat_gateway":
 			isWaste = h.analyzeNAT(n, g)
 		case "aws_eip":
+			eips = append(eips, n)
 			isWaste = h.analyzeEIP(n)
 		case "aws_alb":
 			isWaste = h.analyzeALB(n)
@@ -34,6 +38,11 @@ func (h *NetworkForensicsHeuristic) Analyze(g *graph.Graph) *HeuristicStats {
 			stats.ProjectedSavings += n.Cost
 		}
 	}
+
+	for _, n := range h.analyzeDuplicateEIPs(eips) {
+		stats.ItemsFound++
+		stats.ProjectedSavings += n.Cost
+	}
 	return stats
 }
 
@@ -92,20 +101,74 @@ func (h *NetworkForensicsHeuristic) analyzeEIP(n *graph.Node) bool {
 	n.IsWaste = true
 	n.Cost = 3.5
 
-	inDNS, _ := n.Properties["FoundInDNS"].(bool)
-	if inDNS {
+	if inDNS, _ := n.Properties["FoundInDNS"].(bool); inDNS {
+		// Releasing leaves a dangling record; whoever is allocated the IP next
+		// receives that hostname's traffic (subdomain takeover).
 		zone, _ := n.Properties["DNSZone"].(string)
-		n.RiskScore = 99
-		n.Properties["Reason"] = fmt.Sprintf("Unused EIP %s referenced in DNS zone %s. Do not release due to DNS conflict.", n.IDStr(), zone)
+		record, _ := n.Properties["DNSRecord"].(string)
+		n.RiskScore = 10
+		n.Properties["DNSRisk"] = "SubdomainTakeover"
+		n.Properties["Reason"] = fmt.Sprintf("DANGEROUS: Unused EIP %s is still referenced by DNS record %s in zone %s. Do NOT release until the record is removed (subdomain takeover risk).", n.IDStr(), record, zone)
+		return true
+	}
+
+	if _, unchecked := n.Properties["DNSCheckError"].(string); unchecked {
+		n.RiskScore = 20
+		n.Properties["Reason"] = "Unused EIP. Route53 could not be checked; verify DNS before releasing."
 		return true
 	}
 
-	n.RiskScore = 20
+	n.RiskScore = 60
 	n.Properties["Reason"] = "Safe to Release: Unused EIP (Not in Route53)."
 	n.Properties["Warning"] = "Verify external DNS manually."
 	return true
 }
 
+// analyzeDuplicateEIPs flags extra EIPs on one instance that nothing resolves to.
+// Every public IPv4 address is billed, and one is usually enough per instance.
+func (h *NetworkForensicsHeuristic) analyzeDuplicateEIPs(eips []*graph.Node) []*graph.Node {
+	byInstance := make(map[string][]*graph.Node)
+	for _, n := range eips {
+		if inst, _ := n.Properties["InstanceId"].(string); inst != "" {
+			byInstance[inst] = append(byInstance[inst], n)
+		}
+	}
+
+	var flagged []*graph.Node
+	for inst, group := range byInstance {
+		if len(group) < 2 {
+			continue
+		}
+		sort.Slice(group, func(i, j int) bool { return group[i].IDStr() < group[j].IDStr() })
+
+		// Keep every address DNS points at; if none, keep the first.
+		var spare []*graph.Node
+		kept := 0
+		for _, n := range group {
+			if inDNS, _ := n.Properties["FoundInDNS"].(bool); inDNS {
+				kept++
+			} else {
+				spare = append(spare, n)
+			}
+		}
+		if kept == 0 {
+			spare = spare[1:]
+		}
+
+		for _, n := range spare {
+			if _, unchecked := n.Properties["DNSCheckError"].(string); unchecked {
+				continue
+			}
+			n.IsWaste = true
+			n.RiskScore = 30
+			n.Cost = 3.5
+			n.Properties["Reason"] = fmt.Sprintf("Duplicate EIP: instance %s holds %d Elastic IPs and no DNS record points at this one.", inst, len(group))
+			flagged = append(flagged, n)
+		}
+	}
+	return flagged
+}
+
 func (h *NetworkForensicsHeuristic) analyzeALB(n *graph.Node) bool {
 	reqs, _ := n.Properties["SumRequests7d"].(float64)
 	redirect, _ := n.Properties["IsRedirectOnly"].(bool)
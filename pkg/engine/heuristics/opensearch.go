@@ -0,0 +1,120 @@
+package heuristics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	internalaws "github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/pricing"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// IdleOpenSearchHeuristic flags OpenSearch domains that served no searches and
+// indexed no documents over the scanner's metric window.
+// Domains bill per instance-hour whether or not they are queried.
+type IdleOpenSearchHeuristic struct {
+	Pricing *pricing.Client
+	Region  string        // Scan region; prices domains that carry no region of their own.
+	Window  time.Duration // Scanner's metric lookback; zero means internalaws.OpenSearchMetricWindow.
+}
+
+func (h *IdleOpenSearchHeuristic) Name() string { return "IdleOpenSearchHeuristic" }
+
+func (h *IdleOpenSearchHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	type candidate struct {
+		id, region string
+		groups     []openSearchGroup
+		storageGB  int
+	}
+
+	var candidates []candidate
+	g.Mu.RLock()
+	for _, node := range g.Store.GetAllNodes() {
+		if node.TypeStr() != "AWS::OpenSearch::Domain" || !openSearchIdle(node) {
+			continue
+		}
+		c := candidate{id: node.IDStr(), region: NodeRegion(node, h.Region), groups: openSearchGroups(node)}
+		size, _ := node.Properties["EBSVolumeSize"].(int)
+		count, _ := node.Properties["InstanceCount"].(int)
+		c.storageGB = size * count
+		candidates = append(candidates, c)
+	}
+	g.Mu.RUnlock()
+
+	// Pricing lookups may hit the network; resolve them outside the lock.
+	costs := make(map[string]float64, len(candidates))
+	for _, c := range candidates {
+		cost := float64(c.storageGB) * pricing.OpenSearchEBSGBMonth
+		for _, grp := range c.groups {
+			each := pricing.EstimateOpenSearchInstancePrice(grp.instanceType)
+			if h.Pricing != nil && c.region != "" {
+				if p, err := h.Pricing.GetOpenSearchInstancePrice(ctx, c.region, grp.instanceType); err == nil {
+					each = p
+				}
+			}
+			cost += each * float64(grp.count)
+		}
+		costs[c.id] = cost
+	}
+
+	return applyIdleOpenSearch(g, costs, metricWindow(h.Window, internalaws.OpenSearchMetricWindow)), nil
+}
+
+// openSearchGroup is one billed instance pool of a domain (data, master or warm).
+type openSearchGroup struct {
+	instanceType string
+	count        int
+}
+
+func openSearchGroups(node *graph.Node) []openSearchGroup {
+	var groups []openSearchGroup
+	for _, pool := range [][2]string{{"InstanceType", "InstanceCount"}, {"DedicatedMasterType", "DedicatedMasterCount"}, {"WarmType", "WarmCount"}} {
+		t, _ := node.Properties[pool[0]].(string)
+		n, _ := node.Properties[pool[1]].(int)
+		if t != "" && n > 0 {
+			groups = append(groups, openSearchGroup{t, n})
+		}
+	}
+	return groups
+}
+
+// openSearchIdle reports whether both activity metrics were read and are zero.
+// A domain without metrics is never judged; missing data is not idleness.
+func openSearchIdle(node *graph.Node) bool {
+	search, okSearch := node.Properties["SearchRate"].(float64)
+	indexing, okIndexing := node.Properties["IndexingRate"].(float64)
+	return okSearch && okIndexing && search == 0 && indexing == 0
+}
+
+// applyIdleOpenSearch flags the given domains at their monthly cost.
+func applyIdleOpenSearch(g *graph.Graph, costs map[string]float64, window time.Duration) *HeuristicStats {
+	stats := &HeuristicStats{}
+
+	var findings []pendingFinding
+	g.Mu.RLock()
+	for id, cost := range costs {
+		node := g.GetNode(id)
+		if node == nil || node.IsWaste {
+			continue
+		}
+		name, _ := node.Properties["DomainName"].(string)
+		instanceType, _ := node.Properties["InstanceType"].(string)
+		count, _ := node.Properties["InstanceCount"].(int)
+		docs, _ := node.Properties["SearchableDocuments"].(float64)
+
+		findings = append(findings, pendingFinding{id, graph.Finding{
+			Heuristic: "IdleOpenSearchHeuristic",
+			Reason: fmt.Sprintf("Idle OpenSearch Domain: %s (%d x %s, %.0f documents) served 0 searches and indexed nothing in %s. Costing $%.2f/mo.",
+				name, count, instanceType, docs, windowLabel(window), cost),
+			Score:   60,
+			Savings: cost,
+		}})
+	}
+	g.Mu.RUnlock()
+
+	for _, f := range findings {
+		stats.record(g, f.id, f.Finding)
+	}
+	return stats
+}
@@ -0,0 +1,145 @@
+package heuristics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/config"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/pricing"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// OrphanedSnapshotHeuristic flags old snapshots whose source volume no longer
+// exists and that no AMI references. SnapshotChildrenHeuristic covers the
+// opposite case, where the volume exists but is itself waste.
+type OrphanedSnapshotHeuristic struct {
+	Config  config.OrphanedSnapshotConfig
+	Pricing *pricing.Client
+	Region  string // Scan region; prices snapshots that carry no region of their own.
+}
+
+func (h *OrphanedSnapshotHeuristic) Name() string { return "OrphanedSnapshotHeuristic" }
+
+func (h *OrphanedSnapshotHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	days := h.Config.MinAgeDays
+	if days <= 0 {
+		days = 90
+	}
+	return applyOrphanedSnapshots(g, time.Duration(days)*24*time.Hour, time.Now(), func(node *graph.Node) float64 {
+		return snapshotCost(ctx, h.Pricing, NodeRegion(node, h.Region), node)
+	}), nil
+}
+
+// applyOrphanedSnapshots flags snapshots older than minAge that have no live
+// source volume and no AMI edge. Snapshots without a creation time are skipped.
+func applyOrphanedSnapshots(g *graph.Graph, minAge time.Duration, now time.Time, costOf func(*graph.Node) float64) *HeuristicStats {
+	stats := &HeuristicStats{}
+
+	var candidates []*graph.Node
+	g.Mu.RLock()
+	liveVolumes := make(map[string]bool)
+	for _, node := range g.Store.GetAllNodes() {
+		if node.TypeStr() == "AWS::EC2::Volume" {
+			liveVolumes[resourceIDFromARN(node.IDStr())] = true
+		}
+	}
+	for _, node := range g.Store.GetAllNodes() {
+		if node.TypeStr() != "AWS::EC2::Snapshot" || node.IsWaste {
+			continue
+		}
+		created, ok := node.CreatedAt()
+		if !ok || now.Sub(created) < minAge {
+			continue
+		}
+		if volID, _ := node.Properties["VolumeId"].(string); liveVolumes[volID] {
+			continue
+		}
+		if referencedByAMI(g, node) {
+			continue
+		}
+		candidates = append(candidates, node)
+	}
+	g.Mu.RUnlock()
+
+	// Pricing lookups may hit the network; resolve them outside the lock.
+	costs := make([]float64, len(candidates))
+	for i, node := range candidates {
+		if costOf != nil {
+			costs[i] = costOf(node)
+		}
+	}
+
+	for i, node := range candidates {
+		g.Mu.RLock()
+		id := node.IDStr()
+		created, _ := node.CreatedAt()
+		volID, _ := node.Properties["VolumeId"].(string)
+		g.Mu.RUnlock()
+		if volID == "" {
+			volID = "unknown"
+		}
+
+		stats.record(g, id, graph.Finding{
+			Heuristic: "OrphanedSnapshotHeuristic",
+			Reason: fmt.Sprintf("Orphaned Snapshot: %d days old; source volume %s no longer exists and no AMI uses it ($%.2f/mo).",
+				int(now.Sub(created).Hours()/24), volID, costs[i]),
+			Score:   50,
+			Savings: costs[i],
+		})
+	}
+	return stats
+}
+
+// referencedByAMI reports whether an AMI node has an edge to the snapshot.
+// The caller holds g.Mu.
+func referencedByAMI(g *graph.Graph, snap *graph.Node) bool {
+	for _, edge := range g.Store.GetReverseEdges(snap.Index) {
+		if src := g.Store.GetNode(edge.TargetID); src != nil && src.TypeStr() == "AWS::EC2::AMI" {
+			return true
+		}
+	}
+	return false
+}
+
+// snapshotCost prices a snapshot at its source volume size.
+// A nil client falls back to the static estimate.
+func snapshotCost(ctx context.Context, p *pricing.Client, region string, node *graph.Node) float64 {
+	return snapshotPrice(ctx, p, region, snapshotSizeGB(node))
+}
+
+// snapshotSizeGB is the snapshot's source volume size, or 0 if unrecorded.
+func snapshotSizeGB(node *graph.Node) int {
+	switch s := node.Properties["VolumeSize"].(type) {
+	case int32:
+		return int(s)
+	case int:
+		return s
+	}
+	return 0
+}
+
+// snapshotPrice prices sizeGB of standard-tier snapshot storage.
+// A nil client falls back to the static estimate.
+func snapshotPrice(ctx context.Context, p *pricing.Client, region string, sizeGB int) float64 {
+	if sizeGB <= 0 {
+		return 0
+	}
+	if p == nil {
+		return pricing.EstimateEBSSnapshotPrice(sizeGB)
+	}
+	cost, err := p.GetEBSSnapshotPrice(ctx, region, sizeGB)
+	if err != nil {
+		return pricing.EstimateEBSSnapshotPrice(sizeGB)
+	}
+	return cost
+}
+
+// resourceIDFromARN returns the part after the last "/" ("vol-123" for a volume ARN).
+func resourceIDFromARN(arn string) string {
+	if i := strings.LastIndex(arn, "/"); i >= 0 {
+		return arn[i+1:]
+	}
+	return arn
+}
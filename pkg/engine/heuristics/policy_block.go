@@ -0,0 +1,140 @@
+package heuristics
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	internalaws "github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+)
+
+// deleteActions maps resource types to the IAM action their cleanup needs.
+var deleteActions = map[string]string{
+	"AWS::EC2::Instance":                        "ec2:TerminateInstances",
+	"AWS::EC2::Volume":                          "ec2:DeleteVolume",
+	"AWS::EC2::Snapshot":                        "ec2:DeleteSnapshot",
+	"AWS::EC2::AMI":                             "ec2:DeregisterImage",
+	"AWS::EC2::EIP":                             "ec2:ReleaseAddress",
+	"AWS::EC2::NatGateway":                      "ec2:DeleteNatGateway",
+	"AWS::EC2::VPC":                             "ec2:DeleteVpc",
+	"AWS::S3::Bucket":                           "s3:DeleteBucket",
+	"AWS::RDS::DBInstance":                      "rds:DeleteDBInstance",
+	"AWS::ElasticLoadBalancingV2::LoadBalancer": "elasticloadbalancing:DeleteLoadBalancer",
+	"AWS::ECR::Repository":                      "ecr:DeleteRepository",
+	"AWS::ECS::Cluster":                         "ecs:DeleteCluster",
+	"AWS::ECS::Service":                         "ecs:DeleteService",
+	"AWS::EKS::NodeGroup":                       "eks:DeleteNodegroup",
+	"AWS::EFS::FileSystem":                      "elasticfilesystem:DeleteFileSystem",
+	"AWS::Logs::LogGroup":                       "logs:DeleteLogGroup",
+	"AWS::CodeBuild::Project":                   "codebuild:DeleteProject",
+	"AWS::CodePipeline::Pipeline":               "codepipeline:DeletePipeline",
+}
+
+// PolicyBlockHeuristic simulates the delete action for each finding.
+// Findings a Service Control Policy or permissions boundary would deny are
+// annotated so nobody is sent to clean up something they cannot remove.
+type PolicyBlockHeuristic struct {
+	IAM       *internalaws.IAMClient
+	Principal string // IAM user or role ARN to simulate as.
+	Region    string // Fallback for nodes that record no region.
+}
+
+func (h *PolicyBlockHeuristic) Name() string { return "PolicyBlockHeuristic" }
+
+func (h *PolicyBlockHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	if h.IAM == nil || h.Principal == "" {
+		return &HeuristicStats{}, nil
+	}
+
+	byAction := policyTargets(g, h.Region)
+	actions := make([]string, 0, len(byAction))
+	for action := range byAction {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	blocked := make(map[string]string)
+	for _, action := range actions {
+		arns := make([]string, 0, len(byAction[action]))
+		for a := range byAction[action] {
+			arns = append(arns, a)
+		}
+		sort.Strings(arns)
+
+		denied, err := h.IAM.SimulateDeletes(ctx, h.Principal, action, arns)
+		if err != nil {
+			// No iam:SimulatePrincipalPolicy access; leave findings unannotated.
+			slog.Debug("Policy simulation unavailable", "action", action, "error", err)
+			return &HeuristicStats{}, nil
+		}
+		for a, by := range denied {
+			if id, ok := byAction[action][a]; ok {
+				blocked[id] = by
+			}
+		}
+	}
+
+	return &HeuristicStats{ItemsFound: applyPolicyBlocks(g, blocked)}, nil
+}
+
+// policyTargets groups flagged resources by the action that would remove them,
+// mapping each real ARN to its node ID. Scanners that write "region" and
+// "account" placeholders into IDs are resolved from the node's region and
+// AccountId; a node whose account is unknown is skipped, as simulating a
+// placeholder ARN evaluates no real resource.
+func policyTargets(g *graph.Graph, region string) map[string]map[string]string {
+	g.Mu.RLock()
+	defer g.Mu.RUnlock()
+
+	byAction := make(map[string]map[string]string)
+	for _, node := range g.Store.GetAllNodes() {
+		if !node.IsWaste || node.Justified {
+			continue
+		}
+		action, ok := deleteActions[node.TypeStr()]
+		id := node.IDStr()
+		if !ok || !strings.HasPrefix(id, "arn:") {
+			continue
+		}
+		account, _ := node.Properties["AccountId"].(string)
+		if parsed, err := arn.Parse(id); err != nil || (parsed.AccountID == "account" && account == "") {
+			continue
+		}
+		if byAction[action] == nil {
+			byAction[action] = make(map[string]string)
+		}
+		byAction[action][internalaws.ResolveARN(id, NodeRegion(node, region), account)] = id
+	}
+	return byAction
+}
+
+// applyPolicyBlocks annotates findings whose delete would be denied.
+// Returns the number of findings annotated.
+func applyPolicyBlocks(g *graph.Graph, blocked map[string]string) int {
+	if len(blocked) == 0 {
+		return 0
+	}
+
+	g.Mu.Lock()
+	defer g.Mu.Unlock()
+
+	count := 0
+	for _, node := range g.Store.GetAllNodes() {
+		by, ok := blocked[node.IDStr()]
+		if !ok || !node.IsWaste {
+			continue
+		}
+		node.Properties["RemediationBlocked"] = by
+		node.AddFinding(graph.Finding{
+			Heuristic: "PolicyBlockHeuristic",
+			Reason:    fmt.Sprintf("cannot remediate: blocked by policy (%s)", by),
+			Action:    "Ask the policy owner for an exception, or leave the resource",
+		})
+		count++
+	}
+	return count
+}
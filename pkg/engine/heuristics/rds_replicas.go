@@ -0,0 +1,110 @@
+package heuristics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	internalaws "github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/pricing"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+const (
+	replicaWindow = 14 * 24 * time.Hour
+	// Replication apply traffic alone keeps ReadIOPS near zero.
+	replicaReadIOPSThreshold = 1.0
+)
+
+// IdleReadReplicaHeuristic detects read replicas nobody queries.
+type IdleReadReplicaHeuristic struct {
+	CW      *internalaws.CloudWatchClient
+	Pricing *pricing.Client
+	Window  time.Duration // Metric lookback; zero means replicaWindow.
+}
+
+func (h *IdleReadReplicaHeuristic) Name() string { return "IdleReadReplicaHeuristic" }
+
+func (h *IdleReadReplicaHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	stats := &HeuristicStats{}
+	if h.CW == nil {
+		return stats, nil
+	}
+
+	g.Mu.RLock()
+	var replicas []*graph.Node
+	for _, node := range g.Store.GetAllNodes() {
+		if node.TypeStr() == "AWS::RDS::DBInstance" && isReadReplica(node) {
+			replicas = append(replicas, node)
+		}
+	}
+	g.Mu.RUnlock()
+
+	window := metricWindow(h.Window, replicaWindow)
+	endTime := time.Now()
+	startTime := endTime.Add(-window)
+
+	var throttled throttleTracker
+	defer func() { throttled.report(g, h.Name()) }()
+
+	for _, node := range replicas {
+		parsed, err := arn.Parse(node.IDStr())
+		if err != nil {
+			continue
+		}
+		id := strings.TrimPrefix(parsed.Resource, "db:")
+
+		dims := []types.Dimension{
+			{Name: aws.String("DBInstanceIdentifier"), Value: aws.String(id)},
+		}
+
+		cw := scopedCW(h.CW, node)
+		maxConns, err := cw.GetMetricMax(ctx, "AWS/RDS", "DatabaseConnections", dims, startTime, endTime)
+		if err != nil {
+			throttled.check(err)
+			continue
+		}
+		if maxConns > 0 {
+			continue
+		}
+
+		maxReads, err := cw.GetMetricMax(ctx, "AWS/RDS", "ReadIOPS", dims, startTime, endTime)
+		if err != nil {
+			throttled.check(err)
+			continue
+		}
+		if maxReads > replicaReadIOPSThreshold {
+			continue
+		}
+
+		source, _ := node.Properties["ReplicaSource"].(string)
+		var cost float64
+		if h.Pricing != nil {
+			class, _ := node.Properties["InstanceClass"].(string)
+			engine, _ := node.Properties["Engine"].(string)
+			if price, err := h.Pricing.GetRDSInstancePrice(ctx, parsed.Region, class, engine); err == nil {
+				cost = price
+			}
+		}
+		g.AddFinding(node.IDStr(), graph.Finding{
+			Heuristic: h.Name(),
+			Reason:    fmt.Sprintf("Read replica of %s has 0 connections in %s", source, windowLabel(window)),
+			Score:     70,
+			Action:    "Delete the read replica",
+			Savings:   cost,
+		})
+		stats.ProjectedSavings += cost
+		stats.ItemsFound++
+	}
+	return stats, nil
+}
+
+// isReadReplica reports whether an RDS node replicates from a primary.
+func isReadReplica(node *graph.Node) bool {
+	v, _ := node.Properties["IsReadReplica"].(bool)
+	return v
+}
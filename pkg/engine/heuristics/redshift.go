@@ -0,0 +1,315 @@
+package heuristics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	internalaws "github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/pricing"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+const (
+	redshiftWindow = 7 * 24 * time.Hour
+
+	// Monitoring agents and BI tools polling metadata hold a connection on an
+	// otherwise idle cluster.
+	redshiftIdleConnections = 1.0
+	redshiftIdleQueries     = 10.0 // Queries completed over the window.
+
+	// A cluster averaging under redshiftLowCPU runs on half its nodes. Without
+	// managed storage (DC2, DS2) data lives on the nodes, so the smaller
+	// cluster must also keep disk use under redshiftMaxDisk.
+	redshiftLowCPU  = 10.0
+	redshiftMaxDisk = 70.0
+)
+
+// redshiftMinNodes is the smallest cluster a node type allows; types not
+// listed can run as a single node.
+var redshiftMinNodes = map[string]int{
+	"dc2.8xlarge":  2,
+	"ds2.8xlarge":  2,
+	"ra3.4xlarge":  2,
+	"ra3.16xlarge": 2,
+}
+
+// RedshiftHeuristic flags provisioned clusters that served almost no
+// connections or queries over the metric window, which should be paused, and
+// clusters whose CPU stays low, which should run on fewer nodes. Without
+// CloudWatch (mock mode) it uses the usage recorded on the node.
+type RedshiftHeuristic struct {
+	CW      *internalaws.CloudWatchClient
+	Pricing *pricing.Client
+	Region  string        // Scan region; prices clusters that carry no region of their own.
+	Window  time.Duration // Metric lookback; zero means redshiftWindow.
+}
+
+func (h *RedshiftHeuristic) Name() string { return "RedshiftHeuristic" }
+
+// redshiftUsage is a cluster's load over the window.
+type redshiftUsage struct {
+	PeakConnections float64
+	Queries         float64
+	AvgCPU          float64
+	PeakDisk        float64 // PercentageDiskSpaceUsed.
+}
+
+// redshiftFinding is the evidence for one flagged cluster. Cost is the
+// cluster's monthly cost when idle, or the saving of the smaller cluster.
+type redshiftFinding struct {
+	Usage redshiftUsage
+	Idle  bool
+	Nodes int // Recommended node count when resizing.
+	Cost  float64
+}
+
+type redshiftCandidate struct {
+	id, name, region, nodeType string
+	nodes                      int
+	recorded                   *redshiftUsage
+	cw                         *internalaws.CloudWatchClient
+}
+
+func (h *RedshiftHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	now := time.Now()
+	window := metricWindow(h.Window, redshiftWindow)
+	var candidates []redshiftCandidate
+	g.Mu.RLock()
+	for _, node := range g.Store.GetAllNodes() {
+		if node.TypeStr() != "aws_redshift_cluster" || node.IsWaste {
+			continue
+		}
+		// Paused clusters already bill only for storage.
+		if status, _ := node.Properties["ClusterStatus"].(string); status != "available" {
+			continue
+		}
+		if created, ok := node.CreatedAt(); ok && now.Sub(created) < window {
+			continue
+		}
+		c := redshiftCandidate{id: node.IDStr(), name: node.IDStr(), region: NodeRegion(node, h.Region), cw: scopedCW(h.CW, node)}
+		if name, ok := node.Properties["ClusterIdentifier"].(string); ok && name != "" {
+			c.name = name
+		}
+		c.nodeType, _ = node.Properties["NodeType"].(string)
+		c.nodes, _ = node.Properties["NumberOfNodes"].(int)
+		if c.nodes < 1 {
+			c.nodes = 1
+		}
+		if h.CW == nil {
+			c.recorded = recordedRedshiftUsage(node)
+			if c.recorded == nil {
+				continue
+			}
+		}
+		candidates = append(candidates, c)
+	}
+	g.Mu.RUnlock()
+
+	usage, err := h.usage(ctx, candidates, now.Add(-window), now)
+	if err != nil {
+		if internalaws.IsThrottleError(err) {
+			g.AddError(fmt.Sprintf("CloudWatch [%s]", h.Name()), err)
+		}
+		return &HeuristicStats{}, nil
+	}
+
+	// Pricing calls hit the network; resolve them outside the lock.
+	findings := make(map[string]redshiftFinding)
+	for _, c := range candidates {
+		u, ok := usage[c.id]
+		if !ok {
+			continue
+		}
+		f := redshiftFinding{Usage: u}
+		price := h.clusterPrice(ctx, c.region, c.nodeType, c.nodes)
+		if u.PeakConnections <= redshiftIdleConnections && u.Queries < redshiftIdleQueries {
+			f.Idle = true
+			f.Cost = price
+		} else if u.AvgCPU < redshiftLowCPU {
+			f.Nodes = redshiftResizeNodes(c.nodeType, c.nodes, u.PeakDisk)
+			if f.Nodes >= c.nodes {
+				continue
+			}
+			f.Cost = price - h.clusterPrice(ctx, c.region, c.nodeType, f.Nodes)
+			if f.Cost <= 0 {
+				continue
+			}
+		} else {
+			continue
+		}
+		findings[c.id] = f
+	}
+
+	return applyRedshift(g, findings, window), nil
+}
+
+// redshiftResizeNodes halves a cluster, keeping the node type's minimum and,
+// for node types that store data locally, room for the data.
+func redshiftResizeNodes(nodeType string, nodes int, peakDisk float64) int {
+	target := (nodes + 1) / 2
+	if min := redshiftMinNodes[nodeType]; target < min {
+		target = min
+	}
+	if !strings.HasPrefix(nodeType, "ra3.") {
+		// Disk use is a share of the current cluster's local storage.
+		if needed := int(math.Ceil(float64(nodes) * peakDisk / redshiftMaxDisk)); target < needed {
+			target = needed
+		}
+	}
+	return max(target, 1)
+}
+
+// usage reads connections, completed queries, CPU and disk use for every
+// candidate, keyed by node ID, in one batch per account and region. Without
+// CloudWatch it returns the recorded usage.
+//
+// An available cluster always publishes connections and CPU; a cluster
+// missing either is left out, as its usage is unknown. Completed queries are
+// only published while queries run, so their absence counts as none.
+func (h *RedshiftHeuristic) usage(ctx context.Context, candidates []redshiftCandidate, start, end time.Time) (map[string]redshiftUsage, error) {
+	usage := make(map[string]redshiftUsage)
+	if h.CW == nil {
+		for _, c := range candidates {
+			if c.recorded != nil {
+				usage[c.id] = *c.recorded
+			}
+		}
+		return usage, nil
+	}
+	byCW := make(map[*internalaws.CloudWatchClient][]redshiftCandidate)
+	for _, c := range candidates {
+		byCW[c.cw] = append(byCW[c.cw], c)
+	}
+	for cw, group := range byCW {
+		if err := scopeRedshiftUsage(ctx, cw, group, start, end, usage); err != nil {
+			return nil, err
+		}
+	}
+	return usage, nil
+}
+
+// scopeRedshiftUsage reads usage for candidates in the account and region cw reads.
+func scopeRedshiftUsage(ctx context.Context, cw *internalaws.CloudWatchClient, candidates []redshiftCandidate, start, end time.Time, usage map[string]redshiftUsage) error {
+	metrics := []struct{ name, stat string }{
+		{"DatabaseConnections", "Maximum"},
+		{"QueriesCompletedPerSecond", "Average"},
+		{"CPUUtilization", "Average"},
+		{"PercentageDiskSpaceUsed", "Maximum"},
+	}
+	var queries []internalaws.MetricQuery
+	for i, c := range candidates {
+		dims := []types.Dimension{{Name: aws.String("ClusterIdentifier"), Value: aws.String(c.name)}}
+		for _, m := range metrics {
+			queries = append(queries, internalaws.MetricQuery{
+				ID:         fmt.Sprintf("c%d_%s", i, m.name),
+				Namespace:  "AWS/Redshift",
+				MetricName: m.name,
+				Dimensions: dims,
+				Stat:       m.stat,
+				StartTime:  start,
+				EndTime:    end,
+			})
+		}
+	}
+
+	values, err := cw.GetMetricDataBatchObserved(ctx, queries)
+	if err != nil {
+		return err
+	}
+	for i, c := range candidates {
+		key := func(metric string) float64 { return values[fmt.Sprintf("c%d_%s", i, metric)] }
+		_, hasConns := values[fmt.Sprintf("c%d_DatabaseConnections", i)]
+		_, hasCPU := values[fmt.Sprintf("c%d_CPUUtilization", i)]
+		if !hasConns || !hasCPU {
+			continue
+		}
+		usage[c.id] = redshiftUsage{
+			PeakConnections: key("DatabaseConnections"),
+			// The average rate over the window, back to a count.
+			Queries:  key("QueriesCompletedPerSecond") * end.Sub(start).Seconds(),
+			AvgCPU:   key("CPUUtilization"),
+			PeakDisk: key("PercentageDiskSpaceUsed"),
+		}
+	}
+	return nil
+}
+
+// recordedRedshiftUsage returns usage already on the node, or nil when
+// connections or queries were never recorded.
+func recordedRedshiftUsage(node *graph.Node) *redshiftUsage {
+	conns, ok := node.Properties["PeakConnections"].(float64)
+	if !ok {
+		return nil
+	}
+	queries, ok := node.Properties["QueriesCompleted"].(float64)
+	if !ok {
+		return nil
+	}
+	u := &redshiftUsage{PeakConnections: conns, Queries: queries}
+	u.AvgCPU, _ = node.Properties["AvgCPU"].(float64)
+	u.PeakDisk, _ = node.Properties["PeakDiskPercent"].(float64)
+	return u
+}
+
+func (h *RedshiftHeuristic) clusterPrice(ctx context.Context, region, nodeType string, nodes int) float64 {
+	if h.Pricing != nil && region != "" {
+		if p, err := h.Pricing.GetRedshiftPrice(ctx, region, nodeType, nodes); err == nil {
+			return p
+		}
+	}
+	return pricing.EstimateRedshiftPrice(nodeType, nodes)
+}
+
+// applyRedshift marks idle clusters as pause candidates and underused
+// clusters for review. window is the lookback usage was measured over.
+func applyRedshift(g *graph.Graph, findings map[string]redshiftFinding, window time.Duration) *HeuristicStats {
+	stats := &HeuristicStats{}
+
+	// Evidence goes on the node first, so the waste listener sees it.
+	var pending []pendingFinding
+	g.Mu.Lock()
+	for id, f := range findings {
+		node := g.GetNode(id)
+		if node == nil || node.IsWaste {
+			continue
+		}
+		name, _ := node.Properties["ClusterIdentifier"].(string)
+		if name == "" {
+			name = id
+		}
+		nodeType, _ := node.Properties["NodeType"].(string)
+		nodes, _ := node.Properties["NumberOfNodes"].(int)
+
+		node.Properties["PeakConnections"] = f.Usage.PeakConnections
+		node.Properties["QueriesCompleted"] = f.Usage.Queries
+		node.Properties["AvgCPU"] = f.Usage.AvgCPU
+
+		finding := graph.Finding{Heuristic: "RedshiftHeuristic", Savings: f.Cost}
+		if f.Idle {
+			// Pausing keeps the data and resumes in minutes.
+			finding.Score = 70
+			node.Properties["RedshiftAction"] = "pause"
+			finding.Reason = fmt.Sprintf("Idle Redshift Cluster: %s (%d × %s) peaked at %.0f connections with %.0f queries completed in %s ($%.2f/mo). Recommendation: Pause the cluster, or snapshot and delete it.",
+				name, nodes, nodeType, f.Usage.PeakConnections, f.Usage.Queries, windowLabel(window), f.Cost)
+		} else {
+			// Resizing moves data and briefly interrupts queries; a human decides.
+			finding.Score = 40
+			node.Properties["RedshiftAction"] = "resize"
+			node.Properties["RecommendedNodes"] = f.Nodes
+			finding.Reason = fmt.Sprintf("Oversized Redshift Cluster: %s (%d × %s) averaged %.1f%% CPU in %s. Resize to %d nodes to save $%.2f/mo.",
+				name, nodes, nodeType, f.Usage.AvgCPU, windowLabel(window), f.Nodes, f.Cost)
+		}
+		pending = append(pending, pendingFinding{id, finding})
+	}
+	g.Mu.Unlock()
+
+	for _, f := range pending {
+		stats.record(g, f.id, f.Finding)
+	}
+	return stats
+}
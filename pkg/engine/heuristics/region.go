@@ -0,0 +1,76 @@
+package heuristics
+
+import (
+	"github.com/DrSkyle/cloudslash/v2/pkg/config"
+	internalaws "github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+)
+
+// NodeRegion is the region a node lives in, for pricing lookups: its Region
+// property, else the region in its ARN, else fallback (the scan region), else
+// config.DefaultRegion. Placeholders such as "global" count as unknown.
+func NodeRegion(node *graph.Node, fallback string) string {
+	if r := ownRegion(node); r != "" {
+		return r
+	}
+	if fallback != "" {
+		return fallback
+	}
+	return config.DefaultRegion
+}
+
+// ownRegion is the region a node records itself, or "" when it records none.
+func ownRegion(node *graph.Node) string {
+	if r, ok := node.Properties["Region"].(string); ok && knownRegion(r) {
+		return r
+	}
+	if parsed, err := arn.Parse(node.IDStr()); err == nil && knownRegion(parsed.Region) {
+		return parsed.Region
+	}
+	return ""
+}
+
+func knownRegion(r string) bool {
+	switch r {
+	case "", "global", "region", "RegionUnknown":
+		return false
+	}
+	return true
+}
+
+// NodeAccount is the AWS account a node was scanned in: its AccountId
+// property, else the account in its ARN. Empty when neither is known.
+func NodeAccount(node *graph.Node) string {
+	if a, ok := node.Properties["AccountId"].(string); ok && a != "" {
+		return a
+	}
+	if parsed, err := arn.Parse(node.IDStr()); err == nil {
+		return parsed.AccountID
+	}
+	return ""
+}
+
+// scopedCW returns cw routed to the account and region node lives in, so
+// metrics are read where they were published. A node without a known region
+// is read in cw's own region.
+func scopedCW(cw *internalaws.CloudWatchClient, node *graph.Node) *internalaws.CloudWatchClient {
+	if cw == nil {
+		return nil
+	}
+	return cw.For(NodeAccount(node), ownRegion(node))
+}
+
+// groupByCW splits nodes by the client scopedCW routes each to, so batched
+// metric reads go out once per account and region. It is empty when cw is nil.
+func groupByCW(cw *internalaws.CloudWatchClient, nodes []*graph.Node) map[*internalaws.CloudWatchClient][]*graph.Node {
+	if cw == nil {
+		return nil
+	}
+	groups := make(map[*internalaws.CloudWatchClient][]*graph.Node)
+	for _, node := range nodes {
+		c := scopedCW(cw, node)
+		groups[c] = append(groups[c], node)
+	}
+	return groups
+}
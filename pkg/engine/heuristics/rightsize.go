@@ -0,0 +1,105 @@
+package heuristics
+
+import (
+	"fmt"
+	"strings"
+
+	internalaws "github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/solver"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/tetris"
+)
+
+const (
+	// rightSizeHeadroom sizes replacements so the observed peak uses at most
+	// two thirds of their capacity.
+	rightSizeHeadroom = 1.5
+
+	// burstableBaseline is the CPU share a T-family candidate can sustain
+	// without spending credits: the t3.medium baseline, the lowest among
+	// CandidateTypes.
+	burstableBaseline = 0.2
+
+	// networkBusyBytesPerSec is 100 Mbps averaged over the busiest metric
+	// period. Smaller sizes cap bandwidth, so a busier instance keeps its vCPUs.
+	networkBusyBytesPerSec = 12.5e6
+
+	// minRightSizeSavings is the smallest saving, as a share of the current
+	// price, worth a migration.
+	minRightSizeSavings = 0.1
+)
+
+// instanceUsage is an instance's peak utilization over the metric window.
+type instanceUsage struct {
+	CPUPercent         float64
+	MemoryPercent      float64 // CloudWatch agent mem_used_percent; zero when not published.
+	NetworkBytesPerSec float64 // In plus out.
+}
+
+// rightSizing is a recommended instance type change.
+type rightSizing struct {
+	From, To string
+	Savings  float64 // $/mo
+}
+
+func (r rightSizing) String() string {
+	return fmt.Sprintf("recommend %s → %s, save $%.2f/mo", r.From, r.To, r.Savings)
+}
+
+// recommendInstanceType picks the CandidateTypes entry saving the most that
+// still fits the observed peak with headroom. Candidates share the current
+// architecture, since an AMI runs on one, and are no larger in either
+// dimension. Without a memory metric the current memory is kept. price
+// returns a type's monthly price, false when unknown.
+func recommendInstanceType(current string, usage instanceUsage, price func(instanceType string) (float64, bool)) (rightSizing, bool) {
+	spec, ok := internalaws.LookupSpecs(current)
+	if !ok {
+		return rightSizing{}, false
+	}
+	currentCost, ok := price(current)
+	if !ok || currentCost <= 0 {
+		return rightSizing{}, false
+	}
+
+	// The workload's peak, as an item to pack into each candidate.
+	peak := &tetris.Item{ID: current, Dimensions: tetris.Dimensions{
+		CPU: spec.VCPU * 1000 * usage.CPUPercent / 100 * rightSizeHeadroom,
+		RAM: spec.Memory,
+	}}
+	if usage.MemoryPercent > 0 {
+		peak.Dimensions.RAM = spec.Memory * usage.MemoryPercent / 100 * rightSizeHeadroom
+	}
+	busy := usage.NetworkBytesPerSec > networkBusyBytesPerSec
+
+	best := rightSizing{From: current}
+	for _, candidate := range internalaws.CandidateTypes {
+		if candidate == current {
+			continue
+		}
+		cand, ok := internalaws.LookupSpecs(candidate)
+		if !ok || cand.Arch != spec.Arch || cand.VCPU > spec.VCPU || cand.Memory > spec.Memory {
+			continue
+		}
+		if busy && cand.VCPU < spec.VCPU {
+			continue
+		}
+
+		capacity := tetris.Dimensions{CPU: cand.VCPU * 1000, RAM: cand.Memory}
+		if strings.HasPrefix(solver.InstanceFamily(candidate), "t") {
+			capacity.CPU *= burstableBaseline
+		}
+		bin := &tetris.Bin{ID: candidate, Capacity: capacity}
+		if !bin.AddItem(peak) {
+			continue
+		}
+
+		cost, ok := price(candidate)
+		if !ok {
+			continue
+		}
+		savings := currentCost - cost
+		if savings >= currentCost*minRightSizeSavings && savings > best.Savings {
+			best.To, best.Savings = candidate, savings
+		}
+	}
+	return best, best.To != ""
+}
@@ -0,0 +1,79 @@
+package heuristics
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	internalaws "github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+func staticPrice(t string) (float64, bool) {
+	return (&internalaws.StaticCostEstimator{}).GetEstimatedCost(t, "us-east-1"), true
+}
+
+func TestRecommendInstanceType(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		usage   instanceUsage
+		want    string
+	}{
+		// Without a memory metric, memory is kept: only 16 GiB candidates fit.
+		{"idle, memory unknown", "m5.xlarge", instanceUsage{CPUPercent: 1}, "t3.xlarge"},
+		{"idle, low memory", "m5.xlarge", instanceUsage{CPUPercent: 1, MemoryPercent: 20}, "t3.large"},
+		// 2.4 vCPUs at peak exceed every burstable baseline.
+		{"busy CPU", "m5.xlarge", instanceUsage{CPUPercent: 40, MemoryPercent: 20}, "c5.xlarge"},
+		{"busy network keeps vCPUs", "m5.xlarge", instanceUsage{CPUPercent: 1, MemoryPercent: 20, NetworkBytesPerSec: 20e6}, "t3.xlarge"},
+		{"nothing fits", "m5.xlarge", instanceUsage{CPUPercent: 90, MemoryPercent: 90}, ""},
+		{"unknown type", "x9.huge", instanceUsage{CPUPercent: 1}, ""},
+		// Graviton candidates are never offered to x86 instances and vice versa.
+		{"arm stays arm", "m6g.xlarge", instanceUsage{CPUPercent: 1, MemoryPercent: 20}, "m6g.large"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, ok := recommendInstanceType(tt.current, tt.usage, staticPrice)
+			if ok != (tt.want != "") || rec.To != tt.want {
+				t.Fatalf("recommendInstanceType(%s) = %+v, %v; want %q", tt.current, rec, ok, tt.want)
+			}
+			if ok && (rec.From != tt.current || rec.Savings <= 0) {
+				t.Errorf("Unexpected recommendation %+v", rec)
+			}
+		})
+	}
+
+	// Unpriced candidates are skipped rather than assumed free.
+	onlyCurrent := func(t string) (float64, bool) { return 140, t == "m5.xlarge" }
+	if rec, ok := recommendInstanceType("m5.xlarge", instanceUsage{CPUPercent: 1}, onlyCurrent); ok {
+		t.Errorf("Expected no recommendation without candidate prices, got %+v", rec)
+	}
+}
+
+func TestUnderutilizedInstanceRecommendsType(t *testing.T) {
+	g := graph.NewGraph()
+	id := "arn:aws:ec2:us-east-1:123456789012:instance/i-big"
+	g.AddNode(id, "AWS::EC2::Instance", map[string]interface{}{
+		"State":        "running",
+		"InstanceType": "m5.xlarge",
+	})
+	g.CloseAndWait()
+
+	h := &UnderutilizedInstanceHeuristic{Region: "us-east-1"}
+	stats, err := h.Run(context.Background(), g)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	node := g.GetNode(id)
+	if !node.IsWaste || stats.ItemsFound != 1 {
+		t.Fatal("Expected the idle instance to be flagged")
+	}
+	reason, _ := node.Properties["Reason"].(string)
+	if !strings.Contains(reason, "recommend m5.xlarge → t3.xlarge, save $110.00/mo") {
+		t.Errorf("Expected a concrete recommendation, got %q", reason)
+	}
+	if node.Properties["RecommendedInstanceType"] != "t3.xlarge" || node.Cost != 110 {
+		t.Errorf("Expected the savings as cost, got %v and $%.2f", node.Properties["RecommendedInstanceType"], node.Cost)
+	}
+}
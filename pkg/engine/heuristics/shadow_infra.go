@@ -0,0 +1,52 @@
+package heuristics
+
+import (
+	"context"
+	"strings"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/DrSkyle/cloudslash/v2/pkg/providers/tf"
+)
+
+// ShadowInfraHeuristic flags AWS resources that no Terraform state manages
+// (ClickOps / shadow infrastructure). Being unmanaged is a governance signal,
+// not waste: nodes are annotated with Unmanaged=true and never marked for deletion.
+type ShadowInfraHeuristic struct {
+	State *tf.State
+}
+
+func (h *ShadowInfraHeuristic) Name() string { return "ShadowInfraHeuristic" }
+
+func (h *ShadowInfraHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	if h.State == nil {
+		return &HeuristicStats{}, nil
+	}
+	return applyShadowInfra(g, h.State.GetManagedResourceIDs()), nil
+}
+
+// applyShadowInfra marks every AWS node missing from the managed set.
+// ProjectedSavings is the known cost of unmanaged resources that are also waste.
+func applyShadowInfra(g *graph.Graph, managed map[string]bool) *HeuristicStats {
+	stats := &HeuristicStats{}
+
+	g.Mu.Lock()
+	defer g.Mu.Unlock()
+
+	for _, node := range g.Store.GetAllNodes() {
+		if !isAWSNodeType(node.TypeStr()) || tf.IsManaged(managed, node.IDStr()) {
+			continue
+		}
+		node.Properties["Unmanaged"] = true
+		stats.ItemsFound++
+		if node.IsWaste {
+			stats.ProjectedSavings += node.Cost
+		}
+	}
+	return stats
+}
+
+// isAWSNodeType matches both CloudFormation-style (AWS::EC2::Instance) and
+// Terraform-style (aws_nat_gateway) type names; scanners produce both.
+func isAWSNodeType(t string) bool {
+	return strings.HasPrefix(t, "AWS::") || strings.HasPrefix(t, "aws_")
+}
@@ -0,0 +1,153 @@
+package heuristics
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/pricing"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// Exposure levels recorded as SecurityExposure on shared images and snapshots.
+const (
+	exposurePublic   = "public"
+	exposureExternal = "external-account"
+)
+
+var accountIDPattern = regexp.MustCompile(`^\d{12}$`)
+
+// SharingAuditHeuristic flags AMIs and EBS snapshots that anyone can launch
+// or restore, and those shared with accounts outside TrustedAccounts. Either
+// exposes the disk's contents. Findings are compliance violations, not waste:
+// the fix is to revoke the grant, not to delete the image. The reason quotes
+// the storage the resource keeps paying for. Grants to an organization or OU
+// are not judged.
+type SharingAuditHeuristic struct {
+	Pricing *pricing.Client
+	Region  string // Scan region; prices resources that carry no region of their own.
+
+	// TrustedAccounts may be shared with: the scanned accounts, plus every
+	// account in the organization when OrgListed.
+	TrustedAccounts map[string]bool
+	OrgListed       bool
+}
+
+func (h *SharingAuditHeuristic) Name() string { return "SharingAuditHeuristic" }
+
+// sharingFinding is the evidence for one exposed image or snapshot.
+type sharingFinding struct {
+	Exposure string
+	External []string // Untrusted account IDs, when shared rather than public.
+	Cost     float64
+}
+
+func (h *SharingAuditHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	type candidate struct {
+		id, region string
+		finding    sharingFinding
+		sizesGB    []int
+	}
+
+	var candidates []candidate
+	g.Mu.RLock()
+	for _, node := range g.Store.GetAllNodes() {
+		var key string
+		switch node.TypeStr() {
+		case "AWS::EC2::AMI":
+			key = "LaunchPermissions"
+		case "AWS::EC2::Snapshot":
+			key = "CreateVolumePermissions"
+		default:
+			continue
+		}
+		grantees, _ := node.Properties[key].([]string)
+		public, _ := node.Properties["Public"].(bool)
+
+		c := candidate{id: node.IDStr(), region: NodeRegion(node, h.Region)}
+		if public {
+			c.finding.Exposure = exposurePublic
+		} else if c.finding.External = h.untrusted(grantees); len(c.finding.External) > 0 {
+			c.finding.Exposure = exposureExternal
+		} else {
+			continue
+		}
+
+		// An AMI's storage is the snapshots behind it.
+		if node.TypeStr() == "AWS::EC2::AMI" {
+			for _, edge := range g.Store.GetEdges(node.Index) {
+				if snap := g.Store.GetNode(edge.TargetID); snap != nil && snap.TypeStr() == "AWS::EC2::Snapshot" {
+					c.sizesGB = append(c.sizesGB, snapshotSizeGB(snap))
+				}
+			}
+		} else {
+			c.sizesGB = []int{snapshotSizeGB(node)}
+		}
+		candidates = append(candidates, c)
+	}
+	g.Mu.RUnlock()
+
+	// Pricing calls hit the network; resolve them outside the lock.
+	findings := make(map[string]sharingFinding)
+	for _, c := range candidates {
+		for _, size := range c.sizesGB {
+			c.finding.Cost += snapshotPrice(ctx, h.Pricing, c.region, size)
+		}
+		findings[c.id] = c.finding
+	}
+
+	return h.apply(g, findings), nil
+}
+
+// untrusted returns the account IDs among grantees that are not trusted.
+func (h *SharingAuditHeuristic) untrusted(grantees []string) []string {
+	var out []string
+	for _, g := range grantees {
+		if accountIDPattern.MatchString(g) && !h.TrustedAccounts[g] {
+			out = append(out, g)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// apply records exposed resources as compliance violations and notes the
+// exposure as SecurityExposure. Waste findings on the same resource are left
+// as they are.
+func (h *SharingAuditHeuristic) apply(g *graph.Graph, findings map[string]sharingFinding) *HeuristicStats {
+	stats := &HeuristicStats{}
+
+	for id, f := range findings {
+		g.Mu.Lock()
+		node := g.GetNode(id)
+		if node == nil {
+			g.Mu.Unlock()
+			continue
+		}
+		kind := "Snapshot"
+		if node.TypeStr() == "AWS::EC2::AMI" {
+			kind = "AMI"
+		}
+
+		var reason string
+		if f.Exposure == exposurePublic {
+			reason = fmt.Sprintf("SECURITY ALERT: Public %s: anyone can copy its data ($%.2f/mo storage). Recommendation: Remove public sharing.", kind, f.Cost)
+		} else {
+			scope := "the scanned accounts"
+			if h.OrgListed {
+				scope = "the organization"
+			}
+			reason = fmt.Sprintf("SECURITY ALERT: %s shared with account(s) outside %s: %s ($%.2f/mo storage). Recommendation: Revoke sharing unless the account is a known partner.",
+				kind, scope, strings.Join(f.External, ", "), f.Cost)
+		}
+
+		node.Properties["SecurityExposure"] = f.Exposure
+		g.Mu.Unlock()
+
+		g.MarkCompliance(id, reason)
+		stats.ItemsFound++
+	}
+	return stats
+}
@@ -0,0 +1,75 @@
+package heuristics
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// FindingSink receives findings one at a time as heuristics report them.
+// Sinks may buffer; Flush must be called before the scan returns.
+type FindingSink interface {
+	WriteFinding(f Finding) error
+	Flush() error
+}
+
+// SinkHandler adapts a sink for OnFindings. The sink is flushed after every
+// batch, so consumers see findings as each heuristic completes.
+func SinkHandler(s FindingSink, onErr func(error)) FindingHandler {
+	return func(findings []Finding) {
+		for _, f := range findings {
+			if err := s.WriteFinding(f); err != nil {
+				onErr(err)
+				return
+			}
+		}
+		if err := s.Flush(); err != nil {
+			onErr(err)
+		}
+	}
+}
+
+// NDJSONSink writes one JSON object per line, each tagged with an "event" field.
+// Lines are written whole, so they never interleave with other writers (e.g. JSON logs) on w.
+type NDJSONSink struct {
+	mu sync.Mutex
+	w  *bufio.Writer
+}
+
+func NewNDJSONSink(w io.Writer) *NDJSONSink {
+	return &NDJSONSink{w: bufio.NewWriter(w)}
+}
+
+// WriteFinding writes {"event":"finding", ...}.
+func (s *NDJSONSink) WriteFinding(f Finding) error {
+	return s.WriteEvent(struct {
+		Event string `json:"event"`
+		Finding
+	}{"finding", f})
+}
+
+// WriteEvent writes v as one line. v should carry its own "event" field.
+func (s *NDJSONSink) WriteEvent(v interface{}) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.w.Available() < len(line) {
+		if err := s.w.Flush(); err != nil {
+			return err
+		}
+	}
+	_, err = s.w.Write(line)
+	return err
+}
+
+func (s *NDJSONSink) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Flush()
+}
@@ -0,0 +1,106 @@
+package heuristics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// EmptyVPCHeuristic detects VPCs left behind after a teardown.
+// No direct cost, but they block CIDR reuse and clutter audits.
+type EmptyVPCHeuristic struct{}
+
+func (h *EmptyVPCHeuristic) Name() string { return "EmptyVPCHeuristic" }
+
+func (h *EmptyVPCHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	stats := &HeuristicStats{}
+
+	type finding struct {
+		node      *graph.Node
+		remaining []string
+	}
+	var findings []finding
+
+	g.Mu.RLock()
+	for _, node := range g.Store.GetAllNodes() {
+		if node.TypeStr() != "AWS::EC2::VPC" {
+			continue
+		}
+		// Default VPCs are provisioned by AWS in every region.
+		if isDefault, _ := node.Properties["IsDefault"].(bool); isDefault {
+			continue
+		}
+
+		active, remaining := inspectVPC(g, node)
+		if !active {
+			findings = append(findings, finding{node: node, remaining: remaining})
+		}
+	}
+	g.Mu.RUnlock()
+
+	for _, f := range findings {
+		reason := "Empty VPC: no running instances, load balancers, databases or endpoints"
+		if len(f.remaining) > 0 {
+			reason += fmt.Sprintf(" (remaining: %s)", strings.Join(f.remaining, ", "))
+		}
+		g.AddFinding(f.node.IDStr(), graph.Finding{Heuristic: h.Name(), Reason: reason, Score: 30})
+
+		g.Mu.Lock()
+		f.node.Properties["RemainingResources"] = f.remaining
+		g.Mu.Unlock()
+
+		stats.ItemsFound++
+	}
+	return stats, nil
+}
+
+// inspectVPC walks Contains edges below a VPC.
+// It reports whether any workload lives inside, plus the IDs of what is left.
+// Caller must hold g.Mu.
+func inspectVPC(g *graph.Graph, vpc *graph.Node) (bool, []string) {
+	var remaining []string
+	visited := map[uint32]bool{vpc.Index: true}
+	queue := []uint32{vpc.Index}
+
+	for len(queue) > 0 {
+		idx := queue[0]
+		queue = queue[1:]
+
+		for _, e := range g.Store.GetEdges(idx) {
+			if e.Type != graph.EdgeTypeContains || visited[e.TargetID] {
+				continue
+			}
+			visited[e.TargetID] = true
+
+			child := g.Store.GetNode(e.TargetID)
+			if child == nil {
+				continue
+			}
+			if isVPCWorkload(child) {
+				return true, nil
+			}
+			id := child.IDStr()
+			remaining = append(remaining, id[strings.LastIndex(id, "/")+1:])
+			queue = append(queue, e.TargetID)
+		}
+	}
+
+	sort.Strings(remaining)
+	return false, remaining
+}
+
+// isVPCWorkload reports whether a node keeps its VPC in use.
+func isVPCWorkload(n *graph.Node) bool {
+	switch n.TypeStr() {
+	case "AWS::EC2::Instance":
+		state, _ := n.Properties["State"].(string)
+		return state == "running"
+	case "aws_alb", "AWS::ElasticLoadBalancingV2::LoadBalancer",
+		"AWS::RDS::DBInstance", "aws_vpc_endpoint":
+		return true
+	}
+	return false
+}
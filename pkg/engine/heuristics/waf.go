@@ -0,0 +1,174 @@
+package heuristics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	internalaws "github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/pricing"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+const wafWindow = 14 * 24 * time.Hour
+
+// IdleWAFHeuristic flags web ACLs attached to nothing and web ACLs that
+// inspected no requests over the metric window. Both still bill for the ACL
+// and every rule on it. Regional ACLs are read in their own account and
+// region; GlobalCW must be a us-east-1 client for CloudFront-scope ACLs,
+// which are read in their account. Without CloudWatch (mock mode) the request count
+// recorded on the node is used.
+type IdleWAFHeuristic struct {
+	CW       *internalaws.CloudWatchClient
+	GlobalCW *internalaws.CloudWatchClient
+	Pricing  *pricing.Client
+	Region   string        // Scan region; prices ACLs that carry no region of their own.
+	Window   time.Duration // Metric lookback; zero means wafWindow.
+}
+
+func (h *IdleWAFHeuristic) Name() string { return "IdleWAFHeuristic" }
+
+// wafFinding is the evidence for one flagged web ACL. Requests is only
+// meaningful when Unassociated is false.
+type wafFinding struct {
+	Unassociated bool
+	Requests     float64
+	Cost         float64
+}
+
+func (h *IdleWAFHeuristic) Run(ctx context.Context, g *graph.Graph) (*HeuristicStats, error) {
+	type candidate struct {
+		id, region, dims string
+		global           bool
+		rules            int
+		unassociated     bool
+		recorded         *float64
+		cw               *internalaws.CloudWatchClient
+	}
+
+	var candidates []candidate
+	g.Mu.RLock()
+	for _, node := range g.Store.GetAllNodes() {
+		if node.TypeStr() != "AWS::WAFv2::WebACL" || node.IsWaste {
+			continue
+		}
+		// Firewall Manager recreates the ACLs it owns.
+		if fms, _ := node.Properties["ManagedByFirewallManager"].(bool); fms {
+			continue
+		}
+		c := candidate{id: node.IDStr(), region: NodeRegion(node, h.Region)}
+		c.rules, _ = node.Properties["RuleCount"].(int)
+		scope, _ := node.Properties["Scope"].(string)
+		c.global = scope == "CLOUDFRONT"
+		if c.global {
+			c.cw = h.GlobalCW.For(NodeAccount(node), "")
+		} else {
+			c.cw = scopedCW(h.CW, node)
+		}
+		complete, _ := node.Properties["AssociationsComplete"].(bool)
+		associated, _ := node.Properties["AssociatedResources"].(int)
+		c.unassociated = complete && associated == 0
+		if dims, _ := node.Properties["MetricDimensions"].([]string); len(dims) > 0 {
+			c.dims = dims[0]
+		}
+		if requests, ok := node.Properties["InspectedRequests"].(float64); ok {
+			c.recorded = &requests
+		}
+		candidates = append(candidates, c)
+	}
+	g.Mu.RUnlock()
+
+	// Metric and pricing calls hit the network; resolve them outside the lock.
+	window := metricWindow(h.Window, wafWindow)
+	now := time.Now()
+	findings := make(map[string]wafFinding)
+	for _, c := range candidates {
+		f := wafFinding{Unassociated: c.unassociated}
+		if !f.Unassociated {
+			cw := c.cw
+			switch {
+			case cw != nil && c.dims != "":
+				requests, err := h.inspected(ctx, cw, c.dims, now.Add(-window), now)
+				if err != nil {
+					// Only a successful read counts; missing data is not idleness.
+					continue
+				}
+				f.Requests = requests
+			case cw == nil && c.recorded != nil:
+				f.Requests = *c.recorded
+			default:
+				continue
+			}
+			if f.Requests > 0 {
+				continue
+			}
+		}
+		// Neither case inspects requests, so only the ACL and rule fees remain.
+		f.Cost = h.price(ctx, c.region, c.rules)
+		findings[c.id] = f
+	}
+
+	return applyIdleWAF(g, findings, window), nil
+}
+
+// inspected sums the requests a web ACL allowed and blocked. Any failed read
+// fails the ACL.
+func (h *IdleWAFHeuristic) inspected(ctx context.Context, cw *internalaws.CloudWatchClient, encoded string, start, end time.Time) (float64, error) {
+	dims := internalaws.DecodeMetricDimensions(encoded)
+	allowed, err := cw.GetMetricSum(ctx, "AWS/WAFV2", "AllowedRequests", dims, start, end)
+	if err != nil {
+		return 0, err
+	}
+	blocked, err := cw.GetMetricSum(ctx, "AWS/WAFV2", "BlockedRequests", dims, start, end)
+	if err != nil {
+		return 0, err
+	}
+	return allowed + blocked, nil
+}
+
+func (h *IdleWAFHeuristic) price(ctx context.Context, region string, rules int) float64 {
+	if h.Pricing != nil {
+		if p, err := h.Pricing.GetWAFPrice(ctx, region, rules, 0); err == nil {
+			return p
+		}
+	}
+	return pricing.EstimateWAFPrice(rules, 0)
+}
+
+// applyIdleWAF marks unassociated web ACLs as waste and ACLs that inspected
+// no traffic for review. window is the lookback requests were counted over.
+func applyIdleWAF(g *graph.Graph, findings map[string]wafFinding, window time.Duration) *HeuristicStats {
+	stats := &HeuristicStats{}
+
+	// Evidence goes on the node first, so the waste listener sees it.
+	var pending []pendingFinding
+	g.Mu.Lock()
+	for id, f := range findings {
+		node := g.GetNode(id)
+		if node == nil || node.IsWaste {
+			continue
+		}
+		name, _ := node.Properties["Name"].(string)
+		rules, _ := node.Properties["RuleCount"].(int)
+
+		finding := graph.Finding{Heuristic: "IdleWAFHeuristic", Savings: f.Cost}
+		if f.Unassociated {
+			finding.Score = 75
+			finding.Reason = fmt.Sprintf("Unused WAF Web ACL: %s (%d rules) is not associated with any resource ($%.2f/mo).",
+				name, rules, f.Cost)
+		} else {
+			// The protected resources may themselves be idle; let them be judged first.
+			finding.Score = 40
+			node.Properties["InspectedRequests"] = f.Requests
+			finding.Reason = fmt.Sprintf("Idle WAF Web ACL: %s (%d rules) inspected no requests in %s ($%.2f/mo).",
+				name, rules, windowLabel(window), f.Cost)
+		}
+		pending = append(pending, pendingFinding{id, finding})
+	}
+	g.Mu.Unlock()
+
+	for _, f := range pending {
+		stats.record(g, f.id, f.Finding)
+	}
+	return stats
+}
@@ -14,22 +14,37 @@ type AnalysisResult struct {
 	ProjectedBurn24h float64 // Projected 24h burn rate.
 	TimeToBankrupt   time.Duration
 
+	// Budget signals; zero unless a monthly budget was given.
+	Budget            float64 // $/month
+	ProjectedMonthEnd float64 // Burn rate at month end if velocity holds ($/month).
+	BudgetConsumed    float64 // CurrentBurnRate / Budget.
+	BudgetProjected   float64 // ProjectedMonthEnd / Budget.
+
 	Alerts []string
 }
 
+// OverBudget reports whether projected month-end spend exceeds the budget.
+func (r AnalysisResult) OverBudget() bool {
+	return r.Budget > 0 && r.ProjectedMonthEnd > r.Budget
+}
+
 // Analyze calculates cost trends from historical snapshots.
+// A budget > 0 adds month-end projections and an overrun alert.
 func Analyze(history []Snapshot, budget float64) AnalysisResult {
-	if len(history) < 2 {
+	if len(history) == 0 {
 		return AnalysisResult{CurrentBurnRate: 0}
 	}
 
 	current := history[len(history)-1]
+	if len(history) < 2 {
+		return withBudget(AnalysisResult{CurrentBurnRate: current.TotalMonthlyCost}, current.Timestamp, budget)
+	}
 	prev := history[len(history)-2]
 
 	// Calculate velocity.
 	timeDelta := float64(current.Timestamp-prev.Timestamp) / 3600.0
 	if timeDelta == 0 {
-		return AnalysisResult{CurrentBurnRate: current.TotalMonthlyCost}
+		return withBudget(AnalysisResult{CurrentBurnRate: current.TotalMonthlyCost}, current.Timestamp, budget)
 	}
 
 	costDelta := current.TotalMonthlyCost - prev.TotalMonthlyCost
@@ -79,12 +94,36 @@ func Analyze(history []Snapshot, budget float64) AnalysisResult {
 		alerts = append(alerts, fmt.Sprintf("[CRITICAL] BUDGET EXHAUSTION: Budget exhaustion predicted in %s", ttb.Round(time.Minute)))
 	}
 
-	return AnalysisResult{
+	return withBudget(AnalysisResult{
 		CurrentBurnRate:  current.TotalMonthlyCost,
 		Velocity:         velocity,
 		Acceleration:     acceleration,
 		ProjectedBurn24h: projectedBurn,
 		TimeToBankrupt:   ttb,
 		Alerts:           alerts,
+	}, current.Timestamp, budget)
+}
+
+// withBudget extrapolates velocity to the end of the calendar month (UTC) of
+// the latest snapshot and compares the result against budget.
+func withBudget(res AnalysisResult, timestamp int64, budget float64) AnalysisResult {
+	if budget <= 0 {
+		return res
+	}
+	now := time.Unix(timestamp, 0).UTC()
+	monthEnd := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+
+	res.Budget = budget
+	res.ProjectedMonthEnd = res.CurrentBurnRate + res.Velocity*monthEnd.Sub(now).Hours()
+	if res.ProjectedMonthEnd < 0 {
+		res.ProjectedMonthEnd = 0
+	}
+	res.BudgetConsumed = res.CurrentBurnRate / budget
+	res.BudgetProjected = res.ProjectedMonthEnd / budget
+
+	if res.OverBudget() {
+		res.Alerts = append(res.Alerts, fmt.Sprintf("[CRITICAL] BUDGET OVERRUN: Projected month-end spend $%.0f/mo exceeds the $%.0f/mo budget (%.0f%%)",
+			res.ProjectedMonthEnd, budget, res.BudgetProjected*100))
 	}
+	return res
 }
@@ -0,0 +1,41 @@
+package history
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAnalyzeBudgetProjection(t *testing.T) {
+	// 10 days before month end, spend growing $1/mo every hour.
+	now := time.Date(2026, 4, 21, 0, 0, 0, 0, time.UTC)
+	window := []Snapshot{
+		{Timestamp: now.Add(-time.Hour).Unix(), TotalMonthlyCost: 799},
+		{Timestamp: now.Unix(), TotalMonthlyCost: 800},
+	}
+
+	res := Analyze(window, 1000)
+	if want := 800.0 + 240; res.ProjectedMonthEnd != want {
+		t.Fatalf("Expected month-end projection %.0f, got %.2f", want, res.ProjectedMonthEnd)
+	}
+	if res.BudgetConsumed != 0.8 || res.BudgetProjected != 1.04 {
+		t.Errorf("Expected 80%% consumed / 104%% projected, got %.2f / %.2f", res.BudgetConsumed, res.BudgetProjected)
+	}
+	if !res.OverBudget() {
+		t.Fatal("Expected projection over budget")
+	}
+	if len(res.Alerts) != 1 || !strings.Contains(res.Alerts[0], "BUDGET OVERRUN") {
+		t.Errorf("Expected a budget overrun alert, got %v", res.Alerts)
+	}
+
+	if res := Analyze(window, 2000); res.OverBudget() || len(res.Alerts) != 0 {
+		t.Errorf("Expected no alert under budget, got %v", res.Alerts)
+	}
+	if res := Analyze(window, 0); res.Budget != 0 || res.ProjectedMonthEnd != 0 {
+		t.Error("Expected no budget signals without a budget")
+	}
+	// A single snapshot still reports consumption.
+	if res := Analyze(window[1:], 1000); res.BudgetConsumed != 0.8 || res.OverBudget() {
+		t.Errorf("Unexpected single-snapshot result %+v", res)
+	}
+}
@@ -0,0 +1,74 @@
+package history
+
+import "sort"
+
+// CostChange is a resource flagged in both snapshots whose cost moved.
+type CostChange struct {
+	ID     string  `json:"id"`
+	Type   string  `json:"type"`
+	Before float64 `json:"before"`
+	After  float64 `json:"after"`
+}
+
+// Delta is the change in waste between two snapshots.
+type Delta struct {
+	PreviousTimestamp int64        `json:"previous_timestamp"`
+	Added             []WasteEntry `json:"added"`    // Newly detected waste.
+	Resolved          []WasteEntry `json:"resolved"` // Waste that is gone or no longer flagged.
+	Changed           []CostChange `json:"changed"`
+	CostDelta         float64      `json:"cost_delta"` // Net change in monthly waste cost.
+}
+
+// Empty reports whether nothing changed.
+func (d Delta) Empty() bool {
+	return len(d.Added) == 0 && len(d.Resolved) == 0 && len(d.Changed) == 0
+}
+
+// HasResourceIDs reports whether s recorded the IDs of its waste.
+// Snapshots written before IDs were persisted only carry counts.
+func (s Snapshot) HasResourceIDs() bool {
+	return s.WasteCount == 0 || len(s.Waste) > 0
+}
+
+// Diff compares the waste of prev and current by resource ID.
+// Added and Resolved are sorted by cost, most expensive first.
+func Diff(prev, current Snapshot) Delta {
+	d := Delta{PreviousTimestamp: prev.Timestamp}
+
+	before := make(map[string]WasteEntry, len(prev.Waste))
+	for _, w := range prev.Waste {
+		before[w.ID] = w
+	}
+	seen := make(map[string]bool, len(current.Waste))
+	for _, w := range current.Waste {
+		seen[w.ID] = true
+		old, ok := before[w.ID]
+		switch {
+		case !ok:
+			d.Added = append(d.Added, w)
+			d.CostDelta += w.Cost
+		case old.Cost != w.Cost:
+			d.Changed = append(d.Changed, CostChange{ID: w.ID, Type: w.Type, Before: old.Cost, After: w.Cost})
+			d.CostDelta += w.Cost - old.Cost
+		}
+	}
+	for _, w := range prev.Waste {
+		if !seen[w.ID] {
+			d.Resolved = append(d.Resolved, w)
+			d.CostDelta -= w.Cost
+		}
+	}
+
+	byCost := func(entries []WasteEntry) {
+		sort.Slice(entries, func(i, j int) bool {
+			if entries[i].Cost != entries[j].Cost {
+				return entries[i].Cost > entries[j].Cost
+			}
+			return entries[i].ID < entries[j].ID
+		})
+	}
+	byCost(d.Added)
+	byCost(d.Resolved)
+	sort.Slice(d.Changed, func(i, j int) bool { return d.Changed[i].ID < d.Changed[j].ID })
+	return d
+}
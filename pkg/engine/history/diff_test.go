@@ -0,0 +1,79 @@
+package history
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	prev := Snapshot{Timestamp: 100, WasteCount: 3, Waste: []WasteEntry{
+		{ID: "vol-1", Type: "AWS::EC2::Volume", Cost: 8},
+		{ID: "eip-1", Type: "AWS::EC2::EIP", Cost: 3.6},
+		{ID: "nat-1", Type: "AWS::EC2::NatGateway", Cost: 32},
+	}}
+	current := Snapshot{Timestamp: 200, WasteCount: 3, Waste: []WasteEntry{
+		{ID: "vol-1", Type: "AWS::EC2::Volume", Cost: 10},
+		{ID: "nat-1", Type: "AWS::EC2::NatGateway", Cost: 32},
+		{ID: "i-1", Type: "AWS::EC2::Instance", Cost: 60},
+		{ID: "vol-2", Type: "AWS::EC2::Volume", Cost: 4},
+	}}
+
+	d := Diff(prev, current)
+	if len(d.Added) != 2 || d.Added[0].ID != "i-1" || d.Added[1].ID != "vol-2" {
+		t.Errorf("Expected i-1 and vol-2 added, most expensive first, got %+v", d.Added)
+	}
+	if len(d.Resolved) != 1 || d.Resolved[0].ID != "eip-1" {
+		t.Errorf("Expected eip-1 resolved, got %+v", d.Resolved)
+	}
+	if len(d.Changed) != 1 || d.Changed[0].ID != "vol-1" || d.Changed[0].Before != 8 || d.Changed[0].After != 10 {
+		t.Errorf("Expected vol-1 cost change 8 -> 10, got %+v", d.Changed)
+	}
+	if want := 60 + 4 + 2 - 3.6; d.CostDelta < want-0.001 || d.CostDelta > want+0.001 {
+		t.Errorf("Expected cost delta %.2f, got %.2f", want, d.CostDelta)
+	}
+	if d.PreviousTimestamp != 100 || d.Empty() {
+		t.Errorf("Unexpected delta: %+v", d)
+	}
+
+	if !Diff(current, current).Empty() {
+		t.Error("Expected no changes against the same snapshot")
+	}
+}
+
+func TestLatestRoundTripsWasteIDs(t *testing.T) {
+	c := NewClient(NewLocalBackend(filepath.Join(t.TempDir(), "ledger.jsonl")))
+
+	latest, err := c.Latest()
+	if err != nil || latest != nil {
+		t.Fatalf("Expected no snapshot in an empty ledger, got %+v (%v)", latest, err)
+	}
+
+	_ = c.Append(Snapshot{Timestamp: 1, WasteCount: 2})
+	_ = c.Append(Snapshot{Timestamp: 2, WasteCount: 1, Waste: []WasteEntry{{ID: "vol-1", Type: "AWS::EC2::Volume", Cost: 8}}})
+
+	latest, err = c.Latest()
+	if err != nil || latest == nil {
+		t.Fatalf("Failed to load latest snapshot: %v", err)
+	}
+	if latest.Timestamp != 2 || len(latest.Waste) != 1 || latest.Waste[0].ID != "vol-1" {
+		t.Errorf("Unexpected latest snapshot: %+v", latest)
+	}
+
+	if (Snapshot{WasteCount: 2}).HasResourceIDs() {
+		t.Error("A count-only snapshot should not report resource IDs")
+	}
+
+	// A snapshot line longer than bufio's 64KB default still loads.
+	big := Snapshot{Timestamp: 3}
+	for i := 0; i < 5000; i++ {
+		big.Waste = append(big.Waste, WasteEntry{ID: fmt.Sprintf("vol-%08d", i), Type: "AWS::EC2::Volume", Cost: 8})
+	}
+	big.WasteCount = len(big.Waste)
+	_ = c.Append(big)
+
+	latest, err = c.Latest()
+	if err != nil || latest == nil || len(latest.Waste) != 5000 {
+		t.Fatalf("Failed to load a large snapshot: %v", err)
+	}
+}
@@ -3,19 +3,48 @@ package history
 import (
 	"bufio"
 	"encoding/json"
+	"io"
 	"os"
 	"path/filepath"
 )
 
+// maxSnapshotLine bounds one snapshot line. A snapshot lists every waste
+// resource, so large accounts outgrow bufio's 64KB default.
+const maxSnapshotLine = 256 << 20
+
+// newSnapshotScanner reads a ledger one snapshot per line.
+func newSnapshotScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSnapshotLine)
+	return scanner
+}
+
 // Snapshot represents a point-in-time state.
 type Snapshot struct {
 	Timestamp        int64          `json:"timestamp"`
 	TotalMonthlyCost float64        `json:"monthly_cost"`
 	ResourceCounts   map[string]int `json:"resource_counts"`
 	WasteCount       int            `json:"waste_count"`
+	Waste            []WasteEntry   `json:"waste,omitempty"` // Sorted by ID.
 	Vector           Vector         `json:"-"`
 }
 
+// WasteEntry is one resource flagged as waste in a snapshot.
+type WasteEntry struct {
+	ID   string  `json:"id"`
+	Type string  `json:"type"`
+	Cost float64 `json:"cost"`
+}
+
+// Latest returns the most recent snapshot, or nil if none is stored.
+func (c *Client) Latest() (*Snapshot, error) {
+	window, err := c.backend.Load(1)
+	if err != nil || len(window) == 0 {
+		return nil, err
+	}
+	return &window[len(window)-1], nil
+}
+
 // Backend defines the storage interface for snapshots.
 type Backend interface {
 	Append(s Snapshot) error
@@ -110,7 +139,7 @@ func (b *FileBackend) Load(n int) ([]Snapshot, error) {
 	defer f.Close()
 
 	var history []Snapshot
-	scanner := bufio.NewScanner(f)
+	scanner := newSnapshotScanner(f)
 	for scanner.Scan() {
 		var s Snapshot
 		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
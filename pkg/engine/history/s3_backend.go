@@ -1,7 +1,6 @@
 package history
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -84,8 +83,9 @@ func (b *S3Backend) Load(n int) ([]Snapshot, error) {
 	return history, nil
 }
 
-func (b *S3Backend) readAll() ([]Snapshot, error) {
-	resp, err := b.Client.GetObject(context.Background(), &s3.GetObjectInput{
+// ReadObject downloads the backend's object.
+func (b *S3Backend) ReadObject(ctx context.Context) ([]byte, error) {
+	resp, err := b.Client.GetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(b.Bucket),
 		Key:    aws.String(b.Key),
 	})
@@ -93,15 +93,18 @@ func (b *S3Backend) readAll() ([]Snapshot, error) {
 		return nil, err
 	}
 	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
 
-	var history []Snapshot
-	// Read object content.
-	bodyBytes, err := io.ReadAll(resp.Body)
+func (b *S3Backend) readAll() ([]Snapshot, error) {
+	bodyBytes, err := b.ReadObject(context.Background())
 	if err != nil {
 		return nil, err
 	}
 
-	scanner := bufio.NewScanner(bytes.NewReader(bodyBytes))
+	var history []Snapshot
+
+	scanner := newSnapshotScanner(bytes.NewReader(bodyBytes))
 	for scanner.Scan() {
 		var s Snapshot
 		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
@@ -109,5 +112,8 @@ func (b *S3Backend) readAll() ([]Snapshot, error) {
 		}
 		history = append(history, s)
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
 	return history, nil
 }
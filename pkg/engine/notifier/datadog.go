@@ -0,0 +1,195 @@
+package notifier
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/report"
+)
+
+// DefaultDatadogSite is the US1 Datadog site.
+const DefaultDatadogSite = "datadoghq.com"
+
+// Metric names submitted after each scan.
+const (
+	datadogCostMetric  = "cloudslash.waste.monthly_cost"
+	datadogCountMetric = "cloudslash.waste.count"
+)
+
+// datadogGauge is the v2 series API's metric type for gauges.
+const datadogGauge = 3
+
+// DatadogClient posts the scan summary as a Datadog event and submits waste
+// totals as metrics tagged by region and account.
+type DatadogClient struct {
+	APIKey string
+	Site   string // e.g. "datadoghq.eu"; a URL is used as the API base as is.
+}
+
+// NewDatadogClient initializes the Datadog integration.
+func NewDatadogClient(apiKey, site string) *DatadogClient {
+	if site == "" {
+		site = DefaultDatadogSite
+	}
+	return &DatadogClient{APIKey: apiKey, Site: site}
+}
+
+func (d *DatadogClient) Name() string { return "datadog" }
+
+// SendAnalysisReport submits the waste metrics, then posts the summary event.
+// Both are attempted; their errors are joined.
+func (d *DatadogClient) SendAnalysisReport(summary report.Summary) error {
+	if d.APIKey == "" {
+		return nil
+	}
+	var errs []error
+	if err := d.post("/api/v2/series", d.series(summary, time.Now())); err != nil {
+		errs = append(errs, fmt.Errorf("metrics: %w", err))
+	}
+	if err := d.post("/api/v1/events", d.reportEvent(summary)); err != nil {
+		errs = append(errs, fmt.Errorf("event: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// SendBudgetAlert posts a cost velocity event.
+func (d *DatadogClient) SendBudgetAlert(alert BudgetAlert) error {
+	if d.APIKey == "" {
+		return nil
+	}
+	alertType := "warning"
+	if alert.OverBudget() {
+		alertType = "error"
+	}
+	event := map[string]interface{}{
+		"title":            "CloudSlash: Cost Velocity Alert",
+		"text":             fmt.Sprintf("%s\nVelocity: +$%.2f/mo per hour\nAcceleration: +%.2f%%", alert.headline(), alert.Velocity, alert.Acceleration),
+		"alert_type":       alertType,
+		"source_type_name": "cloudslash",
+		"tags":             []string{"source:cloudslash"},
+	}
+	return d.post("/api/v1/events", event)
+}
+
+func (d *DatadogClient) post(path string, payload interface{}) error {
+	return postJSONWithHeaders(d.apiBase()+path, map[string]string{"DD-API-KEY": d.APIKey}, payload)
+}
+
+// apiBase is the API host of the configured site.
+func (d *DatadogClient) apiBase() string {
+	site := d.Site
+	if site == "" {
+		site = DefaultDatadogSite
+	}
+	if strings.HasPrefix(site, "http://") || strings.HasPrefix(site, "https://") {
+		return strings.TrimSuffix(site, "/")
+	}
+	return "https://api." + strings.TrimPrefix(site, "app.")
+}
+
+// series builds one gauge per metric for each region and account with waste.
+// A scan without waste reports zero for the scanned region, so dashboards
+// show the drop rather than a gap.
+func (d *DatadogClient) series(summary report.Summary, now time.Time) map[string]interface{} {
+	type scope struct{ region, account string }
+	type totals struct {
+		cost  float64
+		count int
+	}
+	byScope := make(map[scope]*totals)
+	for _, f := range summary.Findings {
+		key := scope{region: f.Region, account: f.AccountID}
+		if key.region == "" {
+			key.region = summary.Region
+		}
+		t, ok := byScope[key]
+		if !ok {
+			t = &totals{}
+			byScope[key] = t
+		}
+		t.cost += f.MonthlyCost
+		t.count++
+	}
+	if len(byScope) == 0 {
+		byScope[scope{region: summary.Region}] = &totals{}
+	}
+
+	keys := make([]scope, 0, len(byScope))
+	for k := range byScope {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].region != keys[j].region {
+			return keys[i].region < keys[j].region
+		}
+		return keys[i].account < keys[j].account
+	})
+
+	var series []map[string]interface{}
+	for _, k := range keys {
+		tags := datadogTags(k.region)
+		if k.account != "" {
+			tags = append(tags, "account:"+k.account)
+		}
+		t := byScope[k]
+		series = append(series,
+			datadogPoint(datadogCostMetric, t.cost, tags, now),
+			datadogPoint(datadogCountMetric, float64(t.count), tags, now))
+	}
+	return map[string]interface{}{"series": series}
+}
+
+func datadogPoint(metric string, value float64, tags []string, now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"metric": metric,
+		"type":   datadogGauge,
+		"points": []map[string]interface{}{{"timestamp": now.Unix(), "value": value}},
+		"tags":   tags,
+	}
+}
+
+// reportEvent is the scan summary with the top findings, in Datadog's
+// event markdown.
+func (d *DatadogClient) reportEvent(summary report.Summary) map[string]interface{} {
+	var text strings.Builder
+	text.WriteString("%%% \n")
+	fmt.Fprintf(&text, "**Potential Savings:** $%.2f/mo\n", summary.TotalSavings)
+	fmt.Fprintf(&text, "**Resources Analyzed:** %d | **Inefficiencies Identified:** %d\n", summary.TotalScanned, summary.TotalWaste)
+	for i, f := range summary.Findings {
+		if i == topFindingCount {
+			break
+		}
+		fmt.Fprintf(&text, "- **%s** `%s` · %s · $%.2f/mo\n", f.Type, f.ResourceID, f.Region, f.MonthlyCost)
+	}
+	if remaining := len(summary.Findings) - topFindingCount; remaining > 0 {
+		fmt.Fprintf(&text, "\n+%d more findings in the full report.\n", remaining)
+	}
+	text.WriteString(" %%%")
+
+	alertType := "info"
+	if summary.TotalSavings > 1000 {
+		alertType = "warning"
+	}
+	return map[string]interface{}{
+		"title":            fmt.Sprintf("CloudSlash: $%.2f/mo potential savings", summary.TotalSavings),
+		"text":             text.String(),
+		"alert_type":       alertType,
+		"source_type_name": "cloudslash",
+		"tags":             datadogTags(summary.Region),
+	}
+}
+
+// datadogTags tags a submission with its source and each of a comma-separated
+// list of regions.
+func datadogTags(regions string) []string {
+	tags := []string{"source:cloudslash"}
+	for _, region := range strings.Split(regions, ",") {
+		if region = strings.TrimSpace(region); region != "" {
+			tags = append(tags, "region:"+region)
+		}
+	}
+	return tags
+}
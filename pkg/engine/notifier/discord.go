@@ -0,0 +1,96 @@
+package notifier
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/report"
+)
+
+// DiscordClient posts embeds to a Discord channel webhook.
+type DiscordClient struct {
+	WebhookURL string
+}
+
+// NewDiscordClient initializes the Discord integration.
+func NewDiscordClient(webhookURL string) *DiscordClient {
+	return &DiscordClient{WebhookURL: webhookURL}
+}
+
+func (d *DiscordClient) Name() string { return "discord" }
+
+// SendAnalysisReport posts the scan summary with the top findings.
+func (d *DiscordClient) SendAnalysisReport(summary report.Summary) error {
+	if d.WebhookURL == "" {
+		return nil
+	}
+	return postJSON(d.WebhookURL, d.reportPayload(summary))
+}
+
+// SendBudgetAlert posts a cost velocity alert.
+func (d *DiscordClient) SendBudgetAlert(alert BudgetAlert) error {
+	if d.WebhookURL == "" {
+		return nil
+	}
+	payload := map[string]interface{}{
+		"embeds": []map[string]interface{}{
+			{
+				"title":       "🔥 Cost Velocity Alert",
+				"description": fmt.Sprintf("%s\n**Velocity:** +$%.2f/mo per hour\n**Acceleration:** +%.2f%%", alert.headline(), alert.Velocity, alert.Acceleration),
+				"color":       embedColor(severityColor(1000)),
+			},
+		},
+	}
+	return postJSON(d.WebhookURL, payload)
+}
+
+func (d *DiscordClient) reportPayload(summary report.Summary) map[string]interface{} {
+	var findings strings.Builder
+	for i, f := range summary.Findings {
+		if i == topFindingCount {
+			break
+		}
+		fmt.Fprintf(&findings, "**%s** `%s` · %s · **$%.2f/mo** · [console](%s)\n",
+			f.Type, f.ResourceID, f.Region, f.MonthlyCost, report.ConsoleURL(f.Type, f.ResourceID, f.Region))
+	}
+	if remaining := len(summary.Findings) - topFindingCount; remaining > 0 {
+		fmt.Fprintf(&findings, "+%d more findings in the full report.\n", remaining)
+	}
+
+	fields := []map[string]interface{}{
+		{"name": "Total Potential Savings", "value": fmt.Sprintf("$%.2f/mo", summary.TotalSavings), "inline": true},
+		{"name": "Resources Analyzed", "value": fmt.Sprintf("%d", summary.TotalScanned), "inline": true},
+		{"name": "Inefficiencies Identified", "value": fmt.Sprintf("%d", summary.TotalWaste), "inline": true},
+	}
+	if findings.Len() > 0 {
+		// Discord rejects field values over 1024 characters.
+		value := findings.String()
+		if len(value) > 1024 {
+			value = value[:1021] + "..."
+		}
+		fields = append(fields, map[string]interface{}{"name": "Top Findings", "value": value})
+	}
+
+	return map[string]interface{}{
+		"content": fmt.Sprintf("CloudSlash: $%.2f/mo potential savings across %d findings", summary.TotalSavings, summary.TotalWaste),
+		"embeds": []map[string]interface{}{
+			{
+				"title":  fmt.Sprintf("Potential Savings: $%.2f/mo", summary.TotalSavings),
+				"color":  embedColor(severityColor(summary.TotalSavings)),
+				"fields": fields,
+				"footer": map[string]interface{}{"text": fmt.Sprintf("Scan Date: %s | Region: %s", time.Now().Format("2006-01-02"), summary.Region)},
+			},
+		},
+	}
+}
+
+// embedColor converts a "#RRGGBB" color to the integer Discord expects.
+func embedColor(hex string) int {
+	v, err := strconv.ParseInt(strings.TrimPrefix(hex, "#"), 16, 32)
+	if err != nil {
+		return 0
+	}
+	return int(v)
+}
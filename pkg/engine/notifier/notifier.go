@@ -0,0 +1,142 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/report"
+)
+
+// Notifier delivers scan results to a chat channel.
+type Notifier interface {
+	Name() string
+	SendAnalysisReport(summary report.Summary) error
+	SendBudgetAlert(alert BudgetAlert) error
+}
+
+// BudgetAlert carries the cost signals behind a budget alert.
+type BudgetAlert struct {
+	Velocity          float64 // $/mo per hour
+	Acceleration      float64
+	Budget            float64 // Monthly budget; zero when none was set.
+	ProjectedMonthEnd float64 // $/mo
+}
+
+// OverBudget reports whether projected month-end spend exceeds the budget.
+func (a BudgetAlert) OverBudget() bool {
+	return a.Budget > 0 && a.ProjectedMonthEnd > a.Budget
+}
+
+// headline describes why the alert fired.
+func (a BudgetAlert) headline() string {
+	if a.OverBudget() {
+		return fmt.Sprintf("Projected month-end spend of $%.2f/mo exceeds the $%.2f/mo budget (%.0f%%).",
+			a.ProjectedMonthEnd, a.Budget, a.ProjectedMonthEnd/a.Budget*100)
+	}
+	return "Spend is accelerating dangerously."
+}
+
+// Fanout sends every message to all of its notifiers concurrently.
+// A failing or slow channel does not hold up the others, and each outcome is logged separately.
+type Fanout struct {
+	Notifiers []Notifier
+	Logger    *slog.Logger // Defaults to slog.Default().
+}
+
+func (f *Fanout) Name() string { return "fanout" }
+
+// SendAnalysisReport sends the summary to every channel and joins their errors.
+func (f *Fanout) SendAnalysisReport(summary report.Summary) error {
+	return f.each("analysis report", func(n Notifier) error {
+		return n.SendAnalysisReport(summary)
+	})
+}
+
+// SendBudgetAlert sends the alert to every channel and joins their errors.
+func (f *Fanout) SendBudgetAlert(alert BudgetAlert) error {
+	return f.each("budget alert", func(n Notifier) error {
+		return n.SendBudgetAlert(alert)
+	})
+}
+
+func (f *Fanout) each(message string, send func(Notifier) error) error {
+	logger := f.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	errs := make([]error, len(f.Notifiers))
+	var wg sync.WaitGroup
+	for i, n := range f.Notifiers {
+		wg.Add(1)
+		go func(i int, n Notifier) {
+			defer wg.Done()
+			if err := send(n); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", n.Name(), err)
+				logger.Warn("Notification failed", "channel", n.Name(), "message", message, "error", err)
+				return
+			}
+			logger.Info("Notification delivered", "channel", n.Name(), "message", message)
+		}(i, n)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// maxReasonLength caps the reason shown with each finding.
+const maxReasonLength = 200
+
+// findingReason flattens a finding's reason onto one line and truncates it.
+func findingReason(f report.ExportItem) string {
+	return truncate(strings.Join(strings.Fields(f.AuditDetail), " "), maxReasonLength)
+}
+
+// truncate shortens s to at most limit characters, marking the cut with an ellipsis.
+func truncate(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit-1]) + "…"
+}
+
+// postJSON posts payload to a webhook and expects a 2xx response.
+func postJSON(url string, payload interface{}) error {
+	return postJSONWithHeaders(url, nil, payload)
+}
+
+// postJSONWithHeaders is postJSON for APIs that authenticate by header.
+func postJSONWithHeaders(url string, headers map[string]string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("received non-2xx status from webhook: %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package notifier
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/report"
+)
+
+type recordingNotifier struct {
+	name string
+	err  error
+
+	mu      sync.Mutex
+	reports int
+	alerts  int
+}
+
+func (r *recordingNotifier) Name() string { return r.name }
+
+func (r *recordingNotifier) SendAnalysisReport(report.Summary) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reports++
+	return r.err
+}
+
+func (r *recordingNotifier) SendBudgetAlert(BudgetAlert) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.alerts++
+	return r.err
+}
+
+func TestFanoutContinuesPastFailures(t *testing.T) {
+	failing := &recordingNotifier{name: "teams", err: errors.New("boom")}
+	ok := &recordingNotifier{name: "discord"}
+	f := &Fanout{Notifiers: []Notifier{failing, ok}}
+
+	err := f.SendAnalysisReport(testSummary(1))
+	if err == nil || !strings.Contains(err.Error(), "teams: boom") {
+		t.Errorf("Expected the failing channel's error, got %v", err)
+	}
+	if failing.reports != 1 || ok.reports != 1 {
+		t.Errorf("Expected every channel to be called once, got %d and %d", failing.reports, ok.reports)
+	}
+
+	if err := f.SendBudgetAlert(BudgetAlert{Velocity: 10, Acceleration: 30}); err == nil {
+		t.Error("Expected budget alert error from the failing channel")
+	}
+	if ok.alerts != 1 {
+		t.Errorf("Expected budget alert on the healthy channel, got %d", ok.alerts)
+	}
+}
+
+func TestTeamsSendsAdaptiveCard(t *testing.T) {
+	var got map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	if err := NewTeamsClient(srv.URL).SendAnalysisReport(testSummary(7)); err != nil {
+		t.Fatalf("SendAnalysisReport failed: %v", err)
+	}
+
+	attachments, _ := got["attachments"].([]interface{})
+	if len(attachments) != 1 {
+		t.Fatalf("Expected one attachment, got %v", got)
+	}
+	att := attachments[0].(map[string]interface{})
+	if att["contentType"] != "application/vnd.microsoft.card.adaptive" {
+		t.Errorf("Unexpected content type %v", att["contentType"])
+	}
+	card := att["content"].(map[string]interface{})
+	body := card["body"].([]interface{})
+	// Header, date line, fact set, top findings and the overflow note.
+	if want := 3 + topFindingCount + 1; len(body) != want {
+		t.Errorf("Expected %d card elements, got %d", want, len(body))
+	}
+}
+
+func TestDiscordSendsEmbed(t *testing.T) {
+	var got map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	if err := NewDiscordClient(srv.URL).SendAnalysisReport(testSummary(2)); err != nil {
+		t.Fatalf("SendAnalysisReport failed: %v", err)
+	}
+
+	embeds, _ := got["embeds"].([]interface{})
+	if len(embeds) != 1 {
+		t.Fatalf("Expected one embed, got %v", got)
+	}
+	embed := embeds[0].(map[string]interface{})
+	if embed["color"].(float64) != float64(embedColor("#E01E5A")) {
+		t.Errorf("Expected critical color for $1990/mo, got %v", embed["color"])
+	}
+	fields := embed["fields"].([]interface{})
+	top := fields[len(fields)-1].(map[string]interface{})
+	if !strings.Contains(top["value"].(string), "i-001") {
+		t.Errorf("Expected top findings field, got %v", top)
+	}
+}
+
+func TestDiscordReportsHTTPErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	if err := NewDiscordClient(srv.URL).SendBudgetAlert(BudgetAlert{Velocity: 1, Acceleration: 2}); err == nil {
+		t.Error("Expected an error for a 400 response")
+	}
+}
+
+func TestDatadogSubmitsMetricsAndEvent(t *testing.T) {
+	bodies := make(map[string]map[string]interface{})
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("DD-API-KEY") != "key" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var got map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&got)
+		mu.Lock()
+		bodies[r.URL.Path] = got
+		mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	s := testSummary(3)
+	s.Findings[2].Region = "eu-west-1"
+	s.Findings[2].AccountID = "123456789012"
+	if err := NewDatadogClient("key", srv.URL).SendAnalysisReport(s); err != nil {
+		t.Fatalf("SendAnalysisReport failed: %v", err)
+	}
+
+	series, _ := bodies["/api/v2/series"]["series"].([]interface{})
+	if len(series) != 4 {
+		t.Fatalf("Expected cost and count for two scopes, got %v", bodies["/api/v2/series"])
+	}
+	eu := series[0].(map[string]interface{})
+	if eu["metric"] != "cloudslash.waste.monthly_cost" {
+		t.Errorf("Unexpected first metric %v", eu["metric"])
+	}
+	tags := strings.Join(toStrings(eu["tags"]), ",")
+	if tags != "source:cloudslash,region:eu-west-1,account:123456789012" {
+		t.Errorf("Unexpected tags %s", tags)
+	}
+	points := eu["points"].([]interface{})
+	if v := points[0].(map[string]interface{})["value"]; v != float64(980) {
+		t.Errorf("Expected eu-west-1 cost 980, got %v", v)
+	}
+	count := series[3].(map[string]interface{})
+	if count["metric"] != "cloudslash.waste.count" || count["points"].([]interface{})[0].(map[string]interface{})["value"] != float64(2) {
+		t.Errorf("Expected 2 findings in us-east-1, got %v", count)
+	}
+
+	event := bodies["/api/v1/events"]
+	if event == nil || !strings.Contains(event["title"].(string), "$2970.00/mo") || !strings.Contains(event["text"].(string), "i-000") {
+		t.Errorf("Unexpected event %v", event)
+	}
+}
+
+func TestDatadogReportsFailures(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	err := NewDatadogClient("bad", srv.URL).SendAnalysisReport(testSummary(0))
+	if err == nil || !strings.Contains(err.Error(), "metrics") || !strings.Contains(err.Error(), "event") {
+		t.Errorf("Expected both submissions to fail, got %v", err)
+	}
+	if err := NewDatadogClient("", srv.URL).SendBudgetAlert(BudgetAlert{}); err != nil {
+		t.Errorf("Expected no submission without an API key, got %v", err)
+	}
+	if got := NewDatadogClient("key", "").apiBase(); got != "https://api.datadoghq.com" {
+		t.Errorf("Unexpected default API base %s", got)
+	}
+}
+
+func toStrings(v interface{}) []string {
+	var out []string
+	for _, s := range v.([]interface{}) {
+		out = append(out, s.(string))
+	}
+	return out
+}
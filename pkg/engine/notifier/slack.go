@@ -5,15 +5,33 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/report"
 )
 
+const (
+	slackPostMessageURL = "https://slack.com/api/chat.postMessage"
+	topFindingCount     = 5  // Findings shown in the channel message.
+	followUpChunkSize   = 20 // Findings per threaded follow-up.
+
+	// Slack rejects messages beyond these limits.
+	slackHeaderLimit  = 150  // Characters in a header block.
+	slackSectionLimit = 3000 // Characters in a section or context text.
+	slackMaxFindings  = 20   // Finding attachments per message.
+)
+
+// slackEscaper escapes the characters mrkdwn treats as control sequences.
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
 // SlackClient handles Slack notifications.
 type SlackClient struct {
 	WebhookURL string
 	Channel    string // Optional: Override default channel
+	Token      string // Optional: Bot token; enables threaded follow-ups via chat.postMessage.
+
+	apiURL string // Overridden in tests.
 }
 
 // NewSlackClient initializes the Slack integration.
@@ -21,44 +39,130 @@ func NewSlackClient(webhookURL string, channel string) *SlackClient {
 	return &SlackClient{
 		WebhookURL: webhookURL,
 		Channel:    channel,
+		apiURL:     slackPostMessageURL,
 	}
 }
 
-// SendAnalysisReport sends a summary.
+func (s *SlackClient) Name() string { return "slack" }
+
+// threaded reports whether replies can be posted in a thread.
+// Incoming webhooks do not return a message timestamp, so threading needs a bot token.
+func (s *SlackClient) threaded() bool {
+	return s.Token != "" && s.Channel != ""
+}
+
+// SendAnalysisReport posts the scan summary with the most expensive findings.
 func (s *SlackClient) SendAnalysisReport(summary report.Summary) error {
-	if s.WebhookURL == "" {
+	return s.SendDetailedReport(summary, summary.TopByCost(topFindingCount))
+}
+
+// SendDetailedReport posts the scan summary with topFindings as blocks
+// carrying each finding's type, ID, cost and reason, so responders can
+// triage from the channel. At most slackMaxFindings are shown.
+// With a bot token, the remaining findings follow as thread replies.
+// If Slack rejects the Block Kit message, a plain-text report is sent instead.
+func (s *SlackClient) SendDetailedReport(summary report.Summary, topFindings []report.ExportItem) error {
+	if s.WebhookURL == "" && !s.threaded() {
 		return nil
 	}
+	if len(topFindings) > slackMaxFindings {
+		topFindings = topFindings[:slackMaxFindings]
+	}
 
-	payload := s.constructPayload(summary)
+	ts, err := s.post(s.constructPayload(summary, topFindings))
+	if err != nil {
+		var fallbackErr error
+		ts, fallbackErr = s.post(s.plainPayload(plainTextReport(summary, topFindings)))
+		if fallbackErr != nil {
+			return fmt.Errorf("failed to send slack report: %v (plain-text fallback: %v)", err, fallbackErr)
+		}
+	}
 
+	if ts == "" {
+		return nil
+	}
+	for _, text := range followUps(summary, topFindings) {
+		payload := s.plainPayload(text)
+		payload["thread_ts"] = ts
+		if _, err := s.post(payload); err != nil {
+			return fmt.Errorf("failed to send slack follow-up: %v", err)
+		}
+	}
+	return nil
+}
+
+// post sends a payload and returns the message timestamp, if Slack provides one.
+func (s *SlackClient) post(payload map[string]interface{}) (string, error) {
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
-		return fmt.Errorf("failed to marshal slack payload: %w", err)
+		return "", fmt.Errorf("failed to marshal slack payload: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", s.WebhookURL, bytes.NewBuffer(jsonPayload))
+	url := s.WebhookURL
+	if s.threaded() {
+		url = s.apiURL
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonPayload))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if s.threaded() {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
 	}
-	req.Header.Set("Content-Type", "application/json")
 
 	client := &http.Client{Timeout: 10 * time.Second}
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send webhook: %w", err)
+		return "", fmt.Errorf("failed to send webhook: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("received non-200 status from slack: %d", resp.StatusCode)
+		return "", fmt.Errorf("received non-200 status from slack: %d", resp.StatusCode)
+	}
+	if !s.threaded() {
+		return "", nil
 	}
 
-	return nil
+	// The Web API reports errors in the body with a 200 status.
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+		TS    string `json:"ts"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode slack response: %w", err)
+	}
+	if !result.OK {
+		return "", fmt.Errorf("slack api error: %s", result.Error)
+	}
+	return result.TS, nil
+}
+
+// plainPayload wraps text in a message payload.
+func (s *SlackClient) plainPayload(text string) map[string]interface{} {
+	payload := map[string]interface{}{"text": text}
+	if s.Channel != "" {
+		payload["channel"] = s.Channel
+	}
+	return payload
 }
 
-// constructPayload builds the message blocks.
-func (s *SlackClient) constructPayload(summary report.Summary) map[string]interface{} {
+// severityColor maps a finding's monthly cost to an attachment color bar.
+func severityColor(monthly float64) string {
+	switch {
+	case monthly >= 500:
+		return "#E01E5A" // Critical
+	case monthly >= 100:
+		return "#ECB22E" // High
+	default:
+		return "#2EB67D" // Low
+	}
+}
+
+// constructPayload builds the message blocks, with top as the highlighted findings.
+func (s *SlackClient) constructPayload(summary report.Summary, top []report.ExportItem) map[string]interface{} {
 	// Determine status icon.
 	statusIcon := "🟢"
 	if summary.TotalSavings > 1000 {
@@ -73,7 +177,7 @@ func (s *SlackClient) constructPayload(summary report.Summary) map[string]interf
 			"type": "header",
 			"text": map[string]interface{}{
 				"type": "plain_text",
-				"text": fmt.Sprintf("%s Infrastructure Optimization Report", statusIcon),
+				"text": truncate(fmt.Sprintf("%s Potential Savings: $%.2f/mo", statusIcon, summary.TotalSavings), slackHeaderLimit),
 			},
 		},
 		// Context: Date & Region
@@ -82,7 +186,7 @@ func (s *SlackClient) constructPayload(summary report.Summary) map[string]interf
 			"elements": []map[string]interface{}{
 				{
 					"type": "mrkdwn",
-					"text": fmt.Sprintf("*Scan Date:* %s | *Region:* %s", time.Now().Format("2006-01-02"), summary.Region),
+					"text": truncate(fmt.Sprintf("*Scan Date:* %s | *Region:* %s", time.Now().Format("2006-01-02"), summary.Region), slackSectionLimit),
 				},
 			},
 		},
@@ -121,9 +225,55 @@ func (s *SlackClient) constructPayload(summary report.Summary) map[string]interf
 		})
 	}
 
+	// Top findings, each in an attachment so it gets a severity color bar.
+	var attachments []map[string]interface{}
+	for _, f := range top {
+		text := fmt.Sprintf("*%s*\n`%s` · %s · *$%.2f/mo*", f.Type, f.ResourceID, f.Region, f.MonthlyCost)
+		if reason := findingReason(f); reason != "" {
+			text += "\n>" + slackEscaper.Replace(reason)
+		}
+		attachments = append(attachments, map[string]interface{}{
+			"color": severityColor(f.MonthlyCost),
+			"blocks": []map[string]interface{}{
+				{
+					"type": "section",
+					"text": map[string]interface{}{
+						"type": "mrkdwn",
+						"text": truncate(text, slackSectionLimit),
+					},
+					"accessory": map[string]interface{}{
+						"type": "button",
+						"text": map[string]interface{}{
+							"type": "plain_text",
+							"text": "View in Console",
+						},
+						"url": report.ConsoleURL(f.Type, f.ResourceID, f.Region),
+					},
+				},
+			},
+		})
+	}
+
+	if remaining := len(summary.Findings) - len(top); remaining > 0 {
+		note := fmt.Sprintf("+%d more findings in the full report.", remaining)
+		if s.threaded() {
+			note = fmt.Sprintf("+%d more findings in the thread.", remaining)
+		}
+		blocks = append(blocks, map[string]interface{}{
+			"type": "context",
+			"elements": []map[string]interface{}{
+				{"type": "mrkdwn", "text": note},
+			},
+		})
+	}
+
 	payload := map[string]interface{}{
+		"text":   fmt.Sprintf("CloudSlash: $%.2f/mo potential savings across %d findings", summary.TotalSavings, summary.TotalWaste),
 		"blocks": blocks,
 	}
+	if len(attachments) > 0 {
+		payload["attachments"] = attachments
+	}
 
 	if s.Channel != "" {
 		payload["channel"] = s.Channel
@@ -132,8 +282,54 @@ func (s *SlackClient) constructPayload(summary report.Summary) map[string]interf
 	return payload
 }
 
+// plainTextReport is the fallback when Block Kit is rejected.
+func plainTextReport(summary report.Summary, top []report.ExportItem) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Infrastructure Optimization Report (%s, %s)\n", summary.Region, time.Now().Format("2006-01-02"))
+	fmt.Fprintf(&b, "Potential Savings: $%.2f/mo | Resources Analyzed: %d | Inefficiencies: %d\n",
+		summary.TotalSavings, summary.TotalScanned, summary.TotalWaste)
+	for _, f := range top {
+		fmt.Fprintf(&b, "• %s %s ($%.2f/mo) %s\n", f.Type, f.ResourceID, f.MonthlyCost, report.ConsoleURL(f.Type, f.ResourceID, f.Region))
+		if reason := findingReason(f); reason != "" {
+			fmt.Fprintf(&b, "  %s\n", reason)
+		}
+	}
+	return b.String()
+}
+
+// followUps lists the findings not shown in top, chunked into thread replies.
+func followUps(summary report.Summary, top []report.ExportItem) []string {
+	shown := make(map[string]bool, len(top))
+	for _, f := range top {
+		shown[f.ResourceID] = true
+	}
+	var rest []report.ExportItem
+	for _, f := range summary.Findings {
+		if !shown[f.ResourceID] {
+			rest = append(rest, f)
+		}
+	}
+
+	var msgs []string
+	for start := 0; start < len(rest); start += followUpChunkSize {
+		end := start + followUpChunkSize
+		if end > len(rest) {
+			end = len(rest)
+		}
+		var b strings.Builder
+		for _, f := range rest[start:end] {
+			fmt.Fprintf(&b, "• %s `%s` (%s) $%.2f/mo\n", f.Type, f.ResourceID, f.Region, f.MonthlyCost)
+		}
+		msgs = append(msgs, b.String())
+	}
+	return msgs
+}
+
 // SendBudgetAlert sends a cost velocity alert.
-func (s *SlackClient) SendBudgetAlert(velocity float64, acceleration float64) error {
+func (s *SlackClient) SendBudgetAlert(alert BudgetAlert) error {
+	if s.WebhookURL == "" && !s.threaded() {
+		return nil
+	}
 	payload := map[string]interface{}{
 		"blocks": []map[string]interface{}{
 			{
@@ -147,7 +343,7 @@ func (s *SlackClient) SendBudgetAlert(velocity float64, acceleration float64) er
 				"type": "section",
 				"text": map[string]interface{}{
 					"type": "mrkdwn",
-					"text": fmt.Sprintf("Spend is accelerating dangerously.\n*Velocity:* +$%.2f/mo per hour\n*Acceleration:* +%.2f%%", velocity, acceleration),
+					"text": fmt.Sprintf("%s\n*Velocity:* +$%.2f/mo per hour\n*Acceleration:* +%.2f%%", alert.headline(), alert.Velocity, alert.Acceleration),
 				},
 			},
 		},
@@ -161,14 +357,6 @@ func (s *SlackClient) SendBudgetAlert(velocity float64, acceleration float64) er
 }
 
 func (s *SlackClient) send(payload map[string]interface{}) error {
-	jsonPayload, _ := json.Marshal(payload)
-	req, _ := http.NewRequest("POST", s.WebhookURL, bytes.NewBuffer(jsonPayload))
-	req.Header.Set("Content-Type", "application/json")
-	client := &http.Client{Timeout: 5 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	return nil
+	_, err := s.post(payload)
+	return err
 }
@@ -0,0 +1,166 @@
+package notifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/report"
+)
+
+func testSummary(n int) report.Summary {
+	s := report.Summary{Region: "us-east-1", TotalScanned: 100, TotalWaste: n}
+	for i := 0; i < n; i++ {
+		cost := float64(1000 - i*10)
+		s.Findings = append(s.Findings, report.ExportItem{
+			ResourceID:  fmt.Sprintf("i-%03d", i),
+			Type:        "AWS::EC2::Instance",
+			Region:      "us-east-1",
+			MonthlyCost: cost,
+		})
+		s.TotalSavings += cost
+	}
+	return s
+}
+
+func TestConstructPayload_BlockKit(t *testing.T) {
+	s := NewSlackClient("https://hooks.example", "")
+	summary := testSummary(8)
+	payload := s.constructPayload(summary, summary.TopByCost(topFindingCount))
+
+	attachments, ok := payload["attachments"].([]map[string]interface{})
+	if !ok || len(attachments) != topFindingCount {
+		t.Fatalf("Expected %d finding attachments, got %v", topFindingCount, payload["attachments"])
+	}
+	if attachments[0]["color"] != "#E01E5A" {
+		t.Errorf("Expected critical color on $1000 finding, got %v", attachments[0]["color"])
+	}
+
+	data, _ := json.Marshal(payload)
+	if !strings.Contains(string(data), "InstanceDetails:instanceId=i-000") {
+		t.Error("Expected console button URL for top finding")
+	}
+	if !strings.Contains(string(data), "+3 more findings in the full report.") {
+		t.Error("Expected overflow note for webhook delivery")
+	}
+}
+
+func TestSendAnalysisReport_ThreadsFollowUps(t *testing.T) {
+	var bodies []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer xoxb-test" {
+			t.Errorf("Missing bot token, got %q", r.Header.Get("Authorization"))
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		fmt.Fprint(w, `{"ok":true,"ts":"1700000000.000100"}`)
+	}))
+	defer srv.Close()
+
+	s := NewSlackClient("", "#finops")
+	s.Token = "xoxb-test"
+	s.apiURL = srv.URL
+
+	if err := s.SendAnalysisReport(testSummary(5 + followUpChunkSize + 1)); err != nil {
+		t.Fatalf("SendAnalysisReport failed: %v", err)
+	}
+	if len(bodies) != 3 {
+		t.Fatalf("Expected 1 message and 2 thread replies, got %d posts", len(bodies))
+	}
+	for _, reply := range bodies[1:] {
+		if reply["thread_ts"] != "1700000000.000100" {
+			t.Errorf("Expected reply in thread, got thread_ts=%v", reply["thread_ts"])
+		}
+	}
+}
+
+func TestSendAnalysisReport_FallsBackToPlainText(t *testing.T) {
+	var posts []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		posts = append(posts, body)
+		if _, hasBlocks := body["blocks"]; hasBlocks {
+			w.WriteHeader(http.StatusBadRequest) // invalid_blocks
+			return
+		}
+	}))
+	defer srv.Close()
+
+	s := NewSlackClient(srv.URL, "")
+	if err := s.SendAnalysisReport(testSummary(2)); err != nil {
+		t.Fatalf("Expected plain-text fallback to succeed, got %v", err)
+	}
+	if len(posts) != 2 {
+		t.Fatalf("Expected Block Kit attempt and plain-text retry, got %d posts", len(posts))
+	}
+	if text, _ := posts[1]["text"].(string); !strings.Contains(text, "i-000") {
+		t.Errorf("Expected findings in plain-text report, got %q", text)
+	}
+}
+
+func TestSendDetailedReport_RanksByCostWithReasons(t *testing.T) {
+	var posts []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		posts = append(posts, body)
+	}))
+	defer srv.Close()
+
+	// Caution grouping puts the cheap safe-delete finding first.
+	summary := report.Summary{Region: "us-east-1", TotalWaste: 2, Findings: []report.ExportItem{
+		{ResourceID: "vol-cheap", Type: "AWS::EC2::Volume", MonthlyCost: 5, AuditDetail: "Unattached"},
+		{ResourceID: "db-pricey", Type: "AWS::RDS::DBInstance", MonthlyCost: 900,
+			AuditDetail: "Idle: CPU < 1% & no connections\n" + strings.Repeat("x", 500)},
+	}}
+
+	s := NewSlackClient(srv.URL, "")
+	if err := s.SendAnalysisReport(summary); err != nil {
+		t.Fatalf("SendAnalysisReport failed: %v", err)
+	}
+	if len(posts) != 1 {
+		t.Fatalf("Expected 1 post, got %d", len(posts))
+	}
+
+	attachments, _ := posts[0]["attachments"].([]interface{})
+	if len(attachments) != 2 {
+		t.Fatalf("Expected 2 finding attachments, got %v", posts[0]["attachments"])
+	}
+	blocks := attachments[0].(map[string]interface{})["blocks"].([]interface{})
+	text := blocks[0].(map[string]interface{})["text"].(map[string]interface{})["text"].(string)
+	if !strings.Contains(text, "db-pricey") {
+		t.Errorf("Expected most expensive finding first, got %q", text)
+	}
+	if !strings.Contains(text, ">Idle: CPU &lt; 1% &amp; no connections x") {
+		t.Errorf("Expected escaped single-line reason, got %q", text)
+	}
+	if !strings.HasSuffix(text, "…") || len([]rune(text)) > slackSectionLimit {
+		t.Errorf("Expected truncated reason, got %d chars", len([]rune(text)))
+	}
+}
+
+func TestSendDetailedReport_CapsFindings(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+	}))
+	defer srv.Close()
+
+	summary := testSummary(slackMaxFindings + 5)
+	s := NewSlackClient(srv.URL, "")
+	if err := s.SendDetailedReport(summary, summary.Findings); err != nil {
+		t.Fatalf("SendDetailedReport failed: %v", err)
+	}
+	if attachments, _ := body["attachments"].([]interface{}); len(attachments) != slackMaxFindings {
+		t.Errorf("Expected %d attachments, got %d", slackMaxFindings, len(attachments))
+	}
+	data, _ := json.Marshal(body)
+	if !strings.Contains(string(data), "+5 more findings in the full report.") {
+		t.Error("Expected overflow note for findings beyond the cap")
+	}
+}
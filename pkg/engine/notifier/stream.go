@@ -0,0 +1,54 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/heuristics"
+)
+
+// StreamClient posts findings to a webhook as they are discovered.
+type StreamClient struct {
+	WebhookURL string
+}
+
+// NewStreamClient initializes the streaming webhook.
+func NewStreamClient(webhookURL string) *StreamClient {
+	return &StreamClient{WebhookURL: webhookURL}
+}
+
+// SendFindings posts a batch as newline-delimited JSON.
+func (s *StreamClient) SendFindings(findings []heuristics.Finding) error {
+	if s.WebhookURL == "" || len(findings) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, f := range findings {
+		if err := enc.Encode(f); err != nil {
+			return fmt.Errorf("failed to encode finding: %w", err)
+		}
+	}
+
+	req, err := http.NewRequest("POST", s.WebhookURL, &buf)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("received non-2xx status from stream webhook: %d", resp.StatusCode)
+	}
+	return nil
+}
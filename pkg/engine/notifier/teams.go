@@ -0,0 +1,102 @@
+package notifier
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/report"
+)
+
+// TeamsClient posts Adaptive Cards to a Microsoft Teams incoming webhook or Workflows URL.
+type TeamsClient struct {
+	WebhookURL string
+}
+
+// NewTeamsClient initializes the Teams integration.
+func NewTeamsClient(webhookURL string) *TeamsClient {
+	return &TeamsClient{WebhookURL: webhookURL}
+}
+
+func (t *TeamsClient) Name() string { return "teams" }
+
+// SendAnalysisReport posts the scan summary with the top findings.
+func (t *TeamsClient) SendAnalysisReport(summary report.Summary) error {
+	if t.WebhookURL == "" {
+		return nil
+	}
+	return postJSON(t.WebhookURL, adaptiveCardMessage(t.reportCard(summary)))
+}
+
+// SendBudgetAlert posts a cost velocity alert.
+func (t *TeamsClient) SendBudgetAlert(alert BudgetAlert) error {
+	if t.WebhookURL == "" {
+		return nil
+	}
+	body := []map[string]interface{}{
+		{"type": "TextBlock", "text": "🔥 Cost Velocity Alert", "size": "Large", "weight": "Bolder", "color": "Attention"},
+		{"type": "TextBlock", "text": alert.headline(), "wrap": true},
+		{"type": "FactSet", "facts": []map[string]string{
+			{"title": "Velocity", "value": fmt.Sprintf("+$%.2f/mo per hour", alert.Velocity)},
+			{"title": "Acceleration", "value": fmt.Sprintf("+%.2f%%", alert.Acceleration)},
+		}},
+	}
+	return postJSON(t.WebhookURL, adaptiveCardMessage(body))
+}
+
+// reportCard builds the Adaptive Card body of the analysis report.
+func (t *TeamsClient) reportCard(summary report.Summary) []map[string]interface{} {
+	color := "Good"
+	if summary.TotalSavings > 1000 {
+		color = "Attention"
+	} else if summary.TotalSavings > 0 {
+		color = "Warning"
+	}
+
+	body := []map[string]interface{}{
+		{"type": "TextBlock", "text": fmt.Sprintf("Potential Savings: $%.2f/mo", summary.TotalSavings), "size": "Large", "weight": "Bolder", "color": color},
+		{"type": "TextBlock", "text": fmt.Sprintf("Scan Date: %s | Region: %s", time.Now().Format("2006-01-02"), summary.Region), "isSubtle": true, "spacing": "None"},
+		{"type": "FactSet", "facts": []map[string]string{
+			{"title": "Total Potential Savings", "value": fmt.Sprintf("$%.2f/mo", summary.TotalSavings)},
+			{"title": "Resources Analyzed", "value": fmt.Sprintf("%d", summary.TotalScanned)},
+			{"title": "Inefficiencies Identified", "value": fmt.Sprintf("%d", summary.TotalWaste)},
+		}},
+	}
+
+	top := summary.TopByCost(topFindingCount)
+	for _, f := range top {
+		text := fmt.Sprintf("**%s** `%s` · %s · **$%.2f/mo** · [View in Console](%s)",
+			f.Type, f.ResourceID, f.Region, f.MonthlyCost, report.ConsoleURL(f.Type, f.ResourceID, f.Region))
+		if reason := findingReason(f); reason != "" {
+			text += "\n\n" + reason
+		}
+		body = append(body, map[string]interface{}{
+			"type": "TextBlock",
+			"wrap": true,
+			"text": text,
+		})
+	}
+	if remaining := len(summary.Findings) - len(top); remaining > 0 {
+		body = append(body, map[string]interface{}{
+			"type": "TextBlock", "text": fmt.Sprintf("+%d more findings in the full report.", remaining), "isSubtle": true,
+		})
+	}
+	return body
+}
+
+// adaptiveCardMessage wraps a card body in the message envelope Teams webhooks expect.
+func adaptiveCardMessage(body []map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"type": "message",
+		"attachments": []map[string]interface{}{
+			{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]interface{}{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body":    body,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,63 @@
+package oracle
+
+// MaxAcceptableRisk is the threshold above which the solver rejects a placement.
+const MaxAcceptableRisk = 0.5
+
+// WorkloadProfile describes a proposed change to a workload.
+type WorkloadProfile struct {
+	Zone         string
+	InstanceType string
+
+	Stateful   bool
+	CrossAZ    bool
+	Spot       bool
+	ArchChange bool
+	Production bool
+}
+
+// RiskFactor is a single weighted contribution to a risk score.
+type RiskFactor struct {
+	Name    string
+	Weight  float64
+	Applied bool
+}
+
+// RiskAssessment is the full risk breakdown for a workload.
+type RiskAssessment struct {
+	Base     float64
+	Factors  []RiskFactor
+	Total    float64
+	Accepted bool
+}
+
+// Assess scores a proposed change using the configured weights.
+// The pool's interruption history forms the base; each applicable factor adds its weight.
+func (re *RiskEngine) Assess(p WorkloadProfile) RiskAssessment {
+	base := re.GetRisk(p.Zone, p.InstanceType)
+	w := re.Config.Weights
+
+	factors := []RiskFactor{
+		{Name: "Statefulness", Weight: w.Statefulness, Applied: p.Stateful},
+		{Name: "Cross-AZ Move", Weight: w.CrossAZ, Applied: p.CrossAZ},
+		{Name: "Spot Placement", Weight: w.Spot, Applied: p.Spot},
+		{Name: "Architecture Change", Weight: w.ArchChange, Applied: p.ArchChange},
+		{Name: "Production Tag", Weight: w.Production, Applied: p.Production},
+	}
+
+	total := base
+	for _, f := range factors {
+		if f.Applied {
+			total += f.Weight
+		}
+	}
+	if total > 1.0 {
+		total = 1.0
+	}
+
+	return RiskAssessment{
+		Base:     base,
+		Factors:  factors,
+		Total:    total,
+		Accepted: total <= MaxAcceptableRisk,
+	}
+}
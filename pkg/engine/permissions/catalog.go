@@ -9,11 +9,15 @@ var Catalog = map[string][]string{
 		"ec2:DescribeAddresses",
 		"ec2:DescribeSnapshots",
 		"ec2:DescribeImages",
+		"ec2:DescribeSnapshotAttribute", // Sharing audit
+		"ec2:DescribeImageAttribute",    // Sharing audit
+		"organizations:ListAccounts",    // Sharing audit: trusted accounts
 		"ec2:DescribeVolumesModifications",
 		"ec2:DescribeInstanceTypes",
 		"ec2:DescribeSecurityGroups",
 		"ec2:DescribeSubnets",
 		"ec2:DescribeVpcs",
+		"ec2:DescribeSpotPriceHistory", // --allow-spot
 	},
 	"S3": {
 		"s3:ListAllMyBuckets",
@@ -21,7 +25,8 @@ var Catalog = map[string][]string{
 		"s3:GetBucketTagging",
 		"s3:GetBucketVersioning",
 		"s3:GetLifecycleConfiguration",
-		"s3:ListBucket", // For determining size/object count
+		"s3:GetEncryptionConfiguration", // --compliance
+		"s3:ListBucket",                 // For determining size/object count
 	},
 	"IAM": {
 		"iam:ListUsers",
@@ -30,6 +35,7 @@ var Catalog = map[string][]string{
 		"iam:ListAccessKeys",
 		"iam:GetUser",
 		"iam:GetRole",
+		"iam:SimulatePrincipalPolicy", // --check-policy
 	},
 	"RDS": {
 		"rds:DescribeDBInstances",
@@ -48,10 +54,110 @@ var Catalog = map[string][]string{
 		"lambda:ListFunctions",
 		"lambda:GetFunction", // For runtime details
 		"lambda:ListTags",
+		"lambda:ListProvisionedConcurrencyConfigs", // Idle provisioned concurrency
+		"lambda:ListVersionsByFunction",            // Version pruning
+		"lambda:ListAliases",
 	},
 	"CloudWatch": {
 		"cloudwatch:GetMetricData",
-		"cloudwatch:ListMetrics",
+		"cloudwatch:GetMetricStatistics",
+		"cloudwatch:ListMetrics", // CWAgent disk_used_percent dimensions
+	},
+	"CloudWatchLogs": {
+		"logs:DescribeLogGroups",
+		"logs:StartQuery",      // --flow-logs
+		"logs:GetQueryResults", // --flow-logs
+	},
+	"Tagging": {
+		"tag:GetResources", // verify-ignore, preview-tags
+	},
+	"ComputeOptimizer": {
+		"compute-optimizer:GetEC2InstanceRecommendations",
+	},
+	"CostExplorer": {
+		"ce:GetCostAndUsage",              // cost-explorer
+		"ce:GetCostAndUsageWithResources", // cost-explorer
+	},
+	"CodeBuild": {
+		"codebuild:ListProjects",
+		"codebuild:BatchGetProjects",
+		"codebuild:ListBuildsForProject",
+		"codebuild:BatchGetBuilds",
+	},
+	"CodePipeline": {
+		"codepipeline:ListPipelines",
+		"codepipeline:GetPipeline",
+		"codepipeline:ListPipelineExecutions",
+	},
+	"EFS": {
+		"elasticfilesystem:DescribeFileSystems",
+		"elasticfilesystem:DescribeLifecycleConfiguration",
+	},
+	"SageMaker": {
+		"sagemaker:ListEndpoints",
+		"sagemaker:DescribeEndpoint",
+		"sagemaker:DescribeEndpointConfig",
+	},
+	"Comprehend": {
+		"comprehend:ListEndpoints",
+	},
+	"Rekognition": {
+		"rekognition:DescribeProjects",
+		"rekognition:DescribeProjectVersions",
+	},
+	"DMS": {
+		"dms:DescribeReplicationInstances",
+		"dms:DescribeReplicationTasks",
+	},
+	"ElastiCache": {
+		"elasticache:DescribeCacheClusters",
+		"elasticache:DescribeReservedCacheNodes",
+	},
+	"KMS": {
+		"kms:ListKeys",
+		"kms:ListAliases",
+		"kms:DescribeKey",
+		"kms:GetKeyRotationStatus",
+		"kms:ListKeyRotations",
+		"kms:ListResourceTags",
+		"cloudtrail:LookupEvents", // Last use
+	},
+	"Redshift": {
+		"redshift:DescribeClusters",
+	},
+	"DynamoDB": {
+		"dynamodb:ListTables",
+		"dynamodb:DescribeTable",
+		"application-autoscaling:DescribeScalingPolicies",
+	},
+	"SQS": {
+		"sqs:ListQueues",
+		"sqs:GetQueueAttributes",
+	},
+	"SNS": {
+		"sns:ListTopics",
+		"sns:GetTopicAttributes",
+		"sns:ListSubscriptionsByTopic",
+	},
+	"OpenSearch": {
+		"es:ListDomainNames",
+		"es:DescribeDomains",
+	},
+	"CloudFront": {
+		"cloudfront:ListDistributions",
+		"elasticloadbalancing:DescribeLoadBalancers", // Origin resolution
+	},
+	"WAF": {
+		"wafv2:ListWebACLs",
+		"wafv2:GetWebACL",
+		"wafv2:ListResourcesForWebACL",
+		"cloudfront:ListDistributionsByWebACLId",
+	},
+	"Route53": {
+		"route53:ListHostedZones",
+		"route53:ListResourceRecordSets",             // EIP DNS references, dangling records
+		"elasticloadbalancing:DescribeLoadBalancers", // Alias target resolution
+		"cloudfront:ListDistributions",
 	},
 }
 
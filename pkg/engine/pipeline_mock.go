@@ -4,12 +4,11 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"time"
+	"strings"
 
 	internalconfig "github.com/DrSkyle/cloudslash/v2/pkg/config"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/heuristics"
-	"github.com/DrSkyle/cloudslash/v2/pkg/engine/notifier"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/remediation"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/report"
 	"github.com/DrSkyle/cloudslash/v2/pkg/providers/tf"
@@ -19,15 +18,26 @@ func runMockMode(ctx context.Context, e *Engine) {
 	fmt.Println("DEBUG: Starting Mock Mode...")
 	mockScanner := aws.NewMockScanner(e.Graph)
 
+	// Read the --diff baseline before the seeded history lands on top of it.
+	previous := e.previousSnapshot()
+
 	// Seed data.
 	fmt.Println("DEBUG: Seeding mock data...")
 	e.History.SeedMockData()
 
 	fmt.Println("DEBUG: Running Mock Scanner...")
 	mockScanner.Scan(ctx)
+	if e.config.IncludeMessaging {
+		mockScanner.ScanMessaging(ctx)
+		aws.LinkSubscriptions(e.Graph)
+	}
+	aws.LinkDNSRecords(e.Graph)
+	aws.LinkKMSKeys(e.Graph)
+	e.warnOnCycles()
 
 	// Register heuristics.
-	heuristicEngine := heuristics.NewEngine()
+	heuristicEngine := e.newHeuristicEngine()
+	heuristicEngine.OnFindings(e.findingHandler())
 	heuristicEngine.Register(&heuristics.UnattachedVolumeHeuristic{Config: internalconfig.DefaultHeuristicConfig().UnattachedVolume})
 	heuristicEngine.Register(&heuristics.S3MultipartHeuristic{Config: internalconfig.DefaultHeuristicConfig().S3Multipart})
 	heuristicEngine.Register(&heuristics.IdleClusterHeuristic{Config: internalconfig.DefaultHeuristicConfig().IdleCluster})
@@ -36,6 +46,18 @@ func runMockMode(ctx context.Context, e *Engine) {
 	heuristicEngine.Register(&heuristics.GhostNodeGroupHeuristic{})
 	heuristicEngine.Register(&heuristics.ElasticIPHeuristic{})
 	heuristicEngine.Register(&heuristics.RDSHeuristic{})
+	heuristicEngine.Register(&heuristics.IdleEFSHeuristic{})
+	heuristicEngine.Register(&heuristics.IdleOpenSearchHeuristic{})
+	heuristicEngine.Register(&heuristics.IdleElastiCacheHeuristic{})
+	if e.config.IncludeMessaging {
+		heuristicEngine.Register(&heuristics.IdleMessagingHeuristic{})
+	}
+	heuristicEngine.Register(&heuristics.DanglingDNSHeuristic{})
+	heuristicEngine.Register(&heuristics.CloudFrontHeuristic{})
+	heuristicEngine.Register(&heuristics.IdleWAFHeuristic{})
+	heuristicEngine.Register(&heuristics.DynamoDBHeuristic{})
+	heuristicEngine.Register(&heuristics.RedshiftHeuristic{})
+	heuristicEngine.Register(&heuristics.KMSKeyHeuristic{})
 	heuristicEngine.Register(&heuristics.AgedAMIHeuristic{})
 
 	heuristicEngine.Register(&heuristics.NetworkForensicsHeuristic{})
@@ -51,31 +73,113 @@ func runMockMode(ctx context.Context, e *Engine) {
 	// Init policies.
 	if e.config.RulesFile != "" {
 		e.Logger.Info("Initializing Policy Engine", "rules_file", e.config.RulesFile)
-		if err := runPolicyEngine(ctx, e.config.RulesFile, e.Graph); err != nil {
+		if err := runPolicyEngine(ctx, e.config.RulesFile, e.config.CacheDir, e.Graph); err != nil {
 			e.Logger.Error("Policy Engine failed", "error", err)
 		}
 	}
 
-	hEngine2 := heuristics.NewEngine()
+	hEngine2 := e.newHeuristicEngine()
 	hEngine2.Register(&heuristics.SnapshotChildrenHeuristic{})
+	hEngine2.Register(&heuristics.OrphanedSnapshotHeuristic{Config: internalconfig.DefaultHeuristicConfig().OrphanedSnapshot})
+	hEngine2.Register(&heuristics.NATInstanceHeuristic{})
+	if rules, err := heuristics.LoadDeprecationRules(e.config.DeprecationsFile); err != nil {
+		e.Logger.Warn("Deprecation rules unavailable", "error", err)
+	} else {
+		hEngine2.Register(&heuristics.DeprecationHeuristic{Rules: rules})
+	}
+	if e.config.CostAllocationTags != "" {
+		hEngine2.Register(&heuristics.TagComplianceHeuristic{CostAllocationTags: strings.Split(e.config.CostAllocationTags, ",")})
+	}
+	if e.config.Compliance {
+		hEngine2.Register(&heuristics.EncryptionComplianceHeuristic{})
+	}
 	hEngine2.Run(ctx, e.Graph)
 
+	hEngine3 := e.newHeuristicEngine()
+	hEngine3.Register(&heuristics.SharingAuditHeuristic{TrustedAccounts: map[string]bool{"123456789012": true}})
+	hEngine3.Run(ctx, e.Graph)
+	heuristics.ApplyEnvironment(e.Graph, e.config.EnvTag)
+
 	// Finalize graph.
 	e.Graph.CloseAndWait()
 
+	if e.config.PlanOnly {
+		fmt.Println(" -> Plan-only mode: skipping report and remediation artifacts.")
+	} else {
+		e.writeMockArtifacts()
+	}
+
+	if e.config.MetricsFile != "" {
+		if err := report.GeneratePrometheus(e.Graph, e.config.MetricsFile); err != nil {
+			fmt.Printf("Failed to write Prometheus metrics: %v\n", err)
+		}
+	}
+
+	// Report summary.
+	snapshot := takeSnapshot(e.Graph)
+	summary := report.Summarize(e.Graph, e.config.Region)
+	summary.Diff = e.diffSinceLastScan(previous, snapshot)
+	e.summary = &summary
+
+	// CI decoration.
+	ci := report.NewCIDecorator(e.Logger)
+	if err := ci.Run(summary, e.Graph); err != nil {
+		e.Logger.Error("CI Decoration failed", "error", err)
+	}
+
+	// Chat notifications.
+	if e.Notifier != nil && e.config.Headless {
+		fmt.Println(" -> Transmitting Cost Report to chat channels (MOCK)...")
+		e.Notifier.SendAnalysisReport(summary)
+	}
+	// Analyze.
+	performSignalAnalysis(snapshot, e.Notifier, e.History, e.config.Budget)
+
+	// E2E check.
+	if os.Getenv("CLOUDSLASH_E2E") == "true" {
+		fmt.Println("[E2E] Verifying Graph Integrity...")
+		e.Graph.Mu.RLock()
+		nodeCount := len(e.Graph.Store.GetAllNodes())
+		e.Graph.Mu.RUnlock()
+
+		// Expect at least 1 mock resource (we seed ~7 in mock.go)
+		if nodeCount < 5 {
+			fmt.Printf("[E2E] FAILURE: Expected >5 nodes, got %d\n", nodeCount)
+			os.Exit(1)
+		}
+		fmt.Println("[E2E] SUCCESS: Graph state valid.")
+	}
+}
+
+// writeMockArtifacts writes the mock run's reports, scripts and plans to outputDir.
+func (e *Engine) writeMockArtifacts() {
 	os.Mkdir(e.outputDir, 0755)
 
 	// Generate outputs.
 	report.GenerateCSV(e.Graph, e.outputDir+"/waste_report.csv")
 	report.GenerateJSON(e.Graph, e.outputDir+"/waste_report.json")
+	if e.config.FOCUS {
+		if err := report.GenerateFOCUS(e.Graph, e.outputDir+"/focus_report.csv"); err != nil {
+			fmt.Printf("Failed to generate FOCUS report: %v\n", err)
+		}
+	}
+	if e.config.CostCenterTag != "" {
+		e.writeChargeback()
+	}
 
 	// Generate dashboard.
-	if err := report.GenerateDashboard(e.Graph, e.outputDir+"/dashboard.html"); err != nil {
+	if err := report.GenerateDashboard(e.Graph, e.outputDir+"/dashboard.html", e.config.Report); err != nil {
 		fmt.Printf("Failed to generate dashboard: %v\n", err)
 	}
 
+	if e.config.SankeyJSON {
+		if err := report.GenerateSankeyJSON(e.Graph, e.outputDir+"/topology_sankey.json"); err != nil {
+			fmt.Printf("Failed to generate Sankey JSON: %v\n", err)
+		}
+	}
+
 	// Generate static HTML report (CI Requirement).
-	if err := report.GenerateHTML(e.Graph, e.outputDir+"/report.html"); err != nil {
+	if err := report.GenerateHTML(e.Graph, e.outputDir+"/report.html", e.config.Report); err != nil {
 		fmt.Printf("Failed to generate HTML report: %v\n", err)
 	}
 
@@ -87,65 +191,19 @@ func runMockMode(ctx context.Context, e *Engine) {
 	// Generate artifacts.
 	gen.GenerateWasteTF(e.outputDir + "/waste.tf")
 	gen.GenerateImportScript(e.outputDir + "/import.sh")
-	gen.GenerateDestroyPlan(e.outputDir + "/destroy_plan.out")
+	gen.GenerateDestroyPlan(e.outputDir + "/destroy_plan.sh")
+	os.Chmod(e.outputDir+"/destroy_plan.sh", 0755)
 
 	// Generate plans.
 	remGen := remediation.NewGenerator(e.Graph, e.Logger)
+	remGen.ScanID = e.scanID
 	remGen.GenerateRemediationPlan(e.outputDir + "/remediation_plan.json")
 	remGen.GenerateIgnorePlan(e.outputDir + "/ignore_plan.json")
 	remGen.GenerateRestorationPlan(e.outputDir + "/restoration_plan.json")
+	remGen.GenerateCloudFormationDriftReport(e.outputDir + "/cfn_drift_report.yaml")
 
 	// Generate summary.
-	report.GenerateExecutiveSummary(e.Graph, e.outputDir+"/executive_summary.md", fmt.Sprintf("cs-mock-%d", time.Now().Unix()), "MOCK-ACCOUNT-123")
-
-	// Report summary.
-	count := len(e.Graph.GetNodes())
-	
-	summary := report.Summary{
-		Region:       e.config.Region,
-		TotalScanned: count,
-		TotalWaste:   0,
-		TotalSavings: 0,
-	}
-
-	e.Graph.Mu.RLock()
-	nodes := e.Graph.Store.GetAllNodes()
-	for _, n := range nodes {
-		if n.IsWaste {
-			summary.TotalWaste++
-			summary.TotalSavings += n.Cost
-		}
-	}
-	e.Graph.Mu.RUnlock()
-
-	// CI decoration.
-	ci := report.NewCIDecorator(e.Logger)
-	if err := ci.Run(summary, e.Graph); err != nil {
-		e.Logger.Error("CI Decoration failed", "error", err)
-	}
-
-	// Slack notification.
-	var slackClient *notifier.SlackClient
-	if e.config.SlackWebhook != "" && e.config.Headless {
-		fmt.Println(" -> Transmitting Cost Report to Slack (MOCK)...")
-		slackClient = notifier.NewSlackClient(e.config.SlackWebhook, e.config.SlackChannel)
-		slackClient.SendAnalysisReport(summary)
-	}
-	// Analyze.
-	performSignalAnalysis(e.Graph, slackClient, e.History)
-
-	// E2E check.
-	if os.Getenv("CLOUDSLASH_E2E") == "true" {
-		fmt.Println("[E2E] Verifying Graph Integrity...")
-		e.Graph.Mu.RLock()
-		nodeCount := len(e.Graph.Store.GetAllNodes())
-		e.Graph.Mu.RUnlock()
-
-		// Expect at least 1 mock resource (we seed ~7 in mock.go)
-		if nodeCount < 5 {
-			fmt.Printf("[E2E] FAILURE: Expected >5 nodes, got %d\n", nodeCount)
-			os.Exit(1)
-		}
-		fmt.Println("[E2E] SUCCESS: Graph state valid.")
+	if err := report.WriteSummary(e.Graph, e.outputDir+"/executive_summary.md", e.scanID, "MOCK-ACCOUNT-123", e.config.SummaryTemplate); err != nil {
+		fmt.Printf("Failed to generate executive summary: %v\n", err)
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -12,11 +13,11 @@ import (
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/aws"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/forensics"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/heuristics"
-	"github.com/DrSkyle/cloudslash/v2/pkg/engine/notifier"
-	"github.com/DrSkyle/cloudslash/v2/pkg/engine/pricing"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/remediation"
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/report"
+	"github.com/DrSkyle/cloudslash/v2/pkg/providers/cfn"
 	"github.com/DrSkyle/cloudslash/v2/pkg/providers/k8s"
+	"github.com/DrSkyle/cloudslash/v2/pkg/providers/pulumi"
 	"github.com/DrSkyle/cloudslash/v2/pkg/providers/tf"
 )
 
@@ -25,38 +26,76 @@ func runRealPipeline(ctx context.Context, e *Engine) <-chan struct{} {
 	var err error
 
 	// Init pricing.
-	if e.Pricing == nil {
-		profile := os.Getenv("AWS_PROFILE")
-		e.Pricing, err = pricing.NewClient(ctx, e.Logger, e.config.CacheDir, e.config.DiscountRate, profile)
-		if err != nil {
-			e.Logger.Warn("Pricing Client initialization failed", "error", err)
-		}
-	}
+	e.initPricing(ctx)
 
-	profiles := []string{""}
-	if e.config.AllProfiles {
-		var err error
-		profiles, err = aws.ListProfiles()
+	var targets []scanTarget
+	if providerEnabled(e.config.Provider, "aws") {
+		targets, err = e.scanTargets(ctx)
 		if err != nil {
-			e.Logger.Warn("Failed to list profiles. Using default", "error", err)
-			profiles = []string{"default"}
-		} else {
-			e.Logger.Info("Deep Scanning enabled", "profiles", len(profiles))
+			e.Logger.Error("Cannot resolve accounts to scan", "error", err)
 		}
 	}
 
 	var scanWg sync.WaitGroup
 	var cwClient *aws.CloudWatchClient
+	var globalCWClient *aws.CloudWatchClient // us-east-1, for global services such as CloudFront.
 	var iamClient *aws.IAMClient
 	var ctClient *aws.CloudTrailClient
-	var logsClient *aws.CloudWatchLogsClient
 	var ecsScanner *aws.ECSScanner
 	var ecrScanner *aws.ECRScanner
+	var logsClients []*aws.CloudWatchLogsClient // One per scope.
+	var ecrScanners []*aws.ECRScanner
+	var coClient *aws.ComputeOptimizerClient
+	var ceClient *aws.CostExplorerClient
+	var orgClient *aws.Client // First target's credentials; under --org, the management account's.
+	var principal string // IAM principal for --check-policy simulation
+
+	// CloudTrail answers are shared by the detective and any CloudTrail-backed heuristics.
+	var trailCache *forensics.TrailCache
+	if !e.config.DisableTrailCache {
+		trailCache = forensics.NewTrailCache()
+	}
+
+	// A fresh cached graph replaces Phase 1 scanning; clients are still
+	// needed for the analysis phases.
+	var cachePath string
+	cached := false
+	if e.config.CacheGraph && len(targets) > 0 {
+		cachePath = e.graphCachePath(targets)
+		g, age, err := loadCachedGraph(cachePath, e.config.CacheGraphTTL)
+		if err != nil {
+			e.Logger.Warn("Graph cache unreadable, rescanning", "error", err)
+		} else if g != nil {
+			e.Logger.Info("Using cached graph", "path", cachePath, "age", age.Round(time.Second).String(), "nodes", g.Store.NodeCount())
+			e.Graph.CloseAndWait()
+			e.Graph = g
+			cached = true
+		}
+	}
+
+	// Checkpointing scans each scope into its own graph so it can be persisted.
+	// Multi-account scans do the same in memory, so every resource is
+	// attributed to the account it was scanned in.
+	var cp *checkpointer
+	if !cached && (e.config.Checkpoint || e.config.Resume) {
+		cp, err = newCheckpointer(checkpointDir, e.config.Resume)
+		if err != nil {
+			e.Logger.Warn("Checkpointing disabled", "error", err)
+		}
+	}
+	if !cached && cp == nil && len(targets) > 1 {
+		cp, _ = newCheckpointer("", false)
+	}
+	if cp != nil {
+		cp.qualify = len(targets) > 1
+	}
 
 	// Phase 1.
-	for _, profile := range profiles {
-		if e.config.AllProfiles {
-			e.Logger.Info("Scanning Profile", "profile", profile)
+	var accounts []string
+	var lastAccount string
+	for _, target := range targets {
+		if len(targets) > 1 {
+			e.Logger.Info("Scanning Account", "target", target.String())
 		}
 
 		regions := strings.Split(e.config.Region, ",")
@@ -66,51 +105,128 @@ func runRealPipeline(ctx context.Context, e *Engine) <-chan struct{} {
 				continue
 			}
 
-			client, err := runScanForProfile(ctx, region, profile, e.config.Verbose, e.Graph, e.Swarm, &scanWg)
+			var client *aws.Client
+			if cached {
+				client, err = e.connectScope(ctx, region, target)
+			} else if cp != nil {
+				client, err = e.scanScopeWithCheckpoint(ctx, cp, region, target, &scanWg)
+			} else {
+				client, err = runScanForProfile(ctx, region, target, e.config.Verbose, e.config.IncludeMessaging, e.config.Compliance, e.config.Heuristics.MetricWindow, e.scopes.skippedScanners(), e.Graph, e.Swarm, &scanWg)
+			}
 			if err != nil {
-				e.Logger.Error("Scan failed", "profile", profile, "region", region, "error", err)
+				e.Logger.Error("Scan failed", "target", target.String(), "region", region, "error", err)
 				continue
 			}
 
 			if client != nil {
-				cwClient = aws.NewCloudWatchClient(client.Config)
+				if !slices.Contains(accounts, client.AccountID) {
+					accounts = append(accounts, client.AccountID)
+				}
+				lastAccount = client.AccountID
+				if orgClient == nil {
+					orgClient = client
+				}
+				// Metrics are read with the account and region of each node.
+				if cwClient == nil {
+					cwClient = aws.NewCloudWatchClient(client.Config)
+				}
+				cwClient.AddAccount(client.AccountID, client.Config)
 				iamClient = aws.NewIAMClient(client.Config)
 				ctClient = aws.NewCloudTrailClient(client.Config)
-				logsClient = aws.NewCloudWatchLogsClient(client.Config, e.Graph, e.config.DisableCWMetrics)
+				logsClient := aws.NewCloudWatchLogsClient(client.Config, e.Graph, e.config.DisableCWMetrics)
+				logsClient.AccountID = client.AccountID
+				logsClients = append(logsClients, logsClient)
 				ecsScanner = aws.NewECSScanner(client.Config, e.Graph)
 				ecrScanner = aws.NewECRScanner(client.Config, e.Graph)
+				ecrScanners = append(ecrScanners, ecrScanner)
+				// The first target is the management account under --org, whose
+				// Cost Explorer covers every linked account.
+				if e.config.CostExplorer && ceClient == nil {
+					ceClient = aws.NewCostExplorerClient(client.Config)
+				}
+				if e.config.ComputeOptimizer {
+					coClient = aws.NewComputeOptimizerClient(client.Config)
+				}
+				if e.config.CheckPolicy {
+					principal = e.config.RemediationPrincipal
+					if principal == "" {
+						if arn, err := client.CallerARN(ctx); err == nil {
+							principal = aws.SimulationPrincipal(arn)
+						}
+					}
+				}
 			}
 		}
 	}
 
+	if !cached && providerEnabled(e.config.Provider, "gcp") {
+		if err := runGCPScan(ctx, e.config.GCPProject, e.Graph, e.Swarm, &scanWg); err != nil {
+			e.Logger.Error("Scan failed", "provider", "gcp", "error", err)
+		}
+	}
+	if !cached && providerEnabled(e.config.Provider, "azure") {
+		if err := runAzureScan(ctx, e.config.AzureSubscription, e.Graph, e.Swarm, &scanWg); err != nil {
+			e.Logger.Error("Scan failed", "provider", "azure", "error", err)
+		}
+	}
+
 	go func() {
 		defer close(done)
 		scanWg.Wait()
+		if ctx.Err() != nil {
+			// Interrupted: the graph is incomplete, so skip analysis and reports.
+			return
+		}
+
+		if cp != nil {
+			cp.Refresh(e.Graph)
+		}
 
 		// Finalize ingestion.
 		// NOTE: We do NOT close the graph here as heuristics may need to add edges.
 		// e.Graph.CloseAndWait()
 
-		if logsClient != nil {
-			logsClient.ScanLogGroups(context.Background())
+		if !cached && !e.scopes.skipsScanner("ScanLogGroups") {
+			for _, lc := range logsClients {
+				lc.ScanLogGroups(context.Background())
+			}
 		}
 
-		if ecrScanner != nil {
-			ecrScanner.ScanRepositories(context.Background())
+		if !cached && !e.scopes.skipsScanner("ScanRepositories") {
+			for _, ec := range ecrScanners {
+				ec.ScanRepositories(context.Background())
+			}
+		}
+		globalCWClient = cwClient.For("", "us-east-1")
+
+		// Isolated scopes are already attributed; anything else was scanned
+		// with the last client.
+		e.Graph.Flush()
+		stampAccount(e.Graph, lastAccount)
+		e.accounts = accounts
+
+		// Cache scan output only, before analysis marks anything.
+		if cachePath != "" && !cached {
+			e.saveCachedGraph(cachePath)
 		}
 
 		// Reconcile state.
 		var state *tf.State
-		state, err = tf.LoadState(context.Background(), e.config.TFStatePath)
-		if err == nil {
-			detector := tf.NewDriftDetector(e.Graph, state)
-			detector.ScanForDrift()
-
-			cwd, _ := os.Getwd()
+		cwd, _ := os.Getwd()
+		if iacTool(e.config.IaC, cwd) == "pulumi" {
+			if pState, err := pulumi.LoadState(context.Background(), e.config.PulumiStatePath); err != nil {
+				e.Logger.Warn("Pulumi state unavailable", "error", err)
+			} else {
+				managed, unmanaged := pulumi.NewDriftDetector(e.Graph, pState).ScanForDrift()
+				e.Logger.Info("Reconciled against Pulumi state", "managed", managed, "unmanaged", unmanaged)
+			}
+		} else if state, err = tf.LoadState(context.Background(), e.config.TFStatePath); err == nil {
+			linked := tf.NewDriftDetector(e.Graph, state).LinkAddresses()
+			e.Logger.Info("Reconciled against Terraform state", "managed", linked)
 			auditor := tf.NewCodeAuditor(state)
 
 			e.Graph.Mu.Lock()
-			for _, node := range e.Graph.GetNodes() {
+			for _, node := range e.Graph.Store.GetAllNodes() {
 				if node.IsWaste {
 					file, line, err := auditor.FindSource(node.IDStr(), cwd)
 					if err == nil {
@@ -121,21 +237,48 @@ func runRealPipeline(ctx context.Context, e *Engine) <-chan struct{} {
 			e.Graph.Mu.Unlock()
 		}
 
+		// EIP heuristics read DNS references; link them before any heuristic runs.
+		aws.LinkDNSRecords(e.Graph)
+		if e.config.IncludeMessaging {
+			aws.LinkSubscriptions(e.Graph)
+		}
+		e.warnOnCycles()
+
+		// KMS heuristics read which keys resources reference and when CloudTrail last saw them used.
+		aws.LinkKMSKeys(e.Graph)
+		detective := forensics.NewDetective(ctClient)
+		detective.Cache = trailCache
+		detective.TraceKeyUsage(ctx, e.Graph, lastAccount)
+
 		// Phase 2.
-		hEngine := heuristics.NewEngine()
+		// Nodes are priced in their own region; region covers nodes that carry none.
+		region := e.scanRegion()
+		hEngine := e.newHeuristicEngine()
+		hEngine.OnFindings(e.findingHandler())
 
+		// Zero keeps each heuristic's own default lookback.
+		window := e.config.Heuristics.MetricWindow
 		if cwClient != nil {
-			hEngine.Register(&heuristics.RDSHeuristic{CW: cwClient})
+			hEngine.Register(&heuristics.RDSHeuristic{CW: cwClient, Window: window})
+			hEngine.Register(&heuristics.IdleReadReplicaHeuristic{CW: cwClient, Pricing: e.Pricing, Window: window})
+			hEngine.Register(&heuristics.IdleMLEndpointHeuristic{CW: cwClient, Window: window})
+			hEngine.Register(&heuristics.IdleDMSHeuristic{CW: cwClient, Pricing: e.Pricing, Window: window})
+			hEngine.Register(&heuristics.IdleElastiCacheHeuristic{CW: cwClient, Pricing: e.Pricing, Region: region, Window: window})
+			hEngine.Register(&heuristics.OverallocatedVolumeHeuristic{CW: cwClient, Pricing: e.Pricing, Region: region, Window: window})
+			hEngine.Register(&heuristics.OverprovisionedGP3Heuristic{CW: cwClient, Pricing: e.Pricing, Region: region, Window: window})
 			if e.Pricing != nil {
-				hEngine.Register(&heuristics.UnderutilizedInstanceHeuristic{CW: cwClient, Pricing: e.Pricing})
+				hEngine.Register(&heuristics.UnderutilizedInstanceHeuristic{CW: cwClient, Pricing: e.Pricing, Region: region, Window: window})
 			}
 		}
 
 		if e.Pricing != nil {
-			hEngine.Register(&heuristics.UnattachedVolumeHeuristic{Pricing: e.Pricing, Config: e.config.Heuristics.UnattachedVolume})
+			hEngine.Register(&heuristics.UnattachedVolumeHeuristic{Pricing: e.Pricing, Config: e.config.Heuristics.UnattachedVolume, Region: region})
 		} else {
 			hEngine.Register(&heuristics.UnattachedVolumeHeuristic{Config: e.config.Heuristics.UnattachedVolume})
 		}
+		if providerEnabled(e.config.Provider, "gcp") {
+			hEngine.Register(&heuristics.IdleGCPInstanceHeuristic{})
+		}
 
 		if e.config.RequiredTags != "" {
 			hEngine.Register(&heuristics.TagComplianceHeuristic{RequiredTags: strings.Split(e.config.RequiredTags, ",")})
@@ -145,15 +288,35 @@ func runRealPipeline(ctx context.Context, e *Engine) <-chan struct{} {
 			hEngine.Register(&heuristics.IAMHeuristic{IAM: iamClient})
 		}
 
+		if len(logsClients) > 0 && e.config.FlowLogsGroup != "" {
+			hEngine.Register(&heuristics.CrossAZTransferHeuristic{Logs: logsClients, LogGroup: e.config.FlowLogsGroup})
+		}
+
+		hEngine.Register(&heuristics.IdleOpenSearchHeuristic{Pricing: e.Pricing, Region: region, Window: window})
+		if e.config.IncludeMessaging {
+			hEngine.Register(&heuristics.IdleMessagingHeuristic{Window: window})
+		}
+		if e.config.Compliance {
+			hEngine.Register(&heuristics.EncryptionComplianceHeuristic{})
+		}
+		hEngine.Register(&heuristics.DanglingDNSHeuristic{})
 		hEngine.Register(&heuristics.LogHoardersHeuristic{})
 		hEngine.Register(&heuristics.ECRJanitorHeuristic{})
-		hEngine.Register(&heuristics.DataForensicsHeuristic{})
 		hEngine.Register(&heuristics.LambdaHeuristic{})
 		hEngine.Register(&heuristics.NetworkForensicsHeuristic{})
 		hEngine.Register(&heuristics.StorageOptimizationHeuristic{})
 		hEngine.Register(&heuristics.EBSModernizerHeuristic{})
-		hEngine.Register(&heuristics.GhostNodeGroupHeuristic{})
+		hEngine.Register(&heuristics.EFSLifecycleHeuristic{})
+		hEngine.Register(&heuristics.IdleEFSHeuristic{CW: cwClient, Pricing: e.Pricing, Window: window})
+		hEngine.Register(&heuristics.GhostNodeGroupHeuristic{Region: region})
 		hEngine.Register(&heuristics.AgedAMIHeuristic{})
+		hEngine.Register(&heuristics.EmptyVPCHeuristic{})
+		hEngine.Register(&heuristics.IdleCIHeuristic{})
+		hEngine.Register(&heuristics.CloudFrontHeuristic{CW: globalCWClient, Window: window})
+		hEngine.Register(&heuristics.IdleWAFHeuristic{CW: cwClient, GlobalCW: globalCWClient, Pricing: e.Pricing, Region: region, Window: window})
+		hEngine.Register(&heuristics.DynamoDBHeuristic{CW: cwClient, Pricing: e.Pricing, Region: region, Window: window})
+		hEngine.Register(&heuristics.RedshiftHeuristic{CW: cwClient, Pricing: e.Pricing, Region: region, Window: window})
+		hEngine.Register(&heuristics.KMSKeyHeuristic{Pricing: e.Pricing, Region: region})
 
 		// Register ECS heuristics.
 		hEngine.Register(&heuristics.IdleClusterHeuristic{Config: e.config.Heuristics.IdleCluster})
@@ -170,79 +333,103 @@ func runRealPipeline(ctx context.Context, e *Engine) <-chan struct{} {
 		}
 
 		// Phase 3.
-		hEngine2 := heuristics.NewEngine()
-		if e.Pricing != nil {
-			hEngine2.Register(&heuristics.SnapshotChildrenHeuristic{Pricing: e.Pricing})
+		hEngine2 := e.newHeuristicEngine()
+		hEngine2.OnFindings(e.findingHandler())
+		hEngine2.Register(&heuristics.SnapshotChildrenHeuristic{Pricing: e.Pricing, Region: region})
+		hEngine2.Register(&heuristics.OrphanedSnapshotHeuristic{Pricing: e.Pricing, Config: e.config.Heuristics.OrphanedSnapshot, Region: region})
+		// After NetworkForensics so idle gateways stay flagged for deletion.
+		hEngine2.Register(&heuristics.NATInstanceHeuristic{Pricing: e.Pricing, Region: region})
+		// After AbandonedFargate, which records Fargate pod usage.
+		hEngine2.Register(&heuristics.IdleEKSClusterHeuristic{})
+		if state != nil {
+			hEngine2.Register(&heuristics.ShadowInfraHeuristic{State: state})
+		}
+		if e.config.CostAllocationTags != "" {
+			hEngine2.Register(&heuristics.TagComplianceHeuristic{CostAllocationTags: strings.Split(e.config.CostAllocationTags, ","), Pricing: e.Pricing, Region: region})
+		}
+		if rules, err := heuristics.LoadDeprecationRules(e.config.DeprecationsFile); err != nil {
+			e.Logger.Warn("Deprecation rules unavailable", "error", err)
 		} else {
-			hEngine2.Register(&heuristics.SnapshotChildrenHeuristic{})
+			hEngine2.Register(&heuristics.DeprecationHeuristic{Rules: rules, Pricing: e.Pricing, Region: region})
+		}
+		if coClient != nil {
+			hEngine2.Register(&heuristics.ComputeOptimizerHeuristic{CO: coClient})
 		}
 		if err := hEngine2.Run(ctx, e.Graph); err != nil {
 			e.Logger.Error("Time Machine Analysis failed", "error", err)
 		}
 
+		// Security findings run after the cost phases, so they lead whatever cost findings already flagged.
+		hEngine3 := e.newHeuristicEngine()
+		hEngine3.OnFindings(e.findingHandler())
+		hEngine3.Register(e.sharingAudit(ctx, orgClient, accounts, region))
+		if err := hEngine3.Run(ctx, e.Graph); err != nil {
+			e.Logger.Error("Sharing Audit failed", "error", err)
+		}
+
+		// Policy simulation annotates findings, so it waits until every phase has added its own.
+		if e.config.CheckPolicy && iamClient != nil {
+			hEngine4 := e.newHeuristicEngine()
+			hEngine4.OnFindings(e.findingHandler())
+			hEngine4.Register(&heuristics.PolicyBlockHeuristic{IAM: iamClient, Principal: principal, Region: region})
+			if err := hEngine4.Run(ctx, e.Graph); err != nil {
+				e.Logger.Error("Policy simulation failed", "error", err)
+			}
+		}
+
 		// Phase 4.
 		// Safe to close graph now.
 		e.Graph.CloseAndWait()
 
 		if e.config.RulesFile != "" {
 			e.Logger.Info("Initializing Policy Engine", "rules_file", e.config.RulesFile)
-			if err := runPolicyEngine(ctx, e.config.RulesFile, e.Graph); err != nil {
+			if err := runPolicyEngine(ctx, e.config.RulesFile, e.config.CacheDir, e.Graph); err != nil {
 				e.Logger.Error("Policy Engine failed", "error", err)
 			}
 		}
 
+		// Findings resting on denied describe calls go to review.
+		if n := heuristics.DiscountIncompleteData(e.Graph); n > 0 {
+			e.Logger.Warn("Findings downgraded for incomplete data", "count", n)
+		}
+		if n := heuristics.ApplyEnvironment(e.Graph, e.config.EnvTag); n > 0 {
+			e.Logger.Info("Findings annotated with environment", "tag", e.config.EnvTag, "count", n)
+		}
+
 		// Phase 5.
-		detective := forensics.NewDetective(ctClient)
 		detective.InvestigateGraph(ctx, e.Graph)
-
-		// Phase 6.
-		os.Mkdir(e.outputDir, 0755)
-
-		report.GenerateCSV(e.Graph, e.outputDir+"/waste_report.csv")
-		report.GenerateJSON(e.Graph, e.outputDir+"/waste_report.json")
-
-		gen := tf.NewGenerator(e.Graph, state)
-		gen.GenerateWasteTF(e.outputDir + "/waste.tf")
-		gen.GenerateImportScript(e.outputDir + "/import.sh")
-		gen.GenerateDestroyPlan(e.outputDir + "/destroy_plan.out")
-
-		gen.GenerateFixScript(e.outputDir + "/fix_terraform.sh")
-		os.Chmod(e.outputDir+"/fix_terraform.sh", 0755)
-
-		// Generate remediation plan.
-		remGen := remediation.NewGenerator(e.Graph, e.Logger)
-		planPath := filepath.Join(e.outputDir, "remediation_plan.json")
-		if err := remGen.GenerateRemediationPlan(planPath); err != nil {
-			e.Logger.Error("Failed to generate remediation plan", "error", err)
-		} else {
-			e.Logger.Info("Remediation Plan Generated", "path", planPath)
+		if hits, misses := trailCache.Stats(); hits+misses > 0 {
+			e.Logger.Info("CloudTrail cache", "hits", hits, "misses", misses, "hit_rate", fmt.Sprintf("%.0f%%", trailCache.HitRate()*100))
 		}
 
-		_ = remGen.GenerateIgnorePlan(e.outputDir + "/ignore_plan.json")
-		_ = remGen.GenerateRestorationPlan(e.outputDir + "/restoration_plan.json")
-
-		if err := report.GenerateDashboard(e.Graph, e.outputDir+"/dashboard.html"); err != nil {
-			e.Logger.Error("Failed to generate dashboard", "error", err)
+		if e.config.ProtectCFN {
+			for stack, ids := range cfn.ProtectManaged(e.Graph) {
+				e.Logger.Info("CloudFormation-managed findings set to review", "stack", stack, "count", len(ids))
+			}
 		}
 
-		report.GenerateExecutiveSummary(e.Graph, e.outputDir+"/executive_summary.md", fmt.Sprintf("cs-scan-%d", time.Now().Unix()), "AWS-ACCOUNT")
+		if ceClient != nil {
+			e.reconcileBilledCosts(ctx, ceClient)
+		}
 
-		// Report summary.
-		summary := report.Summary{
-			Region:       e.config.Region,
-			TotalScanned: len(e.Graph.GetNodes()),
-			TotalWaste:   0,
-			TotalSavings: 0,
+		// Phase 6.
+		if e.config.PlanOnly {
+			e.Logger.Info("Plan-only mode: skipping report and remediation artifacts")
+		} else {
+			e.writeArtifacts(state)
 		}
 
-		e.Graph.Mu.RLock()
-		for _, n := range e.Graph.GetNodes() {
-			if n.IsWaste {
-				summary.TotalWaste++
-				summary.TotalSavings += n.Cost
+		if e.config.MetricsFile != "" {
+			if err := report.GeneratePrometheus(e.Graph, e.config.MetricsFile); err != nil {
+				e.Logger.Error("Failed to write Prometheus metrics", "error", err)
 			}
 		}
-		e.Graph.Mu.RUnlock()
+
+		// Report summary.
+		snapshot := takeSnapshot(e.Graph)
+		summary := report.Summarize(e.Graph, e.config.Region)
+		summary.Diff = e.diffSinceLastScan(e.previousSnapshot(), snapshot)
+		e.summary = &summary
 
 		// CI decoration.
 		ci := report.NewCIDecorator(e.Logger)
@@ -250,24 +437,14 @@ func runRealPipeline(ctx context.Context, e *Engine) <-chan struct{} {
 			e.Logger.Error("CI Decoration failed", "error", err)
 		}
 
-		// Slack notification.
-		if e.config.SlackWebhook != "" && e.config.Headless {
-			e.Logger.Info("Transmitting Cost Report to Slack")
-			client := notifier.NewSlackClient(e.config.SlackWebhook, e.config.SlackChannel)
-
-			if err := client.SendAnalysisReport(summary); err != nil {
-				e.Logger.Warn("Failed to send Slack report", "error", err)
-			} else {
-				e.Logger.Info("Slack Report delivered")
-			}
+		// Chat notifications. The fan-out logs each channel's outcome.
+		if e.Notifier != nil && e.config.Headless {
+			e.Logger.Info("Transmitting Cost Report to chat channels")
+			e.Notifier.SendAnalysisReport(summary)
 		}
 
 		// Historical analysis.
-		var slackClient *notifier.SlackClient
-		if e.config.SlackWebhook != "" {
-			slackClient = notifier.NewSlackClient(e.config.SlackWebhook, e.config.SlackChannel)
-		}
-		performSignalAnalysis(e.Graph, slackClient, e.History)
+		performSignalAnalysis(snapshot, e.Notifier, e.History, e.config.Budget)
 
 		// Check partial results.
 		e.Graph.Mu.RLock()
@@ -280,7 +457,7 @@ func runRealPipeline(ctx context.Context, e *Engine) <-chan struct{} {
 		e.Graph.Mu.RUnlock()
 
 		// 7. Artifact Persistence (S3)
-		if e.s3Target != "" {
+		if e.s3Target != "" && !e.config.PlanOnly {
 			if err := e.UploadArtifacts(context.Background()); err != nil {
 				e.Logger.Error("Failed to persist artifacts to S3", "target", e.s3Target, "error", err)
 			} else {
@@ -291,3 +468,59 @@ func runRealPipeline(ctx context.Context, e *Engine) <-chan struct{} {
 
 	return done
 }
+
+// writeArtifacts writes the reports, Terraform scripts and remediation plans to outputDir.
+func (e *Engine) writeArtifacts(state *tf.State) {
+	os.Mkdir(e.outputDir, 0755)
+
+	report.GenerateCSV(e.Graph, e.outputDir+"/waste_report.csv")
+	report.GenerateJSON(e.Graph, e.outputDir+"/waste_report.json")
+	if e.config.FOCUS {
+		if err := report.GenerateFOCUS(e.Graph, e.outputDir+"/focus_report.csv"); err != nil {
+			e.Logger.Error("Failed to generate FOCUS report", "error", err)
+		}
+	}
+
+	if e.config.CostCenterTag != "" {
+		e.writeChargeback()
+	}
+
+	gen := tf.NewGenerator(e.Graph, state)
+	gen.GenerateWasteTF(e.outputDir + "/waste.tf")
+	gen.GenerateImportScript(e.outputDir + "/import.sh")
+	gen.GenerateDestroyPlan(e.outputDir + "/destroy_plan.sh")
+	os.Chmod(e.outputDir+"/destroy_plan.sh", 0755)
+
+	gen.GenerateFixScript(e.outputDir + "/fix_terraform.sh")
+	os.Chmod(e.outputDir+"/fix_terraform.sh", 0755)
+
+	// Generate remediation plan.
+	remGen := remediation.NewGenerator(e.Graph, e.Logger)
+	remGen.ScanID = e.scanID
+	planPath := filepath.Join(e.outputDir, "remediation_plan.json")
+	if err := remGen.GenerateRemediationPlan(planPath); err != nil {
+		e.Logger.Error("Failed to generate remediation plan", "error", err)
+	} else {
+		e.Logger.Info("Remediation Plan Generated", "path", planPath)
+	}
+
+	_ = remGen.GenerateIgnorePlan(e.outputDir + "/ignore_plan.json")
+	_ = remGen.GenerateRestorationPlan(e.outputDir + "/restoration_plan.json")
+	if err := remGen.GenerateCloudFormationDriftReport(e.outputDir + "/cfn_drift_report.yaml"); err != nil {
+		e.Logger.Error("Failed to generate CloudFormation drift report", "error", err)
+	}
+
+	if err := report.GenerateDashboard(e.Graph, e.outputDir+"/dashboard.html", e.config.Report); err != nil {
+		e.Logger.Error("Failed to generate dashboard", "error", err)
+	}
+
+	if e.config.SankeyJSON {
+		if err := report.GenerateSankeyJSON(e.Graph, e.outputDir+"/topology_sankey.json"); err != nil {
+			e.Logger.Error("Failed to generate Sankey JSON", "error", err)
+		}
+	}
+
+	if err := report.WriteSummary(e.Graph, e.outputDir+"/executive_summary.md", e.scanID, e.summaryAccount(), e.config.SummaryTemplate); err != nil {
+		e.Logger.Error("Failed to generate executive summary", "error", err)
+	}
+}
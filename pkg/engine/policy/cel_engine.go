@@ -96,12 +96,24 @@ func NewCELEngine() (*CELEngine, error) {
 	}, nil
 }
 
+// CompileError is a rule whose condition failed to parse or type-check.
+type CompileError struct {
+	RuleID string
+	Issues *cel.Issues
+}
+
+func (e *CompileError) Error() string {
+	return fmt.Sprintf("rule %s compilation error: %v", e.RuleID, e.Issues.Err())
+}
+
+func (e *CompileError) Unwrap() error { return e.Issues.Err() }
+
 // Compile prepares rules for execution.
 func (e *CELEngine) Compile(rules []DynamicRule) error {
 	for _, r := range rules {
 		ast, issues := e.env.Compile(r.Condition)
 		if issues != nil && issues.Err() != nil {
-			return fmt.Errorf("rule %s compilation error: %w", r.ID, issues.Err())
+			return &CompileError{RuleID: r.ID, Issues: issues}
 		}
 
 		prg, err := e.env.Program(ast)
@@ -0,0 +1,139 @@
+package policy
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	celast "github.com/google/cel-go/common/ast"
+	"github.com/google/cel-go/ext"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/DrSkyle/cloudslash/v2/pkg/resource"
+)
+
+// QueryContext is a node as a query sees it: the rule variables, plus the
+// finding state rules never see because they run before it exists.
+type QueryContext struct {
+	EvaluationContext
+	Region string
+	Waste  bool
+	Risk   int
+	Reason string
+	Props  map[string]interface{}
+}
+
+// NewQueryContext builds the query variables for node. Callers hold the
+// graph's read lock.
+func NewQueryContext(node *graph.Node) QueryContext {
+	q := QueryContext{
+		EvaluationContext: EvaluationContext{
+			ID:       node.IDStr(),
+			Kind:     node.TypeStr(),
+			Cost:     node.Cost,
+			Tags:     make(map[string]string),
+			Resource: node.TypedData,
+		},
+		Waste: node.IsWaste,
+		Risk:  node.RiskScore,
+		Props: node.Properties,
+	}
+	if tags, ok := node.Properties["Tags"].(map[string]string); ok {
+		q.Tags = tags
+	}
+	q.Region, _ = node.Properties["Region"].(string)
+	q.Reason, _ = node.Properties["Reason"].(string)
+	if q.Props == nil {
+		q.Props = map[string]interface{}{}
+	}
+	return q
+}
+
+// Query is a compiled filter expression over graph nodes, for interactive
+// investigation rather than enforcement.
+type Query struct {
+	Expr string
+	prg  cel.Program
+}
+
+// CompileQuery compiles a boolean CEL expression. It sees the rule variables
+// (id, kind, cost, tags, resource) and also:
+//
+//	type    alias of kind, e.g. type == "AWS::EC2::Volume"
+//	region  the resource's region, empty when unknown
+//	waste   whether the resource was flagged
+//	risk    the finding's risk score
+//	reason  the finding's reason
+//	props   every recorded property, e.g. props.State == "available"
+func CompileQuery(expr string) (*Query, error) {
+	env, err := cel.NewEnv(
+		ext.NativeTypes(reflect.TypeOf(&resource.EC2Instance{})),
+		// Typed at a prompt, cost > 50 should not need to be cost > 50.0.
+		cel.CrossTypeNumericComparisons(true),
+		cel.Declarations(
+			decls.NewVar("id", decls.String),
+			decls.NewVar("kind", decls.String),
+			decls.NewVar("cost", decls.Double),
+			decls.NewVar("tags", decls.NewMapType(decls.String, decls.String)),
+			decls.NewVar("resource", decls.Dyn),
+			decls.NewVar("region", decls.String),
+			decls.NewVar("waste", decls.Bool),
+			decls.NewVar("risk", decls.Int),
+			decls.NewVar("reason", decls.String),
+			decls.NewVar("props", decls.NewMapType(decls.String, decls.Dyn)),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL env: %w", err)
+	}
+
+	parsed, issues := env.Parse(expr)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("invalid query: %w", issues.Err())
+	}
+	// CEL reserves `type` for the type of types, so it cannot be declared;
+	// read the bare identifier as kind instead. type(x) is a call and keeps
+	// its meaning.
+	fac := celast.NewExprFactory()
+	celast.PostOrderVisit(parsed.NativeRep().Expr(), celast.NewExprVisitor(func(e celast.Expr) {
+		if e.Kind() == celast.IdentKind && e.AsIdent() == "type" {
+			e.SetKindCase(fac.NewIdent(e.ID(), "kind"))
+		}
+	}))
+	ast, issues := env.Check(parsed)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("invalid query: %w", issues.Err())
+	}
+	if ast.OutputType() != cel.BoolType {
+		return nil, fmt.Errorf("invalid query: expression is %s, not bool", ast.OutputType())
+	}
+	prg, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query program: %w", err)
+	}
+	return &Query{Expr: expr, prg: prg}, nil
+}
+
+// Match reports whether the node behind qc satisfies the query. Referencing
+// a property the node does not have is an error, not a mismatch; guard with
+// `has(props.X)` or `"X" in props`.
+func (q *Query) Match(qc QueryContext) (bool, error) {
+	out, _, err := q.prg.Eval(map[string]interface{}{
+		"id":       qc.ID,
+		"kind":     qc.Kind,
+		"cost":     qc.Cost,
+		"tags":     qc.Tags,
+		"resource": qc.Resource,
+		"region":   qc.Region,
+		"waste":    qc.Waste,
+		"risk":     qc.Risk,
+		"reason":   qc.Reason,
+		"props":    qc.Props,
+	})
+	if err != nil {
+		return false, err
+	}
+	match, ok := out.Value().(bool)
+	return ok && match, nil
+}
@@ -0,0 +1,72 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+func TestQuery(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("vol-big", "AWS::EC2::Volume", map[string]interface{}{
+		"Region": "us-east-1", "State": "available", "Size": 500,
+		"Tags": map[string]string{"env": "dev"},
+	})
+	g.AddNode("vol-small", "AWS::EC2::Volume", map[string]interface{}{"Region": "eu-west-1", "State": "in-use"})
+	g.AddNode("i-1", "AWS::EC2::Instance", map[string]interface{}{"Region": "us-east-1"})
+	g.CloseAndWait()
+
+	g.Mu.Lock()
+	big := g.GetNode("vol-big")
+	big.IsWaste, big.Cost, big.RiskScore = true, 60, 70
+	big.Properties["Reason"] = "Unattached volume"
+	g.GetNode("vol-small").Cost = 5
+	g.Mu.Unlock()
+
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{`type == "AWS::EC2::Volume" && cost > 50 && waste`, []string{"vol-big"}},
+		{`type(cost) == double && has(props.Size) && props.Size > 100`, []string{"vol-big"}},
+		{`kind == "AWS::EC2::Volume"`, []string{"vol-big", "vol-small"}},
+		{`region == "us-east-1" && !waste`, []string{"i-1"}},
+		{`"State" in props && props.State == "available"`, []string{"vol-big"}},
+		{`risk >= 50 && reason.contains("Unattached")`, []string{"vol-big"}},
+		{`"env" in tags && tags.env == "dev"`, []string{"vol-big"}},
+	}
+	for _, tt := range tests {
+		q, err := CompileQuery(tt.expr)
+		if err != nil {
+			t.Fatalf("CompileQuery(%q): %v", tt.expr, err)
+		}
+		var got []string
+		g.Mu.RLock()
+		for _, id := range []string{"i-1", "vol-big", "vol-small"} {
+			ok, err := q.Match(NewQueryContext(g.GetNode(id)))
+			if err != nil {
+				t.Fatalf("%q on %s: %v", tt.expr, id, err)
+			}
+			if ok {
+				got = append(got, id)
+			}
+		}
+		g.Mu.RUnlock()
+		if len(got) != len(tt.want) {
+			t.Errorf("%q matched %v, want %v", tt.expr, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%q matched %v, want %v", tt.expr, got, tt.want)
+				break
+			}
+		}
+	}
+
+	for _, bad := range []string{`cost +`, `cost * 2.0`, `unknown == 1`} {
+		if _, err := CompileQuery(bad); err == nil {
+			t.Errorf("CompileQuery(%q) succeeded, want error", bad)
+		}
+	}
+}
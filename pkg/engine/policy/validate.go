@@ -0,0 +1,188 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	celast "github.com/google/cel-go/common/ast"
+	"gopkg.in/yaml.v3"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/resource"
+)
+
+// RuleIssue is one problem found by ValidateRules.
+type RuleIssue struct {
+	RuleID  string
+	Line    int  // Line in the rules file; 0 when unknown.
+	Warning bool // Warnings do not fail validation.
+	Message string
+	Context string // The offending source line with a caret, when known.
+}
+
+// ruleKeys are the YAML keys a rule accepts (yaml.v3 lowercases field names).
+var ruleKeys = map[string]bool{"id": true, "condition": true, "action": true, "priority": true, "targetkinds": true}
+
+// typedResources are the structs scanners attach as node.TypedData, and so
+// the only shapes `resource` can take at evaluation time.
+var typedResources = []reflect.Type{reflect.TypeOf(resource.EC2Instance{})}
+
+// ValidateRules parses a rules file and compiles each rule on its own, so one
+// bad rule does not hide the rest. It also warns about unknown rule keys,
+// duplicate IDs, non-boolean conditions, and `resource` fields that no typed
+// resource has. It returns the number of rules found.
+func ValidateRules(data []byte) (int, []RuleIssue, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return 0, nil, fmt.Errorf("failed to parse rules yaml: %v", err)
+	}
+	seq := rulesSequence(&doc)
+	if seq == nil {
+		return 0, nil, fmt.Errorf("no top-level 'rules' list found")
+	}
+
+	engine, err := NewCELEngine()
+	if err != nil {
+		return 0, nil, err
+	}
+	fields := resourceFields()
+	lines := strings.Split(string(data), "\n")
+
+	var issues []RuleIssue
+	seen := make(map[string]int)
+	for i, item := range seq.Content {
+		var r DynamicRule
+		if err := item.Decode(&r); err != nil {
+			issues = append(issues, RuleIssue{RuleID: fmt.Sprintf("#%d", i+1), Line: item.Line, Message: err.Error()})
+			continue
+		}
+		id := r.ID
+		if id == "" {
+			id = fmt.Sprintf("#%d", i+1)
+			issues = append(issues, RuleIssue{RuleID: id, Line: item.Line, Warning: true, Message: "rule has no id"})
+		} else if first, dup := seen[id]; dup {
+			issues = append(issues, RuleIssue{RuleID: id, Line: item.Line, Warning: true,
+				Message: fmt.Sprintf("duplicate id; replaces the rule on line %d", first)})
+		} else {
+			seen[id] = item.Line
+		}
+
+		var cond *yaml.Node
+		for k := 0; k+1 < len(item.Content); k += 2 {
+			key := item.Content[k]
+			if !ruleKeys[key.Value] {
+				issues = append(issues, RuleIssue{RuleID: id, Line: key.Line, Warning: true,
+					Message: fmt.Sprintf("unknown key %q is ignored (expected id, condition, action, priority, targetkinds)", key.Value)})
+			}
+			if key.Value == "condition" {
+				cond = item.Content[k+1]
+			}
+		}
+		if strings.TrimSpace(r.Condition) == "" {
+			issues = append(issues, RuleIssue{RuleID: id, Line: item.Line, Message: "condition is empty"})
+			continue
+		}
+
+		if err := engine.Compile([]DynamicRule{r}); err != nil {
+			var ce *CompileError
+			if !errors.As(err, &ce) {
+				issues = append(issues, RuleIssue{RuleID: id, Line: item.Line, Message: err.Error()})
+				continue
+			}
+			for _, e := range ce.Issues.Errors() {
+				issue := RuleIssue{RuleID: id, Line: item.Line, Message: e.Message}
+				if cond != nil {
+					issue.Line, issue.Context = locate(lines, cond, r.Condition, e.Location.Line(), e.Location.Column())
+				}
+				issues = append(issues, issue)
+			}
+			continue
+		}
+
+		ast, _ := engine.env.Compile(r.Condition)
+		if t := ast.OutputType(); !t.IsExactType(cel.BoolType) && !t.IsExactType(cel.DynType) {
+			issues = append(issues, RuleIssue{RuleID: id, Line: item.Line, Warning: true,
+				Message: fmt.Sprintf("condition evaluates to %s, not bool; the rule never matches", t)})
+		}
+		for _, f := range unknownResourceFields(ast, fields) {
+			issues = append(issues, RuleIssue{RuleID: id, Line: item.Line, Warning: true,
+				Message: fmt.Sprintf("resource.%s is not a field of any typed resource; it fails at evaluation time", f)})
+		}
+	}
+	return len(seq.Content), issues, nil
+}
+
+// rulesSequence returns the sequence under the document's top-level "rules" key.
+func rulesSequence(doc *yaml.Node) *yaml.Node {
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+	root := doc.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "rules" && root.Content[i+1].Kind == yaml.SequenceNode {
+			return root.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// locate maps a position in a condition (1-based line, 0-based column) to the
+// rules file, returning the file line and that line with a caret under the error.
+// Block scalars start on the line after the key; quoted scalars on the same line.
+func locate(lines []string, cond *yaml.Node, expr string, line, col int) (int, string) {
+	exprLines := strings.Split(expr, "\n")
+	if line < 1 || line > len(exprLines) {
+		return cond.Line, ""
+	}
+	// Errors at end of input point past the last line; move them to its end.
+	for line > 1 && strings.TrimSpace(exprLines[line-1]) == "" {
+		line--
+		col = len(exprLines[line-1])
+	}
+	want := exprLines[line-1]
+	for fileLine := cond.Line + line - 1; fileLine <= cond.Line+line && fileLine <= len(lines); fileLine++ {
+		text := lines[fileLine-1]
+		if idx := strings.Index(text, want); idx >= 0 && want != "" {
+			return fileLine, fmt.Sprintf("%5d | %s\n      | %s^", fileLine, text, strings.Repeat(" ", idx+col))
+		}
+	}
+	return cond.Line, fmt.Sprintf("      | %s\n      | %s^", want, strings.Repeat(" ", col))
+}
+
+// resourceFields lists the exported fields, including promoted ones, of every typed resource.
+func resourceFields() map[string]bool {
+	fields := make(map[string]bool)
+	for _, t := range typedResources {
+		for _, f := range reflect.VisibleFields(t) {
+			if f.IsExported() && !f.Anonymous {
+				fields[f.Name] = true
+			}
+		}
+	}
+	return fields
+}
+
+// unknownResourceFields returns the `resource.X` selections whose X is not in fields.
+func unknownResourceFields(ast *cel.Ast, fields map[string]bool) []string {
+	found := make(map[string]bool)
+	celast.PreOrderVisit(ast.NativeRep().Expr(), celast.NewExprVisitor(func(e celast.Expr) {
+		if e.Kind() != celast.SelectKind {
+			return
+		}
+		sel := e.AsSelect()
+		op := sel.Operand()
+		if op.Kind() == celast.IdentKind && op.AsIdent() == "resource" && !fields[sel.FieldName()] {
+			found[sel.FieldName()] = true
+		}
+	}))
+
+	var names []string
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
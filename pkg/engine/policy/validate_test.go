@@ -0,0 +1,72 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateRules(t *testing.T) {
+	data := []byte(`rules:
+  - id: "high_cost"
+    condition: "cost > 1000.0"
+    action: "warn"
+  - id: "typo"
+    condition: "kind == 'AWS::EC2::Volume' && props.VolumeType == 'gp2'"
+    action: "block"
+  - id: "bad_field"
+    condition: "resource.InstanceTyp == 't2.micro'"
+    target_kinds: ["AWS::EC2::Instance"]
+  - id: "high_cost"
+    condition: |
+      cost > 10.0 &&
+        tags.env == 'prod' &&
+        kind ==
+  - id: "not_bool"
+    condition: "cost * 2.0"
+`)
+
+	count, issues, err := ValidateRules(data)
+	if err != nil {
+		t.Fatalf("ValidateRules failed: %v", err)
+	}
+	if count != 5 {
+		t.Errorf("Expected 5 rules, got %d", count)
+	}
+
+	find := func(id string, warning bool, substr string) *RuleIssue {
+		for i := range issues {
+			if issues[i].RuleID == id && issues[i].Warning == warning && strings.Contains(issues[i].Message, substr) {
+				return &issues[i]
+			}
+		}
+		t.Errorf("Missing issue for %s containing %q in %+v", id, substr, issues)
+		return nil
+	}
+
+	if typo := find("typo", false, "undeclared reference to 'props'"); typo != nil {
+		if typo.Line != 6 || !strings.Contains(typo.Context, "props.VolumeType") {
+			t.Errorf("Expected line 6 with context, got line %d %q", typo.Line, typo.Context)
+		}
+		caret := strings.Split(typo.Context, "\n")[1]
+		if col := strings.Index(caret, "^") - len("      | "); col != strings.Index("    condition: \"kind == 'AWS::EC2::Volume' && props.VolumeType == 'gp2'\"", "props") {
+			t.Errorf("Caret not under 'props': %q", typo.Context)
+		}
+	}
+	find("bad_field", true, "resource.InstanceTyp")
+	find("bad_field", true, `unknown key "target_kinds"`)
+	find("high_cost", true, "duplicate id")
+	if multi := find("high_cost", false, "Syntax error"); multi != nil && (multi.Line != 15 || !strings.Contains(multi.Context, "kind ==")) {
+		t.Errorf("Expected block scalar error on line 15, got %d %q", multi.Line, multi.Context)
+	}
+	find("not_bool", true, "not bool")
+
+	for _, issue := range issues {
+		if issue.RuleID == "high_cost" && issue.Line == 2 {
+			t.Errorf("Valid rule reported an issue: %+v", issue)
+		}
+	}
+
+	if _, _, err := ValidateRules([]byte("policies: []\n")); err == nil {
+		t.Error("Expected an error for a file without a rules list")
+	}
+}
@@ -0,0 +1,27 @@
+package pricing
+
+// azureDiskPerGB is list monthly pricing (East US, LRS) per GB for managed disk
+// SKUs. Standard, StandardSSD and Premium disks bill by size tier; these rates
+// are the mid-range tiers divided by their size.
+var azureDiskPerGB = map[string]float64{
+	"Standard_LRS":    0.045,
+	"StandardSSD_LRS": 0.075,
+	"StandardSSD_ZRS": 0.094,
+	"Premium_LRS":     0.135,
+	"Premium_ZRS":     0.169,
+	"PremiumV2_LRS":   0.082,
+	"UltraSSD_LRS":    0.12,
+}
+
+// defaultAzureDiskPerGB is used for SKUs missing from the table (Standard_LRS).
+const defaultAzureDiskPerGB = 0.045
+
+// EstimateAzureDiskPrice is the static monthly estimate for an Azure managed disk.
+// Provisioned IOPS/throughput on PremiumV2 and Ultra disks are not included.
+func EstimateAzureDiskPrice(sku string, sizeGB int) float64 {
+	perGB, ok := azureDiskPerGB[sku]
+	if !ok {
+		perGB = defaultAzureDiskPerGB
+	}
+	return perGB * float64(sizeGB)
+}
@@ -3,6 +3,8 @@ package pricing
 import (
 	"context"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"sync"
@@ -85,3 +87,145 @@ func TestPricingCache(t *testing.T) {
 		t.Errorf("Persistence failed. Expected %.4f, got %+v", expectedPrice, val)
 	}
 }
+
+func TestWindowsPricedAboveLinux(t *testing.T) {
+	c := &Client{
+		cache:          make(map[string]PriceRecord),
+		cachePath:      filepath.Join(t.TempDir(), "pricing.json"),
+		ttl:            1 * time.Hour,
+		discountFactor: 1.0,
+	}
+
+	region, instType := "us-east-1", "m5.large"
+	now := time.Now().Unix()
+	winOS, winSW := EC2PricingOS("Windows")
+	c.cache[ec2CacheKey(region, instType, "Linux", "NA")] = PriceRecord{Price: 0.096, Timestamp: now}
+	c.cache[ec2CacheKey(region, instType, winOS, winSW)] = PriceRecord{Price: 0.188, Timestamp: now}
+
+	linux, err := c.GetEC2InstancePrice(context.Background(), region, instType)
+	if err != nil {
+		t.Fatalf("Linux price failed: %v", err)
+	}
+	windows, err := c.GetEC2InstancePriceForPlatform(context.Background(), region, instType, "Windows")
+	if err != nil {
+		t.Fatalf("Windows price failed: %v", err)
+	}
+	if windows <= linux {
+		t.Errorf("Expected Windows (%.2f) to cost more than Linux (%.2f)", windows, linux)
+	}
+
+	// Plain Linux platforms share the legacy cache entry.
+	if unix, _ := c.GetEC2InstancePriceForPlatform(context.Background(), region, instType, "Linux/UNIX"); unix != linux {
+		t.Errorf("Expected Linux/UNIX to price as Linux, got %.2f vs %.2f", unix, linux)
+	}
+
+	if got, sw := EC2PricingOS("Windows with SQL Server Standard"); got != "Windows" || sw != "SQL Std" {
+		t.Errorf("Unexpected mapping for SQL Server: %s/%s", got, sw)
+	}
+}
+
+func TestPrefetchServesFromCache(t *testing.T) {
+	c := &Client{
+		logger:         slog.New(slog.NewTextHandler(io.Discard, nil)),
+		cache:          make(map[string]PriceRecord),
+		cachePath:      filepath.Join(t.TempDir(), "pricing.json"),
+		ttl:            time.Hour,
+		discountFactor: 1.0,
+	}
+	types := []string{"t3.micro", "m5.large", "c5.xlarge"}
+	for i, it := range types {
+		c.cache[ec2CacheKey("us-east-1", it, "Linux", "NA")] = PriceRecord{Price: float64(i + 1), Timestamp: time.Now().Unix()}
+	}
+
+	prices := c.Prefetch(context.Background(), "us-east-1", types, 2)
+	if len(prices) != len(types) {
+		t.Fatalf("Expected %d prices, got %v", len(types), prices)
+	}
+	if prices["m5.large"] != 2*HoursPerMonth {
+		t.Errorf("Expected m5.large at %.2f/mo, got %.2f", 2*HoursPerMonth, prices["m5.large"])
+	}
+}
+
+func TestWarmCountsCachedEntries(t *testing.T) {
+	c := &Client{
+		logger:         slog.New(slog.NewTextHandler(io.Discard, nil)),
+		cache:          make(map[string]PriceRecord),
+		cachePath:      filepath.Join(t.TempDir(), "pricing.json"),
+		ttl:            time.Hour,
+		discountFactor: 1.0,
+	}
+	regions := []string{"us-east-1", "eu-west-1"}
+	types := []string{"m5.large", "t3.medium"}
+	now := time.Now().Unix()
+	for _, region := range regions {
+		for _, it := range types {
+			c.cache[ec2CacheKey(region, it, "Linux", "NA")] = PriceRecord{Price: 0.1, Timestamp: now}
+		}
+		for _, vt := range EBSVolumeTypes {
+			c.cache[fmt.Sprintf("ebs-%s-%s", region, vt)] = PriceRecord{Price: 0.08, Timestamp: now}
+		}
+		c.cache[fmt.Sprintf("nat-%s", region)] = PriceRecord{Price: 0.045, Timestamp: now}
+	}
+
+	result := c.Warm(context.Background(), regions, types, 2)
+	want := len(regions) * (len(types) + len(EBSVolumeTypes) + 1)
+	if result.Cached != want || result.Fetched != 0 || result.Failed != 0 {
+		t.Errorf("Expected %d cached entries, got %+v", want, result)
+	}
+}
+
+func TestEBSSnapshotPrice(t *testing.T) {
+	c := &Client{
+		cache:          make(map[string]PriceRecord),
+		cachePath:      filepath.Join(t.TempDir(), "pricing.json"),
+		ttl:            1 * time.Hour,
+		discountFactor: 0.5,
+	}
+	c.cache["ebs-snapshot-eu-central-1"] = PriceRecord{Price: 0.054, Timestamp: time.Now().Unix()}
+
+	got, err := c.GetEBSSnapshotPrice(context.Background(), "eu-central-1", 100)
+	if err != nil {
+		t.Fatalf("Snapshot price failed: %v", err)
+	}
+	if want := 100 * 0.054 * 0.5; got < want-0.0001 || got > want+0.0001 {
+		t.Errorf("Expected $%.2f, got $%.2f", want, got)
+	}
+
+	standard := `{"product":{"attributes":{"usagetype":"EUC1-EBS:SnapshotUsage"}}}`
+	archive := `{"product":{"attributes":{"usagetype":"EUC1-EBS:SnapshotArchiveStorage"}}}`
+	if !isStandardSnapshotUsage(standard) || isStandardSnapshotUsage(archive) {
+		t.Error("Expected only the standard snapshot usage type to match")
+	}
+}
+
+func TestFlushBatchesCacheWrites(t *testing.T) {
+	cacheFile := filepath.Join(t.TempDir(), "pricing.json")
+	c := &Client{cache: make(map[string]PriceRecord), cachePath: cacheFile}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c.storePrice(fmt.Sprintf("ec2-us-east-1-type%d", i), float64(i))
+		}(i)
+	}
+	wg.Wait()
+	if _, err := os.Stat(cacheFile); err == nil {
+		t.Fatal("Expected no disk write before Flush")
+	}
+
+	c.Flush()
+	c2 := &Client{cache: make(map[string]PriceRecord), cachePath: cacheFile}
+	c2.loadCache()
+	if len(c2.cache) != 20 {
+		t.Fatalf("Expected 20 cached prices after Flush, got %d", len(c2.cache))
+	}
+
+	// Nothing new was fetched, so a second Flush leaves the file alone.
+	os.Remove(cacheFile)
+	c.Flush()
+	if _, err := os.Stat(cacheFile); err == nil {
+		t.Error("Expected Flush without new prices to skip the write")
+	}
+}
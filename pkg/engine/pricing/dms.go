@@ -0,0 +1,100 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/pricing"
+	"github.com/aws/aws-sdk-go-v2/service/pricing/types"
+)
+
+// dmsHourly is Single-AZ on-demand pricing (us-east-1) for common replication instance classes.
+var dmsHourly = map[string]float64{
+	"dms.t3.micro":   0.018,
+	"dms.t3.small":   0.036,
+	"dms.t3.medium":  0.073,
+	"dms.t3.large":   0.146,
+	"dms.c5.large":   0.154,
+	"dms.c5.xlarge":  0.308,
+	"dms.c5.2xlarge": 0.616,
+	"dms.c5.4xlarge": 1.232,
+	"dms.r5.large":   0.21,
+	"dms.r5.xlarge":  0.42,
+	"dms.r5.2xlarge": 0.84,
+	"dms.r5.4xlarge": 1.68,
+}
+
+// defaultDMSHourly is used for classes missing from the table (dms.c5.large).
+const defaultDMSHourly = 0.154
+
+// EstimateDMSInstancePrice is the static monthly estimate used when the Pricing API is unavailable.
+// Multi-AZ runs a standby and costs twice as much.
+func EstimateDMSInstancePrice(instanceClass string, multiAZ bool) float64 {
+	hourly, ok := dmsHourly[instanceClass]
+	if !ok {
+		hourly = defaultDMSHourly
+	}
+	if multiAZ {
+		hourly *= 2
+	}
+	return hourly * HoursPerMonth
+}
+
+// GetDMSInstancePrice estimates DMS replication instance monthly cost.
+// Falls back to EstimateDMSInstancePrice if the Pricing API has no answer.
+func (c *Client) GetDMSInstancePrice(ctx context.Context, region, instanceClass string, multiAZ bool) (float64, error) {
+	cacheKey := fmt.Sprintf("dms-%s-%s-%t", region, instanceClass, multiAZ)
+
+	c.mu.RLock()
+	record, ok := c.cache[cacheKey]
+	c.mu.RUnlock()
+
+	if ok && time.Since(time.Unix(record.Timestamp, 0)) < c.ttl {
+		return record.Price * HoursPerMonth * c.discountFactor, nil
+	}
+
+	price, err := c.fetchDMSPrice(ctx, region, instanceClass, multiAZ)
+	if err != nil {
+		c.logger.Debug("DMS price lookup failed, using estimate", "class", instanceClass, "error", err)
+		return EstimateDMSInstancePrice(instanceClass, multiAZ) * c.discountFactor, nil
+	}
+	c.storePrice(cacheKey, price)
+
+	return price * HoursPerMonth * c.discountFactor, nil
+}
+
+func (c *Client) fetchDMSPrice(ctx context.Context, region, instanceClass string, multiAZ bool) (float64, error) {
+	input := &pricing.GetProductsInput{
+		ServiceCode: aws.String("AWSDatabaseMigrationSvc"),
+		Filters: []types.Filter{
+			{
+				Type:  types.FilterTypeTermMatch,
+				Field: aws.String("regionCode"),
+				Value: aws.String(region),
+			},
+			{
+				Type:  types.FilterTypeTermMatch,
+				Field: aws.String("instanceType"),
+				Value: aws.String(instanceClass),
+			},
+		},
+		MaxResults: aws.Int32(10),
+	}
+
+	out, err := c.svc.GetProducts(ctx, input)
+	if err != nil {
+		return 0, err
+	}
+
+	// Single-AZ and Multi-AZ are separate products for the same class.
+	for _, product := range out.PriceList {
+		if strings.Contains(product, "Multi-AZ") != multiAZ {
+			continue
+		}
+		return parsePriceFromJSON(product)
+	}
+	return 0, fmt.Errorf("no pricing found for %s %s (multi-AZ %t)", region, instanceClass, multiAZ)
+}
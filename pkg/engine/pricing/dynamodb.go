@@ -0,0 +1,36 @@
+package pricing
+
+import "context"
+
+// DynamoDB Standard table class list prices (us-east-1).
+const (
+	DynamoDBRCUHour           = 0.00013
+	DynamoDBWCUHour           = 0.00065
+	DynamoDBGBMonth           = 0.25
+	DynamoDBMillionReadUnits  = 0.125
+	DynamoDBMillionWriteUnits = 0.625
+)
+
+// EstimateDynamoDBProvisionedPrice is the monthly cost of a provisioned-mode
+// table: its read and write capacity units (indexes included) and storage.
+func EstimateDynamoDBProvisionedPrice(rcu, wcu, storageGB float64) float64 {
+	return (rcu*DynamoDBRCUHour+wcu*DynamoDBWCUHour)*HoursPerMonth + storageGB*DynamoDBGBMonth
+}
+
+// EstimateDynamoDBOnDemandPrice is the monthly cost of serving monthlyReads
+// read request units and monthlyWrites write request units on demand, plus storage.
+func EstimateDynamoDBOnDemandPrice(monthlyReads, monthlyWrites, storageGB float64) float64 {
+	return monthlyReads/1e6*DynamoDBMillionReadUnits + monthlyWrites/1e6*DynamoDBMillionWriteUnits + storageGB*DynamoDBGBMonth
+}
+
+// GetDynamoDBPrice estimates a provisioned-mode table's monthly cost. Capacity
+// and storage are priced at us-east-1 list prices, which most regions are
+// within a few percent of; only the account discount applies.
+func (c *Client) GetDynamoDBPrice(ctx context.Context, region string, rcu, wcu, storageGB float64) (float64, error) {
+	return EstimateDynamoDBProvisionedPrice(rcu, wcu, storageGB) * c.discountFactor, nil
+}
+
+// GetDynamoDBOnDemandPrice estimates the same table's monthly cost in on-demand mode.
+func (c *Client) GetDynamoDBOnDemandPrice(ctx context.Context, region string, monthlyReads, monthlyWrites, storageGB float64) (float64, error) {
+	return EstimateDynamoDBOnDemandPrice(monthlyReads, monthlyWrites, storageGB) * c.discountFactor, nil
+}
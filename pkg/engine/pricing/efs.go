@@ -0,0 +1,77 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/pricing"
+	"github.com/aws/aws-sdk-go-v2/service/pricing/types"
+)
+
+// EFS list prices (us-east-1, per month).
+const (
+	EFSStandardGBMonth       = 0.30
+	EFSIAGBMonth             = 0.016
+	EFSArchiveGBMonth        = 0.008
+	EFSProvisionedMiBpsMonth = 6.00
+)
+
+// EstimateEFSPrice is the static monthly estimate used when the Pricing API is unavailable.
+func EstimateEFSPrice(standardGB, iaGB, archiveGB, provisionedMiBps float64) float64 {
+	return standardGB*EFSStandardGBMonth + iaGB*EFSIAGBMonth + archiveGB*EFSArchiveGBMonth +
+		provisionedMiBps*EFSProvisionedMiBpsMonth
+}
+
+// GetEFSPrice estimates EFS monthly cost: storage per class plus provisioned throughput.
+// Only the Standard rate is looked up; the smaller components use list prices.
+func (c *Client) GetEFSPrice(ctx context.Context, region string, standardGB, iaGB, archiveGB, provisionedMiBps float64) (float64, error) {
+	rest := iaGB*EFSIAGBMonth + archiveGB*EFSArchiveGBMonth + provisionedMiBps*EFSProvisionedMiBpsMonth
+	cacheKey := fmt.Sprintf("efs-standard-%s", region)
+
+	c.mu.RLock()
+	record, ok := c.cache[cacheKey]
+	c.mu.RUnlock()
+
+	if ok && time.Since(time.Unix(record.Timestamp, 0)) < c.ttl {
+		return (standardGB*record.Price + rest) * c.discountFactor, nil
+	}
+
+	price, err := c.fetchEFSStandardPrice(ctx, region)
+	if err != nil {
+		c.logger.Debug("EFS price lookup failed, using estimate", "region", region, "error", err)
+		return EstimateEFSPrice(standardGB, iaGB, archiveGB, provisionedMiBps) * c.discountFactor, nil
+	}
+	c.storePrice(cacheKey, price)
+
+	return (standardGB*price + rest) * c.discountFactor, nil
+}
+
+func (c *Client) fetchEFSStandardPrice(ctx context.Context, region string) (float64, error) {
+	input := &pricing.GetProductsInput{
+		ServiceCode: aws.String("AmazonEFS"),
+		Filters: []types.Filter{
+			{
+				Type:  types.FilterTypeTermMatch,
+				Field: aws.String("regionCode"),
+				Value: aws.String(region),
+			},
+			{
+				Type:  types.FilterTypeTermMatch,
+				Field: aws.String("storageClass"),
+				Value: aws.String("General Purpose"),
+			},
+		},
+		MaxResults: aws.Int32(1),
+	}
+
+	out, err := c.svc.GetProducts(ctx, input)
+	if err != nil {
+		return 0, err
+	}
+	if len(out.PriceList) == 0 {
+		return 0, fmt.Errorf("no EFS pricing found for %s", region)
+	}
+	return parsePriceFromJSON(out.PriceList[0])
+}
@@ -0,0 +1,175 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/pricing"
+	"github.com/aws/aws-sdk-go-v2/service/pricing/types"
+)
+
+// elastiCacheHourly is on-demand node pricing (us-east-1) for common node types.
+// Redis OSS and Memcached cost the same; Valkey is discounted.
+var elastiCacheHourly = map[string]float64{
+	"cache.t3.micro":     0.017,
+	"cache.t3.small":     0.034,
+	"cache.t3.medium":    0.068,
+	"cache.t4g.micro":    0.016,
+	"cache.t4g.small":    0.032,
+	"cache.t4g.medium":   0.065,
+	"cache.m5.large":     0.156,
+	"cache.m5.xlarge":    0.311,
+	"cache.m5.2xlarge":   0.623,
+	"cache.m5.4xlarge":   1.245,
+	"cache.m5.12xlarge":  3.744,
+	"cache.m5.24xlarge":  7.488,
+	"cache.m6g.large":    0.149,
+	"cache.m6g.xlarge":   0.298,
+	"cache.m6g.2xlarge":  0.597,
+	"cache.m6g.4xlarge":  1.194,
+	"cache.m6g.8xlarge":  2.388,
+	"cache.m6g.12xlarge": 3.582,
+	"cache.m6g.16xlarge": 4.776,
+	"cache.m7g.large":    0.158,
+	"cache.m7g.xlarge":   0.315,
+	"cache.m7g.2xlarge":  0.632,
+	"cache.m7g.4xlarge":  1.264,
+	"cache.r5.large":     0.216,
+	"cache.r5.xlarge":    0.431,
+	"cache.r5.2xlarge":   0.862,
+	"cache.r5.4xlarge":   1.724,
+	"cache.r5.12xlarge":  5.172,
+	"cache.r5.24xlarge":  10.344,
+	"cache.r6g.large":    0.206,
+	"cache.r6g.xlarge":   0.411,
+	"cache.r6g.2xlarge":  0.822,
+	"cache.r6g.4xlarge":  1.645,
+	"cache.r6g.8xlarge":  3.290,
+	"cache.r6g.12xlarge": 4.934,
+	"cache.r6g.16xlarge": 6.579,
+	"cache.r7g.large":    0.219,
+	"cache.r7g.xlarge":   0.437,
+	"cache.r7g.2xlarge":  0.873,
+	"cache.r7g.4xlarge":  1.745,
+}
+
+// defaultElastiCacheHourly is used for node types missing from the table (cache.r6g.large).
+const defaultElastiCacheHourly = 0.206
+
+// valkeyDiscount is Valkey's node price relative to Redis OSS.
+const valkeyDiscount = 0.8
+
+// elastiCacheSizes orders node sizes from smallest to largest.
+var elastiCacheSizes = []string{"micro", "small", "medium", "large", "xlarge", "2xlarge", "4xlarge", "8xlarge", "12xlarge", "16xlarge", "24xlarge"}
+
+// EstimateElastiCachePrice is the static monthly estimate for one node, used
+// when the Pricing API is unavailable.
+func EstimateElastiCachePrice(nodeType, engine string) float64 {
+	hourly, ok := elastiCacheHourly[nodeType]
+	if !ok {
+		hourly = defaultElastiCacheHourly
+	}
+	if strings.EqualFold(engine, "valkey") {
+		hourly *= valkeyDiscount
+	}
+	return hourly * HoursPerMonth
+}
+
+// SmallerElastiCacheNodeType returns the next smaller node type of the same
+// family (cache.r6g.xlarge is cache.r6g.large). Families skip sizes, so only
+// types in the price table are considered.
+func SmallerElastiCacheNodeType(nodeType string) (string, bool) {
+	dot := strings.LastIndex(nodeType, ".")
+	if dot < 0 {
+		return "", false
+	}
+	family, size := nodeType[:dot], nodeType[dot+1:]
+	for i := len(elastiCacheSizes) - 1; i > 0; i-- {
+		if elastiCacheSizes[i] != size {
+			continue
+		}
+		for j := i - 1; j >= 0; j-- {
+			smaller := family + "." + elastiCacheSizes[j]
+			if _, ok := elastiCacheHourly[smaller]; ok {
+				return smaller, true
+			}
+		}
+	}
+	return "", false
+}
+
+// GetElastiCachePrice estimates the monthly on-demand cost of one cache node.
+// Falls back to EstimateElastiCachePrice if the Pricing API has no answer.
+func (c *Client) GetElastiCachePrice(ctx context.Context, region, nodeType, engine string) (float64, error) {
+	engine = elastiCacheEngine(engine)
+	cacheKey := fmt.Sprintf("elasticache-%s-%s-%s", region, nodeType, engine)
+
+	c.mu.RLock()
+	record, ok := c.cache[cacheKey]
+	c.mu.RUnlock()
+
+	if ok && time.Since(time.Unix(record.Timestamp, 0)) < c.ttl {
+		return record.Price * HoursPerMonth * c.discountFactor, nil
+	}
+
+	price, err := c.fetchElastiCachePrice(ctx, region, nodeType, engine)
+	if err != nil {
+		c.logger.Debug("ElastiCache price lookup failed, using estimate", "type", nodeType, "engine", engine, "error", err)
+		return EstimateElastiCachePrice(nodeType, engine) * c.discountFactor, nil
+	}
+	c.storePrice(cacheKey, price)
+
+	return price * HoursPerMonth * c.discountFactor, nil
+}
+
+// elastiCacheEngine maps a cluster's Engine ("redis") to the Pricing API's cacheEngine ("Redis").
+func elastiCacheEngine(engine string) string {
+	switch strings.ToLower(engine) {
+	case "memcached":
+		return "Memcached"
+	case "valkey":
+		return "Valkey"
+	}
+	return "Redis"
+}
+
+func (c *Client) fetchElastiCachePrice(ctx context.Context, region, nodeType, engine string) (float64, error) {
+	input := &pricing.GetProductsInput{
+		ServiceCode: aws.String("AmazonElastiCache"),
+		Filters: []types.Filter{
+			{
+				Type:  types.FilterTypeTermMatch,
+				Field: aws.String("regionCode"),
+				Value: aws.String(region),
+			},
+			{
+				Type:  types.FilterTypeTermMatch,
+				Field: aws.String("instanceType"),
+				Value: aws.String(nodeType),
+			},
+			{
+				Type:  types.FilterTypeTermMatch,
+				Field: aws.String("cacheEngine"),
+				Value: aws.String(engine),
+			},
+			{
+				Type:  types.FilterTypeTermMatch,
+				Field: aws.String("productFamily"),
+				Value: aws.String("Cache Instance"),
+			},
+		},
+		MaxResults: aws.Int32(1),
+	}
+
+	out, err := c.svc.GetProducts(ctx, input)
+	if err != nil {
+		return 0, err
+	}
+	if len(out.PriceList) == 0 {
+		return 0, fmt.Errorf("no pricing found for %s %s (%s)", region, nodeType, engine)
+	}
+	return parsePriceFromJSON(out.PriceList[0])
+}
@@ -0,0 +1,74 @@
+package pricing
+
+import (
+	"strconv"
+	"strings"
+)
+
+// gcpDiskPerGB is list monthly pricing (us-central1) per GB for persistent disk types.
+var gcpDiskPerGB = map[string]float64{
+	"pd-standard": 0.04,
+	"pd-balanced": 0.10,
+	"pd-ssd":      0.17,
+	"pd-extreme":  0.125,
+}
+
+// defaultGCPDiskPerGB is used for disk types missing from the table (pd-standard).
+const defaultGCPDiskPerGB = 0.04
+
+// EstimateGCPDiskPrice is the static monthly estimate for a GCP persistent disk.
+// GCP has no pricing API comparable to AWS's; list prices are close enough for waste sizing.
+func EstimateGCPDiskPrice(diskType string, sizeGB int) float64 {
+	perGB, ok := gcpDiskPerGB[diskType]
+	if !ok {
+		perGB = defaultGCPDiskPerGB
+	}
+	return perGB * float64(sizeGB)
+}
+
+// gcpVCPUHourly is the list hourly price (us-central1) of one vCPU, with its
+// standard share of memory, per machine family.
+var gcpVCPUHourly = map[string]float64{
+	"e2":  0.0335,
+	"n1":  0.0475,
+	"n2":  0.0486,
+	"n2d": 0.0422,
+	"t2d": 0.0422,
+	"c2":  0.0522,
+	"c3":  0.0524,
+}
+
+// gcpSharedCoreMonthly prices the shared-core types, which have no vCPU count in their name.
+var gcpSharedCoreMonthly = map[string]float64{
+	"e2-micro":  6.11,
+	"e2-small":  12.23,
+	"e2-medium": 24.46,
+	"f1-micro":  3.88,
+	"g1-small":  13.23,
+}
+
+// gcpClassFactor scales the standard rate for memory-heavy and CPU-heavy classes.
+var gcpClassFactor = map[string]float64{
+	"standard": 1.0,
+	"highmem":  1.35,
+	"highcpu":  0.75,
+}
+
+// EstimateGCPInstancePrice is the static monthly on-demand estimate for a GCP
+// machine type such as "n2-standard-4". Custom and unknown types return 0.
+func EstimateGCPInstancePrice(machineType string) float64 {
+	if monthly, ok := gcpSharedCoreMonthly[machineType]; ok {
+		return monthly
+	}
+	parts := strings.Split(machineType, "-")
+	if len(parts) != 3 {
+		return 0
+	}
+	perVCPU, ok := gcpVCPUHourly[parts[0]]
+	factor, known := gcpClassFactor[parts[1]]
+	vcpus, err := strconv.Atoi(parts[2])
+	if !ok || !known || err != nil {
+		return 0
+	}
+	return perVCPU * factor * float64(vcpus) * 730
+}
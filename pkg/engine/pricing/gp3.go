@@ -0,0 +1,37 @@
+package pricing
+
+import "context"
+
+// gp3 includes a performance baseline in the storage price; provisioning above
+// it is billed separately (us-east-1 list prices, per month).
+const (
+	GP3BaselineIOPS          = 3000
+	GP3BaselineThroughput    = 125 // MiB/s
+	GP3IOPSMonth             = 0.005
+	GP3ThroughputMiBpsMonth  = 0.04
+	GP3StorageGBMonthDefault = 0.08
+)
+
+// GP3ProvisionedSurcharge is the monthly charge for IOPS and throughput
+// provisioned above the gp3 baseline.
+func GP3ProvisionedSurcharge(iops, throughputMiBps int) float64 {
+	return float64(max(iops-GP3BaselineIOPS, 0))*GP3IOPSMonth +
+		float64(max(throughputMiBps-GP3BaselineThroughput, 0))*GP3ThroughputMiBpsMonth
+}
+
+// EstimateGP3Price is the static monthly estimate used when the Pricing API is unavailable.
+func EstimateGP3Price(sizeGB, iops, throughputMiBps int) float64 {
+	return float64(sizeGB)*GP3StorageGBMonthDefault + GP3ProvisionedSurcharge(iops, throughputMiBps)
+}
+
+// GetGP3Price estimates a gp3 volume's monthly cost: storage plus the surcharge
+// for IOPS and throughput above the baseline. Only the storage rate is looked
+// up; the surcharges use list prices.
+func (c *Client) GetGP3Price(ctx context.Context, region string, sizeGB, iops, throughputMiBps int) (float64, error) {
+	storage, err := c.GetEBSPrice(ctx, region, "gp3", sizeGB)
+	if err != nil {
+		c.logger.Debug("gp3 price lookup failed, using estimate", "region", region, "error", err)
+		return EstimateGP3Price(sizeGB, iops, throughputMiBps) * c.discountFactor, nil
+	}
+	return (storage + GP3ProvisionedSurcharge(iops, throughputMiBps)) * c.discountFactor, nil
+}
@@ -0,0 +1,25 @@
+package pricing
+
+import "context"
+
+// KMS list prices (per month). They are the same in every commercial region.
+const (
+	KMSKeyMonth = 1.00
+	// Each of a key's first two rotations adds its price again; later
+	// rotations are free.
+	KMSRotationMonth = 1.00
+	kmsMaxRotations  = 2
+)
+
+// EstimateKMSKeyPrice is the monthly cost of a customer managed key whose
+// material has been rotated rotations times. Requests are billed separately.
+func EstimateKMSKeyPrice(rotations int) float64 {
+	return KMSKeyMonth + float64(min(max(rotations, 0), kmsMaxRotations))*KMSRotationMonth
+}
+
+// GetKMSKeyPrice estimates a customer managed key's monthly cost. KMS keys
+// cost the same in every region, so no lookup is made; only the account
+// discount applies.
+func (c *Client) GetKMSKeyPrice(ctx context.Context, region string, rotations int) (float64, error) {
+	return EstimateKMSKeyPrice(rotations) * c.discountFactor, nil
+}
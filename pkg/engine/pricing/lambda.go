@@ -0,0 +1,38 @@
+package pricing
+
+// Lambda list prices (us-east-1). Compute is billed per GB-second of memory
+// times billed duration; provisioned concurrency is billed per GB-second for as
+// long as it is configured, whether or not it serves requests.
+const (
+	LambdaRequestPerMillion = 0.20
+
+	lambdaGBSecondX86     = 0.0000166667
+	lambdaGBSecondARM     = 0.0000133334
+	lambdaPCGBSecondX86   = 0.0000041667
+	lambdaPCGBSecondARM   = 0.0000033334
+	secondsPerMonth       = HoursPerMonth * 3600
+	megabytesPerGB        = 1024.0
+	millisecondsPerSecond = 1000.0
+)
+
+func lambdaRates(arch string) (compute, provisioned float64) {
+	if arch == "arm64" {
+		return lambdaGBSecondARM, lambdaPCGBSecondARM
+	}
+	return lambdaGBSecondX86, lambdaPCGBSecondX86
+}
+
+// EstimateLambdaInvocationCost is the cost of invocations totalling durationMs
+// billed milliseconds on a function with memoryMB of memory.
+func EstimateLambdaInvocationCost(memoryMB int, arch string, invocations, durationMs float64) float64 {
+	compute, _ := lambdaRates(arch)
+	gbSeconds := float64(memoryMB) / megabytesPerGB * durationMs / millisecondsPerSecond
+	return gbSeconds*compute + invocations/1e6*LambdaRequestPerMillion
+}
+
+// EstimateLambdaProvisionedConcurrencyMonthly is the monthly charge for keeping
+// concurrency execution environments of memoryMB provisioned.
+func EstimateLambdaProvisionedConcurrencyMonthly(memoryMB int, arch string, concurrency int) float64 {
+	_, provisioned := lambdaRates(arch)
+	return float64(memoryMB) / megabytesPerGB * float64(concurrency) * secondsPerMonth * provisioned
+}
@@ -0,0 +1,96 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/pricing"
+	"github.com/aws/aws-sdk-go-v2/service/pricing/types"
+)
+
+// openSearchHourly is on-demand pricing (us-east-1) for common OpenSearch instance types.
+var openSearchHourly = map[string]float64{
+	"t3.small.search":    0.036,
+	"t3.medium.search":   0.073,
+	"m5.large.search":    0.142,
+	"m5.xlarge.search":   0.283,
+	"m6g.large.search":   0.128,
+	"m6g.xlarge.search":  0.256,
+	"m6g.2xlarge.search": 0.511,
+	"c6g.large.search":   0.113,
+	"c6g.xlarge.search":  0.226,
+	"r5.large.search":    0.186,
+	"r5.xlarge.search":   0.372,
+	"r6g.large.search":   0.167,
+	"r6g.xlarge.search":  0.335,
+	"r6g.2xlarge.search": 0.67,
+}
+
+// defaultOpenSearchHourly is used for types missing from the table (m5.large.search).
+const defaultOpenSearchHourly = 0.142
+
+// OpenSearchEBSGBMonth is the gp2 storage list price for OpenSearch domains (us-east-1).
+const OpenSearchEBSGBMonth = 0.135
+
+// EstimateOpenSearchInstancePrice is the static monthly estimate for one instance,
+// used when the Pricing API is unavailable.
+func EstimateOpenSearchInstancePrice(instanceType string) float64 {
+	hourly, ok := openSearchHourly[instanceType]
+	if !ok {
+		hourly = defaultOpenSearchHourly
+	}
+	return hourly * HoursPerMonth
+}
+
+// GetOpenSearchInstancePrice estimates the monthly cost of one OpenSearch instance.
+// Falls back to EstimateOpenSearchInstancePrice if the Pricing API has no answer.
+func (c *Client) GetOpenSearchInstancePrice(ctx context.Context, region, instanceType string) (float64, error) {
+	cacheKey := fmt.Sprintf("opensearch-%s-%s", region, instanceType)
+
+	c.mu.RLock()
+	record, ok := c.cache[cacheKey]
+	c.mu.RUnlock()
+
+	if ok && time.Since(time.Unix(record.Timestamp, 0)) < c.ttl {
+		return record.Price * HoursPerMonth * c.discountFactor, nil
+	}
+
+	price, err := c.fetchOpenSearchPrice(ctx, region, instanceType)
+	if err != nil {
+		c.logger.Debug("OpenSearch price lookup failed, using estimate", "type", instanceType, "error", err)
+		return EstimateOpenSearchInstancePrice(instanceType) * c.discountFactor, nil
+	}
+	c.storePrice(cacheKey, price)
+
+	return price * HoursPerMonth * c.discountFactor, nil
+}
+
+func (c *Client) fetchOpenSearchPrice(ctx context.Context, region, instanceType string) (float64, error) {
+	input := &pricing.GetProductsInput{
+		ServiceCode: aws.String("AmazonES"),
+		Filters: []types.Filter{
+			{
+				Type:  types.FilterTypeTermMatch,
+				Field: aws.String("regionCode"),
+				Value: aws.String(region),
+			},
+			{
+				Type:  types.FilterTypeTermMatch,
+				Field: aws.String("instanceType"),
+				Value: aws.String(instanceType),
+			},
+		},
+		MaxResults: aws.Int32(1),
+	}
+
+	out, err := c.svc.GetProducts(ctx, input)
+	if err != nil {
+		return 0, err
+	}
+	if len(out.PriceList) == 0 {
+		return 0, fmt.Errorf("no pricing found for %s %s", region, instanceType)
+	}
+	return parsePriceFromJSON(out.PriceList[0])
+}
@@ -0,0 +1,53 @@
+package pricing
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultPrefetchWorkers bounds concurrent Pricing API calls during Prefetch.
+const DefaultPrefetchWorkers = 8
+
+// Prefetch warms the cache with on-demand Linux prices for many instance types
+// at once. It returns the monthly price of each type that resolved; types that
+// failed are omitted so callers can fall back to estimates.
+// Later GetEC2InstancePrice calls for the same types are served from the cache,
+// which is written to disk once when all workers are done.
+func (c *Client) Prefetch(ctx context.Context, region string, instanceTypes []string, workers int) map[string]float64 {
+	if workers <= 0 {
+		workers = DefaultPrefetchWorkers
+	}
+
+	prices := make(map[string]float64, len(instanceTypes))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	jobs := make(chan string)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for it := range jobs {
+				price, err := c.GetEC2InstancePrice(ctx, region, it)
+				if err != nil || price == 0 {
+					c.logger.Debug("Prefetch miss", "type", it, "error", err)
+					continue
+				}
+				mu.Lock()
+				prices[it] = price
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, it := range instanceTypes {
+		select {
+		case jobs <- it:
+		case <-ctx.Done():
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	c.Flush()
+	return prices
+}
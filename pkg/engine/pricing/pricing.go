@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -34,11 +35,13 @@ type PriceRecord struct {
 type Client struct {
 	logger         *slog.Logger
 	svc            *pricing.Client
+	cfg            aws.Config // Regional clients (spot prices) are built from it.
 	cache          map[string]PriceRecord
 	mu             sync.RWMutex
 	cachePath      string
 	ttl            time.Duration
 	discountFactor float64
+	dirty          bool // Prices fetched since the last Flush.
 }
 
 // NewClient initializes the pricing client.
@@ -71,6 +74,7 @@ func NewClient(ctx context.Context, logger *slog.Logger, cacheDir string, manual
 	c := &Client{
 		logger:         logger,
 		svc:            pricing.NewFromConfig(cfg),
+		cfg:            cfg,
 		cache:          make(map[string]PriceRecord),
 		cachePath:      filepath.Join(cacheDir, "pricing.json"),
 		ttl:            DefaultCacheTTL, // 15 Days
@@ -95,6 +99,31 @@ func (c *Client) saveCache() {
 	}
 }
 
+// storePrice caches a fetched unit price. The cache file is written by Flush,
+// not per lookup, so concurrent misses do not serialize on disk I/O.
+func (c *Client) storePrice(key string, price float64) {
+	c.mu.Lock()
+	c.cache[key] = PriceRecord{Price: price, Timestamp: time.Now().Unix()}
+	c.dirty = true
+	c.mu.Unlock()
+}
+
+// Flush writes the cache to disk if any price was fetched since the last flush.
+func (c *Client) Flush() {
+	c.mu.Lock()
+	if !c.dirty {
+		c.mu.Unlock()
+		return
+	}
+	data, err := json.MarshalIndent(c.cache, "", "  ")
+	c.dirty = false
+	c.mu.Unlock()
+
+	if err == nil {
+		os.WriteFile(c.cachePath, data, 0644)
+	}
+}
+
 // GetEBSPrice estimates EBS monthly cost.
 func (c *Client) GetEBSPrice(ctx context.Context, region, volumeType string, sizeGB int) (float64, error) {
 	cacheKey := fmt.Sprintf("ebs-%s-%s", region, volumeType)
@@ -112,10 +141,7 @@ func (c *Client) GetEBSPrice(ctx context.Context, region, volumeType string, siz
 			return 0, err
 		}
 
-		c.mu.Lock()
-		c.cache[cacheKey] = PriceRecord{Price: price, Timestamp: time.Now().Unix()}
-		c.saveCache() // Persist cache.
-		c.mu.Unlock()
+		c.storePrice(cacheKey, price)
 
 		return price * float64(sizeGB), nil
 	}
@@ -188,9 +214,16 @@ func (c *Client) fetchEBSPrice(ctx context.Context, region, volumeType string) (
 	return parsePriceFromJSON(out.PriceList[0])
 }
 
-// GetEC2InstancePrice estimates EC2 monthly cost.
+// GetEC2InstancePrice estimates EC2 monthly cost for Linux instances.
 func (c *Client) GetEC2InstancePrice(ctx context.Context, region, instanceType string) (float64, error) {
-	cacheKey := fmt.Sprintf("ec2-%s-%s", region, instanceType)
+	return c.GetEC2InstancePriceForPlatform(ctx, region, instanceType, "")
+}
+
+// GetEC2InstancePriceForPlatform estimates EC2 monthly cost including OS licensing.
+// platformDetails is the EC2 PlatformDetails value (e.g. "Windows", "Red Hat Enterprise Linux").
+func (c *Client) GetEC2InstancePriceForPlatform(ctx context.Context, region, instanceType, platformDetails string) (float64, error) {
+	opSys, sw := EC2PricingOS(platformDetails)
+	cacheKey := ec2CacheKey(region, instanceType, opSys, sw)
 
 	c.mu.RLock()
 	record, ok := c.cache[cacheKey]
@@ -200,14 +233,11 @@ func (c *Client) GetEC2InstancePrice(ctx context.Context, region, instanceType s
 
 	if !valid {
 		var err error
-		price, err := c.fetchEC2Price(ctx, region, instanceType)
+		price, err := c.fetchEC2Price(ctx, region, instanceType, opSys, sw)
 		if err != nil {
 			return 0, err
 		}
-		c.mu.Lock()
-		c.cache[cacheKey] = PriceRecord{Price: price, Timestamp: time.Now().Unix()}
-		c.saveCache()
-		c.mu.Unlock()
+		c.storePrice(cacheKey, price)
 
 		return price * HoursPerMonth * c.discountFactor, nil
 	}
@@ -215,7 +245,41 @@ func (c *Client) GetEC2InstancePrice(ctx context.Context, region, instanceType s
 	return record.Price * HoursPerMonth * c.discountFactor, nil // Assumes 730h/month.
 }
 
-func (c *Client) fetchEC2Price(ctx context.Context, region, instanceType string) (float64, error) {
+// EC2PricingOS maps EC2 PlatformDetails to Pricing API operatingSystem and
+// preInstalledSw values. Unknown platforms price as Linux.
+func EC2PricingOS(platformDetails string) (operatingSystem, preInstalledSw string) {
+	sw := "NA"
+	switch {
+	case strings.Contains(platformDetails, "SQL Server Enterprise"):
+		sw = "SQL Ent"
+	case strings.Contains(platformDetails, "SQL Server Standard"):
+		sw = "SQL Std"
+	case strings.Contains(platformDetails, "SQL Server Web"):
+		sw = "SQL Web"
+	}
+
+	switch {
+	case strings.HasPrefix(platformDetails, "Windows"):
+		return "Windows", sw
+	case strings.HasPrefix(platformDetails, "Red Hat"):
+		return "RHEL", sw
+	case strings.HasPrefix(platformDetails, "SUSE"):
+		return "SUSE", sw
+	case strings.HasPrefix(platformDetails, "Ubuntu Pro"):
+		return "Ubuntu Pro", sw
+	}
+	return "Linux", sw
+}
+
+// ec2CacheKey keeps the historical key for plain Linux so existing caches stay valid.
+func ec2CacheKey(region, instanceType, operatingSystem, preInstalledSw string) string {
+	if operatingSystem == "Linux" && preInstalledSw == "NA" {
+		return fmt.Sprintf("ec2-%s-%s", region, instanceType)
+	}
+	return fmt.Sprintf("ec2-%s-%s-%s-%s", region, instanceType, operatingSystem, preInstalledSw)
+}
+
+func (c *Client) fetchEC2Price(ctx context.Context, region, instanceType, operatingSystem, preInstalledSw string) (float64, error) {
 	filters := []types.Filter{
 		{
 			Type:  types.FilterTypeTermMatch,
@@ -245,12 +309,18 @@ func (c *Client) fetchEC2Price(ctx context.Context, region, instanceType string)
 		{
 			Type:  types.FilterTypeTermMatch,
 			Field: aws.String("operatingSystem"),
-			Value: aws.String("Linux"),
+			Value: aws.String(operatingSystem),
 		},
 		{
 			Type:  types.FilterTypeTermMatch,
 			Field: aws.String("preInstalledSw"),
-			Value: aws.String("NA"),
+			Value: aws.String(preInstalledSw),
+		},
+		{
+			// Excludes BYOL SKUs, which omit the license fee.
+			Type:  types.FilterTypeTermMatch,
+			Field: aws.String("licenseModel"),
+			Value: aws.String("No License required"),
 		},
 	}
 
@@ -267,7 +337,94 @@ func (c *Client) fetchEC2Price(ctx context.Context, region, instanceType string)
 
 	if len(out.PriceList) == 0 {
 		// Attempt fallback search criteria.
-		return 0, fmt.Errorf("no pricing found for %s %s", region, instanceType)
+		return 0, fmt.Errorf("no pricing found for %s %s (%s)", region, instanceType, operatingSystem)
+	}
+
+	return parsePriceFromJSON(out.PriceList[0])
+}
+
+// GetRDSInstancePrice estimates RDS instance monthly cost.
+func (c *Client) GetRDSInstancePrice(ctx context.Context, region, instanceClass, engine string) (float64, error) {
+	cacheKey := fmt.Sprintf("rds-%s-%s-%s", region, instanceClass, engine)
+
+	c.mu.RLock()
+	record, ok := c.cache[cacheKey]
+	c.mu.RUnlock()
+
+	valid := ok && time.Since(time.Unix(record.Timestamp, 0)) < c.ttl
+
+	if !valid {
+		price, err := c.fetchRDSPrice(ctx, region, instanceClass, engine)
+		if err != nil {
+			return 0, err
+		}
+		c.storePrice(cacheKey, price)
+
+		return price * HoursPerMonth * c.discountFactor, nil
+	}
+
+	return record.Price * HoursPerMonth * c.discountFactor, nil
+}
+
+func (c *Client) fetchRDSPrice(ctx context.Context, region, instanceClass, engine string) (float64, error) {
+	// Map API engine names to pricing labels.
+	var engineVal string
+	switch engine {
+	case "mysql":
+		engineVal = "MySQL"
+	case "postgres":
+		engineVal = "PostgreSQL"
+	case "mariadb":
+		engineVal = "MariaDB"
+	case "aurora-mysql", "aurora":
+		engineVal = "Aurora MySQL"
+	case "aurora-postgresql":
+		engineVal = "Aurora PostgreSQL"
+	default:
+		return 0, fmt.Errorf("unsupported rds engine for pricing: %s", engine)
+	}
+
+	filters := []types.Filter{
+		{
+			Type:  types.FilterTypeTermMatch,
+			Field: aws.String("productFamily"),
+			Value: aws.String("Database Instance"),
+		},
+		{
+			Type:  types.FilterTypeTermMatch,
+			Field: aws.String("regionCode"),
+			Value: aws.String(region),
+		},
+		{
+			Type:  types.FilterTypeTermMatch,
+			Field: aws.String("instanceType"),
+			Value: aws.String(instanceClass),
+		},
+		{
+			Type:  types.FilterTypeTermMatch,
+			Field: aws.String("databaseEngine"),
+			Value: aws.String(engineVal),
+		},
+		{
+			Type:  types.FilterTypeTermMatch,
+			Field: aws.String("deploymentOption"),
+			Value: aws.String("Single-AZ"),
+		},
+	}
+
+	input := &pricing.GetProductsInput{
+		ServiceCode: aws.String("AmazonRDS"),
+		Filters:     filters,
+		MaxResults:  aws.Int32(1),
+	}
+
+	out, err := c.svc.GetProducts(ctx, input)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(out.PriceList) == 0 {
+		return 0, fmt.Errorf("no pricing found for %s %s %s", region, instanceClass, engine)
 	}
 
 	return parsePriceFromJSON(out.PriceList[0])
@@ -294,10 +451,7 @@ func (c *Client) GetNATGatewayPrice(ctx context.Context, region string) (float64
 			// Default timeout fallback.
 			return DefaultNATPrice * HoursPerMonth, nil
 		}
-		c.mu.Lock()
-		c.cache[cacheKey] = PriceRecord{Price: price, Timestamp: time.Now().Unix()}
-		c.saveCache()
-		c.mu.Unlock()
+		c.storePrice(cacheKey, price)
 		return price * HoursPerMonth, nil
 	}
 
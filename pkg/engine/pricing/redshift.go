@@ -0,0 +1,94 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/pricing"
+	"github.com/aws/aws-sdk-go-v2/service/pricing/types"
+)
+
+// redshiftHourly is on-demand node pricing (us-east-1) for provisioned Redshift node types.
+var redshiftHourly = map[string]float64{
+	"dc2.large":    0.25,
+	"dc2.8xlarge":  4.80,
+	"ds2.xlarge":   0.85,
+	"ds2.8xlarge":  6.80,
+	"ra3.large":    0.543,
+	"ra3.xlplus":   1.086,
+	"ra3.4xlarge":  3.26,
+	"ra3.16xlarge": 13.04,
+}
+
+// defaultRedshiftHourly is used for node types missing from the table (ra3.xlplus).
+const defaultRedshiftHourly = 1.086
+
+// EstimateRedshiftPrice is the static monthly estimate for a cluster, used
+// when the Pricing API is unavailable. RA3 managed storage is billed
+// separately and not included.
+func EstimateRedshiftPrice(nodeType string, nodes int) float64 {
+	hourly, ok := redshiftHourly[nodeType]
+	if !ok {
+		hourly = defaultRedshiftHourly
+	}
+	return hourly * float64(nodes) * HoursPerMonth
+}
+
+// GetRedshiftPrice estimates the monthly on-demand cost of a cluster of the
+// given node type and count. Falls back to EstimateRedshiftPrice if the
+// Pricing API has no answer.
+func (c *Client) GetRedshiftPrice(ctx context.Context, region, nodeType string, nodes int) (float64, error) {
+	cacheKey := fmt.Sprintf("redshift-%s-%s", region, nodeType)
+
+	c.mu.RLock()
+	record, ok := c.cache[cacheKey]
+	c.mu.RUnlock()
+
+	if ok && time.Since(time.Unix(record.Timestamp, 0)) < c.ttl {
+		return record.Price * float64(nodes) * HoursPerMonth * c.discountFactor, nil
+	}
+
+	price, err := c.fetchRedshiftPrice(ctx, region, nodeType)
+	if err != nil {
+		c.logger.Debug("Redshift price lookup failed, using estimate", "type", nodeType, "error", err)
+		return EstimateRedshiftPrice(nodeType, nodes) * c.discountFactor, nil
+	}
+	c.storePrice(cacheKey, price)
+
+	return price * float64(nodes) * HoursPerMonth * c.discountFactor, nil
+}
+
+func (c *Client) fetchRedshiftPrice(ctx context.Context, region, nodeType string) (float64, error) {
+	input := &pricing.GetProductsInput{
+		ServiceCode: aws.String("AmazonRedshift"),
+		Filters: []types.Filter{
+			{
+				Type:  types.FilterTypeTermMatch,
+				Field: aws.String("regionCode"),
+				Value: aws.String(region),
+			},
+			{
+				Type:  types.FilterTypeTermMatch,
+				Field: aws.String("instanceType"),
+				Value: aws.String(nodeType),
+			},
+			{
+				Type:  types.FilterTypeTermMatch,
+				Field: aws.String("productFamily"),
+				Value: aws.String("Compute Instance"),
+			},
+		},
+		MaxResults: aws.Int32(1),
+	}
+
+	out, err := c.svc.GetProducts(ctx, input)
+	if err != nil {
+		return 0, err
+	}
+	if len(out.PriceList) == 0 {
+		return 0, fmt.Errorf("no pricing found for %s %s", region, nodeType)
+	}
+	return parsePriceFromJSON(out.PriceList[0])
+}
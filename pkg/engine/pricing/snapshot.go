@@ -0,0 +1,90 @@
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/pricing"
+	"github.com/aws/aws-sdk-go-v2/service/pricing/types"
+)
+
+// EBSSnapshotGBMonth is the standard-tier EBS snapshot list price (us-east-1, per GB-month).
+const EBSSnapshotGBMonth = 0.05
+
+// EstimateEBSSnapshotPrice is the static monthly estimate used when the Pricing API is unavailable.
+func EstimateEBSSnapshotPrice(sizeGB int) float64 {
+	return float64(sizeGB) * EBSSnapshotGBMonth
+}
+
+// GetEBSSnapshotPrice estimates the monthly cost of a standard-tier snapshot.
+// Snapshots are incremental, so billing the full source volume size is an upper bound.
+func (c *Client) GetEBSSnapshotPrice(ctx context.Context, region string, sizeGB int) (float64, error) {
+	cacheKey := fmt.Sprintf("ebs-snapshot-%s", region)
+
+	c.mu.RLock()
+	record, ok := c.cache[cacheKey]
+	c.mu.RUnlock()
+
+	if ok && time.Since(time.Unix(record.Timestamp, 0)) < c.ttl {
+		return float64(sizeGB) * record.Price * c.discountFactor, nil
+	}
+
+	price, err := c.fetchEBSSnapshotPrice(ctx, region)
+	if err != nil {
+		c.logger.Debug("EBS snapshot price lookup failed, using estimate", "region", region, "error", err)
+		return EstimateEBSSnapshotPrice(sizeGB) * c.discountFactor, nil
+	}
+	c.storePrice(cacheKey, price)
+
+	return float64(sizeGB) * price * c.discountFactor, nil
+}
+
+func (c *Client) fetchEBSSnapshotPrice(ctx context.Context, region string) (float64, error) {
+	input := &pricing.GetProductsInput{
+		ServiceCode: aws.String("AmazonEC2"),
+		Filters: []types.Filter{
+			{
+				Type:  types.FilterTypeTermMatch,
+				Field: aws.String("productFamily"),
+				Value: aws.String("Storage Snapshot"),
+			},
+			{
+				Type:  types.FilterTypeTermMatch,
+				Field: aws.String("regionCode"),
+				Value: aws.String(region),
+			},
+		},
+		MaxResults: aws.Int32(20),
+	}
+
+	out, err := c.svc.GetProducts(ctx, input)
+	if err != nil {
+		return 0, err
+	}
+	// The family also holds archive-tier and Fast Snapshot Restore products;
+	// the standard tier is billed under the EBS:SnapshotUsage usage type.
+	for _, item := range out.PriceList {
+		if isStandardSnapshotUsage(item) {
+			return parsePriceFromJSON(item)
+		}
+	}
+	return 0, fmt.Errorf("no EBS snapshot pricing found for %s", region)
+}
+
+// isStandardSnapshotUsage reports whether a price list item is standard-tier snapshot storage.
+// Usage types carry a region prefix outside us-east-1 (e.g. "EUC1-EBS:SnapshotUsage").
+func isStandardSnapshotUsage(item string) bool {
+	var p struct {
+		Product struct {
+			Attributes map[string]string `json:"attributes"`
+		} `json:"product"`
+	}
+	if err := json.Unmarshal([]byte(item), &p); err != nil {
+		return false
+	}
+	return strings.HasSuffix(p.Product.Attributes["usagetype"], "EBS:SnapshotUsage")
+}
@@ -0,0 +1,121 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// SpotCacheTTL is how long a spot price is reused. Spot prices move far more
+// often than on-demand list prices, so they are not held for DefaultCacheTTL.
+const SpotCacheTTL = time.Hour
+
+// GetSpotPrices returns the monthly Linux spot price of each instance type in
+// a region, taking the cheapest availability zone. Cached types are served
+// from the cache; the rest are fetched with one DescribeSpotPriceHistory
+// query. Types without a spot price are omitted.
+func (c *Client) GetSpotPrices(ctx context.Context, region string, instanceTypes []string) (map[string]float64, error) {
+	prices := make(map[string]float64, len(instanceTypes))
+	var missing []string
+
+	c.mu.RLock()
+	for _, it := range instanceTypes {
+		record, ok := c.cache[spotCacheKey(region, it)]
+		if ok && time.Since(time.Unix(record.Timestamp, 0)) < SpotCacheTTL {
+			prices[it] = record.Price * HoursPerMonth * c.discountFactor
+		} else {
+			missing = append(missing, it)
+		}
+	}
+	c.mu.RUnlock()
+
+	if len(missing) == 0 {
+		return prices, nil
+	}
+
+	history, err := c.fetchSpotPriceHistory(ctx, region, missing)
+	if err != nil {
+		return prices, err
+	}
+	for it, hourly := range lowestSpotPrices(history) {
+		c.storePrice(spotCacheKey(region, it), hourly)
+		prices[it] = hourly * HoursPerMonth * c.discountFactor
+	}
+	c.Flush()
+	return prices, nil
+}
+
+func spotCacheKey(region, instanceType string) string {
+	return fmt.Sprintf("spot-%s-%s", region, instanceType)
+}
+
+// fetchSpotPriceHistory asks for the price in effect now, which returns the
+// latest entry per instance type and availability zone.
+func (c *Client) fetchSpotPriceHistory(ctx context.Context, region string, instanceTypes []string) ([]ec2types.SpotPrice, error) {
+	svc := ec2.NewFromConfig(c.cfg, func(o *ec2.Options) {
+		o.Region = region
+	})
+
+	types := make([]ec2types.InstanceType, len(instanceTypes))
+	for i, it := range instanceTypes {
+		types[i] = ec2types.InstanceType(it)
+	}
+	input := &ec2.DescribeSpotPriceHistoryInput{
+		InstanceTypes:       types,
+		ProductDescriptions: []string{"Linux/UNIX"},
+		StartTime:           aws.Time(time.Now()),
+	}
+
+	var history []ec2types.SpotPrice
+	paginator := ec2.NewDescribeSpotPriceHistoryPaginator(svc, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe spot price history: %v", err)
+		}
+		history = append(history, page.SpotPriceHistory...)
+	}
+	return history, nil
+}
+
+// lowestSpotPrices reduces spot price history to the hourly price of each
+// instance type in its cheapest availability zone, using the latest entry
+// per zone.
+func lowestSpotPrices(history []ec2types.SpotPrice) map[string]float64 {
+	type entry struct {
+		price float64
+		at    time.Time
+	}
+	latest := make(map[string]map[string]entry)
+	for _, sp := range history {
+		price, err := strconv.ParseFloat(aws.ToString(sp.SpotPrice), 64)
+		if err != nil || price <= 0 {
+			continue
+		}
+		it := string(sp.InstanceType)
+		zone := aws.ToString(sp.AvailabilityZone)
+		at := aws.ToTime(sp.Timestamp)
+
+		if latest[it] == nil {
+			latest[it] = make(map[string]entry)
+		}
+		if prev, ok := latest[it][zone]; !ok || at.After(prev.at) {
+			latest[it][zone] = entry{price: price, at: at}
+		}
+	}
+
+	prices := make(map[string]float64, len(latest))
+	for it, zones := range latest {
+		for _, e := range zones {
+			if cur, ok := prices[it]; !ok || e.price < cur {
+				prices[it] = e.price
+			}
+		}
+	}
+	return prices
+}
@@ -0,0 +1,53 @@
+package pricing
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func TestLowestSpotPrices(t *testing.T) {
+	now := time.Now()
+	history := []ec2types.SpotPrice{
+		// us-east-1a moved from 0.020 to 0.035; only the latest entry counts.
+		{InstanceType: "m5.large", AvailabilityZone: aws.String("us-east-1a"), SpotPrice: aws.String("0.020"), Timestamp: aws.Time(now.Add(-time.Hour))},
+		{InstanceType: "m5.large", AvailabilityZone: aws.String("us-east-1a"), SpotPrice: aws.String("0.035"), Timestamp: aws.Time(now)},
+		{InstanceType: "m5.large", AvailabilityZone: aws.String("us-east-1b"), SpotPrice: aws.String("0.030"), Timestamp: aws.Time(now)},
+		{InstanceType: "c5.large", AvailabilityZone: aws.String("us-east-1a"), SpotPrice: aws.String("0.031"), Timestamp: aws.Time(now)},
+		{InstanceType: "r5.large", AvailabilityZone: aws.String("us-east-1a"), SpotPrice: aws.String("n/a"), Timestamp: aws.Time(now)},
+	}
+
+	prices := lowestSpotPrices(history)
+	if prices["m5.large"] != 0.030 {
+		t.Errorf("m5.large = %v, want 0.030 (cheapest zone, latest price)", prices["m5.large"])
+	}
+	if prices["c5.large"] != 0.031 {
+		t.Errorf("c5.large = %v, want 0.031", prices["c5.large"])
+	}
+	if _, ok := prices["r5.large"]; ok {
+		t.Error("unparseable price should be omitted")
+	}
+}
+
+func TestGetSpotPricesFromCache(t *testing.T) {
+	c := &Client{
+		cache:          make(map[string]PriceRecord),
+		cachePath:      filepath.Join(t.TempDir(), "pricing.json"),
+		ttl:            DefaultCacheTTL,
+		discountFactor: 1.0,
+	}
+	c.cache[spotCacheKey("us-east-1", "m5.large")] = PriceRecord{Price: 0.03, Timestamp: time.Now().Unix()}
+
+	// Every type is cached, so no EC2 client is needed.
+	prices, err := c.GetSpotPrices(context.Background(), "us-east-1", []string{"m5.large"})
+	if err != nil {
+		t.Fatalf("GetSpotPrices failed: %v", err)
+	}
+	if want := 0.03 * HoursPerMonth; prices["m5.large"] != want {
+		t.Errorf("m5.large = %.2f, want %.2f", prices["m5.large"], want)
+	}
+}
@@ -0,0 +1,23 @@
+package pricing
+
+import "context"
+
+// WAF list prices (per month). They are the same in every commercial region.
+const (
+	WAFWebACLMonth     = 5.00
+	WAFRuleMonth       = 1.00
+	WAFMillionRequests = 0.60
+)
+
+// EstimateWAFPrice is the monthly cost of a web ACL with the given number of
+// rules and groups, inspecting monthlyRequests requests.
+func EstimateWAFPrice(rules int, monthlyRequests float64) float64 {
+	return WAFWebACLMonth + float64(rules)*WAFRuleMonth + monthlyRequests/1e6*WAFMillionRequests
+}
+
+// GetWAFPrice estimates a web ACL's monthly cost: the ACL, each rule on it and
+// the requests it inspects. WAF is not priced per region, so no lookup is made;
+// only the account discount applies.
+func (c *Client) GetWAFPrice(ctx context.Context, region string, rules int, monthlyRequests float64) (float64, error) {
+	return EstimateWAFPrice(rules, monthlyRequests) * c.discountFactor, nil
+}
@@ -0,0 +1,108 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// EBSVolumeTypes are the volume types GetEBSPrice looks up in the Pricing API.
+var EBSVolumeTypes = []string{"gp2", "gp3", "io1", "st1", "sc1", "standard"}
+
+// WarmResult counts the prices Warm looked at.
+type WarmResult struct {
+	Fetched int // Retrieved from the Pricing API.
+	Cached  int // Already cached and within the TTL.
+	Failed  int
+}
+
+// warmJob is one cache entry to fill.
+type warmJob struct {
+	key   string
+	fetch func(ctx context.Context) (float64, error)
+}
+
+// Warm fills the cache with Linux on-demand prices for instanceTypes, every
+// EBSVolumeTypes price and the NAT Gateway price in each region, then writes
+// it to disk. A later scan without Pricing API access reads them from there.
+// Entries still within the TTL are not fetched again. Elastic IP prices are
+// fixed and need no entry.
+func (c *Client) Warm(ctx context.Context, regions, instanceTypes []string, workers int) WarmResult {
+	if workers <= 0 {
+		workers = DefaultPrefetchWorkers
+	}
+
+	var jobs []warmJob
+	for _, region := range regions {
+		for _, it := range instanceTypes {
+			jobs = append(jobs, warmJob{
+				key: ec2CacheKey(region, it, "Linux", "NA"),
+				fetch: func(ctx context.Context) (float64, error) {
+					return c.fetchEC2Price(ctx, region, it, "Linux", "NA")
+				},
+			})
+		}
+		for _, vt := range EBSVolumeTypes {
+			jobs = append(jobs, warmJob{
+				key: fmt.Sprintf("ebs-%s-%s", region, vt),
+				fetch: func(ctx context.Context) (float64, error) {
+					return c.fetchEBSPrice(ctx, region, vt)
+				},
+			})
+		}
+		jobs = append(jobs, warmJob{
+			key: fmt.Sprintf("nat-%s", region),
+			fetch: func(ctx context.Context) (float64, error) {
+				return c.fetchNATPrice(ctx, region)
+			},
+		})
+	}
+
+	var result WarmResult
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	queue := make(chan warmJob)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				c.mu.RLock()
+				record, ok := c.cache[job.key]
+				c.mu.RUnlock()
+				if ok && time.Since(time.Unix(record.Timestamp, 0)) < c.ttl {
+					mu.Lock()
+					result.Cached++
+					mu.Unlock()
+					continue
+				}
+
+				price, err := job.fetch(ctx)
+				if err != nil || price == 0 {
+					c.logger.Debug("Warm miss", "key", job.key, "error", err)
+					mu.Lock()
+					result.Failed++
+					mu.Unlock()
+					continue
+				}
+				c.storePrice(job.key, price)
+				mu.Lock()
+				result.Fetched++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, job := range jobs {
+		select {
+		case queue <- job:
+		case <-ctx.Done():
+		}
+	}
+	close(queue)
+	wg.Wait()
+	c.Flush()
+	return result
+}
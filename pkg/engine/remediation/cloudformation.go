@@ -0,0 +1,189 @@
+package remediation
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/DrSkyle/cloudslash/v2/pkg/providers/cfn"
+	"github.com/DrSkyle/cloudslash/v2/pkg/version"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+)
+
+// cfnResource is a waste resource owned by a stack.
+type cfnResource struct {
+	LogicalID, ID, Type, Reason string
+	Cost                        float64
+}
+
+// cfnStack groups a stack's waste. Stack names are unique per region only.
+type cfnStack struct {
+	Name, Region string
+	Cost         float64
+	Resources    []cfnResource
+}
+
+// GenerateCloudFormationDriftReport writes, for waste owned by CloudFormation
+// stacks, the logical IDs to remove from each stack's template. Deleting a
+// stack resource directly drifts the stack and its next update recreates the
+// resource; the fix belongs in the template. Nothing is written when no waste
+// is stack-managed.
+func (g *Generator) GenerateCloudFormationDriftReport(path string) error {
+	stacks := g.cfnStacks()
+	if len(stacks) == 0 {
+		return nil
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create drift report: %v", err)
+	}
+	defer f.Close()
+
+	fmt.Fprintf(f, "# CloudSlash %s - CloudFormation Drift Report\n", version.Current)
+	fmt.Fprintf(f, "# Generated: %s\n", time.Now().UTC().Format(time.RFC3339))
+	fmt.Fprintf(f, "#\n")
+	fmt.Fprintf(f, "# These resources are waste but belong to CloudFormation stacks. Deleting them\n")
+	fmt.Fprintf(f, "# directly drifts the stack, and its next update recreates them. For each stack:\n")
+	fmt.Fprintf(f, "#   1. Remove every logical ID under \"remove\" from the template's Resources,\n")
+	fmt.Fprintf(f, "#      with any Ref, Fn::GetAtt, DependsOn or Outputs entry naming it.\n")
+	fmt.Fprintf(f, "#   2. Run cfn-lint on the template to catch dangling references.\n")
+	fmt.Fprintf(f, "#   3. Update the stack through a change set and review it. Removed resources\n")
+	fmt.Fprintf(f, "#      are deleted unless their DeletionPolicy is Retain.\n")
+	fmt.Fprintf(f, "# Resources under \"unresolved\" carry no logical ID tag; find them with\n")
+	fmt.Fprintf(f, "# aws cloudformation describe-stack-resources --stack-name <stack>.\n")
+	fmt.Fprintf(f, "stacks:\n")
+
+	for _, s := range stacks {
+		fmt.Fprintf(f, "  - name: %s\n", yamlString(s.Name))
+		if s.Region != "" {
+			fmt.Fprintf(f, "    region: %s\n", yamlString(s.Region))
+		}
+		fmt.Fprintf(f, "    monthly_cost: %.2f\n", s.Cost)
+
+		var unresolved []cfnResource
+		wroteRemove := false
+		for _, r := range s.Resources {
+			if r.LogicalID == "" {
+				unresolved = append(unresolved, r)
+				continue
+			}
+			if !wroteRemove {
+				fmt.Fprintf(f, "    remove:\n")
+				wroteRemove = true
+			}
+			writeCFNComment(f, r)
+			fmt.Fprintf(f, "      - %s\n", yamlString(r.LogicalID))
+		}
+		if len(unresolved) > 0 {
+			fmt.Fprintf(f, "    unresolved:\n")
+			for _, r := range unresolved {
+				writeCFNComment(f, r)
+				fmt.Fprintf(f, "      - %s\n", yamlString(r.ID))
+			}
+		}
+	}
+	return nil
+}
+
+// cfnStacks collects stack-managed waste, most expensive stack first.
+// Justified findings are kept out; they are meant to stay.
+func (g *Generator) cfnStacks() []*cfnStack {
+	g.Graph.Mu.RLock()
+	defer g.Graph.Mu.RUnlock()
+
+	byStack := make(map[string]*cfnStack)
+	for _, node := range g.Graph.Store.GetAllNodes() {
+		if !node.IsWaste || node.Justified {
+			continue
+		}
+		name := cfn.StackName(node)
+		if name == "" {
+			continue
+		}
+		region := cfnRegion(node)
+		key := region + "/" + name
+		s, ok := byStack[key]
+		if !ok {
+			s = &cfnStack{Name: name, Region: region}
+			byStack[key] = s
+		}
+
+		reason, _ := node.Properties["Reason"].(string)
+		// --protect-cfn appends the same advice this report gives.
+		reason, _, _ = strings.Cut(reason, " [IaC-managed:")
+		s.Resources = append(s.Resources, cfnResource{
+			LogicalID: cfn.LogicalID(node),
+			ID:        node.IDStr(),
+			Type:      node.TypeStr(),
+			Reason:    reason,
+			Cost:      node.Cost,
+		})
+		s.Cost += node.Cost
+	}
+
+	stacks := make([]*cfnStack, 0, len(byStack))
+	for _, s := range byStack {
+		sort.Slice(s.Resources, func(i, j int) bool {
+			if s.Resources[i].LogicalID != s.Resources[j].LogicalID {
+				return s.Resources[i].LogicalID < s.Resources[j].LogicalID
+			}
+			return s.Resources[i].ID < s.Resources[j].ID
+		})
+		stacks = append(stacks, s)
+	}
+	sort.Slice(stacks, func(i, j int) bool {
+		if stacks[i].Cost != stacks[j].Cost {
+			return stacks[i].Cost > stacks[j].Cost
+		}
+		if stacks[i].Name != stacks[j].Name {
+			return stacks[i].Name < stacks[j].Name
+		}
+		return stacks[i].Region < stacks[j].Region
+	})
+	return stacks
+}
+
+// cfnRegion is the owning stack's region, from its stack ARN, or else the
+// resource's own.
+func cfnRegion(node *graph.Node) string {
+	if parsed, err := arn.Parse(cfn.StackID(node)); err == nil && parsed.Region != "" {
+		return parsed.Region
+	}
+	if region, _ := node.Properties["Region"].(string); region != "" {
+		return region
+	}
+	if parsed, err := arn.Parse(node.IDStr()); err == nil {
+		return parsed.Region
+	}
+	return ""
+}
+
+func writeCFNComment(w io.Writer, r cfnResource) {
+	comment := fmt.Sprintf("%s %s ($%.2f/mo)", r.Type, extractResourceID(r.ID), r.Cost)
+	if r.Reason != "" {
+		comment += ": " + r.Reason
+	}
+	fmt.Fprintf(w, "      # %s\n", strings.Join(strings.Fields(comment), " "))
+}
+
+var yamlPlain = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9._/-]*$`)
+
+// yamlString returns s as a YAML scalar, quoted unless it is a plain word
+// YAML would not read as a boolean or null.
+func yamlString(s string) string {
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "y", "n", "null":
+		return strconv.Quote(s)
+	}
+	if yamlPlain.MatchString(s) {
+		return s
+	}
+	return strconv.Quote(s)
+}
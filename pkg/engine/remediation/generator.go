@@ -15,6 +15,7 @@ import (
 	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
 	"github.com/DrSkyle/cloudslash/v2/pkg/resources"
 	"github.com/DrSkyle/cloudslash/v2/pkg/storage"
+	"github.com/DrSkyle/cloudslash/v2/pkg/version"
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/config"
 )
@@ -23,6 +24,7 @@ import (
 type Generator struct {
 	Graph  *graph.Graph
 	Logger *slog.Logger
+	ScanID string // Recorded in each plan header; empty when plans are not tied to a scan.
 }
 
 // NewGenerator initializes the generator.
@@ -57,9 +59,15 @@ type Condition struct {
 
 // TransactionManifest is the remediation log.
 type TransactionManifest struct {
-	Version     string       `json:"version"`
-	GeneratedAt time.Time    `json:"generated_at"`
-	Actions     []PlanAction `json:"actions"`
+	// SchemaVersion is the plan format; see PlanSchemaVersion and ValidatePlan.
+	SchemaVersion int `json:"schema_version"`
+	// Version is the revision of the plan kind ("1.0" remediation, "2.0" ignore
+	// and restoration). Compatibility is decided by SchemaVersion alone.
+	Version           string       `json:"version"`
+	GeneratedAt       time.Time    `json:"generated_at"`
+	CloudSlashVersion string       `json:"cloudslash_version,omitempty"`
+	ScanID            string       `json:"scan_id,omitempty"`
+	Actions           []PlanAction `json:"actions"`
 }
 
 // Deprecated: Use TransactionManifest
@@ -67,11 +75,7 @@ type RemediationPlan = TransactionManifest
 
 // GenerateRemediationPlan creates a JSON plan.
 func (g *Generator) GenerateRemediationPlan(path string) error {
-	plan := RemediationPlan{
-		Version:     "1.0",
-		GeneratedAt: time.Now(),
-		Actions:     []PlanAction{},
-	}
+	plan := g.newManifest("1.0")
 
 	g.Graph.Mu.RLock()
 	defer g.Graph.Mu.RUnlock()
@@ -119,7 +123,9 @@ func (g *Generator) GenerateRemediationPlan(path string) error {
 
 		// Phase 1: Snapshot/Tombstone (Side Effect)
 		region := "unknown"
-		if r, ok := node.Properties["region"].(string); ok {
+		if r, ok := node.Properties["Region"].(string); ok && r != "" {
+			region = r
+		} else if r, ok := node.Properties["region"].(string); ok {
 			region = r
 		}
 
@@ -141,6 +147,7 @@ func (g *Generator) GenerateRemediationPlan(path string) error {
 		// Default Params
 		params := map[string]interface{}{
 			"Region": region,
+			"ARN":    node.IDStr(),
 			"Tags": map[string]string{
 				"CloudSlash:Status":     "Purgatory",
 				"CloudSlash:ExpiryDate": expiry,
@@ -160,8 +167,85 @@ func (g *Generator) GenerateRemediationPlan(path string) error {
 			Params: map[string]string{"ID": resourceID, "Region": region},
 		})
 
+		// A Service Control Policy or permissions boundary denies the delete.
+		if by, ok := node.Properties["RemediationBlocked"].(string); ok {
+			action.Operation = "BLOCKED"
+			action.Description = fmt.Sprintf("Cannot remediate: blocked by policy (%s)", by)
+			params["BlockedBy"] = by
+			action.Parameters = params
+			plan.Actions = append(plan.Actions, action)
+			continue
+		}
+
+		// Production resources (--env-tag) are left to a human.
+		env, _ := node.Properties["Environment"].(string)
+		if env != "" {
+			params["Environment"] = env
+		}
+		if caution, _ := node.Properties["RemediationCaution"].(string); caution == "manual-review" {
+			action.Operation = "MANUAL_REVIEW"
+			action.Description = "Review manually: finding needs review"
+			if env != "" {
+				action.Description = fmt.Sprintf("Review manually: %s resource", env)
+			}
+			action.Parameters = params
+			plan.Actions = append(plan.Actions, action)
+			continue
+		}
+
+		// The executor only speaks AWS; the script lists the gcloud commands instead.
+		if strings.HasPrefix(node.TypeStr(), "GCP::") {
+			action.Operation = "MANUAL_REVIEW"
+			action.Description = fmt.Sprintf("Review manually: %s, remediate with gcloud", node.TypeStr())
+			params["Zone"], _ = node.Properties["Zone"].(string)
+			params["Project"] = gcpProject(node.IDStr())
+			action.Parameters = params
+			plan.Actions = append(plan.Actions, action)
+			continue
+		}
+
+		// CloudFormation would recreate the resource; the fix belongs in the template.
+		if stack, ok := node.Properties["CFNStack"].(string); ok {
+			action.Operation = "IAC_REVIEW"
+			action.Description = fmt.Sprintf("Remove from CloudFormation stack %s template", stack)
+			params["StackName"] = stack
+			action.Parameters = params
+			plan.Actions = append(plan.Actions, action)
+			continue
+		}
+
+		// Only gateways NATInstanceHeuristic picked get replaced. Swapping egress
+		// paths needs route table changes; leave it to a human.
+		if inst, ok := node.Properties["NATInstanceRecommendation"].(string); ok {
+			action.Operation = "REPLACE_NAT"
+			action.Description = fmt.Sprintf("Replace NAT Gateway with a %s NAT instance (manual)", inst)
+			params["InstanceType"] = inst
+			action.Parameters = params
+			plan.Actions = append(plan.Actions, action)
+			continue
+		}
+
 		switch node.TypeStr() {
 		case resources.EC2Instance:
+			if to, ok := node.Properties["RecommendedInstanceType"].(string); ok && to != "" {
+				// Right-sizing keeps the instance; it only changes its type.
+				from, _ := node.Properties["InstanceType"].(string)
+				action.Operation = "MODIFY"
+				action.Description = fmt.Sprintf("Resize EC2 Instance to %s", to)
+				params["InstanceType"] = to
+				action.PostConditions = append(action.PostConditions, Condition{
+					Type:   "PROPERTY_MATCH",
+					Params: map[string]string{"ID": resourceID, "Region": region, "Property": "InstanceType", "Value": to},
+				})
+				if from != "" {
+					action.Rollback = &PlanAction{
+						ID: resourceID, Type: node.TypeStr(), Operation: "MODIFY",
+						Description: fmt.Sprintf("Rollback: Resize EC2 Instance to %s", from),
+						Parameters:  map[string]interface{}{"Region": region, "InstanceType": from},
+					}
+				}
+				break
+			}
 			action.Operation = "STOP"
 			action.Description = "Tag and Stop EC2 Instance"
 
@@ -193,12 +277,56 @@ func (g *Generator) GenerateRemediationPlan(path string) error {
 					Type:   "PROPERTY_MATCH",
 					Params: map[string]string{"ID": resourceID, "Region": region, "Property": "VolumeType", "Value": "gp3"},
 				})
+			} else if downshift, _ := node.Properties["GP3Downshift"].(bool); downshift {
+				// Performance change only; the volume and its data stay.
+				action.Operation = "MODIFY"
+				action.Description = "Reduce gp3 Volume provisioned IOPS/throughput"
+				params["Iops"] = fmt.Sprint(node.Properties["RecommendedIops"])
+				params["Throughput"] = fmt.Sprint(node.Properties["RecommendedThroughput"])
+
+				action.PostConditions = append(action.PostConditions, Condition{
+					Type:   "PROPERTY_MATCH",
+					Params: map[string]string{"ID": resourceID, "Region": region, "Property": "Iops", "Value": params["Iops"].(string)},
+				})
 			} else {
 				action.Operation = "SNAPSHOT_AND_DELETE" // Upgraded from DELETE
 				action.Description = "Snapshot, Tag and Delete EBS Volume"
 			}
 
+		case "AWS::EFS::FileSystem":
+			if lc, _ := node.Properties["LifecycleRecommendation"].(bool); lc {
+				// Tiering keeps the data; nothing is deleted.
+				action.Operation = "PUT_LIFECYCLE"
+				action.Description = "Add EFS lifecycle policy (IA after 30 days)"
+				params["TransitionToIA"] = "AFTER_30_DAYS"
+				params["TransitionToPrimaryStorageClass"] = "AFTER_1_ACCESS"
+				action.PostConditions = append(action.PostConditions, Condition{
+					Type:   "PROPERTY_MATCH",
+					Params: map[string]string{"ID": resourceID, "Region": region, "Property": "TransitionToIA", "Value": "AFTER_30_DAYS"},
+				})
+				break
+			}
+			action.Operation = "DELETE"
+			action.Description = "Delete EFS File System"
+			action.PostConditions = append(action.PostConditions, Condition{
+				Type:   "NOT_EXISTS",
+				Params: map[string]string{"ID": resourceID, "Region": region},
+			})
+
 		case "AWS::RDS::DBInstance":
+			if isReplica, _ := node.Properties["IsReadReplica"].(bool); isReplica {
+				// Replicas hold no unique data; deleting one leaves the primary untouched.
+				action.Operation = "DELETE_REPLICA"
+				action.Description = "Delete RDS Read Replica"
+				if source, ok := node.Properties["ReplicaSource"].(string); ok {
+					params["ReplicaSource"] = source
+				}
+				action.PostConditions = append(action.PostConditions, Condition{
+					Type:   "NOT_EXISTS",
+					Params: map[string]string{"ID": resourceID, "Region": region},
+				})
+				break
+			}
 			action.Operation = "STOP"
 			action.Description = "Tag and Stop RDS Instance"
 			action.PostConditions = append(action.PostConditions, Condition{
@@ -218,6 +346,18 @@ func (g *Generator) GenerateRemediationPlan(path string) error {
 				Params: map[string]string{"ID": resourceID, "Region": region},
 			})
 
+		case "AWS::DMS::ReplicationInstance":
+			// An instance cannot be deleted while tasks reference it; they go first.
+			action.Operation = "DELETE"
+			action.Description = "Delete DMS Replication Instance"
+			if tasks, ok := node.Properties["ReplicationTaskArns"].([]string); ok && len(tasks) > 0 {
+				params["ReplicationTasks"] = tasks
+			}
+			action.PostConditions = append(action.PostConditions, Condition{
+				Type:   "NOT_EXISTS",
+				Params: map[string]string{"ID": resourceID, "Region": region},
+			})
+
 		case "AWS::EC2::EIP":
 			action.Operation = "RELEASE"
 			action.Description = "Release Elastic IP"
@@ -234,6 +374,27 @@ func (g *Generator) GenerateRemediationPlan(path string) error {
 				Params: map[string]string{"ID": resourceID, "Region": region},
 			})
 
+		case "aws_redshift_cluster":
+			if act, _ := node.Properties["RedshiftAction"].(string); act == "resize" {
+				// A resize redistributes data and interrupts queries; leave it to a human.
+				action.Operation = "RESIZE_CLUSTER"
+				action.Description = fmt.Sprintf("Resize Redshift Cluster to %v nodes (manual)", node.Properties["RecommendedNodes"])
+				params["NumberOfNodes"] = fmt.Sprint(node.Properties["RecommendedNodes"])
+				break
+			}
+			// Pausing stops compute billing and keeps the data.
+			action.Operation = "PAUSE"
+			action.Description = "Pause Redshift Cluster"
+			action.PostConditions = append(action.PostConditions, Condition{
+				Type:   "STATUS_MATCH",
+				Params: map[string]string{"ID": resourceID, "Region": region, "Value": "paused"},
+			})
+			action.Rollback = &PlanAction{
+				ID: resourceID, Type: node.TypeStr(), Operation: "RESUME",
+				Description: "Rollback: Resume Redshift Cluster",
+				Parameters:  map[string]interface{}{"Region": region},
+			}
+
 		// ... (others keep basic DELETE) ...
 		default:
 			action.Operation = "DELETE" // Conservative default if known waste
@@ -268,7 +429,14 @@ func (g *Generator) GenerateBashScript(path string, plan TransactionManifest) er
 
 	fmt.Fprintf(f, "#!/bin/bash\n")
 	fmt.Fprintf(f, "# CloudSlash Safe Cleanup Script v%s\n", plan.Version)
-	fmt.Fprintf(f, "# Generated: %s\n\n", plan.GeneratedAt)
+	fmt.Fprintf(f, "# Generated: %s\n", plan.GeneratedAt)
+	if plan.CloudSlashVersion != "" {
+		fmt.Fprintf(f, "# CloudSlash: %s (plan schema v%d)\n", plan.CloudSlashVersion, plan.SchemaVersion)
+	}
+	if plan.ScanID != "" {
+		fmt.Fprintf(f, "# Scan: %s\n", plan.ScanID)
+	}
+	fmt.Fprintf(f, "\n")
 	fmt.Fprintf(f, "set -e\n\n")
 
 	for _, action := range plan.Actions {
@@ -295,11 +463,62 @@ func (g *Generator) GenerateBashScript(path string, plan TransactionManifest) er
 			// FIX: Use sanitized variables for volume-id and tags
 			fmt.Fprintf(f, "aws ec2 create-snapshot --volume-id %s --description 'CloudSlash Auto-Backup' --tag-specifications 'ResourceType=snapshot,Tags=[{Key=CreatedBy,Value=CloudSlash},{Key=SourceVolume,Value=%s}]' --region %s\n", id, id, region)
 			fmt.Fprintf(f, "aws ec2 delete-volume --volume-id %s --region %s\n", id, region)
+		case "MODIFY":
+			if action.Type == "AWS::EC2::Volume" {
+				cmd := fmt.Sprintf("aws ec2 modify-volume --volume-id %s", id)
+				if vt, ok := action.Parameters["VolumeType"].(string); ok {
+					cmd += " --volume-type " + shellQuote(vt)
+				}
+				if iops, ok := action.Parameters["Iops"].(string); ok {
+					cmd += " --iops " + shellQuote(iops)
+				}
+				if tp, ok := action.Parameters["Throughput"].(string); ok {
+					cmd += " --throughput " + shellQuote(tp)
+				}
+				fmt.Fprintf(f, "%s --region %s\n", cmd, region)
+			}
+			if action.Type == resources.EC2Instance {
+				// The type can only change while the instance is stopped.
+				it := shellQuote(action.Parameters["InstanceType"].(string))
+				fmt.Fprintf(f, "aws ec2 stop-instances --instance-ids %s --region %s\n", id, region)
+				fmt.Fprintf(f, "aws ec2 wait instance-stopped --instance-ids %s --region %s\n", id, region)
+				fmt.Fprintf(f, "aws ec2 modify-instance-attribute --instance-id %s --instance-type Value=%s --region %s\n", id, it, region)
+				fmt.Fprintf(f, "aws ec2 start-instances --instance-ids %s --region %s\n", id, region)
+			}
+		case "PUT_LIFECYCLE":
+			fmt.Fprintf(f, "aws efs put-lifecycle-configuration --file-system-id %s --lifecycle-policies '[{\"TransitionToIA\":\"AFTER_30_DAYS\"},{\"TransitionToPrimaryStorageClass\":\"AFTER_1_ACCESS\"}]' --region %s\n", id, region)
+		case "MANUAL_REVIEW":
+			if cmds := gcloudCommands(action); cmds != nil {
+				for _, cmd := range cmds {
+					fmt.Fprintf(f, "# Manual: %s\n", cmd)
+				}
+				break
+			}
+			fmt.Fprintf(f, "# Skipped: %s is tagged as a production resource (%s); review and remediate manually.\n", id, shellQuote(fmt.Sprint(action.Parameters["Environment"])))
+		case "REPLACE_NAT":
+			fmt.Fprintf(f, "# Manual: launch a %s NAT instance (fck-nat), repoint private route tables, then delete NAT Gateway %s.\n", shellQuote(action.Parameters["InstanceType"].(string)), id)
+		case "PAUSE":
+			if action.Type == "aws_redshift_cluster" {
+				fmt.Fprintf(f, "aws redshift pause-cluster --cluster-identifier %s --region %s\n", id, region)
+			}
+		case "RESIZE_CLUSTER":
+			fmt.Fprintf(f, "# Manual: aws redshift resize-cluster --cluster-identifier %s --number-of-nodes %s --region %s\n", id, shellQuote(fmt.Sprint(action.Parameters["NumberOfNodes"])), region)
+		case "DELETE_REPLICA":
+			// Replicas cannot take a final snapshot; the primary retains the data.
+			fmt.Fprintf(f, "aws rds delete-db-instance --db-instance-identifier %s --skip-final-snapshot --region %s\n", id, region)
 		case "DELETE":
 			if action.Type == "AWS::EC2::NatGateway" {
 				// FIX: Use sanitized variables
 				fmt.Fprintf(f, "aws ec2 delete-nat-gateway --nat-gateway-id %s --region %s\n", id, region)
 			}
+			if action.Type == "AWS::DMS::ReplicationInstance" {
+				for _, task := range dmsTaskArns(action.Parameters["ReplicationTasks"]) {
+					fmt.Fprintf(f, "aws dms delete-replication-task --replication-task-arn %s --region %s\n", shellQuote(task), region)
+					fmt.Fprintf(f, "aws dms wait replication-task-deleted --filters Name=replication-task-arn,Values=%s --region %s\n", shellQuote(task), region)
+				}
+				instanceArn, _ := action.Parameters["ARN"].(string)
+				fmt.Fprintf(f, "aws dms delete-replication-instance --replication-instance-arn %s --region %s\n", shellQuote(instanceArn), region)
+			}
 		// Add other cases as needed
 		}
 		fmt.Fprintf(f, "\n")
@@ -308,6 +527,55 @@ func (g *Generator) GenerateBashScript(path string, plan TransactionManifest) er
 	return os.Chmod(path, 0755)
 }
 
+// dmsTaskArns reads the ReplicationTasks parameter, whether built in memory
+// ([]string) or loaded from JSON ([]interface{}).
+func dmsTaskArns(v interface{}) []string {
+	switch tasks := v.(type) {
+	case []string:
+		return tasks
+	case []interface{}:
+		out := make([]string, 0, len(tasks))
+		for _, t := range tasks {
+			if s, ok := t.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// gcloudCommands are the commands that remediate a GCP action: a disk is
+// snapshotted then deleted, a VM stopped. Other actions return nil.
+func gcloudCommands(action PlanAction) []string {
+	zone, _ := action.Parameters["Zone"].(string)
+	project, _ := action.Parameters["Project"].(string)
+	scope := fmt.Sprintf("--zone %s --project %s", shellQuote(zone), shellQuote(project))
+	id := shellQuote(action.ID)
+	switch action.Type {
+	case "GCP::Compute::Disk":
+		return []string{
+			fmt.Sprintf("gcloud compute snapshots create %s --source-disk %s --source-disk-zone %s --project %s", shellQuote(action.ID+"-cloudslash-backup"), id, shellQuote(zone), shellQuote(project)),
+			fmt.Sprintf("gcloud compute disks delete %s %s --quiet", id, scope),
+		}
+	case "GCP::Compute::Instance":
+		return []string{fmt.Sprintf("gcloud compute instances stop %s %s", id, scope)}
+	}
+	return nil
+}
+
+// gcpProject reads the project from a GCP resource URL
+// (.../projects/<project>/zones/...).
+func gcpProject(selfLink string) string {
+	parts := strings.Split(selfLink, "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if parts[i] == "projects" {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
+
 // shellQuote quotes a string for bash.
 func shellQuote(s string) string {
 	if s == "" {
@@ -317,11 +585,7 @@ func shellQuote(s string) string {
 }
 // GenerateRestorationPlan creates a restoration plan.
 func (g *Generator) GenerateRestorationPlan(path string) error {
-	plan := TransactionManifest{
-		Version:     "2.0",
-		GeneratedAt: time.Now(),
-		Actions:     []PlanAction{},
-	}
+	plan := g.newManifest("2.0")
 
 	g.Graph.Mu.RLock()
 	defer g.Graph.Mu.RUnlock()
@@ -374,11 +638,7 @@ func (g *Generator) GenerateRestorationPlan(path string) error {
 
 // GenerateIgnorePlan creates an ignore plan.
 func (g *Generator) GenerateIgnorePlan(path string) error {
-	plan := TransactionManifest{
-		Version:     "2.0",
-		GeneratedAt: time.Now(),
-		Actions:     []PlanAction{},
-	}
+	plan := g.newManifest("2.0")
 
 	g.Graph.Mu.RLock()
 	defer g.Graph.Mu.RUnlock()
@@ -412,7 +672,7 @@ func (g *Generator) GenerateIgnorePlan(path string) error {
 			Operation:   "TAG_IGNORE",
 			Description: "Apply cloudslash:ignore tag",
 			Parameters: map[string]interface{}{
-				"Tags": map[string]string{"cloudslash:ignore": "true"},
+				"Tags": map[string]string{IgnoreTagKey: "true"},
 				"ARN":  node.IDStr(),
 			},
 		}
@@ -423,6 +683,10 @@ func (g *Generator) GenerateIgnorePlan(path string) error {
 }
 
 func extractResourceID(id string) string {
+	// GCP resources are keyed by URL; the name is the last segment.
+	if strings.HasPrefix(id, "https://") {
+		return id[strings.LastIndex(id, "/")+1:]
+	}
 	// Parse ARN using official library.
 	if parsed, err := arn.Parse(id); err == nil {
 		// Use fields function to split by / or : safely
@@ -434,7 +698,19 @@ func extractResourceID(id string) string {
 		}
 		return parsed.Resource
 	}
-	return id
+	return graph.UnqualifyID(id)
+}
+
+// newManifest starts a plan with the header every plan carries.
+func (g *Generator) newManifest(revision string) TransactionManifest {
+	return TransactionManifest{
+		SchemaVersion:     PlanSchemaVersion,
+		Version:           revision,
+		GeneratedAt:       time.Now(),
+		CloudSlashVersion: version.Current,
+		ScanID:            g.ScanID,
+		Actions:           []PlanAction{},
+	}
 }
 
 func writeJSON(path string, data interface{}) error {
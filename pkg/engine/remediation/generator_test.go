@@ -1,13 +1,16 @@
 package remediation
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/DrSkyle/cloudslash/v2/pkg/version"
 	"github.com/sebdah/goldie/v2"
 	"github.com/stretchr/testify/assert"
 )
@@ -95,6 +98,9 @@ func TestGenerateRemediationPlan_Golden(t *testing.T) {
 	re := regexp.MustCompile(`"generated_at": ".*"`)
 	content = re.ReplaceAllString(content, `"generated_at": "2026-01-01T00:00:00Z"`)
 	
+	reRelease := regexp.MustCompile(`"cloudslash_version": ".*"`)
+	content = reRelease.ReplaceAllString(content, `"cloudslash_version": "v0.0.0"`)
+
 	reExpiry := regexp.MustCompile(`"CloudSlash:ExpiryDate": ".*"`)
 	content = reExpiry.ReplaceAllString(content, `"CloudSlash:ExpiryDate": "2026-03-04"`)
 
@@ -162,3 +168,447 @@ func TestGenerateBashScript_SecurityInjection(t *testing.T) {
 		t.Errorf("Script did not contain expected escaping. Got:\n%s", scriptContent)
 	}
 }
+
+// TestGenerateRemediationPlan_ReadReplica ensures replicas are deleted, not stopped.
+func TestGenerateRemediationPlan_ReadReplica(t *testing.T) {
+	g := graph.NewGraph()
+	replicaARN := "arn:aws:rds:us-east-1:123:db:reports-replica"
+	g.AddNode(replicaARN, "AWS::RDS::DBInstance", map[string]interface{}{
+		"IsReadReplica": true,
+		"ReplicaSource": "reports-primary",
+		"region":        "us-east-1",
+	})
+	g.CloseAndWait()
+	g.MarkWaste(replicaARN, 70)
+
+	tmpDir := t.TempDir()
+	planPath := filepath.Join(tmpDir, "remediation_plan.json")
+	gen := NewGenerator(g, nil)
+	if err := gen.GenerateRemediationPlan(planPath); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	planBytes, _ := os.ReadFile(planPath)
+	assert.Contains(t, string(planBytes), `"operation": "DELETE_REPLICA"`)
+	assert.NotContains(t, string(planBytes), `"operation": "STOP"`)
+
+	script, _ := os.ReadFile(filepath.Join(tmpDir, "remediation_plan.sh"))
+	assert.Contains(t, string(script), "aws rds delete-db-instance --db-instance-identifier 'reports-replica' --skip-final-snapshot --region 'us-east-1'")
+}
+
+func TestGenerateRemediationPlan_EFSLifecycle(t *testing.T) {
+	g := graph.NewGraph()
+	fsARN := "arn:aws:elasticfilesystem:us-east-1:123:file-system/fs-0abc"
+	g.AddNode(fsARN, "AWS::EFS::FileSystem", map[string]interface{}{
+		"LifecycleRecommendation": true,
+		"region":                  "us-east-1",
+	})
+	g.CloseAndWait()
+	g.MarkWaste(fsARN, 5)
+
+	tmpDir := t.TempDir()
+	planPath := filepath.Join(tmpDir, "remediation_plan.json")
+	gen := NewGenerator(g, nil)
+	if err := gen.GenerateRemediationPlan(planPath); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	planBytes, _ := os.ReadFile(planPath)
+	assert.Contains(t, string(planBytes), `"operation": "PUT_LIFECYCLE"`)
+
+	script, _ := os.ReadFile(filepath.Join(tmpDir, "remediation_plan.sh"))
+	assert.Contains(t, string(script), "aws efs put-lifecycle-configuration --file-system-id 'fs-0abc'")
+	assert.NotContains(t, string(script), "delete-file-system")
+}
+
+func TestGenerateRemediationPlan_NATInstance(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("nat-0dev", "aws_nat_gateway", map[string]interface{}{
+		"NATInstanceRecommendation": "t4g.nano",
+		"region":                    "us-east-1",
+	})
+	// A gateway NATInstanceHeuristic did not pick keeps its usual handling.
+	g.AddNode("nat-0idle", "aws_nat_gateway", map[string]interface{}{"region": "us-east-1"})
+	g.CloseAndWait()
+	g.MarkWaste("nat-0dev", 10)
+	g.MarkWaste("nat-0idle", 80)
+
+	tmpDir := t.TempDir()
+	planPath := filepath.Join(tmpDir, "remediation_plan.json")
+	gen := NewGenerator(g, nil)
+	if err := gen.GenerateRemediationPlan(planPath); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	planBytes, _ := os.ReadFile(planPath)
+	assert.Contains(t, string(planBytes), `"operation": "REPLACE_NAT"`)
+	assert.Contains(t, string(planBytes), `"description": "Delete aws_nat_gateway"`)
+
+	script, _ := os.ReadFile(filepath.Join(tmpDir, "remediation_plan.sh"))
+	assert.Contains(t, string(script), "# Manual: launch a 't4g.nano' NAT instance")
+	assert.NotContains(t, string(script), "delete-nat-gateway")
+}
+
+func TestGenerateRemediationPlan_GP3Downshift(t *testing.T) {
+	g := graph.NewGraph()
+	volARN := "arn:aws:ec2:us-east-1:123:volume/vol-0fast"
+	g.AddNode(volARN, "AWS::EC2::Volume", map[string]interface{}{
+		"GP3Downshift":          true,
+		"RecommendedIops":       3000,
+		"RecommendedThroughput": 125,
+		"region":                "us-east-1",
+	})
+	g.CloseAndWait()
+	g.MarkWaste(volARN, 30)
+
+	tmpDir := t.TempDir()
+	planPath := filepath.Join(tmpDir, "remediation_plan.json")
+	gen := NewGenerator(g, nil)
+	if err := gen.GenerateRemediationPlan(planPath); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	planBytes, _ := os.ReadFile(planPath)
+	assert.Contains(t, string(planBytes), `"operation": "MODIFY"`)
+
+	script, _ := os.ReadFile(filepath.Join(tmpDir, "remediation_plan.sh"))
+	assert.Contains(t, string(script), "aws ec2 modify-volume --volume-id 'vol-0fast' --iops '3000' --throughput '125' --region 'us-east-1'")
+	assert.NotContains(t, string(script), "delete-volume")
+}
+
+func TestGenerateRemediationPlan_RightSize(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("i-oversized", "AWS::EC2::Instance", map[string]interface{}{
+		"InstanceType":            "m5.2xlarge",
+		"RecommendedInstanceType": "m5.large",
+		"Region":                  "us-east-1",
+	})
+	g.CloseAndWait()
+	g.MarkWaste("i-oversized", 40)
+
+	tmpDir := t.TempDir()
+	planPath := filepath.Join(tmpDir, "remediation_plan.json")
+	gen := NewGenerator(g, nil)
+	if err := gen.GenerateRemediationPlan(planPath); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	planBytes, _ := os.ReadFile(planPath)
+	assert.Contains(t, string(planBytes), `"operation": "MODIFY"`)
+	assert.NotContains(t, string(planBytes), `"operation": "STOP"`)
+
+	script, _ := os.ReadFile(filepath.Join(tmpDir, "remediation_plan.sh"))
+	assert.Contains(t, string(script), "aws ec2 modify-instance-attribute --instance-id 'i-oversized' --instance-type Value='m5.large' --region 'us-east-1'")
+	assert.Contains(t, string(script), "aws ec2 start-instances --instance-ids 'i-oversized'")
+	assert.NotContains(t, string(script), "CloudSlash:Status,Value=Purgatory")
+}
+
+func TestGenerateRemediationPlan_GCP(t *testing.T) {
+	disk := "https://www.googleapis.com/compute/v1/projects/analytics/zones/us-central1-a/disks/scratch"
+	g := graph.NewGraph()
+	g.AddNode(disk, "GCP::Compute::Disk", map[string]interface{}{"Zone": "us-central1-a", "Region": "us-central1"})
+	g.CloseAndWait()
+	g.MarkWaste(disk, 90)
+
+	t.Chdir(t.TempDir()) // Tombstones are written relative to the working directory.
+	tmpDir := t.TempDir()
+	planPath := filepath.Join(tmpDir, "remediation_plan.json")
+	if err := NewGenerator(g, nil).GenerateRemediationPlan(planPath); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	plan, err := LoadManifest(planPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if assert.Len(t, plan.Actions, 1) {
+		assert.Equal(t, "scratch", plan.Actions[0].ID)
+		assert.Equal(t, "MANUAL_REVIEW", plan.Actions[0].Operation, "the executor cannot act on GCP resources")
+	}
+
+	script, _ := os.ReadFile(filepath.Join(tmpDir, "remediation_plan.sh"))
+	assert.Contains(t, string(script), "# Manual: gcloud compute disks delete 'scratch' --zone 'us-central1-a' --project 'analytics' --quiet")
+	assert.NotContains(t, string(script), "aws ")
+}
+
+func TestGenerateRemediationPlan_Redshift(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("analytics-idle", "aws_redshift_cluster", map[string]interface{}{
+		"RedshiftAction": "pause",
+		"Region":         "us-east-1",
+	})
+	g.AddNode("analytics-big", "aws_redshift_cluster", map[string]interface{}{
+		"RedshiftAction":   "resize",
+		"RecommendedNodes": 4,
+		"Region":           "us-east-1",
+	})
+	g.CloseAndWait()
+	g.MarkWaste("analytics-idle", 70)
+	g.MarkWaste("analytics-big", 40)
+
+	tmpDir := t.TempDir()
+	planPath := filepath.Join(tmpDir, "remediation_plan.json")
+	gen := NewGenerator(g, nil)
+	if err := gen.GenerateRemediationPlan(planPath); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	planBytes, _ := os.ReadFile(planPath)
+	assert.Contains(t, string(planBytes), `"operation": "PAUSE"`)
+	assert.Contains(t, string(planBytes), `"operation": "RESIZE_CLUSTER"`)
+	if _, err := ValidatePlan(planPath); err != nil {
+		t.Errorf("ValidatePlan: %v", err)
+	}
+
+	script, _ := os.ReadFile(filepath.Join(tmpDir, "remediation_plan.sh"))
+	assert.Contains(t, string(script), "aws redshift pause-cluster --cluster-identifier 'analytics-idle' --region 'us-east-1'")
+	assert.Contains(t, string(script), "# Manual: aws redshift resize-cluster --cluster-identifier 'analytics-big' --number-of-nodes '4'")
+	assert.NotContains(t, string(script), "delete-cluster")
+}
+
+func TestGenerateCloudFormationDriftReport(t *testing.T) {
+	stackTags := func(stack, logicalID string) map[string]string {
+		tags := map[string]string{"aws:cloudformation:stack-name": stack}
+		if logicalID != "" {
+			tags["aws:cloudformation:logical-id"] = logicalID
+		}
+		return tags
+	}
+
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:ec2:us-east-1:123456789012:volume/vol-0data", "AWS::EC2::Volume", map[string]interface{}{
+		"Tags": stackTags("billing-api", "DataVolume"),
+	})
+	g.AddNode("arn:aws:ec2:us-east-1:123456789012:volume/vol-0cache", "AWS::EC2::Volume", map[string]interface{}{
+		"Tags": stackTags("billing-api", "CacheVolume"),
+	})
+	g.AddNode("arn:aws:ec2:us-east-1:123456789012:natgateway/nat-0old", "aws_nat_gateway", map[string]interface{}{
+		"Tags": stackTags("network", ""),
+	})
+	g.AddNode("arn:aws:ec2:eu-west-1:123456789012:volume/vol-0eu", "AWS::EC2::Volume", map[string]interface{}{
+		"Tags": stackTags("billing-api", "DataVolume"),
+	})
+	g.AddNode("arn:aws:ec2:us-east-1:123456789012:volume/vol-0dr", "AWS::EC2::Volume", map[string]interface{}{
+		"Tags": map[string]string{
+			"aws:cloudformation:stack-name": "billing-api",
+			"aws:cloudformation:logical-id": "DrVolume",
+			"cloudslash:ignore":             "justified:dr",
+		},
+	})
+	g.AddNode("arn:aws:ec2:us-east-1:123456789012:volume/vol-0loose", "AWS::EC2::Volume", nil)
+	g.CloseAndWait()
+
+	costs := map[string]float64{
+		"arn:aws:ec2:us-east-1:123456789012:volume/vol-0data":    8,
+		"arn:aws:ec2:us-east-1:123456789012:volume/vol-0cache":   4,
+		"arn:aws:ec2:us-east-1:123456789012:natgateway/nat-0old": 32.85,
+		"arn:aws:ec2:eu-west-1:123456789012:volume/vol-0eu":      1,
+		"arn:aws:ec2:us-east-1:123456789012:volume/vol-0dr":      16,
+		"arn:aws:ec2:us-east-1:123456789012:volume/vol-0loose":   2,
+	}
+	for id, cost := range costs {
+		g.GetNode(id).Cost = cost
+		g.MarkWaste(id, 80)
+	}
+	g.GetNode("arn:aws:ec2:us-east-1:123456789012:volume/vol-0data").Properties["Reason"] = "Unattached EBS Volume [IaC-managed: remove from CloudFormation stack billing-api]"
+
+	path := filepath.Join(t.TempDir(), "cfn_drift_report.yaml")
+	gen := NewGenerator(g, nil)
+	if err := gen.GenerateCloudFormationDriftReport(path); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Report not written: %v", err)
+	}
+	report := string(data)
+
+	// Most expensive stack first; stacks with the same name in two regions stay apart.
+	network := strings.Index(report, "  - name: network\n    region: us-east-1\n    monthly_cost: 32.85\n")
+	billing := strings.Index(report, "  - name: billing-api\n    region: us-east-1\n    monthly_cost: 12.00\n    remove:\n")
+	billingEU := strings.Index(report, "  - name: billing-api\n    region: eu-west-1\n    monthly_cost: 1.00\n")
+	if network < 0 || billing < 0 || billingEU < 0 || !(network < billing && billing < billingEU) {
+		t.Fatalf("Expected stacks network, billing-api (us-east-1), billing-api (eu-west-1) in cost order, got:\n%s", report)
+	}
+	assert.Contains(t, report, "      # AWS::EC2::Volume vol-0cache ($4.00/mo)\n      - CacheVolume\n      # AWS::EC2::Volume vol-0data ($8.00/mo): Unattached EBS Volume\n      - DataVolume\n")
+	assert.Contains(t, report, "    unresolved:\n      # aws_nat_gateway nat-0old ($32.85/mo)\n      - \"arn:aws:ec2:us-east-1:123456789012:natgateway/nat-0old\"\n")
+	assert.NotContains(t, report, "DrVolume")
+	assert.NotContains(t, report, "vol-0loose")
+	assert.NotContains(t, report, "[IaC-managed")
+
+	// Without stack-managed waste, nothing is written.
+	empty := graph.NewGraph()
+	empty.CloseAndWait()
+	emptyPath := filepath.Join(t.TempDir(), "cfn_drift_report.yaml")
+	if err := NewGenerator(empty, nil).GenerateCloudFormationDriftReport(emptyPath); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+	if _, err := os.Stat(emptyPath); !os.IsNotExist(err) {
+		t.Errorf("Expected no report without CloudFormation-managed waste, stat err = %v", err)
+	}
+}
+
+func TestVerifyIgnorePlan(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:ec2:us-east-1:123:volume/vol-tagged", "AWS::EC2::Volume", map[string]interface{}{})
+	g.AddNode("arn:aws:ec2:us-east-1:123:volume/vol-untagged", "AWS::EC2::Volume", map[string]interface{}{})
+	g.CloseAndWait()
+	g.MarkWaste("arn:aws:ec2:us-east-1:123:volume/vol-tagged", 80)
+	g.MarkWaste("arn:aws:ec2:us-east-1:123:volume/vol-untagged", 80)
+
+	planPath := filepath.Join(t.TempDir(), "ignore_plan.json")
+	if err := NewGenerator(g, nil).GenerateIgnorePlan(planPath); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+	plan, err := LoadManifest(planPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	// A hand-edited plan keyed by a bare ID is skipped, not sent to the tagging API.
+	plan.Actions = append(plan.Actions, PlanAction{ID: "vol-bare", Type: "AWS::EC2::Volume", Operation: "TAG_IGNORE", Parameters: map[string]interface{}{"ARN": "vol-bare"}})
+
+	targets := IgnoreTargets(plan)
+	assert.Len(t, targets, 3)
+	assert.Equal(t, map[string]string{IgnoreTagKey: "true"}, targets[0].Tags)
+
+	results := VerifyIgnoreTags(targets, map[string]map[string]string{
+		"arn:aws:ec2:us-east-1:123:volume/vol-tagged":   {IgnoreTagKey: "true"},
+		"arn:aws:ec2:us-east-1:123:volume/vol-untagged": {"Name": "scratch"},
+	})
+	for _, r := range results {
+		switch r.ID {
+		case "vol-tagged":
+			assert.True(t, r.Applied)
+		case "vol-untagged":
+			assert.False(t, r.Applied)
+			assert.True(t, r.Found)
+		case "vol-bare":
+			assert.NotEmpty(t, r.Skipped, "bare IDs are not sent to the tagging API")
+			assert.False(t, r.Applied)
+		}
+	}
+}
+
+func TestPreviewTags(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:ec2:us-east-1:123:instance/i-owned", "AWS::EC2::Instance", map[string]interface{}{})
+	g.AddNode("arn:aws:ec2:us-east-1:123:instance/i-full", "AWS::EC2::Instance", map[string]interface{}{})
+	g.AddNode("arn:aws:ec2:us-east-1:123:instance/i-blocked", "AWS::EC2::Instance", map[string]interface{}{"RemediationBlocked": "SCP"})
+	g.AddNode("vol-bare", "AWS::EC2::Volume", map[string]interface{}{})
+	g.CloseAndWait()
+	g.MarkWaste("arn:aws:ec2:us-east-1:123:instance/i-owned", 80)
+	g.MarkWaste("arn:aws:ec2:us-east-1:123:instance/i-full", 80)
+	g.MarkWaste("arn:aws:ec2:us-east-1:123:instance/i-blocked", 80)
+	g.MarkWaste("vol-bare", 80)
+
+	t.Chdir(t.TempDir()) // Tombstones are written relative to the working directory.
+	planPath := filepath.Join(t.TempDir(), "remediation_plan.json")
+	if err := NewGenerator(g, nil).GenerateRemediationPlan(planPath); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+	plan, err := LoadManifest(planPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	targets := TagPreviewTargets(plan)
+	assert.Len(t, targets, 3, "blocked actions are never tagged")
+
+	full := map[string]string{}
+	for i := 0; i < 49; i++ {
+		full[fmt.Sprintf("k%d", i)] = "v"
+	}
+	previews := PreviewTags(targets, map[string]map[string]string{
+		"arn:aws:ec2:us-east-1:123:instance/i-owned": {"CloudSlash:Status": "Active", "Name": "web"},
+		"arn:aws:ec2:us-east-1:123:instance/i-full":  full,
+	})
+
+	for _, p := range previews {
+		switch p.ID {
+		case "i-owned":
+			assert.Equal(t, "web", p.After["Name"])
+			assert.Equal(t, "Purgatory", p.After["CloudSlash:Status"])
+			assert.Equal(t, "Active", p.Before["CloudSlash:Status"])
+			kinds := map[string]string{}
+			for _, c := range p.Changes {
+				kinds[c.Key] = c.Kind
+			}
+			assert.Equal(t, "overwrite", kinds["CloudSlash:Status"])
+			assert.Equal(t, "add", kinds["CloudSlash:ExpiryDate"])
+			assert.Len(t, p.Warnings, 1)
+		case "i-full":
+			assert.Len(t, p.After, 51)
+			assert.Len(t, p.Warnings, 1)
+			assert.Contains(t, p.Warnings[0], "50-tag limit")
+		case "vol-bare":
+			assert.NotEmpty(t, p.Skipped, "bare IDs are not sent to the tagging API")
+			assert.False(t, p.Found)
+			assert.Equal(t, "Purgatory", p.After["CloudSlash:Status"])
+			for _, c := range p.Changes {
+				assert.Equal(t, "unknown", c.Kind)
+			}
+		}
+	}
+}
+
+func TestValidatePlan(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:ec2:us-east-1:123:instance/i-idle", "AWS::EC2::Instance", map[string]interface{}{})
+	g.CloseAndWait()
+	g.MarkWaste("arn:aws:ec2:us-east-1:123:instance/i-idle", 80)
+
+	t.Chdir(t.TempDir()) // Tombstones are written relative to the working directory.
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, "remediation_plan.json")
+	gen := NewGenerator(g, nil)
+	gen.ScanID = "cs-scan-1"
+	if err := gen.GenerateRemediationPlan(planPath); err != nil {
+		t.Fatalf("Generation failed: %v", err)
+	}
+
+	plan, err := ValidatePlan(planPath)
+	if err != nil {
+		t.Fatalf("Generated plan should validate: %v", err)
+	}
+	assert.Equal(t, PlanSchemaVersion, plan.SchemaVersion)
+	assert.Equal(t, version.Current, plan.CloudSlashVersion)
+	assert.Equal(t, "cs-scan-1", plan.ScanID)
+
+	script, err := os.ReadFile(filepath.Join(dir, "remediation_plan.sh"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(script), "# Scan: cs-scan-1")
+
+	write := func(m TransactionManifest) string {
+		p := filepath.Join(dir, "plan.json")
+		assert.NoError(t, writeJSON(p, m))
+		return p
+	}
+	valid := func() TransactionManifest {
+		return TransactionManifest{
+			SchemaVersion: PlanSchemaVersion,
+			GeneratedAt:   time.Now(),
+			Actions: []PlanAction{{
+				ID: "i-1", Type: "AWS::EC2::Instance", Operation: "STOP",
+				Rollback: &PlanAction{ID: "i-1", Type: "AWS::EC2::Instance", Operation: "START"},
+			}},
+		}
+	}
+
+	cases := map[string]func(*TransactionManifest){
+		"no schema_version":                func(m *TransactionManifest) { m.SchemaVersion = 0 },
+		"newer than":                       func(m *TransactionManifest) { m.SchemaVersion = PlanSchemaVersion + 1 },
+		"missing generated_at":             func(m *TransactionManifest) { m.GeneratedAt = time.Time{} },
+		"missing id":                       func(m *TransactionManifest) { m.Actions[0].ID = "" },
+		"unknown operation":                func(m *TransactionManifest) { m.Actions[0].Operation = "SHRED" },
+		"rollback: i-1: missing operation": func(m *TransactionManifest) { m.Actions[0].Rollback.Operation = "" },
+	}
+	for want, mutate := range cases {
+		m := valid()
+		mutate(&m)
+		_, err := ValidatePlan(write(m))
+		if assert.Error(t, err, want) {
+			assert.Contains(t, err.Error(), want)
+		}
+	}
+}
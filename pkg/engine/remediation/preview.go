@@ -0,0 +1,157 @@
+package remediation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MaxTagsPerResource is the AWS limit on user tags per resource.
+const MaxTagsPerResource = 50
+
+// TagChange is one planned tag write.
+type TagChange struct {
+	Key    string `json:"key"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after"`
+	Kind   string `json:"kind"` // "add", "overwrite", "unchanged" or "unknown"
+}
+
+// TagPreview is the before/after tag set of one planned action.
+type TagPreview struct {
+	ID        string            `json:"id"`
+	Type      string            `json:"type"`
+	ARN       string            `json:"arn"`
+	Operation string            `json:"operation"`
+	Found     bool              `json:"found"` // The tagging API returned the resource.
+	Before    map[string]string `json:"before"`
+	After     map[string]string `json:"after"`
+	Changes   []TagChange       `json:"changes"`
+	Warnings  []string          `json:"warnings,omitempty"`
+	Skipped   string            `json:"skipped,omitempty"` // Why the current tags could not be read.
+
+	planned map[string]string
+}
+
+// untaggedOperations are planned but never executed, so their tags are never written.
+var untaggedOperations = map[string]bool{
+	"BLOCKED":        true,
+	"IAC_REVIEW":     true,
+	"MANUAL_REVIEW":  true,
+	"REPLACE_NAT":    true,
+	"RESIZE_CLUSTER": true,
+}
+
+// IsARN reports whether id is an ARN the tagging API can look up. Some
+// scanners key nodes by bare resource IDs (vol-123, i-123), which it rejects.
+func IsARN(id string) bool {
+	return strings.HasPrefix(id, "arn:")
+}
+
+// TagPreviewTargets lists the actions of a plan that will write tags and carry
+// an ARN. Actions keyed by a bare resource ID are included but marked Skipped,
+// so the caller reports them instead of sending them to the tagging API.
+func TagPreviewTargets(plan *TransactionManifest) []TagPreview {
+	var targets []TagPreview
+	for _, a := range plan.Actions {
+		if untaggedOperations[a.Operation] {
+			continue
+		}
+		planned := plannedTags(a)
+		arn, _ := a.Parameters["ARN"].(string)
+		if len(planned) == 0 || arn == "" {
+			continue
+		}
+		t := TagPreview{ID: a.ID, Type: a.Type, ARN: arn, Operation: a.Operation, planned: planned}
+		if !IsARN(arn) {
+			t.Skipped = "not an ARN; current tags cannot be read, so overwrites are not checked"
+		}
+		targets = append(targets, t)
+	}
+	return targets
+}
+
+// plannedTags reads the Tags parameter of an action, whether built in memory
+// (map[string]string) or loaded from JSON (map[string]interface{}).
+func plannedTags(a PlanAction) map[string]string {
+	switch tags := a.Parameters["Tags"].(type) {
+	case map[string]string:
+		return tags
+	case map[string]interface{}:
+		out := make(map[string]string, len(tags))
+		for k, v := range tags {
+			out[k] = fmt.Sprint(v)
+		}
+		return out
+	}
+	return nil
+}
+
+// PreviewTags merges each target's planned tags over its current tags, as read
+// from AWS and keyed by ARN, and flags overwrites and tag-limit overflows.
+// Skipped targets list their planned tags with kind "unknown".
+func PreviewTags(targets []TagPreview, current map[string]map[string]string) []TagPreview {
+	results := make([]TagPreview, len(targets))
+	for i, t := range targets {
+		if t.Skipped != "" {
+			results[i] = previewUnknown(t)
+			continue
+		}
+		before, found := current[t.ARN]
+		t.Found = found
+		t.Before = make(map[string]string, len(before))
+		t.After = make(map[string]string, len(before)+len(t.planned))
+		for k, v := range before {
+			t.Before[k] = v
+			t.After[k] = v
+		}
+
+		keys := make([]string, 0, len(t.planned))
+		for k := range t.planned {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			want := t.planned[k]
+			old, exists := before[k]
+			change := TagChange{Key: k, Before: old, After: want}
+			switch {
+			case !exists:
+				change.Kind = "add"
+			case old == want:
+				change.Kind = "unchanged"
+			default:
+				change.Kind = "overwrite"
+				t.Warnings = append(t.Warnings, fmt.Sprintf("overwrites existing %s=%s", k, old))
+			}
+			t.After[k] = want
+			t.Changes = append(t.Changes, change)
+		}
+
+		if !found {
+			t.Warnings = append(t.Warnings, "not returned by tagging API (untagged, deleted, or unsupported); assuming no current tags")
+		}
+		if len(t.After) > MaxTagsPerResource {
+			t.Warnings = append(t.Warnings, fmt.Sprintf("exceeds the %d-tag limit (%d tags after apply); the tag call will fail", MaxTagsPerResource, len(t.After)))
+		}
+		results[i] = t
+	}
+	return results
+}
+
+// previewUnknown lists the planned tags of a target whose current tags were not read.
+func previewUnknown(t TagPreview) TagPreview {
+	t.After = make(map[string]string, len(t.planned))
+	keys := make([]string, 0, len(t.planned))
+	for k, v := range t.planned {
+		t.After[k] = v
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		t.Changes = append(t.Changes, TagChange{Key: k, After: t.planned[k], Kind: "unknown"})
+	}
+	t.Warnings = append(t.Warnings, t.Skipped)
+	return t
+}
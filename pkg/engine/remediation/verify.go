@@ -0,0 +1,165 @@
+package remediation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// IgnoreTagKey suppresses a resource in future scans.
+const IgnoreTagKey = "cloudslash:ignore"
+
+// IgnoreCheck is the read-back result for one planned ignore tag.
+type IgnoreCheck struct {
+	ID      string
+	Type    string
+	ARN     string
+	Applied bool
+	Value   string // Current cloudslash:ignore value, if any.
+	Found   bool   // The tagging API returned the resource.
+	Skipped string // Why the resource cannot be tagged through the tagging API.
+	// Tags the plan applies: cloudslash:ignore, plus any others the action sets.
+	Tags map[string]string
+}
+
+// PlanSchemaVersion is the plan format this binary writes and executes.
+// Bump it whenever a plan field is added, removed or changes meaning, so an
+// executor never acts on a plan it would misread.
+const PlanSchemaVersion = 1
+
+// planOperations are the operations an executor knows how to carry out.
+var planOperations = map[string]bool{
+	"STOP":                true,
+	"START":               true,
+	"SNAPSHOT_AND_DELETE": true,
+	"MODIFY":              true,
+	"PUT_LIFECYCLE":       true,
+	"DELETE":              true,
+	"DELETE_REPLICA":      true,
+	"RELEASE":             true,
+	"DEREGISTER":          true,
+	"REPLACE_NAT":         true,
+	"PAUSE":               true,
+	"RESUME":              true,
+	"RESIZE_CLUSTER":      true,
+	"MANUAL_REVIEW":       true,
+	"IAC_REVIEW":          true,
+	"BLOCKED":             true,
+	"TAG_IGNORE":          true,
+	"RESTORE_INSTANCE":    true,
+	"RESTORE_VOLUME":      true,
+	"RESTORE_RDS":         true,
+}
+
+// ValidatePlan reads a plan and checks that this binary can execute it: the
+// schema version must match PlanSchemaVersion and every action must name a
+// resource, its type and a known operation. Run it before acting on a plan.
+func ValidatePlan(path string) (*TransactionManifest, error) {
+	plan, err := LoadManifest(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateManifest(plan); err != nil {
+		return nil, fmt.Errorf("invalid plan %s: %v", path, err)
+	}
+	return plan, nil
+}
+
+func validateManifest(plan *TransactionManifest) error {
+	switch {
+	case plan.SchemaVersion == 0:
+		return fmt.Errorf("no schema_version; it predates plan versioning, regenerate it with 'cloudslash scan'")
+	case plan.SchemaVersion > PlanSchemaVersion:
+		return fmt.Errorf("schema v%d (written by CloudSlash %s) is newer than this binary supports (v%d); upgrade CloudSlash", plan.SchemaVersion, plan.CloudSlashVersion, PlanSchemaVersion)
+	case plan.SchemaVersion < PlanSchemaVersion:
+		return fmt.Errorf("schema v%d (written by CloudSlash %s) is no longer supported (v%d); regenerate it with 'cloudslash scan'", plan.SchemaVersion, plan.CloudSlashVersion, PlanSchemaVersion)
+	}
+	if plan.GeneratedAt.IsZero() {
+		return fmt.Errorf("missing generated_at")
+	}
+	for i, a := range plan.Actions {
+		if err := validateAction(a); err != nil {
+			return fmt.Errorf("action %d: %v", i, err)
+		}
+		if a.Rollback != nil {
+			if err := validateAction(*a.Rollback); err != nil {
+				return fmt.Errorf("action %d rollback: %v", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateAction(a PlanAction) error {
+	switch {
+	case a.ID == "":
+		return fmt.Errorf("missing id")
+	case a.Type == "":
+		return fmt.Errorf("%s: missing type", a.ID)
+	case a.Operation == "":
+		return fmt.Errorf("%s: missing operation", a.ID)
+	case !planOperations[a.Operation]:
+		return fmt.Errorf("%s: unknown operation %q", a.ID, a.Operation)
+	}
+	return nil
+}
+
+// LoadManifest reads a plan written by GenerateIgnorePlan or GenerateRemediationPlan.
+func LoadManifest(path string) (*TransactionManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan: %v", err)
+	}
+	var plan TransactionManifest
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %v", err)
+	}
+	return &plan, nil
+}
+
+// IgnoreTargets lists the TAG_IGNORE actions of a plan that carry an ARN.
+// Actions keyed by a bare resource ID are marked Skipped.
+func IgnoreTargets(plan *TransactionManifest) []IgnoreCheck {
+	var targets []IgnoreCheck
+	for _, a := range plan.Actions {
+		if a.Operation != "TAG_IGNORE" {
+			continue
+		}
+		arn, _ := a.Parameters["ARN"].(string)
+		if arn == "" {
+			continue
+		}
+		tags := plannedTags(a)
+		if tags[IgnoreTagKey] == "" {
+			tags = map[string]string{IgnoreTagKey: "true"}
+			for k, v := range plannedTags(a) {
+				tags[k] = v
+			}
+		}
+		t := IgnoreCheck{ID: a.ID, Type: a.Type, ARN: arn, Tags: tags}
+		if !IsARN(arn) {
+			t.Skipped = "not an ARN; tag it with the service's own API"
+		}
+		targets = append(targets, t)
+	}
+	return targets
+}
+
+// VerifyIgnoreTags checks each target's tags as read back from AWS.
+// Targets are matched by ARN; a missing ARN means the tag did not stick
+// or the resource is gone. Skipped targets are passed through unchecked.
+func VerifyIgnoreTags(targets []IgnoreCheck, tags map[string]map[string]string) []IgnoreCheck {
+	results := make([]IgnoreCheck, len(targets))
+	for i, t := range targets {
+		if t.Skipped != "" {
+			results[i] = t
+			continue
+		}
+		current, found := tags[t.ARN]
+		t.Found = found
+		t.Value = current[IgnoreTagKey]
+		t.Applied = t.Value != ""
+		results[i] = t
+	}
+	return results
+}
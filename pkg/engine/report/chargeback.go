@@ -0,0 +1,85 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// UntaggedCostCenter collects waste that carries no cost-center tag.
+const UntaggedCostCenter = "Untagged"
+
+// ChargebackRow is the waste attributed to one cost center.
+type ChargebackRow struct {
+	CostCenter    string
+	MonthlyWaste  float64
+	ResourceCount int
+}
+
+// Chargeback groups waste by the value of tagKey.
+// Justified findings are excluded. Rows are sorted by monthly waste, largest first.
+func Chargeback(g *graph.Graph, tagKey string) []ChargebackRow {
+	g.Mu.RLock()
+	defer g.Mu.RUnlock()
+
+	byCenter := make(map[string]*ChargebackRow)
+	for _, node := range g.Store.GetAllNodes() {
+		if !node.IsWaste || node.Justified {
+			continue
+		}
+		center := UntaggedCostCenter
+		if tags, ok := node.Properties["Tags"].(map[string]string); ok && tags[tagKey] != "" {
+			center = tags[tagKey]
+		}
+
+		row, ok := byCenter[center]
+		if !ok {
+			row = &ChargebackRow{CostCenter: center}
+			byCenter[center] = row
+		}
+		row.MonthlyWaste += node.Cost
+		row.ResourceCount++
+	}
+
+	rows := make([]ChargebackRow, 0, len(byCenter))
+	for _, row := range byCenter {
+		rows = append(rows, *row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].MonthlyWaste != rows[j].MonthlyWaste {
+			return rows[i].MonthlyWaste > rows[j].MonthlyWaste
+		}
+		return rows[i].CostCenter < rows[j].CostCenter
+	})
+	return rows
+}
+
+// GenerateChargebackCSV writes waste per cost center for FinOps chargeback.
+func GenerateChargebackCSV(rows []ChargebackRow, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.Write([]string{"cost_center", "monthly_waste", "annual_projection", "resource_count"}); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		record := []string{
+			row.CostCenter,
+			fmt.Sprintf("%.2f", row.MonthlyWaste),
+			fmt.Sprintf("%.2f", row.MonthlyWaste*12),
+			fmt.Sprintf("%d", row.ResourceCount),
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
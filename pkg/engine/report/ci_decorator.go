@@ -134,6 +134,10 @@ func (d *CIDecorator) generateMarkdown(s Summary, g *graph.Graph) string {
 	sb.WriteString(fmt.Sprintf("| **Scanned Nodes** | `%d` |\n", s.TotalScanned))
 	sb.WriteString("\n")
 
+	if s.Diff != nil {
+		sb.WriteString(diffMarkdown(*s.Diff))
+	}
+
 	if s.TotalWaste > 0 {
 		sb.WriteString("#### ⚠️ High-Impact Findings\n\n")
 		sb.WriteString("| Resource | Type | Cost | Reason |\n")
@@ -0,0 +1,47 @@
+package report
+
+import (
+	"sort"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// ComplianceItem is one compliance violation. Violations are listed apart
+// from waste findings and never count toward cost totals.
+type ComplianceItem struct {
+	ResourceID string `json:"resource_id"`
+	Type       string `json:"type"`
+	Region     string `json:"region"`
+	Reason     string `json:"reason"`
+}
+
+// ComplianceViolations lists the graph's compliance violations, by type
+// then resource ID.
+func ComplianceViolations(g *graph.Graph) []ComplianceItem {
+	g.Mu.RLock()
+	defer g.Mu.RUnlock()
+
+	var items []ComplianceItem
+	for _, node := range g.Store.GetAllNodes() {
+		if !node.ComplianceViolation {
+			continue
+		}
+		region, _ := node.Properties["Region"].(string)
+		if region == "" {
+			region = "global"
+		}
+		items = append(items, ComplianceItem{
+			ResourceID: node.IDStr(),
+			Type:       node.TypeStr(),
+			Region:     region,
+			Reason:     node.ComplianceReason,
+		})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Type != items[j].Type {
+			return items[i].Type < items[j].Type
+		}
+		return items[i].ResourceID < items[j].ResourceID
+	})
+	return items
+}
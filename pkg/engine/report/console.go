@@ -0,0 +1,21 @@
+package report
+
+import "fmt"
+
+// ConsoleURL links a resource to its AWS console page.
+// Unknown types fall back to the console home page.
+func ConsoleURL(resourceType, id, region string) string {
+	if region == "" || region == "global" {
+		region = "us-east-1"
+	}
+
+	switch resourceType {
+	case "AWS::EC2::Instance":
+		return fmt.Sprintf("https://%s.console.aws.amazon.com/ec2/home?region=%s#InstanceDetails:instanceId=%s", region, region, id)
+	case "AWS::CloudFront::Distribution":
+		return fmt.Sprintf("https://us-east-1.console.aws.amazon.com/cloudfront/v4/home#/distributions/%s", extractID(id))
+	case "AWS::S3::Bucket":
+		return fmt.Sprintf("https://s3.console.aws.amazon.com/s3/buckets/%s?region=%s", id, region)
+	}
+	return "https://console.aws.amazon.com"
+}
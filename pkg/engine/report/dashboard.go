@@ -3,7 +3,7 @@ package report
 import (
 	"encoding/json"
 	"fmt"
-	"math"
+	"html"
 	"os"
 	"strings"
 	"time"
@@ -12,8 +12,9 @@ import (
 	"github.com/DrSkyle/cloudslash/v2/pkg/version"
 )
 
-// GenerateDashboard generates an interactive HTML dashboard.
-func GenerateDashboard(g *graph.Graph, path string) error {
+// GenerateDashboard generates an interactive HTML dashboard, grading risk by cfg.
+func GenerateDashboard(g *graph.Graph, path string, cfg ReportConfig) error {
+	cfg = cfg.withDefaults()
 	items := extractItems(g)
 
 	// Compute statistics.
@@ -21,13 +22,13 @@ func GenerateDashboard(g *graph.Graph, path string) error {
 	riskCount := 0
 	for _, item := range items {
 		totalCost += item.MonthlyCost
-		if item.RiskScore > 50 {
+		if item.RiskScore > cfg.ReviewThreshold {
 			riskCount++
 		}
 	}
 
 	// Prepare chart data.
-	graphData, err := buildSankeyData(g)
+	graphData, err := json.Marshal(BuildSankeyData(g))
 	if err != nil {
 		fmt.Printf("[WARN] Failed to build Sankey data: %v\n", err)
 		// Handle empty graph.
@@ -173,6 +174,9 @@ func GenerateDashboard(g *graph.Graph, path string) error {
             outline: none;
         }
         .search-box:focus { border-color: var(--primary); }
+        .filter-select { width: auto; cursor: pointer; }
+        .filter-select option { background: var(--bg); }
+        .filter-count { margin-left: auto; color: var(--text-dim); font-size: 0.8rem; }
 
         .table-scroll {
             width: 100%;
@@ -191,6 +195,7 @@ func GenerateDashboard(g *graph.Graph, path string) error {
             user-select: none;
         }
         th:hover { color: var(--text); }
+        th.sorted { color: var(--primary); }
         tr:last-child td { border-bottom: none; }
         tr:hover { background: rgba(255,255,255,0.02); }
 
@@ -198,6 +203,15 @@ func GenerateDashboard(g *graph.Graph, path string) error {
         .badge.JUNK { background: rgba(255, 51, 102, 0.15); color: var(--danger); }
         .badge.REVIEW { background: rgba(135, 75, 253, 0.15); color: var(--secondary); }
         .badge.JUSTIFIED { background: rgba(0, 255, 153, 0.15); color: var(--primary); }
+        .badge.COMPLIANCE { background: rgba(255, 184, 0, 0.15); color: #FFB800; }
+
+        /* Resources flagged by several heuristics list each finding. */
+        .findings { margin: 0; padding-left: 1rem; }
+        .finding-source { opacity: 0.6; font-size: 0.75rem; }
+
+        /* Compliance violations are listed apart from waste. */
+        .compliance-wrapper { margin-top: 40px; }
+        .compliance-wrapper .toolbar { font-weight: 600; }
         
         /* 7. Footer styles. */
         footer { margin-top: 60px; color: var(--text-dim); font-size: 0.8rem; text-align: center; border-top: 1px solid var(--border); padding-top: 20px; }
@@ -257,17 +271,31 @@ func GenerateDashboard(g *graph.Graph, path string) error {
     <!-- 4. Data Grid section. -->
     <div class="table-wrapper">
         <div class="toolbar">
-            <input type="text" id="searchInput" class="search-box" placeholder="Filter resources..." onkeyup="filterTable()">
+            <input type="text" id="searchInput" class="search-box" placeholder="Filter resources..." oninput="filterTable()">
+            <select id="actionFilter" class="search-box filter-select" onchange="filterTable()">
+                <option value="">All actions</option>
+                <option value="JUNK">JUNK</option>
+                <option value="REVIEW">REVIEW</option>
+                <option value="JUSTIFIED">JUSTIFIED</option>
+            </select>
+            <select id="regionFilter" class="search-box filter-select" onchange="filterTable()">
+                <option value="">All regions</option>
+            </select>
+            <select id="typeFilter" class="search-box filter-select" onchange="filterTable()">
+                <option value="">All types</option>
+            </select>
+            <span id="filterCount" class="filter-count"></span>
         </div>
         <div class="table-scroll">
             <table id="resourceTable">
                 <thead>
                     <tr>
-                        <th onclick="sortTable(0)">Type &#8597;</th>
-                        <th onclick="sortTable(1)">Resource ID &#8597;</th>
-                        <th onclick="sortTable(2)">Region &#8597;</th>
-                        <th onclick="sortTable(3)">Monthly Cost &#8597;</th>
-                        <th onclick="sortTable(4)">Action &#8597;</th>
+                        <th onclick="sortTable(0)">Type <span class="sort-arrow">&#8597;</span></th>
+                        <th onclick="sortTable(1)">Resource ID <span class="sort-arrow">&#8597;</span></th>
+                        <th onclick="sortTable(2)">Region <span class="sort-arrow">&#8597;</span></th>
+                        <th onclick="sortTable(3)">Environment <span class="sort-arrow">&#8597;</span></th>
+                        <th onclick="sortTable(4)">Monthly Cost <span class="sort-arrow">&#8597;</span></th>
+                        <th onclick="sortTable(5)">Action <span class="sort-arrow">&#8597;</span></th>
                         <th>Evidence</th>
                     </tr>
                 </thead>
@@ -278,6 +306,7 @@ func GenerateDashboard(g *graph.Graph, path string) error {
         </div>
     </div>
 
+{{COMPLIANCE_SECTION}}
     <footer>
         Generated by CloudSlash ` + version.Current + ` (AGPLv3) | Local-First Cloud Auditor
     </footer>
@@ -286,6 +315,7 @@ func GenerateDashboard(g *graph.Graph, path string) error {
         // --- DATA ---
         window.REPORT_DATA = {{REPORT_DATA}};
         window.GRAPH_DATA = {{GRAPH_DATA}};
+        const REVIEW_THRESHOLD = {{REVIEW_THRESHOLD}};
         const currency = new Intl.NumberFormat('en-US', { style: 'currency', currency: 'USD' });
 
         // --- 1. TABLE INITIALIZATION ---
@@ -295,43 +325,157 @@ func GenerateDashboard(g *graph.Graph, path string) error {
             tbody.innerHTML = '';
             data.forEach(item => {
                 const tr = document.createElement('tr');
-                const badgeClass = item.risk_score > 50 ? 'JUNK' : (item.action === 'JUSTIFIED' ? 'JUSTIFIED' : 'REVIEW');
+                const badgeClass = actionOf(item);
                 const costStyle = item.monthly_cost > 0 ? 'color: #FF3366; font-weight: bold;' : 'color: #94A3B8;';
 
                 tr.innerHTML = ` + "`" + `
                     <td><span style="opacity:0.8; font-weight: 500;">` + "`" + ` + item.type.replace('AWS::', '') + ` + "`" + `</span></td>
                     <td style="font-weight:600; color: #fff;">` + "`" + ` + item.resource_id + ` + "`" + `</td>
                     <td>` + "`" + ` + item.region + ` + "`" + `</td>
+                    <td>` + "`" + ` + (item.environment || '-') + ` + "`" + `</td>
                     <td style="` + "`" + ` + costStyle + ` + "`" + `">` + "`" + ` + currency.format(item.monthly_cost) + ` + "`" + `</td>
                     <td><span class="badge ` + "`" + ` + badgeClass + ` + "`" + `">` + "`" + ` + item.action + ` + "`" + `</span></td>
-                    <td style="color: #94A3B8;">` + "`" + ` + item.audit_detail + ` + "`" + `</td>
+                    <td style="color: #94A3B8;">` + "`" + ` + detailOf(item) + ` + "`" + `</td>
                 ` + "`" + `;
                 tbody.appendChild(tr);
             });
         }
-        renderTable(window.REPORT_DATA);
+        // --- 2. FILTERS ---
+        // Search and dropdowns combine (AND); state lives in location.hash so a filtered view can be shared.
+        const filterKeys = { q: 'searchInput', action: 'actionFilter', region: 'regionFilter', type: 'typeFilter' };
 
-        // --- 2. SEARCH ---
-        function filterTable() {
-            const input = document.getElementById('searchInput');
-            const filter = input.value.toUpperCase();
-            const filtered = window.REPORT_DATA.filter(item => {
-                return Object.values(item).some(val => 
-                    String(val).toUpperCase().includes(filter)
-                );
+        function actionOf(item) {
+            return item.risk_score > REVIEW_THRESHOLD ? 'JUNK' : (item.action === 'JUSTIFIED' ? 'JUSTIFIED' : 'REVIEW');
+        }
+
+        function detailOf(item) {
+            if (!item.findings || item.findings.length < 2) return item.audit_detail;
+            return '<ul class="findings">' + item.findings.map(f =>
+                '<li>' + f.reason + (f.heuristic ? ' <span class="finding-source">' + f.heuristic + '</span>' : '') + '</li>'
+            ).join('') + '</ul>';
+        }
+
+        function populateSelect(id, values) {
+            const select = document.getElementById(id);
+            [...new Set(values)].filter(v => v).sort().forEach(v => {
+                const opt = document.createElement('option');
+                opt.value = v;
+                opt.textContent = v.replace('AWS::', '');
+                select.appendChild(opt);
             });
+        }
+
+        function readFilters() {
+            const state = {};
+            Object.entries(filterKeys).forEach(([key, id]) => {
+                state[key] = document.getElementById(id).value;
+            });
+            return state;
+        }
+
+        function loadFiltersFromHash() {
+            const params = new URLSearchParams(location.hash.slice(1));
+            Object.entries(filterKeys).forEach(([key, id]) => {
+                const el = document.getElementById(id);
+                const value = params.get(key) || '';
+                // Ignore values no longer present in this report (e.g. a region from another scan).
+                if (el.tagName === 'SELECT' && ![...el.options].some(o => o.value === value)) {
+                    el.value = '';
+                } else {
+                    el.value = value;
+                }
+            });
+        }
+
+        function syncHash(state) {
+            const params = new URLSearchParams();
+            Object.entries(state).forEach(([key, value]) => {
+                if (value) params.set(key, value);
+            });
+            const hash = params.toString();
+            const url = location.pathname + location.search + (hash ? '#' + hash : '');
+            // replaceState avoids a history entry per keystroke and does not fire hashchange.
+            history.replaceState(null, '', url);
+        }
+
+        function matchesFilters(item, state) {
+            if (state.action && actionOf(item) !== state.action) return false;
+            if (state.region && item.region !== state.region) return false;
+            if (state.type && item.type !== state.type) return false;
+            if (state.q) {
+                const needle = state.q.toUpperCase();
+                return Object.values(item).some(val => String(val).toUpperCase().includes(needle));
+            }
+            return true;
+        }
+
+        function applyFilters(state) {
+            const filtered = sortRows(window.REPORT_DATA.filter(item => matchesFilters(item, state)));
             renderTable(filtered);
+            document.getElementById('filterCount').textContent =
+                filtered.length + ' of ' + window.REPORT_DATA.length + ' resources';
+        }
+
+        function filterTable() {
+            const state = readFilters();
+            syncHash(state);
+            applyFilters(state);
         }
 
         // --- 3. SORT ---
+        // Column index -> sort key, in header order. Monthly Cost compares as a number.
+        const sortKeys = [
+            item => item.type,
+            item => item.resource_id,
+            item => item.region,
+            item => item.environment || '',
+            item => Number(item.monthly_cost) || 0,
+            item => actionOf(item),
+        ];
+        const sortState = { col: -1, dir: 1 };
+
+        function sortRows(rows) {
+            if (sortState.col < 0) return rows;
+            const key = sortKeys[sortState.col];
+            // Array.prototype.sort is stable, so ties keep the report order.
+            return rows.slice().sort((a, b) => {
+                const x = key(a), y = key(b);
+                const cmp = typeof x === 'number'
+                    ? x - y
+                    : String(x).localeCompare(String(y), undefined, { numeric: true, sensitivity: 'base' });
+                return cmp * sortState.dir;
+            });
+        }
+
         function sortTable(n) {
-            // Sort implementation.
-            const table = document.getElementById("resourceTable");
-            
-            
-            console.log("Sort clicked on col " + n);
+            if (!sortKeys[n]) return;
+            if (sortState.col === n) {
+                sortState.dir = -sortState.dir;
+            } else {
+                sortState.col = n;
+                // Costs are most useful largest first; text columns start A-Z.
+                sortState.dir = n === 4 ? -1 : 1;
+            }
+            document.querySelectorAll('#resourceTable th').forEach((th, i) => {
+                const arrow = th.querySelector('.sort-arrow');
+                if (!arrow) return;
+                const active = i === sortState.col;
+                th.classList.toggle('sorted', active);
+                arrow.innerHTML = active ? (sortState.dir > 0 ? '&#9650;' : '&#9660;') : '&#8597;';
+            });
+            // Re-apply the current filters so sorting never clears a search.
+            applyFilters(readFilters());
         }
 
+        populateSelect('regionFilter', window.REPORT_DATA.map(item => item.region));
+        populateSelect('typeFilter', window.REPORT_DATA.map(item => item.type));
+        loadFiltersFromHash();
+        applyFilters(readFilters());
+        window.addEventListener('hashchange', () => {
+            loadFiltersFromHash();
+            applyFilters(readFilters());
+        });
+
         // --- 4. CHARTS ---
         
         // 4.1 Gradient Helper
@@ -700,114 +844,48 @@ func GenerateDashboard(g *graph.Graph, path string) error {
 	html = strings.ReplaceAll(html, "{{GENERATED_TIME}}", time.Now().Format("2006-01-02 15:04:05"))
 	html = strings.ReplaceAll(html, "{{TOTAL_COST}}", fmt.Sprintf("%.2f", totalCost))
 	html = strings.ReplaceAll(html, "{{RISK_COUNT}}", fmt.Sprintf("%d", riskCount))
+	html = strings.ReplaceAll(html, "{{REVIEW_THRESHOLD}}", fmt.Sprintf("%d", cfg.ReviewThreshold))
 	html = strings.ReplaceAll(html, "{{REPORT_DATA}}", string(jsonData))
 	html = strings.ReplaceAll(html, "{{GRAPH_DATA}}", string(graphData))
+	html = strings.ReplaceAll(html, "{{COMPLIANCE_SECTION}}", complianceSection(ComplianceViolations(g)))
 
 	return os.WriteFile(path, []byte(html), 0644)
 }
 
-// Sankey visualization structures.
-type SankeyNode struct {
-	Name  string `json:"name"`
-	Waste bool   `json:"waste"`
-}
-type SankeyLink struct {
-	Source int     `json:"source"`
-	Target int     `json:"target"`
-	Value  float64 `json:"value"`
-}
-type SankeyData struct {
-	Nodes []SankeyNode `json:"nodes"`
-	Links []SankeyLink `json:"links"`
-}
-
-func buildSankeyData(g *graph.Graph) ([]byte, error) {
-	g.Mu.RLock()
-	defer g.Mu.RUnlock()
-
-	nodes := make([]SankeyNode, 0)
-	links := make([]SankeyLink, 0)
-	idToIndex := make(map[string]int)
-
-	// 1. Add Internet root node.
-	nodes = append(nodes, SankeyNode{Name: "Internet [0.0.0.0/0]", Waste: false})
-	idToIndex["INTERNET"] = 0
-
-	// 2. Add graph nodes.
-	currentIndex := 1
-	for _, n := range g.Store.GetAllNodes() {
-		idToIndex[n.IDStr()] = currentIndex
-		name := extractID(n.IDStr())
-		nodes = append(nodes, SankeyNode{Name: name, Waste: n.IsWaste})
-		currentIndex++
+// complianceSection renders compliance violations as their own table, or
+// nothing when there are none.
+func complianceSection(items []ComplianceItem) string {
+	if len(items) == 0 {
+		return ""
 	}
-
-	// Create links.
-	allNodes := g.Store.GetAllNodes()
-	for _, sourceNode := range allNodes {
-		edges := g.Store.GetEdges(sourceNode.Index)
-
-		srcIdx, ok1 := idToIndex[sourceNode.IDStr()]
-		if !ok1 {
-			continue
-		}
-
-		for _, e := range edges {
-			targetNode := g.Store.GetNode(e.TargetID)
-			if targetNode == nil {
-				continue
-			}
-			tgtIdx, ok2 := idToIndex[targetNode.IDStr()]
-			if !ok2 {
-				continue
-			}
-
-			// Calculate link weight based on cost.
-
-			// Weight link by target cost.
-
-			val := 8.0
-			if targetNode != nil && targetNode.Cost > 0 {
-				val += math.Log10(targetNode.Cost+1) * 8
-			}
-
-			links = append(links, SankeyLink{
-				Source: srcIdx,
-				Target: tgtIdx,
-				Value:  val,
-			})
-		}
-	}
-
-	// 4. Link gateways to Internet.
-	// Find IGWs and link INTERNET -> IGW
-	for _, n := range g.Store.GetAllNodes() {
-		if n.TypeStr() == "AWS::EC2::InternetGateway" {
-			links = append(links, SankeyLink{
-				Source: 0, // Internet
-				Target: idToIndex[n.IDStr()],
-				Value:  10.0, // Fat pipe
-			})
-		}
-	}
-
-	data := SankeyData{
-		Nodes: nodes,
-		Links: links,
+	var b strings.Builder
+	b.WriteString(`    <!-- 5. Compliance section. -->
+    <div class="table-wrapper compliance-wrapper">
+        <div class="toolbar">Compliance Violations <span class="filter-count">` + fmt.Sprintf("%d resources · not included in waste totals", len(items)) + `</span></div>
+        <div class="table-scroll">
+            <table id="complianceTable">
+                <thead>
+                    <tr>
+                        <th>Type</th>
+                        <th>Resource ID</th>
+                        <th>Region</th>
+                        <th>Violation</th>
+                    </tr>
+                </thead>
+                <tbody>
+`)
+	for _, item := range items {
+		fmt.Fprintf(&b, "                    <tr><td>%s</td><td>%s</td><td>%s</td><td><span class=\"badge COMPLIANCE\">%s</span></td></tr>\n",
+			html.EscapeString(item.Type), html.EscapeString(extractID(item.ResourceID)), html.EscapeString(item.Region), html.EscapeString(item.Reason))
 	}
-
-	return json.Marshal(data)
+	b.WriteString(`                </tbody>
+            </table>
+        </div>
+    </div>
+`)
+	return b.String()
 }
 
 func extractID(arn string) string {
-	// Simple short ID
-	if len(arn) > 15 {
-		// Try to find /
-		// arn:aws:ec2:region:account:instance/i-1234 -> i-1234
-		lastSlash := strings.LastIndex(arn, "/")
-		if lastSlash != -1 && lastSlash < len(arn)-1 {
-			return arn[lastSlash+1:]
-		}
-	}
-	return arn
+	return graph.ShortID(arn)
 }
@@ -0,0 +1,90 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/history"
+)
+
+// maxDiffRows caps each section of the printed diff.
+const maxDiffRows = 20
+
+// WriteDiff prints the waste delta since the previous scan.
+func WriteDiff(w io.Writer, d history.Delta) {
+	since := time.Unix(d.PreviousTimestamp, 0).Format(time.RFC3339)
+	fmt.Fprintf(w, "\n[ WASTE SINCE LAST SCAN (%s) ]\n", since)
+	if d.Empty() {
+		fmt.Fprintln(w, " No change.")
+		fmt.Fprintln(w, "-----------------------------------------------------------------")
+		return
+	}
+
+	writeEntries := func(title, sign string, entries []history.WasteEntry) {
+		if len(entries) == 0 {
+			return
+		}
+		fmt.Fprintf(w, " %s (%d):\n", title, len(entries))
+		for i, e := range entries {
+			if i == maxDiffRows {
+				fmt.Fprintf(w, "   ...and %d more\n", len(entries)-i)
+				break
+			}
+			fmt.Fprintf(w, "   %s %-40s %-28s %s$%.2f/mo\n", sign, e.ID, e.Type, sign, e.Cost)
+		}
+	}
+	writeEntries("New waste", "+", d.Added)
+	writeEntries("Resolved", "-", d.Resolved)
+
+	if len(d.Changed) > 0 {
+		fmt.Fprintf(w, " Cost changed (%d):\n", len(d.Changed))
+		for i, c := range d.Changed {
+			if i == maxDiffRows {
+				fmt.Fprintf(w, "   ...and %d more\n", len(d.Changed)-i)
+				break
+			}
+			fmt.Fprintf(w, "   ~ %-40s %-28s $%.2f -> $%.2f/mo\n", c.ID, c.Type, c.Before, c.After)
+		}
+	}
+
+	fmt.Fprintf(w, " Net change: %+.2f $/mo (%d new, %d resolved)\n", d.CostDelta, len(d.Added), len(d.Resolved))
+	fmt.Fprintln(w, "-----------------------------------------------------------------")
+}
+
+// diffMarkdown renders the delta as a CI comment section.
+func diffMarkdown(d history.Delta) string {
+	var sb strings.Builder
+	sb.WriteString("#### 🔁 Since Last Scan\n\n")
+	if d.Empty() {
+		sb.WriteString("No change in waste.\n\n")
+		return sb.String()
+	}
+	sb.WriteString(fmt.Sprintf("**%d new**, **%d resolved**, net `%+.2f $/mo`\n\n", len(d.Added), len(d.Resolved), d.CostDelta))
+	if len(d.Added) == 0 && len(d.Resolved) == 0 {
+		return sb.String()
+	}
+	sb.WriteString("| Change | Resource | Type | Cost |\n")
+	sb.WriteString("| :--- | :--- | :--- | :--- |\n")
+	rows := 0
+	for _, e := range d.Added {
+		if rows == 10 {
+			break
+		}
+		sb.WriteString(fmt.Sprintf("| 🆕 New | `%s` | %s | **$%.2f** |\n", e.ID, e.Type, e.Cost))
+		rows++
+	}
+	for _, e := range d.Resolved {
+		if rows == 10 {
+			break
+		}
+		sb.WriteString(fmt.Sprintf("| ✅ Resolved | `%s` | %s | $%.2f |\n", e.ID, e.Type, e.Cost))
+		rows++
+	}
+	if more := len(d.Added) + len(d.Resolved) - rows; more > 0 {
+		sb.WriteString(fmt.Sprintf("\n*...and %d more.*\n", more))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
@@ -6,31 +6,78 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"time"
 
 	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
 )
 
+// hoursPerMonth matches the pricing package's monthly convention.
+const hoursPerMonth = 730.0
+
 // ExportItem represents a row in the export.
 type ExportItem struct {
 	ResourceID  string  `json:"resource_id"`
 	Type        string  `json:"type"`
 	Region      string  `json:"region"`
+	AccountID   string  `json:"account_id,omitempty"`
 	NameTag     string  `json:"name_tag"`
 	MonthlyCost float64 `json:"monthly_cost"`
 	RiskScore   int     `json:"risk_score"`
 	AuditDetail string  `json:"audit_detail"`
 	OwnerARN    string  `json:"owner_arn"`
 	Action      string  `json:"action"`
+	// WastedToDate is monthly cost × months since creation; 0 if unknown.
+	WastedToDate float64 `json:"wasted_to_date"`
+	// Environment and Caution come from --env-tag.
+	Environment string `json:"environment,omitempty"`
+	Caution     string `json:"caution,omitempty"`
+	// EstimatedCost is the pricing estimate; BilledCost is what Cost Explorer
+	// billed, when the finding was reconciled. MonthlyCost is the billed cost
+	// when known.
+	EstimatedCost float64  `json:"estimated_cost"`
+	BilledCost    *float64 `json:"billed_cost,omitempty"`
+	// Security marks security findings by exposure ("public",
+	// "external-account"); empty for cost findings.
+	Security string `json:"security,omitempty"`
+
+	// Graph state, so `report --from` can rebuild the graph (graph.LoadFromJSON).
+	Justified      bool                   `json:"justified,omitempty"`
+	Justification  string                 `json:"justification,omitempty"`
+	WasteReason    string                 `json:"waste_reason,omitempty"`
+	SourceLocation string                 `json:"source_location,omitempty"`
+	Findings       []graph.Finding        `json:"findings,omitempty"`
+	Properties     map[string]interface{} `json:"properties,omitempty"`
+	PropertyTypes  map[string]string      `json:"property_types,omitempty"`
+	Edges          []graph.JSONEdge       `json:"edges,omitempty"`
 }
 
-// GenerateCSV exports findings to CSV.
-func GenerateCSV(g *graph.Graph, path string) error {
+// Findings returns all waste items grouped by caution (safe-delete first,
+// manual-review last), most expensive first within each group.
+// Without --env-tag this is plain cost order.
+func Findings(g *graph.Graph) []ExportItem {
 	items := extractItems(g)
-
-	// Sort by cost.
-	sort.Slice(items, func(i, j int) bool {
+	sort.SliceStable(items, func(i, j int) bool {
+		if ri, rj := cautionRank(items[i].Caution), cautionRank(items[j].Caution); ri != rj {
+			return ri < rj
+		}
 		return items[i].MonthlyCost > items[j].MonthlyCost
 	})
+	return items
+}
+
+func cautionRank(caution string) int {
+	switch caution {
+	case "safe-delete":
+		return 0
+	case "manual-review":
+		return 2
+	}
+	return 1
+}
+
+// GenerateCSV exports findings to CSV.
+func GenerateCSV(g *graph.Graph, path string) error {
+	items := Findings(g)
 
 	f, err := os.Create(path)
 	if err != nil {
@@ -52,12 +99,23 @@ func GenerateCSV(g *graph.Graph, path string) error {
 		"AuditDetail",
 		"OwnerARN",
 		"Action",
+		"WastedToDate",
+		"Environment",
+		"Caution",
+		"AccountID",
+		"EstimatedCost",
+		"BilledCost",
+		"Security",
 	}
 	if err := w.Write(header); err != nil {
 		return err
 	}
 
 	for _, item := range items {
+		billed := ""
+		if item.BilledCost != nil {
+			billed = fmt.Sprintf("$%.2f", *item.BilledCost)
+		}
 		record := []string{
 			item.ResourceID,
 			item.Type,
@@ -68,6 +126,13 @@ func GenerateCSV(g *graph.Graph, path string) error {
 			item.AuditDetail,
 			item.OwnerARN,
 			item.Action,
+			fmt.Sprintf("$%.2f", item.WastedToDate),
+			item.Environment,
+			item.Caution,
+			item.AccountID,
+			fmt.Sprintf("$%.2f", item.EstimatedCost),
+			billed,
+			item.Security,
 		}
 		if err := w.Write(record); err != nil {
 			return err
@@ -79,12 +144,7 @@ func GenerateCSV(g *graph.Graph, path string) error {
 
 // GenerateJSON exports findings to JSON.
 func GenerateJSON(g *graph.Graph, path string) error {
-	items := extractItems(g)
-
-	// Sort by Cost Descending
-	sort.Slice(items, func(i, j int) bool {
-		return items[i].MonthlyCost > items[j].MonthlyCost
-	})
+	items := Findings(g)
 
 	data, err := json.MarshalIndent(items, "", "  ")
 	if err != nil {
@@ -94,10 +154,22 @@ func GenerateJSON(g *graph.Graph, path string) error {
 	return os.WriteFile(path, data, 0644)
 }
 
+// WastedToDate estimates what a resource has already cost since creation
+// at its current monthly rate. Returns 0 when the creation time is unknown.
+func WastedToDate(node *graph.Node, now time.Time) float64 {
+	created, ok := node.CreatedAt()
+	if !ok || !created.Before(now) {
+		return 0
+	}
+	months := now.Sub(created).Hours() / hoursPerMonth
+	return node.Cost * months
+}
+
 func extractItems(g *graph.Graph) []ExportItem {
 	g.Mu.RLock()
 	defer g.Mu.RUnlock()
 
+	now := time.Now()
 	var items []ExportItem
 	for _, node := range g.Store.GetAllNodes() {
 		if node.IsWaste {
@@ -126,20 +198,60 @@ func extractItems(g *graph.Graph) []ExportItem {
 			if node.RiskScore < 50 {
 				action = "REVIEW"
 			}
+			if _, managed := node.Properties["CFNStack"].(string); managed {
+				action = "REVIEW_IAC"
+			}
+			if _, blocked := node.Properties["RemediationBlocked"].(string); blocked {
+				action = "BLOCKED"
+			}
 			if node.Justified {
 				action = "JUSTIFIED"
 			}
+			env, _ := node.Properties["Environment"].(string)
+			caution, _ := node.Properties["RemediationCaution"].(string)
+			account, _ := node.Properties["AccountId"].(string)
+			if account == "" {
+				account = accountFromARN(node.IDStr(), "")
+			}
+			// Only findings reconciled with billed cost carry an estimate apart.
+			estimated, reconciled := node.Properties["EstimatedCost"].(float64)
+			var billed *float64
+			if reconciled {
+				cost := node.Cost
+				billed = &cost
+			} else {
+				estimated = node.Cost
+			}
+			security, _ := node.Properties["SecurityExposure"].(string)
+			props, propTypes := graph.EncodeProperties(node.Properties)
 
 			items = append(items, ExportItem{
-				ResourceID:  node.IDStr(),
-				Type:        node.TypeStr(),
-				Region:      region,
-				NameTag:     nameTag,
-				MonthlyCost: node.Cost,
-				RiskScore:   node.RiskScore,
-				AuditDetail: reason,
-				OwnerARN:    owner,
-				Action:      action,
+				ResourceID:   node.IDStr(),
+				Type:         node.TypeStr(),
+				Region:       region,
+				AccountID:    account,
+				NameTag:      nameTag,
+				MonthlyCost:  node.Cost,
+				RiskScore:    node.RiskScore,
+				AuditDetail:  reason,
+				OwnerARN:     owner,
+				Action:       action,
+				WastedToDate: WastedToDate(node, now),
+				Environment:  env,
+				Caution:      caution,
+
+				EstimatedCost: estimated,
+				BilledCost:    billed,
+				Security:      security,
+
+				Justified:      node.Justified,
+				Justification:  node.Justification,
+				WasteReason:    node.WasteReason,
+				SourceLocation: node.SourceLocation,
+				Findings:       node.Findings,
+				Properties:     props,
+				PropertyTypes:  propTypes,
+				Edges:          g.JSONEdges(node),
 			})
 		}
 	}
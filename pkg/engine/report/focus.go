@@ -0,0 +1,246 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+)
+
+// focusChargeCategory marks CloudSlash rows apart from billed usage. It is not
+// one of the FOCUS 1.0 ChargeCategory values: a waste row is a projection of
+// spend already present in the CUR, and must not be summed with it.
+const focusChargeCategory = "Waste"
+
+// focusService is the FOCUS ServiceName and ServiceCategory of a resource type.
+// AWS names match the product names in the AWS FOCUS export so rows join with
+// CUR data on ServiceName.
+type focusService struct {
+	Name     string
+	Category string
+}
+
+var focusServices = map[string]focusService{
+	"AWS::EC2::Instance":         {"Amazon Elastic Compute Cloud", "Compute"},
+	"AWS::EC2::Volume":           {"Amazon Elastic Compute Cloud", "Storage"},
+	"AWS::EC2::Snapshot":         {"Amazon Elastic Compute Cloud", "Storage"},
+	"AWS::EC2::AMI":              {"Amazon Elastic Compute Cloud", "Storage"},
+	"AWS::EC2::NatGateway":       {"Amazon Elastic Compute Cloud", "Networking"},
+	"aws_nat_gateway":            {"Amazon Elastic Compute Cloud", "Networking"},
+	"AWS::EC2::NetworkInterface": {"Amazon Elastic Compute Cloud", "Networking"},
+	"AWS::EC2::EIP":              {"Amazon Virtual Private Cloud", "Networking"},
+	"aws_eip":                    {"Amazon Virtual Private Cloud", "Networking"},
+	"AWS::EC2::VPC":              {"Amazon Virtual Private Cloud", "Networking"},
+	"AWS::EC2::Subnet":           {"Amazon Virtual Private Cloud", "Networking"},
+	"aws_subnet":                 {"Amazon Virtual Private Cloud", "Networking"},
+	"AWS::EC2::RouteTable":       {"Amazon Virtual Private Cloud", "Networking"},
+	"aws_route_table":            {"Amazon Virtual Private Cloud", "Networking"},
+	"AWS::EC2::InternetGateway":  {"Amazon Virtual Private Cloud", "Networking"},
+	"AWS::EC2::VPNGateway":       {"Amazon Virtual Private Cloud", "Networking"},
+	"AWS::EC2::SecurityGroup":    {"Amazon Virtual Private Cloud", "Networking"},
+	"aws_vpc_endpoint":           {"Amazon Virtual Private Cloud", "Networking"},
+
+	"AWS::ElasticLoadBalancing::LoadBalancer":   {"Elastic Load Balancing", "Networking"},
+	"AWS::ElasticLoadBalancingV2::LoadBalancer": {"Elastic Load Balancing", "Networking"},
+	"aws_alb":                          {"Elastic Load Balancing", "Networking"},
+	"AWS::CloudFront::Distribution":    {"Amazon CloudFront", "Networking"},
+	"AWS::WAFv2::WebACL":               {"AWS WAF", "Security"},
+	"AWS::Route53::HostedZone":         {"Amazon Route 53", "Networking"},
+	"AWS::Route53::RecordSet":          {"Amazon Route 53", "Networking"},
+	"AWS::S3::Bucket":                  {"Amazon Simple Storage Service", "Storage"},
+	"AWS::S3::MultipartUpload":         {"Amazon Simple Storage Service", "Storage"},
+	"AWS::EFS::FileSystem":             {"Amazon Elastic File System", "Storage"},
+	"AWS::ECR::Repository":             {"Amazon EC2 Container Registry (ECR)", "Storage"},
+	"AWS::Logs::LogGroup":              {"AmazonCloudWatch", "Management and Governance"},
+	"AWS::RDS::DBInstance":             {"Amazon Relational Database Service", "Databases"},
+	"AWS::RDS::DBSnapshot":             {"Amazon Relational Database Service", "Storage"},
+	"AWS::DynamoDB::Table":             {"Amazon DynamoDB", "Databases"},
+	"aws_dynamodb_table":               {"Amazon DynamoDB", "Databases"},
+	"aws_elasticache_cluster":          {"Amazon ElastiCache", "Databases"},
+	"aws_redshift_cluster":             {"Amazon Redshift", "Analytics"},
+	"AWS::OpenSearch::Domain":          {"Amazon OpenSearch Service", "Analytics"},
+	"AWS::Lambda::Function":            {"AWS Lambda", "Compute"},
+	"aws_lambda_function":              {"AWS Lambda", "Compute"},
+	"AWS::ECS::Cluster":                {"Amazon Elastic Container Service", "Compute"},
+	"AWS::ECS::Service":                {"Amazon Elastic Container Service", "Compute"},
+	"AWS::ECS::Task":                   {"Amazon Elastic Container Service", "Compute"},
+	"AWS::ECS::ContainerInstance":      {"Amazon Elastic Container Service", "Compute"},
+	"AWS::EKS::Cluster":                {"Amazon Elastic Kubernetes Service", "Compute"},
+	"AWS::EKS::NodeGroup":              {"Amazon Elastic Kubernetes Service", "Compute"},
+	"AWS::EKS::FargateProfile":         {"Amazon Elastic Kubernetes Service", "Compute"},
+	"K8s::Pod":                         {"Amazon Elastic Kubernetes Service", "Compute"},
+	"AWS::SageMaker::Endpoint":         {"Amazon SageMaker", "AI and Machine Learning"},
+	"AWS::Comprehend::Endpoint":        {"Amazon Comprehend", "AI and Machine Learning"},
+	"AWS::Rekognition::ProjectVersion": {"Amazon Rekognition", "AI and Machine Learning"},
+	"AWS::DMS::ReplicationInstance":    {"AWS Database Migration Service", "Migration"},
+	"AWS::CodeBuild::Project":          {"AWS CodeBuild", "Developer Tools"},
+	"AWS::CodePipeline::Pipeline":      {"AWS CodePipeline", "Developer Tools"},
+	"AWS::SNS::Topic":                  {"Amazon Simple Notification Service", "Integration"},
+	"AWS::SQS::Queue":                  {"Amazon Simple Queue Service", "Integration"},
+	"AWS::IAM::Role":                   {"AWS Identity and Access Management", "Identity"},
+	"AWS::IAM::User":                   {"AWS Identity and Access Management", "Identity"},
+
+	"GCP::Compute::Instance":         {"Compute Engine", "Compute"},
+	"GCP::Compute::Disk":             {"Compute Engine", "Storage"},
+	"Azure::Compute::VirtualMachine": {"Virtual Machines", "Compute"},
+	"Azure::Compute::Disk":           {"Storage", "Storage"},
+}
+
+// FocusService returns the FOCUS ServiceName and ServiceCategory for a
+// resource type. Unmapped types fall back to their service segment
+// ("AWS::Foo::Bar" is "Foo") in the Other category.
+func FocusService(resourceType string) (name, category string) {
+	if s, ok := focusServices[resourceType]; ok {
+		return s.Name, s.Category
+	}
+	if parts := strings.Split(resourceType, "::"); len(parts) == 3 {
+		return parts[1], "Other"
+	}
+	return resourceType, "Other"
+}
+
+// focusProvider returns the FOCUS ProviderName for a resource type.
+func focusProvider(resourceType string) string {
+	switch {
+	case strings.HasPrefix(resourceType, "GCP::"):
+		return "Google Cloud"
+	case strings.HasPrefix(resourceType, "Azure::"):
+		return "Microsoft"
+	}
+	return "AWS"
+}
+
+// focusAccount returns the account that owns a resource: the AWS account it
+// was scanned in or named in its ARN, the Azure subscription in its resource
+// ID, or the GCP project in its self link.
+func focusAccount(item ExportItem) string {
+	if item.AccountID != "" {
+		return item.AccountID
+	}
+	if parsed, err := arn.Parse(item.ResourceID); err == nil {
+		return parsed.AccountID
+	}
+	parts := strings.Split(item.ResourceID, "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if strings.EqualFold(parts[i], "subscriptions") || parts[i] == "projects" {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
+
+var focusHeader = []string{
+	"BillingAccountId",
+	"SubAccountId",
+	"ProviderName",
+	"PublisherName",
+	"InvoiceIssuerName",
+	"BillingCurrency",
+	"BillingPeriodStart",
+	"BillingPeriodEnd",
+	"ChargePeriodStart",
+	"ChargePeriodEnd",
+	"ChargeCategory",
+	"ChargeFrequency",
+	"ChargeDescription",
+	"BilledCost",
+	"EffectiveCost",
+	"ListCost",
+	"ResourceId",
+	"ResourceName",
+	"ResourceType",
+	"RegionId",
+	"RegionName",
+	"ServiceName",
+	"ServiceCategory",
+	"Tags",
+	"x_RiskScore",
+	"x_Action",
+	"x_WastedToDate",
+}
+
+// GenerateFOCUS exports findings as FOCUS 1.0 CSV, one row per finding. Cost
+// columns carry the projected monthly waste for the current billing period
+// (calendar month, UTC). CloudSlash-specific columns use the x_ prefix the
+// spec reserves for custom columns.
+func GenerateFOCUS(g *graph.Graph, path string) error {
+	return writeFOCUS(Findings(g), path, time.Now())
+}
+
+func writeFOCUS(items []ExportItem, path string, now time.Time) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.Write(focusHeader); err != nil {
+		return err
+	}
+
+	now = now.UTC()
+	periodStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	start := periodStart.Format(time.RFC3339)
+	end := periodStart.AddDate(0, 1, 0).Format(time.RFC3339)
+
+	for _, item := range items {
+		service, category := FocusService(item.Type)
+		provider := focusProvider(item.Type)
+		account := focusAccount(item)
+		region := item.Region
+		if region == "global" {
+			region = ""
+		}
+		tags := "{}"
+		if t, ok := item.Properties["Tags"].(map[string]string); ok && len(t) > 0 {
+			data, err := json.Marshal(t)
+			if err != nil {
+				return fmt.Errorf("failed to encode tags of %s: %v", item.ResourceID, err)
+			}
+			tags = string(data)
+		}
+		cost := fmt.Sprintf("%.2f", item.MonthlyCost)
+
+		record := []string{
+			account,
+			account,
+			provider,
+			provider,
+			provider,
+			"USD",
+			start,
+			end,
+			start,
+			end,
+			focusChargeCategory,
+			"Recurring",
+			item.AuditDetail,
+			cost,
+			cost,
+			cost,
+			item.ResourceID,
+			item.NameTag,
+			item.Type,
+			region,
+			region,
+			service,
+			category,
+			tags,
+			fmt.Sprintf("%d", item.RiskScore),
+			item.Action,
+			fmt.Sprintf("%.2f", item.WastedToDate),
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,80 @@
+package report
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+func TestWriteFOCUS(t *testing.T) {
+	g := graph.NewGraph()
+	vol := "arn:aws:ec2:eu-west-1:123456789012:volume/vol-1"
+	g.AddNode(vol, "AWS::EC2::Volume", map[string]interface{}{
+		"Region": "eu-west-1",
+		"Reason": "Unattached Volume",
+		"Tags":   map[string]string{"Name": "scratch", "team": "data"},
+	})
+	disk := "/subscriptions/sub-1/resourcegroups/rg/providers/microsoft.compute/disks/d1"
+	g.AddNode(disk, "Azure::Compute::Disk", map[string]interface{}{"Region": "eastus"})
+	g.AddNode("healthy", "AWS::EC2::Instance", map[string]interface{}{})
+	g.CloseAndWait()
+	g.MarkWaste(vol, 80)
+	g.MarkWaste(disk, 80)
+	g.GetNode(vol).Cost = 40
+	g.GetNode(disk).Cost = 12.5
+
+	path := filepath.Join(t.TempDir(), "focus.csv")
+	if err := writeFOCUS(Findings(g), path, time.Date(2025, 2, 14, 9, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("writeFOCUS: %v", err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want header + 2 findings", len(rows))
+	}
+
+	col := make(map[string]int)
+	for i, name := range rows[0] {
+		col[name] = i
+	}
+	row := rows[1] // Most expensive first.
+	for name, want := range map[string]string{
+		"BillingAccountId":   "123456789012",
+		"ProviderName":       "AWS",
+		"ServiceName":        "Amazon Elastic Compute Cloud",
+		"ServiceCategory":    "Storage",
+		"ChargeCategory":     "Waste",
+		"BilledCost":         "40.00",
+		"RegionId":           "eu-west-1",
+		"ResourceName":       "scratch",
+		"BillingPeriodStart": "2025-02-01T00:00:00Z",
+		"BillingPeriodEnd":   "2025-03-01T00:00:00Z",
+		"Tags":               `{"Name":"scratch","team":"data"}`,
+	} {
+		if got := row[col[name]]; got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+
+	azure := rows[2]
+	if azure[col["ProviderName"]] != "Microsoft" || azure[col["SubAccountId"]] != "sub-1" || azure[col["Tags"]] != "{}" {
+		t.Errorf("unexpected Azure row: %v", azure)
+	}
+}
+
+func TestFocusServiceFallback(t *testing.T) {
+	if name, category := FocusService("AWS::Kinesis::Stream"); name != "Kinesis" || category != "Other" {
+		t.Errorf("FocusService fallback = %q, %q", name, category)
+	}
+}
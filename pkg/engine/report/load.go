@@ -0,0 +1,103 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// LoadFindings reads a waste_report.json written by GenerateJSON, typically
+// after reviewers have removed the findings they do not approve.
+func LoadFindings(path string) ([]ExportItem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report: %v", err)
+	}
+	var items []ExportItem
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("failed to parse report %s: %v", path, err)
+	}
+	for i, item := range items {
+		if item.ResourceID == "" || item.Type == "" {
+			return nil, fmt.Errorf("report %s: finding %d is missing resource_id or type", path, i)
+		}
+	}
+	return items, nil
+}
+
+// GraphFromFindings rebuilds the graph of the given findings for the
+// remediation generator, with the properties, findings and edges the scan
+// exported, so the generator picks the same action the scan did (a gp2
+// upgrade, a lifecycle policy, a replica delete) rather than a plain delete.
+// JUSTIFIED findings are skipped: they are not to be acted on. REVIEW
+// findings are kept for manual review, never executed. Reports written
+// before properties were exported are rebuilt from their summary fields.
+func GraphFromFindings(items []ExportItem) (*graph.Graph, error) {
+	approved := make([]ExportItem, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		if item.Action == "JUSTIFIED" || seen[item.ResourceID] {
+			continue
+		}
+		seen[item.ResourceID] = true
+		if item.Properties == nil {
+			item.Properties, item.PropertyTypes = graph.EncodeProperties(summaryProperties(item))
+		}
+		approved = append(approved, item)
+	}
+
+	data, err := json.Marshal(approved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode approved findings: %v", err)
+	}
+	g, err := graph.LoadFromJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	g.Mu.Lock()
+	defer g.Mu.Unlock()
+	for _, item := range approved {
+		node := g.GetNode(item.ResourceID)
+		if node == nil || item.Action != "REVIEW" {
+			continue
+		}
+		if caution, _ := node.Properties["RemediationCaution"].(string); caution == "" {
+			node.Properties["RemediationCaution"] = "manual-review"
+		}
+	}
+	return g, nil
+}
+
+// summaryProperties rebuilds what a finding's summary fields say about the
+// resource, for reports that carry no properties.
+func summaryProperties(item ExportItem) map[string]interface{} {
+	props := map[string]interface{}{
+		"Reason": item.AuditDetail,
+		"Owner":  item.OwnerARN,
+	}
+	if item.Region != "" && item.Region != "global" {
+		props["Region"] = item.Region
+	}
+	if item.NameTag != "" {
+		props["Tags"] = map[string]string{"Name": item.NameTag}
+	}
+	if item.Environment != "" {
+		props["Environment"] = item.Environment
+	}
+	if item.Caution != "" {
+		props["RemediationCaution"] = item.Caution
+	}
+	// Keep the actions that must not become deletes. The summary does not
+	// carry the stack or policy name, only the verdict.
+	switch item.Action {
+	case "REVIEW_IAC":
+		props["CFNStack"] = "unknown"
+	case "BLOCKED":
+		props["RemediationBlocked"] = "policy (see scan report)"
+	}
+	return props
+}
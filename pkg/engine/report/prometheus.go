@@ -0,0 +1,111 @@
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// wasteGroup is one (type, region) series.
+type wasteGroup struct {
+	Type   string
+	Region string
+	Count  int
+	Cost   float64
+}
+
+// WritePrometheus writes the waste totals in Prometheus text exposition format.
+// Totals are always emitted, so a clean scan reports 0 rather than no data.
+func WritePrometheus(g *graph.Graph, w io.Writer) error {
+	groups := make(map[[2]string]*wasteGroup)
+	var count int
+	var cost float64
+
+	g.Mu.RLock()
+	for _, node := range g.Store.GetAllNodes() {
+		if !node.IsWaste {
+			continue
+		}
+		region, _ := node.Properties["Region"].(string)
+		if region == "" {
+			region = "global"
+		}
+		key := [2]string{node.TypeStr(), region}
+		grp, ok := groups[key]
+		if !ok {
+			grp = &wasteGroup{Type: key[0], Region: key[1]}
+			groups[key] = grp
+		}
+		grp.Count++
+		grp.Cost += node.Cost
+		count++
+		cost += node.Cost
+	}
+	g.Mu.RUnlock()
+
+	sorted := make([]*wasteGroup, 0, len(groups))
+	for _, grp := range groups {
+		sorted = append(sorted, grp)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Type != sorted[j].Type {
+			return sorted[i].Type < sorted[j].Type
+		}
+		return sorted[i].Region < sorted[j].Region
+	})
+
+	bw := bufio.NewWriter(w)
+	writeMetricHeader(bw, "cloudslash_waste_monthly_cost", "Projected monthly cost of all waste, in USD.")
+	fmt.Fprintf(bw, "cloudslash_waste_monthly_cost %s\n", formatMetricValue(cost))
+	writeMetricHeader(bw, "cloudslash_waste_resource_count", "Number of resources flagged as waste.")
+	fmt.Fprintf(bw, "cloudslash_waste_resource_count %d\n", count)
+
+	writeMetricHeader(bw, "cloudslash_waste_type_monthly_cost", "Projected monthly cost of waste by resource type and region, in USD.")
+	for _, grp := range sorted {
+		fmt.Fprintf(bw, "cloudslash_waste_type_monthly_cost{type=\"%s\",region=\"%s\"} %s\n", escapeLabelValue(grp.Type), escapeLabelValue(grp.Region), formatMetricValue(grp.Cost))
+	}
+	writeMetricHeader(bw, "cloudslash_waste_type_resource_count", "Number of waste resources by resource type and region.")
+	for _, grp := range sorted {
+		fmt.Fprintf(bw, "cloudslash_waste_type_resource_count{type=\"%s\",region=\"%s\"} %d\n", escapeLabelValue(grp.Type), escapeLabelValue(grp.Region), grp.Count)
+	}
+	return bw.Flush()
+}
+
+// GeneratePrometheus writes the metrics file. It writes to a temporary file and
+// renames it, so a node_exporter textfile collector never reads a partial file.
+func GeneratePrometheus(g *graph.Graph, path string) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("failed to create metrics file: %v", err)
+	}
+	if err := WritePrometheus(g, f); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write metrics: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write metrics: %v", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+func writeMetricHeader(w io.Writer, name, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+}
+
+// escapeLabelValue escapes backslash, double quote and newline, as the exposition format requires.
+func escapeLabelValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+func formatMetricValue(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
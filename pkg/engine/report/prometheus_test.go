@@ -0,0 +1,69 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+func TestWritePrometheus(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("vol-1", "AWS::EC2::Volume", map[string]interface{}{"Region": "us-east-1"})
+	g.AddNode("vol-2", "AWS::EC2::Volume", map[string]interface{}{"Region": "us-east-1"})
+	g.AddNode("eip-1", "AWS::EC2::EIP", map[string]interface{}{"Region": `eu-"west"-1`})
+	g.AddNode("vpc-1", "AWS::EC2::VPC", map[string]interface{}{})
+	g.CloseAndWait()
+
+	for id, cost := range map[string]float64{"vol-1": 8, "vol-2": 2.5, "eip-1": 3.6} {
+		g.MarkWaste(id, 50)
+		g.GetNode(id).Cost = cost
+	}
+
+	var buf bytes.Buffer
+	if err := WritePrometheus(g, &buf); err != nil {
+		t.Fatalf("WritePrometheus failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"# TYPE cloudslash_waste_monthly_cost gauge\n",
+		"cloudslash_waste_monthly_cost 14.1\n",
+		"cloudslash_waste_resource_count 3\n",
+		`cloudslash_waste_type_monthly_cost{type="AWS::EC2::Volume",region="us-east-1"} 10.5` + "\n",
+		`cloudslash_waste_type_resource_count{type="AWS::EC2::Volume",region="us-east-1"} 2` + "\n",
+		`cloudslash_waste_type_resource_count{type="AWS::EC2::EIP",region="eu-\"west\"-1"} 1` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "AWS::EC2::VPC") {
+		t.Error("Expected non-waste nodes to be excluded")
+	}
+}
+
+func TestWritePrometheusNoWaste(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("vpc-1", "AWS::EC2::VPC", map[string]interface{}{})
+	g.CloseAndWait()
+
+	var buf bytes.Buffer
+	if err := WritePrometheus(g, &buf); err != nil {
+		t.Fatalf("WritePrometheus failed: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "cloudslash_waste_monthly_cost 0\n") || !strings.Contains(out, "cloudslash_waste_resource_count 0\n") {
+		t.Errorf("Expected zero totals, got:\n%s", out)
+	}
+	if !strings.Contains(out, "# TYPE cloudslash_waste_type_monthly_cost gauge\n") {
+		t.Errorf("Expected per-type metric headers even without series, got:\n%s", out)
+	}
+}
+
+func TestEscapeLabelValue(t *testing.T) {
+	if got := escapeLabelValue("a\\b\"c\nd"); got != `a\\b\"c\nd` {
+		t.Errorf("escapeLabelValue = %q", got)
+	}
+}
@@ -12,6 +12,32 @@ import (
 	"github.com/DrSkyle/cloudslash/v2/pkg/version"
 )
 
+// ReportConfig sets the risk scores the reports grade findings by.
+type ReportConfig struct {
+	// CriticalThreshold is the lowest score badged CRITICAL.
+	CriticalThreshold int
+	// ReviewThreshold is the highest score left for review; findings above
+	// it are JUNK, and count as risky resources.
+	ReviewThreshold int
+}
+
+// DefaultReportConfig returns the stock thresholds: CRITICAL from 80, JUNK above 50.
+func DefaultReportConfig() ReportConfig {
+	return ReportConfig{CriticalThreshold: 80, ReviewThreshold: 50}
+}
+
+// withDefaults fills unset thresholds from DefaultReportConfig.
+func (c ReportConfig) withDefaults() ReportConfig {
+	d := DefaultReportConfig()
+	if c.CriticalThreshold <= 0 {
+		c.CriticalThreshold = d.CriticalThreshold
+	}
+	if c.ReviewThreshold <= 0 {
+		c.ReviewThreshold = d.ReviewThreshold
+	}
+	return c
+}
+
 // ReportData contains data for the static report template.
 type ReportData struct {
 	GeneratedAt      string
@@ -24,6 +50,10 @@ type ReportData struct {
 	WasteItems       []WasteItem
 	JustifiedItems   []WasteItem
 
+	// Risk grading.
+	CriticalThreshold int
+	ReviewThreshold   int
+
 	// Chart Data
 	ChartLabelsJSON template.JS
 	ChartValuesJSON template.JS
@@ -37,6 +67,8 @@ type WasteItem struct {
 	Cost      float64
 	RiskScore int
 	SrcLoc    string
+	Security  string          // Exposure, for security findings.
+	Findings  []graph.Finding // Listed in place of Reason when there are several.
 }
 
 const htmlTemplate = `
@@ -263,6 +295,23 @@ const htmlTemplate = `
             font-weight: 700;
         }
 
+        .findings {
+            margin: 0;
+            padding-left: 1rem;
+        }
+
+        .finding-source {
+            opacity: 0.6;
+            font-size: 0.75rem;
+        }
+
+        .security-pill {
+            margin-top: 4px;
+            color: #f87171;
+            background: rgba(239, 68, 68, 0.1);
+            border-color: rgba(239, 68, 68, 0.4);
+        }
+
         .risk-high { color: #f87171; text-shadow: 0 0 20px rgba(239, 68, 68, 0.3); }
         .risk-mid { color: #fbbf24; }
         .risk-low { color: #94a3b8; }
@@ -395,16 +444,27 @@ const htmlTemplate = `
                             <div class="resource-id">{{.ID}}</div>
                             <div style="font-size: 0.75rem; color: var(--text-secondary); margin-top: 4px;">{{.SrcLoc}}</div>
                         </td>
-                        <td><span class="type-pill">{{.Type}}</span></td>
                         <td>
-                             {{if ge .RiskScore 80}}
+                            <span class="type-pill">{{.Type}}</span>
+                            {{if .Security}}<span class="type-pill security-pill">SECURITY: {{.Security}}</span>{{end}}
+                        </td>
+                        <td>
+                             {{if ge .RiskScore $.CriticalThreshold}}
                                 <span class="risk-score risk-high">CRITICAL</span>
-                            {{else}}
+                            {{else if gt .RiskScore $.ReviewThreshold}}
                                 <span class="risk-score risk-mid">Medium</span>
+                            {{else}}
+                                <span class="risk-score risk-low">Low</span>
                             {{end}}
                         </td>
                         <td style="font-family: var(--font-mono); color: var(--text-primary);">$ {{printf "%.2f" .Cost}}</td>
-                        <td style="color: var(--text-secondary);">{{.Reason}}</td>
+                        <td style="color: var(--text-secondary);">
+                            {{if gt (len .Findings) 1}}
+                            <ul class="findings">
+                                {{range .Findings}}<li>{{.Reason}}{{if .Heuristic}} <span class="finding-source">{{.Heuristic}}</span>{{end}}</li>{{end}}
+                            </ul>
+                            {{else}}{{.Reason}}{{end}}
+                        </td>
                     </tr>
                     {{else}}
                     <tr>
@@ -534,12 +594,15 @@ const htmlTemplate = `
 </html>
 `
 
-// GenerateHTML renders the static report.
-func GenerateHTML(g *graph.Graph, outputPath string) error {
+// GenerateHTML renders the static report, grading risk by cfg.
+func GenerateHTML(g *graph.Graph, outputPath string, cfg ReportConfig) error {
+	cfg = cfg.withDefaults()
 	data := ReportData{
-		GeneratedAt: time.Now().Format(time.RFC822),
-		Version:     version.Current,
-		License:     version.License,
+		GeneratedAt:       time.Now().Format(time.RFC822),
+		Version:           version.Current,
+		License:           version.License,
+		CriticalThreshold: cfg.CriticalThreshold,
+		ReviewThreshold:   cfg.ReviewThreshold,
 	}
 
 	// Aggregate chart data.
@@ -572,6 +635,8 @@ func GenerateHTML(g *graph.Graph, outputPath string) error {
 				RiskScore: node.RiskScore,
 				SrcLoc:    node.SourceLocation, // Include source location.
 			}
+			item.Security, _ = node.Properties["SecurityExposure"].(string)
+			item.Findings = node.FindingList()
 
 			if node.Justified {
 				item.Reason = node.Justification // Use justification as reason.
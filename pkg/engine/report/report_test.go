@@ -4,6 +4,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
 )
@@ -31,7 +32,7 @@ func TestGenerateHTML_XSS(t *testing.T) {
 	}
 	defer os.Remove(tmpFile.Name())
 	
-	err = GenerateHTML(g, tmpFile.Name())
+	err = GenerateHTML(g, tmpFile.Name(), DefaultReportConfig())
 	if err != nil {
 		t.Fatalf("GenerateHTML failed: %v", err)
 	}
@@ -104,3 +105,192 @@ func TestGenerateHTML_XSS(t *testing.T) {
 		}
 	}
 }
+
+func TestGraphFromApprovedFindings(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:ec2:us-east-1:123:volume/vol-approved", "AWS::EC2::Volume", map[string]interface{}{"Region": "us-east-1", "Tags": map[string]string{"Name": "scratch"}, "IsGP2": true})
+	g.AddNode("arn:aws:ec2:us-east-1:123:volume/vol-review", "AWS::EC2::Volume", map[string]interface{}{"Region": "us-east-1"})
+	g.AddNode("arn:aws:ec2:us-east-1:123:volume/vol-rejected", "AWS::EC2::Volume", map[string]interface{}{"Region": "us-east-1"})
+	g.AddNode("arn:aws:ec2:us-east-1:123:volume/vol-stack", "AWS::EC2::Volume", map[string]interface{}{"Region": "us-east-1", "CFNStack": "data"})
+	g.CloseAndWait()
+	for _, id := range []string{"vol-approved", "vol-rejected", "vol-stack"} {
+		g.MarkWaste("arn:aws:ec2:us-east-1:123:volume/"+id, 80)
+	}
+	g.MarkWaste("arn:aws:ec2:us-east-1:123:volume/vol-review", 30)
+	g.GetNode("arn:aws:ec2:us-east-1:123:volume/vol-approved").Cost = 12.5
+
+	path := t.TempDir() + "/waste_report.json"
+	if err := GenerateJSON(g, path); err != nil {
+		t.Fatalf("GenerateJSON failed: %v", err)
+	}
+	items, err := LoadFindings(path)
+	if err != nil {
+		t.Fatalf("LoadFindings failed: %v", err)
+	}
+
+	// A reviewer rejects one finding.
+	var approved []ExportItem
+	for _, item := range items {
+		if !strings.HasSuffix(item.ResourceID, "vol-rejected") {
+			approved = append(approved, item)
+		}
+	}
+
+	rebuilt, err := GraphFromFindings(approved)
+	if err != nil {
+		t.Fatalf("GraphFromFindings failed: %v", err)
+	}
+	if n := len(rebuilt.GetNodes()); n != 3 {
+		t.Fatalf("Expected 3 approved nodes, got %d", n)
+	}
+	node := rebuilt.GetNode("arn:aws:ec2:us-east-1:123:volume/vol-approved")
+	if node == nil || !node.IsWaste || node.Cost != 12.5 || node.RiskScore != 80 {
+		t.Fatalf("Approved finding not restored: %+v", node)
+	}
+	if node.Properties["Region"] != "us-east-1" {
+		t.Errorf("Expected region restored, got %v", node.Properties["Region"])
+	}
+	// The generator picks the gp3 upgrade from this, not a delete.
+	if gp2, _ := node.Properties["IsGP2"].(bool); !gp2 {
+		t.Errorf("Expected scan properties restored, got %v", node.Properties)
+	}
+	review := rebuilt.GetNode("arn:aws:ec2:us-east-1:123:volume/vol-review")
+	if caution, _ := review.Properties["RemediationCaution"].(string); caution != "manual-review" {
+		t.Errorf("REVIEW findings must stay manual, got caution %q", caution)
+	}
+	if _, ok := rebuilt.GetNode("arn:aws:ec2:us-east-1:123:volume/vol-stack").Properties["CFNStack"]; !ok {
+		t.Error("REVIEW_IAC findings must stay IaC-managed, not become deletes")
+	}
+
+	// The rebuilt graph reports the same findings.
+	if again := Findings(rebuilt); len(again) != 3 || again[0].Action != "DELETE" || again[1].Action != "REVIEW_IAC" || again[2].Action != "REVIEW" {
+		t.Errorf("Round trip changed findings: %+v", again)
+	}
+}
+
+func TestLoadFromJSONRoundTrip(t *testing.T) {
+	created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:ec2:us-east-1:123:instance/i-web", "AWS::EC2::Instance", map[string]interface{}{"State": "running"})
+	g.AddNode("arn:aws:ec2:us-east-1:123:volume/vol-1", "AWS::EC2::Volume", map[string]interface{}{
+		"Region":     "us-east-1",
+		"Size":       100,
+		"CreateTime": created,
+		"Tags":       map[string]string{"Name": "scratch"},
+		"History":    []float64{1, 2.5},
+		"Ratio":      0.25,
+	})
+	g.AddNode("arn:aws:ec2:us-east-1:123:snapshot/snap-1", "AWS::EC2::Snapshot", map[string]interface{}{})
+	g.AddTypedEdge("arn:aws:ec2:us-east-1:123:instance/i-web", "arn:aws:ec2:us-east-1:123:volume/vol-1", graph.EdgeTypeAttachedTo, 10)
+	g.AddTypedEdge("arn:aws:ec2:us-east-1:123:snapshot/snap-1", "arn:aws:ec2:us-east-1:123:volume/vol-1", graph.EdgeTypeUses, 1)
+	g.CloseAndWait()
+	g.MarkWaste("arn:aws:ec2:us-east-1:123:volume/vol-1", 80)
+	g.MarkWaste("arn:aws:ec2:us-east-1:123:snapshot/snap-1", 40)
+	g.GetNode("arn:aws:ec2:us-east-1:123:volume/vol-1").Cost = 8
+	g.AddFinding("arn:aws:ec2:us-east-1:123:volume/vol-1", graph.Finding{Heuristic: "UnattachedVolumeHeuristic", Reason: "Unattached", Score: 80, Savings: 8})
+	g.GetNode("arn:aws:ec2:us-east-1:123:snapshot/snap-1").Justified = true
+
+	path := t.TempDir() + "/waste_report.json"
+	if err := GenerateJSON(g, path); err != nil {
+		t.Fatalf("GenerateJSON failed: %v", err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rebuilt, err := graph.LoadFromJSON(f)
+	if err != nil {
+		t.Fatalf("LoadFromJSON failed: %v", err)
+	}
+
+	vol := rebuilt.GetNode("arn:aws:ec2:us-east-1:123:volume/vol-1")
+	if vol == nil || !vol.IsWaste || vol.Cost != 8 || vol.RiskScore != 80 {
+		t.Fatalf("volume not restored: %+v", vol)
+	}
+	if vol.Properties["Size"] != 100 || vol.Properties["Ratio"] != 0.25 {
+		t.Errorf("numbers not restored with their types: %#v %#v", vol.Properties["Size"], vol.Properties["Ratio"])
+	}
+	if ts, ok := vol.Properties["CreateTime"].(time.Time); !ok || !ts.Equal(created) {
+		t.Errorf("CreateTime = %#v, want %v", vol.Properties["CreateTime"], created)
+	}
+	if tags, ok := vol.Properties["Tags"].(map[string]string); !ok || tags["Name"] != "scratch" {
+		t.Errorf("Tags = %#v", vol.Properties["Tags"])
+	}
+	if h, ok := vol.Properties["History"].([]float64); !ok || len(h) != 2 || h[1] != 2.5 {
+		t.Errorf("History = %#v", vol.Properties["History"])
+	}
+	if len(vol.Findings) != 1 || vol.Findings[0].Heuristic != "UnattachedVolumeHeuristic" {
+		t.Errorf("Findings = %+v", vol.Findings)
+	}
+	if !rebuilt.GetNode("arn:aws:ec2:us-east-1:123:snapshot/snap-1").Justified {
+		t.Error("Justified flag lost")
+	}
+
+	// The attached instance is not a finding but comes back as a typed peer.
+	web := rebuilt.GetNode("arn:aws:ec2:us-east-1:123:instance/i-web")
+	if web == nil || web.TypeStr() != "AWS::EC2::Instance" || web.IsWaste {
+		t.Fatalf("peer not restored: %+v", web)
+	}
+	if !rebuilt.AreConnected("arn:aws:ec2:us-east-1:123:instance/i-web", "arn:aws:ec2:us-east-1:123:volume/vol-1") {
+		t.Error("edge to peer lost")
+	}
+	if edges := rebuilt.GetEdges(rebuilt.GetNode("arn:aws:ec2:us-east-1:123:snapshot/snap-1").Index); len(edges) != 1 {
+		t.Errorf("edge between two findings restored %d times, want once", len(edges))
+	}
+
+	before, after := Findings(g), Findings(rebuilt)
+	if len(before) != len(after) {
+		t.Fatalf("findings %d -> %d", len(before), len(after))
+	}
+	for i := range before {
+		if before[i].ResourceID != after[i].ResourceID || before[i].Action != after[i].Action || (before[i].WastedToDate > 0) != (after[i].WastedToDate > 0) {
+			t.Errorf("finding %d changed: %+v -> %+v", i, before[i], after[i])
+		}
+	}
+}
+
+func TestReportThresholds(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:ec2:us-east-1:123:volume/vol-1", "AWS::EC2::Volume", map[string]interface{}{"Region": "us-east-1"})
+	g.CloseAndWait()
+	g.MarkWaste("arn:aws:ec2:us-east-1:123:volume/vol-1", 70)
+
+	dir := t.TempDir()
+	render := func(name string, gen func(string) error) string {
+		path := dir + "/" + name
+		if err := gen(path); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	// Defaults: 70 is above review (50) but below critical (80).
+	html := render("default.html", func(p string) error { return GenerateHTML(g, p, ReportConfig{}) })
+	if strings.Contains(html, ">CRITICAL<") || !strings.Contains(html, ">Medium<") {
+		t.Error("score 70 should grade Medium by default")
+	}
+	html = render("strict.html", func(p string) error { return GenerateHTML(g, p, ReportConfig{CriticalThreshold: 60}) })
+	if !strings.Contains(html, ">CRITICAL<") {
+		t.Error("score 70 should grade CRITICAL from 60")
+	}
+	html = render("lax.html", func(p string) error { return GenerateHTML(g, p, ReportConfig{ReviewThreshold: 75}) })
+	if !strings.Contains(html, ">Low<") {
+		t.Error("score 70 should grade Low when review runs to 75")
+	}
+
+	riskCard := `<h3>Risky Resources</h3>
+            <div class="value">%s</div>`
+	dash := render("dash.html", func(p string) error { return GenerateDashboard(g, p, ReportConfig{}) })
+	if !strings.Contains(dash, strings.Replace(riskCard, "%s", "1", 1)) || !strings.Contains(dash, "const REVIEW_THRESHOLD = 50;") {
+		t.Error("dashboard should count score 70 as risky by default")
+	}
+	dash = render("dash-lax.html", func(p string) error { return GenerateDashboard(g, p, ReportConfig{ReviewThreshold: 75}) })
+	if !strings.Contains(dash, strings.Replace(riskCard, "%s", "0", 1)) || !strings.Contains(dash, "const REVIEW_THRESHOLD = 75;") {
+		t.Error("dashboard should not count score 70 as risky when review runs to 75")
+	}
+}
@@ -0,0 +1,111 @@
+package report
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// Sankey visualization structures.
+type SankeyNode struct {
+	Name  string `json:"name"`
+	Waste bool   `json:"waste"`
+}
+type SankeyLink struct {
+	Source int     `json:"source"`
+	Target int     `json:"target"`
+	Value  float64 `json:"value"`
+}
+type SankeyData struct {
+	Nodes []SankeyNode `json:"nodes"`
+	Links []SankeyLink `json:"links"`
+}
+
+// BuildSankeyData converts the topology into Sankey nodes and links.
+// Index 0 is always the Internet root, linked to every Internet Gateway.
+func BuildSankeyData(g *graph.Graph) SankeyData {
+	g.Mu.RLock()
+	defer g.Mu.RUnlock()
+
+	nodes := make([]SankeyNode, 0)
+	links := make([]SankeyLink, 0)
+	idToIndex := make(map[string]int)
+
+	// 1. Add Internet root node.
+	nodes = append(nodes, SankeyNode{Name: "Internet [0.0.0.0/0]", Waste: false})
+	idToIndex["INTERNET"] = 0
+
+	// 2. Add graph nodes.
+	currentIndex := 1
+	for _, n := range g.Store.GetAllNodes() {
+		idToIndex[n.IDStr()] = currentIndex
+		name := extractID(n.IDStr())
+		nodes = append(nodes, SankeyNode{Name: name, Waste: n.IsWaste})
+		currentIndex++
+	}
+
+	// Create links.
+	allNodes := g.Store.GetAllNodes()
+	for _, sourceNode := range allNodes {
+		edges := g.Store.GetEdges(sourceNode.Index)
+
+		srcIdx, ok1 := idToIndex[sourceNode.IDStr()]
+		if !ok1 {
+			continue
+		}
+
+		for _, e := range edges {
+			targetNode := g.Store.GetNode(e.TargetID)
+			if targetNode == nil {
+				continue
+			}
+			tgtIdx, ok2 := idToIndex[targetNode.IDStr()]
+			if !ok2 {
+				continue
+			}
+
+			// Calculate link weight based on cost.
+
+			// Weight link by target cost.
+
+			val := 8.0
+			if targetNode != nil && targetNode.Cost > 0 {
+				val += math.Log10(targetNode.Cost+1) * 8
+			}
+
+			links = append(links, SankeyLink{
+				Source: srcIdx,
+				Target: tgtIdx,
+				Value:  val,
+			})
+		}
+	}
+
+	// 4. Link gateways to Internet.
+	// Find IGWs and link INTERNET -> IGW
+	for _, n := range g.Store.GetAllNodes() {
+		if n.TypeStr() == "AWS::EC2::InternetGateway" {
+			links = append(links, SankeyLink{
+				Source: 0, // Internet
+				Target: idToIndex[n.IDStr()],
+				Value:  10.0, // Fat pipe
+			})
+		}
+	}
+
+	return SankeyData{
+		Nodes: nodes,
+		Links: links,
+	}
+}
+
+// GenerateSankeyJSON writes the topology as D3-compatible Sankey JSON.
+func GenerateSankeyJSON(g *graph.Graph, path string) error {
+	data, err := json.MarshalIndent(BuildSankeyData(g), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
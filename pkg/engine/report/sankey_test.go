@@ -0,0 +1,47 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+func TestGenerateSankeyJSON_InternetLink(t *testing.T) {
+	g := graph.NewGraph()
+	igwID := "arn:aws:ec2:us-east-1:123:internet-gateway/igw-123"
+	g.AddNode(igwID, "AWS::EC2::InternetGateway", map[string]interface{}{})
+	g.AddNode("arn:aws:ec2:us-east-1:123:vpc/vpc-123", "AWS::EC2::VPC", map[string]interface{}{})
+	g.CloseAndWait()
+
+	path := filepath.Join(t.TempDir(), "topology_sankey.json")
+	if err := GenerateSankeyJSON(g, path); err != nil {
+		t.Fatalf("GenerateSankeyJSON failed: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var data SankeyData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	if len(data.Nodes) != 3 || data.Nodes[0].Name != "Internet [0.0.0.0/0]" {
+		t.Fatalf("Expected Internet root plus 2 nodes, got %+v", data.Nodes)
+	}
+
+	found := false
+	for _, l := range data.Links {
+		if l.Source == 0 && data.Nodes[l.Target].Name == "igw-123" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected Internet -> IGW link, got %+v", data.Links)
+	}
+}
@@ -3,8 +3,11 @@ package report
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/history"
 	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
 	"github.com/DrSkyle/cloudslash/v2/pkg/version"
 )
@@ -17,20 +20,90 @@ func GenerateExecutiveSummary(g *graph.Graph, path string, scanID string, accoun
 	}
 	defer f.Close()
 
+	// Take their own read locks.
+	hotspots := g.TopCostPaths(5)
+	compliance := ComplianceViolations(g)
+
 	g.Mu.RLock()
 	defer g.Mu.RUnlock()
 
 	totalWasteCost := 0.0
 	totalWasteCount := 0
+	wastedToDate := 0.0
+	now := time.Now()
 	var catCompute, catStorage, catNetwork, catDatabase float64
 
+	type stackTotals struct {
+		count int
+		cost  float64
+	}
+	cfnStacks := make(map[string]*stackTotals)
+	envs := make(map[string]*stackTotals)
+	envCaution := make(map[string]string)
+	var blocked []*graph.Node
+	var dnsEIPs []*graph.Node
+	var deprecated []*graph.Node
+	incomplete := 0
+	noDiskMetrics := 0
+	var unattributable []*graph.Node
+	unattributableCost := 0.0
+	var unmanagedCount int
+	var unmanagedWaste []*graph.Node
+	unmanagedWasteCost := 0.0
+
 	// Cost categories.
 
 	// Aggregate statistics.
 	for _, node := range g.Store.GetAllNodes() {
+		// Deprecations are migration risks, reported whether or not the resource is waste.
+		if _, ok := node.Properties["DeprecationRisk"].(string); ok {
+			deprecated = append(deprecated, node)
+		}
+		if errs, _ := node.Properties["_errors"].([]string); len(errs) > 0 {
+			incomplete++
+		}
+		if missing, _ := node.Properties["DiskMetricsMissing"].(bool); missing {
+			noDiskMetrics++
+		}
+		// Spend that cannot be charged back (--tag-from-cost-allocation).
+		if cost, ok := node.Properties["UnattributableCost"].(float64); ok {
+			unattributable = append(unattributable, node)
+			unattributableCost += cost
+		}
+		// Shadow infrastructure (--tfstate): counted whether or not the resource is waste.
+		if unmanaged, _ := node.Properties["Unmanaged"].(bool); unmanaged {
+			unmanagedCount++
+			if node.IsWaste {
+				unmanagedWaste = append(unmanagedWaste, node)
+				unmanagedWasteCost += node.Cost
+			}
+		}
 		if node.IsWaste {
 			totalWasteCount++
 			totalWasteCost += node.Cost
+			wastedToDate += WastedToDate(node, now)
+
+			if env, ok := node.Properties["Environment"].(string); ok {
+				if envs[env] == nil {
+					envs[env] = &stackTotals{}
+				}
+				envs[env].count++
+				envs[env].cost += node.Cost
+				envCaution[env], _ = node.Properties["RemediationCaution"].(string)
+			}
+			if stack, ok := node.Properties["CFNStack"].(string); ok {
+				if cfnStacks[stack] == nil {
+					cfnStacks[stack] = &stackTotals{}
+				}
+				cfnStacks[stack].count++
+				cfnStacks[stack].cost += node.Cost
+			}
+			if _, ok := node.Properties["RemediationBlocked"].(string); ok {
+				blocked = append(blocked, node)
+			}
+			if risk, _ := node.Properties["DNSRisk"].(string); risk == "SubdomainTakeover" {
+				dnsEIPs = append(dnsEIPs, node)
+			}
 
 			if isCompute(node.TypeStr()) {
 				catCompute += node.Cost
@@ -58,10 +131,23 @@ func GenerateExecutiveSummary(g *graph.Graph, path string, scanID string, accoun
 	// Executive Overview.
 	fmt.Fprintf(f, "## 1. Executive Overview\n\n")
 	fmt.Fprintf(f, "CloudSlash has completed a comprehensive analysis of the AWS infrastructure. The audit identified **%d unattached or idle resources** contributing to unnecessary operational overhead.\n\n", totalWasteCount)
+	if incomplete > 0 {
+		fmt.Fprintf(f, "> **Note:** %d resources had incomplete data (access denied on one or more describe calls). Findings on them were downgraded to review; grant the missing permissions for full confidence.\n\n", incomplete)
+	}
 
 	fmt.Fprintf(f, "### Key Financial Findings\n")
 	fmt.Fprintf(f, "- **Monthly Burn Rate:** $%.2f / mo\n", totalWasteCost)
-	fmt.Fprintf(f, "- **projected Annual Savings:** $%.2f / yr\n\n", annualSavings)
+	fmt.Fprintf(f, "- **projected Annual Savings:** $%.2f / yr\n", annualSavings)
+	if wastedToDate > 0 {
+		fmt.Fprintf(f, "- **Wasted to Date:** $%.2f since creation\n", wastedToDate)
+	}
+	if len(unattributable) > 0 {
+		fmt.Fprintf(f, "- **Unattributable Spend:** $%.2f / mo across %d resources missing cost-allocation tags\n", unattributableCost, len(unattributable))
+	}
+	fmt.Fprintf(f, "\n")
+	if wastedToDate > 0 {
+		fmt.Fprintf(f, "This account has burned **$%.2f** on now-idle resources.\n\n", wastedToDate)
+	}
 
 	fmt.Fprintf(f, "> ** Strategic Insight:** Immediate remediation of these resources will reduce the cloud billing baseline by approximately **$%.0f** annually without impacting active workloads.\n\n", annualSavings)
 
@@ -85,6 +171,168 @@ func GenerateExecutiveSummary(g *graph.Graph, path string, scanID string, accoun
 	}
 	fmt.Fprintf(f, "\n")
 
+	// Findings grouped by environment tag (--env-tag).
+	if len(envs) > 0 {
+		envNames := make([]string, 0, len(envs))
+		for name := range envs {
+			envNames = append(envNames, name)
+		}
+		sort.Slice(envNames, func(i, j int) bool { return envs[envNames[i]].cost > envs[envNames[j]].cost })
+
+		fmt.Fprintf(f, "### Findings by Environment\n\n")
+		fmt.Fprintf(f, "Production findings are held for manual review and left out of the cleanup scripts. Sandbox and development findings are the safest to clean up first.\n\n")
+		fmt.Fprintf(f, "| Environment | Resources | Monthly Cost | Remediation |\n")
+		fmt.Fprintf(f, "| :--- | :--- | :--- | :--- |\n")
+		for _, name := range envNames {
+			caution := envCaution[name]
+			if caution == "" {
+				caution = "standard"
+			}
+			fmt.Fprintf(f, "| %s | %d | $%.2f | %s |\n", name, envs[name].count, envs[name].cost, caution)
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
+	// CloudFormation-managed findings (--protect-cfn).
+	if len(cfnStacks) > 0 {
+		stackNames := make([]string, 0, len(cfnStacks))
+		for name := range cfnStacks {
+			stackNames = append(stackNames, name)
+		}
+		sort.Strings(stackNames)
+
+		fmt.Fprintf(f, "### CloudFormation-Managed Findings\n\n")
+		fmt.Fprintf(f, "These resources belong to CloudFormation stacks. Deleting them directly causes drift and the next stack update recreates them; remove them from the template instead.\n\n")
+		fmt.Fprintf(f, "| Stack | Resources | Monthly Cost |\n")
+		fmt.Fprintf(f, "| :--- | :--- | :--- |\n")
+		for _, name := range stackNames {
+			fmt.Fprintf(f, "| `%s` | %d | $%.2f |\n", name, cfnStacks[name].count, cfnStacks[name].cost)
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
+	// Findings a guardrail would prevent deleting (--check-policy).
+	if len(blocked) > 0 {
+		sort.Slice(blocked, func(i, j int) bool { return blocked[i].IDStr() < blocked[j].IDStr() })
+
+		fmt.Fprintf(f, "### Blocked by Policy\n\n")
+		fmt.Fprintf(f, "Policy simulation shows these deletions would be denied by a Service Control Policy or permissions boundary. They are excluded from the cleanup scripts; raise them with the account owners instead.\n\n")
+		fmt.Fprintf(f, "| Resource | Type | Blocked By | Monthly Cost |\n")
+		fmt.Fprintf(f, "| :--- | :--- | :--- | :--- |\n")
+		for _, node := range blocked {
+			by, _ := node.Properties["RemediationBlocked"].(string)
+			fmt.Fprintf(f, "| `%s` | %s | %s | $%.2f |\n", extractID(node.IDStr()), node.TypeStr(), by, node.Cost)
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
+	// Unused addresses that DNS still resolves to.
+	if len(dnsEIPs) > 0 {
+		sort.Slice(dnsEIPs, func(i, j int) bool { return dnsEIPs[i].IDStr() < dnsEIPs[j].IDStr() })
+
+		fmt.Fprintf(f, "### Elastic IPs Still in DNS\n\n")
+		fmt.Fprintf(f, "These addresses are unused but Route53 records still point at them. Releasing one first leaves a dangling record that the next holder of the IP can serve traffic on (subdomain takeover). Remove the records, then release.\n\n")
+		fmt.Fprintf(f, "| Elastic IP | DNS Record | Zone |\n")
+		fmt.Fprintf(f, "| :--- | :--- | :--- |\n")
+		for _, node := range dnsEIPs {
+			record, _ := node.Properties["DNSRecord"].(string)
+			zone, _ := node.Properties["DNSZone"].(string)
+			fmt.Fprintf(f, "| `%s` | %s | %s |\n", extractID(node.IDStr()), record, zone)
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
+	// Resources on deprecated or sunsetting services.
+	if len(deprecated) > 0 {
+		sort.Slice(deprecated, func(i, j int) bool {
+			ci, _ := deprecated[i].Properties["DeprecationCost"].(float64)
+			cj, _ := deprecated[j].Properties["DeprecationCost"].(float64)
+			if ci != cj {
+				return ci > cj
+			}
+			return deprecated[i].IDStr() < deprecated[j].IDStr()
+		})
+
+		fmt.Fprintf(f, "### Deprecation Risk\n\n")
+		fmt.Fprintf(f, "These resources run on deprecated or sunsetting AWS services. They are not waste, but they will need migrating, and some already incur extended-support charges.\n\n")
+		fmt.Fprintf(f, "| Resource | Type | Service | Deadline | Migration | Cost at Stake |\n")
+		fmt.Fprintf(f, "| :--- | :--- | :--- | :--- | :--- | :--- |\n")
+		for _, node := range deprecated {
+			service, _ := node.Properties["DeprecationRisk"].(string)
+			migration, _ := node.Properties["DeprecationMigration"].(string)
+			deadline, _ := node.Properties["DeprecationDeadline"].(string)
+			if deadline == "" {
+				deadline = "-"
+			}
+			cost, _ := node.Properties["DeprecationCost"].(float64)
+			fmt.Fprintf(f, "| `%s` | %s | %s | %s | %s | $%.2f/mo |\n", extractID(node.IDStr()), node.TypeStr(), service, deadline, migration, cost)
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
+	// Spend missing activated cost-allocation tags.
+	if len(unattributable) > 0 {
+		sort.Slice(unattributable, func(i, j int) bool {
+			ci, _ := unattributable[i].Properties["UnattributableCost"].(float64)
+			cj, _ := unattributable[j].Properties["UnattributableCost"].(float64)
+			if ci != cj {
+				return ci > cj
+			}
+			return unattributable[i].IDStr() < unattributable[j].IDStr()
+		})
+
+		fmt.Fprintf(f, "### Unattributable Spend\n\n")
+		fmt.Fprintf(f, "> **$%.2f / mo** ($%.2f / yr) cannot be charged back because these resources lack one or more activated cost-allocation tags. Cost Allocation reports will show it as untagged.\n\n", unattributableCost, unattributableCost*12)
+		fmt.Fprintf(f, "| Resource | Type | Missing Tags | Monthly Cost |\n")
+		fmt.Fprintf(f, "| :--- | :--- | :--- | :--- |\n")
+		for _, node := range unattributable {
+			missing, _ := node.Properties["MissingCostTags"].([]string)
+			cost, _ := node.Properties["UnattributableCost"].(float64)
+			fmt.Fprintf(f, "| `%s` | %s | %s | $%.2f |\n", extractID(node.IDStr()), node.TypeStr(), strings.Join(missing, ", "), cost)
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
+	// Volumes that could not be checked for over-allocation.
+	if noDiskMetrics > 0 {
+		fmt.Fprintf(f, "> **Tip:** %d large in-use EBS volumes could not be checked for over-allocation because their instances do not publish `disk_used_percent`. Install the CloudWatch agent with disk metrics to size them.\n\n", noDiskMetrics)
+	}
+
+	// Resources outside Terraform.
+	if unmanagedCount > 0 {
+		sort.Slice(unmanagedWaste, func(i, j int) bool {
+			if unmanagedWaste[i].Cost != unmanagedWaste[j].Cost {
+				return unmanagedWaste[i].Cost > unmanagedWaste[j].Cost
+			}
+			return unmanagedWaste[i].IDStr() < unmanagedWaste[j].IDStr()
+		})
+
+		fmt.Fprintf(f, "### Unmanaged Infrastructure\n\n")
+		fmt.Fprintf(f, "**%d resources** are not in the Terraform state (created by hand or by other tooling). **%d** of them are also waste, costing **$%.2f/mo**; with no IaC owner they are easy to miss and safe to remove without touching code.\n\n", unmanagedCount, len(unmanagedWaste), unmanagedWasteCost)
+		if len(unmanagedWaste) > 0 {
+			fmt.Fprintf(f, "| Resource | Type | Monthly Cost |\n")
+			fmt.Fprintf(f, "| :--- | :--- | :--- |\n")
+			for _, node := range unmanagedWaste {
+				fmt.Fprintf(f, "| `%s` | %s | $%.2f |\n", extractID(node.IDStr()), node.TypeStr(), node.Cost)
+			}
+			fmt.Fprintf(f, "\n")
+		}
+	}
+
+	// Cost Hotspots.
+	if len(hotspots) > 0 {
+		fmt.Fprintf(f, "### Cost Hotspots\n\n")
+		fmt.Fprintf(f, "The most expensive dependency chains in the topology. Start investigations here.\n\n")
+		for i, p := range hotspots {
+			hops := make([]string, len(p.Nodes))
+			for j, id := range p.Nodes {
+				hops[j] = extractID(id)
+			}
+			fmt.Fprintf(f, "%d. `%s` = **$%.2f/mo**\n", i+1, strings.Join(hops, " → "), p.Cost)
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
 	// Remediation Strategy.
 	fmt.Fprintf(f, "## 3. Recommended Remediation Strategy\n\n")
 	fmt.Fprintf(f, "> [!CAUTION]\n")
@@ -120,6 +368,18 @@ func GenerateExecutiveSummary(g *graph.Graph, path string, scanID string, accoun
 	fmt.Fprintf(f, "bash cloudslash-out/undo_cleanup.sh\n")
 	fmt.Fprintf(f, "```\n\n")
 
+	// Compliance violations carry no cost and stay out of the totals above.
+	if len(compliance) > 0 {
+		fmt.Fprintf(f, "## 4. Compliance Violations\n\n")
+		fmt.Fprintf(f, "%d resources fail compliance checks. They are not waste and are not included in the figures above.\n\n", len(compliance))
+		fmt.Fprintf(f, "| Resource | Type | Region | Violation |\n")
+		fmt.Fprintf(f, "| :--- | :--- | :--- | :--- |\n")
+		for _, c := range compliance {
+			fmt.Fprintf(f, "| `%s` | %s | %s | %s |\n", extractID(c.ResourceID), c.Type, c.Region, c.Reason)
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
 	fmt.Fprintf(f, "---\n")
 	fmt.Fprintf(f, "*Report generated by CloudSlash Audit Engine v%s.*\n", version.Current)
 
@@ -132,6 +392,106 @@ type Summary struct {
 	TotalScanned int
 	TotalWaste   int
 	TotalSavings float64
+	Findings     []ExportItem   // In Findings order.
+	Diff         *history.Delta // Change since the previous scan; set with --diff.
+}
+
+// TopByCost returns the n most expensive findings. Findings is grouped by
+// caution under --env-tag, so its head is not always the costliest.
+func (s Summary) TopByCost(n int) []ExportItem {
+	items := append([]ExportItem(nil), s.Findings...)
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].MonthlyCost > items[j].MonthlyCost
+	})
+	if len(items) > n {
+		items = items[:n]
+	}
+	return items
+}
+
+// Summarize counts scanned resources and waste totals.
+func Summarize(g *graph.Graph, region string) Summary {
+	findings := Findings(g)
+
+	g.Mu.RLock()
+	defer g.Mu.RUnlock()
+
+	nodes := g.Store.GetAllNodes()
+	summary := Summary{
+		Region:       region,
+		TotalScanned: len(nodes),
+		Findings:     findings,
+	}
+	for _, n := range nodes {
+		if n.IsWaste {
+			summary.TotalWaste++
+			summary.TotalSavings += n.Cost
+		}
+	}
+	return summary
+}
+
+// Services breaks the findings down by service, most expensive first.
+func (s Summary) Services() []CostBreakdown {
+	services := make(map[string]*CostBreakdown)
+	for _, item := range s.Findings {
+		addBreakdown(services, serviceName(item.Type), item.MonthlyCost)
+	}
+	return sortedBreakdown(services)
+}
+
+// WasteGate fails a CI build on too much waste (scan --fail-on-waste and
+// --fail-on-count). A zero threshold is not checked.
+type WasteGate struct {
+	MaxMonthlyCost float64
+	MaxCount       int
+}
+
+// Breaches describes each threshold the summary exceeds; none means it passes.
+func (g WasteGate) Breaches(s Summary) []string {
+	var breaches []string
+	if g.MaxMonthlyCost > 0 && s.TotalSavings > g.MaxMonthlyCost {
+		breaches = append(breaches, fmt.Sprintf("monthly waste of $%.2f exceeds --fail-on-waste $%.2f", s.TotalSavings, g.MaxMonthlyCost))
+	}
+	if g.MaxCount > 0 && s.TotalWaste > g.MaxCount {
+		breaches = append(breaches, fmt.Sprintf("%d wasteful resources exceed --fail-on-count %d", s.TotalWaste, g.MaxCount))
+	}
+	return breaches
+}
+
+// ScanSummary is the machine-readable summary printed at the end of a scan
+// (scan --summary-format json).
+type ScanSummary struct {
+	Region         string           `json:"region"`
+	TotalScanned   int              `json:"total_scanned"`
+	TotalWaste     int              `json:"total_waste"`
+	MonthlySavings float64          `json:"monthly_savings"`
+	Services       []ServiceSummary `json:"services"`
+	Artifacts      []string         `json:"artifacts"`
+}
+
+// ServiceSummary is one service's share of the findings.
+type ServiceSummary struct {
+	Service     string  `json:"service"`
+	Findings    int     `json:"findings"`
+	MonthlyCost float64 `json:"monthly_cost"`
+}
+
+// NewScanSummary builds the machine-readable summary of s and the artifacts
+// the scan wrote. Lists are empty rather than null.
+func NewScanSummary(s Summary, artifacts []string) ScanSummary {
+	out := ScanSummary{
+		Region:         s.Region,
+		TotalScanned:   s.TotalScanned,
+		TotalWaste:     s.TotalWaste,
+		MonthlySavings: s.TotalSavings,
+		Services:       []ServiceSummary{},
+		Artifacts:      append([]string{}, artifacts...),
+	}
+	for _, b := range s.Services() {
+		out.Services = append(out.Services, ServiceSummary{Service: b.Name, Findings: b.Count, MonthlyCost: b.Monthly})
+	}
+	return out
 }
 
 func isCompute(t string) bool {
@@ -0,0 +1,241 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"github.com/DrSkyle/cloudslash/v2/pkg/version"
+)
+
+// SummaryData is the model exposed to summary templates.
+type SummaryData struct {
+	ScanID      string
+	AccountID   string
+	GeneratedAt time.Time
+	Version     string
+
+	WasteCount    int
+	MonthlyWaste  float64
+	AnnualSavings float64
+	WastedToDate  float64 // Already spent on current findings since creation.
+
+	TopFindings []ExportItem // Ten most expensive.
+	Services    []CostBreakdown
+	Accounts    []CostBreakdown
+	Hotspots    []graph.Path
+
+	Compliance []ComplianceItem // Not counted in the waste figures.
+}
+
+// CostBreakdown aggregates waste under one label (service or account).
+type CostBreakdown struct {
+	Name    string
+	Count   int
+	Monthly float64
+}
+
+// Annual projects the monthly figure over a year.
+func (b CostBreakdown) Annual() float64 { return b.Monthly * 12 }
+
+// builtinSummaryTemplates are selectable by name via --summary-template.
+var builtinSummaryTemplates = map[string]string{
+	"executive": `# Cloud Waste Summary — {{.GeneratedAt.Format "Jan 02, 2006"}}
+
+CloudSlash found **{{.WasteCount}} idle or unused resources** costing **{{money .MonthlyWaste}}/month**.
+Removing them saves approximately **{{money .AnnualSavings}} per year** without affecting active workloads.
+{{- if .WastedToDate}}
+This account has already burned **{{money .WastedToDate}}** on these now-idle resources.
+{{- end}}
+{{- with .Services}}
+
+The largest share is {{(index . 0).Name}} at {{money (index . 0).Monthly}}/month.
+{{- end}}
+{{- with .Compliance}}
+
+Separately, **{{len .}} resources** have compliance violations (see the technical report). They are not counted as waste.
+{{- end}}
+`,
+	"technical": `# CloudSlash Technical Findings
+
+| **Scan Ref** | ` + "`{{.ScanID}}`" + ` |
+| :--- | :--- |
+| **Account ID** | ` + "`{{.AccountID}}`" + ` |
+| **Date** | {{.GeneratedAt.Format "2006-01-02 15:04 MST"}} |
+
+**{{.WasteCount}} findings**, {{money .MonthlyWaste}}/mo ({{money .AnnualSavings}}/yr){{if .WastedToDate}}, {{money .WastedToDate}} wasted to date{{end}}.
+
+## By Service
+
+| Service | Findings | Monthly | Annual |
+| :--- | ---: | ---: | ---: |
+{{- range .Services}}
+| {{.Name}} | {{.Count}} | {{money .Monthly}} | {{money .Annual}} |
+{{- end}}
+
+## By Account
+
+| Account | Findings | Monthly |
+| :--- | ---: | ---: |
+{{- range .Accounts}}
+| ` + "`{{.Name}}`" + ` | {{.Count}} | {{money .Monthly}} |
+{{- end}}
+
+## Top Findings
+
+| Resource | Type | Action | Risk | Monthly | Detail |
+| :--- | :--- | :--- | ---: | ---: | :--- |
+{{- range .TopFindings}}
+| ` + "`{{.ResourceID}}`" + ` | {{.Type}} | {{.Action}} | {{.RiskScore}} | {{money .MonthlyCost}} | {{.AuditDetail}} |
+{{- end}}
+{{- with .Hotspots}}
+
+## Cost Hotspots
+{{range $i, $p := .}}
+{{inc $i}}. ` + "`{{path $p.Nodes}}`" + ` = {{money $p.Cost}}/mo
+{{- end}}
+{{- end}}
+{{- with .Compliance}}
+
+## Compliance Violations
+
+Not included in the waste figures above.
+
+| Resource | Type | Region | Violation |
+| :--- | :--- | :--- | :--- |
+{{- range .}}
+| ` + "`{{.ResourceID}}`" + ` | {{.Type}} | {{.Region}} | {{.Reason}} |
+{{- end}}
+{{- end}}
+
+---
+*Generated by CloudSlash v{{.Version}}.*
+`,
+}
+
+// BuildSummaryData collects totals and breakdowns for templating.
+func BuildSummaryData(g *graph.Graph, scanID, accountID string) SummaryData {
+	data := SummaryData{
+		ScanID:      scanID,
+		AccountID:   accountID,
+		GeneratedAt: time.Now(),
+		Version:     version.Current,
+		Hotspots:    g.TopCostPaths(5),
+		Compliance:  ComplianceViolations(g),
+	}
+
+	findings := Findings(g)
+	services := make(map[string]*CostBreakdown)
+	accounts := make(map[string]*CostBreakdown)
+
+	for _, item := range findings {
+		data.WasteCount++
+		data.MonthlyWaste += item.MonthlyCost
+		data.WastedToDate += item.WastedToDate
+
+		addBreakdown(services, serviceName(item.Type), item.MonthlyCost)
+		account := item.AccountID
+		if account == "" {
+			account = accountID
+		}
+		addBreakdown(accounts, account, item.MonthlyCost)
+	}
+	data.AnnualSavings = data.MonthlyWaste * 12
+
+	if len(findings) > 10 {
+		findings = findings[:10]
+	}
+	data.TopFindings = findings
+	data.Services = sortedBreakdown(services)
+	data.Accounts = sortedBreakdown(accounts)
+	return data
+}
+
+// WriteSummary renders the executive summary.
+// An empty template keeps the default report; otherwise it names a built-in
+// template ("executive", "technical") or a Go template file.
+func WriteSummary(g *graph.Graph, path, scanID, accountID, tmpl string) error {
+	if tmpl == "" {
+		return GenerateExecutiveSummary(g, path, scanID, accountID)
+	}
+
+	text, ok := builtinSummaryTemplates[tmpl]
+	if !ok {
+		raw, err := os.ReadFile(tmpl)
+		if err != nil {
+			return fmt.Errorf("summary template %q is neither built-in nor a readable file: %w", tmpl, err)
+		}
+		text = string(raw)
+	}
+
+	t, err := template.New("summary").Funcs(summaryFuncs).Parse(text)
+	if err != nil {
+		return fmt.Errorf("failed to parse summary template: %w", err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return t.Execute(f, BuildSummaryData(g, scanID, accountID))
+}
+
+var summaryFuncs = template.FuncMap{
+	"money": func(v float64) string { return fmt.Sprintf("$%.2f", v) },
+	"inc":   func(i int) int { return i + 1 },
+	"path": func(ids []string) string {
+		hops := make([]string, len(ids))
+		for i, id := range ids {
+			hops[i] = extractID(id)
+		}
+		return strings.Join(hops, " → ")
+	},
+}
+
+func addBreakdown(m map[string]*CostBreakdown, name string, cost float64) {
+	if m[name] == nil {
+		m[name] = &CostBreakdown{Name: name}
+	}
+	m[name].Count++
+	m[name].Monthly += cost
+}
+
+func sortedBreakdown(m map[string]*CostBreakdown) []CostBreakdown {
+	out := make([]CostBreakdown, 0, len(m))
+	for _, b := range m {
+		out = append(out, *b)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Monthly != out[j].Monthly {
+			return out[i].Monthly > out[j].Monthly
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// serviceName turns "AWS::EC2::Volume" into "EC2" and "aws_lambda_function" into "lambda".
+func serviceName(resourceType string) string {
+	if parts := strings.Split(resourceType, "::"); len(parts) >= 2 {
+		return parts[1]
+	}
+	if parts := strings.Split(resourceType, "_"); len(parts) >= 2 {
+		return parts[1]
+	}
+	return resourceType
+}
+
+// accountFromARN falls back when the ARN carries no real account ID.
+func accountFromARN(id, fallback string) string {
+	parts := strings.Split(id, ":")
+	if len(parts) > 4 && parts[4] != "" && parts[4] != "account" {
+		return parts[4]
+	}
+	return fallback
+}
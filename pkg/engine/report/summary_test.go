@@ -0,0 +1,280 @@
+package report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+func TestExecutiveSummary_CostHotspots(t *testing.T) {
+	g := graph.NewGraph()
+	vpc := "arn:aws:ec2:us-east-1:123:vpc/vpc-123"
+	nat := "arn:aws:ec2:us-east-1:123:natgateway/nat-123"
+	g.AddNode(vpc, "AWS::EC2::VPC", map[string]interface{}{})
+	g.AddNode(nat, "AWS::EC2::NatGateway", map[string]interface{}{})
+	g.AddTypedEdge(vpc, nat, graph.EdgeTypeContains, 100)
+	g.CloseAndWait()
+
+	g.MarkWaste(nat, 80)
+	g.GetNode(nat).Cost = 32.4
+
+	path := filepath.Join(t.TempDir(), "executive_summary.md")
+	if err := GenerateExecutiveSummary(g, path, "scan-1", "123"); err != nil {
+		t.Fatalf("GenerateExecutiveSummary failed: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(raw)
+	if !strings.Contains(out, "### Cost Hotspots") {
+		t.Fatal("Expected a Cost Hotspots section")
+	}
+	if !strings.Contains(out, "`vpc-123 → nat-123` = **$32.40/mo**") {
+		t.Errorf("Expected the VPC → NAT path in hotspots, got:\n%s", out)
+	}
+}
+
+func TestWriteSummary_Templates(t *testing.T) {
+	g := graph.NewGraph()
+	vol := "arn:aws:ec2:us-east-1:111122223333:volume/vol-1"
+	g.AddNode(vol, "AWS::EC2::Volume", map[string]interface{}{})
+	g.CloseAndWait()
+	g.MarkWaste(vol, 70)
+	g.GetNode(vol).Cost = 8
+
+	dir := t.TempDir()
+
+	// Built-in by name.
+	path := filepath.Join(dir, "technical.md")
+	if err := WriteSummary(g, path, "scan-1", "fallback", "technical"); err != nil {
+		t.Fatalf("technical template failed: %v", err)
+	}
+	raw, _ := os.ReadFile(path)
+	for _, want := range []string{"| EC2 | 1 | $8.00 | $96.00 |", "`111122223333`", "`" + vol + "`"} {
+		if !strings.Contains(string(raw), want) {
+			t.Errorf("technical summary missing %q:\n%s", want, raw)
+		}
+	}
+
+	path = filepath.Join(dir, "executive.md")
+	if err := WriteSummary(g, path, "scan-1", "fallback", "executive"); err != nil {
+		t.Fatalf("executive template failed: %v", err)
+	}
+	raw, _ = os.ReadFile(path)
+	if !strings.Contains(string(raw), "**1 idle or unused resources** costing **$8.00/month**") {
+		t.Errorf("Unexpected executive summary:\n%s", raw)
+	}
+
+	// Custom file.
+	tmpl := filepath.Join(dir, "cfo.tmpl")
+	os.WriteFile(tmpl, []byte("{{.WasteCount}} items, {{money .AnnualSavings}}/yr"), 0644)
+	path = filepath.Join(dir, "cfo.md")
+	if err := WriteSummary(g, path, "scan-1", "fallback", tmpl); err != nil {
+		t.Fatalf("custom template failed: %v", err)
+	}
+	raw, _ = os.ReadFile(path)
+	if string(raw) != "1 items, $96.00/yr" {
+		t.Errorf("Unexpected custom output: %q", raw)
+	}
+
+	if err := WriteSummary(g, filepath.Join(dir, "x.md"), "scan-1", "fallback", "no-such-template"); err == nil {
+		t.Error("Expected an error for an unknown template")
+	}
+}
+
+func TestChargebackCSV(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("vol-a", "AWS::EC2::Volume", map[string]interface{}{"Tags": map[string]string{"CostCenter": "CC-100"}})
+	g.AddNode("vol-b", "AWS::EC2::Volume", map[string]interface{}{"Tags": map[string]string{"CostCenter": "CC-100"}})
+	g.AddNode("vol-c", "AWS::EC2::Volume", map[string]interface{}{"Tags": map[string]string{"Name": "scratch"}})
+	g.AddNode("vol-d", "AWS::EC2::Volume", map[string]interface{}{})
+	g.CloseAndWait()
+
+	for id, cost := range map[string]float64{"vol-a": 10, "vol-b": 5, "vol-c": 40, "vol-d": 1} {
+		g.MarkWaste(id, 80)
+		g.GetNode(id).Cost = cost
+	}
+
+	rows := Chargeback(g, "CostCenter")
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 cost centers, got %+v", rows)
+	}
+	if rows[0].CostCenter != UntaggedCostCenter || rows[0].MonthlyWaste != 41 || rows[0].ResourceCount != 2 {
+		t.Errorf("Expected Untagged bucket first with $41 over 2 resources, got %+v", rows[0])
+	}
+
+	path := filepath.Join(t.TempDir(), "chargeback.csv")
+	if err := GenerateChargebackCSV(rows, path); err != nil {
+		t.Fatalf("GenerateChargebackCSV failed: %v", err)
+	}
+	raw, _ := os.ReadFile(path)
+	want := "cost_center,monthly_waste,annual_projection,resource_count\nUntagged,41.00,492.00,2\nCC-100,15.00,180.00,2\n"
+	if string(raw) != want {
+		t.Errorf("Unexpected CSV:\n%s", raw)
+	}
+}
+
+func TestWastedToDate(t *testing.T) {
+	g := graph.NewGraph()
+	launched := time.Now().Add(-730 * 3 * time.Hour) // Three months ago.
+	g.AddNode("i-old", "AWS::EC2::Instance", map[string]interface{}{"LaunchTime": &launched})
+	g.AddNode("ami-old", "AWS::EC2::AMI", map[string]interface{}{"CreationDate": launched.UTC().Format("2006-01-02T15:04:05.000Z")})
+	g.AddNode("vol-unknown", "AWS::EC2::Volume", map[string]interface{}{})
+	g.CloseAndWait()
+
+	for _, id := range []string{"i-old", "ami-old", "vol-unknown"} {
+		g.MarkWaste(id, 80)
+		g.GetNode(id).Cost = 10
+	}
+
+	byID := make(map[string]ExportItem)
+	for _, item := range Findings(g) {
+		byID[item.ResourceID] = item
+	}
+	for _, id := range []string{"i-old", "ami-old"} {
+		if got := byID[id].WastedToDate; got < 29.9 || got > 30.1 {
+			t.Errorf("Expected ~$30 wasted to date for %s, got %.2f", id, got)
+		}
+	}
+	if byID["vol-unknown"].WastedToDate != 0 {
+		t.Error("Expected no estimate without a creation time")
+	}
+
+	path := filepath.Join(t.TempDir(), "executive_summary.md")
+	if err := GenerateExecutiveSummary(g, path, "scan-1", "123"); err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := os.ReadFile(path)
+	if !strings.Contains(string(raw), "This account has burned **$60.00**") {
+		t.Errorf("Expected wasted-to-date total in summary, got:\n%s", raw)
+	}
+}
+
+func TestExecutiveSummary_UnattributableSpend(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:ec2:us-east-1:123:instance/i-1", "AWS::EC2::Instance", map[string]interface{}{
+		"MissingCostTags": []string{"team"}, "UnattributableCost": 61.32,
+	})
+	g.CloseAndWait()
+
+	path := filepath.Join(t.TempDir(), "executive_summary.md")
+	if err := GenerateExecutiveSummary(g, path, "scan-1", "123"); err != nil {
+		t.Fatalf("GenerateExecutiveSummary failed: %v", err)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(raw)
+	if !strings.Contains(out, "- **Unattributable Spend:** $61.32 / mo across 1 resources") {
+		t.Errorf("Expected unattributable spend in key findings, got:\n%s", out)
+	}
+	if !strings.Contains(out, "| `i-1` | AWS::EC2::Instance | team | $61.32 |") {
+		t.Errorf("Expected unattributable resource row, got:\n%s", out)
+	}
+}
+
+func TestNewScanSummary(t *testing.T) {
+	s := Summary{
+		Region:       "us-east-1",
+		TotalScanned: 40,
+		TotalWaste:   3,
+		TotalSavings: 65,
+		Findings: []ExportItem{
+			{ResourceID: "vol-1", Type: "AWS::EC2::Volume", MonthlyCost: 10},
+			{ResourceID: "db-1", Type: "AWS::RDS::DBInstance", MonthlyCost: 50},
+			{ResourceID: "vol-2", Type: "AWS::EC2::Volume", MonthlyCost: 5},
+		},
+	}
+	got := NewScanSummary(s, []string{"cloudslash-out/dashboard.html"})
+	if got.TotalScanned != 40 || got.TotalWaste != 3 || got.MonthlySavings != 65 || got.Region != "us-east-1" {
+		t.Errorf("totals not carried over: %+v", got)
+	}
+	want := []ServiceSummary{{"RDS", 1, 50}, {"EC2", 2, 15}}
+	if len(got.Services) != len(want) {
+		t.Fatalf("services = %+v, want %+v", got.Services, want)
+	}
+	for i := range want {
+		if got.Services[i] != want[i] {
+			t.Errorf("services[%d] = %+v, want %+v", i, got.Services[i], want[i])
+		}
+	}
+	if len(got.Artifacts) != 1 {
+		t.Errorf("artifacts = %v", got.Artifacts)
+	}
+
+	// No findings and no artifacts still encode as lists.
+	empty := NewScanSummary(Summary{}, nil)
+	if empty.Services == nil || empty.Artifacts == nil {
+		t.Error("empty summary should have empty lists, not nil")
+	}
+}
+
+func TestWasteGate(t *testing.T) {
+	s := Summary{TotalWaste: 12, TotalSavings: 812.4}
+
+	if got := (WasteGate{}).Breaches(s); len(got) != 0 {
+		t.Errorf("zero thresholds should not be checked, got %v", got)
+	}
+	if got := (WasteGate{MaxMonthlyCost: 812.4, MaxCount: 12}).Breaches(s); len(got) != 0 {
+		t.Errorf("totals equal to a threshold should pass, got %v", got)
+	}
+
+	got := (WasteGate{MaxMonthlyCost: 500, MaxCount: 10}).Breaches(s)
+	if len(got) != 2 {
+		t.Fatalf("breaches = %v, want both thresholds", got)
+	}
+	if !strings.Contains(got[0], "$812.40") || !strings.Contains(got[0], "--fail-on-waste $500.00") {
+		t.Errorf("cost breach = %q", got[0])
+	}
+	if !strings.Contains(got[1], "12 wasteful resources") || !strings.Contains(got[1], "--fail-on-count 10") {
+		t.Errorf("count breach = %q", got[1])
+	}
+}
+
+func TestComplianceReportedApartFromWaste(t *testing.T) {
+	g := graph.NewGraph()
+	waste := "arn:aws:ec2:us-east-1:123:volume/vol-idle"
+	plain := "arn:aws:ec2:us-east-1:123:volume/vol-plain"
+	g.AddNode(waste, "AWS::EC2::Volume", map[string]interface{}{"Region": "us-east-1"})
+	g.AddNode(plain, "AWS::EC2::Volume", map[string]interface{}{"Region": "us-east-1"})
+	g.CloseAndWait()
+	g.MarkWaste(waste, 70)
+	g.GetNode(waste).Cost = 8
+	g.MarkCompliance(plain, "EBS volume is not encrypted")
+
+	items := ComplianceViolations(g)
+	if len(items) != 1 || items[0].ResourceID != plain || items[0].Region != "us-east-1" {
+		t.Fatalf("Expected one violation on %s, got %+v", plain, items)
+	}
+	if findings := Findings(g); len(findings) != 1 || findings[0].ResourceID != waste {
+		t.Errorf("Expected only the waste volume as a finding, got %+v", findings)
+	}
+
+	dir := t.TempDir()
+	if err := GenerateDashboard(g, filepath.Join(dir, "dashboard.html"), ReportConfig{}); err != nil {
+		t.Fatalf("GenerateDashboard failed: %v", err)
+	}
+	raw, _ := os.ReadFile(filepath.Join(dir, "dashboard.html"))
+	if !strings.Contains(string(raw), `id="complianceTable"`) || !strings.Contains(string(raw), "EBS volume is not encrypted") {
+		t.Error("Expected a compliance table in the dashboard")
+	}
+	if !strings.Contains(string(raw), "$8.00") {
+		t.Error("Expected waste totals to exclude compliance findings")
+	}
+
+	path := filepath.Join(dir, "technical.md")
+	if err := WriteSummary(g, path, "scan-1", "123", "technical"); err != nil {
+		t.Fatalf("technical template failed: %v", err)
+	}
+	raw, _ = os.ReadFile(path)
+	if !strings.Contains(string(raw), "**1 findings**") || !strings.Contains(string(raw), "| `"+plain+"` | AWS::EC2::Volume | us-east-1 | EBS volume is not encrypted |") {
+		t.Errorf("Expected compliance listed apart from findings:\n%s", raw)
+	}
+}
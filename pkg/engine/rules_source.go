@@ -0,0 +1,75 @@
+package engine
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/history"
+)
+
+// remoteRulesTTL is how long a fetched s3:// rules file is served from cache.
+const remoteRulesTTL = 15 * time.Minute
+
+// fetchRemoteRules downloads an s3://bucket/key object. Replaced in tests.
+var fetchRemoteRules = func(ctx context.Context, s3URL string) ([]byte, error) {
+	b, err := history.NewS3Backend(s3URL)
+	if err != nil {
+		return nil, err
+	}
+	return b.ReadObject(ctx)
+}
+
+// ReadRules returns a policy rules file from a local path or an s3://bucket/key URL.
+// Remote rules are cached under cacheDir for a short TTL; if S3 is unreachable
+// the cached copy is used regardless of age, with a warning.
+func ReadRules(ctx context.Context, src, cacheDir string, logger *slog.Logger) ([]byte, error) {
+	if !strings.HasPrefix(src, "s3://") {
+		data, err := os.ReadFile(src)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rules file: %w", err)
+		}
+		return data, nil
+	}
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if cacheDir == "" {
+		cacheDir = ".cloudslash"
+	}
+	sum := sha256.Sum256([]byte(src))
+	cachePath := filepath.Join(cacheDir, "rules", hex.EncodeToString(sum[:8])+".yaml")
+
+	info, statErr := os.Stat(cachePath)
+	if statErr == nil && time.Since(info.ModTime()) < remoteRulesTTL {
+		if data, err := os.ReadFile(cachePath); err == nil {
+			logger.Debug("Using cached remote rules", "source", src, "path", cachePath)
+			return data, nil
+		}
+	}
+
+	data, err := fetchRemoteRules(ctx, src)
+	if err != nil {
+		if statErr == nil {
+			if cached, readErr := os.ReadFile(cachePath); readErr == nil {
+				logger.Warn("Remote rules unreachable, using cached copy", "source", src, "age", time.Since(info.ModTime()).Round(time.Second), "error", err)
+				return cached, nil
+			}
+		}
+		return nil, fmt.Errorf("failed to fetch rules from %s: %w", src, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+		logger.Warn("Failed to cache remote rules", "error", err)
+	} else if err := os.WriteFile(cachePath, data, 0644); err != nil {
+		logger.Warn("Failed to cache remote rules", "error", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,64 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReadRulesFromS3(t *testing.T) {
+	cacheDir := t.TempDir()
+	calls := 0
+	remote := []byte("rules: []\n")
+	var fetchErr error
+	orig := fetchRemoteRules
+	fetchRemoteRules = func(ctx context.Context, s3URL string) ([]byte, error) {
+		calls++
+		return remote, fetchErr
+	}
+	defer func() { fetchRemoteRules = orig }()
+
+	ctx := context.Background()
+	src := "s3://security-rules/cloudslash/rules.yaml"
+
+	// First read fetches; a second within the TTL is served from cache.
+	for i := 0; i < 2; i++ {
+		data, err := ReadRules(ctx, src, cacheDir, nil)
+		if err != nil || string(data) != string(remote) {
+			t.Fatalf("Read %d: got %q, %v", i, data, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 fetch within the TTL, got %d", calls)
+	}
+
+	// Expire the cache, then fail the fetch: the stale copy is used.
+	matches, _ := filepath.Glob(filepath.Join(cacheDir, "rules", "*.yaml"))
+	if len(matches) != 1 {
+		t.Fatalf("Expected 1 cached rules file, got %v", matches)
+	}
+	old := time.Now().Add(-2 * remoteRulesTTL)
+	os.Chtimes(matches[0], old, old)
+	fetchErr = errors.New("network unreachable")
+	remote = nil
+	data, err := ReadRules(ctx, src, cacheDir, nil)
+	if err != nil || string(data) != "rules: []\n" || calls != 2 {
+		t.Errorf("Expected stale cached rules after a failed fetch, got %q, %v (%d fetches)", data, err, calls)
+	}
+
+	// No cache: the fetch error is returned.
+	if _, err := ReadRules(ctx, "s3://other/rules.yaml", cacheDir, nil); err == nil {
+		t.Error("Expected an error without a cached copy")
+	}
+}
+
+func TestReadRulesLocalPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	os.WriteFile(path, []byte("rules: []\n"), 0644)
+	if data, err := ReadRules(context.Background(), path, "", nil); err != nil || string(data) != "rules: []\n" {
+		t.Errorf("Unexpected local read: %q, %v", data, err)
+	}
+}
@@ -17,6 +17,7 @@ import (
 // Registry manages a collection of scanners.
 type Registry struct {
 	scanners []Scanner
+	skipped  map[string]bool
 }
 
 // NewRegistry creates a new scanner registry.
@@ -26,8 +27,22 @@ func NewRegistry() *Registry {
 	}
 }
 
-// Register adds a scanner to the registry.
+// Skip leaves out scanners by Name() in later Register calls.
+func (r *Registry) Skip(names ...string) {
+	if r.skipped == nil {
+		r.skipped = make(map[string]bool, len(names))
+	}
+	for _, name := range names {
+		r.skipped[name] = true
+	}
+}
+
+// Register adds a scanner to the registry, unless it is skipped.
 func (r *Registry) Register(s Scanner) {
+	if r.skipped[s.Name()] {
+		slog.Debug("Scanner skipped", "name", s.Name())
+		return
+	}
 	r.scanners = append(r.scanners, s)
 }
 
@@ -0,0 +1,205 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// resourceScope is a resource family that --only and --skip select by name.
+type resourceScope struct {
+	Name     string
+	Scanners []string // Scanner task names (Scanner.Name).
+	// Heuristics that read these resources. Without them a heuristic would
+	// miss references and flag resources that are in use, so it is skipped
+	// whenever any scope listing it is left out.
+	Heuristics []string
+}
+
+// resourceScopes is the single mapping from friendly names to scanners and
+// the heuristics that depend on them. Heuristics listed under no scope always
+// run; they read only what they flag, or no scanned resources at all.
+var resourceScopes = []resourceScope{
+	{Name: "ec2-instances", Scanners: []string{"ScanInstances"},
+		Heuristics: []string{"UnderutilizedInstanceHeuristic", "CrossAZTransferHeuristic", "ComputeOptimizerHeuristic", "AgedAMIs", "EmptyVPCHeuristic"}},
+	{Name: "ec2-volumes", Scanners: []string{"ScanVolumes"},
+		Heuristics: []string{"UnattachedVolumeHeuristic", "OverallocatedVolumeHeuristic", "OverprovisionedGP3Heuristic", "EBSModernizer", "OrphanedSnapshotHeuristic", "SnapshotChildrenHeuristic", "KMSKeyHeuristic"}},
+	{Name: "snapshots", Scanners: []string{"ScanSnapshots"},
+		Heuristics: []string{"OrphanedSnapshotHeuristic", "SnapshotChildrenHeuristic", "SharingAuditHeuristic", "KMSKeyHeuristic"}},
+	{Name: "amis", Scanners: []string{"ScanImages"},
+		Heuristics: []string{"AgedAMIs", "OrphanedSnapshotHeuristic", "SharingAuditHeuristic"}},
+	{Name: "nat-gateways", Scanners: []string{"ScanNATGateways"},
+		Heuristics: []string{"NATInstanceHeuristic"}},
+	{Name: "elastic-ips", Scanners: []string{"ScanAddresses"},
+		Heuristics: []string{"ElasticIPHeuristic"}},
+	{Name: "load-balancers", Scanners: []string{"ScanALBs"},
+		Heuristics: []string{"EmptyVPCHeuristic", "IdleEKSClusterHeuristic"}},
+	{Name: "vpc-endpoints", Scanners: []string{"ScanEndpoints"},
+		Heuristics: []string{"EmptyVPCHeuristic"}},
+	{Name: "vpcs", Scanners: []string{"ScanVPCs", "ScanSubnets"},
+		Heuristics: []string{"EmptyVPCHeuristic", "NATInstanceHeuristic"}},
+	{Name: "s3", Scanners: []string{"ScanBuckets"},
+		Heuristics: []string{"S3MultipartHeuristic", "StorageOptimization", "CloudFrontHeuristic", "IdleCIHeuristic"}},
+	{Name: "cloudfront", Scanners: []string{"ScanCloudFrontDistributions"},
+		Heuristics: []string{"CloudFrontHeuristic"}},
+	{Name: "waf", Scanners: []string{"ScanWAFWebACLs"},
+		Heuristics: []string{"IdleWAFHeuristic"}},
+	{Name: "rds", Scanners: []string{"ScanRDSInstances"},
+		Heuristics: []string{"RDSHeuristic", "IdleReadReplicaHeuristic", "EmptyVPCHeuristic", "KMSKeyHeuristic"}},
+	{Name: "eks", Scanners: []string{"ScanEKSClusters"},
+		Heuristics: []string{"IdleEKSClusterHeuristic", "GhostNodeGroupHeuristic", "AbandonedFargateHeuristic"}},
+	{Name: "ecs", Scanners: []string{"ScanECSClusters"},
+		Heuristics: []string{"IdleClusterHeuristic", "EmptyServiceHeuristic"}},
+	{Name: "elasticache", Scanners: []string{"ScanElasticacheClusters"},
+		Heuristics: []string{"IdleElastiCacheHeuristic"}},
+	{Name: "redshift", Scanners: []string{"ScanRedshiftClusters"},
+		Heuristics: []string{"RedshiftHeuristic"}},
+	{Name: "kms", Scanners: []string{"ScanKMSKeys"},
+		Heuristics: []string{"KMSKeyHeuristic"}},
+	{Name: "dynamodb", Scanners: []string{"ScanDynamoDBTables"},
+		Heuristics: []string{"DynamoDBHeuristic", "KMSKeyHeuristic"}},
+	{Name: "lambda", Scanners: []string{"ScanLambdaFunctions"},
+		Heuristics: []string{"LambdaForensics"}},
+	{Name: "ci", Scanners: []string{"ScanCodeBuildProjects", "ScanCodePipelines"},
+		Heuristics: []string{"IdleCIHeuristic"}},
+	{Name: "efs", Scanners: []string{"ScanEFSFileSystems"},
+		Heuristics: []string{"EFSLifecycle", "IdleEFSHeuristic", "KMSKeyHeuristic"}},
+	{Name: "sagemaker", Scanners: []string{"ScanMLEndpoints"},
+		Heuristics: []string{"IdleMLEndpointHeuristic"}},
+	{Name: "dms", Scanners: []string{"ScanDMSReplicationInstances"},
+		Heuristics: []string{"IdleDMSHeuristic"}},
+	{Name: "opensearch", Scanners: []string{"ScanOpenSearchDomains"},
+		Heuristics: []string{"IdleOpenSearchHeuristic"}},
+	// Unused elastic IPs are only safe to release once DNS was checked.
+	{Name: "route53", Scanners: []string{"ScanRoute53Records"},
+		Heuristics: []string{"DanglingDNSHeuristic", "NetworkForensics", "ElasticIPHeuristic"}},
+	// Still requires --include-messaging.
+	{Name: "messaging", Scanners: []string{"ScanSQSQueues", "ScanSNSTopics"},
+		Heuristics: []string{"IdleMessagingHeuristic"}},
+	{Name: "log-groups", Scanners: []string{"ScanLogGroups"},
+		Heuristics: []string{"LogHoarders"}},
+	{Name: "ecr", Scanners: []string{"ScanRepositories"},
+		Heuristics: []string{"ECRJanitor"}},
+	{Name: "kubernetes", Scanners: []string{"K8sScanner"}},
+}
+
+// ResourceScopeNames lists the names --only and --skip accept, sorted.
+func ResourceScopeNames() []string {
+	names := make([]string, 0, len(resourceScopes))
+	for _, s := range resourceScopes {
+		names = append(names, s.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// scopeSelection is what --only and --skip leave out of a scan.
+type scopeSelection struct {
+	scanners   map[string]bool
+	heuristics map[string]bool
+}
+
+// skipsScanner reports whether the named scanner is left out. A nil
+// selection runs everything.
+func (s *scopeSelection) skipsScanner(name string) bool {
+	return s != nil && s.scanners[name]
+}
+
+// skippedScanners returns the left-out scanner names.
+func (s *scopeSelection) skippedScanners() []string {
+	if s == nil {
+		return nil
+	}
+	return sortedKeys(s.scanners)
+}
+
+// skippedHeuristics returns the heuristics that depend on a left-out scope.
+func (s *scopeSelection) skippedHeuristics() []string {
+	if s == nil {
+		return nil
+	}
+	return sortedKeys(s.heuristics)
+}
+
+// ValidateResourceScopes checks --only and --skip names without starting a scan.
+func ValidateResourceScopes(only, skip []string) error {
+	_, err := resolveResourceScopes(only, skip)
+	return err
+}
+
+// resolveResourceScopes turns --only and --skip into the scanners and
+// heuristics to leave out. Without either it returns nil, selecting
+// everything.
+func resolveResourceScopes(only, skip []string) (*scopeSelection, error) {
+	onlySet, err := scopeSet(only, "--only")
+	if err != nil {
+		return nil, err
+	}
+	skipSet, err := scopeSet(skip, "--skip")
+	if err != nil {
+		return nil, err
+	}
+	if len(onlySet) == 0 && len(skipSet) == 0 {
+		return nil, nil
+	}
+
+	sel := &scopeSelection{scanners: make(map[string]bool), heuristics: make(map[string]bool)}
+	for _, s := range resourceScopes {
+		if (len(onlySet) == 0 || onlySet[s.Name]) && !skipSet[s.Name] {
+			continue
+		}
+		for _, name := range s.Scanners {
+			sel.scanners[name] = true
+		}
+		for _, name := range s.Heuristics {
+			sel.heuristics[name] = true
+		}
+	}
+	if len(sel.scanners) == len(scopeScanners()) {
+		return nil, fmt.Errorf("--only and --skip leave no resource types to scan")
+	}
+	return sel, nil
+}
+
+// scopeSet parses comma-separated or repeated scope names.
+func scopeSet(values []string, flag string) (map[string]bool, error) {
+	known := make(map[string]bool, len(resourceScopes))
+	for _, s := range resourceScopes {
+		known[s.Name] = true
+	}
+
+	set := make(map[string]bool)
+	for _, v := range values {
+		for _, name := range strings.Split(v, ",") {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name == "" {
+				continue
+			}
+			if !known[name] {
+				return nil, fmt.Errorf("%s: unknown resource type %q (valid: %s)", flag, name, strings.Join(ResourceScopeNames(), ", "))
+			}
+			set[name] = true
+		}
+	}
+	return set, nil
+}
+
+// scopeScanners returns every scanner named in resourceScopes.
+func scopeScanners() map[string]bool {
+	all := make(map[string]bool)
+	for _, s := range resourceScopes {
+		for _, name := range s.Scanners {
+			all[name] = true
+		}
+	}
+	return all
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,103 @@
+package engine
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestResolveResourceScopes(t *testing.T) {
+	sel, err := resolveResourceScopes(nil, nil)
+	if err != nil || sel != nil {
+		t.Fatalf("Expected no selection without flags, got %v, %v", sel, err)
+	}
+	if sel.skipsScanner("ScanVolumes") || len(sel.skippedHeuristics()) > 0 {
+		t.Error("A nil selection should skip nothing")
+	}
+
+	// --only keeps the named scanners and the heuristics that need only them.
+	sel, err = resolveResourceScopes([]string{"ec2-volumes, Snapshots"}, nil)
+	if err != nil {
+		t.Fatalf("resolve --only: %v", err)
+	}
+	for _, name := range []string{"ScanVolumes", "ScanSnapshots"} {
+		if sel.skipsScanner(name) {
+			t.Errorf("--only ec2-volumes,snapshots should keep %s", name)
+		}
+	}
+	for _, name := range []string{"ScanInstances", "ScanBuckets", "ScanLogGroups", "K8sScanner"} {
+		if !sel.skipsScanner(name) {
+			t.Errorf("--only ec2-volumes,snapshots should skip %s", name)
+		}
+	}
+	skipped := sel.skippedHeuristics()
+	for _, name := range []string{"UnattachedVolumeHeuristic", "SnapshotChildrenHeuristic"} {
+		if slices.Contains(skipped, name) {
+			t.Errorf("%s needs only volumes and snapshots; it should run", name)
+		}
+	}
+	// Snapshots backing AMIs look orphaned without the AMI scan.
+	for _, name := range []string{"OrphanedSnapshotHeuristic", "KMSKeyHeuristic", "RDSHeuristic"} {
+		if !slices.Contains(skipped, name) {
+			t.Errorf("%s reads a type that was not scanned; it should be skipped", name)
+		}
+	}
+
+	// --skip leaves everything else, and the heuristics reading the skipped type.
+	sel, err = resolveResourceScopes(nil, []string{"route53"})
+	if err != nil {
+		t.Fatalf("resolve --skip: %v", err)
+	}
+	if !sel.skipsScanner("ScanRoute53Records") || sel.skipsScanner("ScanAddresses") {
+		t.Errorf("--skip route53 skipped %v", sel.skippedScanners())
+	}
+	if got := sel.skippedHeuristics(); !slices.Equal(got, []string{"DanglingDNSHeuristic", "ElasticIPHeuristic", "NetworkForensics"}) {
+		t.Errorf("--skip route53 skipped heuristics %v", got)
+	}
+
+	// Both flags: --skip removes from --only.
+	sel, err = resolveResourceScopes([]string{"ec2-volumes", "snapshots"}, []string{"snapshots"})
+	if err != nil {
+		t.Fatalf("resolve --only with --skip: %v", err)
+	}
+	if sel.skipsScanner("ScanVolumes") || !sel.skipsScanner("ScanSnapshots") {
+		t.Errorf("--only ec2-volumes,snapshots --skip snapshots skipped %v", sel.skippedScanners())
+	}
+}
+
+func TestResolveResourceScopesErrors(t *testing.T) {
+	_, err := resolveResourceScopes([]string{"ec2-volumes,volumes"}, nil)
+	if err == nil {
+		t.Fatal("Expected an error for an unknown name")
+	}
+	for _, want := range []string{"--only", `"volumes"`, "ec2-volumes", "snapshots"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Error %q should mention %s", err, want)
+		}
+	}
+
+	if _, err := resolveResourceScopes(nil, []string{"nope"}); err == nil || !strings.Contains(err.Error(), "--skip") {
+		t.Errorf("Expected a --skip error, got %v", err)
+	}
+
+	if _, err := resolveResourceScopes(nil, ResourceScopeNames()); err == nil {
+		t.Error("Expected an error when every resource type is skipped")
+	}
+}
+
+func TestResourceScopeNamesUnique(t *testing.T) {
+	seenScope := make(map[string]bool)
+	seenScanner := make(map[string]string)
+	for _, s := range resourceScopes {
+		if seenScope[s.Name] {
+			t.Errorf("Duplicate scope %s", s.Name)
+		}
+		seenScope[s.Name] = true
+		for _, name := range s.Scanners {
+			if other, ok := seenScanner[name]; ok {
+				t.Errorf("Scanner %s is in both %s and %s", name, other, s.Name)
+			}
+			seenScanner[name] = s.Name
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Package server serves the latest scan's findings, summary and topology as
+// a JSON API, rescanning on an interval.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/report"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// ScanFunc runs one complete scan and returns its graph. Each call must build
+// a fresh graph: the previous one is still being served while it runs.
+type ScanFunc func(ctx context.Context) (*graph.Graph, error)
+
+// scanResult is one completed scan.
+type scanResult struct {
+	graph     *graph.Graph
+	started   time.Time
+	completed time.Time
+}
+
+// Server runs Scan every Interval and serves the last completed scan. A scan
+// in progress never replaces the served graph until it finishes, so readers
+// always see a whole scan.
+type Server struct {
+	Scan     ScanFunc
+	Interval time.Duration
+	Region   string // Reported in /api/summary.
+	Logger   *slog.Logger
+
+	mu       sync.RWMutex
+	latest   *scanResult
+	scanning bool
+	lastErr  error
+}
+
+// New returns a server scanning every interval.
+func New(scan ScanFunc, interval time.Duration, region string, logger *slog.Logger) *Server {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return &Server{Scan: scan, Interval: interval, Region: region, Logger: logger}
+}
+
+// Handler routes the API.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", s.handleHealth)
+	mux.HandleFunc("GET /api/findings", s.withGraph(func(g *graph.Graph, _ *scanResult) interface{} {
+		if items := report.Findings(g); items != nil {
+			return items
+		}
+		return []report.ExportItem{}
+	}))
+	mux.HandleFunc("GET /api/summary", s.withGraph(func(g *graph.Graph, r *scanResult) interface{} {
+		return newSummary(report.Summarize(g, s.Region), r)
+	}))
+	mux.HandleFunc("GET /api/graph", s.withGraph(func(g *graph.Graph, _ *scanResult) interface{} {
+		return report.BuildSankeyData(g)
+	}))
+	return mux
+}
+
+// ListenAndServe scans immediately and then every Interval, serving on addr
+// until ctx is cancelled.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	srv := &http.Server{Addr: addr, Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+
+	go s.loop(ctx)
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	s.Logger.Info("Serving findings", "addr", addr, "interval", s.Interval)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *Server) loop(ctx context.Context) {
+	s.RunOnce(ctx)
+	if s.Interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce runs one scan and, if it succeeds, swaps it in as the served scan.
+// A failed scan leaves the previous one in place.
+func (s *Server) RunOnce(ctx context.Context) {
+	s.mu.Lock()
+	if s.scanning {
+		s.mu.Unlock()
+		return
+	}
+	s.scanning = true
+	s.mu.Unlock()
+
+	started := time.Now()
+	g, err := s.Scan(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scanning = false
+	s.lastErr = err
+	if err != nil || g == nil {
+		s.Logger.Error("Scan failed; serving the previous scan", "error", err)
+		return
+	}
+	s.latest = &scanResult{graph: g, started: started, completed: time.Now()}
+	s.Logger.Info("Scan complete", "duration", time.Since(started).Round(time.Second))
+}
+
+// current returns the served scan, or nil before the first scan completes.
+func (s *Server) current() *scanResult {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.latest
+}
+
+// withGraph serves build's payload for the current scan, or 503 until one exists.
+// The report builders hold the graph's read lock while they walk it.
+func (s *Server) withGraph(build func(*graph.Graph, *scanResult) interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res := s.current()
+		if res == nil {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "first scan in progress"})
+			return
+		}
+		writeJSON(w, http.StatusOK, build(res.graph, res))
+	}
+}
+
+type health struct {
+	Status        string     `json:"status"`
+	Scanning      bool       `json:"scanning"`
+	LastScan      *time.Time `json:"last_scan,omitempty"`
+	LastScanError string     `json:"last_scan_error,omitempty"`
+}
+
+// handleHealth reports liveness; status is "starting" until a scan completes.
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	h := health{Status: "ok", Scanning: s.scanning}
+	if s.latest == nil {
+		h.Status = "starting"
+	} else {
+		h.LastScan = &s.latest.completed
+	}
+	if s.lastErr != nil {
+		h.LastScanError = s.lastErr.Error()
+	}
+	s.mu.RUnlock()
+	writeJSON(w, http.StatusOK, h)
+}
+
+// summary is the /api/summary payload: the totals without the findings list.
+type summary struct {
+	Region          string    `json:"region"`
+	TotalScanned    int       `json:"total_scanned"`
+	TotalWaste      int       `json:"total_waste"`
+	MonthlySavings  float64   `json:"monthly_savings"`
+	ScanStarted     time.Time `json:"scan_started"`
+	ScanCompleted   time.Time `json:"scan_completed"`
+	DurationSeconds float64   `json:"duration_seconds"`
+}
+
+func newSummary(sum report.Summary, r *scanResult) summary {
+	return summary{
+		Region:          sum.Region,
+		TotalScanned:    sum.TotalScanned,
+		TotalWaste:      sum.TotalWaste,
+		MonthlySavings:  sum.TotalSavings,
+		ScanStarted:     r.started,
+		ScanCompleted:   r.completed,
+		DurationSeconds: r.completed.Sub(r.started).Seconds(),
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/report"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// scanGraph builds a graph with one idle volume costing cost.
+func scanGraph(cost float64) *graph.Graph {
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:ec2:us-east-1:123456789012:volume/vol-1", "AWS::EC2::Volume", map[string]interface{}{"Region": "us-east-1"})
+	g.AddNode("arn:aws:ec2:us-east-1:123456789012:instance/i-1", "AWS::EC2::Instance", map[string]interface{}{})
+	g.AddTypedEdge("arn:aws:ec2:us-east-1:123456789012:instance/i-1", "arn:aws:ec2:us-east-1:123456789012:volume/vol-1", graph.EdgeTypeAttachedTo, 100)
+	g.CloseAndWait()
+	g.MarkWaste("arn:aws:ec2:us-east-1:123456789012:volume/vol-1", 80)
+	g.GetNode("arn:aws:ec2:us-east-1:123456789012:volume/vol-1").Cost = cost
+	return g
+}
+
+func getJSON(t *testing.T, h http.Handler, path string, v interface{}) int {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	if v != nil && rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+	}
+	return rec.Code
+}
+
+func TestServerServesLatestCompletedScan(t *testing.T) {
+	release := make(chan struct{})
+	scans := 0
+	s := New(func(ctx context.Context) (*graph.Graph, error) {
+		scans++
+		if scans == 2 {
+			<-release
+		}
+		if scans == 3 {
+			return nil, fmt.Errorf("throttled")
+		}
+		return scanGraph(float64(scans * 10)), nil
+	}, 0, "us-east-1", nil)
+	h := s.Handler()
+
+	if code := getJSON(t, h, "/api/findings", nil); code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 before the first scan, got %d", code)
+	}
+	var health map[string]interface{}
+	getJSON(t, h, "/healthz", &health)
+	if health["status"] != "starting" {
+		t.Errorf("Expected health to report starting, got %v", health)
+	}
+
+	s.RunOnce(context.Background())
+	var findings []report.ExportItem
+	if code := getJSON(t, h, "/api/findings", &findings); code != http.StatusOK || len(findings) != 1 || findings[0].MonthlyCost != 10 {
+		t.Fatalf("Expected the first scan's finding, got %d %+v", code, findings)
+	}
+
+	// While the second scan runs, the first is still served.
+	done := make(chan struct{})
+	go func() {
+		s.RunOnce(context.Background())
+		close(done)
+	}()
+	var sum summary
+	getJSON(t, h, "/api/summary", &sum)
+	if sum.MonthlySavings != 10 || sum.TotalScanned != 2 || sum.TotalWaste != 1 || sum.Region != "us-east-1" {
+		t.Errorf("Expected the first scan's summary during the second scan, got %+v", sum)
+	}
+	close(release)
+	<-done
+	getJSON(t, h, "/api/summary", &sum)
+	if sum.MonthlySavings != 20 {
+		t.Errorf("Expected the second scan swapped in, got %+v", sum)
+	}
+
+	// A failed scan keeps the last good one.
+	s.RunOnce(context.Background())
+	getJSON(t, h, "/api/summary", &sum)
+	getJSON(t, h, "/healthz", &health)
+	if sum.MonthlySavings != 20 || health["status"] != "ok" || health["last_scan_error"] != "throttled" {
+		t.Errorf("Expected the previous scan kept after a failure, got %+v / %v", sum, health)
+	}
+
+	var sankey report.SankeyData
+	if code := getJSON(t, h, "/api/graph", &sankey); code != http.StatusOK || len(sankey.Nodes) != 3 || len(sankey.Links) != 1 {
+		t.Errorf("Expected the topology with the Internet root, got %d %+v", code, sankey)
+	}
+}
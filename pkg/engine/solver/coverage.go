@@ -0,0 +1,143 @@
+package solver
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FleetInstance is one running instance and its current on-demand cost.
+type FleetInstance struct {
+	ID          string
+	Type        string
+	MonthlyCost float64
+}
+
+// CoverageModel describes compute already paid for through Savings Plans or Reserved Instances.
+type CoverageModel struct {
+	// FamilyCoverage is the fraction (0-1) of on-demand spend covered per instance family ("m5").
+	// The "*" family is a Compute Savings Plan: it covers, and can be spent on, any family.
+	FamilyCoverage map[string]float64
+	// CoveredIDs are instances fully covered by a commitment. They are kept as-is, never repacked.
+	// Entries match a workload ID exactly or by its trailing instance ID.
+	CoveredIDs []string
+}
+
+type coverageFile struct {
+	Families  map[string]float64 `yaml:"families"`  // percent covered
+	Instances []string           `yaml:"instances"` // ARNs or instance IDs
+}
+
+// LoadCoverageModel reads a commitment coverage file:
+//
+//	families:
+//	  "*": 70   # Compute Savings Plan covering 70% of spend
+//	  r5: 100   # r5 Reserved Instances
+//	instances:
+//	  - i-0abc123
+func LoadCoverageModel(path string) (*CoverageModel, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read coverage file: %v", err)
+	}
+	var f coverageFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse coverage file %s: %v", path, err)
+	}
+
+	m := &CoverageModel{FamilyCoverage: make(map[string]float64), CoveredIDs: f.Instances}
+	for family, pct := range f.Families {
+		if pct < 0 || pct > 100 {
+			return nil, fmt.Errorf("coverage for %q must be between 0 and 100, got %v", family, pct)
+		}
+		m.FamilyCoverage[family] = pct / 100
+	}
+	return m, nil
+}
+
+// InstanceFamily returns the family of an instance type: "m5.large" -> "m5".
+func InstanceFamily(instanceType string) string {
+	if i := strings.Index(instanceType, "."); i > 0 {
+		return instanceType[:i]
+	}
+	return instanceType
+}
+
+// covers reports whether id is listed in CoveredIDs.
+func (m *CoverageModel) covers(id string) bool {
+	for _, c := range m.CoveredIDs {
+		if id == c || strings.HasSuffix(id, "/"+c) {
+			return true
+		}
+	}
+	return false
+}
+
+// commitments is the committed monthly spend of the current fleet under a CoverageModel.
+type commitments struct {
+	pinned     map[string]bool    // workload IDs kept as-is
+	pinnedCost float64            // their monthly cost
+	family     map[string]float64 // committed $/mo usable only by that family
+	flexible   float64            // committed $/mo usable by any family
+}
+
+// commitments prices the coverage of fleet. A nil model has no commitments.
+func (m *CoverageModel) commitments(fleet []FleetInstance) *commitments {
+	if m == nil {
+		return nil
+	}
+	c := &commitments{pinned: make(map[string]bool), family: make(map[string]float64)}
+	for _, inst := range fleet {
+		if m.covers(inst.ID) {
+			c.pinned[inst.ID] = true
+			c.pinnedCost += inst.MonthlyCost
+			continue
+		}
+		family := InstanceFamily(inst.Type)
+		if frac, ok := m.FamilyCoverage[family]; ok {
+			c.family[family] += inst.MonthlyCost * frac
+		} else if frac, ok := m.FamilyCoverage["*"]; ok {
+			c.flexible += inst.MonthlyCost * frac
+		}
+	}
+	return c
+}
+
+// committed is the commitment spend that remains payable whatever the plan, pinned instances excluded.
+func (c *commitments) committed() float64 {
+	if c == nil {
+		return 0
+	}
+	total := c.flexible
+	for _, v := range c.family {
+		total += v
+	}
+	return total
+}
+
+// adjustedCost is what a plan with the given on-demand spend per instance type
+// actually costs: the commitments are paid regardless, and only spend they
+// cannot absorb is billed on top. Without commitments it is the plain sum.
+func (c *commitments) adjustedCost(spend map[string]float64) float64 {
+	left := make(map[string]float64)
+	if c != nil {
+		for k, v := range c.family {
+			left[k] = v
+		}
+	}
+
+	var onDemand float64
+	for instanceType, cost := range spend {
+		family := InstanceFamily(instanceType)
+		absorbed := min(cost, left[family])
+		left[family] -= absorbed
+		onDemand += cost - absorbed
+	}
+	if c == nil {
+		return onDemand
+	}
+	onDemand -= min(onDemand, c.flexible)
+	return c.committed() + onDemand
+}
@@ -9,12 +9,17 @@ import (
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/tetris"
 )
 
+// InterruptionTolerantTag marks an instance whose workload may run on spot
+// capacity when the solver is allowed to use it.
+const InterruptionTolerantTag = "cloudslash:interruption-tolerant"
+
 // InstanceType defines a compute instance configuration.
 type InstanceType struct {
 	Name       string
 	CPU        float64 // mCPU
 	RAM        float64 // MiB
 	HourlyCost float64
+	SpotPrice  float64 // Hourly spot price; zero when unknown.
 	Region     string
 	Zone       string
 }
@@ -24,15 +29,34 @@ type OptimizationRequest struct {
 	Workloads    []*tetris.Item
 	Catalog      []InstanceType
 	CurrentSpend float64
+
+	// Fleet and Coverage are optional. Together they let the solver account
+	// for Savings Plans and Reserved Instances instead of assuming on-demand.
+	Fleet    []FleetInstance
+	Coverage *CoverageModel
+
+	// AllowSpot lets interruption-tolerant workloads move to spot capacity.
+	AllowSpot bool
 }
 
 // AllocationPlan represents the optimized resource allocation.
 type AllocationPlan struct {
 	Nodes        []*tetris.Bin
-	TotalCost    float64
-	Savings      float64
-	RiskScore    float64
+	TotalCost    float64 // On-demand monthly cost, plus SpotCost.
+	Savings      float64 // On-demand savings.
+	RiskScore    float64 // Node-weighted across the on-demand and spot pools.
 	Instructions []string
+
+	// SpotCost is the monthly cost of the spot pool. Commitments never cover it.
+	SpotCost float64
+
+	// CommittedSpend is the monthly commitment spend that stays payable under any plan.
+	CommittedSpend float64
+	// CommitmentAdjustedCost is what the plan actually costs once commitments are paid.
+	CommitmentAdjustedCost    float64
+	CommitmentAdjustedSavings float64
+
+	spend map[string]float64 // On-demand monthly cost per instance type.
 }
 
 type Optimizer struct {
@@ -50,7 +74,45 @@ func NewOptimizer(o *oracle.RiskEngine, p *policy.Validator) *Optimizer {
 }
 
 // Solve finds optimal resource allocations.
+// With a CoverageModel, plans are ranked by commitment-adjusted cost and
+// covered instances are kept out of the packing.
+// With AllowSpot, interruption-tolerant workloads are packed onto a separate
+// spot pool and the rest are planned on-demand.
 func (opt *Optimizer) Solve(req OptimizationRequest) (*AllocationPlan, error) {
+	commit := req.Coverage.commitments(req.Fleet)
+	if commit != nil && len(commit.pinned) > 0 {
+		var workloads []*tetris.Item
+		for _, w := range req.Workloads {
+			if !commit.pinned[w.ID] {
+				workloads = append(workloads, w)
+			}
+		}
+		req.Workloads = workloads
+		if len(workloads) == 0 {
+			return opt.finalize(req, commit, &AllocationPlan{
+				Instructions: []string{"No changes: every instance is covered by a commitment"},
+			}), nil
+		}
+	}
+
+	var spot *spotPool
+	if req.AllowSpot {
+		var tolerant, rest []*tetris.Item
+		for _, w := range req.Workloads {
+			if w.InterruptionTolerant {
+				tolerant = append(tolerant, w)
+			} else {
+				rest = append(rest, w)
+			}
+		}
+		if spot = opt.solveSpot(tolerant, req.Catalog); spot != nil {
+			req.Workloads = rest
+			if len(rest) == 0 {
+				return opt.finalize(req, commit, spot.apply(req, &AllocationPlan{})), nil
+			}
+		}
+	}
+
 	var bestPlan *AllocationPlan
 	minCost := req.CurrentSpend * 10.0 // Start high
 
@@ -63,10 +125,11 @@ func (opt *Optimizer) Solve(req OptimizationRequest) (*AllocationPlan, error) {
 		}
 
 		// Check risk factors.
-		risk := opt.Oracle.GetRisk(instance.Zone, instance.Name)
-		if risk > 0.5 {
+		assessment := opt.assessMove(req.Workloads, instance, false)
+		if !assessment.Accepted {
 			continue
 		}
+		risk := assessment.Total
 
 		// Simulate packing.
 		factory := func() *tetris.Bin {
@@ -79,9 +142,10 @@ func (opt *Optimizer) Solve(req OptimizationRequest) (*AllocationPlan, error) {
 		bins := opt.Packer.Pack(req.Workloads, factory)
 
 		totalCost := float64(len(bins)) * instance.HourlyCost * 730 // Monthly
+		spend := map[string]float64{instance.Name: totalCost}
 
-		if totalCost < minCost {
-			minCost = totalCost
+		if cost := commit.adjustedCost(spend); cost < minCost {
+			minCost = cost
 			bestPlan = &AllocationPlan{
 				Nodes:     bins,
 				TotalCost: totalCost,
@@ -90,6 +154,7 @@ func (opt *Optimizer) Solve(req OptimizationRequest) (*AllocationPlan, error) {
 				Instructions: []string{
 					fmt.Sprintf("Migrate to %d nodes of type %s", len(bins), instance.Name),
 				},
+				spend: spend,
 			}
 		}
 	}
@@ -97,9 +162,9 @@ func (opt *Optimizer) Solve(req OptimizationRequest) (*AllocationPlan, error) {
 	// Attempt heterogeneous optimization.
 	heteroPlan, err := opt.solveHeterogeneous(req)
 	if err == nil {
-		if bestPlan == nil || heteroPlan.TotalCost < minCost {
+		if cost := commit.adjustedCost(heteroPlan.spend); bestPlan == nil || cost < minCost {
 			bestPlan = heteroPlan
-			minCost = heteroPlan.TotalCost
+			minCost = cost
 		}
 	}
 
@@ -107,14 +172,144 @@ func (opt *Optimizer) Solve(req OptimizationRequest) (*AllocationPlan, error) {
 		return nil, fmt.Errorf("no feasible plan found satisfying all constraints")
 	}
 
-	return bestPlan, nil
+	return opt.finalize(req, commit, spot.apply(req, bestPlan)), nil
+}
+
+// spotPool is the spot placement chosen for interruption-tolerant workloads.
+type spotPool struct {
+	instance InstanceType
+	bins     []*tetris.Bin
+	cost     float64 // Monthly.
+	risk     float64 // Interruption risk from the oracle, including the spot weight.
+	items    int
+}
+
+// solveSpot packs workloads onto the cheapest spot pool the oracle accepts.
+// Types without a spot price, or whose spot price is no lower than on-demand,
+// are skipped. It returns nil when there is nothing to place or no pool fits.
+func (opt *Optimizer) solveSpot(workloads []*tetris.Item, catalog []InstanceType) *spotPool {
+	if len(workloads) == 0 {
+		return nil
+	}
+
+	var best *spotPool
+	for _, instance := range catalog {
+		if instance.SpotPrice <= 0 || instance.SpotPrice >= instance.HourlyCost {
+			continue
+		}
+		if err := opt.Policy.ValidateProposal(0, instance.Name, 0); err != nil {
+			continue
+		}
+
+		assessment := opt.assessMove(workloads, instance, true)
+		if !assessment.Accepted {
+			continue
+		}
+
+		factory := func() *tetris.Bin {
+			return &tetris.Bin{
+				ID:       fmt.Sprintf("node-%s-spot", instance.Name),
+				Capacity: tetris.Dimensions{CPU: instance.CPU, RAM: instance.RAM},
+			}
+		}
+		bins := opt.Packer.Pack(workloads, factory)
+		cost := float64(len(bins)) * instance.SpotPrice * 730
+
+		if best == nil || cost < best.cost || (cost == best.cost && assessment.Total < best.risk) {
+			best = &spotPool{
+				instance: instance,
+				bins:     bins,
+				cost:     cost,
+				risk:     assessment.Total,
+				items:    len(workloads),
+			}
+		}
+	}
+	return best
+}
+
+// assessMove scores moving workloads onto instance. Each workload is assessed
+// on its own profile and the move carries their mean risk, so a few stateful
+// or cross-AZ workloads weigh by their share of the fleet instead of tainting
+// every placement.
+func (opt *Optimizer) assessMove(workloads []*tetris.Item, instance InstanceType, spot bool) oracle.RiskAssessment {
+	base := oracle.WorkloadProfile{Zone: instance.Zone, InstanceType: instance.Name, Spot: spot}
+	if len(workloads) == 0 {
+		return opt.Oracle.Assess(base)
+	}
+
+	var total float64
+	for _, w := range workloads {
+		p := base
+		p.Stateful = w.Stateful
+		p.Production = w.Production
+		p.CrossAZ = w.Zone != "" && instance.Zone != "" && w.Zone != instance.Zone
+		total += opt.Oracle.Assess(p).Total
+	}
+	total /= float64(len(workloads))
+	return oracle.RiskAssessment{
+		Base:     opt.Oracle.GetRisk(instance.Zone, instance.Name),
+		Total:    total,
+		Accepted: total <= oracle.MaxAcceptableRisk,
+	}
+}
+
+// apply merges the spot pool into an on-demand plan. A nil pool leaves the
+// plan unchanged.
+func (s *spotPool) apply(req OptimizationRequest, plan *AllocationPlan) *AllocationPlan {
+	if s == nil {
+		return plan
+	}
+
+	onDemand := float64(len(plan.Nodes))
+	total := onDemand + float64(len(s.bins))
+	if total > 0 {
+		plan.RiskScore = (plan.RiskScore*onDemand + s.risk*float64(len(s.bins))) / total
+	}
+
+	plan.Nodes = append(plan.Nodes, s.bins...)
+	plan.SpotCost = s.cost
+	plan.TotalCost += s.cost
+	plan.Savings = req.CurrentSpend - plan.TotalCost
+	plan.Instructions = append(plan.Instructions,
+		fmt.Sprintf("pool-spot: %d nodes of type %s for %d interruption-tolerant workloads ($%.2f/mo, interruption risk %.0f%%)",
+			len(s.bins), s.instance.Name, s.items, s.cost, s.risk*100))
+	return plan
+}
+
+// finalize adds covered instances back into the plan and splits on-demand
+// savings from commitment-adjusted savings.
+func (opt *Optimizer) finalize(req OptimizationRequest, commit *commitments, plan *AllocationPlan) *AllocationPlan {
+	plan.CommitmentAdjustedCost = commit.adjustedCost(plan.spend) + plan.SpotCost
+	if commit == nil {
+		return plan
+	}
+
+	plan.TotalCost += commit.pinnedCost
+	plan.Savings = req.CurrentSpend - plan.TotalCost
+	plan.CommittedSpend = commit.committed() + commit.pinnedCost
+	plan.CommitmentAdjustedCost += commit.pinnedCost
+	plan.CommitmentAdjustedSavings = req.CurrentSpend - plan.CommitmentAdjustedCost
+
+	if n := len(commit.pinned); n > 0 {
+		plan.Instructions = append([]string{fmt.Sprintf("Keep %d commitment-covered instances as-is ($%.2f/mo)", n, commit.pinnedCost)}, plan.Instructions...)
+	}
+	plan.Instructions = append(plan.Instructions,
+		fmt.Sprintf("On-demand savings: $%.2f/mo", plan.Savings),
+		fmt.Sprintf("Commitment-adjusted savings: $%.2f/mo ($%.2f/mo already committed)", plan.CommitmentAdjustedSavings, plan.CommittedSpend),
+	)
+	return plan
 }
 
 // solveHeterogeneous performs multi-phase bin packing.
 func (opt *Optimizer) solveHeterogeneous(req OptimizationRequest) (*AllocationPlan, error) {
-	// Identify primary workhorse instance.
-	candidates := make([]InstanceType, len(req.Catalog))
-	copy(candidates, req.Catalog)
+	// Identify primary workhorse instance among the types the oracle accepts.
+	var candidates []InstanceType
+	for _, instance := range req.Catalog {
+		if opt.assessMove(req.Workloads, instance, false).Accepted {
+			candidates = append(candidates, instance)
+		}
+	}
 
 	sort.Slice(candidates, func(i, j int) bool {
 		// Sort by cost/capacity ratio.
@@ -124,7 +319,7 @@ func (opt *Optimizer) solveHeterogeneous(req OptimizationRequest) (*AllocationPl
 	})
 
 	if len(candidates) == 0 {
-		return nil, fmt.Errorf("empty catalog")
+		return nil, fmt.Errorf("no catalog instance type within acceptable risk")
 	}
 	workhorse := candidates[0]
 
@@ -206,12 +401,18 @@ func (opt *Optimizer) solveHeterogeneous(req OptimizationRequest) (*AllocationPl
 			totalCost += dustCost
 			instructions = append(instructions, fmt.Sprintf("pool-dust: 1 node of type %s", dustName))
 
+			spend := map[string]float64{dustName: dustCost}
+			if len(mainFleet) > 0 {
+				spend[workhorse.Name] += float64(len(mainFleet)) * workhorse.HourlyCost * 730
+			}
+
 			return &AllocationPlan{
 				Nodes:        finalBins,
 				TotalCost:    totalCost,
 				Savings:      req.CurrentSpend - totalCost,
 				RiskScore:    0.1, // Mixed fleet slightly higher risk?
 				Instructions: instructions,
+				spend:        spend,
 			}, nil
 		}
 	}
@@ -222,8 +423,10 @@ func (opt *Optimizer) solveHeterogeneous(req OptimizationRequest) (*AllocationPl
 		Nodes:     bins,
 		TotalCost: totalCost,
 		Savings:   req.CurrentSpend - totalCost,
+		RiskScore: opt.assessMove(req.Workloads, workhorse, false).Total,
 		Instructions: []string{
 			fmt.Sprintf("Migrate to %d nodes of type %s", len(bins), workhorse.Name),
 		},
+		spend: map[string]float64{workhorse.Name: totalCost},
 	}, nil
 }
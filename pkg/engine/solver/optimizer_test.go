@@ -2,6 +2,8 @@ package solver
 
 import (
 	"fmt"
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/DrSkyle/cloudslash/v2/pkg/config"
@@ -113,3 +115,220 @@ func TestSolveHomogenousFallback(t *testing.T) {
 		t.Errorf("Expected $9.00, got $%.2f", plan.TotalCost/730.0)
 	}
 }
+
+func TestSolveWithCommitmentCoverage(t *testing.T) {
+	catalog := []InstanceType{
+		{Name: "m5.large", CPU: 2000, RAM: 8192, HourlyCost: 0.10, Zone: "us-east-1a"},
+		{Name: "c5.4xlarge", CPU: 16000, RAM: 32768, HourlyCost: 0.68, Zone: "us-east-1a"},
+	}
+
+	// Four m5.large-sized workloads on m5.xlarge today ($140/mo each); i-3 is on a Reserved Instance.
+	var workloads []*tetris.Item
+	var fleet []FleetInstance
+	for i := 0; i < 4; i++ {
+		id := fmt.Sprintf("arn:aws:ec2:us-east-1:123:instance/i-%d", i)
+		workloads = append(workloads, &tetris.Item{ID: id, Dimensions: tetris.Dimensions{CPU: 2000, RAM: 4096}})
+		fleet = append(fleet, FleetInstance{ID: id, Type: "m5.xlarge", MonthlyCost: 140})
+	}
+
+	req := OptimizationRequest{
+		Workloads:    workloads,
+		Catalog:      catalog,
+		CurrentSpend: 560,
+		Fleet:        fleet,
+		Coverage: &CoverageModel{
+			FamilyCoverage: map[string]float64{"m5": 1.0},
+			CoveredIDs:     []string{"i-3"},
+		},
+	}
+
+	opt := NewOptimizer(oracle.NewRiskEngine(config.RiskConfig{}), policy.NewValidator(policy.DefaultPolicy()))
+	plan, err := opt.Solve(req)
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+
+	// i-3 is kept; the other three repack onto 3 m5.large ($219/mo), which the
+	// $420/mo m5 commitment fully absorbs.
+	if len(plan.Nodes) != 3 {
+		t.Errorf("Expected 3 repacked nodes, got %d", len(plan.Nodes))
+	}
+	if plan.CommittedSpend != 560 {
+		t.Errorf("CommittedSpend = %.2f, want 560", plan.CommittedSpend)
+	}
+	if plan.CommitmentAdjustedSavings != 0 {
+		t.Errorf("CommitmentAdjustedSavings = %.2f, want 0 (all spend prepaid)", plan.CommitmentAdjustedSavings)
+	}
+	if want := 560 - (219 + 140.0); plan.Savings < want-0.01 || plan.Savings > want+0.01 {
+		t.Errorf("Savings = %.2f, want on-demand savings %.2f", plan.Savings, want)
+	}
+	if plan.Instructions[0] != "Keep 1 commitment-covered instances as-is ($140.00/mo)" {
+		t.Errorf("first instruction = %q", plan.Instructions[0])
+	}
+}
+
+func TestCommitmentsAdjustedCost(t *testing.T) {
+	c := (&CoverageModel{FamilyCoverage: map[string]float64{"*": 0.5, "r5": 1.0}}).commitments([]FleetInstance{
+		{ID: "a", Type: "m5.large", MonthlyCost: 100}, // $50 flexible
+		{ID: "b", Type: "r5.large", MonthlyCost: 80},  // $80 r5-only
+	})
+
+	// r5 spend is absorbed by the r5 commitment, the rest by the flexible plan.
+	if got := c.adjustedCost(map[string]float64{"r5.xlarge": 80, "c5.large": 30}); got != 130 {
+		t.Errorf("adjustedCost = %.2f, want 130 (commitments only)", got)
+	}
+	// Moving off r5 strands that commitment: 130 committed + (120 - 50 flexible).
+	if got := c.adjustedCost(map[string]float64{"c5.large": 120}); got != 200 {
+		t.Errorf("adjustedCost = %.2f, want 200", got)
+	}
+	if got := (*commitments)(nil).adjustedCost(map[string]float64{"m5.large": 42}); got != 42 {
+		t.Errorf("nil commitments adjustedCost = %.2f, want 42", got)
+	}
+}
+
+func TestSolveWithSpot(t *testing.T) {
+	catalog := []InstanceType{
+		{Name: "m5.large", CPU: 2000, RAM: 8192, HourlyCost: 0.10, SpotPrice: 0.03, Zone: "us-east-1a"},
+	}
+	validator := policy.NewValidator(policy.DefaultPolicy())
+	riskConfig := config.RiskConfig{BaselineRisk: 0.05, InterruptionPenalty: 1.0, Weights: config.RiskWeights{Spot: 0.20}}
+
+	// Four m5.large-sized workloads; the first two tolerate interruption.
+	newRequest := func(allowSpot bool) OptimizationRequest {
+		var workloads []*tetris.Item
+		for i := 0; i < 4; i++ {
+			workloads = append(workloads, &tetris.Item{
+				ID:                   fmt.Sprintf("i-%d", i),
+				Dimensions:           tetris.Dimensions{CPU: 2000, RAM: 4096},
+				InterruptionTolerant: i < 2,
+			})
+		}
+		return OptimizationRequest{Workloads: workloads, Catalog: catalog, CurrentSpend: 560, AllowSpot: allowSpot}
+	}
+
+	t.Run("tolerant workloads move to spot", func(t *testing.T) {
+		plan, err := NewOptimizer(oracle.NewRiskEngine(riskConfig), validator).Solve(newRequest(true))
+		if err != nil {
+			t.Fatalf("Solve failed: %v", err)
+		}
+		if len(plan.Nodes) != 4 {
+			t.Fatalf("expected 4 nodes, got %d", len(plan.Nodes))
+		}
+		if want := 2 * 0.03 * 730; math.Abs(plan.SpotCost-want) > 0.01 {
+			t.Errorf("SpotCost = %.2f, want %.2f", plan.SpotCost, want)
+		}
+		if want := 2*0.10*730 + 2*0.03*730; math.Abs(plan.TotalCost-want) > 0.01 {
+			t.Errorf("TotalCost = %.2f, want %.2f", plan.TotalCost, want)
+		}
+		// Two on-demand nodes at baseline risk, two spot nodes at baseline plus the spot weight.
+		if want := (2*0.05 + 2*0.25) / 4; math.Abs(plan.RiskScore-want) > 1e-9 {
+			t.Errorf("RiskScore = %.3f, want %.3f", plan.RiskScore, want)
+		}
+		if !strings.Contains(strings.Join(plan.Instructions, "\n"), "pool-spot: 2 nodes of type m5.large") {
+			t.Errorf("missing spot instruction: %v", plan.Instructions)
+		}
+	})
+
+	t.Run("spot is off by default", func(t *testing.T) {
+		plan, err := NewOptimizer(oracle.NewRiskEngine(riskConfig), validator).Solve(newRequest(false))
+		if err != nil {
+			t.Fatalf("Solve failed: %v", err)
+		}
+		if plan.SpotCost != 0 || math.Abs(plan.TotalCost-4*0.10*730) > 0.01 {
+			t.Errorf("expected an on-demand plan, got TotalCost %.2f SpotCost %.2f", plan.TotalCost, plan.SpotCost)
+		}
+	})
+
+	t.Run("risky spot pools are rejected", func(t *testing.T) {
+		riskEngine := oracle.NewRiskEngine(riskConfig)
+		riskEngine.RecordInterruption("us-east-1a", "m5.large")
+		// Recorded interruptions make on-demand placement unacceptable too, so
+		// add a quiet on-demand-only type for the rest of the plan.
+		req := newRequest(true)
+		req.Catalog = append(req.Catalog, InstanceType{Name: "m5a.large", CPU: 2000, RAM: 8192, HourlyCost: 0.09, Zone: "us-east-1a"})
+
+		plan, err := NewOptimizer(riskEngine, validator).Solve(req)
+		if err != nil {
+			t.Fatalf("Solve failed: %v", err)
+		}
+		if plan.SpotCost != 0 {
+			t.Errorf("expected no spot pool, got SpotCost %.2f", plan.SpotCost)
+		}
+	})
+}
+
+func TestSolveWeighsWorkloadProfile(t *testing.T) {
+	// The cheaper type sits in another zone; moving stateful production
+	// workloads there crosses the acceptable risk.
+	catalog := []InstanceType{
+		{Name: "m5a.large", CPU: 2000, RAM: 8192, HourlyCost: 0.08, Zone: "us-east-1b"},
+		{Name: "m5.large", CPU: 2000, RAM: 8192, HourlyCost: 0.10, Zone: "us-east-1a"},
+	}
+	riskConfig := config.RiskConfig{BaselineRisk: 0.05, Weights: config.RiskWeights{Statefulness: 0.2, CrossAZ: 0.2, Production: 0.1}}
+
+	newRequest := func(stateful bool) OptimizationRequest {
+		var workloads []*tetris.Item
+		for i := 0; i < 2; i++ {
+			workloads = append(workloads, &tetris.Item{
+				ID:         fmt.Sprintf("i-%d", i),
+				Dimensions: tetris.Dimensions{CPU: 2000, RAM: 4096},
+				Zone:       "us-east-1a",
+				Stateful:   stateful,
+				Production: true,
+			})
+		}
+		return OptimizationRequest{Workloads: workloads, Catalog: catalog, CurrentSpend: 300}
+	}
+
+	validator := policy.NewValidator(policy.DefaultPolicy())
+	plan, err := NewOptimizer(oracle.NewRiskEngine(riskConfig), validator).Solve(newRequest(true))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if instr := strings.Join(plan.Instructions, "\n"); strings.Contains(instr, "m5a.large") {
+		t.Errorf("Expected stateful production workloads to stay in their zone, got %v", plan.Instructions)
+	}
+	if want := 0.05 + 0.2 + 0.1; math.Abs(plan.RiskScore-want) > 1e-9 {
+		t.Errorf("RiskScore = %.3f, want %.3f", plan.RiskScore, want)
+	}
+
+	// Stateless workloads may move.
+	plan, err = NewOptimizer(oracle.NewRiskEngine(riskConfig), validator).Solve(newRequest(false))
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if instr := strings.Join(plan.Instructions, "\n"); !strings.Contains(instr, "m5a.large") {
+		t.Errorf("Expected stateless workloads to move to the cheaper zone, got %v", plan.Instructions)
+	}
+}
+
+func TestSolveProductionMultiAZFleet(t *testing.T) {
+	// Production spread over three zones, with a database pair in the
+	// target zone. Taken as one profile it would be stateful, production and
+	// cross-AZ at once (0.60) and nothing would be planned.
+	zones := []string{"us-east-1a", "us-east-1b", "us-east-1c"}
+	var workloads []*tetris.Item
+	for i := 0; i < 6; i++ {
+		workloads = append(workloads, &tetris.Item{
+			ID:         fmt.Sprintf("i-%d", i),
+			Dimensions: tetris.Dimensions{CPU: 1000, RAM: 2048},
+			Zone:       zones[i%3],
+			Stateful:   i < 4 && i%3 == 0,
+			Production: true,
+		})
+	}
+	catalog := []InstanceType{{Name: "m5.large", CPU: 2000, RAM: 8192, HourlyCost: 0.096, Zone: "us-east-1a"}}
+	req := OptimizationRequest{Workloads: workloads, Catalog: catalog, CurrentSpend: 600}
+
+	plan, err := NewOptimizer(oracle.NewRiskEngine(config.DefaultRiskConfig()), policy.NewValidator(policy.DefaultPolicy())).Solve(req)
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	if len(plan.Nodes) == 0 {
+		t.Fatalf("Expected a plan for the production fleet, got %v", plan.Instructions)
+	}
+	// Two stateful in-zone (0.50) and four stateless cross-AZ (0.35).
+	if want := (2*0.50 + 4*0.35) / 6; math.Abs(plan.RiskScore-want) > 1e-9 {
+		t.Errorf("RiskScore = %.3f, want %.3f", plan.RiskScore, want)
+	}
+}
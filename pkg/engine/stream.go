@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/heuristics"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/notifier"
+)
+
+// findingHandler builds the streaming sink, or returns nil when streaming is off.
+// Printing is limited to headless runs so the TUI is left untouched.
+func (e *Engine) findingHandler() heuristics.FindingHandler {
+	printFindings := e.config.Stream && e.config.Headless && e.findingSink == nil
+	if !printFindings && e.config.StreamWebhook == "" && e.findingSink == nil {
+		return nil
+	}
+
+	var client *notifier.StreamClient
+	if e.config.StreamWebhook != "" {
+		client = notifier.NewStreamClient(e.config.StreamWebhook)
+	}
+
+	var toSink heuristics.FindingHandler
+	if e.findingSink != nil {
+		toSink = heuristics.SinkHandler(e.findingSink, func(err error) {
+			e.Logger.Warn("Failed to write findings", "error", err)
+		})
+	}
+
+	return func(findings []heuristics.Finding) {
+		if toSink != nil {
+			toSink(findings)
+		}
+		if printFindings {
+			for _, f := range findings {
+				fmt.Printf("[FINDING] %s %s ($%.2f/mo) %s\n", f.Type, f.ID, f.Cost, f.Reason)
+			}
+		}
+		if client != nil {
+			if err := client.SendFindings(findings); err != nil {
+				e.Logger.Warn("Failed to stream findings", "error", err)
+			}
+		}
+	}
+}
+
+// exitSummary is the last NDJSON line of a machine-mode run.
+type exitSummary struct {
+	Event        string   `json:"event"`
+	Resources    int      `json:"resources"`
+	Findings     int      `json:"findings"`
+	MonthlyWaste float64  `json:"monthly_waste"`
+	Partial      bool     `json:"partial"`
+	FailedScopes []string `json:"failed_scopes,omitempty"`
+	DurationMs   int64    `json:"duration_ms"`
+}
+
+// writeExitSummary writes the summary line and flushes the finding sink.
+func (e *Engine) writeExitSummary(start time.Time) {
+	summary := exitSummary{Event: "summary", DurationMs: time.Since(start).Milliseconds()}
+
+	e.Graph.Mu.RLock()
+	for _, n := range e.Graph.Store.GetAllNodes() {
+		summary.Resources++
+		if n.IsWaste && !n.Ignored {
+			summary.Findings++
+			summary.MonthlyWaste += n.Cost
+		}
+	}
+	// AddError records failed scopes without setting Partial; either one means data is missing.
+	summary.Partial = e.Graph.Metadata.Partial || len(e.Graph.Metadata.FailedScopes) > 0
+	for _, s := range e.Graph.Metadata.FailedScopes {
+		summary.FailedScopes = append(summary.FailedScopes, s.Scope)
+	}
+	e.Graph.Mu.RUnlock()
+
+	if err := e.findingSink.WriteEvent(summary); err != nil {
+		e.Logger.Warn("Failed to write exit summary", "error", err)
+	}
+	if err := e.findingSink.Flush(); err != nil {
+		e.Logger.Warn("Failed to flush findings", "error", err)
+	}
+}
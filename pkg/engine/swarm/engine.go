@@ -20,6 +20,13 @@ type Engine struct {
 	MaxWorkers int // MaxWorkers sets a hard ceiling on concurrency
 	mu         sync.Mutex
 	stats      Stats
+
+	// Tasks run under taskCtx, cancelled by the parent context or Shutdown.
+	taskCtx  context.Context
+	cancel   context.CancelFunc
+	submitMu sync.RWMutex
+	draining bool
+	stopOnce sync.Once
 }
 
 type Stats struct {
@@ -37,22 +44,96 @@ func NewEngine() *Engine {
 	}
 }
 
-// Start begins the worker loop.
+// Start begins the worker loop. Cancelling ctx cancels the context every task
+// receives; tasks still queued then run with the cancelled context, so their
+// cleanup (e.g. a deferred WaitGroup.Done) still happens.
 func (e *Engine) Start(ctx context.Context) {
-	go e.loop(ctx)
+	e.submitMu.Lock()
+	e.taskCtx, e.cancel = context.WithCancel(ctx)
+	e.submitMu.Unlock()
+	// The loop holds a count too, so workers it adds never race Stop's Wait.
+	e.wg.Add(1)
+	go e.loop(e.taskCtx)
 }
 
-// Submit sends a task for processing.
+// Submit sends a task for processing. Once the engine is cancelled the task
+// runs immediately, on the caller's goroutine, with the cancelled context.
 func (e *Engine) Submit(t Task) {
-	e.tasks <- t
+	e.submitMu.RLock()
+	ctx, draining := e.taskCtx, e.draining
+	if !draining {
+		var done <-chan struct{}
+		if ctx != nil {
+			done = ctx.Done()
+		}
+		select {
+		case e.tasks <- t:
+			e.submitMu.RUnlock()
+			return
+		case <-done:
+		}
+	}
+	e.submitMu.RUnlock()
+	t(ctx)
 }
 
 // Stop shuts down the engine.
 func (e *Engine) Stop() {
-	close(e.quit)
+	e.stopOnce.Do(func() { close(e.quit) })
 	e.wg.Wait()
 }
 
+// Shutdown cancels running tasks, runs the queued ones with the cancelled
+// context and waits for the workers. It gives up and returns ctx.Err() when
+// ctx expires first, abandoning tasks that ignore cancellation.
+func (e *Engine) Shutdown(ctx context.Context) error {
+	e.submitMu.RLock()
+	cancel := e.cancel
+	e.submitMu.RUnlock()
+	if cancel != nil {
+		cancel()
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		e.drain()
+		e.Stop()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// drain stops queueing and runs every queued task with the cancelled context.
+func (e *Engine) drain() {
+	e.submitMu.Lock()
+	if e.draining {
+		e.submitMu.Unlock()
+		return
+	}
+	e.draining = true
+	if e.taskCtx == nil {
+		// Never started: queued tasks still get a cancelled context.
+		e.taskCtx, e.cancel = context.WithCancel(context.Background())
+		e.cancel()
+	}
+	ctx := e.taskCtx
+	e.submitMu.Unlock()
+
+	for {
+		select {
+		case t := <-e.tasks:
+			t(ctx)
+		default:
+			return
+		}
+	}
+}
+
 // GetStats returns current metrics.
 func (e *Engine) GetStats() Stats {
 	e.mu.Lock()
@@ -65,12 +146,14 @@ func (e *Engine) GetStats() Stats {
 }
 
 func (e *Engine) loop(ctx context.Context) {
+	defer e.wg.Done()
 	ticker := time.NewTicker(50 * time.Millisecond)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
+			e.drain()
 			return
 		case <-e.quit:
 			return
@@ -0,0 +1,99 @@
+package swarm
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEngine_ShutdownDrainsQueuedTasks(t *testing.T) {
+	e := NewEngine()
+	e.MaxWorkers = 1
+	e.Start(context.Background())
+
+	var wg sync.WaitGroup
+	var cancelled atomic.Int32
+	task := func(ctx context.Context) error {
+		defer wg.Done()
+		<-ctx.Done() // Blocks the only worker until shutdown.
+		cancelled.Add(1)
+		return ctx.Err()
+	}
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		e.Submit(task)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := e.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	wg.Wait()
+	if n := cancelled.Load(); n != 3 {
+		t.Errorf("%d tasks saw cancellation, want 3", n)
+	}
+
+	// Late submissions run inline with the cancelled context instead of blocking.
+	ran := false
+	e.Submit(func(ctx context.Context) error {
+		ran = ctx.Err() != nil
+		return nil
+	})
+	if !ran {
+		t.Error("Submit after Shutdown should run the task with a cancelled context")
+	}
+	e.Stop()
+}
+
+func TestEngine_ParentCancelReleasesWaiters(t *testing.T) {
+	e := NewEngine()
+	e.MaxWorkers = 1
+	ctx, cancel := context.WithCancel(context.Background())
+	e.Start(ctx)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		e.Submit(func(ctx context.Context) error {
+			defer wg.Done()
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("queued tasks never ran after the parent context was cancelled")
+	}
+	e.Stop()
+}
+
+func TestEngine_ShutdownTimesOut(t *testing.T) {
+	e := NewEngine()
+	e.MaxWorkers = 1
+	e.Start(context.Background())
+
+	release := make(chan struct{})
+	defer close(release)
+	e.Submit(func(ctx context.Context) error {
+		<-release // Ignores cancellation.
+		return nil
+	})
+	time.Sleep(100 * time.Millisecond) // Let the worker pick it up.
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := e.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Shutdown = %v, want DeadlineExceeded", err)
+	}
+}
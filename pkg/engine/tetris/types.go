@@ -13,6 +13,15 @@ type Item struct {
 	ID         string
 	Dimensions Dimensions
 	Group      string
+
+	// InterruptionTolerant workloads may be placed on spot capacity.
+	InterruptionTolerant bool
+
+	// Zone, Stateful and Production describe where the workload runs now;
+	// the solver weighs moving it with the oracle.
+	Zone       string
+	Stateful   bool
+	Production bool
 }
 
 // Bin represents a resource container.
@@ -2,6 +2,7 @@ package graph
 
 import (
 	"fmt"
+	"sort"
 )
 
 // TopologicalSort resolves dependency order.
@@ -69,3 +70,227 @@ func (g *Graph) TopologicalSort(nodes []*Node) ([]*Node, error) {
 
 	return sorted, nil
 }
+
+// DetectCycles returns the strongly connected components of more than one
+// node along forward edges, each as sorted node IDs. Infrastructure graphs
+// should be acyclic; a cycle usually means a scanner added an edge in both
+// directions. Self-loops are not reported.
+func (g *Graph) DetectCycles() [][]string {
+	g.Mu.RLock()
+	defer g.Mu.RUnlock()
+
+	var cycles [][]string
+	for _, scc := range g.stronglyConnected() {
+		if len(scc) < 2 {
+			continue
+		}
+		ids := make([]string, 0, len(scc))
+		for _, idx := range scc {
+			if node := g.Store.GetNode(idx); node != nil {
+				ids = append(ids, node.IDStr())
+			}
+		}
+		sort.Strings(ids)
+		cycles = append(cycles, ids)
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+// stronglyConnected is Tarjan's algorithm. It keeps an explicit call stack so
+// long dependency chains cannot overflow the goroutine stack.
+func (g *Graph) stronglyConnected() [][]uint32 {
+	type frame struct {
+		v     uint32
+		edges []Edge
+		next  int
+	}
+
+	index := make(map[uint32]int)
+	low := make(map[uint32]int)
+	onStack := make(map[uint32]bool)
+	var stack []uint32
+	var sccs [][]uint32
+
+	visit := func(v uint32) frame {
+		index[v] = len(index)
+		low[v] = index[v]
+		stack = append(stack, v)
+		onStack[v] = true
+		return frame{v: v, edges: g.Store.GetEdges(v)}
+	}
+
+	for _, node := range g.Store.GetAllNodes() {
+		if _, seen := index[node.Index]; seen {
+			continue
+		}
+		calls := []frame{visit(node.Index)}
+		for len(calls) > 0 {
+			f := &calls[len(calls)-1]
+			if f.next < len(f.edges) {
+				w := f.edges[f.next].TargetID
+				f.next++
+				if _, seen := index[w]; !seen {
+					calls = append(calls, visit(w))
+				} else if onStack[w] {
+					low[f.v] = min(low[f.v], index[w])
+				}
+				continue
+			}
+
+			v := f.v
+			calls = calls[:len(calls)-1]
+			if len(calls) > 0 {
+				parent := calls[len(calls)-1].v
+				low[parent] = min(low[parent], low[v])
+			}
+			if low[v] != index[v] {
+				continue
+			}
+			var scc []uint32
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				scc = append(scc, w)
+				if w == v {
+					break
+				}
+			}
+			sccs = append(sccs, scc)
+		}
+	}
+	return sccs
+}
+
+// Path is a root-to-leaf chain of node IDs and its summed monthly cost.
+type Path struct {
+	Nodes []string
+	Cost  float64
+}
+
+// TopCostPaths returns the n most expensive root-to-leaf paths along forward edges.
+// Roots are nodes without incoming edges. Single-node paths are omitted.
+// Each node keeps only its n most expensive paths down to a leaf, built from
+// its children's in reverse topological order, so the work is O(E·n) however
+// many paths the graph holds. Edges that close a cycle are ignored.
+func (g *Graph) TopCostPaths(n int) []Path {
+	if n <= 0 {
+		return nil
+	}
+
+	g.Mu.RLock()
+	defer g.Mu.RUnlock()
+
+	var roots []uint32
+	for _, node := range g.Store.GetAllNodes() {
+		if len(g.Store.GetReverseEdges(node.Index)) == 0 {
+			roots = append(roots, node.Index)
+		}
+	}
+
+	// suffix is one of a node's top paths to a leaf. It continues with the
+	// rank-th suffix of next, unless the node is the leaf.
+	type suffix struct {
+		cost float64
+		next uint32
+		rank int
+		leaf bool
+	}
+	best := make(map[uint32][]suffix)
+
+	for _, idx := range g.postOrder(roots) {
+		cost := g.Store.GetNode(idx).Cost
+		var cands []suffix
+		seen := make(map[uint32]bool)
+		for _, e := range g.Store.GetEdges(idx) {
+			// Targets without suffixes yet close a cycle back to the DFS path.
+			below, ok := best[e.TargetID]
+			if !ok || seen[e.TargetID] {
+				continue
+			}
+			seen[e.TargetID] = true
+			for rank, s := range below {
+				cands = append(cands, suffix{cost: cost + s.cost, next: e.TargetID, rank: rank})
+			}
+		}
+		if len(cands) == 0 {
+			best[idx] = []suffix{{cost: cost, leaf: true}}
+			continue
+		}
+		sort.SliceStable(cands, func(i, j int) bool { return cands[i].cost > cands[j].cost })
+		if len(cands) > n {
+			cands = cands[:n]
+		}
+		best[idx] = cands
+	}
+
+	type start struct {
+		root uint32
+		rank int
+		cost float64
+	}
+	var starts []start
+	for _, root := range roots {
+		for rank, s := range best[root] {
+			if !s.leaf && s.cost > 0 {
+				starts = append(starts, start{root: root, rank: rank, cost: s.cost})
+			}
+		}
+	}
+	sort.SliceStable(starts, func(i, j int) bool { return starts[i].cost > starts[j].cost })
+	if len(starts) > n {
+		starts = starts[:n]
+	}
+
+	top := make([]Path, 0, len(starts))
+	for _, st := range starts {
+		p := Path{Cost: st.cost}
+		for idx, rank := st.root, st.rank; ; {
+			p.Nodes = append(p.Nodes, g.Store.GetNode(idx).IDStr())
+			s := best[idx][rank]
+			if s.leaf {
+				break
+			}
+			idx, rank = s.next, s.rank
+		}
+		top = append(top, p)
+	}
+	return top
+}
+
+// postOrder lists the nodes reachable from roots so that each comes after the
+// targets of its forward edges, except targets that close a cycle. Like
+// stronglyConnected it keeps an explicit stack.
+func (g *Graph) postOrder(roots []uint32) []uint32 {
+	type frame struct {
+		v     uint32
+		edges []Edge
+		next  int
+	}
+
+	visited := make(map[uint32]bool)
+	var order []uint32
+	for _, root := range roots {
+		if visited[root] {
+			continue
+		}
+		visited[root] = true
+		calls := []frame{{v: root, edges: g.Store.GetEdges(root)}}
+		for len(calls) > 0 {
+			f := &calls[len(calls)-1]
+			if f.next < len(f.edges) {
+				w := f.edges[f.next].TargetID
+				f.next++
+				if !visited[w] && g.Store.GetNode(w) != nil {
+					visited[w] = true
+					calls = append(calls, frame{v: w, edges: g.Store.GetEdges(w)})
+				}
+				continue
+			}
+			order = append(order, f.v)
+			calls = calls[:len(calls)-1]
+		}
+	}
+	return order
+}
@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 )
@@ -168,3 +169,114 @@ func FuzzTopologicalSort(f *testing.F) {
 		}
 	})
 }
+
+func TestTopCostPaths(t *testing.T) {
+	g := NewGraph()
+
+	g.AddNode("internet", "Internet", nil)
+	g.AddNode("igw", "AWS::EC2::InternetGateway", nil)
+	g.AddNode("nat-a", "AWS::EC2::NatGateway", nil)
+	g.AddNode("nat-b", "AWS::EC2::NatGateway", nil)
+	g.AddNode("i-big", "AWS::EC2::Instance", nil)
+	g.AddNode("i-small", "AWS::EC2::Instance", nil)
+
+	g.AddEdge("internet", "igw")
+	g.AddEdge("igw", "nat-a")
+	g.AddEdge("igw", "nat-b")
+	g.AddEdge("nat-a", "i-big")
+	g.AddEdge("nat-b", "i-small")
+	// Cycle back to the root must not loop forever.
+	g.AddEdge("i-big", "igw")
+
+	g.CloseAndWait()
+
+	costs := map[string]float64{"nat-a": 32, "nat-b": 32, "i-big": 100, "i-small": 10}
+	for id, c := range costs {
+		g.GetNode(id).Cost = c
+	}
+
+	paths := g.TopCostPaths(1)
+	if len(paths) != 1 {
+		t.Fatalf("Expected 1 path, got %d", len(paths))
+	}
+	want := []string{"internet", "igw", "nat-a", "i-big"}
+	if !reflect.DeepEqual(paths[0].Nodes, want) {
+		t.Errorf("Expected %v, got %v", want, paths[0].Nodes)
+	}
+	if paths[0].Cost != 132 {
+		t.Errorf("Expected cost 132, got %.2f", paths[0].Cost)
+	}
+
+	all := g.TopCostPaths(5)
+	if len(all) != 2 {
+		t.Fatalf("Expected 2 paths, got %d", len(all))
+	}
+	if all[1].Cost != 42 {
+		t.Errorf("Expected second path cost 42, got %.2f", all[1].Cost)
+	}
+
+	// 30 fully connected layers of 3 hold 3^30 paths; they must not be walked.
+	wide := NewGraph()
+	wide.AddNode("root", "Root", nil)
+	prev := []string{"root"}
+	for layer := 0; layer < 30; layer++ {
+		var cur []string
+		for i := 0; i < 3; i++ {
+			id := fmt.Sprintf("n-%d-%d", layer, i)
+			wide.AddNode(id, "AWS::EC2::Instance", nil)
+			for _, p := range prev {
+				wide.AddEdge(p, id)
+			}
+			cur = append(cur, id)
+		}
+		prev = cur
+	}
+	wide.CloseAndWait()
+	for layer := 0; layer < 30; layer++ {
+		for i := 0; i < 3; i++ {
+			wide.GetNode(fmt.Sprintf("n-%d-%d", layer, i)).Cost = float64(i)
+		}
+	}
+
+	paths = wide.TopCostPaths(3)
+	if len(paths) != 3 {
+		t.Fatalf("Expected 3 paths, got %d", len(paths))
+	}
+	if paths[0].Cost != 60 || paths[1].Cost != 59 || paths[2].Cost != 59 {
+		t.Errorf("Expected costs 60, 59, 59, got %.0f, %.0f, %.0f", paths[0].Cost, paths[1].Cost, paths[2].Cost)
+	}
+	if len(paths[0].Nodes) != 31 {
+		t.Errorf("Expected 31 nodes on the top path, got %d", len(paths[0].Nodes))
+	}
+}
+
+func TestDetectCycles(t *testing.T) {
+	g := NewGraph()
+	// A -> B -> C -> A is a cycle; D <-> E is a bidirectional pair; F is a plain leaf.
+	g.AddEdge("A", "B")
+	g.AddEdge("B", "C")
+	g.AddEdge("C", "A")
+	g.AddEdge("C", "F")
+	g.AddEdge("D", "E")
+	g.AddEdge("E", "D")
+	g.AddEdge("S", "S") // Self-loops are not reported.
+	g.CloseAndWait()
+
+	want := [][]string{{"A", "B", "C"}, {"D", "E"}}
+	if got := g.DetectCycles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("DetectCycles() = %v, want %v", got, want)
+	}
+	if stats := g.DumpStats(); stats != "Nodes: 7 | Cycles: 2 | Storage: Memory" {
+		t.Errorf("DumpStats() = %q", stats)
+	}
+
+	// A long chain must not recurse.
+	chain := NewGraph()
+	for i := 0; i < 100000; i++ {
+		chain.AddEdge(fmt.Sprint(i), fmt.Sprint(i+1))
+	}
+	chain.CloseAndWait()
+	if got := chain.DetectCycles(); len(got) != 0 {
+		t.Errorf("acyclic chain reported cycles: %v", got)
+	}
+}
@@ -0,0 +1,232 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/sys/intern"
+)
+
+// binaryVersion changes whenever binaryGraph does; older files are rejected.
+const binaryVersion = 3
+
+// binaryGraph is the on-disk form of a graph. Unlike Snapshot it keeps node
+// indices, reverse edges and analysis state, so loading needs no rebuild.
+// Interned IDs are process-local, so node IDs and types are written as
+// positions in a string table of their own.
+type binaryGraph struct {
+	Version      int
+	Strings      []string
+	Nodes        []binaryNode
+	Edges        [][]Edge // By source index.
+	ReverseEdges [][]Edge // By target index.
+	Metadata     GraphMetadata
+}
+
+type binaryNode struct {
+	ID, Type       uint32 // Positions in Strings.
+	Properties     map[string]interface{}
+	TypedData      interface{}
+	IsWaste        bool
+	WasteReason    string
+	Justified      bool
+	Justification  string
+	Ignored        bool
+	RiskScore      int
+	Cost           float64
+	SourceLocation string
+	Reachability   ReachabilityState
+	Findings       []Finding
+
+	ComplianceViolation bool
+	ComplianceReason    string
+}
+
+// SaveBinary writes the graph to path in a compact gob format that LoadBinary
+// reads back. The file is replaced atomically. Pending writes are not waited
+// for; call Flush or CloseAndWait first.
+func (g *Graph) SaveBinary(path string) error {
+	g.Mu.RLock()
+	defer g.Mu.RUnlock()
+
+	b := binaryGraph{Version: binaryVersion, Metadata: g.Metadata}
+	positions := make(map[uint32]uint32)
+	position := func(id uint32) uint32 {
+		if p, ok := positions[id]; ok {
+			return p
+		}
+		p := uint32(len(b.Strings))
+		positions[id] = p
+		b.Strings = append(b.Strings, intern.GetStr(id))
+		return p
+	}
+
+	nodes := g.Store.GetAllNodes()
+	b.Nodes = make([]binaryNode, len(nodes))
+	b.Edges = make([][]Edge, len(nodes))
+	b.ReverseEdges = make([][]Edge, len(nodes))
+	for i, n := range nodes {
+		b.Nodes[i] = binaryNode{
+			ID:             position(n.ID),
+			Type:           position(n.Type),
+			Properties:     n.Properties,
+			TypedData:      n.TypedData,
+			IsWaste:        n.IsWaste,
+			WasteReason:    n.WasteReason,
+			Justified:      n.Justified,
+			Justification:  n.Justification,
+			Ignored:        n.Ignored,
+			RiskScore:      n.RiskScore,
+			Cost:           n.Cost,
+			SourceLocation: n.SourceLocation,
+			Reachability:   n.Reachability,
+			Findings:       n.Findings,
+
+			ComplianceViolation: n.ComplianceViolation,
+			ComplianceReason:    n.ComplianceReason,
+		}
+		b.Edges[i] = g.Store.GetEdges(n.Index)
+		b.ReverseEdges[i] = g.Store.GetReverseEdges(n.Index)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to save graph: %v", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to save graph: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	if err := gob.NewEncoder(w).Encode(&b); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to encode graph: %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save graph: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save graph: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save graph: %v", err)
+	}
+	return nil
+}
+
+// LoadBinary reads a graph written by SaveBinary. Node indices are kept as
+// saved, and the graph accepts further writes like one from NewGraph.
+func LoadBinary(path string) (*Graph, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load graph: %v", err)
+	}
+	defer f.Close()
+
+	var b binaryGraph
+	if err := gob.NewDecoder(bufio.NewReader(f)).Decode(&b); err != nil {
+		return nil, fmt.Errorf("failed to decode graph: %v", err)
+	}
+	if b.Version != binaryVersion {
+		return nil, fmt.Errorf("unsupported graph format version %d (want %d)", b.Version, binaryVersion)
+	}
+	if len(b.Edges) != len(b.Nodes) || len(b.ReverseEdges) != len(b.Nodes) {
+		return nil, fmt.Errorf("corrupt graph file: %d nodes, %d edge lists, %d reverse edge lists", len(b.Nodes), len(b.Edges), len(b.ReverseEdges))
+	}
+
+	ids := make([]uint32, len(b.Strings))
+	for i, s := range b.Strings {
+		ids[i] = intern.Get(s)
+	}
+
+	store := NewMemoryStore()
+	store.nodes = make([]*Node, len(b.Nodes))
+	store.edges = b.Edges
+	store.reverseEdges = b.ReverseEdges
+	shared := make(stringTable)
+	for i, bn := range b.Nodes {
+		if int(bn.ID) >= len(ids) || int(bn.Type) >= len(ids) {
+			return nil, fmt.Errorf("corrupt graph file: node %d names string %d of %d", i, max(bn.ID, bn.Type), len(ids))
+		}
+		n := &Node{
+			Index:          uint32(i),
+			ID:             ids[bn.ID],
+			Type:           ids[bn.Type],
+			Properties:     shared.props(bn.Properties),
+			TypedData:      bn.TypedData,
+			IsWaste:        bn.IsWaste,
+			WasteReason:    bn.WasteReason,
+			Justified:      bn.Justified,
+			Justification:  bn.Justification,
+			Ignored:        bn.Ignored,
+			RiskScore:      bn.RiskScore,
+			Cost:           bn.Cost,
+			SourceLocation: bn.SourceLocation,
+			Reachability:   bn.Reachability,
+			Findings:       bn.Findings,
+
+			ComplianceViolation: bn.ComplianceViolation,
+			ComplianceReason:    bn.ComplianceReason,
+		}
+		if n.Properties == nil {
+			n.Properties = make(map[string]interface{})
+		}
+		store.nodes[i] = n
+		store.idMap[n.ID] = n.Index
+	}
+
+	g := NewGraph()
+	g.Store = store
+	g.Metadata = b.Metadata
+	g.DSU.Resize(len(b.Nodes))
+	for src, edges := range b.Edges {
+		for _, e := range edges {
+			if int(e.TargetID) >= len(b.Nodes) {
+				g.CloseAndWait()
+				return nil, fmt.Errorf("corrupt graph file: edge from node %d to missing node %d", src, e.TargetID)
+			}
+			store.edgeSet[edgeKey{source: uint32(src), target: e.TargetID, typ: e.Type}] = struct{}{}
+			g.DSU.Union(src, int(e.TargetID))
+		}
+	}
+	return g, nil
+}
+
+// stringTable shares one copy of each repeated property string. Gob decodes
+// every occurrence separately; without this a large account holds "Region"
+// and "available" once per node.
+type stringTable map[string]string
+
+func (t stringTable) get(s string) string {
+	if c, ok := t[s]; ok {
+		return c
+	}
+	t[s] = s
+	return s
+}
+
+func (t stringTable) props(props map[string]interface{}) map[string]interface{} {
+	if props == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(props))
+	for k, v := range props {
+		switch val := v.(type) {
+		case string:
+			v = t.get(val)
+		case map[string]string:
+			m := make(map[string]string, len(val))
+			for mk, mv := range val {
+				m[t.get(mk)] = t.get(mv)
+			}
+			v = m
+		}
+		out[t.get(k)] = v
+	}
+	return out
+}
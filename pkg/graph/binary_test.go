@@ -0,0 +1,108 @@
+package graph
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	launched := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	src := NewGraph()
+	src.AddNode("i-1", "AWS::EC2::Instance", map[string]interface{}{"State": "running"})
+	src.AddNode("vol-1", "AWS::EC2::Volume", map[string]interface{}{
+		"Size":       int32(100),
+		"State":      "running",
+		"Tags":       map[string]string{"Env": "dev"},
+		"LaunchTime": &launched,
+	})
+	src.AddNode("vol-2", "AWS::EC2::Volume", nil)
+	src.AddTypedEdge("i-1", "vol-1", EdgeTypeAttachedTo, 50)
+	src.AddError("default:us-east-1 [EC2]", errors.New("throttled"))
+	src.CloseAndWait()
+	src.MarkWaste("vol-2", 80)
+	src.GetNode("vol-2").Cost = 12.5
+	src.MarkCompliance("vol-1", "EBS volume is not encrypted")
+	src.AddFinding("i-1", Finding{Heuristic: "UnderutilizedInstanceHeuristic", Reason: "Idle", Score: 60, Savings: 30})
+	src.Metadata.Partial = true
+
+	path := filepath.Join(t.TempDir(), "cache", "graph.bin")
+	if err := src.SaveBinary(path); err != nil {
+		t.Fatalf("SaveBinary failed: %v", err)
+	}
+	dst, err := LoadBinary(path)
+	if err != nil {
+		t.Fatalf("LoadBinary failed: %v", err)
+	}
+
+	if dst.Store.NodeCount() != 3 {
+		t.Fatalf("Expected 3 nodes, got %d", dst.Store.NodeCount())
+	}
+	inst, vol := dst.GetNode("i-1"), dst.GetNode("vol-1")
+	if inst == nil || vol == nil {
+		t.Fatal("Expected nodes to be found by ID")
+	}
+	if inst.Index != src.GetNode("i-1").Index || vol.TypeStr() != "AWS::EC2::Volume" {
+		t.Errorf("Expected indices and types to be kept, got %d %s", inst.Index, vol.TypeStr())
+	}
+	if tags, ok := vol.Properties["Tags"].(map[string]string); !ok || tags["Env"] != "dev" {
+		t.Errorf("Expected typed Tags to survive, got %#v", vol.Properties["Tags"])
+	}
+	if lt, ok := vol.Properties["LaunchTime"].(time.Time); !ok || !lt.Equal(launched) {
+		t.Errorf("Expected LaunchTime to survive, got %#v", vol.Properties["LaunchTime"])
+	}
+
+	edges := dst.GetEdges(inst.Index)
+	if len(edges) != 1 || edges[0].TargetID != vol.Index || edges[0].Type != EdgeTypeAttachedTo || edges[0].Weight != 50 {
+		t.Errorf("Expected attached-to edge, got %+v", edges)
+	}
+	rev := dst.GetReverseEdges(vol.Index)
+	if len(rev) != 1 || rev[0].TargetID != inst.Index {
+		t.Errorf("Expected reverse edge to the instance, got %+v", rev)
+	}
+	if !dst.DSU.Connected(int(inst.Index), int(vol.Index)) {
+		t.Error("Expected connectivity to be rebuilt")
+	}
+
+	waste := dst.GetNode("vol-2")
+	if !waste.IsWaste || waste.RiskScore != 80 || waste.Cost != 12.5 || waste.Properties == nil {
+		t.Errorf("Expected analysis state to survive, got %+v", waste)
+	}
+	if !vol.ComplianceViolation || vol.ComplianceReason != "EBS volume is not encrypted" {
+		t.Errorf("Expected compliance state to survive, got %v %q", vol.ComplianceViolation, vol.ComplianceReason)
+	}
+	if f := inst.Findings; len(f) != 1 || f[0].Heuristic != "UnderutilizedInstanceHeuristic" || f[0].Savings != 30 {
+		t.Errorf("Expected findings to survive, got %+v", f)
+	}
+	if !dst.Metadata.Partial || len(dst.Metadata.FailedScopes) != 1 {
+		t.Errorf("Expected metadata to survive, got %+v", dst.Metadata)
+	}
+
+	// The loaded graph takes further writes; duplicates are still rejected.
+	dst.AddTypedEdge("i-1", "vol-1", EdgeTypeAttachedTo, 50)
+	dst.AddTypedEdge("i-1", "vol-2", EdgeTypeAttachedTo, 50)
+	dst.AddNode("i-2", "AWS::EC2::Instance", nil)
+	dst.CloseAndWait()
+	if n := len(dst.GetEdges(inst.Index)); n != 2 {
+		t.Errorf("Expected 2 edges after writes, got %d", n)
+	}
+	if dst.GetNode("i-2") == nil || dst.GetNode("i-2").Index != 3 {
+		t.Error("Expected a new node to take the next index")
+	}
+}
+
+func TestLoadBinaryRejectsOtherFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "graph.bin")
+	if err := os.WriteFile(path, []byte("not a graph"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadBinary(path); err == nil {
+		t.Error("Expected an error for a non-graph file")
+	}
+	if _, err := LoadBinary(filepath.Join(t.TempDir(), "missing.bin")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
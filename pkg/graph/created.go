@@ -0,0 +1,33 @@
+package graph
+
+import "time"
+
+// creationTimeKeys are the properties scanners use for a resource's creation time.
+var creationTimeKeys = []string{"LaunchTime", "CreateTime", "CreatedAt", "CreationTime", "CreationDate"}
+
+// creationLayouts parse string timestamps (AMI CreationDate, S3 exports).
+var creationLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05.000Z", "2006-01-02"}
+
+// CreatedAt returns when the resource was created, if a scanner recorded it.
+// Scanners store time.Time, *time.Time, or an ISO-8601 string depending on the SDK.
+func (n *Node) CreatedAt() (time.Time, bool) {
+	for _, key := range creationTimeKeys {
+		switch v := n.Properties[key].(type) {
+		case time.Time:
+			if !v.IsZero() {
+				return v, true
+			}
+		case *time.Time:
+			if v != nil && !v.IsZero() {
+				return *v, true
+			}
+		case string:
+			for _, layout := range creationLayouts {
+				if t, err := time.Parse(layout, v); err == nil {
+					return t, true
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
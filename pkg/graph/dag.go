@@ -55,6 +55,14 @@ type Node struct {
 	Cost           float64
 	SourceLocation string
 	Reachability   ReachabilityState
+
+	// Findings attributes waste to the heuristics that found it; see AddFinding.
+	Findings []Finding
+
+	// Compliance findings are kept apart from waste: they carry no cost and
+	// are reported in their own table.
+	ComplianceViolation bool
+	ComplianceReason    string
 }
 
 // IDStr returns the string representation of the Node ID.
@@ -78,7 +86,7 @@ type ScopeError struct {
 }
 
 type GraphOp struct {
-	Kind      string // "Node" or "Edge"
+	Kind      string // "Node", "Edge" or "Barrier"
 	ID        string // For Node ops, the string ID
 	Type      string // For Node ops, the string Type
 	Props     map[string]interface{}
@@ -87,6 +95,8 @@ type GraphOp struct {
 	TargetID  string      // For Edge ops, the string TargetID
 	EdgeType  EdgeType
 	Weight    int
+
+	done chan struct{} // For Barrier ops, closed once every earlier op is applied
 }
 
 type Graph struct {
@@ -102,6 +112,9 @@ type Graph struct {
 	buildDone chan struct{}
 	quitChan  chan struct{}
 	closed    bool
+
+	// Streaming
+	wasteListener func(id string)
 }
 
 func NewGraph() *Graph {
@@ -137,19 +150,38 @@ func (g *Graph) CloseAndWait() {
 	<-g.buildDone
 }
 
+// maxOpBatch bounds how long the builder holds Mu, so readers are not starved.
+const maxOpBatch = 256
+
 func (g *Graph) builderLoop() {
 	defer close(g.buildDone)
 
-	// Op handler closure
-	handle := func(op GraphOp) {
-		g.Mu.Lock()
+	apply := func(op GraphOp) {
 		switch op.Kind {
 		case "Node":
 			g.unsafeAddNode(op.ID, op.Type, op.Props, op.TypedData)
 		case "Edge":
 			g.unsafeAddEdge(op.SourceID, op.TargetID, op.EdgeType, op.Weight)
+		case "Barrier":
+			close(op.done)
+		}
+	}
+
+	// Op handler closure: applies the op plus whatever is already queued
+	// under a single lock acquisition.
+	handle := func(op GraphOp) {
+		g.Mu.Lock()
+		defer g.Mu.Unlock()
+
+		apply(op)
+		for i := 1; i < maxOpBatch; i++ {
+			select {
+			case next := <-g.opChan:
+				apply(next)
+			default:
+				return
+			}
 		}
-		g.Mu.Unlock()
 	}
 
 	for {
@@ -172,6 +204,29 @@ func (g *Graph) builderLoop() {
 	}
 }
 
+// Flush blocks until every node and edge queued before the call has been applied.
+// Use it before a pass that reads the store directly after concurrent writers.
+// If the graph is closed meanwhile, it returns once the builder has drained.
+func (g *Graph) Flush() {
+	g.Mu.RLock()
+	if g.closed {
+		g.Mu.RUnlock()
+		return
+	}
+	g.Mu.RUnlock()
+
+	done := make(chan struct{})
+	select {
+	case g.opChan <- GraphOp{Kind: "Barrier", done: done}:
+	case <-g.buildDone:
+		return
+	}
+	select {
+	case <-done:
+	case <-g.buildDone:
+	}
+}
+
 func (g *Graph) AddError(scope string, err error) {
 	// Lock required.
 	g.Mu.Lock()
@@ -392,6 +447,14 @@ func (g *Graph) AreConnected(id1, id2 string) bool {
 }
 
 func (g *Graph) MarkWaste(idStr string, score int) {
+	// Notify after the lock is released so listeners may read the graph.
+	var notify func(id string)
+	defer func() {
+		if notify != nil {
+			notify(idStr)
+		}
+	}()
+
 	// Mutex required for thread-safe store updates during concurrent heuristic analysis.
 	g.Mu.Lock()
 	defer g.Mu.Unlock()
@@ -402,44 +465,112 @@ func (g *Graph) MarkWaste(idStr string, score int) {
 	}
 
 	g.Store.UpdateNode(idx, func(node *Node) {
-		// Check for ignore tags.
-		if tags, ok := node.Properties["Tags"].(map[string]string); ok {
-			if val, ok := tags["cloudslash:ignore"]; ok {
-				val = strings.ToLower(strings.TrimSpace(val))
-
-				if val == "true" {
-					return
-				}
+		if g.markWaste(node, score) {
+			notify = g.wasteListener
+		}
+	})
+}
 
-				if strings.HasPrefix(val, "cost<") {
-					limitStr := strings.TrimPrefix(val, "cost<")
-					if limit, err := strconv.ParseFloat(limitStr, 64); err == nil {
-						if node.Cost < limit {
-							return
-						}
-					}
+// markWaste flags node as waste with score unless its cloudslash:ignore tag
+// suppresses it. A justified: tag flags it as justified; the listener is only
+// notified, and true returned, for an unjustified flag.
+func (g *Graph) markWaste(node *Node, score int) bool {
+	// Check for ignore tags.
+	if tags, ok := node.Properties["Tags"].(map[string]string); ok {
+		if val, ok := tags["cloudslash:ignore"]; ok {
+			val = strings.ToLower(strings.TrimSpace(val))
+
+			switch {
+			case val == "true":
+				return false
+			case strings.HasPrefix(val, "cost<"):
+				limit, err := strconv.ParseFloat(strings.TrimPrefix(val, "cost<"), 64)
+				if err != nil {
+					// The owner meant to protect the resource; a typo must not flag it.
+					node.Properties["IgnoreTagError"] = fmt.Sprintf("invalid cost limit in cloudslash:ignore value %q", val)
+					return false
 				}
-
-				if strings.HasPrefix(val, "justified:") {
-					node.IsWaste = true
-					node.Justified = true
-					node.Justification = strings.TrimPrefix(val, "justified:")
-					node.RiskScore = score
-					return
+				if node.Cost < limit {
+					return false
 				}
-
+			case strings.HasPrefix(val, "justified:"):
+				node.IsWaste = true
+				node.Justified = true
+				node.Justification = strings.TrimPrefix(val, "justified:")
+				node.RiskScore = score
+				return false
+			default:
 				if ignoreUntil, err := time.Parse("2006-01-02", val); err == nil {
 					if time.Now().Before(ignoreUntil) {
-						return
+						return false
 					}
+					break
+				}
+				retention, err := parseRetention(val)
+				if err != nil {
+					node.Properties["IgnoreTagError"] = err.Error()
+					return false
+				}
+				// Without a creation time the age is unknown; keep honouring the tag.
+				if created, ok := node.CreatedAt(); !ok || time.Since(created) < retention {
+					return false
 				}
 			}
 		}
-		node.IsWaste = true
-		node.RiskScore = score
+	}
+	node.IsWaste = true
+	node.RiskScore = score
+	return true
+}
+
+// MarkCompliance records a compliance violation on a node. Further reasons
+// for the same node are appended. Waste state and cost are left untouched.
+func (g *Graph) MarkCompliance(idStr, reason string) {
+	g.Mu.Lock()
+	defer g.Mu.Unlock()
+
+	idx, ok := g.Store.GetNodeID(idStr)
+	if !ok {
+		return
+	}
+	g.Store.UpdateNode(idx, func(node *Node) {
+		if node.ComplianceViolation && node.ComplianceReason != "" {
+			node.ComplianceReason += "; " + reason
+		} else {
+			node.ComplianceReason = reason
+		}
+		node.ComplianceViolation = true
 	})
 }
 
+// parseRetention parses a cloudslash:ignore retention period: a whole number
+// of days ("30d") or hours ("12h").
+func parseRetention(val string) (time.Duration, error) {
+	unit := time.Hour
+	switch {
+	case strings.HasSuffix(val, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(val, "h"):
+	default:
+		return 0, fmt.Errorf("unrecognized cloudslash:ignore value %q", val)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(val[:len(val)-1]))
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid retention period in cloudslash:ignore value %q", val)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// SetWasteListener registers a callback invoked whenever MarkWaste or AddFinding
+// flags a node. The callback runs outside the graph lock and may read the node
+// right away, so heuristics that report a reason or cost use AddFinding, which
+// sets them before notifying. Pass nil to unregister.
+func (g *Graph) SetWasteListener(fn func(id string)) {
+	g.Mu.Lock()
+	defer g.Mu.Unlock()
+	g.wasteListener = fn
+}
+
 func (g *Graph) GetDownstream(id string) []string {
 	idx, ok := g.Store.GetNodeID(id)
 	if !ok {
@@ -470,8 +601,10 @@ func (g *Graph) GetUpstream(id string) []string {
 	return upstream
 }
 
+// DumpStats summarizes the graph. g.Mu must not be held.
 func (g *Graph) DumpStats() string {
 	// Basic stats.
 	count := g.Store.NodeCount()
-	return fmt.Sprintf("Nodes: %d | Storage: Memory", count)
+	cycles := g.DetectCycles()
+	return fmt.Sprintf("Nodes: %d | Cycles: %d | Storage: Memory", count, len(cycles))
 }
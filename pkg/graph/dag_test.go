@@ -1,7 +1,9 @@
 package graph
 
 import (
+	"fmt"
 	"testing"
+	"time"
 )
 
 func TestMarkWaste_AdvancedSuppression(t *testing.T) {
@@ -70,3 +72,177 @@ func TestMarkWaste_AdvancedSuppression(t *testing.T) {
 		t.Errorf("Future date snoozed node should be ignored")
 	}
 }
+
+func TestMarkWaste_IgnoreTagValues(t *testing.T) {
+	created := time.Now().Add(-100 * 24 * time.Hour)
+	tests := []struct {
+		tag       string
+		cost      float64
+		noCreated bool
+		waste     bool
+		justified bool
+		tagError  bool
+	}{
+		{tag: "true"},
+		{tag: " TRUE "},
+		{tag: "120d"},
+		{tag: "30d", waste: true},
+		{tag: "3000h"},
+		{tag: "12h", waste: true},
+		{tag: "0d", waste: true},
+		{tag: "30d", noCreated: true},
+		{tag: "cost<10", cost: 5},
+		{tag: "cost<10", cost: 15, waste: true},
+		{tag: "justified:DisasterRecovery", waste: true, justified: true},
+		{tag: "2099-01-01"},
+		{tag: "2000-01-01", waste: true},
+		{tag: "abcd", tagError: true},
+		{tag: "abcd", noCreated: true, tagError: true},
+		{tag: "xd", tagError: true},
+		{tag: "-5d", tagError: true},
+		{tag: "1.5d", tagError: true},
+		{tag: "d", tagError: true},
+		{tag: "30w", tagError: true},
+		{tag: "cost<ten", tagError: true},
+		{tag: "2026-13-01", tagError: true},
+	}
+
+	g := NewGraph()
+	for i, tt := range tests {
+		props := map[string]interface{}{
+			"Tags": map[string]string{"cloudslash:ignore": tt.tag},
+		}
+		if !tt.noCreated {
+			props["CreateTime"] = created
+		}
+		g.AddNode(fmt.Sprintf("node-%d", i), "Test", props)
+	}
+	g.CloseAndWait()
+
+	for i, tt := range tests {
+		id := fmt.Sprintf("node-%d", i)
+		g.GetNode(id).Cost = tt.cost
+		g.MarkWaste(id, 80)
+
+		node := g.GetNode(id)
+		if node.IsWaste != tt.waste {
+			t.Errorf("%q (created=%v): IsWaste = %v, want %v", tt.tag, !tt.noCreated, node.IsWaste, tt.waste)
+		}
+		if node.Justified != tt.justified {
+			t.Errorf("%q: Justified = %v, want %v", tt.tag, node.Justified, tt.justified)
+		}
+		if _, ok := node.Properties["IgnoreTagError"]; ok != tt.tagError {
+			t.Errorf("%q: IgnoreTagError set = %v, want %v", tt.tag, ok, tt.tagError)
+		}
+	}
+}
+
+func TestFlushAppliesQueuedOps(t *testing.T) {
+	g := NewGraph()
+	defer g.CloseAndWait()
+
+	for i := 0; i < 1000; i++ {
+		g.AddNode(fmt.Sprintf("node-%d", i), "test", nil)
+	}
+	g.Flush()
+
+	g.Mu.RLock()
+	n := len(g.Store.GetAllNodes())
+	g.Mu.RUnlock()
+	if n != 1000 {
+		t.Fatalf("Expected 1000 nodes after Flush, got %d", n)
+	}
+}
+
+func TestFlushReturnsAfterClose(t *testing.T) {
+	g := NewGraph()
+	g.AddNode("node-1", "test", nil)
+	g.CloseAndWait()
+
+	// A Flush that passed its closed check just before CloseAndWait ran:
+	// the builder is gone and nothing will apply its barrier.
+	g.Mu.Lock()
+	g.closed = false
+	g.Mu.Unlock()
+
+	flushed := make(chan struct{})
+	go func() {
+		g.Flush()
+		close(flushed)
+	}()
+	select {
+	case <-flushed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Flush blocked after the builder exited")
+	}
+}
+
+func TestAddFinding_AttributesEachHeuristic(t *testing.T) {
+	g := NewGraph()
+	g.AddNode("i-1", "AWS::EC2::Instance", map[string]interface{}{})
+	g.AddNode("i-2", "AWS::EC2::Instance", map[string]interface{}{
+		"Tags": map[string]string{"cloudslash:ignore": "true"},
+	})
+	g.CloseAndWait()
+
+	// A reason set without a finding is kept once findings arrive.
+	g.MarkWaste("i-1", 50)
+	g.GetNode("i-1").Properties["Reason"] = "Stopped for 30 days"
+
+	g.AddFinding("i-1", Finding{Heuristic: "UnderutilizedInstanceHeuristic", Reason: "Max CPU 1%", Score: 60, Savings: 40})
+	g.AddFinding("i-1", Finding{Heuristic: "IAMHeuristic", Reason: "Dangerous permissions", Score: 95})
+	g.AddFinding("i-1", Finding{Heuristic: "UnderutilizedInstanceHeuristic", Reason: "Max CPU 2%", Score: 60, Savings: 40})
+	g.AddFinding("i-2", Finding{Heuristic: "IAMHeuristic", Reason: "Dangerous permissions", Score: 95})
+
+	node := g.GetNode("i-1")
+	findings := node.FindingList()
+	if len(findings) != 3 {
+		t.Fatalf("Expected 3 findings (legacy + 2 heuristics), got %+v", findings)
+	}
+	if findings[0].Heuristic != "" || findings[0].Reason != "Stopped for 30 days" {
+		t.Errorf("Expected the earlier reason as an unattributed finding, got %+v", findings[0])
+	}
+	if !node.IsWaste || node.RiskScore != 95 || node.Cost != 40 {
+		t.Errorf("Expected aggregates IsWaste/95/$40, got %v/%d/$%.2f", node.IsWaste, node.RiskScore, node.Cost)
+	}
+	if reason := node.Properties["Reason"]; reason != "Stopped for 30 days; Max CPU 2%; Dangerous permissions" {
+		t.Errorf("Expected joined reasons with the replaced finding, got %q", reason)
+	}
+
+	if ignored := g.GetNode("i-2"); ignored.IsWaste || len(ignored.Findings) != 0 {
+		t.Error("Expected cloudslash:ignore to suppress the finding")
+	}
+}
+
+func TestAddFinding_KeepsDemotionsAndDirectReasons(t *testing.T) {
+	g := NewGraph()
+	g.AddNode("i-1", "AWS::EC2::Instance", map[string]interface{}{})
+	g.CloseAndWait()
+
+	g.AddFinding("i-1", Finding{Heuristic: "UnderutilizedInstanceHeuristic", Reason: "Max CPU 1%", Score: 60})
+	node := g.GetNode("i-1")
+	// A later heuristic demotes the finding and rewrites the reason directly.
+	node.RiskScore = 45
+	node.Properties["Reason"] = "Compute Optimizer disagrees"
+
+	g.AddFinding("i-1", Finding{Heuristic: "TagComplianceHeuristic", Reason: "Missing Tags: Owner", Score: 40})
+	g.AddFinding("i-1", Finding{Heuristic: "PolicyBlockHeuristic", Reason: "blocked by policy"})
+
+	if node.RiskScore != 45 {
+		t.Errorf("Expected the demoted score to stay at 45, got %d", node.RiskScore)
+	}
+	want := "Max CPU 1%; Compute Optimizer disagrees; Missing Tags: Owner; blocked by policy"
+	if reason := node.Properties["Reason"]; reason != want {
+		t.Errorf("Expected %q, got %q", want, reason)
+	}
+
+	// Appended directly after the last finding: listed, not yet recorded.
+	node.Properties["Reason"] = want + "; Stopped for 30 days"
+	findings := node.FindingList()
+	if last := findings[len(findings)-1]; len(findings) != 5 || last.Heuristic != "" || last.Reason != "Stopped for 30 days" {
+		t.Errorf("Expected the appended reason as a fifth unattributed finding, got %+v", findings)
+	}
+	if len(node.Findings) != 4 {
+		t.Errorf("FindingList must not modify the node, got %d findings", len(node.Findings))
+	}
+}
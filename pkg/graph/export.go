@@ -0,0 +1,72 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ShortID returns the trailing resource ID of an ARN:
+// arn:aws:ec2:region:account:instance/i-1234 -> i-1234.
+func ShortID(arn string) string {
+	if len(arn) > 15 {
+		lastSlash := strings.LastIndex(arn, "/")
+		if lastSlash != -1 && lastSlash < len(arn)-1 {
+			return arn[lastSlash+1:]
+		}
+	}
+	return arn
+}
+
+// ExportDOT writes the graph in GraphViz DOT format.
+// Waste nodes are red; nodes only known as edge targets ("Unknown" type) are dashed.
+func (g *Graph) ExportDOT(w io.Writer) error {
+	g.Mu.RLock()
+	defer g.Mu.RUnlock()
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph cloudslash {")
+	fmt.Fprintln(bw, "  rankdir=LR;")
+	fmt.Fprintln(bw, `  node [shape=box, style="rounded,filled", fillcolor="#f8fafc", fontname="Helvetica", fontsize=10];`)
+	fmt.Fprintln(bw, `  edge [fontname="Helvetica", fontsize=8, color="#64748b"];`)
+
+	nodes := g.Store.GetAllNodes()
+	for _, n := range nodes {
+		label := ShortID(n.IDStr()) + "\n" + n.TypeStr()
+		attrs := ""
+		switch {
+		case n.TypeStr() == "Unknown":
+			label = ShortID(n.IDStr()) + "\n(not scanned)"
+			attrs = `, style="rounded,dashed", color="#94a3b8", fontcolor="#64748b"`
+		case n.IsWaste:
+			label += fmt.Sprintf("\n$%.2f/mo", n.Cost)
+			attrs = `, fillcolor="#fecaca", color="#dc2626"`
+		}
+		fmt.Fprintf(bw, "  n%d [label=%s, tooltip=%s%s];\n", n.Index, dotQuote(label), dotQuote(n.IDStr()), attrs)
+	}
+
+	for _, n := range nodes {
+		for _, e := range g.Store.GetEdges(n.Index) {
+			if g.Store.GetNode(e.TargetID) == nil {
+				continue
+			}
+			edgeType := e.Type
+			if edgeType == "" {
+				edgeType = EdgeTypeUnknown
+			}
+			fmt.Fprintf(bw, "  n%d -> n%d [label=%s];\n", n.Index, e.TargetID, dotQuote(string(edgeType)))
+		}
+	}
+
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// dotQuote returns s as a quoted DOT string; newlines become line breaks.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
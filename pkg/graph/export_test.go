@@ -0,0 +1,40 @@
+package graph
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestExportDOT(t *testing.T) {
+	g := NewGraph()
+	g.AddNode("arn:aws:ec2:us-east-1:123:instance/i-abc", "AWS::EC2::Instance", nil)
+	g.AddNode("arn:aws:ec2:us-east-1:123:volume/vol-1", "AWS::EC2::Volume", nil)
+	g.AddTypedEdge("arn:aws:ec2:us-east-1:123:volume/vol-1", "arn:aws:ec2:us-east-1:123:instance/i-abc", EdgeTypeAttachedTo, 100)
+	// Auto-vivifies an "Unknown" target.
+	g.AddTypedEdge("arn:aws:ec2:us-east-1:123:instance/i-abc", `sg-"quoted"`, EdgeTypeSecuredBy, 1)
+	g.CloseAndWait()
+	g.MarkWaste("arn:aws:ec2:us-east-1:123:volume/vol-1", 90)
+
+	var buf bytes.Buffer
+	if err := g.ExportDOT(&buf); err != nil {
+		t.Fatalf("ExportDOT failed: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"digraph cloudslash {",
+		`label="vol-1\nAWS::EC2::Volume\n$0.00/mo", tooltip="arn:aws:ec2:us-east-1:123:volume/vol-1", fillcolor="#fecaca"`,
+		`label="i-abc\nAWS::EC2::Instance"`,
+		`label="sg-\"quoted\"\n(not scanned)"`,
+		`[label="AttachedTo"]`,
+		`[label="SecuredBy"]`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("DOT output missing %q:\n%s", want, out)
+		}
+	}
+	if !strings.HasSuffix(out, "}\n") {
+		t.Errorf("DOT output not terminated:\n%s", out)
+	}
+}
@@ -0,0 +1,143 @@
+package graph
+
+import "strings"
+
+// Finding is one heuristic's verdict on a resource. A resource can carry
+// findings from several heuristics; IsWaste, RiskScore and the Reason
+// property are kept as aggregates of them for existing readers.
+type Finding struct {
+	Heuristic string  `json:"heuristic,omitempty"` // Empty for a reason set without a finding.
+	Reason    string  `json:"reason"`
+	Score     int     `json:"score"`
+	Action    string  `json:"action,omitempty"`  // Suggested remediation.
+	Savings   float64 `json:"savings,omitempty"` // Estimated monthly savings in USD.
+}
+
+// AddFinding records f on the node, replacing an earlier finding from the
+// same heuristic, and updates the aggregates: the node becomes waste, its
+// RiskScore and Cost are raised to the finding's score and savings if lower,
+// and Reason joins every finding's reason. A score another heuristic lowered
+// stays lowered unless f scores higher. The caller holds the graph lock;
+// heuristics that do not should use Graph.AddFinding.
+func (n *Node) AddFinding(f Finding) {
+	n.adoptReason()
+
+	replaced := false
+	if f.Heuristic != "" {
+		for i := range n.Findings {
+			if n.Findings[i].Heuristic == f.Heuristic {
+				n.Findings[i] = f
+				replaced = true
+				break
+			}
+		}
+	}
+	if !replaced {
+		n.Findings = append(n.Findings, f)
+	}
+
+	n.IsWaste = true
+	n.RiskScore = max(n.RiskScore, f.Score)
+	n.Cost = max(n.Cost, f.Savings)
+	if n.Properties == nil {
+		n.Properties = make(map[string]interface{})
+	}
+	n.Properties["Reason"] = n.recordedReason()
+}
+
+// recordedReason joins the reasons of the node's findings.
+func (n *Node) recordedReason() string {
+	reasons := make([]string, 0, len(n.Findings))
+	for _, finding := range n.Findings {
+		if finding.Reason != "" {
+			reasons = append(reasons, finding.Reason)
+		}
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// unrecordedReason returns the part of a waste node's Reason that a heuristic
+// set directly rather than through a finding: all of it before any finding,
+// what was appended to the recorded reasons, or the whole Reason when it was
+// overwritten.
+func (n *Node) unrecordedReason() string {
+	if !n.IsWaste {
+		return ""
+	}
+	reason, _ := n.Properties["Reason"].(string)
+	if len(n.Findings) == 0 {
+		return reason
+	}
+	recorded := n.recordedReason()
+	if strings.HasPrefix(reason, recorded) {
+		return strings.TrimLeft(reason[len(recorded):], "; ")
+	}
+	return reason
+}
+
+// adoptReason turns a Reason set directly by a heuristic into an unattributed
+// finding so a later finding appends to it instead of dropping it.
+func (n *Node) adoptReason() {
+	extra := n.unrecordedReason()
+	if extra == "" {
+		return
+	}
+	if len(n.Findings) == 0 {
+		n.Findings = append(n.Findings, Finding{Reason: extra, Score: n.RiskScore, Savings: n.Cost})
+	} else {
+		n.Findings = append(n.Findings, Finding{Reason: extra, Score: n.RiskScore})
+	}
+	n.Properties["Reason"] = n.recordedReason()
+}
+
+// FindingList returns the node's findings. A Reason set without a finding is
+// returned as an unattributed finding built from it.
+func (n *Node) FindingList() []Finding {
+	extra := n.unrecordedReason()
+	if len(n.Findings) == 0 {
+		if !n.IsWaste {
+			return nil
+		}
+		if extra == "" {
+			extra = n.WasteReason
+		}
+		return []Finding{{Reason: extra, Score: n.RiskScore, Savings: n.Cost}}
+	}
+	if extra == "" {
+		return n.Findings
+	}
+	return append(n.Findings[:len(n.Findings):len(n.Findings)], Finding{Reason: extra, Score: n.RiskScore})
+}
+
+// AddFinding is MarkWaste with attribution: it records f on the node unless
+// a cloudslash:ignore tag suppresses it, then notifies the waste listener.
+func (g *Graph) AddFinding(idStr string, f Finding) {
+	var notify func(id string)
+	defer func() {
+		if notify != nil {
+			notify(idStr)
+		}
+	}()
+
+	g.Mu.Lock()
+	defer g.Mu.Unlock()
+
+	idx, ok := g.Store.GetNodeID(idStr)
+	if !ok {
+		return
+	}
+	g.Store.UpdateNode(idx, func(node *Node) {
+		node.adoptReason()
+		// Keep a score another heuristic lowered; AddFinding only raises it.
+		score, flagged := node.RiskScore, node.IsWaste
+		if g.markWaste(node, f.Score) {
+			notify = g.wasteListener
+		}
+		if flagged {
+			node.RiskScore = score
+		}
+		if node.IsWaste {
+			node.AddFinding(f)
+		}
+	})
+}
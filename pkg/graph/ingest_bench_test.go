@@ -0,0 +1,43 @@
+package graph
+
+import (
+	"fmt"
+	"testing"
+)
+
+// ingest queues nodes and hub-heavy edges, roughly like a large account:
+// most resources hang off a handful of VPCs and subnets.
+func ingest(g *Graph, nodes int) {
+	const hubs = 8
+	for i := 0; i < nodes; i++ {
+		g.AddNode(fmt.Sprintf("res-%d", i), "AWS::Bench::Resource", map[string]interface{}{})
+		g.AddTypedEdge(fmt.Sprintf("hub-%d", i%hubs), fmt.Sprintf("res-%d", i), EdgeTypeContains, 1)
+	}
+}
+
+// BenchmarkGraphIngestion measures 500k queued node/edge ops.
+func BenchmarkGraphIngestion(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		g := NewGraph()
+		ingest(g, 250000)
+		g.CloseAndWait()
+	}
+}
+
+func TestEdgeDedupOnHubNodes(t *testing.T) {
+	g := NewGraph()
+	ingest(g, 5000)
+	// Replay the same edges; none may be added twice.
+	ingest(g, 5000)
+	g.AddTypedEdge("hub-0", "res-0", EdgeTypeUses, 1) // Same pair, different type.
+	g.CloseAndWait()
+
+	hub := g.GetNode("hub-0")
+	edges := g.GetEdges(hub.Index)
+	if len(edges) != 5000/8+1 {
+		t.Fatalf("Expected %d edges from hub-0, got %d", 5000/8+1, len(edges))
+	}
+	if rev := g.GetReverseEdges(g.GetNode("res-0").Index); len(rev) != 2 {
+		t.Errorf("Expected 2 reverse edges on res-0, got %d", len(rev))
+	}
+}
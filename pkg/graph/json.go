@@ -0,0 +1,255 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// JSONNode is the graph state of one finding in waste_report.json. The JSON
+// keys match report.ExportItem, so a report file decodes directly into it.
+type JSONNode struct {
+	ID             string                 `json:"resource_id"`
+	Type           string                 `json:"type"`
+	Cost           float64                `json:"monthly_cost"`
+	RiskScore      int                    `json:"risk_score"`
+	Justified      bool                   `json:"justified,omitempty"`
+	Justification  string                 `json:"justification,omitempty"`
+	WasteReason    string                 `json:"waste_reason,omitempty"`
+	SourceLocation string                 `json:"source_location,omitempty"`
+	Findings       []Finding              `json:"findings,omitempty"`
+	Properties     map[string]interface{} `json:"properties,omitempty"`
+	PropertyTypes  map[string]string      `json:"property_types,omitempty"`
+	Edges          []JSONEdge             `json:"edges,omitempty"`
+}
+
+// JSONEdge is an edge between a finding and Peer. Inbound edges run from the
+// peer to the finding. PeerType lets a peer that is not itself a finding be
+// restored with its type.
+type JSONEdge struct {
+	Peer     string   `json:"peer"`
+	PeerType string   `json:"peer_type,omitempty"`
+	Type     EdgeType `json:"type"`
+	Weight   int      `json:"weight"`
+	Inbound  bool     `json:"inbound,omitempty"`
+}
+
+// EncodeProperties prepares node properties for JSON. Values JSON cannot tell
+// apart from another Go type (ints, times, typed slices and maps) are named in
+// the returned type map so DecodeProperties can restore them. Values that do
+// not marshal (NaN, channels) are dropped.
+func EncodeProperties(props map[string]interface{}) (map[string]interface{}, map[string]string) {
+	values := make(map[string]interface{}, len(props))
+	types := make(map[string]string)
+	for k, v := range props {
+		if t, ok := v.(*time.Time); ok {
+			if t == nil {
+				continue
+			}
+			v = *t
+		}
+		if _, err := json.Marshal(v); err != nil {
+			continue
+		}
+		values[k] = v
+		switch v.(type) {
+		case int:
+			types[k] = "int"
+		case int32:
+			types[k] = "int32"
+		case int64:
+			types[k] = "int64"
+		case time.Time:
+			types[k] = "time"
+		case []string:
+			types[k] = "[]string"
+		case []float64:
+			types[k] = "[]float64"
+		case map[string]string:
+			types[k] = "map[string]string"
+		}
+	}
+	if len(types) == 0 {
+		types = nil
+	}
+	return values, types
+}
+
+// DecodeProperties reverses EncodeProperties on generically decoded JSON values.
+func DecodeProperties(values map[string]interface{}, types map[string]string) (map[string]interface{}, error) {
+	props := make(map[string]interface{}, len(values))
+	for k, v := range values {
+		t, typed := types[k]
+		if !typed {
+			props[k] = v
+			continue
+		}
+		decoded, err := decodeValue(t, v)
+		if err != nil {
+			return nil, fmt.Errorf("property %s: %v", k, err)
+		}
+		props[k] = decoded
+	}
+	return props, nil
+}
+
+func decodeValue(t string, v interface{}) (interface{}, error) {
+	switch t {
+	case "int", "int32", "int64":
+		f, ok := v.(float64)
+		if !ok {
+			return nil, fmt.Errorf("want number for %s, got %T", t, v)
+		}
+		switch t {
+		case "int32":
+			return int32(f), nil
+		case "int64":
+			return int64(f), nil
+		}
+		return int(f), nil
+	case "time":
+		s, _ := v.(string)
+		ts, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return nil, err
+		}
+		return ts, nil
+	case "[]string", "[]float64":
+		list, ok := v.([]interface{})
+		if !ok && v != nil {
+			return nil, fmt.Errorf("want list for %s, got %T", t, v)
+		}
+		if t == "[]string" {
+			out := make([]string, 0, len(list))
+			for _, item := range list {
+				s, _ := item.(string)
+				out = append(out, s)
+			}
+			return out, nil
+		}
+		out := make([]float64, 0, len(list))
+		for _, item := range list {
+			f, _ := item.(float64)
+			out = append(out, f)
+		}
+		return out, nil
+	case "map[string]string":
+		m, ok := v.(map[string]interface{})
+		if !ok && v != nil {
+			return nil, fmt.Errorf("want object for %s, got %T", t, v)
+		}
+		out := make(map[string]string, len(m))
+		for mk, mv := range m {
+			s, _ := mv.(string)
+			out[mk] = s
+		}
+		return out, nil
+	}
+	return v, nil
+}
+
+// JSONEdges lists the edges into and out of node for a report. Requires g.Mu held.
+func (g *Graph) JSONEdges(node *Node) []JSONEdge {
+	var edges []JSONEdge
+	add := func(list []Edge, inbound bool) {
+		for _, e := range list {
+			peer := g.Store.GetNode(e.TargetID)
+			if peer == nil {
+				continue
+			}
+			edges = append(edges, JSONEdge{
+				Peer:     peer.IDStr(),
+				PeerType: peer.TypeStr(),
+				Type:     e.Type,
+				Weight:   e.Weight,
+				Inbound:  inbound,
+			})
+		}
+	}
+	add(g.Store.GetEdges(node.Index), false)
+	add(g.Store.GetReverseEdges(node.Index), true)
+	return edges
+}
+
+// LoadFromJSON rebuilds a graph from a findings report written by
+// report.GenerateJSON. Every finding becomes a waste node with its cost, risk
+// score and properties; edges are restored, and peers that are not findings
+// are added with their type only. Resources that were neither waste nor
+// linked to a finding are not in the report, so they are not in the graph.
+// The returned graph is closed: it is for reading, not for further scans.
+func LoadFromJSON(r io.Reader) (*Graph, error) {
+	var nodes []JSONNode
+	if err := json.NewDecoder(r).Decode(&nodes); err != nil {
+		return nil, fmt.Errorf("failed to parse report: %v", err)
+	}
+
+	type edgeKey struct {
+		source, target string
+		edgeType       EdgeType
+	}
+	findings := make(map[string]JSONNode, len(nodes))
+	peers := make(map[string]string)
+	edges := make(map[edgeKey]int)
+	var order []edgeKey
+
+	g := NewGraph()
+	for i, n := range nodes {
+		if n.ID == "" || n.Type == "" {
+			g.CloseAndWait()
+			return nil, fmt.Errorf("finding %d is missing resource_id or type", i)
+		}
+		if _, dup := findings[n.ID]; dup {
+			continue
+		}
+		props, err := DecodeProperties(n.Properties, n.PropertyTypes)
+		if err != nil {
+			g.CloseAndWait()
+			return nil, fmt.Errorf("finding %s: %v", n.ID, err)
+		}
+		findings[n.ID] = n
+		g.AddNode(n.ID, n.Type, props)
+
+		for _, e := range n.Edges {
+			if e.PeerType != "" {
+				peers[e.Peer] = e.PeerType
+			}
+			key := edgeKey{n.ID, e.Peer, e.Type}
+			if e.Inbound {
+				key = edgeKey{e.Peer, n.ID, e.Type}
+			}
+			// An edge between two findings is listed on both ends.
+			if _, seen := edges[key]; !seen {
+				order = append(order, key)
+			}
+			edges[key] = e.Weight
+		}
+	}
+	for id, t := range peers {
+		if _, isFinding := findings[id]; !isFinding {
+			g.AddNode(id, t, map[string]interface{}{})
+		}
+	}
+	for _, key := range order {
+		g.AddTypedEdge(key.source, key.target, key.edgeType, edges[key])
+	}
+	g.CloseAndWait()
+
+	g.Mu.Lock()
+	defer g.Mu.Unlock()
+	for id, n := range findings {
+		node := g.GetNode(id)
+		if node == nil {
+			continue
+		}
+		node.IsWaste = true
+		node.Cost = n.Cost
+		node.RiskScore = n.RiskScore
+		node.Justified = n.Justified
+		node.Justification = n.Justification
+		node.WasteReason = n.WasteReason
+		node.SourceLocation = n.SourceLocation
+		node.Findings = n.Findings
+	}
+	return g, nil
+}
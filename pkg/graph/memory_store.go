@@ -6,6 +6,13 @@ import (
 	"github.com/DrSkyle/cloudslash/v2/pkg/sys/intern"
 )
 
+// edgeKey identifies an edge for deduplication.
+type edgeKey struct {
+	source uint32
+	target uint32
+	typ    EdgeType
+}
+
 // MemoryStore is an in-memory graph storage.
 type MemoryStore struct {
 	mu           sync.RWMutex
@@ -13,6 +20,7 @@ type MemoryStore struct {
 	edges        [][]Edge
 	reverseEdges [][]Edge
 	idMap        map[uint32]uint32 // Interned String ID -> Index
+	edgeSet      map[edgeKey]struct{}
 }
 
 func NewMemoryStore() *MemoryStore {
@@ -21,6 +29,7 @@ func NewMemoryStore() *MemoryStore {
 		edges:        make([][]Edge, 0, 1000),
 		reverseEdges: make([][]Edge, 0, 1000),
 		idMap:        make(map[uint32]uint32),
+		edgeSet:      make(map[edgeKey]struct{}),
 	}
 }
 
@@ -107,12 +116,12 @@ func (s *MemoryStore) AddEdge(sourceIndex uint32, edge Edge) {
 		return
 	}
 
-	// Check duplicates.
-	for _, e := range s.edges[sourceIndex] {
-		if e.TargetID == edge.TargetID && e.Type == edge.Type {
-			return
-		}
+	// Check duplicates in O(1); hub nodes can have tens of thousands of edges.
+	key := edgeKey{source: sourceIndex, target: edge.TargetID, typ: edge.Type}
+	if _, dup := s.edgeSet[key]; dup {
+		return
 	}
+	s.edgeSet[key] = struct{}{}
 
 	s.edges[sourceIndex] = append(s.edges[sourceIndex], edge)
 
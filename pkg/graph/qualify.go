@@ -0,0 +1,48 @@
+package graph
+
+import "strings"
+
+// QualifyID prefixes a non-ARN ID with the account it was scanned in
+// ("123456789012/my-table"). ARNs already carry their account; bare IDs such
+// as names do not, and would collide when several accounts are merged into
+// one graph.
+func QualifyID(account, id string) string {
+	if account == "" || id == "" || strings.HasPrefix(id, "arn:") || qualified(id) {
+		return id
+	}
+	return account + "/" + id
+}
+
+// UnqualifyID returns the ID an AWS API knows a resource by, without the
+// account QualifyID added.
+func UnqualifyID(id string) string {
+	if qualified(id) {
+		return id[13:]
+	}
+	return id
+}
+
+// qualified reports whether id starts with a 12-digit account and a slash.
+func qualified(id string) bool {
+	if len(id) < 14 || id[12] != '/' {
+		return false
+	}
+	for _, r := range id[:12] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Qualify rewrites the snapshot's non-ARN node IDs and edge endpoints with
+// QualifyID, for merging scopes from several accounts.
+func (s *Snapshot) Qualify(account string) {
+	for i := range s.Nodes {
+		s.Nodes[i].ID = QualifyID(account, s.Nodes[i].ID)
+	}
+	for i := range s.Edges {
+		s.Edges[i].SourceID = QualifyID(account, s.Edges[i].SourceID)
+		s.Edges[i].TargetID = QualifyID(account, s.Edges[i].TargetID)
+	}
+}
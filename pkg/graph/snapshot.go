@@ -0,0 +1,121 @@
+package graph
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+	"time"
+)
+
+func init() {
+	// Property value types written by the scanners.
+	// Resource structs carried in TypedData are registered by their packages' callers.
+	gob.Register(map[string]string{})
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+	gob.Register([]string{})
+	gob.Register(time.Time{}) // gob flattens pointers, so *time.Time decodes as time.Time.
+}
+
+// Snapshot is a serializable copy of a graph's nodes and edges.
+// It captures scan output only; analysis state (waste flags, costs) is not kept.
+type Snapshot struct {
+	Nodes        []SnapshotNode
+	Edges        []SnapshotEdge
+	FailedScopes []ScopeError
+}
+
+// SnapshotNode is a node keyed by its string ID.
+type SnapshotNode struct {
+	ID         string
+	Type       string
+	Properties map[string]interface{}
+	TypedData  interface{}
+}
+
+// SnapshotEdge is an edge keyed by string IDs.
+type SnapshotEdge struct {
+	SourceID string
+	TargetID string
+	Type     EdgeType
+	Weight   int
+}
+
+// Snapshot copies the graph's current contents.
+// Property maps are copied so later writes to the graph do not leak into it.
+func (g *Graph) Snapshot() *Snapshot {
+	g.Mu.RLock()
+	defer g.Mu.RUnlock()
+
+	s := &Snapshot{
+		FailedScopes: append([]ScopeError(nil), g.Metadata.FailedScopes...),
+	}
+
+	for _, node := range g.Store.GetAllNodes() {
+		props := make(map[string]interface{}, len(node.Properties))
+		for k, v := range node.Properties {
+			props[k] = v
+		}
+		s.Nodes = append(s.Nodes, SnapshotNode{
+			ID:         node.IDStr(),
+			Type:       node.TypeStr(),
+			Properties: props,
+			TypedData:  node.TypedData,
+		})
+
+		for _, e := range g.Store.GetEdges(node.Index) {
+			target := g.Store.GetNode(e.TargetID)
+			if target == nil {
+				continue
+			}
+			s.Edges = append(s.Edges, SnapshotEdge{
+				SourceID: node.IDStr(),
+				TargetID: target.IDStr(),
+				Type:     e.Type,
+				Weight:   e.Weight,
+			})
+		}
+	}
+	return s
+}
+
+// Merge queues a snapshot's nodes and edges into the graph.
+// Existing nodes keep their state and gain the snapshot's properties.
+// Like AddNode, writes are asynchronous; call CloseAndWait before reading.
+func (g *Graph) Merge(s *Snapshot) error {
+	for _, n := range s.Nodes {
+		props := make(map[string]interface{}, len(n.Properties))
+		for k, v := range n.Properties {
+			props[k] = v
+		}
+		if err := g.AddTypedNode(n.ID, n.Type, props, n.TypedData); err != nil {
+			return err
+		}
+	}
+	for _, e := range s.Edges {
+		if err := g.AddTypedEdge(e.SourceID, e.TargetID, e.Type, e.Weight); err != nil {
+			return err
+		}
+	}
+	for _, fs := range s.FailedScopes {
+		g.AddError(fs.Scope, fmt.Errorf("%s", fs.Error))
+	}
+	return nil
+}
+
+// Encode writes the snapshot in gob format.
+func (s *Snapshot) Encode(w io.Writer) error {
+	if err := gob.NewEncoder(w).Encode(s); err != nil {
+		return fmt.Errorf("failed to encode graph snapshot: %v", err)
+	}
+	return nil
+}
+
+// DecodeSnapshot reads a snapshot written by Encode.
+func DecodeSnapshot(r io.Reader) (*Snapshot, error) {
+	var s Snapshot
+	if err := gob.NewDecoder(r).Decode(&s); err != nil {
+		return nil, fmt.Errorf("failed to decode graph snapshot: %v", err)
+	}
+	return &s, nil
+}
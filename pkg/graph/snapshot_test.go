@@ -0,0 +1,91 @@
+package graph
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSnapshotRoundTripAndMerge(t *testing.T) {
+	launched := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	src := NewGraph()
+	src.AddNode("vol-1", "AWS::EC2::Volume", map[string]interface{}{
+		"Size":       int32(100),
+		"Tags":       map[string]string{"Env": "dev"},
+		"Subnets":    []string{"subnet-a", "subnet-b"},
+		"LaunchTime": &launched,
+	})
+	src.AddTypedEdge("i-1", "vol-1", EdgeTypeAttachedTo, 50)
+	src.AddError("default:us-east-1 [EC2]", errors.New("throttled"))
+	src.CloseAndWait()
+
+	var buf bytes.Buffer
+	if err := src.Snapshot().Encode(&buf); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	snap, err := DecodeSnapshot(&buf)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+
+	dst := NewGraph()
+	dst.AddNode("i-1", "AWS::EC2::Instance", map[string]interface{}{"State": "running"})
+	if err := dst.Merge(snap); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	dst.CloseAndWait()
+
+	vol := dst.GetNode("vol-1")
+	if vol == nil {
+		t.Fatal("Expected merged volume node")
+	}
+	if size, ok := vol.Properties["Size"].(int32); !ok || size != 100 {
+		t.Errorf("Expected int32 Size to survive, got %#v", vol.Properties["Size"])
+	}
+	if tags, ok := vol.Properties["Tags"].(map[string]string); !ok || tags["Env"] != "dev" {
+		t.Errorf("Expected typed Tags to survive, got %#v", vol.Properties["Tags"])
+	}
+	if lt, ok := vol.Properties["LaunchTime"].(time.Time); !ok || !lt.Equal(launched) {
+		t.Errorf("Expected LaunchTime to survive, got %#v", vol.Properties["LaunchTime"])
+	}
+
+	// The live node keeps its type; the auto-vivified copy must not downgrade it.
+	inst := dst.GetNode("i-1")
+	if inst.TypeStr() != "AWS::EC2::Instance" {
+		t.Errorf("Expected instance type to be kept, got %s", inst.TypeStr())
+	}
+	edges := dst.GetEdges(inst.Index)
+	if len(edges) != 1 || edges[0].Type != EdgeTypeAttachedTo || edges[0].TargetID != vol.Index {
+		t.Errorf("Expected AttachedTo edge to be merged, got %+v", edges)
+	}
+	if len(dst.Metadata.FailedScopes) != 1 {
+		t.Errorf("Expected scope errors to be merged, got %+v", dst.Metadata.FailedScopes)
+	}
+}
+
+func TestSnapshotQualifyKeepsAccountsApart(t *testing.T) {
+	snap := &Snapshot{
+		Nodes: []SnapshotNode{
+			{ID: "orders", Type: "AWS::DynamoDB::Table"},
+			{ID: "arn:aws:lambda:us-east-1:111111111111:function:f", Type: "AWS::Lambda::Function"},
+		},
+		Edges: []SnapshotEdge{{SourceID: "arn:aws:lambda:us-east-1:111111111111:function:f", TargetID: "orders"}},
+	}
+	snap.Qualify("111111111111")
+	snap.Qualify("111111111111")
+
+	if snap.Nodes[0].ID != "111111111111/orders" {
+		t.Errorf("Expected bare ID to be qualified once, got %s", snap.Nodes[0].ID)
+	}
+	if snap.Nodes[1].ID != "arn:aws:lambda:us-east-1:111111111111:function:f" {
+		t.Errorf("Expected ARN to be kept, got %s", snap.Nodes[1].ID)
+	}
+	if snap.Edges[0].TargetID != "111111111111/orders" {
+		t.Errorf("Expected edge target to follow the node, got %s", snap.Edges[0].TargetID)
+	}
+	if UnqualifyID(snap.Nodes[0].ID) != "orders" {
+		t.Errorf("Expected UnqualifyID to strip the account, got %s", UnqualifyID(snap.Nodes[0].ID))
+	}
+}
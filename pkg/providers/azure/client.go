@@ -0,0 +1,44 @@
+package azure
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v6"
+)
+
+// Client wraps the Azure Resource Manager clients used by the scanners.
+type Client struct {
+	VMs            *armcompute.VirtualMachinesClient
+	Disks          *armcompute.DisksClient
+	SubscriptionID string
+}
+
+// NewClient authenticates with DefaultAzureCredential (environment variables,
+// workload or managed identity, or az login). subscription may be empty; it
+// then falls back to AZURE_SUBSCRIPTION_ID.
+func NewClient(subscription string) (*Client, error) {
+	if subscription == "" {
+		subscription = os.Getenv("AZURE_SUBSCRIPTION_ID")
+	}
+	if subscription == "" {
+		return nil, fmt.Errorf("no Azure subscription set; use --subscription or AZURE_SUBSCRIPTION_ID")
+	}
+
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find Azure credentials: %v", err)
+	}
+
+	factory, err := armcompute.NewClientFactory(subscription, cred, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create compute client: %v", err)
+	}
+
+	return &Client{
+		VMs:            factory.NewVirtualMachinesClient(),
+		Disks:          factory.NewDisksClient(),
+		SubscriptionID: subscription,
+	}, nil
+}
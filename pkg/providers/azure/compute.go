@@ -0,0 +1,238 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v6"
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// Node types. Properties mirror their AWS counterparts (State, Size, VolumeType,
+// AttachedInstanceId, Tags) so heuristics can treat them uniformly.
+const (
+	VirtualMachineType = "Azure::Compute::VirtualMachine"
+	DiskType           = "Azure::Compute::Disk"
+)
+
+// ComputeScanner lists virtual machines and managed disks in a subscription.
+type ComputeScanner struct {
+	Client *Client
+	Graph  *graph.Graph
+}
+
+func NewComputeScanner(client *Client, g *graph.Graph) *ComputeScanner {
+	return &ComputeScanner{
+		Client: client,
+		Graph:  g,
+	}
+}
+
+func (s *ComputeScanner) Name() string { return "AzureComputeScanner" }
+
+// Scan adds every VM and managed disk in the subscription, across all regions.
+// Node IDs are lower-cased ARM resource IDs: ARM IDs are case-insensitive and
+// a disk's managedBy often differs in case from the VM's own ID.
+func (s *ComputeScanner) Scan(ctx context.Context, g *graph.Graph) error {
+	if s.Client == nil {
+		return nil
+	}
+
+	// The model list carries no run state; statusOnly returns the instance views.
+	power := make(map[string]powerStatus)
+	statusPager := s.Client.VMs.NewListAllPager(&armcompute.VirtualMachinesClientListAllOptions{StatusOnly: strPtr("true")})
+	for statusPager.More() {
+		page, err := statusPager.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list Azure VM statuses: %v", err)
+		}
+		for _, vm := range page.Value {
+			if vm.ID != nil && vm.Properties != nil && vm.Properties.InstanceView != nil {
+				power[resourceKey(*vm.ID)] = parsePowerStatus(vm.Properties.InstanceView.Statuses)
+			}
+		}
+	}
+
+	vmPager := s.Client.VMs.NewListAllPager(nil)
+	for vmPager.More() {
+		page, err := vmPager.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list Azure VMs: %v", err)
+		}
+		for _, vm := range page.Value {
+			if vm.ID == nil {
+				continue
+			}
+			id := resourceKey(*vm.ID)
+			g.AddNode(id, VirtualMachineType, vmProps(vm, power[id]))
+		}
+	}
+
+	diskPager := s.Client.Disks.NewListPager(nil)
+	for diskPager.More() {
+		page, err := diskPager.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list Azure disks: %v", err)
+		}
+		for _, disk := range page.Value {
+			if disk.ID == nil {
+				continue
+			}
+			id := resourceKey(*disk.ID)
+			g.AddNode(id, DiskType, diskProps(disk))
+			if disk.ManagedBy != nil && *disk.ManagedBy != "" {
+				g.AddTypedEdge(id, resourceKey(*disk.ManagedBy), graph.EdgeTypeAttachedTo, 100)
+			}
+		}
+	}
+	return nil
+}
+
+// powerStatus is a VM's power state code ("deallocated") and when its last
+// operation finished.
+type powerStatus struct {
+	State   string
+	Changed time.Time
+}
+
+// parsePowerStatus reads the PowerState/* and ProvisioningState/* entries of an
+// instance view. Azure does not report when a VM last started or stopped; the
+// provisioning status time is the last completed operation, which for a
+// deallocated VM is the deallocation.
+func parsePowerStatus(statuses []*armcompute.InstanceViewStatus) powerStatus {
+	var ps powerStatus
+	for _, st := range statuses {
+		if st == nil || st.Code == nil {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(*st.Code, "PowerState/"):
+			ps.State = strings.TrimPrefix(*st.Code, "PowerState/")
+		case strings.HasPrefix(*st.Code, "ProvisioningState/") && st.Time != nil:
+			ps.Changed = *st.Time
+		}
+	}
+	return ps
+}
+
+// vmProps maps a VM to graph properties. Only a deallocated VM is "stopped":
+// a VM stopped from inside the guest keeps its hardware and keeps billing.
+func vmProps(vm *armcompute.VirtualMachine, ps powerStatus) map[string]interface{} {
+	props := map[string]interface{}{
+		"Name":          deref(vm.Name),
+		"Region":        deref(vm.Location),
+		"ResourceGroup": resourceGroup(deref(vm.ID)),
+		"PowerState":    ps.State,
+		"State":         vmState(ps.State),
+		"Tags":          tags(vm.Tags),
+		"Provider":      "azure",
+	}
+	if p := vm.Properties; p != nil {
+		if p.HardwareProfile != nil && p.HardwareProfile.VMSize != nil {
+			props["Type"] = string(*p.HardwareProfile.VMSize)
+		}
+		if p.TimeCreated != nil {
+			props["CreationTime"] = *p.TimeCreated
+		}
+	}
+	// Stands in for the last start: no later than it, so stopped-age checks
+	// never overstate how long a VM has been idle.
+	if !ps.Changed.IsZero() {
+		props["LaunchTime"] = ps.Changed
+		if ps.State == "deallocated" {
+			props["LastStopTime"] = ps.Changed
+		}
+	} else if t, ok := props["CreationTime"].(time.Time); ok {
+		props["LaunchTime"] = t
+	}
+	return props
+}
+
+// diskProps maps a managed disk to graph properties. An Unattached disk is
+// "available" like an EBS volume; Reserved (attached to a deallocated VM) is
+// "in-use", and the VM's state decides whether it is idle.
+func diskProps(disk *armcompute.Disk) map[string]interface{} {
+	props := map[string]interface{}{
+		"Name":          deref(disk.Name),
+		"Region":        deref(disk.Location),
+		"ResourceGroup": resourceGroup(deref(disk.ID)),
+		"State":         "in-use",
+		"Tags":          tags(disk.Tags),
+		"Provider":      "azure",
+	}
+	if disk.SKU != nil && disk.SKU.Name != nil {
+		props["VolumeType"] = string(*disk.SKU.Name)
+	}
+	if p := disk.Properties; p != nil {
+		if p.DiskSizeGB != nil {
+			props["Size"] = int(*p.DiskSizeGB)
+		}
+		if p.DiskState != nil {
+			props["DiskState"] = string(*p.DiskState)
+			if *p.DiskState == armcompute.DiskStateUnattached {
+				props["State"] = "available"
+			}
+		}
+		if p.TimeCreated != nil {
+			props["CreateTime"] = *p.TimeCreated
+		}
+		if p.LastOwnershipUpdateTime != nil && props["State"] == "available" {
+			props["LastDetachTime"] = *p.LastOwnershipUpdateTime
+		}
+	}
+	if disk.ManagedBy != nil && *disk.ManagedBy != "" {
+		props["AttachedInstanceId"] = lastSegment(*disk.ManagedBy)
+		props["AttachedInstanceNode"] = resourceKey(*disk.ManagedBy)
+	}
+	return props
+}
+
+func vmState(power string) string {
+	switch power {
+	case "deallocated":
+		return "stopped"
+	case "stopped":
+		return "stopped-allocated"
+	}
+	return power
+}
+
+// resourceKey normalizes an ARM resource ID for use as a node ID.
+func resourceKey(id string) string {
+	return strings.ToLower(id)
+}
+
+// resourceGroup extracts the resource group from an ARM resource ID.
+func resourceGroup(id string) string {
+	parts := strings.Split(id, "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if strings.EqualFold(parts[i], "resourceGroups") {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
+
+// lastSegment returns the name at the end of a resource ID.
+func lastSegment(id string) string {
+	return id[strings.LastIndex(id, "/")+1:]
+}
+
+func tags(t map[string]*string) map[string]string {
+	out := make(map[string]string, len(t))
+	for k, v := range t {
+		out[k] = deref(v)
+	}
+	return out
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func strPtr(s string) *string { return &s }
@@ -0,0 +1,77 @@
+package azure
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v6"
+)
+
+func TestVMProps(t *testing.T) {
+	stopped := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	size := armcompute.VirtualMachineSizeTypesStandardD2SV3
+	ps := parsePowerStatus([]*armcompute.InstanceViewStatus{
+		{Code: strPtr("ProvisioningState/succeeded"), Time: &stopped},
+		{Code: strPtr("PowerState/deallocated")},
+	})
+	props := vmProps(&armcompute.VirtualMachine{
+		ID:       strPtr("/subscriptions/s/resourceGroups/Web-RG/providers/Microsoft.Compute/virtualMachines/web-1"),
+		Name:     strPtr("web-1"),
+		Location: strPtr("eastus"),
+		Tags:     map[string]*string{"env": strPtr("dev")},
+		Properties: &armcompute.VirtualMachineProperties{
+			HardwareProfile: &armcompute.HardwareProfile{VMSize: &size},
+		},
+	}, ps)
+
+	if props["State"] != "stopped" || props["PowerState"] != "deallocated" {
+		t.Errorf("State = %v (%v), want stopped (deallocated)", props["State"], props["PowerState"])
+	}
+	if props["Type"] != "Standard_D2s_v3" || props["Region"] != "eastus" || props["ResourceGroup"] != "Web-RG" {
+		t.Errorf("unexpected size/location props: %v", props)
+	}
+	if props["LaunchTime"] != stopped {
+		t.Errorf("LaunchTime = %v, want the provisioning status time", props["LaunchTime"])
+	}
+	if tags, _ := props["Tags"].(map[string]string); tags["env"] != "dev" {
+		t.Errorf("Tags = %v", props["Tags"])
+	}
+
+	// Stopped without deallocating still bills for compute.
+	if s := vmState("stopped"); s == "stopped" {
+		t.Error("an allocated stopped VM must not look like a deallocated one")
+	}
+}
+
+func TestDiskProps(t *testing.T) {
+	unattachedState := armcompute.DiskStateUnattached
+	sku := armcompute.DiskStorageAccountTypesPremiumLRS
+	unattached := diskProps(&armcompute.Disk{
+		Name:     strPtr("orphan"),
+		Location: strPtr("westeurope"),
+		SKU:      &armcompute.DiskSKU{Name: &sku},
+		Properties: &armcompute.DiskProperties{
+			DiskSizeGB: int32Ptr(256),
+			DiskState:  &unattachedState,
+		},
+	})
+	if unattached["State"] != "available" || unattached["Size"] != 256 || unattached["VolumeType"] != "Premium_LRS" {
+		t.Errorf("unexpected unattached disk props: %v", unattached)
+	}
+	if _, ok := unattached["AttachedInstanceId"]; ok {
+		t.Error("unattached disk should have no AttachedInstanceId")
+	}
+
+	reserved := armcompute.DiskStateReserved
+	vm := "/subscriptions/s/resourceGroups/WEB-RG/providers/Microsoft.Compute/virtualMachines/web-1"
+	attached := diskProps(&armcompute.Disk{
+		Name:       strPtr("web-1-os"),
+		ManagedBy:  &vm,
+		Properties: &armcompute.DiskProperties{DiskSizeGB: int32Ptr(30), DiskState: &reserved},
+	})
+	if attached["State"] != "in-use" || attached["AttachedInstanceId"] != "web-1" || attached["AttachedInstanceNode"] != resourceKey(vm) {
+		t.Errorf("unexpected attached disk props: %v", attached)
+	}
+}
+
+func int32Ptr(v int32) *int32 { return &v }
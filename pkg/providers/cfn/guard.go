@@ -0,0 +1,69 @@
+// Package cfn protects CloudFormation-managed resources from out-of-band remediation.
+package cfn
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// Tags applied by CloudFormation (and CDK) to every stack resource.
+const (
+	StackNameTag = "aws:cloudformation:stack-name"
+	StackIDTag   = "aws:cloudformation:stack-id"
+	LogicalIDTag = "aws:cloudformation:logical-id"
+)
+
+// StackName returns the owning stack of a node, or "" if unmanaged.
+func StackName(node *graph.Node) string {
+	return stackTag(node, StackNameTag)
+}
+
+// StackID returns the owning stack's ARN, or "" if unknown.
+func StackID(node *graph.Node) string {
+	return stackTag(node, StackIDTag)
+}
+
+// LogicalID returns the node's logical ID in its stack template, or "".
+func LogicalID(node *graph.Node) string {
+	return stackTag(node, LogicalIDTag)
+}
+
+func stackTag(node *graph.Node, key string) string {
+	if tags, ok := node.Properties["Tags"].(map[string]string); ok {
+		return tags[key]
+	}
+	return ""
+}
+
+// ProtectManaged downgrades CFN-managed waste to review.
+// Deleting a stack resource directly causes drift, and the next stack
+// update recreates it; the fix belongs in the template.
+// Returns the affected resource IDs grouped by stack name.
+func ProtectManaged(g *graph.Graph) map[string][]string {
+	g.Mu.Lock()
+	defer g.Mu.Unlock()
+
+	byStack := make(map[string][]string)
+	for _, node := range g.Store.GetAllNodes() {
+		if !node.IsWaste || node.Justified {
+			continue
+		}
+		stack := StackName(node)
+		if stack == "" {
+			continue
+		}
+
+		reason, _ := node.Properties["Reason"].(string)
+		node.Properties["CFNStack"] = stack
+		node.Properties["Reason"] = fmt.Sprintf("%s [IaC-managed: remove from CloudFormation stack %s]", reason, stack)
+
+		byStack[stack] = append(byStack[stack], node.IDStr())
+	}
+
+	for stack := range byStack {
+		sort.Strings(byStack[stack])
+	}
+	return byStack
+}
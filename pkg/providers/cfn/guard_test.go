@@ -0,0 +1,37 @@
+package cfn
+
+import (
+	"testing"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+func TestProtectManaged(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("vol-stack", "AWS::EC2::Volume", map[string]interface{}{
+		"Tags": map[string]string{StackNameTag: "billing-api"},
+	})
+	g.AddNode("vol-loose", "AWS::EC2::Volume", map[string]interface{}{
+		"Tags": map[string]string{"Name": "scratch"},
+	})
+	g.CloseAndWait()
+
+	g.MarkWaste("vol-stack", 90)
+	g.MarkWaste("vol-loose", 90)
+
+	byStack := ProtectManaged(g)
+
+	if ids := byStack["billing-api"]; len(ids) != 1 || ids[0] != "vol-stack" {
+		t.Fatalf("Expected vol-stack grouped under billing-api, got %v", byStack)
+	}
+
+	g.Mu.RLock()
+	defer g.Mu.RUnlock()
+
+	if g.GetNode("vol-stack").Properties["CFNStack"] != "billing-api" {
+		t.Error("Expected owning stack to be recorded on the finding")
+	}
+	if _, ok := g.GetNode("vol-loose").Properties["CFNStack"]; ok {
+		t.Error("Unmanaged resource should not be marked as CFN-managed")
+	}
+}
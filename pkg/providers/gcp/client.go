@@ -0,0 +1,57 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/monitoring/v3"
+	"google.golang.org/api/option"
+)
+
+// Client wraps the GCP API services used by the scanners.
+type Client struct {
+	Compute    *compute.Service
+	Monitoring *monitoring.Service
+	Project    string
+}
+
+// NewClient authenticates with Application Default Credentials
+// (gcloud auth application-default login, GOOGLE_APPLICATION_CREDENTIALS, or the metadata server).
+// project may be empty; it then falls back to the environment, then to the credentials' project.
+func NewClient(ctx context.Context, project string) (*Client, error) {
+	creds, err := google.FindDefaultCredentials(ctx, compute.ComputeReadonlyScope, monitoring.MonitoringReadScope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find GCP application default credentials: %v", err)
+	}
+
+	if project == "" {
+		project = os.Getenv("GOOGLE_CLOUD_PROJECT")
+	}
+	if project == "" {
+		project = os.Getenv("CLOUDSDK_CORE_PROJECT")
+	}
+	if project == "" {
+		project = creds.ProjectID
+	}
+	if project == "" {
+		return nil, fmt.Errorf("no GCP project set; use --gcp-project or GOOGLE_CLOUD_PROJECT")
+	}
+
+	svc, err := compute.NewService(ctx, option.WithCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create compute client: %v", err)
+	}
+	mon, err := monitoring.NewService(ctx, option.WithCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create monitoring client: %v", err)
+	}
+
+	return &Client{
+		Compute:    svc,
+		Monitoring: mon,
+		Project:    project,
+	}, nil
+}
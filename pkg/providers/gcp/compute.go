@@ -0,0 +1,219 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/monitoring/v3"
+)
+
+// Node types. Properties mirror their AWS counterparts (State, Size, VolumeType,
+// AttachedInstanceId, Tags) so heuristics can treat them uniformly.
+const (
+	InstanceType = "GCP::Compute::Instance"
+	DiskType     = "GCP::Compute::Disk"
+)
+
+// CPUWindow is the lookback of an instance's PeakCPUPercent.
+const CPUWindow = 14 * 24 * time.Hour
+
+const cpuMetric = "compute.googleapis.com/instance/cpu/utilization"
+
+// ComputeScanner lists Compute Engine instances and persistent disks.
+type ComputeScanner struct {
+	Client *Client
+	Graph  *graph.Graph
+}
+
+func NewComputeScanner(client *Client, g *graph.Graph) *ComputeScanner {
+	return &ComputeScanner{
+		Client: client,
+		Graph:  g,
+	}
+}
+
+func (s *ComputeScanner) Name() string { return "GCPComputeScanner" }
+
+// Scan adds every instance and disk in the project, across all zones.
+// Running instances carry their peak CPU over CPUWindow (PeakCPUPercent)
+// when Cloud Monitoring has data for them.
+func (s *ComputeScanner) Scan(ctx context.Context, g *graph.Graph) error {
+	if s.Client == nil {
+		return nil
+	}
+
+	peaks, err := s.peakCPU(ctx)
+	if err != nil {
+		// Without metrics no instance is judged idle; disks are still scanned.
+		g.AddError("GCP Monitoring", err)
+	}
+
+	err = s.Client.Compute.Instances.AggregatedList(s.Client.Project).Pages(ctx, func(page *compute.InstanceAggregatedList) error {
+		for _, scoped := range page.Items {
+			for _, inst := range scoped.Instances {
+				props := instanceProps(inst)
+				if peak, ok := peaks[fmt.Sprint(inst.Id)]; ok && inst.Status == "RUNNING" {
+					props["PeakCPUPercent"] = peak
+				}
+				g.AddNode(inst.SelfLink, InstanceType, props)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list GCP instances: %v", err)
+	}
+
+	err = s.Client.Compute.Disks.AggregatedList(s.Client.Project).Pages(ctx, func(page *compute.DiskAggregatedList) error {
+		for _, scoped := range page.Items {
+			for _, disk := range scoped.Disks {
+				g.AddNode(disk.SelfLink, DiskType, diskProps(disk))
+				for _, user := range disk.Users {
+					g.AddTypedEdge(disk.SelfLink, user, graph.EdgeTypeAttachedTo, 100)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list GCP disks: %v", err)
+	}
+	return nil
+}
+
+// peakCPU reads each instance's peak CPU utilization over CPUWindow, in
+// percent and keyed by numeric instance ID, in one project-wide query.
+func (s *ComputeScanner) peakCPU(ctx context.Context) (map[string]float64, error) {
+	if s.Client.Monitoring == nil {
+		return nil, nil
+	}
+	end := time.Now()
+	var series []*monitoring.TimeSeries
+	err := s.Client.Monitoring.Projects.TimeSeries.List("projects/"+s.Client.Project).
+		Filter(fmt.Sprintf("metric.type=%q", cpuMetric)).
+		IntervalStartTime(end.Add(-CPUWindow).Format(time.RFC3339)).
+		IntervalEndTime(end.Format(time.RFC3339)).
+		AggregationAlignmentPeriod(fmt.Sprintf("%ds", int(CPUWindow.Seconds()))).
+		AggregationPerSeriesAligner("ALIGN_MAX").
+		Pages(ctx, func(page *monitoring.ListTimeSeriesResponse) error {
+			series = append(series, page.TimeSeries...)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GCP CPU utilization: %v", err)
+	}
+	return peakCPUByInstance(series), nil
+}
+
+// peakCPUByInstance takes the highest point of each instance's CPU series.
+// The metric is a 0-1 fraction; the result is a percentage.
+func peakCPUByInstance(series []*monitoring.TimeSeries) map[string]float64 {
+	peaks := make(map[string]float64)
+	for _, ts := range series {
+		if ts.Resource == nil {
+			continue
+		}
+		id := ts.Resource.Labels["instance_id"]
+		for _, p := range ts.Points {
+			if id == "" || p.Value == nil || p.Value.DoubleValue == nil {
+				continue
+			}
+			v := *p.Value.DoubleValue * 100
+			if old, ok := peaks[id]; !ok || v > old {
+				peaks[id] = v
+			}
+		}
+	}
+	return peaks
+}
+
+// instanceProps maps an instance to graph properties.
+// A TERMINATED (stopped) or SUSPENDED instance is reported as "stopped"; its disks keep billing.
+func instanceProps(inst *compute.Instance) map[string]interface{} {
+	zone := lastSegment(inst.Zone)
+	props := map[string]interface{}{
+		"Name":         inst.Name,
+		"Zone":         zone,
+		"Region":       zoneRegion(zone),
+		"Type":         lastSegment(inst.MachineType),
+		"Status":       inst.Status,
+		"State":        instanceState(inst.Status),
+		"CreationTime": parseTimestamp(inst.CreationTimestamp),
+		"Tags":         labels(inst.Labels),
+		"Provider":     "gcp",
+	}
+	if t := parseTimestamp(inst.LastStartTimestamp); !t.IsZero() {
+		props["LaunchTime"] = t
+	}
+	if t := parseTimestamp(inst.LastStopTimestamp); !t.IsZero() {
+		props["LastStopTime"] = t
+	}
+	return props
+}
+
+// diskProps maps a persistent disk to graph properties.
+// A disk with no users is "available" (unattached), like an EBS volume.
+func diskProps(disk *compute.Disk) map[string]interface{} {
+	zone := lastSegment(disk.Zone)
+	props := map[string]interface{}{
+		"Name":       disk.Name,
+		"Zone":       zone,
+		"Region":     zoneRegion(zone),
+		"Size":       int(disk.SizeGb),
+		"VolumeType": lastSegment(disk.Type),
+		"State":      "available",
+		"CreateTime": parseTimestamp(disk.CreationTimestamp),
+		"Tags":       labels(disk.Labels),
+		"Provider":   "gcp",
+	}
+	if len(disk.Users) > 0 {
+		props["State"] = "in-use"
+		props["AttachedInstanceId"] = lastSegment(disk.Users[0])
+		props["AttachedInstanceNode"] = disk.Users[0]
+	}
+	if t := parseTimestamp(disk.LastDetachTimestamp); !t.IsZero() {
+		props["LastDetachTime"] = t
+	}
+	return props
+}
+
+func instanceState(status string) string {
+	switch status {
+	case "RUNNING":
+		return "running"
+	case "TERMINATED", "SUSPENDED":
+		return "stopped"
+	}
+	return strings.ToLower(status)
+}
+
+// lastSegment returns the name at the end of a resource URL.
+func lastSegment(url string) string {
+	return url[strings.LastIndex(url, "/")+1:]
+}
+
+// zoneRegion strips the zone suffix: us-central1-a -> us-central1.
+func zoneRegion(zone string) string {
+	if i := strings.LastIndex(zone, "-"); i > 0 {
+		return zone[:i]
+	}
+	return zone
+}
+
+func parseTimestamp(s string) time.Time {
+	t, _ := time.Parse(time.RFC3339, s)
+	return t
+}
+
+// labels copies GCP labels, which play the role of tags.
+func labels(l map[string]string) map[string]string {
+	out := make(map[string]string, len(l))
+	for k, v := range l {
+		out[k] = v
+	}
+	return out
+}
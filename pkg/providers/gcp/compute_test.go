@@ -0,0 +1,75 @@
+package gcp
+
+import (
+	"testing"
+
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/monitoring/v3"
+)
+
+func TestInstanceProps(t *testing.T) {
+	props := instanceProps(&compute.Instance{
+		Name:               "web-1",
+		Zone:               "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a",
+		MachineType:        "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/machineTypes/e2-medium",
+		Status:             "TERMINATED",
+		LastStartTimestamp: "2025-01-02T03:04:05.000-07:00",
+		Labels:             map[string]string{"env": "dev"},
+	})
+
+	if props["State"] != "stopped" {
+		t.Errorf("State = %v, want stopped", props["State"])
+	}
+	if props["Type"] != "e2-medium" || props["Zone"] != "us-central1-a" || props["Region"] != "us-central1" {
+		t.Errorf("unexpected location/type props: %v", props)
+	}
+	if _, ok := props["LaunchTime"]; !ok {
+		t.Error("LaunchTime not set from LastStartTimestamp")
+	}
+	if tags, _ := props["Tags"].(map[string]string); tags["env"] != "dev" {
+		t.Errorf("Tags = %v, want labels", props["Tags"])
+	}
+}
+
+func TestDiskProps(t *testing.T) {
+	unattached := diskProps(&compute.Disk{
+		Name:   "orphan",
+		Zone:   "projects/p/zones/europe-west1-b",
+		SizeGb: 200,
+		Type:   "projects/p/zones/europe-west1-b/diskTypes/pd-ssd",
+	})
+	if unattached["State"] != "available" || unattached["Size"] != 200 || unattached["VolumeType"] != "pd-ssd" {
+		t.Errorf("unexpected unattached disk props: %v", unattached)
+	}
+	if _, ok := unattached["AttachedInstanceId"]; ok {
+		t.Error("unattached disk should have no AttachedInstanceId")
+	}
+
+	user := "https://www.googleapis.com/compute/v1/projects/p/zones/europe-west1-b/instances/web-1"
+	attached := diskProps(&compute.Disk{Name: "boot", SizeGb: 10, Users: []string{user}})
+	if attached["State"] != "in-use" || attached["AttachedInstanceId"] != "web-1" || attached["AttachedInstanceNode"] != user {
+		t.Errorf("unexpected attached disk props: %v", attached)
+	}
+}
+
+func TestPeakCPUByInstance(t *testing.T) {
+	point := func(v float64) *monitoring.Point {
+		return &monitoring.Point{Value: &monitoring.TypedValue{DoubleValue: &v}}
+	}
+	series := []*monitoring.TimeSeries{
+		{Resource: &monitoring.MonitoredResource{Labels: map[string]string{"instance_id": "101"}}, Points: []*monitoring.Point{point(0.012), point(0.031)}},
+		{Resource: &monitoring.MonitoredResource{Labels: map[string]string{"instance_id": "202"}}, Points: []*monitoring.Point{point(0.74)}},
+		{Resource: &monitoring.MonitoredResource{Labels: map[string]string{}}, Points: []*monitoring.Point{point(0.5)}},
+	}
+
+	peaks := peakCPUByInstance(series)
+	if len(peaks) != 2 {
+		t.Fatalf("Expected peaks for 2 instances, got %v", peaks)
+	}
+	if peaks["101"] < 3.09 || peaks["101"] > 3.11 {
+		t.Errorf("peak for 101 = %.2f%%, want 3.1%%", peaks["101"])
+	}
+	if peaks["202"] < 73.9 || peaks["202"] > 74.1 {
+		t.Errorf("peak for 202 = %.2f%%, want 74%%", peaks["202"])
+	}
+}
@@ -0,0 +1,60 @@
+package pulumi
+
+import (
+	"strings"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+// DriftDetector reconciles the graph against a Pulumi stack.
+type DriftDetector struct {
+	Graph *graph.Graph
+	State *State
+}
+
+// NewDriftDetector creates a new detector.
+func NewDriftDetector(g *graph.Graph, s *State) *DriftDetector {
+	return &DriftDetector{
+		Graph: g,
+		State: s,
+	}
+}
+
+// ScanForDrift points managed AWS nodes at their resource URN via SourceLocation
+// and annotates the rest with Unmanaged=true. Like Terraform shadow infrastructure,
+// being unmanaged is a governance signal and never marks a node as waste.
+func (d *DriftDetector) ScanForDrift() (managed, unmanaged int) {
+	mapping := d.State.GetResourceMapping()
+	d.Graph.Mu.Lock()
+	defer d.Graph.Mu.Unlock()
+
+	for _, node := range d.Graph.Store.GetAllNodes() {
+		if t := node.TypeStr(); !strings.HasPrefix(t, "AWS::") && !strings.HasPrefix(t, "aws_") {
+			continue
+		}
+		if urn, ok := lookupURN(mapping, node.IDStr()); ok {
+			node.SourceLocation = urn
+			managed++
+			continue
+		}
+		if node.Properties == nil {
+			node.Properties = make(map[string]interface{})
+		}
+		node.Properties["Unmanaged"] = true
+		unmanaged++
+	}
+	return managed, unmanaged
+}
+
+// lookupURN matches a graph node ID by exact ID/ARN or by the ARN's trailing resource ID.
+func lookupURN(mapping map[string]string, id string) (string, bool) {
+	if urn, ok := mapping[id]; ok {
+		return urn, true
+	}
+	parts := strings.Split(id, "/")
+	if len(parts) > 1 {
+		urn, ok := mapping[parts[len(parts)-1]]
+		return urn, ok
+	}
+	return "", false
+}
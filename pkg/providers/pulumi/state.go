@@ -0,0 +1,123 @@
+package pulumi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// State is the resource list of a Pulumi stack checkpoint.
+type State struct {
+	Version   int
+	Resources []Resource
+}
+
+// Resource is one resource of a Pulumi deployment.
+type Resource struct {
+	URN     string                 `json:"urn"`
+	Type    string                 `json:"type"`
+	ID      string                 `json:"id"`
+	Custom  bool                   `json:"custom"`
+	Outputs map[string]interface{} `json:"outputs"`
+}
+
+type deployment struct {
+	Resources []Resource `json:"resources"`
+}
+
+// checkpointFile covers both shapes Pulumi writes: `pulumi stack export`
+// ("deployment") and the local backend's stack file ("checkpoint.latest").
+type checkpointFile struct {
+	Version    int         `json:"version"`
+	Deployment *deployment `json:"deployment"`
+	Checkpoint *struct {
+		Latest *deployment `json:"latest"`
+	} `json:"checkpoint"`
+}
+
+// IsProject reports whether dir holds a Pulumi project file.
+func IsProject(dir string) bool {
+	for _, name := range []string{"Pulumi.yaml", "Pulumi.yml"} {
+		if info, err := os.Stat(filepath.Join(dir, name)); err == nil && !info.IsDir() {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadState reads a checkpoint from path, or runs `pulumi stack export` in the
+// current directory when path is empty.
+func LoadState(ctx context.Context, path string) (*State, error) {
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read pulumi state file: %v", err)
+		}
+		return ParseState(data)
+	}
+
+	if _, err := exec.LookPath("pulumi"); err != nil {
+		return nil, fmt.Errorf("no pulumi state file given and the pulumi CLI is not installed")
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	data, err := exec.CommandContext(ctx, "pulumi", "stack", "export").Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("pulumi stack export failed: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("failed to run pulumi stack export: %v", err)
+	}
+	return ParseState(data)
+}
+
+// ParseState parses checkpoint JSON.
+func ParseState(data []byte) (*State, error) {
+	var cp checkpointFile
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("failed to parse pulumi state JSON: %v", err)
+	}
+
+	var d *deployment
+	switch {
+	case cp.Deployment != nil:
+		d = cp.Deployment
+	case cp.Checkpoint != nil && cp.Checkpoint.Latest != nil:
+		d = cp.Checkpoint.Latest
+	default:
+		return nil, fmt.Errorf("pulumi state has no deployment (expected `pulumi stack export` output)")
+	}
+	return &State{Version: cp.Version, Resources: d.Resources}, nil
+}
+
+// GetResourceMapping maps the cloud ID and ARN of every custom resource to its URN.
+// Component resources and providers own nothing in the cloud and are skipped.
+func (s *State) GetResourceMapping() map[string]string {
+	mapping := make(map[string]string)
+	for _, res := range s.Resources {
+		if !res.Custom || strings.HasPrefix(res.Type, "pulumi:providers:") {
+			continue
+		}
+		if res.ID != "" {
+			mapping[res.ID] = res.URN
+		}
+		if arn, ok := res.Outputs["arn"].(string); ok && arn != "" {
+			mapping[arn] = res.URN
+		}
+	}
+	return mapping
+}
+
+// GetManagedResourceIDs returns managed IDs.
+func (s *State) GetManagedResourceIDs() map[string]bool {
+	managed := make(map[string]bool)
+	for id := range s.GetResourceMapping() {
+		managed[id] = true
+	}
+	return managed
+}
@@ -0,0 +1,85 @@
+package pulumi
+
+import (
+	"testing"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
+
+const exportJSON = `{
+  "version": 3,
+  "deployment": {
+    "manifest": {"time": "2024-05-01T10:00:00Z"},
+    "resources": [
+      {"urn": "urn:pulumi:prod::app::pulumi:pulumi:Stack::app-prod", "type": "pulumi:pulumi:Stack", "custom": false},
+      {"urn": "urn:pulumi:prod::app::pulumi:providers:aws::default", "type": "pulumi:providers:aws", "custom": true, "id": "0b1c2d"},
+      {"urn": "urn:pulumi:prod::app::aws:ec2/instance:Instance::web", "type": "aws:ec2/instance:Instance", "custom": true,
+       "id": "i-0abc", "outputs": {"arn": "arn:aws:ec2:us-east-1:123:instance/i-0abc"}},
+      {"urn": "urn:pulumi:prod::app::aws:s3/bucket:Bucket::assets", "type": "aws:s3/bucket:Bucket", "custom": true,
+       "id": "assets-bucket", "outputs": {"arn": "arn:aws:s3:::assets-bucket"}}
+    ]
+  }
+}`
+
+func TestParseState(t *testing.T) {
+	s, err := ParseState([]byte(exportJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapping := s.GetResourceMapping()
+	if len(mapping) != 4 {
+		t.Errorf("Expected ID and ARN of 2 resources, got %v", mapping)
+	}
+	if mapping["arn:aws:s3:::assets-bucket"] != "urn:pulumi:prod::app::aws:s3/bucket:Bucket::assets" {
+		t.Errorf("Bucket ARN not mapped to its URN: %v", mapping)
+	}
+	if _, ok := mapping["0b1c2d"]; ok {
+		t.Error("Providers own no cloud resources and must not be mapped")
+	}
+
+	// The local backend's stack file wraps the deployment in checkpoint.latest.
+	local := `{"version": 3, "checkpoint": {"stack": "prod", "latest": {"resources": [
+		{"urn": "urn:pulumi:prod::app::aws:ec2/eip:Eip::nat", "type": "aws:ec2/eip:Eip", "custom": true, "id": "eipalloc-1"}]}}}`
+	s, err = ParseState([]byte(local))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.GetManagedResourceIDs()["eipalloc-1"] {
+		t.Error("Expected resources from checkpoint.latest")
+	}
+
+	if _, err := ParseState([]byte(`{"version": 3}`)); err == nil {
+		t.Error("Expected an error for a file with no deployment")
+	}
+}
+
+func TestScanForDrift(t *testing.T) {
+	s, err := ParseState([]byte(exportJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:ec2:us-east-1:123:instance/i-0abc", "AWS::EC2::Instance", map[string]interface{}{})
+	g.AddNode("arn:aws:ec2:us-east-1:123:instance/i-0clickops", "AWS::EC2::Instance", map[string]interface{}{})
+	g.AddNode("arn:aws:s3:::assets-bucket", "AWS::S3::Bucket", map[string]interface{}{})
+	g.AddNode("arn:aws:ec2:us-east-1:123:natgateway/nat-clickops", "aws_nat_gateway", map[string]interface{}{})
+	g.AddNode("projects/p/zones/z/disks/d", "GCP::Compute::Disk", map[string]interface{}{})
+	g.CloseAndWait()
+
+	managed, unmanaged := NewDriftDetector(g, s).ScanForDrift()
+	if managed != 2 || unmanaged != 2 {
+		t.Errorf("Expected 2 managed and 2 unmanaged, got %d and %d", managed, unmanaged)
+	}
+
+	web := g.GetNode("arn:aws:ec2:us-east-1:123:instance/i-0abc")
+	if web.SourceLocation != "urn:pulumi:prod::app::aws:ec2/instance:Instance::web" {
+		t.Errorf("Expected SourceLocation to be the URN, got %q", web.SourceLocation)
+	}
+	shadow := g.GetNode("arn:aws:ec2:us-east-1:123:instance/i-0clickops")
+	if v, _ := shadow.Properties["Unmanaged"].(bool); !v || shadow.IsWaste {
+		t.Errorf("Expected unmanaged annotation without waste, got %v (waste %t)", shadow.Properties, shadow.IsWaste)
+	}
+	if _, ok := g.GetNode("projects/p/zones/z/disks/d").Properties["Unmanaged"]; ok {
+		t.Error("Non-AWS nodes are out of scope for the AWS provider state")
+	}
+}
@@ -36,23 +36,7 @@ func (d *DriftDetector) ScanForDrift() {
 		// Check management.
 		id := node.IDStr()
 
-		isManaged := false
-
-		// Check ID match.
-		if managedIDs[id] {
-			isManaged = true
-		} else {
-			// Check ARN suffix match.
-			parts := strings.Split(id, "/")
-			if len(parts) > 1 {
-				resourceID := parts[len(parts)-1]
-				if managedIDs[resourceID] {
-					isManaged = true
-				}
-			}
-		}
-
-		if !isManaged {
+		if !IsManaged(managedIDs, id) {
 			// Identify as Shadow IT.
 			node.IsWaste = true
 			node.RiskScore = 100
@@ -64,3 +48,52 @@ func (d *DriftDetector) ScanForDrift() {
 		}
 	}
 }
+
+// LinkAddresses records the Terraform address of every managed node in its
+// TF_ADDRESS property, which destroy and state-removal plans target.
+// It returns the number of nodes linked.
+func (d *DriftDetector) LinkAddresses() int {
+	mapping := d.State.GetResourceMapping()
+	d.Graph.Mu.Lock()
+	defer d.Graph.Mu.Unlock()
+
+	linked := 0
+	for _, node := range d.Graph.Store.GetAllNodes() {
+		address, ok := lookupAddress(mapping, node.IDStr())
+		if !ok {
+			continue
+		}
+		if node.Properties == nil {
+			node.Properties = make(map[string]interface{})
+		}
+		node.Properties["TF_ADDRESS"] = address
+		linked++
+	}
+	return linked
+}
+
+// lookupAddress matches a graph node ID by exact ID/ARN or by the ARN's trailing resource ID.
+func lookupAddress(mapping map[string]string, id string) (string, bool) {
+	if address, ok := mapping[id]; ok {
+		return address, true
+	}
+	parts := strings.Split(id, "/")
+	if len(parts) > 1 {
+		address, ok := mapping[parts[len(parts)-1]]
+		return address, ok
+	}
+	return "", false
+}
+
+// IsManaged reports whether a graph node ID appears in the managed set from
+// GetManagedResourceIDs, by exact ID/ARN or by the ARN's trailing resource ID.
+func IsManaged(managedIDs map[string]bool, id string) bool {
+	if managedIDs[id] {
+		return true
+	}
+	parts := strings.Split(id, "/")
+	if len(parts) > 1 && managedIDs[parts[len(parts)-1]] {
+		return true
+	}
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -23,7 +24,13 @@ func NewGenerator(g *graph.Graph, s *State) *Generator {
 
 var safeIDRegex = regexp.MustCompile("^[a-zA-Z0-9._/-]+$")
 
-// GenerateWasteTF creates Terraform resource blocks for waste.
+// maxTagValueLen is AWS's limit on a tag value, in characters.
+const maxTagValueLen = 256
+
+// GenerateWasteTF creates Terraform resource blocks for waste. Each block is
+// preceded by a comment recording why it was flagged, and tags the resource
+// cloudslash:flagged and cloudslash:reason alongside its existing tags, so
+// the file documents itself in review.
 func (g *Generator) GenerateWasteTF(path string) error {
 	f, err := os.Create(path)
 	if err != nil {
@@ -31,10 +38,14 @@ func (g *Generator) GenerateWasteTF(path string) error {
 	}
 	defer f.Close()
 
+	detected := time.Now().UTC().Format(time.RFC3339)
+
 	g.Graph.Mu.RLock()
 	defer g.Graph.Mu.RUnlock()
 
-	for _, node := range g.Graph.GetNodes() {
+	nodes := g.Graph.Store.GetAllNodes()
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].IDStr() < nodes[j].IDStr() })
+	for _, node := range nodes {
 		if !node.IsWaste {
 			continue
 		}
@@ -46,21 +57,92 @@ func (g *Generator) GenerateWasteTF(path string) error {
 
 		// Generate sanitized resource name.
 		tfName := sanitizeName(node.IDStr())
+		reason, _ := node.Properties["Reason"].(string)
+
+		// Write finding metadata.
+		fmt.Fprintf(f, "# Flagged by CloudSlash\n")
+		for i, line := range strings.Split(reason, "\n") {
+			if i == 0 {
+				fmt.Fprintf(f, "#   Reason:       %s\n", line)
+			} else {
+				fmt.Fprintf(f, "#                 %s\n", line)
+			}
+		}
+		fmt.Fprintf(f, "#   Risk score:   %d\n", node.RiskScore)
+		fmt.Fprintf(f, "#   Monthly cost: $%.2f\n", node.Cost)
+		fmt.Fprintf(f, "#   Detected:     %s\n", detected)
 
 		fmt.Fprintf(f, "resource \"%s\" \"%s\" {\n", tfType, tfName)
-		// Write import metadata.
-		fmt.Fprintf(f, "  # Imported by CloudSlash (Risk Score: %d)\n", node.RiskScore)
 		if isGP2, _ := node.Properties["IsGP2"].(bool); isGP2 {
 			fmt.Fprintf(f, "  type = \"gp3\"\n")
 		}
-		if reason, ok := node.Properties["Reason"].(string); ok {
-			fmt.Fprintf(f, "  # Reason: %s\n", reason)
+		if downshift, _ := node.Properties["GP3Downshift"].(bool); downshift {
+			fmt.Fprintf(f, "  iops       = %v\n", node.Properties["RecommendedIops"])
+			fmt.Fprintf(f, "  throughput = %v\n", node.Properties["RecommendedThroughput"])
+		}
+		if taggableTFTypes[tfType] {
+			writeTags(f, wasteTags(node, reason))
 		}
 		fmt.Fprintf(f, "}\n\n")
 	}
 	return nil
 }
 
+// taggableTFTypes are the generated resource types that take a tags argument.
+var taggableTFTypes = map[string]bool{
+	"aws_instance":    true,
+	"aws_ebs_volume":  true,
+	"aws_nat_gateway": true,
+	"aws_eip":         true,
+	"aws_s3_bucket":   true,
+}
+
+// wasteTags returns the resource's existing tags plus the CloudSlash markers.
+// Existing tags are kept so applying the file does not strip them.
+func wasteTags(node *graph.Node, reason string) map[string]string {
+	tags := make(map[string]string)
+	if existing, ok := node.Properties["Tags"].(map[string]string); ok {
+		for k, v := range existing {
+			tags[k] = v
+		}
+	}
+	if reason == "" {
+		reason = "Waste identified"
+	}
+	reason = strings.Join(strings.Fields(reason), " ")
+	if r := []rune(reason); len(r) > maxTagValueLen {
+		reason = string(r[:maxTagValueLen-3]) + "..."
+	}
+	tags["cloudslash:flagged"] = "true"
+	tags["cloudslash:reason"] = reason
+	return tags
+}
+
+// writeTags writes a tags argument, keys sorted and aligned as terraform fmt would.
+func writeTags(f *os.File, tags map[string]string) {
+	keys := make([]string, 0, len(tags))
+	width := 0
+	for k := range tags {
+		keys = append(keys, k)
+		if w := len(hclString(k)); w > width {
+			width = w
+		}
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(f, "  tags = {\n")
+	for _, k := range keys {
+		fmt.Fprintf(f, "    %-*s = %s\n", width, hclString(k), hclString(tags[k]))
+	}
+	fmt.Fprintf(f, "  }\n")
+}
+
+// hclString quotes s as an HCL string literal, escaping template sequences.
+func hclString(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`, "${", "$${", "%{", "%%{")
+	return `"` + r.Replace(s) + `"`
+}
+
 // GenerateImportScript creates the import script.
 func (g *Generator) GenerateImportScript(path string) error {
 	f, err := os.Create(path)
@@ -99,7 +181,11 @@ func (g *Generator) GenerateImportScript(path string) error {
 	return nil
 }
 
-// GenerateDestroyPlan creates destruction report.
+// GenerateDestroyPlan writes a bash script that deletes waste.
+// Terraform-managed resources are destroyed with terraform destroy -target, keyed
+// by the TF_ADDRESS the drift detector recorded (or the state mapping); unmanaged
+// resources fall back to the AWS CLI. Types without a CLI fallback are listed for
+// manual removal, and findings that need review are written commented out.
 func (g *Generator) GenerateDestroyPlan(path string) error {
 	f, err := os.Create(path)
 	if err != nil {
@@ -107,41 +193,162 @@ func (g *Generator) GenerateDestroyPlan(path string) error {
 	}
 	defer f.Close()
 
-	fmt.Fprintf(f, "CloudSlash Destruction Plan\n")
-	fmt.Fprintf(f, "===========================\n\n")
+	var stateMap map[string]string
+	if g.State != nil {
+		stateMap = g.State.GetResourceMapping()
+	}
 
 	g.Graph.Mu.RLock()
-	defer g.Graph.Mu.RUnlock()
-
-	totalWaste := 0
-	for _, node := range g.Graph.GetNodes() {
+	var targets []string
+	var unmanaged, review []*graph.Node
+	nodes := g.Graph.Store.GetAllNodes()
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].IDStr() < nodes[j].IDStr() })
+	for _, node := range nodes {
 		if !node.IsWaste {
 			continue
 		}
-		totalWaste++
-
-		fmt.Fprintf(f, "[%d] %s (%s)\n", node.RiskScore, node.IDStr(), node.TypeStr())
-		if reason, ok := node.Properties["Reason"].(string); ok {
-			fmt.Fprintf(f, "    Reason: %s\n", reason)
+		// Skip logical upgrades.
+		if isInPlaceModify(node) {
+			continue
+		}
+		if needsReview(node) {
+			review = append(review, node)
+			continue
+		}
+		if addr := nodeAddress(node, stateMap); addr != "" {
+			targets = append(targets, addr)
+			continue
 		}
+		unmanaged = append(unmanaged, node)
+	}
 
-		// Handle S3 Multipart Uploads.
-		if node.TypeStr() == "AWS::S3::MultipartUpload" {
-			bucket, _ := node.Properties["Bucket"].(string)
-			key, _ := node.Properties["Key"].(string)
-			uploadId, _ := node.Properties["UploadId"].(string)
-			// Safety check
-			if safeIDRegex.MatchString(bucket) && safeIDRegex.MatchString(key) && safeIDRegex.MatchString(uploadId) {
-				fmt.Fprintf(f, "    Command: aws s3api abort-multipart-upload --bucket %s --key \"%s\" --upload-id %s\n", bucket, key, uploadId)
-			}
+	fmt.Fprintf(f, "#!/bin/bash\n")
+	fmt.Fprintf(f, "set -e\n")
+	fmt.Fprintf(f, "set -o pipefail\n\n")
+	fmt.Fprintf(f, "# CLOUDSLASH DESTROY PLAN (%s)\n", version.Current)
+	fmt.Fprintf(f, "# Risk Level: DESTRUCTIVE (Deletes resources)\n")
+	fmt.Fprintf(f, "# Terraform-managed: %d, unmanaged: %d, needs review: %d\n\n", len(targets), len(unmanaged), len(review))
+
+	fmt.Fprintf(f, "# --- TERRAFORM-MANAGED (run from the Terraform root module) ---\n")
+	if len(targets) == 0 {
+		fmt.Fprintf(f, "# None.\n")
+	} else {
+		fmt.Fprintf(f, "terraform destroy")
+		for _, addr := range targets {
+			fmt.Fprintf(f, " \\\n  -target='%s'", addr)
 		}
 		fmt.Fprintf(f, "\n")
 	}
 
-	fmt.Fprintf(f, "Total Waste Items: %d\n", totalWaste)
+	fmt.Fprintf(f, "\n# --- UNMANAGED (AWS CLI) ---\n")
+	for _, node := range unmanaged {
+		fmt.Fprintf(f, "# [%d] %s (%s)\n", node.RiskScore, node.IDStr(), node.TypeStr())
+		if cmd := awsDeleteCommand(node); cmd != "" {
+			fmt.Fprintf(f, "%s\n", cmd)
+		} else {
+			fmt.Fprintf(f, "# No CLI fallback for this type; remove it manually.\n")
+		}
+	}
+
+	fmt.Fprintf(f, "\n# --- NEEDS REVIEW (not executed) ---\n")
+	for _, node := range review {
+		fmt.Fprintf(f, "# [%d] %s (%s)\n", node.RiskScore, node.IDStr(), node.TypeStr())
+		if reason, ok := node.Properties["Reason"].(string); ok {
+			fmt.Fprintf(f, "#   Reason: %s\n", strings.ReplaceAll(reason, "\n", " "))
+		}
+		if addr := nodeAddress(node, stateMap); addr != "" {
+			fmt.Fprintf(f, "# terraform destroy -target='%s'\n", addr)
+		} else if cmd := awsDeleteCommand(node); cmd != "" {
+			fmt.Fprintf(f, "# %s\n", cmd)
+		}
+	}
+	g.Graph.Mu.RUnlock()
 	return nil
 }
 
+// isInPlaceModify reports whether a finding is fixed by changing the resource
+// (gp2 to gp3, a gp3 performance downshift) rather than destroying it.
+func isInPlaceModify(node *graph.Node) bool {
+	isGP2, _ := node.Properties["IsGP2"].(bool)
+	downshift, _ := node.Properties["GP3Downshift"].(bool)
+	return isGP2 || downshift
+}
+
+// needsReview reports whether a finding must not be deleted unattended: a low
+// risk score (e.g. an EIP still in DNS), a policy block, a production
+// environment, or a CloudFormation stack that would recreate it.
+func needsReview(node *graph.Node) bool {
+	if node.RiskScore <= 50 {
+		return true
+	}
+	if _, blocked := node.Properties["RemediationBlocked"].(string); blocked {
+		return true
+	}
+	if caution, _ := node.Properties["RemediationCaution"].(string); caution == "manual-review" {
+		return true
+	}
+	// Redshift findings are a pause or a resize; destroy is neither.
+	if _, ok := node.Properties["RedshiftAction"].(string); ok {
+		return true
+	}
+	_, inStack := node.Properties["CFNStack"].(string)
+	return inStack
+}
+
+// nodeAddress returns the node's Terraform address, or "" when it is not
+// managed or the address is not safe to quote.
+func nodeAddress(node *graph.Node, stateMap map[string]string) string {
+	addr, _ := node.Properties["TF_ADDRESS"].(string)
+	if addr == "" {
+		addr = stateMap[node.IDStr()]
+	}
+	if addr == "" {
+		addr = stateMap[extractResourceID(node.IDStr(), node.TypeStr())]
+	}
+	if strings.ContainsAny(addr, "'\n") {
+		return ""
+	}
+	return addr
+}
+
+// awsDeleteCommand returns the AWS CLI command deleting node, or "".
+func awsDeleteCommand(node *graph.Node) string {
+	if node.TypeStr() == "AWS::S3::MultipartUpload" {
+		bucket, _ := node.Properties["Bucket"].(string)
+		key, _ := node.Properties["Key"].(string)
+		uploadId, _ := node.Properties["UploadId"].(string)
+		// Safety check
+		if safeIDRegex.MatchString(bucket) && safeIDRegex.MatchString(key) && safeIDRegex.MatchString(uploadId) {
+			return fmt.Sprintf("aws s3api abort-multipart-upload --bucket %s --key \"%s\" --upload-id %s", bucket, key, uploadId)
+		}
+		return ""
+	}
+
+	id := extractResourceID(node.IDStr(), node.TypeStr())
+	if id == "UNSAFE_ID_DETECTED" {
+		return ""
+	}
+	var cmd string
+	switch node.TypeStr() {
+	case "AWS::EC2::Instance":
+		cmd = "aws ec2 terminate-instances --instance-ids " + id
+	case "AWS::EC2::Volume":
+		cmd = "aws ec2 delete-volume --volume-id " + id
+	case "AWS::EC2::Snapshot":
+		cmd = "aws ec2 delete-snapshot --snapshot-id " + id
+	case "AWS::EC2::NatGateway":
+		cmd = "aws ec2 delete-nat-gateway --nat-gateway-id " + id
+	case "AWS::EC2::EIP", "aws_eip":
+		cmd = "aws ec2 release-address --allocation-id " + id
+	default:
+		return ""
+	}
+	if region, _ := node.Properties["Region"].(string); safeIDRegex.MatchString(region) {
+		cmd += " --region " + region
+	}
+	return cmd
+}
+
 // GenerateFixScript creates state removal script.
 func (g *Generator) GenerateFixScript(path string) error {
 	f, err := os.Create(path)
@@ -212,7 +419,7 @@ echo -e "${CYAN}[3/3] Removing Unused Resources...${NC}"
 		}
 
 		// Skip logical upgrades.
-		if isGP2, _ := node.Properties["IsGP2"].(bool); isGP2 {
+		if isInPlaceModify(node) {
 			continue
 		}
 
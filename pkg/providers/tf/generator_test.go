@@ -1,7 +1,138 @@
 package tf
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/DrSkyle/cloudslash/v2/pkg/graph"
+)
 
 func TestPlaceholder(t *testing.T) {
 	// Replaced legacy deletion script tests.
 }
+
+func TestGenerateDestroyPlan(t *testing.T) {
+	g := graph.NewGraph()
+	// Managed via a TF_ADDRESS recorded by the drift detector.
+	g.AddNode("arn:aws:ec2:us-east-1:123:volume/vol-managed", "AWS::EC2::Volume", map[string]interface{}{
+		"TF_ADDRESS": `module.data.aws_ebs_volume.scratch["a"]`,
+	})
+	// Managed via the state mapping only.
+	g.AddNode("arn:aws:ec2:us-east-1:123:instance/i-state", "AWS::EC2::Instance", map[string]interface{}{})
+	g.AddNode("arn:aws:ec2:us-east-1:123:volume/vol-unmanaged", "AWS::EC2::Volume", map[string]interface{}{"Region": "eu-west-1"})
+	g.AddNode("arn:aws:rds:us-east-1:123:db:orphan", "AWS::RDS::DBInstance", map[string]interface{}{})
+	// Still referenced by DNS: low risk score, never deleted unattended.
+	g.AddNode("arn:aws:ec2:us-east-1:123:eip/eipalloc-dns", "AWS::EC2::EIP", map[string]interface{}{"Reason": "DANGEROUS: in DNS"})
+	// Idle Redshift is paused, not destroyed.
+	g.AddNode("arn:aws:redshift:us-east-1:123:cluster:idle", "aws_redshift_cluster", map[string]interface{}{
+		"TF_ADDRESS": "aws_redshift_cluster.idle", "RedshiftAction": "pause",
+	})
+	g.CloseAndWait()
+	for _, n := range g.GetNodes() {
+		n.IsWaste = true
+		n.RiskScore = 90
+	}
+	g.GetNode("arn:aws:ec2:us-east-1:123:eip/eipalloc-dns").RiskScore = 10
+
+	state := &State{Resources: []Resource{{
+		Mode: "managed", Type: "aws_instance", Name: "web",
+		Instances: []Instance{{IndexKey: float64(1), Attributes: map[string]interface{}{"id": "i-state"}}},
+	}}}
+	path := filepath.Join(t.TempDir(), "destroy_plan.sh")
+	if err := NewGenerator(g, state).GenerateDestroyPlan(path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+
+	for _, want := range []string{
+		"terraform destroy \\\n  -target='aws_instance.web[1]' \\\n  -target='module.data.aws_ebs_volume.scratch[\"a\"]'\n",
+		"aws ec2 delete-volume --volume-id vol-unmanaged --region eu-west-1\n",
+		"# [90] arn:aws:rds:us-east-1:123:db:orphan (AWS::RDS::DBInstance)\n# No CLI fallback",
+		"#   Reason: DANGEROUS: in DNS\n# aws ec2 release-address --allocation-id eipalloc-dns\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected plan to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "  -target='aws_redshift_cluster.idle'") || !strings.Contains(out, "# terraform destroy -target='aws_redshift_cluster.idle'") {
+		t.Errorf("A Redshift pause candidate must not be destroyed:\n%s", out)
+	}
+	if strings.Contains(out, "vol-managed --region") || strings.Contains(out, "terminate-instances") {
+		t.Errorf("Managed resources must not fall back to the AWS CLI:\n%s", out)
+	}
+}
+
+func TestResourceMappingUsesInstanceAddresses(t *testing.T) {
+	state := &State{Resources: []Resource{
+		{Mode: "managed", Module: "module.net", Type: "aws_eip", Name: "nat", Instances: []Instance{
+			{IndexKey: "a", Attributes: map[string]interface{}{"id": "eipalloc-a"}},
+		}},
+		{Mode: "data", Type: "aws_vpc", Name: "main", Instances: []Instance{
+			{Attributes: map[string]interface{}{"id": "vpc-1"}},
+		}},
+	}}
+	mapping := state.GetResourceMapping()
+	if got := mapping["eipalloc-a"]; got != `module.net.aws_eip.nat["a"]` {
+		t.Errorf("Unexpected address %q", got)
+	}
+	if _, ok := mapping["vpc-1"]; ok {
+		t.Error("Expected data sources to be skipped")
+	}
+
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:ec2:us-east-1:123:eip/eipalloc-a", "AWS::EC2::EIP", nil)
+	g.CloseAndWait()
+	if n := NewDriftDetector(g, state).LinkAddresses(); n != 1 {
+		t.Fatalf("Expected 1 linked node, got %d", n)
+	}
+	if addr := g.GetNode("arn:aws:ec2:us-east-1:123:eip/eipalloc-a").Properties["TF_ADDRESS"]; addr != `module.net.aws_eip.nat["a"]` {
+		t.Errorf("Unexpected TF_ADDRESS %v", addr)
+	}
+}
+
+func TestGenerateWasteTF(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode("arn:aws:ec2:us-east-1:123:volume/vol-zombie", "AWS::EC2::Volume", map[string]interface{}{
+		"Reason": "Unattached for 30 days\nno ${owner} tag",
+		"Tags":   map[string]string{"Name": "scratch", "team": "data"},
+	})
+	g.AddNode("arn:aws:rds:us-east-1:123:db:orphan", "AWS::RDS::DBInstance", map[string]interface{}{})
+	g.CloseAndWait()
+	for _, n := range g.GetNodes() {
+		n.IsWaste = true
+		n.RiskScore = 90
+		n.Cost = 12.5
+	}
+
+	path := filepath.Join(t.TempDir(), "waste.tf")
+	if err := NewGenerator(g, nil).GenerateWasteTF(path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+
+	for _, want := range []string{
+		"#   Reason:       Unattached for 30 days\n#                 no ${owner} tag\n#   Risk score:   90\n#   Monthly cost: $12.50\n#   Detected:     ",
+		"resource \"aws_ebs_volume\" \"vol_zombie\" {\n  tags = {\n" +
+			"    \"Name\"               = \"scratch\"\n" +
+			"    \"cloudslash:flagged\" = \"true\"\n" +
+			"    \"cloudslash:reason\"  = \"Unattached for 30 days no $${owner} tag\"\n" +
+			"    \"team\"               = \"data\"\n  }\n}\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected waste.tf to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "orphan") {
+		t.Errorf("Unsupported types must be skipped:\n%s", out)
+	}
+}
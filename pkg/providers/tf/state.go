@@ -26,6 +26,7 @@ type State struct {
 // Resource represents a state resource.
 type Resource struct {
 	Mode      string     `json:"mode"`
+	Module    string     `json:"module,omitempty"` // e.g. "module.vpc"; empty in the root module
 	Type      string     `json:"type"`
 	Name      string     `json:"name"`
 	Provider  string     `json:"provider"`
@@ -34,6 +35,7 @@ type Resource struct {
 
 // Instance represents a resource instance.
 type Instance struct {
+	IndexKey   interface{}            `json:"index_key,omitempty"` // count index (number) or for_each key (string)
 	Attributes map[string]interface{} `json:"attributes"`
 }
 
@@ -217,15 +219,18 @@ func (s *State) GetManagedResourceIDs() map[string]bool {
 	return managed
 }
 
-// GetResourceMapping maps IDs to addresses.
+// GetResourceMapping maps the IDs and ARNs of managed resources to their full
+// instance address (module path and count/for_each key included), the form
+// accepted by -target and terraform state rm. Data sources are skipped.
 func (s *State) GetResourceMapping() map[string]string {
 	mapping := make(map[string]string)
 
 	for _, res := range s.Resources {
-		// Build address.
-		address := fmt.Sprintf("%s.%s", res.Type, res.Name)
-
+		if res.Mode == "data" {
+			continue
+		}
 		for _, inst := range res.Instances {
+			address := res.Address(inst)
 			if id, ok := inst.Attributes["id"].(string); ok {
 				mapping[id] = address
 			}
@@ -236,3 +241,18 @@ func (s *State) GetResourceMapping() map[string]string {
 	}
 	return mapping
 }
+
+// Address returns the instance address, e.g. module.vpc.aws_eip.nat["a"].
+func (r Resource) Address(inst Instance) string {
+	address := fmt.Sprintf("%s.%s", r.Type, r.Name)
+	if r.Module != "" {
+		address = r.Module + "." + address
+	}
+	switch key := inst.IndexKey.(type) {
+	case float64:
+		address += fmt.Sprintf("[%d]", int(key))
+	case string:
+		address += fmt.Sprintf("[%q]", key)
+	}
+	return address
+}
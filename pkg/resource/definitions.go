@@ -33,4 +33,6 @@ type EC2Instance struct {
 	VpcID        string
 	SubnetID     string
 	ImageID      string // AMI
+
+	PlatformDetails string // e.g. "Linux/UNIX", "Windows"
 }
@@ -47,6 +47,7 @@ type Model struct {
 	wasteItems    []*graph.Node
 	topologyLines []TopologyLine // Flattened hierarchy for rendering.
 	totalSavings  float64
+	newWaste      int // Items flagged since the previous scan (--diff).
 	riskScore     int
 	tasksDone     int
 	tfRepairReady bool
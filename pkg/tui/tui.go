@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/DrSkyle/cloudslash/v2/pkg/engine/audit"
+	"github.com/DrSkyle/cloudslash/v2/pkg/engine/report"
 
 	"gopkg.in/yaml.v2"
 
@@ -270,9 +271,16 @@ func (m Model) View() string {
 
 // Helpers
 
+// isNewWaste reports whether --diff found n flagged for the first time.
+func isNewWaste(n *graph.Node) bool {
+	d, _ := n.Properties["WasteDiff"].(string)
+	return d == "new"
+}
+
 func (m *Model) refreshData() {
 	var total float64
 	var nodes []*graph.Node
+	newWaste := 0
 
 	m.Graph.Mu.RLock()
 	defer m.Graph.Mu.RUnlock()
@@ -302,6 +310,9 @@ func (m *Model) refreshData() {
 
 			total += n.Cost
 			nodes = append(nodes, n)
+			if isNewWaste(n) {
+				newWaste++
+			}
 		}
 	}
 
@@ -317,6 +328,7 @@ func (m *Model) refreshData() {
 	}
 
 	m.totalSavings = total
+	m.newWaste = newWaste
 	m.wasteItems = nodes
 
 	// Ensure topology view is synchronized with the latest graph state.
@@ -361,18 +373,8 @@ func (m Model) ignoreNode(id string) {
 }
 
 func getConsoleURL(node *graph.Node) string {
-	region := fmt.Sprintf("%v", node.Properties["Region"])
-	if region == "" || region == "<nil>" {
-		region = "us-east-1"
-	}
-
-	switch node.TypeStr() {
-	case "AWS::EC2::Instance":
-		return fmt.Sprintf("https://%s.console.aws.amazon.com/ec2/home?region=%s#InstanceDetails:instanceId=%s", region, region, node.IDStr())
-	case "AWS::S3::Bucket":
-		return fmt.Sprintf("https://s3.console.aws.amazon.com/s3/buckets/%s?region=%s", node.IDStr(), region)
-	}
-	return "https://console.aws.amazon.com"
+	region, _ := node.Properties["Region"].(string)
+	return report.ConsoleURL(node.TypeStr(), node.IDStr(), region)
 }
 
 func copyToClipboard(text string) error {
@@ -62,6 +62,22 @@ func (m Model) viewDetails() string {
 		lipgloss.NewStyle().Foreground(lipgloss.Color("#F05D5E")).Render("BLAME:         "+fmt.Sprintf("%v", node.Properties["Owner"])),
 	)
 
+	// Findings, one line per heuristic.
+	var findings []string
+	for _, f := range node.FindingList() {
+		line := "• " + f.Reason
+		if f.Heuristic != "" {
+			line = fmt.Sprintf("• [%s] %s", f.Heuristic, f.Reason)
+		}
+		if f.Action != "" {
+			line += " → " + f.Action
+		}
+		if f.Savings > 0 {
+			line += fmt.Sprintf(" ($%.2f/mo)", f.Savings)
+		}
+		findings = append(findings, line)
+	}
+
 	// IAC Provenance.
 	source := "Source: Unknown (Not managed by Terraform)"
 	if node.SourceLocation != "" {
@@ -82,6 +98,9 @@ func (m Model) viewDetails() string {
 		"",
 		intelBlock,
 		"",
+		highlight.Render("FINDINGS:"),
+		strings.Join(findings, "\n"),
+		"",
 		dimStyle.Render(strings.Join(props, "\n")),
 		"",
 		subtle.Render(source),
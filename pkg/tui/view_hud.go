@@ -59,6 +59,9 @@ func (m Model) viewHUD() string {
 	} else {
 		segStatus = statusColor.Render(fmt.Sprintf("[ STATUS: %-10s ]", status))
 	}
+	if m.newWaste > 0 {
+		savings += fmt.Sprintf(" [%d NEW]", m.newWaste)
+	}
 	segWaste := hudLabelStyle.Render("WASTE:") + hudValueStyle.Render(savings)
 	segRisk := hudLabelStyle.Render("RISK:") + riskColor.Render(riskLevel)
 
@@ -74,6 +74,12 @@ func (m Model) viewList() string {
 
 		// Reason (cut off rest)
 		reason := fmt.Sprintf("%v", node.Properties["Reason"])
+		if findings := node.FindingList(); len(findings) > 1 {
+			reason = fmt.Sprintf("(%d) %s", len(findings), findings[0].Reason)
+		}
+		if isNewWaste(node) {
+			reason = "[NEW] " + reason
+		}
 		if len(reason) > 40 {
 			reason = reason[:37] + "..."
 		}
@@ -82,7 +88,10 @@ func (m Model) viewList() string {
 		var line string
 		baseLine := fmt.Sprintf("%-20s | %-15s | %-10s | %s", dispID, dispType, dispCost, reason)
 
-		if node.RiskScore > 80 {
+		if isNewWaste(node) {
+			// Flagged since the previous scan.
+			baseLine = lipgloss.NewStyle().Foreground(lipgloss.Color("#00E5FF")).Render(baseLine)
+		} else if node.RiskScore > 80 {
 			// Critical Risk
 			baseLine = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0055")).Render(baseLine)
 		} else if node.Cost > 50 {